@@ -7,13 +7,18 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmizerany/pat"
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/api/robeaux"
 )
 
+// DefaultTelemetryInterval is the default interval between two telemetry events
+const DefaultTelemetryInterval = time.Second
+
 // API represents an API server
 type API struct {
 	master   *gobot.Master
@@ -27,8 +32,11 @@ type API struct {
 }
 
 // NewAPI returns a new api instance
-func NewAPI(m *gobot.Master) *API {
-	return &API{
+//
+// Optional params:
+//	api.WithDiagnostics(): adds the routes to diagnose the performance
+func NewAPI(m *gobot.Master, options ...func(*API)) *API {
+	a := &API{
 		master: m,
 		router: pat.New(),
 		Port:   "3000",
@@ -47,6 +55,10 @@ func NewAPI(m *gobot.Master) *API {
 			}()
 		},
 	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
 }
 
 // ServeHTTP calls api handlers and then serves request using api router
@@ -131,9 +143,11 @@ func (a *API) AddC3PIORoutes() {
 	a.Get("/api/robots/:robot/commands", a.robotCommands)
 	a.Get(robotCommandRoute, a.executeRobotCommand)
 	a.Post(robotCommandRoute, a.executeRobotCommand)
+	a.Get("/api/robots/:robot/telemetry", a.robotTelemetry)
 	a.Get("/api/robots/:robot/devices", a.robotDevices)
 	a.Get("/api/robots/:robot/devices/:device", a.robotDevice)
 	a.Get("/api/robots/:robot/devices/:device/events/:event", a.robotDeviceEvent)
+	a.Get("/api/robots/:robot/devices/:device/telemetry", a.robotDeviceTelemetry)
 	a.Get("/api/robots/:robot/devices/:device/commands", a.robotDeviceCommands)
 	a.Get(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
 	a.Post(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
@@ -224,6 +238,16 @@ func (a *API) robotCommands(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// robotTelemetry returns telemetry route handler.
+// Writes JSON with the telemetry snapshots of the robot devices
+func (a *API) robotTelemetry(res http.ResponseWriter, req *http.Request) {
+	if robot := a.master.Robot(req.URL.Query().Get(":robot")); robot != nil {
+		a.writeJSON(map[string]interface{}{"telemetry": robot.Telemetry()}, res)
+	} else {
+		a.writeJSON(map[string]interface{}{"error": "No Robot found with the name " + req.URL.Query().Get(":robot")}, res)
+	}
+}
+
 // robotDevices returns devices route handler.
 // Writes JSON with robot devices representation
 func (a *API) robotDevices(res http.ResponseWriter, req *http.Request) {
@@ -287,6 +311,57 @@ func (a *API) robotDeviceEvent(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// robotDeviceTelemetry returns device telemetry route handler.
+// Writes the telemetry snapshot of the device as server-sent event
+// periodically. The interval defaults to DefaultTelemetryInterval and can be
+// changed by the "interval" query parameter in milliseconds.
+func (a *API) robotDeviceTelemetry(res http.ResponseWriter, req *http.Request) {
+	device := a.master.Robot(req.URL.Query().Get(":robot")).
+		Device(req.URL.Query().Get(":device"))
+	if device == nil {
+		a.writeJSON(map[string]interface{}{
+			"error": "No Device found with the name " + req.URL.Query().Get(":device"),
+		}, res)
+		return
+	}
+
+	telemeter, ok := device.(gobot.Telemeter)
+	if !ok {
+		a.writeJSON(map[string]interface{}{
+			"error": "No Telemetry found for the device " + device.Name(),
+		}, res)
+		return
+	}
+
+	interval := DefaultTelemetryInterval
+	if ms, err := strconv.Atoi(req.URL.Query().Get("interval")); err == nil && ms > 0 {
+		interval = time.Duration(ms) * time.Millisecond
+	}
+
+	f, _ := res.(http.Flusher)
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d, _ := json.Marshal(telemeter.Telemetry())
+		fmt.Fprintf(res, "data: %v\n\n", string(d))
+		if f != nil {
+			f.Flush()
+		}
+
+		select {
+		case <-ticker.C:
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
 // robotDeviceCommands returns device commands route handler
 // writes JSON with robot device commands representation
 func (a *API) robotDeviceCommands(res http.ResponseWriter, req *http.Request) {
@@ -325,6 +400,7 @@ func (a *API) robotConnection(res http.ResponseWriter, req *http.Request) {
 // executeMcpCommand calls a global command associated to requested route
 func (a *API) executeMcpCommand(res http.ResponseWriter, req *http.Request) {
 	a.executeCommand(a.master.Command(req.URL.Query().Get(":command")),
+		nil,
 		res,
 		req,
 	)
@@ -336,10 +412,15 @@ func (a *API) executeRobotDeviceCommand(res http.ResponseWriter, req *http.Reque
 		req.URL.Query().Get(":device")); err != nil {
 		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
 	} else {
+		device := a.master.Robot(req.URL.Query().Get(":robot")).
+			Device(req.URL.Query().Get(":device"))
+		var schema gobot.CommandSchema
+		if schemer, ok := device.(gobot.CommandSchemer); ok {
+			schema = schemer.CommandSchema(req.URL.Query().Get(":command"))
+		}
 		a.executeCommand(
-			a.master.Robot(req.URL.Query().Get(":robot")).
-				Device(req.URL.Query().Get(":device")).(gobot.Commander).
-				Command(req.URL.Query().Get(":command")),
+			device.(gobot.Commander).Command(req.URL.Query().Get(":command")),
+			schema,
 			res,
 			req,
 		)
@@ -354,6 +435,7 @@ func (a *API) executeRobotCommand(res http.ResponseWriter, req *http.Request) {
 		a.executeCommand(
 			a.master.Robot(req.URL.Query().Get(":robot")).
 				Command(req.URL.Query().Get(":command")),
+			nil,
 			res,
 			req,
 		)
@@ -361,7 +443,11 @@ func (a *API) executeRobotCommand(res http.ResponseWriter, req *http.Request) {
 }
 
 // executeCommand writes JSON response with `f` returned value.
+// If a schema is given, which is only the case for a device implementing
+// gobot.CommandSchemer, the request parameters are validated before calling `f`
+// and a 400 response with the field errors is written if they do not match.
 func (a *API) executeCommand(f func(map[string]interface{}) interface{},
+	schema gobot.CommandSchema,
 	res http.ResponseWriter,
 	req *http.Request,
 ) {
@@ -369,17 +455,32 @@ func (a *API) executeCommand(f func(map[string]interface{}) interface{},
 	body := make(map[string]interface{})
 	json.NewDecoder(req.Body).Decode(&body)
 
-	if f != nil {
-		a.writeJSON(map[string]interface{}{"result": f(body)}, res)
-	} else {
+	if f == nil {
 		a.writeJSON(map[string]interface{}{"error": "Unknown Command"}, res)
+		return
 	}
+
+	if errs := schema.Validate(body); len(errs) > 0 {
+		a.writeJSONStatus(map[string]interface{}{
+			"error":  "Invalid Parameters",
+			"fields": errs,
+		}, http.StatusBadRequest, res)
+		return
+	}
+
+	a.writeJSON(map[string]interface{}{"result": f(body)}, res)
 }
 
 // writeJSON writes `j` as JSON in response
 func (a *API) writeJSON(j interface{}, res http.ResponseWriter) {
+	a.writeJSONStatus(j, http.StatusOK, res)
+}
+
+// writeJSONStatus writes `j` as JSON in response with the given status code
+func (a *API) writeJSONStatus(j interface{}, status int, res http.ResponseWriter) {
 	data, _ := json.Marshal(j)
 	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(status)
 	res.Write(data)
 }
 
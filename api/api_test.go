@@ -15,10 +15,10 @@ import (
 	"gobot.io/x/gobot/gobottest"
 )
 
-func initTestAPI() *API {
+func initTestAPI(options ...func(*API)) *API {
 	log.SetOutput(NullReadWriteCloser{})
 	g := gobot.NewMaster()
-	a := NewAPI(g)
+	a := NewAPI(g, options...)
 	a.start = func(m *API) {}
 	a.Start()
 	a.Debug()
@@ -187,6 +187,30 @@ func TestRobotDevices(t *testing.T) {
 	gobottest.Assert(t, body["error"], "No Robot found with the name UnknownRobot1")
 }
 
+func TestRobotTelemetry(t *testing.T) {
+	a := initTestAPI()
+
+	// known robot
+	request, _ := http.NewRequest("GET", "/api/robots/Robot1/telemetry", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	var body map[string]interface{}
+	json.NewDecoder(response.Body).Decode(&body)
+	telemetry := body["telemetry"].(map[string]interface{})
+	gobottest.Assert(t, len(telemetry), 3)
+	gobottest.Assert(t, telemetry["Device2"], map[string]interface{}{"pin": "2"})
+
+	// unknown robot
+	request, _ = http.NewRequest("GET", "/api/robots/UnknownRobot1/telemetry", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	body = nil
+	json.NewDecoder(response.Body).Decode(&body)
+	gobottest.Assert(t, body["error"], "No Robot found with the name UnknownRobot1")
+}
+
 func TestRobotCommands(t *testing.T) {
 	a := initTestAPI()
 
@@ -336,6 +360,38 @@ func TestExecuteRobotDeviceCommand(t *testing.T) {
 
 }
 
+func TestExecuteRobotDeviceCommandInvalidParams(t *testing.T) {
+	var body map[string]interface{}
+	a := initTestAPI()
+
+	// wrong type
+	request, _ := http.NewRequest("GET",
+		"/api/robots/Robot1/devices/Device1/commands/TestDriverCommand",
+		bytes.NewBufferString(`{"name":42}`),
+	)
+	request.Header.Add("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	gobottest.Assert(t, response.Code, http.StatusBadRequest)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobottest.Assert(t, body["error"], "Invalid Parameters")
+	gobottest.Assert(t, body["fields"].(map[string]interface{})["name"], "must be of type string")
+
+	// missing parameter
+	request, _ = http.NewRequest("GET",
+		"/api/robots/Robot1/devices/Device1/commands/TestDriverCommand",
+		bytes.NewBufferString(`{}`),
+	)
+	request.Header.Add("Content-Type", "application/json")
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	gobottest.Assert(t, response.Code, http.StatusBadRequest)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobottest.Assert(t, body["fields"].(map[string]interface{})["name"], "is required")
+}
+
 func TestRobotConnections(t *testing.T) {
 	a := initTestAPI()
 
@@ -430,6 +486,34 @@ func TestRobotDeviceEvent(t *testing.T) {
 	gobottest.Assert(t, body["error"], "No Event found with the name UnknownEvent")
 }
 
+func TestRobotDeviceTelemetry(t *testing.T) {
+	a := initTestAPI()
+	server := httptest.NewServer(a)
+	defer server.Close()
+
+	// known device
+	resp, err := http.Get(server.URL + "/api/robots/Robot1/devices/Device2/telemetry?interval=10")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	reader := bufio.NewReader(resp.Body)
+	for i := 0; i < 2; i++ {
+		data, _ := reader.ReadString('\n')
+		gobottest.Assert(t, data, "data: {\"pin\":\"2\"}\n")
+		reader.ReadString('\n')
+	}
+	resp.Body.Close()
+
+	server.CloseClientConnections()
+
+	// unknown device
+	response, _ := http.Get(server.URL + "/api/robots/Robot1/devices/UnknownDevice1/telemetry")
+
+	var body map[string]interface{}
+	json.NewDecoder(response.Body).Decode(&body)
+	gobottest.Assert(t, body["error"], "No Device found with the name UnknownDevice1")
+}
+
 func TestAPIRouter(t *testing.T) {
 	a := initTestAPI()
 
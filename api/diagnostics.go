@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"gobot.io/x/gobot"
+)
+
+// WithDiagnostics option adds the routes to diagnose the performance of a
+// deployed robot to the API, they are not added by default:
+//
+//	/debug/pprof/ - the profiles of the net/http/pprof package
+//	/debug/stats - the goroutines, the heap and the event queue depths and
+//	               lock contentions of the devices
+//
+func WithDiagnostics() func(*API) {
+	return func(a *API) {
+		a.addDiagnosticsRoutes()
+	}
+}
+
+func (a *API) addDiagnosticsRoutes() {
+	a.Get("/debug/pprof/cmdline", pprof.Cmdline)
+	a.Get("/debug/pprof/profile", pprof.Profile)
+	a.Get("/debug/pprof/symbol", pprof.Symbol)
+	a.Post("/debug/pprof/symbol", pprof.Symbol)
+	a.Get("/debug/pprof/trace", pprof.Trace)
+	a.Get("/debug/pprof/", pprof.Index)
+	a.Get("/debug/stats", a.stats)
+}
+
+// stats returns the runtime stats route handler.
+// Writes JSON with the runtime stats and the event queue depths and lock
+// contentions of the master, the robots and their devices
+func (a *API) stats(res http.ResponseWriter, req *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	robots := []map[string]interface{}{}
+	a.master.Robots().Each(func(r *gobot.Robot) {
+		devices := []map[string]interface{}{}
+		r.Devices().Each(func(d gobot.Device) {
+			device := map[string]interface{}{"name": d.Name()}
+			if depth, ok := gobot.EventQueueDepth(d); ok {
+				device["event_queue"] = depth
+			}
+			if c, ok := d.(gobot.ContentionCounter); ok {
+				device["contentions"] = c.Contentions()
+			}
+			devices = append(devices, device)
+		})
+		robot := map[string]interface{}{
+			"name":    r.Name,
+			"devices": devices,
+		}
+		if depth, ok := gobot.EventQueueDepth(r); ok {
+			robot["event_queue"] = depth
+		}
+		robots = append(robots, robot)
+	})
+
+	stats := map[string]interface{}{
+		"goroutines": runtime.NumGoroutine(),
+		"heap": map[string]interface{}{
+			"alloc":   mem.HeapAlloc,
+			"sys":     mem.HeapSys,
+			"objects": mem.HeapObjects,
+			"num_gc":  mem.NumGC,
+		},
+		"robots": robots,
+	}
+	if depth, ok := gobot.EventQueueDepth(a.master); ok {
+		stats["event_queue"] = depth
+	}
+	a.writeJSON(stats, res)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestDiagnosticsRoutesNotAdded(t *testing.T) {
+	a := initTestAPI()
+	request, _ := http.NewRequest("GET", "/debug/stats", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobottest.Assert(t, response.Code, 404)
+}
+
+func TestPprof(t *testing.T) {
+	a := initTestAPI(WithDiagnostics())
+
+	request, _ := http.NewRequest("GET", "/debug/pprof/", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobottest.Assert(t, response.Code, 200)
+
+	request, _ = http.NewRequest("GET", "/debug/pprof/goroutine?debug=1", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobottest.Assert(t, response.Code, 200)
+
+	request, _ = http.NewRequest("GET", "/debug/pprof/cmdline", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobottest.Assert(t, response.Code, 200)
+}
+
+func TestStats(t *testing.T) {
+	a := initTestAPI(WithDiagnostics())
+
+	request, _ := http.NewRequest("GET", "/debug/stats", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobottest.Assert(t, response.Code, 200)
+
+	var body map[string]interface{}
+	json.NewDecoder(response.Body).Decode(&body)
+	gobottest.Assert(t, body["goroutines"].(float64) > 0, true)
+	gobottest.Assert(t, body["heap"].(map[string]interface{})["alloc"].(float64) > 0, true)
+	gobottest.Assert(t, body["event_queue"], 0.0)
+
+	robots := body["robots"].([]interface{})
+	gobottest.Assert(t, len(robots), 3)
+	robot := robots[0].(map[string]interface{})
+	gobottest.Assert(t, robot["name"], "Robot1")
+	device := robot["devices"].([]interface{})[0].(map[string]interface{})
+	gobottest.Assert(t, device["name"], "Device1")
+	gobottest.Assert(t, device["event_queue"], 0.0)
+	_, ok := device["contentions"]
+	gobottest.Assert(t, ok, false)
+}
@@ -25,6 +25,7 @@ type testDriver struct {
 	pin        string
 	connection gobot.Connection
 	gobot.Commander
+	gobot.CommandSchemer
 	gobot.Eventer
 }
 
@@ -35,13 +36,18 @@ func (t *testDriver) SetName(n string)             { t.name = n }
 func (t *testDriver) Pin() string                  { return t.pin }
 func (t *testDriver) Connection() gobot.Connection { return t.connection }
 
+func (t *testDriver) Telemetry() map[string]interface{} {
+	return map[string]interface{}{"pin": t.pin}
+}
+
 func newTestDriver(adaptor *testAdaptor, name string, pin string) *testDriver {
 	t := &testDriver{
-		name:       name,
-		connection: adaptor,
-		pin:        pin,
-		Eventer:    gobot.NewEventer(),
-		Commander:  gobot.NewCommander(),
+		name:           name,
+		connection:     adaptor,
+		pin:            pin,
+		Eventer:        gobot.NewEventer(),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
 	}
 
 	t.AddEvent("TestEvent")
@@ -50,6 +56,7 @@ func newTestDriver(adaptor *testAdaptor, name string, pin string) *testDriver {
 		name := params["name"].(string)
 		return fmt.Sprintf("hello %v", name)
 	})
+	t.AddCommandSchema("TestDriverCommand", gobot.CommandSchema{"name": gobot.CommandParamString})
 
 	t.AddCommand("DriverCommand", func(params map[string]interface{}) interface{} {
 		name := params["name"].(string)
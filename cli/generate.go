@@ -288,7 +288,7 @@ func main() {
       fmt.Println(data)
     })
 
-    gobot.Every(1200*time.Millisecond, func() {
+    gobot.EveryTimer(1200*time.Millisecond, func() {
       fmt.Println(dev.Ping())
     })
   }
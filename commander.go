@@ -1,7 +1,33 @@
 package gobot
 
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	// CommandParamString is the schema type for a string command parameter
+	CommandParamString = "string"
+	// CommandParamNumber is the schema type for a numeric command parameter
+	CommandParamNumber = "number"
+	// CommandParamBool is the schema type for a boolean command parameter
+	CommandParamBool = "bool"
+)
+
+// CommandSchema describes the parameters expected by a command, by mapping
+// each parameter name to one of the CommandParam* types.
+type CommandSchema map[string]string
+
 type commander struct {
 	commands map[string]func(map[string]interface{}) interface{}
+	limiter  *CommandLimiter
+	mutex    sync.Mutex
+}
+
+type commandSchemer struct {
+	schemas map[string]CommandSchema
 }
 
 // Commander is the interface which describes the behaviour for a Driver or Adaptor
@@ -15,6 +41,26 @@ type Commander interface {
 	AddCommand(name string, command func(map[string]interface{}) interface{})
 }
 
+// LimitedCommander is the optional interface for a Commander which limits the
+// execution of its commands, like the one returned by NewCommander. Callers
+// type-assert for it, e.g. on the Commander embedded by a driver.
+type LimitedCommander interface {
+	// SetCommandLimiter sets the limiter applied to all commands returned by
+	// Command. A nil limiter removes the limit.
+	SetCommandLimiter(limiter *CommandLimiter)
+}
+
+// CommandSchemer is the optional interface for a Driver or Adaptor which
+// declares the parameters expected by its commands. Callers type-assert for it,
+// e.g. the API validates the parameters of a request before calling a command.
+type CommandSchemer interface {
+	// CommandSchema returns the parameter schema of a command given a name.
+	// Returns nil if no schema was declared for the command.
+	CommandSchema(name string) (schema CommandSchema)
+	// AddCommandSchema declares the parameter schema of a command given a name.
+	AddCommandSchema(name string, schema CommandSchema)
+}
+
 // NewCommander returns a new Commander.
 func NewCommander() Commander {
 	return &commander{
@@ -22,9 +68,23 @@ func NewCommander() Commander {
 	}
 }
 
-// Command returns the command interface whene passed a valid command name
+// NewCommandSchemer returns a new CommandSchemer.
+func NewCommandSchemer() CommandSchemer {
+	return &commandSchemer{
+		schemas: make(map[string]CommandSchema),
+	}
+}
+
+// Command returns the command interface whene passed a valid command name.
+// If a limiter is set, the returned command waits for the limiter.
 func (c *commander) Command(name string) (command func(map[string]interface{}) interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	command, _ = c.commands[name]
+	if command != nil && c.limiter != nil {
+		command = c.limiter.Wrap(command)
+	}
 	return
 }
 
@@ -35,5 +95,140 @@ func (c *commander) Commands() map[string]func(map[string]interface{}) interface
 
 // AddCommand adds a new command, when passed a command name and the command interface.
 func (c *commander) AddCommand(name string, command func(map[string]interface{}) interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	c.commands[name] = command
 }
+
+// SetCommandLimiter sets the limiter applied to the commands returned by Command
+func (c *commander) SetCommandLimiter(limiter *CommandLimiter) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.limiter = limiter
+}
+
+// CommandSchema returns the parameter schema declared for the command name
+func (c *commandSchemer) CommandSchema(name string) (schema CommandSchema) {
+	schema, _ = c.schemas[name]
+	return
+}
+
+// AddCommandSchema declares the parameter schema for the command name
+func (c *commandSchemer) AddCommandSchema(name string, schema CommandSchema) {
+	c.schemas[name] = schema
+}
+
+// Validate checks the given params against the schema. All parameters of the
+// schema are required. Returns a map of parameter names to error messages,
+// which is empty if the params are valid.
+func (s CommandSchema) Validate(params map[string]interface{}) map[string]string {
+	errs := make(map[string]string)
+	for name, kind := range s {
+		val, ok := params[name]
+		if !ok || val == nil {
+			errs[name] = "is required"
+			continue
+		}
+		if !commandParamIsKind(val, kind) {
+			errs[name] = fmt.Sprintf("must be of type %s", kind)
+		}
+	}
+	return errs
+}
+
+func commandParamIsKind(val interface{}, kind string) bool {
+	switch reflect.TypeOf(val).Kind() {
+	case reflect.String:
+		return kind == CommandParamString
+	case reflect.Bool:
+		return kind == CommandParamBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return kind == CommandParamNumber
+	}
+	return false
+}
+
+// CommandParamFloat returns the value of a number parameter as float64. It
+// accepts the float64 decoded from a JSON request as well as the integer types
+// passed by a caller in Go. Returns 0 if the value is not a number.
+func CommandParamFloat(val interface{}) float64 {
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return 0
+}
+
+// CommandLimiter queues the commands of a device, so that at most
+// maxConcurrent commands are executed at the same time and at least
+// minInterval passes between the start of two commands. Commands exceeding
+// the limit block until they are allowed to run, in the order they arrived.
+type CommandLimiter struct {
+	maxConcurrent int
+	minInterval   time.Duration
+	running       int
+	// next is the earliest start of the next command
+	next time.Time
+	// tickets is the number of arrived commands, serving the ticket of the
+	// first waiting one
+	tickets uint64
+	serving uint64
+	mutex   sync.Mutex
+	cond    *sync.Cond
+}
+
+// NewCommandLimiter returns a new CommandLimiter. A maxConcurrent of 0 does
+// not limit the number of concurrent commands.
+func NewCommandLimiter(maxConcurrent int, minInterval time.Duration) *CommandLimiter {
+	l := &CommandLimiter{maxConcurrent: maxConcurrent, minInterval: minInterval}
+	l.cond = sync.NewCond(&l.mutex)
+	return l
+}
+
+// Wrap returns the command, which waits for the limiter before executing
+func (l *CommandLimiter) Wrap(command func(map[string]interface{}) interface{}) func(map[string]interface{}) interface{} {
+	return func(params map[string]interface{}) interface{} {
+		l.acquire()
+		defer l.release()
+		return command(params)
+	}
+}
+
+// acquire waits until all commands which arrived before are started and a
+// slot is free, then for the min interval since the start of the last command
+func (l *CommandLimiter) acquire() {
+	l.mutex.Lock()
+	ticket := l.tickets
+	l.tickets++
+	for ticket != l.serving || (l.maxConcurrent > 0 && l.running >= l.maxConcurrent) {
+		l.cond.Wait()
+	}
+	now := time.Now()
+	wait := l.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	l.next = now.Add(wait + l.minInterval)
+	l.running++
+	l.serving++
+	l.cond.Broadcast()
+	l.mutex.Unlock()
+
+	time.Sleep(wait)
+}
+
+func (l *CommandLimiter) release() {
+	l.mutex.Lock()
+	l.running--
+	l.cond.Broadcast()
+	l.mutex.Unlock()
+}
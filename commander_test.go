@@ -1,7 +1,9 @@
 package gobot
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot/gobottest"
 )
@@ -22,3 +24,135 @@ func TestCommaner(t *testing.T) {
 	command = c.Command("booyeah")
 	gobottest.Assert(t, command, (func(map[string]interface{}) interface{})(nil))
 }
+
+func TestCommandSchemer(t *testing.T) {
+	c := NewCommandSchemer()
+	gobottest.Assert(t, c.CommandSchema("test"), CommandSchema(nil))
+
+	c.AddCommandSchema("test", CommandSchema{
+		"name":  CommandParamString,
+		"level": CommandParamNumber,
+		"on":    CommandParamBool,
+	})
+	schema := c.CommandSchema("test")
+
+	errs := schema.Validate(map[string]interface{}{"name": "a", "level": 1.0, "on": true})
+	gobottest.Assert(t, len(errs), 0)
+
+	errs = schema.Validate(map[string]interface{}{"name": 1.0, "level": "1"})
+	gobottest.Assert(t, errs, map[string]string{
+		"name":  "must be of type string",
+		"level": "must be of type number",
+		"on":    "is required",
+	})
+}
+
+func TestCommandSchemaNil(t *testing.T) {
+	var schema CommandSchema
+	gobottest.Assert(t, len(schema.Validate(map[string]interface{}{"val": 1})), 0)
+}
+
+func TestCommandParamFloat(t *testing.T) {
+	gobottest.Assert(t, CommandParamFloat(1.5), 1.5)
+	gobottest.Assert(t, CommandParamFloat(-2), -2.0)
+	gobottest.Assert(t, CommandParamFloat(uint8(255)), 255.0)
+	gobottest.Assert(t, CommandParamFloat("1"), 0.0)
+	gobottest.Assert(t, CommandParamFloat(nil), 0.0)
+}
+
+func TestCommanderLimiter(t *testing.T) {
+	c := NewCommander()
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+	c.AddCommand("move", func(map[string]interface{}) interface{} {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+		return "moved"
+	})
+	c.(LimitedCommander).SetCommandLimiter(NewCommandLimiter(1, 0))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gobottest.Assert(t, c.Command("move")(nil), "moved")
+		}()
+	}
+	wg.Wait()
+	gobottest.Assert(t, maxRunning, 1)
+
+	c.(LimitedCommander).SetCommandLimiter(nil)
+	gobottest.Assert(t, c.Command("none"), (func(map[string]interface{}) interface{})(nil))
+}
+
+func TestCommandLimiterMinInterval(t *testing.T) {
+	l := NewCommandLimiter(0, 10*time.Millisecond)
+	command := l.Wrap(func(map[string]interface{}) interface{} { return nil })
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		command(nil)
+	}
+	gobottest.Assert(t, time.Since(start) >= 20*time.Millisecond, true)
+}
+
+func TestCommandLimiterOrder(t *testing.T) {
+	l := NewCommandLimiter(1, 0)
+	var mutex sync.Mutex
+	order := []int{}
+	command := l.Wrap(func(params map[string]interface{}) interface{} {
+		mutex.Lock()
+		order = append(order, params["i"].(int))
+		mutex.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+
+	// the commands arrive while the first one is running
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			command(map[string]interface{}{"i": i})
+		}(i)
+		// wait for the arrival of the command before the next one
+		for arrived := false; !arrived; time.Sleep(100 * time.Microsecond) {
+			l.mutex.Lock()
+			arrived = l.tickets == uint64(i+1)
+			l.mutex.Unlock()
+		}
+	}
+	wg.Wait()
+	gobottest.Assert(t, order, []int{0, 1, 2, 3, 4})
+}
+
+func TestCommanderSetCommandLimiterConcurrently(t *testing.T) {
+	c := NewCommander()
+	c.AddCommand("move", func(map[string]interface{}) interface{} { return "moved" })
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.(LimitedCommander).SetCommandLimiter(NewCommandLimiter(1, 0))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			gobottest.Assert(t, c.Command("move")(nil), "moved")
+		}
+	}()
+	wg.Wait()
+}
@@ -1,12 +1,20 @@
 package gobot
 
 import (
+	"fmt"
 	"log"
 	"reflect"
+	"sync"
+	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 )
 
+// DefaultFinalizeTimeout is the time Connections.Finalize waits for a
+// Connection, e.g. for an Adaptor which hangs because its device has
+// disappeared.
+const DefaultFinalizeTimeout = 10 * time.Second
+
 // JSONConnection is a JSON representation of a Connection.
 type JSONConnection struct {
 	Name    string `json:"name"`
@@ -58,10 +66,39 @@ func (c *Connections) Start() (err error) {
 	return err
 }
 
-// Finalize calls Finalize on each Connection in c
+// Finalize calls Finalize on each Connection in c concurrently, see
+// FinalizeWithTimeout. DefaultFinalizeTimeout is used.
 func (c *Connections) Finalize() (err error) {
-	for _, connection := range *c {
-		if cerr := connection.Finalize(); cerr != nil {
+	return c.FinalizeWithTimeout(DefaultFinalizeTimeout)
+}
+
+// FinalizeWithTimeout calls Finalize on each Connection in c concurrently. A
+// Connection which does not return within the timeout is reported as error
+// and left behind, so the other Connections are finalized nevertheless.
+func (c *Connections) FinalizeWithTimeout(timeout time.Duration) (err error) {
+	errs := make([]error, len(*c))
+	var wg sync.WaitGroup
+	for i, connection := range *c {
+		wg.Add(1)
+		go func(i int, connection Connection) {
+			defer wg.Done()
+
+			done := make(chan error, 1)
+			go func() { done <- connection.Finalize() }()
+
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case errs[i] = <-done:
+			case <-timer.C:
+				errs[i] = fmt.Errorf("Finalize of connection %s timed out after %v", connection.Name(), timeout)
+			}
+		}(i, connection)
+	}
+	wg.Wait()
+
+	for _, cerr := range errs {
+		if cerr != nil {
 			err = multierror.Append(err, cerr)
 		}
 	}
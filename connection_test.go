@@ -0,0 +1,71 @@
+package gobot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type finalizeTestAdaptor struct {
+	testAdaptor
+	finalize func() error
+}
+
+func (t *finalizeTestAdaptor) Finalize() error { return t.finalize() }
+
+func TestConnectionsFinalize(t *testing.T) {
+	var c Connections
+	for _, name := range []string{"a", "b", "c"} {
+		c = append(c, &finalizeTestAdaptor{
+			testAdaptor: testAdaptor{name: name},
+			finalize: func() error {
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			},
+		})
+	}
+
+	// the connections are finalized concurrently
+	begin := time.Now()
+	gobottest.Assert(t, c.Finalize(), nil)
+	gobottest.Assert(t, time.Since(begin) < 140*time.Millisecond, true)
+}
+
+func TestConnectionsFinalizeErrors(t *testing.T) {
+	hang := make(chan bool)
+	defer close(hang)
+	finalized := make(chan bool, 1)
+	c := Connections{
+		&finalizeTestAdaptor{
+			testAdaptor: testAdaptor{name: "hanging"},
+			finalize:    func() error { <-hang; return nil },
+		},
+		&finalizeTestAdaptor{
+			testAdaptor: testAdaptor{name: "failing"},
+			finalize:    func() error { return errors.New("finalize error") },
+		},
+		&finalizeTestAdaptor{
+			testAdaptor: testAdaptor{name: "ok"},
+			finalize:    func() error { finalized <- true; return nil },
+		},
+	}
+
+	err := c.FinalizeWithTimeout(20 * time.Millisecond)
+	gobottest.Refute(t, err, nil)
+	gobottest.Assert(t, strings.Contains(err.Error(), "2 errors occurred"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "Finalize of connection hanging timed out after 20ms"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "finalize error"), true)
+	gobottest.Assert(t, <-finalized, true)
+}
+
+func TestRobotStopRepeated(t *testing.T) {
+	r := newTestRobot("Robot99")
+	gobottest.Assert(t, r.Start(), nil)
+	gobottest.Assert(t, r.Stop(), nil)
+	gobottest.Assert(t, r.Stop(), nil)
+	gobottest.Assert(t, r.Stop(), nil)
+	gobottest.Assert(t, r.Running(), false)
+}
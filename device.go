@@ -1,8 +1,10 @@
 package gobot
 
 import (
+	"fmt"
 	"log"
 	"reflect"
+	"sync"
 
 	multierror "github.com/hashicorp/go-multierror"
 )
@@ -52,24 +54,172 @@ func (d *Devices) Each(f func(Device)) {
 	}
 }
 
-// Start calls Start on each Device in d
+// Start calls Start on each Device in d. The devices are started
+// concurrently in batches, so slow initializations don't add up. Devices of a
+// batch sharing the same connection are started one after another, because
+// most adaptors, e.g. of an i2c bus or a serial port, can't be used
+// concurrently. A device is started after the batch of the devices it depends
+// on, see Dependent. A device is not started, when one of its dependencies
+// failed to start.
 func (d *Devices) Start() (err error) {
 	log.Println("Starting devices...")
-	for _, device := range *d {
-		info := "Starting device " + device.Name()
+	errs := make([]error, len(*d))
+	for _, batch := range d.startBatches() {
+		// the errors of the running batch must not be accessed
+		skip := make([]error, len(batch))
+		for j, i := range batch {
+			if dep := d.failedDependency((*d)[i], errs); dep != nil {
+				skip[j] = fmt.Errorf("Device %s not started, because its dependency %s failed to start", (*d)[i].Name(), dep.Name())
+			}
+		}
 
-		if pinner, ok := device.(Pinner); ok {
-			info = info + " on pin " + pinner.Pin()
+		var wg sync.WaitGroup
+		for _, group := range d.connectionGroups(batch) {
+			wg.Add(1)
+			go func(group []int) {
+				defer wg.Done()
+				for _, j := range group {
+					i := batch[j]
+					if skip[j] != nil {
+						errs[i] = skip[j]
+						continue
+					}
+					device := (*d)[i]
+					info := "Starting device " + device.Name()
+					if pinner, ok := device.(Pinner); ok {
+						info = info + " on pin " + pinner.Pin()
+					}
+					log.Println(info + "...")
+					errs[i] = device.Start()
+				}
+			}(group)
 		}
+		wg.Wait()
+	}
 
-		log.Println(info + "...")
-		if derr := device.Start(); derr != nil {
+	for _, derr := range errs {
+		if derr != nil {
 			err = multierror.Append(err, derr)
 		}
 	}
 	return err
 }
 
+// connectionGroups groups the positions in the batch by the connection of the
+// devices, keeping the order of the batch. Devices without a connection get a
+// group of their own.
+func (d *Devices) connectionGroups(batch []int) (groups [][]int) {
+	byConnection := make(map[interface{}]int)
+	for j, i := range batch {
+		c := (*d)[i].Connection()
+		if c == nil || !reflect.TypeOf(c).Comparable() {
+			groups = append(groups, []int{j})
+			continue
+		}
+		if g, ok := byConnection[c]; ok {
+			groups[g] = append(groups[g], j)
+			continue
+		}
+		byConnection[c] = len(groups)
+		groups = append(groups, []int{j})
+	}
+	return groups
+}
+
+// haltDependentsFirst calls Halt on each Device in d in the reverse order of
+// the start batches, so a device is halted before its dependencies. Within a
+// batch the devices are halted in reverse order.
+func (d *Devices) haltDependentsFirst() (err error) {
+	batches := d.startBatches()
+	for b := len(batches) - 1; b >= 0; b-- {
+		batch := batches[b]
+		for j := len(batch) - 1; j >= 0; j-- {
+			if derr := (*d)[batch[j]].Halt(); derr != nil {
+				err = multierror.Append(err, derr)
+			}
+		}
+	}
+	return err
+}
+
+// dependencies returns the indexes of the devices in d the device depends on
+func (d *Devices) dependencies(device Device) (deps []int) {
+	candidates := []interface{}{}
+	if c := device.Connection(); c != nil {
+		candidates = append(candidates, c)
+	}
+	if dependent, ok := device.(Dependent); ok {
+		for _, dep := range dependent.Dependencies() {
+			candidates = append(candidates, dep)
+		}
+	}
+	for _, c := range candidates {
+		if !reflect.TypeOf(c).Comparable() {
+			continue
+		}
+		for i, other := range *d {
+			if other != device && reflect.TypeOf(other).Comparable() && interface{}(other) == c {
+				deps = append(deps, i)
+			}
+		}
+	}
+	return deps
+}
+
+// startBatches groups the indexes of the devices in d, so each device comes
+// after all of its dependencies. Devices with cyclic dependencies are put
+// into the last batch.
+func (d *Devices) startBatches() (batches [][]int) {
+	deps := make([][]int, len(*d))
+	for i, device := range *d {
+		deps[i] = d.dependencies(device)
+	}
+
+	started := make([]bool, len(*d))
+	remaining := len(*d)
+	for remaining > 0 {
+		batch := []int{}
+		for i := range *d {
+			if started[i] {
+				continue
+			}
+			ready := true
+			for _, dep := range deps[i] {
+				if !started[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				batch = append(batch, i)
+			}
+		}
+		if len(batch) == 0 {
+			for i := range *d {
+				if !started[i] {
+					batch = append(batch, i)
+				}
+			}
+		}
+		for _, i := range batch {
+			started[i] = true
+		}
+		remaining -= len(batch)
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// failedDependency returns a dependency of the device which failed to start
+func (d *Devices) failedDependency(device Device, errs []error) Device {
+	for _, dep := range d.dependencies(device) {
+		if errs[dep] != nil {
+			return (*d)[dep]
+		}
+	}
+	return nil
+}
+
 // Halt calls Halt on each Device in d
 func (d *Devices) Halt() (err error) {
 	for _, device := range *d {
@@ -0,0 +1,134 @@
+package gobot
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+// startTestDevice is a device, which records its start and can be used as
+// connection of other devices
+type startTestDevice struct {
+	testAdaptor
+	connection Connection
+	deps       []Driver
+	delay      time.Duration
+	err        error
+	started    *[]string
+	mutex      *sync.Mutex
+}
+
+func newStartTestDevice(name string, started *[]string, mutex *sync.Mutex) *startTestDevice {
+	return &startTestDevice{
+		testAdaptor: testAdaptor{name: name},
+		started:     started,
+		mutex:       mutex,
+	}
+}
+
+func (s *startTestDevice) Start() error {
+	time.Sleep(s.delay)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	*s.started = append(*s.started, s.name)
+	return s.err
+}
+
+func (s *startTestDevice) Halt() error            { return nil }
+func (s *startTestDevice) Connection() Connection { return s.connection }
+func (s *startTestDevice) Dependencies() []Driver { return s.deps }
+
+func TestDevicesStartConcurrently(t *testing.T) {
+	var started []string
+	var mutex sync.Mutex
+	devices := Devices{}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		d := newStartTestDevice(name, &started, &mutex)
+		d.delay = 50 * time.Millisecond
+		devices = append(devices, d)
+	}
+
+	begin := time.Now()
+	gobottest.Assert(t, devices.Start(), nil)
+	gobottest.Assert(t, time.Since(begin) < 150*time.Millisecond, true)
+	gobottest.Assert(t, len(started), 4)
+}
+
+func TestDevicesStartSharedConnection(t *testing.T) {
+	var started []string
+	var mutex sync.Mutex
+	bus := &testAdaptor{name: "bus"}
+	devices := Devices{}
+	for _, name := range []string{"a", "b", "c"} {
+		d := newStartTestDevice(name, &started, &mutex)
+		d.delay = 30 * time.Millisecond
+		d.connection = bus
+		devices = append(devices, d)
+	}
+	other := newStartTestDevice("other", &started, &mutex)
+	other.delay = 30 * time.Millisecond
+	devices = append(devices, other)
+
+	// the devices on the bus are started one after another, in their order
+	begin := time.Now()
+	gobottest.Assert(t, devices.Start(), nil)
+	gobottest.Assert(t, time.Since(begin) >= 90*time.Millisecond, true)
+	gobottest.Assert(t, len(started), 4)
+	onBus := []string{}
+	for _, name := range started {
+		if name != "other" {
+			onBus = append(onBus, name)
+		}
+	}
+	gobottest.Assert(t, onBus, []string{"a", "b", "c"})
+}
+
+func TestDevicesStartDependencies(t *testing.T) {
+	var started []string
+	var mutex sync.Mutex
+	expander := newStartTestDevice("expander", &started, &mutex)
+	expander.delay = 20 * time.Millisecond
+	lcd := newStartTestDevice("lcd", &started, &mutex)
+	lcd.connection = expander
+	sensor := newStartTestDevice("sensor", &started, &mutex)
+	display := newStartTestDevice("display", &started, &mutex)
+	display.deps = []Driver{lcd}
+
+	devices := Devices{display, lcd, sensor, expander}
+	gobottest.Assert(t, devices.Start(), nil)
+	gobottest.Assert(t, started, []string{"sensor", "expander", "lcd", "display"})
+}
+
+func TestDevicesStartFailedDependency(t *testing.T) {
+	var started []string
+	var mutex sync.Mutex
+	expander := newStartTestDevice("expander", &started, &mutex)
+	expander.err = errors.New("start error")
+	lcd := newStartTestDevice("lcd", &started, &mutex)
+	lcd.connection = expander
+	sensor := newStartTestDevice("sensor", &started, &mutex)
+
+	devices := Devices{lcd, expander, sensor}
+	err := devices.Start()
+	gobottest.Refute(t, err, nil)
+	gobottest.Assert(t, len(started), 2)
+	gobottest.Assert(t, strings.Contains(err.Error(), "Device lcd not started, because its dependency expander failed to start"), true)
+	gobottest.Assert(t, strings.Contains(err.Error(), "start error"), true)
+}
+
+func TestDevicesStartCyclicDependencies(t *testing.T) {
+	var started []string
+	var mutex sync.Mutex
+	a := newStartTestDevice("a", &started, &mutex)
+	b := newStartTestDevice("b", &started, &mutex)
+	a.connection = b
+	b.connection = a
+
+	devices := Devices{a, b}
+	gobottest.Assert(t, devices.Start(), nil)
+	gobottest.Assert(t, len(started), 2)
+}
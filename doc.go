@@ -24,7 +24,7 @@ Here is a "Classic Gobot" program that blinks an LED using an Arduino:
     	led := gpio.NewLedDriver(firmataAdaptor, "13")
 
     	work := func() {
-    		gobot.Every(1*time.Second, func() {
+    		gobot.EveryTimer(1*time.Second, func() {
     			led.Toggle()
     		})
     	}
@@ -38,6 +38,16 @@ Here is a "Classic Gobot" program that blinks an LED using an Arduino:
     	robot.Start()
     }
 
+Start blocks until SIGINT is received. Run blocks until the context is done or
+SIGINT or SIGTERM is received and returns all errors of the shutdown, so the
+robot can be stopped by the program too:
+
+    	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+    	defer cancel()
+    	if err := robot.Run(ctx); err != nil {
+    		log.Fatal(err)
+    	}
+
 Metal Gobot
 
 You can also use Metal Gobot and pick and choose from the various Gobot packages to control hardware with nothing but pure idiomatic Golang code. For example:
@@ -90,10 +100,10 @@ Finally, you can use Master Gobot to add the complete Gobot API or control swarm
     			fmt.Println("Collision Detected!")
     		})
 
-    		gobot.Every(1*time.Second, func() {
+    		gobot.EveryTimer(1*time.Second, func() {
     			spheroDriver.Roll(100, uint16(gobot.Rand(360)))
     		})
-    		gobot.Every(3*time.Second, func() {
+    		gobot.EveryTimer(3*time.Second, func() {
     			spheroDriver.SetRGB(uint8(gobot.Rand(255)),
     				uint8(gobot.Rand(255)),
     				uint8(gobot.Rand(255)),
@@ -18,3 +18,23 @@ type Driver interface {
 type Pinner interface {
 	Pin() string
 }
+
+// Telemeter is the interface that describes a driver which provides a
+// snapshot of its latest readings and state
+type Telemeter interface {
+	Telemetry() map[string]interface{}
+}
+
+// ContentionCounter is the interface that describes a driver which counts
+// how often a caller had to wait for its lock, e.g. by using a Mutex
+type ContentionCounter interface {
+	Contentions() uint64
+}
+
+// Dependent is the interface that describes a driver which must be started
+// after other devices of the robot, e.g. a driver which uses the pins of an
+// expander driver. A device whose Connection is another device of the robot
+// depends on it without implementing Dependent.
+type Dependent interface {
+	Dependencies() []Driver
+}
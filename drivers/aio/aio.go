@@ -17,6 +17,14 @@ const (
 	Data = "data"
 	// Vibration event
 	Vibration = "vibration"
+	// Above event
+	Above = "above"
+	// Below event
+	Below = "below"
+	// LowVoltage event
+	LowVoltage = "lowVoltage"
+	// HighTemperature event
+	HighTemperature = "highTemperature"
 )
 
 // AnalogReader interface represents an Adaptor which has Analog capabilities
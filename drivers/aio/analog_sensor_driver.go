@@ -1,6 +1,8 @@
 package aio
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -10,38 +12,58 @@ import (
 type AnalogSensorDriver struct {
 	name       string
 	pin        string
-	halt       chan bool
-	interval   time.Duration
+	poller     *gobot.Poller
 	connection AnalogReader
+	thresholds *analogThresholds
+	// the last reading of the polling
+	value int
+	mutex sync.Mutex
 	gobot.Eventer
 	gobot.Commander
 }
 
+type analogThresholds struct {
+	lower      int
+	upper      int
+	hysteresis int
+	above      bool
+	below      bool
+}
+
 // NewAnalogSensorDriver returns a new AnalogSensorDriver with a polling interval of
 // 10 Milliseconds given an AnalogReader and pin.
 //
 // Optionally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
-func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *AnalogSensorDriver {
+func NewAnalogSensorDriver(a AnalogReader, pin string, v ...interface{}) *AnalogSensorDriver {
 	d := &AnalogSensorDriver{
 		name:       gobot.DefaultName("AnalogSensor"),
 		connection: a,
 		pin:        pin,
 		Eventer:    gobot.NewEventer(),
 		Commander:  gobot.NewCommander(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		poller:     gobot.NewPoller(10 * time.Millisecond),
 	}
 
-	if len(v) > 0 {
-		d.interval = v[0]
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for aio.NewAnalogSensorDriver", opt))
+		}
 	}
 
 	d.AddEvent(Data)
 	d.AddEvent(Error)
+	d.AddEvent(Above)
+	d.AddEvent(Below)
 
 	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
 		val, err := d.Read()
@@ -55,35 +77,32 @@ func NewAnalogSensorDriver(a AnalogReader, pin string, v ...time.Duration) *Anal
 // Emits the Events:
 //	Data int - Event is emitted on change and represents the current reading from the sensor.
 //	Error error - Event is emitted on error reading from the sensor.
+//	Above int - Event is emitted when the reading rises above the upper threshold.
+//	Below int - Event is emitted when the reading falls below the lower threshold.
 func (a *AnalogSensorDriver) Start() (err error) {
 	var value int = 0
-	go func() {
-		timer := time.NewTimer(a.interval)
-		timer.Stop()
-		for {
-			newValue, err := a.Read()
-			if err != nil {
-				a.Publish(a.Event(Error), err)
-			} else if newValue != value && newValue != -1 {
+	a.poller.Start(func() error {
+		newValue, err := a.Read()
+		if err != nil {
+			a.Publish(a.Event(Error), err)
+		} else if newValue != -1 {
+			a.mutex.Lock()
+			a.value = newValue
+			a.mutex.Unlock()
+			if newValue != value {
 				value = newValue
 				a.Publish(a.Event(Data), value)
 			}
-
-			timer.Reset(a.interval)
-			select {
-			case <-timer.C:
-			case <-a.halt:
-				timer.Stop()
-				return
-			}
+			a.checkThresholds(newValue)
 		}
-	}()
+		return err
+	})
 	return
 }
 
 // Halt stops polling the analog sensor for new information
 func (a *AnalogSensorDriver) Halt() (err error) {
-	a.halt <- true
+	a.poller.Stop()
 	return
 }
 
@@ -103,3 +122,59 @@ func (a *AnalogSensorDriver) Connection() gobot.Connection { return a.connection
 func (a *AnalogSensorDriver) Read() (val int, err error) {
 	return a.connection.AnalogRead(a.Pin())
 }
+
+// Telemetry returns the last reading of the polling
+func (a *AnalogSensorDriver) Telemetry() map[string]interface{} {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return map[string]interface{}{"value": a.value}
+}
+
+// SetThresholds enables the Above and Below events. Above is published once
+// when the reading rises above upper and again only after the reading has
+// fallen below upper - hysteresis. Below is published once when the reading
+// falls below lower and again only after the reading has risen above
+// lower + hysteresis.
+func (a *AnalogSensorDriver) SetThresholds(lower, upper, hysteresis int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.thresholds = &analogThresholds{lower: lower, upper: upper, hysteresis: hysteresis}
+}
+
+// ClearThresholds disables the Above and Below events
+func (a *AnalogSensorDriver) ClearThresholds() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.thresholds = nil
+}
+
+func (a *AnalogSensorDriver) checkThresholds(val int) {
+	a.mutex.Lock()
+	t := a.thresholds
+	if t == nil {
+		a.mutex.Unlock()
+		return
+	}
+
+	var events []string
+	if !t.above && val > t.upper {
+		t.above = true
+		events = append(events, Above)
+	} else if t.above && val < t.upper-t.hysteresis {
+		t.above = false
+	}
+	if !t.below && val < t.lower {
+		t.below = true
+		events = append(events, Below)
+	} else if t.below && val > t.lower+t.hysteresis {
+		t.below = false
+	}
+	a.mutex.Unlock()
+
+	for _, e := range events {
+		a.Publish(a.Event(e), val)
+	}
+}
@@ -2,7 +2,10 @@ package aio
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 )
 
 var _ gobot.Driver = (*AnalogSensorDriver)(nil)
+var _ gobot.Telemeter = (*AnalogSensorDriver)(nil)
 
 func TestAnalogSensorDriver(t *testing.T) {
 	a := newAioTestAdaptor()
@@ -18,12 +22,15 @@ func TestAnalogSensorDriver(t *testing.T) {
 	gobottest.Refute(t, d.Connection(), nil)
 
 	// default interval
-	gobottest.Assert(t, d.interval, 10*time.Millisecond)
+	gobottest.Assert(t, d.poller.Interval(), 10*time.Millisecond)
 
 	a = newAioTestAdaptor()
 	d = NewAnalogSensorDriver(a, "42", 30*time.Second)
 	gobottest.Assert(t, d.Pin(), "42")
-	gobottest.Assert(t, d.interval, 30*time.Second)
+	gobottest.Assert(t, d.poller.Interval(), 30*time.Second)
+
+	d = NewAnalogSensorDriver(a, "42", gobot.WithPollInterval(time.Second))
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
 
 	a.TestAdaptorAnalogRead(func() (val int, err error) {
 		val = 100
@@ -35,6 +42,14 @@ func TestAnalogSensorDriver(t *testing.T) {
 	gobottest.Assert(t, ret["err"], nil)
 }
 
+func TestAnalogSensorDriverUnsupportedOption(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover(), "unsupported option int for aio.NewAnalogSensorDriver")
+	}()
+	// an interval without unit is not accepted
+	NewAnalogSensorDriver(newAioTestAdaptor(), "1", 100)
+}
+
 func TestAnalogSensorDriverStart(t *testing.T) {
 	sem := make(chan bool, 1)
 	a := newAioTestAdaptor()
@@ -59,6 +74,7 @@ func TestAnalogSensorDriverStart(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Errorf("AnalogSensor Event \"Data\" was not published")
 	}
+	gobottest.Assert(t, d.Telemetry(), map[string]interface{}{"value": 100})
 
 	// expect error to be received
 	d.Once(d.Event(Error), func(data interface{}) {
@@ -88,7 +104,7 @@ func TestAnalogSensorDriverStart(t *testing.T) {
 		return
 	})
 
-	d.halt <- true
+	gobottest.Assert(t, d.Halt(), nil)
 
 	select {
 	case <-sem:
@@ -99,17 +115,9 @@ func TestAnalogSensorDriverStart(t *testing.T) {
 
 func TestAnalogSensorDriverHalt(t *testing.T) {
 	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1")
-	done := make(chan struct{})
-	go func() {
-		<-d.halt
-		close(done)
-	}()
+	gobottest.Assert(t, d.Start(), nil)
 	gobottest.Assert(t, d.Halt(), nil)
-	select {
-	case <-done:
-	case <-time.After(100 * time.Millisecond):
-		t.Errorf("AnalogSensor was not halted")
-	}
+	gobottest.Assert(t, d.poller.Running(), false)
 }
 
 func TestAnalogSensorDriverDefaultName(t *testing.T) {
@@ -122,3 +130,77 @@ func TestAnalogSensorDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestAnalogSensorDriverThresholds(t *testing.T) {
+	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1")
+
+	var mtx sync.Mutex
+	var events []string
+	d.On(d.Event(Above), func(data interface{}) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		events = append(events, fmt.Sprintf("above %d", data.(int)))
+	})
+	d.On(d.Event(Below), func(data interface{}) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		events = append(events, fmt.Sprintf("below %d", data.(int)))
+	})
+
+	// disabled by default
+	d.checkThresholds(1000)
+
+	d.SetThresholds(100, 500, 20)
+	for _, val := range []int{300, 510, 520, 490, 510, 470, 510, 90, 80, 110, 95, 130, 90} {
+		d.checkThresholds(val)
+	}
+
+	d.ClearThresholds()
+	d.checkThresholds(1000)
+
+	time.Sleep(50 * time.Millisecond)
+	mtx.Lock()
+	defer mtx.Unlock()
+	sort.Strings(events)
+	gobottest.Assert(t, events, []string{"above 510", "above 510", "below 90", "below 90"})
+}
+
+func TestAnalogSensorDriverStartThresholds(t *testing.T) {
+	sem := make(chan bool, 1)
+	a := newAioTestAdaptor()
+	d := NewAnalogSensorDriver(a, "1")
+	d.SetThresholds(100, 500, 20)
+
+	d.Once(d.Event(Above), func(data interface{}) {
+		gobottest.Assert(t, data.(int), 600)
+		sem <- true
+	})
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		val = 600
+		return
+	})
+	gobottest.Assert(t, d.Start(), nil)
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("AnalogSensor Event \"Above\" was not published")
+	}
+
+	d.Once(d.Event(Below), func(data interface{}) {
+		gobottest.Assert(t, data.(int), 50)
+		sem <- true
+	})
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		val = 50
+		return
+	})
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("AnalogSensor Event \"Below\" was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,293 @@
+package aio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// ChemistryLiPo is a lithium polymer or lithium ion cell
+	ChemistryLiPo = "lipo"
+	// ChemistryLiFePO4 is a lithium iron phosphate cell
+	ChemistryLiFePO4 = "lifepo4"
+	// ChemistryNiMH is a nickel metal hydride cell
+	ChemistryNiMH = "nimh"
+	// ChemistryAlkaline is an alkaline cell
+	ChemistryAlkaline = "alkaline"
+	// ChemistryLeadAcid is a lead acid cell, e.g. 6 of them make a 12V battery
+	ChemistryLeadAcid = "leadacid"
+)
+
+// batteryCurve is a list of cell voltages and the related state of charge in
+// percent, sorted by descending voltage
+type batteryCurve [][2]float64
+
+var batteryCurves = map[string]batteryCurve{
+	ChemistryLiPo: {
+		{4.20, 100}, {4.10, 90}, {4.00, 80}, {3.92, 70}, {3.85, 60}, {3.80, 50},
+		{3.75, 40}, {3.70, 30}, {3.65, 20}, {3.55, 10}, {3.30, 0},
+	},
+	ChemistryLiFePO4: {
+		{3.40, 100}, {3.35, 90}, {3.32, 70}, {3.30, 40}, {3.27, 30}, {3.20, 20},
+		{3.00, 10}, {2.50, 0},
+	},
+	ChemistryNiMH: {
+		{1.40, 100}, {1.30, 90}, {1.25, 70}, {1.20, 50}, {1.15, 30}, {1.10, 15},
+		{1.00, 0},
+	},
+	ChemistryAlkaline: {
+		{1.60, 100}, {1.50, 90}, {1.40, 70}, {1.30, 50}, {1.20, 30}, {1.10, 10},
+		{0.90, 0},
+	},
+	ChemistryLeadAcid: {
+		{2.12, 100}, {2.10, 90}, {2.08, 80}, {2.05, 70}, {2.03, 60}, {2.01, 50},
+		{1.98, 40}, {1.95, 30}, {1.93, 20}, {1.90, 10}, {1.75, 0},
+	},
+}
+
+// BatteryMonitorDriver represents a battery (or any other voltage source)
+// measured through a voltage divider at an analog pin.
+type BatteryMonitorDriver struct {
+	name       string
+	pin        string
+	poller     *gobot.Poller
+	connection AnalogReader
+	reference  float64
+	maxValue   int
+	ratio      float64
+	offset     float64
+	chemistry  string
+	cells      int
+	lowVoltage float64
+	voltage    float64
+	mutex      sync.Mutex
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewBatteryMonitorDriver returns a new BatteryMonitorDriver with a polling
+// interval of 1 second given an AnalogReader and pin. By default a 10 bit ADC
+// with a 5V reference and a single LiPo cell without divider is assumed.
+//
+// Optionally accepts:
+//	WithBatteryInterval(time.Duration): interval at which the voltage is polled
+//	WithBatteryPolling(...gobot.PollOption): options of the polling
+//	WithAnalogReference(float64, int): reference voltage and maximum raw value of the ADC
+//	WithDividerRatio(float64): ratio of the divider, e.g. 2 for two equal resistors
+//	WithCalibrationOffset(float64): offset in volts added to the measured voltage
+//	WithBatteryChemistry(string, int): chemistry and number of cells in series
+//	WithLowVoltage(float64): voltage below which the LowVoltage event is published
+//
+// Adds the following API Commands:
+//	"Voltage" - See BatteryMonitorDriver.Voltage
+//	"Percentage" - See BatteryMonitorDriver.Percentage
+func NewBatteryMonitorDriver(a AnalogReader, pin string, options ...func(*BatteryMonitorDriver)) *BatteryMonitorDriver {
+	b := &BatteryMonitorDriver{
+		name:       gobot.DefaultName("BatteryMonitor"),
+		connection: a,
+		pin:        pin,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+		poller:     gobot.NewPoller(time.Second),
+		reference:  5.0,
+		maxValue:   1023,
+		ratio:      1,
+		chemistry:  ChemistryLiPo,
+		cells:      1,
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+	if _, ok := batteryCurves[b.chemistry]; !ok {
+		panic(fmt.Sprintf("unsupported battery chemistry %q for aio.NewBatteryMonitorDriver", b.chemistry))
+	}
+
+	b.AddEvent(Data)
+	b.AddEvent(Error)
+	b.AddEvent(LowVoltage)
+
+	b.AddCommand("Voltage", func(params map[string]interface{}) interface{} {
+		val, err := b.Voltage()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	b.AddCommand("Percentage", func(params map[string]interface{}) interface{} {
+		val, err := b.Percentage()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return b
+}
+
+// WithBatteryInterval option sets the interval at which the voltage is polled.
+func WithBatteryInterval(interval time.Duration) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.poller.Apply(gobot.WithPollInterval(interval))
+	}
+}
+
+// WithBatteryPolling option applies the options of the polling, e.g.
+// gobot.WithPollJitter(time.Duration).
+func WithBatteryPolling(options ...gobot.PollOption) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.poller.Apply(options...)
+	}
+}
+
+// WithAnalogReference option sets the reference voltage of the ADC and the raw
+// value which is read at the reference voltage, e.g. 3.3 and 4095 for a 12 bit ADC.
+func WithAnalogReference(reference float64, maxValue int) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.reference = reference
+		b.maxValue = maxValue
+	}
+}
+
+// WithDividerRatio option sets the ratio of the voltage divider, which is
+// (R1 + R2) / R2 with R2 being the resistor between the analog pin and ground.
+func WithDividerRatio(ratio float64) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.ratio = ratio
+	}
+}
+
+// WithCalibrationOffset option sets an offset in volts, which is added to the
+// measured voltage, e.g. to compensate a protection diode.
+func WithCalibrationOffset(offset float64) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.offset = offset
+	}
+}
+
+// WithBatteryChemistry option sets the chemistry of the battery, one of the
+// Chemistry constants, and the number of cells in series, which are used for
+// the percentage estimation. NewBatteryMonitorDriver panics on an unknown
+// chemistry.
+func WithBatteryChemistry(chemistry string, cells int) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.chemistry = chemistry
+		if cells > 0 {
+			b.cells = cells
+		}
+	}
+}
+
+// WithLowVoltage option sets the voltage below which the LowVoltage event is
+// published. The event is disabled by default.
+func WithLowVoltage(voltage float64) func(*BatteryMonitorDriver) {
+	return func(b *BatteryMonitorDriver) {
+		b.lowVoltage = voltage
+	}
+}
+
+// Start starts the BatteryMonitorDriver and reads the voltage at the given interval.
+// Emits the Events:
+//	Data float64 - Event is emitted on change and represents the current voltage.
+//	LowVoltage float64 - Event is emitted when the voltage drops below the low voltage,
+//	it is emitted again only after the voltage has recovered.
+//	Error error - Event is emitted on error reading from the sensor.
+func (b *BatteryMonitorDriver) Start() (err error) {
+	var value float64
+	low := false
+	b.poller.Start(func() error {
+		newValue, err := b.Voltage()
+		if err != nil {
+			b.Publish(b.Event(Error), err)
+		} else {
+			if newValue != value {
+				value = newValue
+				b.Publish(b.Event(Data), value)
+			}
+			if b.lowVoltage > 0 && newValue < b.lowVoltage {
+				if !low {
+					low = true
+					b.Publish(b.Event(LowVoltage), newValue)
+				}
+			} else {
+				low = false
+			}
+		}
+		return err
+	})
+	return
+}
+
+// Halt stops polling the voltage
+func (b *BatteryMonitorDriver) Halt() (err error) {
+	b.poller.Stop()
+	return
+}
+
+// Name returns the BatteryMonitorDrivers name
+func (b *BatteryMonitorDriver) Name() string { return b.name }
+
+// SetName sets the BatteryMonitorDrivers name
+func (b *BatteryMonitorDriver) SetName(n string) { b.name = n }
+
+// Pin returns the BatteryMonitorDrivers pin
+func (b *BatteryMonitorDriver) Pin() string { return b.pin }
+
+// Connection returns the BatteryMonitorDrivers Connection
+func (b *BatteryMonitorDriver) Connection() gobot.Connection {
+	return b.connection.(gobot.Connection)
+}
+
+// Voltage reads the analog pin and returns the voltage of the battery
+func (b *BatteryMonitorDriver) Voltage() (voltage float64, err error) {
+	raw, err := b.connection.AnalogRead(b.Pin())
+	if err != nil {
+		return 0, err
+	}
+
+	voltage = float64(raw)/float64(b.maxValue)*b.reference*b.ratio + b.offset
+
+	b.mutex.Lock()
+	b.voltage = voltage
+	b.mutex.Unlock()
+	return
+}
+
+// Percentage reads the voltage and returns the estimated state of charge in
+// percent for the configured chemistry and number of cells
+func (b *BatteryMonitorDriver) Percentage() (percent float64, err error) {
+	voltage, err := b.Voltage()
+	if err != nil {
+		return 0, err
+	}
+	return b.estimate(voltage), nil
+}
+
+// LastVoltage returns the last voltage read by the driver
+func (b *BatteryMonitorDriver) LastVoltage() float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.voltage
+}
+
+// Telemetry returns the last voltage and the estimated state of charge in
+// percent
+func (b *BatteryMonitorDriver) Telemetry() map[string]interface{} {
+	voltage := b.LastVoltage()
+	return map[string]interface{}{
+		"voltage":    voltage,
+		"percentage": b.estimate(voltage),
+	}
+}
+
+func (b *BatteryMonitorDriver) estimate(voltage float64) float64 {
+	curve := batteryCurves[b.chemistry]
+	cell := voltage / float64(b.cells)
+	if cell >= curve[0][0] {
+		return 100
+	}
+	for i := 1; i < len(curve); i++ {
+		if cell >= curve[i][0] {
+			upper, lower := curve[i-1], curve[i]
+			return lower[1] + (cell-lower[0])/(upper[0]-lower[0])*(upper[1]-lower[1])
+		}
+	}
+	return 0
+}
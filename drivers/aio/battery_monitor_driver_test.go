@@ -0,0 +1,177 @@
+package aio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*BatteryMonitorDriver)(nil)
+var _ gobot.Telemeter = (*BatteryMonitorDriver)(nil)
+
+func TestBatteryMonitorDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewBatteryMonitorDriver(a, "1")
+	gobottest.Assert(t, d.Connection(), a)
+	gobottest.Assert(t, d.Pin(), "1")
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
+	gobottest.Assert(t, d.chemistry, ChemistryLiPo)
+	gobottest.Assert(t, d.cells, 1)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "BatteryMonitor"), true)
+
+	d.SetName("battery")
+	gobottest.Assert(t, d.Name(), "battery")
+}
+
+func TestBatteryMonitorDriverOptions(t *testing.T) {
+	d := NewBatteryMonitorDriver(newAioTestAdaptor(), "1",
+		WithBatteryInterval(100*time.Millisecond),
+		WithAnalogReference(3.3, 4095),
+		WithDividerRatio(4),
+		WithCalibrationOffset(0.3),
+		WithBatteryChemistry(ChemistryLeadAcid, 6),
+		WithLowVoltage(11.5),
+	)
+	gobottest.Assert(t, d.poller.Interval(), 100*time.Millisecond)
+	gobottest.Assert(t, d.reference, 3.3)
+	gobottest.Assert(t, d.maxValue, 4095)
+	gobottest.Assert(t, d.ratio, 4.0)
+	gobottest.Assert(t, d.offset, 0.3)
+	gobottest.Assert(t, d.chemistry, ChemistryLeadAcid)
+	gobottest.Assert(t, d.cells, 6)
+
+	p := NewBatteryMonitorDriver(newAioTestAdaptor(), "1",
+		WithBatteryPolling(gobot.WithPollInterval(time.Minute)))
+	gobottest.Assert(t, p.poller.Interval(), time.Minute)
+	gobottest.Assert(t, d.lowVoltage, 11.5)
+}
+
+func TestBatteryMonitorDriverUnsupportedChemistry(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover(), `unsupported battery chemistry "lion" for aio.NewBatteryMonitorDriver`)
+	}()
+	NewBatteryMonitorDriver(newAioTestAdaptor(), "1", WithBatteryChemistry("lion", 1))
+}
+
+func TestBatteryMonitorDriverVoltage(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewBatteryMonitorDriver(a, "1",
+		WithAnalogReference(3.3, 1023),
+		WithDividerRatio(2),
+		WithCalibrationOffset(0.1),
+	)
+
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		return 620, nil
+	})
+	v, err := d.Voltage()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, fmt.Sprintf("%.2f", v), "4.10")
+	gobottest.Assert(t, d.LastVoltage(), v)
+
+	ret := d.Command("Voltage")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"].(float64), v)
+	gobottest.Assert(t, ret["err"], nil)
+
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		err = errors.New("read error")
+		return
+	})
+	_, err = d.Voltage()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestBatteryMonitorDriverPercentage(t *testing.T) {
+	var tests = map[string]struct {
+		chemistry string
+		cells     int
+		voltage   float64
+		want      string
+	}{
+		"lipo full":          {chemistry: ChemistryLiPo, cells: 1, voltage: 4.3, want: "100.0"},
+		"lipo half":          {chemistry: ChemistryLiPo, cells: 1, voltage: 3.8, want: "50.0"},
+		"lipo interpolated":  {chemistry: ChemistryLiPo, cells: 2, voltage: 7.65, want: "55.0"},
+		"lipo empty":         {chemistry: ChemistryLiPo, cells: 1, voltage: 3.0, want: "0.0"},
+		"nimh":               {chemistry: ChemistryNiMH, cells: 4, voltage: 4.8, want: "50.0"},
+		"lead acid":          {chemistry: ChemistryLeadAcid, cells: 6, voltage: 12.06, want: "50.0"},
+		"lifepo4":            {chemistry: ChemistryLiFePO4, cells: 4, voltage: 13.2, want: "40.0"},
+		"alkaline two cells": {chemistry: ChemistryAlkaline, cells: 2, voltage: 2.6, want: "50.0"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := newAioTestAdaptor()
+			// 1V per 100 raw steps
+			d := NewBatteryMonitorDriver(a, "1",
+				WithAnalogReference(10, 1000),
+				WithBatteryChemistry(tc.chemistry, tc.cells),
+			)
+			a.TestAdaptorAnalogRead(func() (val int, err error) {
+				return int(tc.voltage*100 + 0.5), nil
+			})
+			p, err := d.Percentage()
+			gobottest.Assert(t, err, nil)
+			gobottest.Assert(t, fmt.Sprintf("%.1f", p), tc.want)
+		})
+	}
+}
+
+func TestBatteryMonitorDriverStart(t *testing.T) {
+	sem := make(chan bool, 1)
+	a := newAioTestAdaptor()
+	d := NewBatteryMonitorDriver(a, "1",
+		WithBatteryInterval(10*time.Millisecond),
+		WithAnalogReference(10, 1000),
+		WithLowVoltage(3.5),
+	)
+
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		return 370, nil
+	})
+	d.Once(d.Event(Data), func(data interface{}) {
+		gobottest.Assert(t, fmt.Sprintf("%.2f", data.(float64)), "3.70")
+		sem <- true
+	})
+	gobottest.Assert(t, d.Start(), nil)
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("BatteryMonitor Event \"Data\" was not published")
+	}
+
+	d.Once(d.Event(LowVoltage), func(data interface{}) {
+		gobottest.Assert(t, fmt.Sprintf("%.2f", data.(float64)), "3.40")
+		sem <- true
+	})
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		return 340, nil
+	})
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("BatteryMonitor Event \"LowVoltage\" was not published")
+	}
+
+	d.Once(d.Event(Error), func(data interface{}) {
+		gobottest.Assert(t, data.(error).Error(), "read error")
+		sem <- true
+	})
+	a.TestAdaptorAnalogRead(func() (val int, err error) {
+		err = errors.New("read error")
+		return
+	})
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("BatteryMonitor Event \"Error\" was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
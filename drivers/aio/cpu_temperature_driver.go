@@ -0,0 +1,188 @@
+package aio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// cpuTemperatureSysPath is the mount point of the Linux sysfs
+const cpuTemperatureSysPath = "/sys"
+
+// CPUTemperatureDriver represents the temperature sensor of the SoC, which is
+// read from a thermal zone of the board the robot is running on. The
+// temperature is reported in degree Celsius. The files of the Linux sysfs
+// are read, so reading fails on other systems.
+type CPUTemperatureDriver struct {
+	name            string
+	zone            int
+	sysPath         string
+	poller          *gobot.Poller
+	connection      gobot.Connection
+	highTemperature float64
+	temperature     float64
+	mutex           sync.Mutex
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewCPUTemperatureDriver returns a new CPUTemperatureDriver with a polling
+// interval of 1 second for thermal zone 0. The connection is the adaptor of
+// the board, it is not used to read the temperature.
+//
+// Optionally accepts:
+//	WithThermalZone(int): thermal zone to read, see /sys/class/thermal
+//	WithCPUTemperatureInterval(time.Duration): interval at which the temperature is polled
+//	WithHighTemperature(float64): temperature above which the HighTemperature event is published
+//
+// Adds the following API Commands:
+//	"Temperature" - See CPUTemperatureDriver.Temperature
+//	"Frequency" - See CPUTemperatureDriver.Frequency
+func NewCPUTemperatureDriver(a gobot.Connection, options ...func(*CPUTemperatureDriver)) *CPUTemperatureDriver {
+	d := &CPUTemperatureDriver{
+		name:       gobot.DefaultName("CPUTemperature"),
+		connection: a,
+		sysPath:    cpuTemperatureSysPath,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+		poller:     gobot.NewPoller(time.Second),
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+	d.AddEvent(HighTemperature)
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		val, err := d.Temperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Frequency", func(params map[string]interface{}) interface{} {
+		val, err := d.Frequency()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return d
+}
+
+// WithThermalZone option sets the thermal zone to read, which is 0 by default.
+func WithThermalZone(zone int) func(*CPUTemperatureDriver) {
+	return func(d *CPUTemperatureDriver) {
+		d.zone = zone
+	}
+}
+
+// WithCPUTemperatureInterval option sets the interval at which the temperature
+// is polled.
+func WithCPUTemperatureInterval(interval time.Duration) func(*CPUTemperatureDriver) {
+	return func(d *CPUTemperatureDriver) {
+		d.poller.Apply(gobot.WithPollInterval(interval))
+	}
+}
+
+// WithHighTemperature option sets the temperature above which the
+// HighTemperature event is published, e.g. to throttle the motors. The event
+// is disabled by default.
+func WithHighTemperature(celsius float64) func(*CPUTemperatureDriver) {
+	return func(d *CPUTemperatureDriver) {
+		d.highTemperature = celsius
+	}
+}
+
+// Start starts the CPUTemperatureDriver and reads the temperature at the given interval.
+// Emits the Events:
+//	Data float64 - Event is emitted on change and represents the current temperature.
+//	HighTemperature float64 - Event is emitted when the temperature rises above the high
+//	temperature, it is emitted again only after the temperature has dropped.
+//	Error error - Event is emitted on error reading the thermal zone.
+func (c *CPUTemperatureDriver) Start() (err error) {
+	var value float64
+	high := false
+	c.poller.Start(func() error {
+		newValue, err := c.Temperature()
+		if err != nil {
+			c.Publish(c.Event(Error), err)
+		} else {
+			if newValue != value {
+				value = newValue
+				c.Publish(c.Event(Data), value)
+			}
+			if c.highTemperature > 0 && newValue > c.highTemperature {
+				if !high {
+					high = true
+					c.Publish(c.Event(HighTemperature), newValue)
+				}
+			} else {
+				high = false
+			}
+		}
+		return err
+	})
+	return
+}
+
+// Halt stops polling the temperature
+func (c *CPUTemperatureDriver) Halt() (err error) {
+	c.poller.Stop()
+	return
+}
+
+// Name returns the CPUTemperatureDrivers name
+func (c *CPUTemperatureDriver) Name() string { return c.name }
+
+// SetName sets the CPUTemperatureDrivers name
+func (c *CPUTemperatureDriver) SetName(n string) { c.name = n }
+
+// Connection returns the CPUTemperatureDrivers Connection
+func (c *CPUTemperatureDriver) Connection() gobot.Connection { return c.connection }
+
+// Temperature reads the thermal zone and returns the temperature in degree
+// Celsius
+func (c *CPUTemperatureDriver) Temperature() (celsius float64, err error) {
+	milli, err := readSysInt(fmt.Sprintf("%s/class/thermal/thermal_zone%d/temp", c.sysPath, c.zone))
+	if err != nil {
+		return 0, err
+	}
+	celsius = float64(milli) / 1000
+
+	c.mutex.Lock()
+	c.temperature = celsius
+	c.mutex.Unlock()
+	return
+}
+
+// LastTemperature returns the last temperature read by the driver
+func (c *CPUTemperatureDriver) LastTemperature() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.temperature
+}
+
+// Telemetry returns the last temperature in celsius
+func (c *CPUTemperatureDriver) Telemetry() map[string]interface{} {
+	return map[string]interface{}{"temperature": c.LastTemperature()}
+}
+
+// Frequency returns the current frequency of the first CPU in kHz, which is
+// lowered by the kernel when the SoC gets too hot
+func (c *CPUTemperatureDriver) Frequency() (khz int, err error) {
+	return readSysInt(c.sysPath + "/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq")
+}
+
+// readSysInt reads a file of the sysfs, which contains a single integer
+func readSysInt(path string) (int, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(buf)))
+}
@@ -0,0 +1,112 @@
+package aio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*CPUTemperatureDriver)(nil)
+var _ gobot.Telemeter = (*CPUTemperatureDriver)(nil)
+
+// initTestCPUTemperatureSysPath returns a temporary directory with the files
+// of the sysfs read by the driver
+func initTestCPUTemperatureSysPath(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "sys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"class/thermal/thermal_zone1/temp":                 "72500\n",
+		"devices/system/cpu/cpu0/cpufreq/scaling_cur_freq": "1200000\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCPUTemperatureDriver(t *testing.T) {
+	a := newAioTestAdaptor()
+	d := NewCPUTemperatureDriver(a)
+	gobottest.Assert(t, d.Connection(), a)
+	gobottest.Assert(t, d.zone, 0)
+	gobottest.Assert(t, d.sysPath, "/sys")
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "CPUTemperature"), true)
+
+	d.SetName("soc")
+	gobottest.Assert(t, d.Name(), "soc")
+
+	d = NewCPUTemperatureDriver(a,
+		WithThermalZone(1),
+		WithCPUTemperatureInterval(100*time.Millisecond),
+		WithHighTemperature(70),
+	)
+	gobottest.Assert(t, d.zone, 1)
+	gobottest.Assert(t, d.poller.Interval(), 100*time.Millisecond)
+	gobottest.Assert(t, d.highTemperature, 70.0)
+}
+
+func TestCPUTemperatureDriverTemperature(t *testing.T) {
+	sysPath := initTestCPUTemperatureSysPath(t)
+	defer os.RemoveAll(sysPath)
+	d := NewCPUTemperatureDriver(newAioTestAdaptor(), WithThermalZone(1))
+	d.sysPath = sysPath
+
+	celsius, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, 72.5)
+	gobottest.Assert(t, d.LastTemperature(), 72.5)
+
+	ret := d.Command("Frequency")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"], 1200000)
+	gobottest.Assert(t, ret["err"], nil)
+
+	d = NewCPUTemperatureDriver(newAioTestAdaptor(), WithThermalZone(2))
+	d.sysPath = sysPath
+	ret = d.Command("Temperature")(nil).(map[string]interface{})
+	gobottest.Refute(t, ret["err"], nil)
+}
+
+func TestCPUTemperatureDriverStart(t *testing.T) {
+	sysPath := initTestCPUTemperatureSysPath(t)
+	defer os.RemoveAll(sysPath)
+	sem := make(chan bool, 2)
+	d := NewCPUTemperatureDriver(newAioTestAdaptor(),
+		WithThermalZone(1),
+		WithCPUTemperatureInterval(10*time.Millisecond),
+		WithHighTemperature(70),
+	)
+	d.sysPath = sysPath
+
+	d.Once(d.Event(Data), func(data interface{}) {
+		gobottest.Assert(t, data.(float64), 72.5)
+		sem <- true
+	})
+	d.Once(d.Event(HighTemperature), func(data interface{}) {
+		gobottest.Assert(t, data.(float64), 72.5)
+		sem <- true
+	})
+	gobottest.Assert(t, d.Start(), nil)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-sem:
+		case <-time.After(1 * time.Second):
+			t.Errorf("CPUTemperature Events \"Data\" and \"HighTemperature\" were not published")
+		}
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
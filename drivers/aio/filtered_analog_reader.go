@@ -0,0 +1,176 @@
+package aio
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// FilterMean filters by the mean of the last readings
+	FilterMean = "mean"
+	// FilterMedian filters by the median of the last readings
+	FilterMedian = "median"
+	// FilterExponential filters by an exponential moving average
+	FilterExponential = "exponential"
+)
+
+type analogFilterState struct {
+	window  []int
+	average float64
+	primed  bool
+}
+
+// FilteredAnalogReader wraps an AnalogReader and filters the noise of the
+// readings per pin. It can be used in place of the wrapped AnalogReader for
+// all drivers, e.g. ADS1x15 or GroveSensors. The filtered reader should not be
+// added to the connections of a robot, the wrapped adaptor should be used instead.
+type FilteredAnalogReader struct {
+	reader     AnalogReader
+	filter     string
+	windowSize int
+	alpha      float64
+	states     map[string]*analogFilterState
+	mutex      sync.Mutex
+}
+
+// NewFilteredAnalogReader returns a new FilteredAnalogReader around the given
+// AnalogReader. By default the mean of the last 5 readings is returned.
+//
+// Optionally accepts:
+//	WithMeanFilter(int): mean of the given number of readings
+//	WithMedianFilter(int): median of the given number of readings
+//	WithExponentialFilter(float64): exponential moving average with the given smoothing factor
+func NewFilteredAnalogReader(a AnalogReader, options ...func(*FilteredAnalogReader)) *FilteredAnalogReader {
+	f := &FilteredAnalogReader{
+		reader:     a,
+		filter:     FilterMean,
+		windowSize: 5,
+		states:     make(map[string]*analogFilterState),
+	}
+
+	for _, option := range options {
+		option(f)
+	}
+
+	return f
+}
+
+// WithMeanFilter option sets the filter to the mean of the last windowSize readings.
+func WithMeanFilter(windowSize int) func(*FilteredAnalogReader) {
+	return func(f *FilteredAnalogReader) {
+		f.filter = FilterMean
+		f.windowSize = int(math.Max(float64(windowSize), 1))
+	}
+}
+
+// WithMedianFilter option sets the filter to the median of the last windowSize
+// readings, which suppresses single spikes better than the mean.
+func WithMedianFilter(windowSize int) func(*FilteredAnalogReader) {
+	return func(f *FilteredAnalogReader) {
+		f.filter = FilterMedian
+		f.windowSize = int(math.Max(float64(windowSize), 1))
+	}
+}
+
+// WithExponentialFilter option sets the filter to an exponential moving average.
+// The smoothing factor alpha must be between 0 (no change) and 1 (no filtering).
+func WithExponentialFilter(alpha float64) func(*FilteredAnalogReader) {
+	return func(f *FilteredAnalogReader) {
+		f.filter = FilterExponential
+		f.alpha = math.Min(math.Max(alpha, 0), 1)
+	}
+}
+
+// Name returns the name of the wrapped AnalogReader, if it is a gobot.Connection
+func (f *FilteredAnalogReader) Name() string {
+	if c, ok := f.reader.(gobot.Connection); ok {
+		return c.Name()
+	}
+	return ""
+}
+
+// SetName sets the name of the wrapped AnalogReader, if it is a gobot.Connection
+func (f *FilteredAnalogReader) SetName(n string) {
+	if c, ok := f.reader.(gobot.Connection); ok {
+		c.SetName(n)
+	}
+}
+
+// Connect does nothing, the wrapped AnalogReader is connected by the robot
+func (f *FilteredAnalogReader) Connect() (err error) { return }
+
+// Finalize does nothing, the wrapped AnalogReader is finalized by the robot
+func (f *FilteredAnalogReader) Finalize() (err error) { return }
+
+// AnalogRead reads the value of the pin from the wrapped AnalogReader and
+// returns the filtered value. Errors are returned without changing the filter.
+func (f *FilteredAnalogReader) AnalogRead(pin string) (val int, err error) {
+	raw, err := f.reader.AnalogRead(pin)
+	if err != nil {
+		return 0, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	state, ok := f.states[pin]
+	if !ok {
+		state = &analogFilterState{}
+		f.states[pin] = state
+	}
+
+	switch f.filter {
+	case FilterExponential:
+		if !state.primed {
+			state.average = float64(raw)
+			state.primed = true
+		} else {
+			state.average = f.alpha*float64(raw) + (1-f.alpha)*state.average
+		}
+		return int(math.Round(state.average)), nil
+	case FilterMedian:
+		state.push(raw, f.windowSize)
+		return state.median(), nil
+	default:
+		state.push(raw, f.windowSize)
+		return state.mean(), nil
+	}
+}
+
+// Reset clears the filter state of all pins.
+func (f *FilteredAnalogReader) Reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.states = make(map[string]*analogFilterState)
+}
+
+func (s *analogFilterState) push(val int, size int) {
+	s.window = append(s.window, val)
+	if len(s.window) > size {
+		s.window = s.window[len(s.window)-size:]
+	}
+}
+
+func (s *analogFilterState) mean() int {
+	sum := 0
+	for _, v := range s.window {
+		sum += v
+	}
+	return int(math.Round(float64(sum) / float64(len(s.window))))
+}
+
+func (s *analogFilterState) median() int {
+	sorted := make([]int, len(s.window))
+	copy(sorted, s.window)
+	sort.Ints(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return int(math.Round(float64(sorted[n/2-1]+sorted[n/2]) / 2))
+}
@@ -0,0 +1,86 @@
+package aio
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ AnalogReader = (*FilteredAnalogReader)(nil)
+var _ gobot.Connection = (*FilteredAnalogReader)(nil)
+
+func initTestFilteredAnalogReader(vals []int, options ...func(*FilteredAnalogReader)) *FilteredAnalogReader {
+	a := newAioTestAdaptor()
+	i := 0
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		val := vals[i%len(vals)]
+		i++
+		return val, nil
+	})
+	return NewFilteredAnalogReader(a, options...)
+}
+
+func readFiltered(f *FilteredAnalogReader, n int) (vals []int) {
+	for i := 0; i < n; i++ {
+		val, _ := f.AnalogRead("1")
+		vals = append(vals, val)
+	}
+	return
+}
+
+func TestFilteredAnalogReaderMean(t *testing.T) {
+	f := initTestFilteredAnalogReader([]int{10, 20, 30, 40}, WithMeanFilter(3))
+	gobottest.Assert(t, readFiltered(f, 4), []int{10, 15, 20, 30})
+}
+
+func TestFilteredAnalogReaderDefault(t *testing.T) {
+	f := initTestFilteredAnalogReader([]int{10, 20, 30, 40, 50, 60})
+	gobottest.Assert(t, readFiltered(f, 6), []int{10, 15, 20, 25, 30, 40})
+}
+
+func TestFilteredAnalogReaderMedian(t *testing.T) {
+	f := initTestFilteredAnalogReader([]int{10, 1000, 12, 11}, WithMedianFilter(3))
+	gobottest.Assert(t, readFiltered(f, 4), []int{10, 505, 12, 12})
+}
+
+func TestFilteredAnalogReaderExponential(t *testing.T) {
+	f := initTestFilteredAnalogReader([]int{100, 0, 0}, WithExponentialFilter(0.5))
+	gobottest.Assert(t, readFiltered(f, 3), []int{100, 50, 25})
+
+	f.Reset()
+	gobottest.Assert(t, readFiltered(f, 1), []int{100})
+}
+
+func TestFilteredAnalogReaderPins(t *testing.T) {
+	f := initTestFilteredAnalogReader([]int{10, 20}, WithMeanFilter(2))
+	val, _ := f.AnalogRead("1")
+	gobottest.Assert(t, val, 10)
+	val, _ = f.AnalogRead("2")
+	gobottest.Assert(t, val, 20)
+}
+
+func TestFilteredAnalogReaderError(t *testing.T) {
+	a := newAioTestAdaptor()
+	a.TestAdaptorAnalogRead(func() (int, error) {
+		return 0, errors.New("read error")
+	})
+	f := NewFilteredAnalogReader(a)
+	_, err := f.AnalogRead("1")
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestFilteredAnalogReaderConnection(t *testing.T) {
+	a := newAioTestAdaptor()
+	a.SetName("adaptor")
+	f := NewFilteredAnalogReader(a)
+	gobottest.Assert(t, f.Name(), "adaptor")
+	f.SetName("new")
+	gobottest.Assert(t, a.Name(), "new")
+	gobottest.Assert(t, f.Connect(), nil)
+	gobottest.Assert(t, f.Finalize(), nil)
+
+	d := NewAnalogSensorDriver(f, "1")
+	gobottest.Assert(t, d.Connection().Name(), "new")
+}
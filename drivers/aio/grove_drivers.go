@@ -1,9 +1,5 @@
 package aio
 
-import (
-	"time"
-)
-
 // GroveRotaryDriver represents an analog rotary dial with a Grove connector
 type GroveRotaryDriver struct {
 	*AnalogSensorDriver
@@ -14,10 +10,11 @@ type GroveRotaryDriver struct {
 //
 // Optionally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
-func NewGroveRotaryDriver(a AnalogReader, pin string, v ...time.Duration) *GroveRotaryDriver {
+func NewGroveRotaryDriver(a AnalogReader, pin string, v ...interface{}) *GroveRotaryDriver {
 	return &GroveRotaryDriver{
 		AnalogSensorDriver: NewAnalogSensorDriver(a, pin, v...),
 	}
@@ -34,10 +31,11 @@ type GroveLightSensorDriver struct {
 //
 // Optionally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
-func NewGroveLightSensorDriver(a AnalogReader, pin string, v ...time.Duration) *GroveLightSensorDriver {
+func NewGroveLightSensorDriver(a AnalogReader, pin string, v ...interface{}) *GroveLightSensorDriver {
 	return &GroveLightSensorDriver{
 		AnalogSensorDriver: NewAnalogSensorDriver(a, pin, v...),
 	}
@@ -54,10 +52,11 @@ type GrovePiezoVibrationSensorDriver struct {
 //
 // Optionally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
-func NewGrovePiezoVibrationSensorDriver(a AnalogReader, pin string, v ...time.Duration) *GrovePiezoVibrationSensorDriver {
+func NewGrovePiezoVibrationSensorDriver(a AnalogReader, pin string, v ...interface{}) *GrovePiezoVibrationSensorDriver {
 	sensor := &GrovePiezoVibrationSensorDriver{
 		AnalogSensorDriver: NewAnalogSensorDriver(a, pin, v...),
 	}
@@ -84,10 +83,11 @@ type GroveSoundSensorDriver struct {
 //
 // Optionally accepts:
 // 	time.Duration: Interval at which the AnalogSensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
-func NewGroveSoundSensorDriver(a AnalogReader, pin string, v ...time.Duration) *GroveSoundSensorDriver {
+func NewGroveSoundSensorDriver(a AnalogReader, pin string, v ...interface{}) *GroveSoundSensorDriver {
 	return &GroveSoundSensorDriver{
 		AnalogSensorDriver: NewAnalogSensorDriver(a, pin, v...),
 	}
@@ -1,7 +1,9 @@
 package aio
 
 import (
+	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -14,10 +16,10 @@ var _ gobot.Driver = (*GroveTemperatureSensorDriver)(nil)
 type GroveTemperatureSensorDriver struct {
 	name        string
 	pin         string
-	halt        chan bool
+	poller      *gobot.Poller
 	temperature float64
-	interval    time.Duration
 	connection  AnalogReader
+	mutex       sync.Mutex
 	gobot.Eventer
 }
 
@@ -26,21 +28,28 @@ type GroveTemperatureSensorDriver struct {
 //
 // Optionally accepts:
 // 	time.Duration: Interval at which the TemperatureSensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
 //
 // Adds the following API Commands:
 // 	"Read" - See AnalogSensor.Read
-func NewGroveTemperatureSensorDriver(a AnalogReader, pin string, v ...time.Duration) *GroveTemperatureSensorDriver {
+func NewGroveTemperatureSensorDriver(a AnalogReader, pin string, v ...interface{}) *GroveTemperatureSensorDriver {
 	d := &GroveTemperatureSensorDriver{
 		name:       gobot.DefaultName("GroveTemperatureSensor"),
 		connection: a,
 		pin:        pin,
 		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		poller:     gobot.NewPoller(10 * time.Millisecond),
 	}
 
-	if len(v) > 0 {
-		d.interval = v[0]
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for aio.NewGroveTemperatureSensorDriver", opt))
+		}
 	}
 
 	d.AddEvent(Data)
@@ -55,34 +64,32 @@ func NewGroveTemperatureSensorDriver(a AnalogReader, pin string, v ...time.Durat
 //	Error error - Event is emitted on error reading from the sensor.
 func (a *GroveTemperatureSensorDriver) Start() (err error) {
 	thermistor := 3975.0
+	a.mutex.Lock()
 	a.temperature = 0
+	a.mutex.Unlock()
+
+	a.poller.Start(func() error {
+		rawValue, err := a.Read()
+
+		resistance := float64(1023.0-rawValue) * 10000 / float64(rawValue)
+		newValue := 1/(math.Log(resistance/10000.0)/thermistor+1/298.15) - 273.15
 
-	go func() {
-		for {
-			rawValue, err := a.Read()
-
-			resistance := float64(1023.0-rawValue) * 10000 / float64(rawValue)
-			newValue := 1/(math.Log(resistance/10000.0)/thermistor+1/298.15) - 273.15
-
-			if err != nil {
-				a.Publish(Error, err)
-			} else if newValue != a.temperature && newValue != -1 {
-				a.temperature = newValue
-				a.Publish(Data, a.temperature)
-			}
-			select {
-			case <-time.After(a.interval):
-			case <-a.halt:
-				return
-			}
+		if err != nil {
+			a.Publish(Error, err)
+		} else if newValue != a.Temperature() && newValue != -1 {
+			a.mutex.Lock()
+			a.temperature = newValue
+			a.mutex.Unlock()
+			a.Publish(Data, newValue)
 		}
-	}()
+		return err
+	})
 	return
 }
 
 // Halt stops polling the analog sensor for new information
 func (a *GroveTemperatureSensorDriver) Halt() (err error) {
-	a.halt <- true
+	a.poller.Stop()
 	return
 }
 
@@ -102,9 +109,16 @@ func (a *GroveTemperatureSensorDriver) Connection() gobot.Connection {
 
 // Read returns the current Temperature from the Sensor
 func (a *GroveTemperatureSensorDriver) Temperature() (val float64) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
 	return a.temperature
 }
 
+// Telemetry returns the last temperature in celsius
+func (a *GroveTemperatureSensorDriver) Telemetry() map[string]interface{} {
+	return map[string]interface{}{"temperature": a.Temperature()}
+}
+
 // Read returns the raw reading from the Sensor
 func (a *GroveTemperatureSensorDriver) Read() (val int, err error) {
 	return a.connection.AnalogRead(a.Pin())
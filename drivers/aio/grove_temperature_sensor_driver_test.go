@@ -12,13 +12,14 @@ import (
 )
 
 var _ gobot.Driver = (*GroveTemperatureSensorDriver)(nil)
+var _ gobot.Telemeter = (*GroveTemperatureSensorDriver)(nil)
 
 func TestGroveTemperatureSensorDriver(t *testing.T) {
 	testAdaptor := newAioTestAdaptor()
 	d := NewGroveTemperatureSensorDriver(testAdaptor, "123")
 	gobottest.Assert(t, d.Connection(), testAdaptor)
 	gobottest.Assert(t, d.Pin(), "123")
-	gobottest.Assert(t, d.interval, 10*time.Millisecond)
+	gobottest.Assert(t, d.poller.Interval(), 10*time.Millisecond)
 }
 
 func TestGroveTempSensorPublishesTemperatureInCelsius(t *testing.T) {
@@ -73,17 +74,9 @@ func TestGroveTempSensorPublishesError(t *testing.T) {
 
 func TestGroveTempSensorHalt(t *testing.T) {
 	d := NewGroveTemperatureSensorDriver(newAioTestAdaptor(), "1")
-	done := make(chan struct{})
-	go func() {
-		<-d.halt
-		close(done)
-	}()
+	gobottest.Assert(t, d.Start(), nil)
 	gobottest.Assert(t, d.Halt(), nil)
-	select {
-	case <-done:
-	case <-time.After(100 * time.Millisecond):
-		t.Errorf("Grove Temperature Sensor was not halted")
-	}
+	gobottest.Assert(t, d.poller.Running(), false)
 }
 
 func TestGroveTempDriverDefaultName(t *testing.T) {
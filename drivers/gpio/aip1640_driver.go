@@ -21,53 +21,36 @@ const (
 //
 // Library ported from: https://github.com/wemos/WEMOS_Matrix_LED_Shield_Arduino_Library
 type AIP1640Driver struct {
+	*Driver
 	pinClock  *DirectPinDriver
-	pinData    *DirectPinDriver
-	name       string
-	intensity  byte
-	buffer     [8]byte
-	connection gobot.Connection
-	gobot.Commander
+	pinData   *DirectPinDriver
+	intensity byte
+	buffer    [8]byte
 }
 
 // NewAIP1640Driver return a new AIP1640Driver given a gobot.Connection and the clock, data and strobe pins
 func NewAIP1640Driver(a gobot.Connection, clockPin string, dataPin string) *AIP1640Driver {
 	t := &AIP1640Driver{
-		name:       gobot.DefaultName("AIP1640Driver"),
+		Driver:    NewDriver(a, "AIP1640Driver"),
 		pinClock:  NewDirectPinDriver(a, clockPin),
-		pinData:    NewDirectPinDriver(a, dataPin),
-		intensity:  7,
-		connection: a,
-		Commander:  gobot.NewCommander(),
+		pinData:   NewDirectPinDriver(a, dataPin),
+		intensity: 7,
 	}
+	t.afterStart = t.initialize
 
 	/* TODO : Add commands */
 
 	return t
 }
 
-// Start initializes the tm1638, it uses a SPI-like communication protocol
-func (a *AIP1640Driver) Start() (err error) {
+// initialize initializes the tm1638, it uses a SPI-like communication protocol
+func (a *AIP1640Driver) initialize() (err error) {
 	a.pinData.On()
 	a.pinClock.On()
 
 	return
 }
 
-// Halt implements the Driver interface
-func (a *AIP1640Driver) Halt() (err error) { return }
-
-// Name returns the AIP1640Drivers name
-func (a *AIP1640Driver) Name() string { return a.name }
-
-// SetName sets the AIP1640Drivers name
-func (a *AIP1640Driver) SetName(n string) { a.name = n }
-
-// Connection returns the AIP1640Driver Connection
-func (a *AIP1640Driver) Connection() gobot.Connection {
-	return a.connection
-}
-
 // SetIntensity changes the intensity (from 1 to 7) of the display
 func (a *AIP1640Driver) SetIntensity(level byte) {
 	if level >= 7 {
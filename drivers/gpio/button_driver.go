@@ -1,6 +1,7 @@
 package gpio
 
 import (
+	"fmt"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -8,89 +9,146 @@ import (
 
 // ButtonDriver Represents a digital Button
 type ButtonDriver struct {
+	*Driver
 	Active       bool
 	DefaultState int
 	pin          string
-	name         string
-	halt         chan bool
-	interval     time.Duration
-	connection   DigitalReader
+	poller       *gobot.Poller
+	debounce     time.Duration
+	longPress    time.Duration
+	doublePress  time.Duration
 	gobot.Eventer
 }
 
+// ButtonOption is an option of the ButtonDriver
+type ButtonOption func(*ButtonDriver)
+
 // NewButtonDriver returns a new ButtonDriver with a polling interval of
 // 10 Milliseconds given a DigitalReader and pin.
 //
 // Optionally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
-func NewButtonDriver(a DigitalReader, pin string, v ...time.Duration) *ButtonDriver {
+//  gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//  WithDebounce(time.Duration): Time a new state must be stable before it is accepted
+//  WithLongPress(time.Duration): Time the button must be pushed for the LongPress event
+//  WithDoublePress(time.Duration): Maximum time between two pushes for the DoublePress event
+func NewButtonDriver(a DigitalReader, pin string, v ...interface{}) *ButtonDriver {
 	b := &ButtonDriver{
-		name:         gobot.DefaultName("Button"),
-		connection:   a,
+		Driver:       NewDriver(a, "Button"),
 		pin:          pin,
 		Active:       false,
 		DefaultState: 0,
 		Eventer:      gobot.NewEventer(),
-		interval:     10 * time.Millisecond,
-		halt:         make(chan bool),
+		poller:       gobot.NewPoller(10 * time.Millisecond),
 	}
+	b.afterStart = b.initialize
+	b.beforeHalt = b.shutdown
 
-	if len(v) > 0 {
-		b.interval = v[0]
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			b.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			b.poller.Apply(o)
+		case ButtonOption:
+			o(b)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for gpio.NewButtonDriver", opt))
+		}
 	}
 
 	b.AddEvent(ButtonPush)
 	b.AddEvent(ButtonRelease)
+	b.AddEvent(ButtonLongPress)
+	b.AddEvent(ButtonDoublePress)
 	b.AddEvent(Error)
 
 	return b
 }
 
-// Start starts the ButtonDriver and polls the state of the button at the given interval.
+// WithDebounce option sets the time a new state of the button must be stable
+// before it is accepted, to suppress the bouncing of the contacts.
+func WithDebounce(d time.Duration) ButtonOption {
+	return func(b *ButtonDriver) {
+		b.debounce = d
+	}
+}
+
+// WithLongPress option enables the LongPress event, which is published when
+// the button is pushed for at least the given time.
+func WithLongPress(d time.Duration) ButtonOption {
+	return func(b *ButtonDriver) {
+		b.longPress = d
+	}
+}
+
+// WithDoublePress option enables the DoublePress event, which is published
+// when the button is pushed again within the given time after the last push.
+func WithDoublePress(d time.Duration) ButtonOption {
+	return func(b *ButtonDriver) {
+		b.doublePress = d
+	}
+}
+
+// initialize starts to poll the state of the button at the given interval.
 //
 // Emits the Events:
 // 	Push int - On button push
 //	Release int - On button release
+//	LongPress int - On button push for at least the long press time
+//	DoublePress int - On second button push within the double press time
 //	Error error - On button error
-func (b *ButtonDriver) Start() (err error) {
+func (b *ButtonDriver) initialize() (err error) {
 	state := b.DefaultState
-	go func() {
-		for {
-			newValue, err := b.connection.DigitalRead(b.Pin())
-			if err != nil {
-				b.Publish(Error, err)
-			} else if newValue != state && newValue != -1 {
+	candidate := state
+	var changed, pushed, lastPush time.Time
+	longPressed := false
+	b.poller.Start(func() error {
+		newValue, err := b.digitalRead(b.Pin())
+		now := time.Now()
+		if err != nil {
+			b.Publish(Error, err)
+		} else if newValue != state && newValue != -1 {
+			if newValue != candidate {
+				candidate = newValue
+				changed = now
+			}
+			if now.Sub(changed) >= b.debounce {
 				state = newValue
 				b.update(newValue)
+				if b.Active {
+					if b.doublePress > 0 && !lastPush.IsZero() && now.Sub(lastPush) <= b.doublePress {
+						b.Publish(ButtonDoublePress, newValue)
+						lastPush = time.Time{}
+					} else {
+						lastPush = now
+					}
+					pushed = now
+					longPressed = false
+				}
 			}
-			select {
-			case <-time.After(b.interval):
-			case <-b.halt:
-				return
-			}
+		} else if newValue == state {
+			candidate = state
 		}
-	}()
+
+		if b.longPress > 0 && b.Active && !longPressed && now.Sub(pushed) >= b.longPress {
+			longPressed = true
+			b.Publish(ButtonLongPress, state)
+		}
+		return err
+	})
 	return
 }
 
-// Halt stops polling the button for new information
-func (b *ButtonDriver) Halt() (err error) {
-	b.halt <- true
+// shutdown stops polling the button for new information
+func (b *ButtonDriver) shutdown() (err error) {
+	b.poller.Stop()
 	return
 }
 
-// Name returns the ButtonDrivers name
-func (b *ButtonDriver) Name() string { return b.name }
-
-// SetName sets the ButtonDrivers name
-func (b *ButtonDriver) SetName(n string) { b.name = n }
-
 // Pin returns the ButtonDrivers pin
 func (b *ButtonDriver) Pin() string { return b.pin }
 
-// Connection returns the ButtonDrivers Connection
-func (b *ButtonDriver) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
-
 func (b *ButtonDriver) update(newValue int) {
 	if newValue != b.DefaultState {
 		b.Active = true
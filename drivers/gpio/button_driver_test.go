@@ -3,6 +3,7 @@ package gpio
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,10 +21,9 @@ func initTestButtonDriver() *ButtonDriver {
 
 func TestButtonDriverHalt(t *testing.T) {
 	d := initTestButtonDriver()
-	go func() {
-		<-d.halt
-	}()
+	gobottest.Assert(t, d.Start(), nil)
 	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.poller.Running(), false)
 }
 
 func TestButtonDriver(t *testing.T) {
@@ -31,7 +31,10 @@ func TestButtonDriver(t *testing.T) {
 	gobottest.Refute(t, d.Connection(), nil)
 
 	d = NewButtonDriver(newGpioTestAdaptor(), "1", 30*time.Second)
-	gobottest.Assert(t, d.interval, 30*time.Second)
+	gobottest.Assert(t, d.poller.Interval(), 30*time.Second)
+
+	d = NewButtonDriver(newGpioTestAdaptor(), "1", gobot.WithPollInterval(time.Second))
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
 }
 
 func TestButtonDriverStart(t *testing.T) {
@@ -92,7 +95,7 @@ func TestButtonDriverStart(t *testing.T) {
 		sem <- true
 	})
 
-	d.halt <- true
+	gobottest.Assert(t, d.Halt(), nil)
 
 	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
 		val = 1
@@ -106,6 +109,41 @@ func TestButtonDriverStart(t *testing.T) {
 	}
 }
 
+func TestButtonDriverStartEventHelpers(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewButtonDriver(a, "1")
+
+	push := gobottest.ListenForEvent(d, ButtonPush)
+	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
+		val = 1
+		return
+	})
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, push.Wait(t, buttonTestDelay*time.Millisecond), 1)
+
+	release := gobottest.ListenForEvent(d, ButtonRelease)
+	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
+		val = 0
+		return
+	})
+	release.Wait(t, buttonTestDelay*time.Millisecond)
+
+	// the error is published by each poll, so it can't be missed
+	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
+		err = errors.New("digital read error")
+		return
+	})
+	err := gobottest.WaitForEvent(t, d, Error, buttonTestDelay*time.Millisecond)
+	gobottest.Assert(t, err, errors.New("digital read error"))
+
+	gobottest.Assert(t, d.Halt(), nil)
+	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
+		val = 1
+		return
+	})
+	gobottest.AssertNoEvent(t, d, ButtonPush, buttonTestDelay*time.Millisecond)
+}
+
 func TestButtonDriverDefaultState(t *testing.T) {
 	sem := make(chan bool, 0)
 	a := newGpioTestAdaptor()
@@ -157,3 +195,104 @@ func TestButtonDriverSetName(t *testing.T) {
 	g.SetName("mybot")
 	gobottest.Assert(t, g.Name(), "mybot")
 }
+
+func initTestButtonDriverWithSequence(seq []int, v ...interface{}) *ButtonDriver {
+	var mtx sync.Mutex
+	a := newGpioTestAdaptor()
+	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		val = seq[0]
+		if len(seq) > 1 {
+			seq = seq[1:]
+		}
+		return
+	})
+	return NewButtonDriver(a, "1", append([]interface{}{time.Millisecond}, v...)...)
+}
+
+func countButtonEvents(d *ButtonDriver, event string) func() int {
+	var mtx sync.Mutex
+	n := 0
+	d.On(event, func(data interface{}) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		n++
+	})
+	return func() int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return n
+	}
+}
+
+func TestButtonDriverOptions(t *testing.T) {
+	d := NewButtonDriver(newGpioTestAdaptor(), "1",
+		30*time.Millisecond,
+		WithDebounce(20*time.Millisecond),
+		WithLongPress(time.Second),
+		WithDoublePress(300*time.Millisecond),
+	)
+	gobottest.Assert(t, d.poller.Interval(), 30*time.Millisecond)
+	gobottest.Assert(t, d.debounce, 20*time.Millisecond)
+	gobottest.Assert(t, d.longPress, time.Second)
+	gobottest.Assert(t, d.doublePress, 300*time.Millisecond)
+
+	g := NewGroveButtonDriver(newGpioTestAdaptor(), "1", WithDebounce(10*time.Millisecond))
+	gobottest.Assert(t, g.debounce, 10*time.Millisecond)
+}
+
+func TestButtonDriverDebounce(t *testing.T) {
+	bouncing := []int{1, 0, 1, 0, 1, 0, 1, 0, 1}
+
+	d := initTestButtonDriverWithSequence(bouncing)
+	pushes := countButtonEvents(d, ButtonPush)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Halt()
+	gobottest.Assert(t, pushes() > 1, true)
+
+	d = initTestButtonDriverWithSequence(bouncing, WithDebounce(20*time.Millisecond))
+	pushes = countButtonEvents(d, ButtonPush)
+	releases := countButtonEvents(d, ButtonRelease)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Halt()
+	gobottest.Assert(t, pushes(), 1)
+	gobottest.Assert(t, releases(), 0)
+}
+
+func TestButtonDriverLongPress(t *testing.T) {
+	d := initTestButtonDriverWithSequence([]int{1}, WithLongPress(30*time.Millisecond))
+	longPresses := countButtonEvents(d, ButtonLongPress)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Halt()
+	gobottest.Assert(t, longPresses(), 1)
+
+	// released before the long press time
+	d = initTestButtonDriverWithSequence([]int{1, 1, 1, 0}, WithLongPress(30*time.Millisecond))
+	longPresses = countButtonEvents(d, ButtonLongPress)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Halt()
+	gobottest.Assert(t, longPresses(), 0)
+}
+
+func TestButtonDriverDoublePress(t *testing.T) {
+	seq := []int{1, 1, 1, 0, 0, 0, 1, 1, 1, 0}
+
+	d := initTestButtonDriverWithSequence(seq, WithDoublePress(500*time.Millisecond))
+	doublePresses := countButtonEvents(d, ButtonDoublePress)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Halt()
+	gobottest.Assert(t, doublePresses(), 1)
+
+	d = initTestButtonDriverWithSequence(seq)
+	doublePresses = countButtonEvents(d, ButtonDoublePress)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	d.Halt()
+	gobottest.Assert(t, doublePresses(), 0)
+}
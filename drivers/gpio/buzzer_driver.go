@@ -129,46 +129,42 @@ var _ gobot.Driver = (*BuzzerDriver)(nil)
 
 // BuzzerDriver represents a digital buzzer
 type BuzzerDriver struct {
-	pin        string
-	name       string
-	connection DigitalWriter
-	high       bool
-	BPM        float64
+	*Driver
+	pin    string
+	high   bool
+	stop   chan bool
+	done   chan bool
+	resume chan bool
+	paused bool
+	mutex  gobot.Mutex
+	BPM    float64
+	gobot.Eventer
 }
 
 // NewBuzzerDriver return a new BuzzerDriver given a DigitalWriter and pin.
 func NewBuzzerDriver(a DigitalWriter, pin string) *BuzzerDriver {
 	l := &BuzzerDriver{
-		name:       gobot.DefaultName("Buzzer"),
-		pin:        pin,
-		connection: a,
-		high:       false,
-		BPM:        96.0,
+		Driver:  NewDriver(a, "Buzzer"),
+		pin:     pin,
+		high:    false,
+		BPM:     96.0,
+		Eventer: gobot.NewEventer(),
 	}
+	l.beforeHalt = l.Stop
+
+	l.AddEvent(Error)
 
 	return l
 }
 
-// Start implements the Driver interface
-func (l *BuzzerDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface
-func (l *BuzzerDriver) Halt() (err error) { return }
-
-// Name returns the BuzzerDrivers name
-func (l *BuzzerDriver) Name() string { return l.name }
-
-// SetName sets the BuzzerDrivers name
-func (l *BuzzerDriver) SetName(n string) { l.name = n }
+// Contentions returns how often a caller had to wait for the driver
+func (l *BuzzerDriver) Contentions() uint64 {
+	return l.Driver.Contentions() + l.mutex.Contentions()
+}
 
 // Pin returns the BuzzerDrivers name
 func (l *BuzzerDriver) Pin() string { return l.pin }
 
-// Connection returns the BuzzerDrivers Connection
-func (l *BuzzerDriver) Connection() gobot.Connection {
-	return l.connection.(gobot.Connection)
-}
-
 // State return true if the buzzer is On and false if the led is Off
 func (l *BuzzerDriver) State() bool {
 	return l.high
@@ -176,7 +172,7 @@ func (l *BuzzerDriver) State() bool {
 
 // On sets the buzzer to a high state.
 func (l *BuzzerDriver) On() (err error) {
-	if err = l.connection.DigitalWrite(l.Pin(), 1); err != nil {
+	if err = l.digitalWrite(l.Pin(), 1); err != nil {
 		return
 	}
 	l.high = true
@@ -185,7 +181,7 @@ func (l *BuzzerDriver) On() (err error) {
 
 // Off sets the buzzer to a low state.
 func (l *BuzzerDriver) Off() (err error) {
-	if err = l.connection.DigitalWrite(l.Pin(), 0); err != nil {
+	if err = l.digitalWrite(l.Pin(), 0); err != nil {
 		return
 	}
 	l.high = false
@@ -202,13 +198,169 @@ func (l *BuzzerDriver) Toggle() (err error) {
 	return
 }
 
+// Tone plays a tone with the given frequency in Hz for the given duration in
+// beats and blocks until it is played
 func (l *BuzzerDriver) Tone(hz, duration float64) (err error) {
+	_, err = l.tone(hz, duration, l.BPM, nil)
+	return
+}
+
+// PlayRTTTL parses a melody in the Ring Tone Text Transfer Language and plays
+// it in the background, see Play
+func (l *BuzzerDriver) PlayRTTTL(rtttl string) (err error) {
+	m, err := ParseRTTTL(rtttl)
+	if err != nil {
+		return
+	}
+	return l.Play(m)
+}
+
+// Play plays the melody in the background, a playing melody is stopped before.
+// Emits the Events:
+//	Error error - Event is emitted when writing to the pin fails.
+func (l *BuzzerDriver) Play(m *BuzzerMelody) (err error) {
+	if err = l.Stop(); err != nil {
+		return
+	}
+
+	bpm := m.BPM
+	if bpm <= 0 {
+		bpm = l.BPM
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.paused = false
+	l.stop = make(chan bool)
+	l.done = make(chan bool)
+	go l.play(m.Notes, bpm, l.stop, l.done)
+	return
+}
+
+// Stop stops a playing melody and turns the buzzer off
+func (l *BuzzerDriver) Stop() (err error) {
+	l.mutex.Lock()
+	stop, done := l.stop, l.done
+	l.mutex.Unlock()
+
+	if done == nil {
+		return
+	}
+	select {
+	case stop <- true:
+	case <-done:
+	}
+	<-done
+
+	l.mutex.Lock()
+	l.paused = false
+	l.mutex.Unlock()
+	return l.Off()
+}
+
+// Pause pauses a playing melody after the current note
+func (l *BuzzerDriver) Pause() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.paused {
+		l.paused = true
+		l.resume = make(chan bool)
+	}
+}
+
+// Resume resumes a paused melody
+func (l *BuzzerDriver) Resume() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.paused {
+		l.paused = false
+		close(l.resume)
+	}
+}
+
+// IsPlaying returns whether a melody is playing, also if it is paused
+func (l *BuzzerDriver) IsPlaying() bool {
+	l.mutex.Lock()
+	done := l.done
+	l.mutex.Unlock()
+
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Wait blocks until the playing melody is finished or stopped
+func (l *BuzzerDriver) Wait() {
+	l.mutex.Lock()
+	done := l.done
+	l.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+func (l *BuzzerDriver) play(notes []BuzzerNote, bpm float64, stop chan bool, done chan bool) {
+	defer close(done)
+
+	for _, note := range notes {
+		l.mutex.Lock()
+		paused, resume := l.paused, l.resume
+		l.mutex.Unlock()
+
+		if paused {
+			select {
+			case <-resume:
+			case <-stop:
+				return
+			}
+		}
+
+		var stopped bool
+		var err error
+		if note.Frequency == Rest {
+			select {
+			case <-time.After(time.Duration(60 / bpm * note.Duration * float64(time.Second))):
+			case <-stop:
+				stopped = true
+			}
+		} else {
+			stopped, err = l.tone(note.Frequency, note.Duration, bpm, stop)
+		}
+
+		if err != nil {
+			l.Publish(l.Event(Error), err)
+			return
+		}
+		if stopped {
+			return
+		}
+	}
+}
+
+// tone plays a tone and returns early if stop is signaled
+func (l *BuzzerDriver) tone(hz, duration, bpm float64, stop chan bool) (stopped bool, err error) {
 	// calculation based off https://www.arduino.cc/en/Tutorial/Melody
 	tone := (1.0 / (2.0 * hz)) * 1000000.0
 
-	tempo := ((60 / l.BPM) * (duration * 1000))
+	tempo := ((60 / bpm) * (duration * 1000))
 
 	for i := 0.0; i < tempo*1000; i += tone * 2.0 {
+		select {
+		case <-stop:
+			return true, nil
+		default:
+		}
+
 		if err = l.On(); err != nil {
 			return
 		}
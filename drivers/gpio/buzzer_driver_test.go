@@ -2,14 +2,18 @@ package gpio
 
 import (
 	"errors"
+	"math"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*BuzzerDriver)(nil)
+var _ gobot.ContentionCounter = (*BuzzerDriver)(nil)
 
 func initTestBuzzerDriver(conn DigitalWriter) *BuzzerDriver {
 	return NewBuzzerDriver(conn, "1")
@@ -36,6 +40,23 @@ func TestBuzzerDriverHalt(t *testing.T) {
 	gobottest.Assert(t, d.Halt(), nil)
 }
 
+func TestBuzzerDriverContentions(t *testing.T) {
+	d := initTestBuzzerDriver(newGpioTestAdaptor())
+	gobottest.Assert(t, d.Contentions(), uint64(0))
+
+	// the lock of the buzzer is counted in addition to the one of the base driver
+	d.mutex.Lock()
+	paused := make(chan bool)
+	go func() {
+		d.Pause()
+		close(paused)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	d.mutex.Unlock()
+	<-paused
+	gobottest.Assert(t, d.Contentions(), uint64(1))
+}
+
 func TestBuzzerDriverToggle(t *testing.T) {
 	d := initTestBuzzerDriver(newGpioTestAdaptor())
 	d.Off()
@@ -79,3 +100,110 @@ func TestBuzzerDriverToneError(t *testing.T) {
 
 	gobottest.Assert(t, d.Tone(100, 0.01), errors.New("write error"))
 }
+
+func TestBuzzerDriverPlay(t *testing.T) {
+	var mtx sync.Mutex
+	writes := 0
+	a := newGpioTestAdaptor()
+	a.TestAdaptorDigitalWrite(func(string, byte) (err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		writes++
+		return
+	})
+	d := initTestBuzzerDriver(a)
+
+	m := &BuzzerMelody{BPM: 6000, Notes: []BuzzerNote{{C5, Quarter}, {Rest, Quarter}, {E5, Eighth}}}
+	gobottest.Assert(t, d.Play(m), nil)
+	gobottest.Assert(t, d.IsPlaying(), true)
+	d.Wait()
+	gobottest.Assert(t, d.IsPlaying(), false)
+
+	mtx.Lock()
+	gobottest.Assert(t, writes > 2, true)
+	mtx.Unlock()
+
+	gobottest.Assert(t, d.PlayRTTTL("Beep:d=8,o=5,b=6000:c,p,e"), nil)
+	d.Wait()
+	gobottest.Refute(t, d.PlayRTTTL("Beep"), nil)
+}
+
+func TestBuzzerDriverStopPauseResume(t *testing.T) {
+	d := initTestBuzzerDriver(newGpioTestAdaptor())
+
+	gobottest.Assert(t, d.PlayRTTTL("Long:d=1,o=5,b=30:c,d,e"), nil)
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, d.Stop(), nil)
+	gobottest.Assert(t, d.IsPlaying(), false)
+	gobottest.Assert(t, d.State(), false)
+
+	d.Pause()
+	gobottest.Assert(t, d.PlayRTTTL("Short:d=32,o=5,b=6000:c,d"), nil)
+	d.Pause()
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, d.IsPlaying(), true)
+	d.Resume()
+	d.Wait()
+	gobottest.Assert(t, d.IsPlaying(), false)
+
+	gobottest.Assert(t, d.PlayRTTTL("Short:d=32,o=5,b=6000:c,d"), nil)
+	d.Pause()
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.IsPlaying(), false)
+}
+
+func TestBuzzerDriverPlayError(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := initTestBuzzerDriver(a)
+	a.TestAdaptorDigitalWrite(func(string, byte) (err error) {
+		return errors.New("write error")
+	})
+
+	sem := make(chan bool, 1)
+	d.Once(d.Event(Error), func(data interface{}) {
+		gobottest.Assert(t, data.(error), errors.New("write error"))
+		sem <- true
+	})
+	gobottest.Assert(t, d.Play(&BuzzerMelody{Notes: []BuzzerNote{{C4, Eighth}}}), nil)
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("Buzzer Event \"Error\" was not published")
+	}
+}
+
+func TestParseRTTTL(t *testing.T) {
+	m, err := ParseRTTTL("The Simpsons:d=4,o=5,b=160:c.6,e6,f#6,8a6,g.6,8p,32b,a#4.")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.Name, "The Simpsons")
+	gobottest.Assert(t, m.BPM, 160.0)
+	gobottest.Assert(t, len(m.Notes), 8)
+
+	round := func(f float64) float64 { return math.Round(f*100) / 100 }
+	gobottest.Assert(t, round(m.Notes[0].Frequency), C6)
+	gobottest.Assert(t, m.Notes[0].Duration, 1.5)
+	gobottest.Assert(t, round(m.Notes[1].Frequency), E6)
+	gobottest.Assert(t, round(m.Notes[2].Frequency), Gb6)
+	gobottest.Assert(t, round(m.Notes[3].Frequency), A6)
+	gobottest.Assert(t, m.Notes[3].Duration, 0.5)
+	gobottest.Assert(t, m.Notes[5], BuzzerNote{Rest, 0.5})
+	gobottest.Assert(t, round(m.Notes[6].Frequency), B5)
+	gobottest.Assert(t, m.Notes[6].Duration, 0.125)
+	gobottest.Assert(t, round(m.Notes[7].Frequency), Bb4)
+	gobottest.Assert(t, m.Notes[7].Duration, 1.5)
+
+	// defaults
+	m, err = ParseRTTTL("x::c")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.BPM, 63.0)
+	gobottest.Assert(t, round(m.Notes[0].Frequency), C6)
+	gobottest.Assert(t, m.Notes[0].Duration, 1.0)
+}
+
+func TestParseRTTTLErrors(t *testing.T) {
+	for _, s := range []string{"x:d=4", "x:d=a:c", "x:q=4:c", "x:d4:c", "x::x", "x::4", "x::c$", "x::0c"} {
+		_, err := ParseRTTTL(s)
+		gobottest.Refute(t, err, nil)
+	}
+}
@@ -0,0 +1,122 @@
+package gpio
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BuzzerNote is a single note of a melody, the frequency is in Hz (Rest for a
+// pause) and the duration is in beats, like the arguments of BuzzerDriver.Tone
+type BuzzerNote struct {
+	Frequency float64
+	Duration  float64
+}
+
+// BuzzerMelody is a sequence of notes. If BPM is 0, the BPM of the
+// BuzzerDriver is used.
+type BuzzerMelody struct {
+	Name  string
+	BPM   float64
+	Notes []BuzzerNote
+}
+
+var rtttlSemitones = map[byte]int{'c': 0, 'd': 2, 'e': 4, 'f': 5, 'g': 7, 'a': 9, 'b': 11, 'h': 11}
+
+// ParseRTTTL parses a melody in the Ring Tone Text Transfer Language, e.g.
+// "Beep:d=8,o=5,b=120:c,p,4c6". The beat of the melody is a quarter note.
+func ParseRTTTL(s string) (m *BuzzerMelody, err error) {
+	sections := strings.SplitN(s, ":", 3)
+	if len(sections) != 3 {
+		return nil, fmt.Errorf("RTTTL must consist of name, defaults and notes separated by ':'")
+	}
+
+	m = &BuzzerMelody{Name: strings.TrimSpace(sections[0]), BPM: 63}
+	duration, octave := 4, 6
+	for _, def := range strings.Split(sections[1], ",") {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+		kv := strings.SplitN(def, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("Invalid RTTTL default '%s'", def)
+		}
+		val, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid RTTTL default '%s'", def)
+		}
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "d":
+			duration = val
+		case "o":
+			octave = val
+		case "b":
+			m.BPM = float64(val)
+		default:
+			return nil, fmt.Errorf("Invalid RTTTL default '%s'", def)
+		}
+	}
+
+	for _, token := range strings.Split(sections[2], ",") {
+		token = strings.ToLower(strings.TrimSpace(token))
+		if token == "" {
+			continue
+		}
+		note, err := parseRTTTLNote(token, duration, octave)
+		if err != nil {
+			return nil, err
+		}
+		m.Notes = append(m.Notes, note)
+	}
+	return m, nil
+}
+
+// parseRTTTLNote parses a note like "8c#6." given the default duration and octave
+func parseRTTTLNote(token string, duration int, octave int) (note BuzzerNote, err error) {
+	i := 0
+	for i < len(token) && token[i] >= '0' && token[i] <= '9' {
+		i++
+	}
+	if i > 0 {
+		duration, _ = strconv.Atoi(token[:i])
+	}
+	if duration <= 0 || i >= len(token) {
+		return note, fmt.Errorf("Invalid RTTTL note '%s'", token)
+	}
+
+	semitone, ok := rtttlSemitones[token[i]]
+	rest := token[i] == 'p'
+	if !ok && !rest {
+		return note, fmt.Errorf("Invalid RTTTL note '%s'", token)
+	}
+	i++
+
+	if i < len(token) && token[i] == '#' {
+		semitone++
+		i++
+	}
+
+	dotted := false
+	for ; i < len(token); i++ {
+		switch {
+		case token[i] == '.':
+			dotted = true
+		case token[i] >= '0' && token[i] <= '9':
+			octave = int(token[i] - '0')
+		default:
+			return note, fmt.Errorf("Invalid RTTTL note '%s'", token)
+		}
+	}
+
+	// a whole note lasts 4 beats
+	note.Duration = 4 / float64(duration)
+	if dotted {
+		note.Duration *= 1.5
+	}
+	if !rest {
+		note.Frequency = 440 * math.Pow(2, float64(semitone-9)/12+float64(octave-4))
+	}
+	return
+}
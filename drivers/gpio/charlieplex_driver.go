@@ -0,0 +1,242 @@
+package gpio
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// CharlieplexDriver represents an array of N*(N-1) LEDs which are multiplexed
+// over N pins. Only one LED is lit at a time, so the LEDs are scanned by a
+// refresh goroutine. Unused pins are switched to input (high impedance), so
+// the adaptor must provide DigitalPins with a configurable direction.
+//
+// The LEDs are numbered by anode and cathode pin, LED 0 has its anode at
+// pin 0 and its cathode at pin 1, LED 1 its anode at pin 0 and its cathode at
+// pin 2 and so on, so LED n*(N-1)+m has its anode at pin n.
+type CharlieplexDriver struct {
+	*Driver
+	pins    []string
+	slot    time.Duration
+	levels  []byte
+	digital []gobot.DigitalPinner
+	halt    chan bool
+	done    chan bool
+	mutex   gobot.Mutex
+}
+
+// NewCharlieplexDriver returns a new CharlieplexDriver given a
+// DigitalPinnerProvider and the pins of the array.
+//
+// Optionally accepts:
+// 	time.Duration: Time slot of each lit LED within a refresh cycle, default 1ms
+//
+// Adds the following API Commands:
+// 	"On" - See CharlieplexDriver.On
+// 	"Off" - See CharlieplexDriver.Off
+// 	"Brightness" - See CharlieplexDriver.Brightness
+// 	"Clear" - See CharlieplexDriver.Clear
+func NewCharlieplexDriver(a gobot.DigitalPinnerProvider, pins []string, v ...time.Duration) *CharlieplexDriver {
+	c := &CharlieplexDriver{
+		Driver: NewDriver(a, "Charlieplex"),
+		pins:   pins,
+		slot:   time.Millisecond,
+		levels: make([]byte, len(pins)*(len(pins)-1)),
+	}
+	c.afterStart = c.initialize
+	c.beforeHalt = c.shutdown
+
+	if len(v) > 0 {
+		c.slot = v[0]
+	}
+
+	c.AddCommand("On", func(params map[string]interface{}) interface{} {
+		return c.On(int(params["led"].(float64)))
+	})
+	c.AddCommandSchema("On", gobot.CommandSchema{"led": gobot.CommandParamNumber})
+	c.AddCommand("Off", func(params map[string]interface{}) interface{} {
+		return c.Off(int(params["led"].(float64)))
+	})
+	c.AddCommandSchema("Off", gobot.CommandSchema{"led": gobot.CommandParamNumber})
+	c.AddCommand("Brightness", func(params map[string]interface{}) interface{} {
+		return c.Brightness(int(params["led"].(float64)), byte(params["level"].(float64)))
+	})
+	c.AddCommandSchema("Brightness", gobot.CommandSchema{"led": gobot.CommandParamNumber, "level": gobot.CommandParamNumber})
+	c.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		c.Clear()
+		return nil
+	})
+
+	return c
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (c *CharlieplexDriver) Contentions() uint64 {
+	return c.Driver.Contentions() + c.mutex.Contentions()
+}
+
+// initialize switches all pins to input and starts the refresh goroutine
+func (c *CharlieplexDriver) initialize() (err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.pins) < 2 {
+		return fmt.Errorf("Charlieplexing needs at least 2 pins")
+	}
+
+	c.digital = make([]gobot.DigitalPinner, len(c.pins))
+	for i, pin := range c.pins {
+		if c.digital[i], err = c.connection.(gobot.DigitalPinnerProvider).DigitalPin(pin, pinIn); err != nil {
+			return
+		}
+	}
+
+	c.halt = make(chan bool)
+	c.done = make(chan bool)
+	go c.refresh(c.halt, c.done)
+	return
+}
+
+// shutdown stops the refresh goroutine and turns all LEDs off
+func (c *CharlieplexDriver) shutdown() (err error) {
+	c.mutex.Lock()
+	halt, done := c.halt, c.done
+	c.mutex.Unlock()
+
+	if done == nil {
+		return
+	}
+	halt <- true
+	<-done
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.done = nil
+	for _, p := range c.digital {
+		if err = p.Direction(pinIn); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// LEDCount returns the number of LEDs of the array
+func (c *CharlieplexDriver) LEDCount() int { return len(c.levels) }
+
+// On turns the LED on with full brightness
+func (c *CharlieplexDriver) On(led int) (err error) {
+	return c.Brightness(led, 255)
+}
+
+// Off turns the LED off
+func (c *CharlieplexDriver) Off(led int) (err error) {
+	return c.Brightness(led, 0)
+}
+
+// Brightness sets the brightness of the LED between 0 (off) and 255 (full),
+// which is the part of its time slot the LED is lit
+func (c *CharlieplexDriver) Brightness(led int, level byte) (err error) {
+	if led < 0 || led >= len(c.levels) {
+		return fmt.Errorf("LED %d is out of range, the array has %d LEDs", led, len(c.levels))
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.levels[led] = level
+	return
+}
+
+// Level returns the brightness of the LED
+func (c *CharlieplexDriver) Level(led int) byte {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if led < 0 || led >= len(c.levels) {
+		return 0
+	}
+	return c.levels[led]
+}
+
+// Clear turns all LEDs off
+func (c *CharlieplexDriver) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i := range c.levels {
+		c.levels[i] = 0
+	}
+}
+
+// charlieplexPins returns the anode and cathode pin of the LED
+func charlieplexPins(led int, pins int) (anode int, cathode int) {
+	anode = led / (pins - 1)
+	cathode = led % (pins - 1)
+	if cathode >= anode {
+		cathode++
+	}
+	return
+}
+
+func (c *CharlieplexDriver) refresh(halt chan bool, done chan bool) {
+	defer close(done)
+
+	anode, cathode := -1, -1
+	release := func() {
+		if anode >= 0 {
+			c.digital[anode].Direction(pinIn)
+			c.digital[cathode].Direction(pinIn)
+			anode, cathode = -1, -1
+		}
+	}
+	defer release()
+
+	wait := func(d time.Duration) bool {
+		select {
+		case <-time.After(d):
+			return true
+		case <-halt:
+			return false
+		}
+	}
+
+	levels := make([]byte, len(c.levels))
+	for {
+		c.mutex.Lock()
+		copy(levels, c.levels)
+		c.mutex.Unlock()
+
+		lit := false
+		for led, level := range levels {
+			if level == 0 {
+				continue
+			}
+			lit = true
+
+			release()
+			anode, cathode = charlieplexPins(led, len(c.pins))
+			c.digital[cathode].Direction(pinOut)
+			c.digital[cathode].Write(pinLow)
+			c.digital[anode].Direction(pinOut)
+			c.digital[anode].Write(pinHigh)
+
+			on := time.Duration(int64(c.slot) * int64(level) / 255)
+			if !wait(on) {
+				return
+			}
+			if on < c.slot {
+				release()
+				if !wait(c.slot - on) {
+					return
+				}
+			}
+		}
+
+		if !lit {
+			release()
+			if !wait(c.slot) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*CharlieplexDriver)(nil)
+
+type charlieplexTestPin struct {
+	a   *charlieplexTestAdaptor
+	pin string
+}
+
+func (p *charlieplexTestPin) Export() error   { return nil }
+func (p *charlieplexTestPin) Unexport() error { return nil }
+func (p *charlieplexTestPin) Read() (int, error) {
+	return 0, nil
+}
+func (p *charlieplexTestPin) Direction(dir string) error {
+	p.a.mtx.Lock()
+	defer p.a.mtx.Unlock()
+	p.a.dirs[p.pin] = dir
+	return nil
+}
+func (p *charlieplexTestPin) Write(val int) error {
+	p.a.mtx.Lock()
+	defer p.a.mtx.Unlock()
+	p.a.levels[p.pin] = val
+	// remember which LED was lit, given by anode and cathode pin
+	if val == pinHigh {
+		for pin, dir := range p.a.dirs {
+			if pin != p.pin && dir == pinOut && p.a.levels[pin] == pinLow {
+				p.a.lit[p.pin+pin] = true
+			}
+		}
+	}
+	return nil
+}
+
+type charlieplexTestAdaptor struct {
+	gpioTestBareAdaptor
+	mtx    sync.Mutex
+	dirs   map[string]string
+	levels map[string]int
+	lit    map[string]bool
+	err    error
+}
+
+func (a *charlieplexTestAdaptor) DigitalPin(pin string, dir string) (gobot.DigitalPinner, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	p := &charlieplexTestPin{a: a, pin: pin}
+	p.Direction(dir)
+	return p, nil
+}
+
+func (a *charlieplexTestAdaptor) litLEDs() map[string]bool {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	lit := a.lit
+	a.lit = make(map[string]bool)
+	return lit
+}
+
+func initTestCharlieplexDriver() (*CharlieplexDriver, *charlieplexTestAdaptor) {
+	a := &charlieplexTestAdaptor{
+		dirs:   make(map[string]string),
+		levels: make(map[string]int),
+		lit:    make(map[string]bool),
+	}
+	return NewCharlieplexDriver(a, []string{"a", "b", "c"}, 100*time.Microsecond), a
+}
+
+func TestCharlieplexDriver(t *testing.T) {
+	d, a := initTestCharlieplexDriver()
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.LEDCount(), 6)
+	gobottest.Assert(t, d.slot, 100*time.Microsecond)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "Charlieplex"), true)
+
+	d.SetName("status")
+	gobottest.Assert(t, d.Name(), "status")
+
+	d = NewCharlieplexDriver(a, []string{"a", "b", "c", "d"})
+	gobottest.Assert(t, d.LEDCount(), 12)
+	gobottest.Assert(t, d.slot, time.Millisecond)
+}
+
+func TestCharlieplexPins(t *testing.T) {
+	var pins [][2]int
+	for led := 0; led < 6; led++ {
+		anode, cathode := charlieplexPins(led, 3)
+		pins = append(pins, [2]int{anode, cathode})
+	}
+	gobottest.Assert(t, pins, [][2]int{{0, 1}, {0, 2}, {1, 0}, {1, 2}, {2, 0}, {2, 1}})
+}
+
+func TestCharlieplexDriverLevels(t *testing.T) {
+	d, _ := initTestCharlieplexDriver()
+	gobottest.Assert(t, d.On(1), nil)
+	gobottest.Assert(t, d.Level(1), byte(255))
+	gobottest.Assert(t, d.Brightness(2, 100), nil)
+	gobottest.Assert(t, d.Level(2), byte(100))
+	gobottest.Assert(t, d.Off(1), nil)
+	gobottest.Assert(t, d.Level(1), byte(0))
+	gobottest.Refute(t, d.On(6), nil)
+	gobottest.Refute(t, d.Brightness(-1, 1), nil)
+	gobottest.Assert(t, d.Level(6), byte(0))
+
+	gobottest.Assert(t, d.Command("On")(map[string]interface{}{"led": 3.0}), nil)
+	gobottest.Assert(t, d.Level(3), byte(255))
+	gobottest.Assert(t, d.Command("Brightness")(map[string]interface{}{"led": 4.0, "level": 20.0}), nil)
+	gobottest.Assert(t, d.Level(4), byte(20))
+	d.Command("Clear")(nil)
+	gobottest.Assert(t, d.Level(2), byte(0))
+	gobottest.Assert(t, d.Level(3), byte(0))
+}
+
+func TestCharlieplexDriverRefresh(t *testing.T) {
+	d, a := initTestCharlieplexDriver()
+	d.On(0)
+	d.Brightness(5, 128)
+	gobottest.Assert(t, d.Start(), nil)
+
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, a.litLEDs(), map[string]bool{"ab": true, "cb": true})
+
+	d.Clear()
+	d.On(2)
+	time.Sleep(20 * time.Millisecond)
+	a.litLEDs()
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, a.litLEDs(), map[string]bool{"ba": true})
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	a.mtx.Lock()
+	gobottest.Assert(t, a.dirs, map[string]string{"a": pinIn, "b": pinIn, "c": pinIn})
+	a.mtx.Unlock()
+}
+
+func TestCharlieplexDriverStartError(t *testing.T) {
+	d, a := initTestCharlieplexDriver()
+	a.err = errors.New("pin error")
+	gobottest.Assert(t, d.Start(), errors.New("pin error"))
+
+	d = NewCharlieplexDriver(a, []string{"a"})
+	gobottest.Refute(t, d.Start(), nil)
+}
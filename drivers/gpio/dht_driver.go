@@ -0,0 +1,310 @@
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
+)
+
+const (
+	// DHT11 sensor type
+	DHT11 = "dht11"
+	// DHT22 sensor type, also used for the AM2302
+	DHT22 = "dht22"
+)
+
+const (
+	// DHTTemperature event, published with the changed temperature as
+	// sensor.Measurement in celsius degrees
+	DHTTemperature = "temperature"
+	// DHTHumidity event, published with the changed relative humidity as
+	// sensor.Measurement in percent
+	DHTHumidity = "humidity"
+)
+
+const (
+	// dhtMaxSamples is the number of equal samples after which the pin is
+	// considered to be idle
+	dhtMaxSamples = 10000
+	// dhtMaxTransmission is the time after which the sampling is stopped, the
+	// answer of the sensor takes less than 5ms
+	dhtMaxTransmission = 10 * time.Millisecond
+	// dhtMaxSamplePeriod is the longest average time between two samples, which
+	// still distinguishes the 26-28us and 70us high phases of the bits
+	dhtMaxSamplePeriod = 20 * time.Microsecond
+)
+
+var (
+	// ErrDHTNoResponse is the error resulting when the sensor does not send the
+	// expected number of bits
+	ErrDHTNoResponse = errors.New("DHT sensor did not respond with 40 bits")
+	// ErrDHTChecksum is the error resulting when the received checksum does not match
+	ErrDHTChecksum = errors.New("DHT checksum mismatch")
+	// ErrDHTSamplingTooSlow is the error resulting when the pin can not be read
+	// fast enough to decode the bits, e.g. a pin of the sysfs gpio interface
+	ErrDHTSamplingTooSlow = errors.New("DHT pin sampling is too slow")
+)
+
+type dhtRun struct {
+	level   int
+	samples int
+}
+
+// DHTDriver represents a DHT11 or DHT22 temperature and humidity sensor, which
+// uses a single wire protocol. The bits are decoded by comparing the length of
+// the high phases with the length of the 50us low phases in between, so no
+// absolute timing is needed, but the adaptor must provide pins which can be
+// read much faster than every 20us, e.g. by memory mapped gpio registers.
+// Pins of the sysfs gpio interface take milliseconds for a read, with them
+// Read returns ErrDHTSamplingTooSlow.
+type DHTDriver struct {
+	*Driver
+	pin         string
+	sensorType  string
+	poller      *gobot.Poller
+	retries     int
+	retryDelay  time.Duration
+	temperature float64
+	humidity    float64
+	mutex       gobot.Mutex
+	gobot.Eventer
+}
+
+// NewDHTDriver returns a new DHTDriver with a polling interval of 2 seconds
+// given a DigitalPinnerProvider, pin and sensor type (DHT11 or DHT22).
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the sensor is polled for new information
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//
+// Adds the following API Commands:
+// 	"Read" - See DHTDriver.Read
+func NewDHTDriver(a gobot.DigitalPinnerProvider, pin string, sensorType string, v ...interface{}) *DHTDriver {
+	d := &DHTDriver{
+		Driver:     NewDriver(a, "DHT"),
+		pin:        pin,
+		sensorType: sensorType,
+		poller:     gobot.NewPoller(2 * time.Second),
+		retries:    3,
+		retryDelay: time.Second,
+		Eventer:    gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for gpio.NewDHTDriver", opt))
+		}
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(DHTTemperature)
+	d.AddEvent(DHTHumidity)
+	d.AddEvent(Error)
+
+	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		temperature, humidity, err := d.Read()
+		return map[string]interface{}{"temperature": temperature, "humidity": humidity, "err": err}
+	})
+
+	return d
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (d *DHTDriver) Contentions() uint64 {
+	return d.Driver.Contentions() + d.mutex.Contentions()
+}
+
+// Pin returns the DHTDrivers pin
+func (d *DHTDriver) Pin() string { return d.pin }
+
+// initialize starts to read the sensor at the given interval.
+// Emits the Events:
+//	Data map[string]float64 - Event is emitted on change and contains the "temperature" in celsius and the relative "humidity" in percent.
+//	DHTTemperature sensor.Measurement - Event is emitted on change of the temperature.
+//	DHTHumidity sensor.Measurement - Event is emitted on change of the relative humidity.
+//	Error error - Event is emitted on error reading from the sensor.
+func (d *DHTDriver) initialize() (err error) {
+	var temperature, humidity float64
+	d.poller.Start(func() error {
+		newTemperature, newHumidity, err := d.Read()
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+		} else if newTemperature != temperature || newHumidity != humidity {
+			if newTemperature != temperature {
+				d.Publish(d.Event(DHTTemperature), sensor.NewMeasurement(newTemperature, sensor.Celsius))
+			}
+			if newHumidity != humidity {
+				d.Publish(d.Event(DHTHumidity), sensor.NewMeasurement(newHumidity, sensor.Percent))
+			}
+			temperature, humidity = newTemperature, newHumidity
+			d.Publish(d.Event(Data), map[string]float64{"temperature": temperature, "humidity": humidity})
+		}
+		return err
+	})
+	return
+}
+
+// shutdown stops polling the sensor
+func (d *DHTDriver) shutdown() (err error) {
+	d.poller.Stop()
+	return
+}
+
+// Temperature returns the last temperature read in celsius
+func (d *DHTDriver) Temperature() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.temperature
+}
+
+// Humidity returns the last relative humidity read in percent
+func (d *DHTDriver) Humidity() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.humidity
+}
+
+// Telemetry returns the last temperature in celsius and relative humidity in
+// percent
+func (d *DHTDriver) Telemetry() map[string]interface{} {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return map[string]interface{}{
+		DHTTemperature: d.temperature,
+		DHTHumidity:    d.humidity,
+	}
+}
+
+// Read reads the temperature in celsius and the relative humidity in percent.
+// Failed transmissions are retried, because they are common with this protocol.
+func (d *DHTDriver) Read() (temperature float64, humidity float64, err error) {
+	for i := 0; i <= d.retries; i++ {
+		if i > 0 {
+			time.Sleep(d.retryDelay)
+		}
+		temperature, humidity, err = d.read()
+		if err == nil || err == ErrDHTSamplingTooSlow {
+			return
+		}
+	}
+	return
+}
+
+// read does a single transmission, the mutex is held only for its duration
+// and not during the delay between the retries
+func (d *DHTDriver) read() (temperature float64, humidity float64, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data, err := d.readData()
+	if err != nil {
+		return 0, 0, err
+	}
+	if temperature, humidity, err = d.decode(data); err == nil {
+		d.temperature, d.humidity = temperature, humidity
+	}
+	return
+}
+
+// readData sends the start signal and samples the 40 bits sent by the sensor
+func (d *DHTDriver) readData() (data []byte, err error) {
+	pin, err := d.connection.(gobot.DigitalPinnerProvider).DigitalPin(d.pin, pinOut)
+	if err != nil {
+		return
+	}
+
+	start := 18 * time.Millisecond
+	if d.sensorType == DHT22 {
+		start = 1100 * time.Microsecond
+	}
+	if err = pin.Write(pinLow); err != nil {
+		return
+	}
+	time.Sleep(start)
+	if err = pin.Write(pinHigh); err != nil {
+		return
+	}
+	if err = pin.Direction(pinIn); err != nil {
+		return
+	}
+
+	var runs []dhtRun
+	samples := 0
+	begin := time.Now()
+	for len(runs) < 100 && time.Since(begin) < dhtMaxTransmission {
+		val, err := pin.Read()
+		if err != nil {
+			return nil, err
+		}
+		samples++
+		if len(runs) > 0 && runs[len(runs)-1].level == val {
+			runs[len(runs)-1].samples++
+			if runs[len(runs)-1].samples > dhtMaxSamples {
+				break
+			}
+			continue
+		}
+		runs = append(runs, dhtRun{level: val, samples: 1})
+	}
+
+	if time.Since(begin)/time.Duration(samples) > dhtMaxSamplePeriod {
+		return nil, ErrDHTSamplingTooSlow
+	}
+	return dhtBits(runs)
+}
+
+// dhtBits decodes the runs of equal samples, which end with 40 pairs of low
+// and high phases, the final low phase and the idle high level
+func dhtBits(runs []dhtRun) (data []byte, err error) {
+	if len(runs) < 82 {
+		return nil, ErrDHTNoResponse
+	}
+
+	bits := runs[len(runs)-82 : len(runs)-2]
+	data = make([]byte, 5)
+	for i := 0; i < 40; i++ {
+		low, high := bits[2*i], bits[2*i+1]
+		if low.level != pinLow || high.level != pinHigh {
+			return nil, ErrDHTNoResponse
+		}
+		data[i/8] <<= 1
+		if high.samples > low.samples {
+			data[i/8] |= 1
+		}
+	}
+	return
+}
+
+// decode validates the checksum and converts the data of the sensor type
+func (d *DHTDriver) decode(data []byte) (temperature float64, humidity float64, err error) {
+	if data[0]+data[1]+data[2]+data[3] != data[4] {
+		return 0, 0, ErrDHTChecksum
+	}
+
+	if d.sensorType == DHT22 {
+		humidity = float64(uint16(data[0])<<8|uint16(data[1])) / 10
+		temperature = float64(uint16(data[2]&0x7f)<<8|uint16(data[3])) / 10
+		if data[2]&0x80 != 0 {
+			temperature = -temperature
+		}
+		return
+	}
+
+	humidity = float64(data[0]) + float64(data[1])/10
+	temperature = float64(data[2]) + float64(data[3]&0x7f)/10
+	if data[3]&0x80 != 0 {
+		temperature = -temperature
+	}
+	return
+}
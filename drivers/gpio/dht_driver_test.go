@@ -0,0 +1,251 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*DHTDriver)(nil)
+var _ gobot.Telemeter = (*DHTDriver)(nil)
+
+// dhtTestPin returns the samples of the sensor answer after the start signal
+type dhtTestPin struct {
+	samples []int
+	writes  []int
+	dir     string
+	delay   time.Duration
+}
+
+func (p *dhtTestPin) Export() error   { return nil }
+func (p *dhtTestPin) Unexport() error { return nil }
+func (p *dhtTestPin) Direction(dir string) error {
+	p.dir = dir
+	return nil
+}
+func (p *dhtTestPin) Write(val int) error {
+	p.writes = append(p.writes, val)
+	return nil
+}
+func (p *dhtTestPin) Read() (int, error) {
+	time.Sleep(p.delay)
+	if len(p.samples) == 0 {
+		return pinHigh, nil
+	}
+	val := p.samples[0]
+	p.samples = p.samples[1:]
+	return val, nil
+}
+
+type dhtTestAdaptor struct {
+	gpioTestBareAdaptor
+	answers [][]byte
+	pin     *dhtTestPin
+	err     error
+	delay   time.Duration
+}
+
+func (a *dhtTestAdaptor) DigitalPin(pin string, dir string) (gobot.DigitalPinner, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	a.pin = &dhtTestPin{dir: dir, delay: a.delay}
+	if len(a.answers) > 0 {
+		a.pin.samples = dhtTestSamples(a.answers[0])
+		a.answers = a.answers[1:]
+	}
+	return a.pin, nil
+}
+
+func dhtTestSamples(data []byte) (samples []int) {
+	add := func(level int, n int) {
+		for i := 0; i < n; i++ {
+			samples = append(samples, level)
+		}
+	}
+	// pull up, response and 40 bits with 27us (0) or 70us (1) high phases
+	add(1, 3)
+	add(0, 16)
+	add(1, 16)
+	for _, b := range data {
+		for i := 7; i >= 0; i-- {
+			add(0, 10)
+			if b>>uint(i)&1 == 1 {
+				add(1, 14)
+			} else {
+				add(1, 5)
+			}
+		}
+	}
+	add(0, 10)
+	return
+}
+
+func initTestDHTDriver(sensorType string, answers ...[]byte) (*DHTDriver, *dhtTestAdaptor) {
+	a := &dhtTestAdaptor{answers: answers}
+	d := NewDHTDriver(a, "7", sensorType)
+	d.retryDelay = time.Millisecond
+	return d, a
+}
+
+func TestDHTDriver(t *testing.T) {
+	d, a := initTestDHTDriver(DHT22)
+	gobottest.Assert(t, d.Pin(), "7")
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.poller.Interval(), 2*time.Second)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DHT"), true)
+
+	d.SetName("climate")
+	gobottest.Assert(t, d.Name(), "climate")
+
+	d = NewDHTDriver(a, "7", DHT11, 5*time.Second)
+	gobottest.Assert(t, d.poller.Interval(), 5*time.Second)
+}
+
+func TestDHTDriverUnsupportedOption(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover(), "unsupported option int for gpio.NewDHTDriver")
+	}()
+	NewDHTDriver(&dhtTestAdaptor{}, "7", DHT11, 2000)
+}
+
+func TestDHTDriverReadDHT22(t *testing.T) {
+	// 65.2 %, -10.1 C
+	d, a := initTestDHTDriver(DHT22, []byte{0x02, 0x8c, 0x80, 0x65, 0x73})
+
+	temperature, humidity, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temperature, -10.1)
+	gobottest.Assert(t, humidity, 65.2)
+	gobottest.Assert(t, d.Temperature(), -10.1)
+	gobottest.Assert(t, d.Humidity(), 65.2)
+	gobottest.Assert(t, a.pin.writes, []int{pinLow, pinHigh})
+	gobottest.Assert(t, a.pin.dir, pinIn)
+}
+
+func TestDHTDriverTelemetry(t *testing.T) {
+	// 65.2 %, -10.1 C
+	d, _ := initTestDHTDriver(DHT22, []byte{0x02, 0x8c, 0x80, 0x65, 0x73})
+
+	_, _, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.Telemetry(), map[string]interface{}{DHTTemperature: -10.1, DHTHumidity: 65.2})
+}
+
+func TestDHTDriverReadDHT11(t *testing.T) {
+	// 45.0 %, 23.5 C
+	d, _ := initTestDHTDriver(DHT11, []byte{45, 0, 23, 5, 73})
+
+	ret := d.Command("Read")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["temperature"], 23.5)
+	gobottest.Assert(t, ret["humidity"], 45.0)
+	gobottest.Assert(t, ret["err"], nil)
+}
+
+func TestDHTDriverReadRetries(t *testing.T) {
+	d, _ := initTestDHTDriver(DHT11, []byte{45, 0, 23, 5, 0}, []byte{45, 0, 23, 5, 73})
+
+	temperature, _, err := d.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temperature, 23.5)
+}
+
+func TestDHTDriverReadErrors(t *testing.T) {
+	d, a := initTestDHTDriver(DHT11,
+		[]byte{45, 0, 23, 5, 0},
+		[]byte{45, 0, 23, 5, 0},
+		[]byte{45, 0, 23, 5, 0},
+		[]byte{45, 0, 23, 5, 0},
+	)
+	_, _, err := d.Read()
+	gobottest.Assert(t, err, ErrDHTChecksum)
+
+	_, _, err = d.Read()
+	gobottest.Assert(t, err, ErrDHTNoResponse)
+
+	a.err = errors.New("pin error")
+	_, _, err = d.Read()
+	gobottest.Assert(t, err, errors.New("pin error"))
+}
+
+func TestDHTDriverReadTooSlow(t *testing.T) {
+	d, a := initTestDHTDriver(DHT11, []byte{45, 0, 23, 5, 73}, []byte{45, 0, 23, 5, 73})
+	a.delay = 100 * time.Microsecond
+
+	_, _, err := d.Read()
+	gobottest.Assert(t, err, ErrDHTSamplingTooSlow)
+	// not retried
+	gobottest.Assert(t, len(a.answers), 1)
+}
+
+func TestDHTDriverReadRetryUnlocked(t *testing.T) {
+	d, _ := initTestDHTDriver(DHT11, []byte{45, 0, 23, 5, 0})
+	d.retries = 1
+	d.retryDelay = time.Second
+	go d.Read()
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan bool)
+	go func() {
+		d.Temperature()
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Errorf("DHT mutex is held during the retry delay")
+	}
+}
+
+func TestDHTDriverStart(t *testing.T) {
+	sem := make(chan bool, 1)
+	d, _ := initTestDHTDriver(DHT11, []byte{45, 0, 23, 5, 73})
+	d.poller.Apply(gobot.WithPollInterval(time.Millisecond))
+
+	d.Once(d.Event(Data), func(data interface{}) {
+		gobottest.Assert(t, data.(map[string]float64)["temperature"], 23.5)
+		sem <- true
+	})
+	gobottest.Assert(t, d.Start(), nil)
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("DHT Event \"Data\" was not published")
+	}
+
+	d.Once(d.Event(Error), func(data interface{}) {
+		gobottest.Assert(t, data.(error), ErrDHTNoResponse)
+		sem <- true
+	})
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("DHT Event \"Error\" was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestDHTDriverStartMeasurements(t *testing.T) {
+	d, _ := initTestDHTDriver(DHT11, []byte{45, 0, 23, 5, 73})
+	d.poller.Apply(gobot.WithPollInterval(time.Millisecond))
+	temperature := gobottest.ListenForEvent(d, DHTTemperature)
+	humidity := gobottest.ListenForEvent(d, DHTHumidity)
+
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	m := temperature.Wait(t, time.Second).(sensor.Measurement)
+	gobottest.Assert(t, m.Value, 23.5)
+	gobottest.Assert(t, m.Unit, sensor.Celsius)
+	m = humidity.Wait(t, time.Second).(sensor.Measurement)
+	gobottest.Assert(t, m.Value, 45.0)
+	gobottest.Assert(t, m.Unit, sensor.Percent)
+}
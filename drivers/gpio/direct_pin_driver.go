@@ -8,10 +8,8 @@ import (
 
 // DirectPinDriver represents a GPIO pin
 type DirectPinDriver struct {
-	name       string
-	pin        string
-	connection gobot.Connection
-	gobot.Commander
+	*Driver
+	pin string
 }
 
 // NewDirectPinDriver return a new DirectPinDriver given a Connection and pin.
@@ -24,10 +22,8 @@ type DirectPinDriver struct {
 // 	"ServoWrite" - See DirectPinDriver.ServoWrite
 func NewDirectPinDriver(a gobot.Connection, pin string) *DirectPinDriver {
 	d := &DirectPinDriver{
-		name:       gobot.DefaultName("DirectPin"),
-		connection: a,
-		pin:        pin,
-		Commander:  gobot.NewCommander(),
+		Driver: NewDriver(a, "DirectPin"),
+		pin:    pin,
 	}
 
 	d.AddCommand("DigitalRead", func(params map[string]interface{}) interface{} {
@@ -47,77 +43,43 @@ func NewDirectPinDriver(a gobot.Connection, pin string) *DirectPinDriver {
 		return d.ServoWrite(byte(level))
 	})
 
+	levelSchema := gobot.CommandSchema{"level": gobot.CommandParamString}
+	d.AddCommandSchema("DigitalWrite", levelSchema)
+	d.AddCommandSchema("PwmWrite", levelSchema)
+	d.AddCommandSchema("ServoWrite", levelSchema)
+
 	return d
 }
 
-// Name returns the DirectPinDrivers name
-func (d *DirectPinDriver) Name() string { return d.name }
-
-// SetName sets the DirectPinDrivers name
-func (d *DirectPinDriver) SetName(n string) { d.name = n }
-
 // Pin returns the DirectPinDrivers pin
 func (d *DirectPinDriver) Pin() string { return d.pin }
 
-// Connection returns the DirectPinDrivers Connection
-func (d *DirectPinDriver) Connection() gobot.Connection { return d.connection }
-
-// Start implements the Driver interface
-func (d *DirectPinDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface
-func (d *DirectPinDriver) Halt() (err error) { return }
-
 // Turn Off pin
 func (d *DirectPinDriver) Off() (err error) {
-	if writer, ok := d.Connection().(DigitalWriter); ok {
-		return writer.DigitalWrite(d.Pin(), byte(0))
-	}
-	err = ErrDigitalWriteUnsupported
-	return
+	return d.digitalWrite(d.Pin(), byte(0))
 }
 
 // Turn On pin
 func (d *DirectPinDriver) On() (err error) {
-	if writer, ok := d.Connection().(DigitalWriter); ok {
-		return writer.DigitalWrite(d.Pin(), byte(1))
-	}
-	err = ErrDigitalWriteUnsupported
-	return
+	return d.digitalWrite(d.Pin(), byte(1))
 }
 
 // DigitalRead returns the current digital state of the pin
 func (d *DirectPinDriver) DigitalRead() (val int, err error) {
-	if reader, ok := d.Connection().(DigitalReader); ok {
-		return reader.DigitalRead(d.Pin())
-	}
-	err = ErrDigitalReadUnsupported
-	return
+	return d.digitalRead(d.Pin())
 }
 
 // DigitalWrite writes to the pin. Acceptable values are 1 or 0
 func (d *DirectPinDriver) DigitalWrite(level byte) (err error) {
-	if writer, ok := d.Connection().(DigitalWriter); ok {
-		return writer.DigitalWrite(d.Pin(), level)
-	}
-	err = ErrDigitalWriteUnsupported
-	return
+	return d.digitalWrite(d.Pin(), level)
 }
 
 // PwmWrite writes the 0-254 value to the specified pin
 func (d *DirectPinDriver) PwmWrite(level byte) (err error) {
-	if writer, ok := d.Connection().(PwmWriter); ok {
-		return writer.PwmWrite(d.Pin(), level)
-	}
-	err = ErrPwmWriteUnsupported
-	return
+	return d.pwmWrite(d.Pin(), level)
 }
 
 // ServoWrite writes value to the specified pin
 func (d *DirectPinDriver) ServoWrite(level byte) (err error) {
-	if writer, ok := d.Connection().(ServoWriter); ok {
-		return writer.ServoWrite(d.Pin(), level)
-	}
-	err = ErrServoWriteUnsupported
-	return
+	return d.servoWrite(d.Pin(), level)
 }
@@ -0,0 +1,94 @@
+package gpio
+
+import (
+	"gobot.io/x/gobot"
+)
+
+// Driver implements the interface gobot.Driver and is the base of the gpio
+// drivers. It holds the name, the connection and the commands of a driver,
+// including the parameter schemas of the commands.
+// A driver adds its own initialization and finalization by the afterStart
+// and beforeHalt hooks, which are called with the mutex locked.
+type Driver struct {
+	name string
+	// connection is the adaptor as given to the constructor of the driver,
+	// mostly a DigitalWriter or a DigitalReader
+	connection interface{}
+	afterStart func() error
+	beforeHalt func() error
+	gobot.Commander
+	gobot.CommandSchemer
+	mutex *gobot.Mutex
+}
+
+// NewDriver creates a new base driver with the default name based on the
+// given name and the connection.
+func NewDriver(a interface{}, name string) *Driver {
+	return &Driver{
+		name:           gobot.DefaultName(name),
+		connection:     a,
+		afterStart:     func() error { return nil },
+		beforeHalt:     func() error { return nil },
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		mutex:          &gobot.Mutex{},
+	}
+}
+
+// Name returns the name of the driver
+func (d *Driver) Name() string { return d.name }
+
+// SetName sets the name of the driver
+func (d *Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the driver
+func (d *Driver) Connection() gobot.Connection {
+	return d.connection.(gobot.Connection)
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (d *Driver) Contentions() uint64 { return d.mutex.Contentions() }
+
+// Start initializes the driver
+func (d *Driver) Start() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.afterStart()
+}
+
+// Halt finalizes the driver
+func (d *Driver) Halt() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.beforeHalt()
+}
+
+func (d *Driver) digitalRead(pin string) (int, error) {
+	if reader, ok := d.connection.(DigitalReader); ok {
+		return reader.DigitalRead(pin)
+	}
+	return 0, ErrDigitalReadUnsupported
+}
+
+func (d *Driver) digitalWrite(pin string, val byte) error {
+	if writer, ok := d.connection.(DigitalWriter); ok {
+		return writer.DigitalWrite(pin, val)
+	}
+	return ErrDigitalWriteUnsupported
+}
+
+func (d *Driver) pwmWrite(pin string, level byte) error {
+	if writer, ok := d.connection.(PwmWriter); ok {
+		return writer.PwmWrite(pin, level)
+	}
+	return ErrPwmWriteUnsupported
+}
+
+func (d *Driver) servoWrite(pin string, level byte) error {
+	if writer, ok := d.connection.(ServoWriter); ok {
+		return writer.ServoWrite(pin, level)
+	}
+	return ErrServoWriteUnsupported
+}
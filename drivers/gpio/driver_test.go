@@ -0,0 +1,83 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*Driver)(nil)
+
+var _ gobot.ContentionCounter = (*Driver)(nil)
+
+var _ gobot.CommandSchemer = (*Driver)(nil)
+
+func TestNewDriver(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewDriver(a, "Test")
+
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "Test"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	d.SetName("foobot")
+	gobottest.Assert(t, d.Name(), "foobot")
+}
+
+func TestDriverHooks(t *testing.T) {
+	d := NewDriver(newGpioTestAdaptor(), "Test")
+	var calls []string
+	d.afterStart = func() error {
+		calls = append(calls, "start")
+		return errors.New("start error")
+	}
+	d.beforeHalt = func() error {
+		calls = append(calls, "halt")
+		return errors.New("halt error")
+	}
+
+	gobottest.Assert(t, d.Start(), errors.New("start error"))
+	gobottest.Assert(t, d.Halt(), errors.New("halt error"))
+	gobottest.Assert(t, calls, []string{"start", "halt"})
+}
+
+func TestDriverWrites(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewDriver(a, "Test")
+	var written []string
+	record := func(kind string) func(string, byte) error {
+		return func(pin string, val byte) error {
+			written = append(written, kind+":"+pin+"="+string('0'+val))
+			return nil
+		}
+	}
+	a.TestAdaptorDigitalWrite(record("digital"))
+	a.TestAdaptorPwmWrite(record("pwm"))
+	a.TestAdaptorServoWrite(record("servo"))
+	a.TestAdaptorDigitalRead(func(pin string) (int, error) {
+		return 1, nil
+	})
+
+	gobottest.Assert(t, d.digitalWrite("1", 1), nil)
+	gobottest.Assert(t, d.pwmWrite("2", 2), nil)
+	gobottest.Assert(t, d.servoWrite("3", 3), nil)
+	gobottest.Assert(t, written, []string{"digital:1=1", "pwm:2=2", "servo:3=3"})
+
+	val, err := d.digitalRead("4")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+}
+
+func TestDriverUnsupported(t *testing.T) {
+	d := NewDriver(&gpioTestBareAdaptor{}, "Test")
+
+	_, err := d.digitalRead("1")
+	gobottest.Assert(t, err, ErrDigitalReadUnsupported)
+	gobottest.Assert(t, d.digitalWrite("1", 1), ErrDigitalWriteUnsupported)
+	gobottest.Assert(t, d.pwmWrite("1", 1), ErrPwmWriteUnsupported)
+	gobottest.Assert(t, d.servoWrite("1", 1), ErrServoWriteUnsupported)
+}
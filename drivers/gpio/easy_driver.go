@@ -10,14 +10,12 @@ import (
 
 // EasyDriver object
 type EasyDriver struct {
-	gobot.Commander
+	*Driver
 
-	name       string
-	connection DigitalWriter
-	stepPin    string
-	dirPin     string
-	enPin      string
-	sleepPin   string
+	stepPin  string
+	dirPin   string
+	enPin    string
+	sleepPin string
 
 	angle    float32
 	rpm      uint
@@ -39,13 +37,11 @@ type EasyDriver struct {
 // angle - Step angle of motor
 func NewEasyDriver(a DigitalWriter, angle float32, stepPin string, dirPin string, enPin string, sleepPin string) *EasyDriver {
 	d := &EasyDriver{
-		Commander:  gobot.NewCommander(),
-		name:       gobot.DefaultName("EasyDriver"),
-		connection: a,
-		stepPin:    stepPin,
-		dirPin:     dirPin,
-		enPin:      enPin,
-		sleepPin:   sleepPin,
+		Driver:   NewDriver(a, "EasyDriver"),
+		stepPin:  stepPin,
+		dirPin:   dirPin,
+		enPin:    enPin,
+		sleepPin: sleepPin,
 
 		angle:    angle,
 		rpm:      1,
@@ -53,6 +49,7 @@ func NewEasyDriver(a DigitalWriter, angle float32, stepPin string, dirPin string
 		enabled:  true,
 		sleeping: false,
 	}
+	d.beforeHalt = d.shutdown
 
 	// panic if step pin isn't set
 	if stepPin == "" {
@@ -66,6 +63,7 @@ func NewEasyDriver(a DigitalWriter, angle float32, stepPin string, dirPin string
 		degs, _ := strconv.Atoi(params["degs"].(string))
 		return d.Move(degs)
 	})
+	d.AddCommandSchema("Move", gobot.CommandSchema{"degs": gobot.CommandParamString})
 	d.AddCommand("Step", func(params map[string]interface{}) interface{} {
 		return d.Step()
 	})
@@ -79,20 +77,8 @@ func NewEasyDriver(a DigitalWriter, angle float32, stepPin string, dirPin string
 	return d
 }
 
-// Name of EasyDriver
-func (d *EasyDriver) Name() string { return d.name }
-
-// SetName sets name for EasyDriver
-func (d *EasyDriver) SetName(n string) { d.name = n }
-
-// Connection returns EasyDriver's connection
-func (d *EasyDriver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
-
-// Start implements the Driver interface
-func (d *EasyDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface; stops running the stepper
-func (d *EasyDriver) Halt() (err error) {
+// shutdown stops running the stepper
+func (d *EasyDriver) shutdown() (err error) {
 	d.Stop()
 	return
 }
@@ -126,11 +112,11 @@ func (d *EasyDriver) Step() (err error) {
 	stepsPerRev := d.GetMaxSpeed()
 
 	// a valid steps occurs for a low to high transition
-	d.connection.DigitalWrite(d.stepPin, 0)
+	d.digitalWrite(d.stepPin, 0)
 	// 1 minute / steps per revolution / revolutions per minute
 	// let's keep it as Microseconds so we only have to do integer math
 	time.Sleep(time.Duration(60*1000*1000/stepsPerRev/d.rpm) * time.Microsecond)
-	d.connection.DigitalWrite(d.stepPin, 1)
+	d.digitalWrite(d.stepPin, 1)
 
 	// increment or decrement the number of steps by 1
 	d.stepNum += int(d.dir)
@@ -171,10 +157,10 @@ func (d *EasyDriver) SetDirection(dir string) (err error) {
 
 	if dir == "ccw" {
 		d.dir = -1
-		d.connection.DigitalWrite(d.dirPin, 1) // high is ccw
+		d.digitalWrite(d.dirPin, 1) // high is ccw
 	} else { // default to cw, even if user specified wrong value
 		d.dir = 1
-		d.connection.DigitalWrite(d.dirPin, 0) // low is cw
+		d.digitalWrite(d.dirPin, 0) // low is cw
 	}
 
 	return
@@ -216,7 +202,7 @@ func (d *EasyDriver) Enable() (err error) {
 	}
 
 	d.enabled = true
-	d.connection.DigitalWrite(d.enPin, 0) // enPin is active low
+	d.digitalWrite(d.enPin, 0) // enPin is active low
 
 	return
 }
@@ -232,7 +218,7 @@ func (d *EasyDriver) Disable() (err error) {
 	d.Stop()
 
 	d.enabled = false
-	d.connection.DigitalWrite(d.enPin, 1) // enPin is active low
+	d.digitalWrite(d.enPin, 1) // enPin is active low
 
 	return
 }
@@ -253,7 +239,7 @@ func (d *EasyDriver) Sleep() (err error) {
 	d.Stop()
 
 	d.sleeping = true
-	d.connection.DigitalWrite(d.sleepPin, 0) // sleepPin is active low
+	d.digitalWrite(d.sleepPin, 0) // sleepPin is active low
 
 	return
 }
@@ -266,7 +252,7 @@ func (d *EasyDriver) Wake() (err error) {
 	}
 
 	d.sleeping = false
-	d.connection.DigitalWrite(d.sleepPin, 1) // sleepPin is active low
+	d.digitalWrite(d.sleepPin, 1) // sleepPin is active low
 
 	// we need to wait 1ms after sleeping before doing a step to charge the step pump (according to data sheet)
 	// this will ensure that happens
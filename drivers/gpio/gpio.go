@@ -32,6 +32,10 @@ const (
 	ButtonRelease = "release"
 	// ButtonPush event
 	ButtonPush = "push"
+	// ButtonLongPress event
+	ButtonLongPress = "long-press"
+	// ButtonDoublePress event
+	ButtonDoublePress = "double-press"
 	// Data event
 	Data = "data"
 	// Vibration event
@@ -40,6 +44,22 @@ const (
 	MotionDetected = "motion-detected"
 	// MotionStopped event
 	MotionStopped = "motion-stopped"
+	// MotionStart event
+	MotionStart = "motion-start"
+	// MotionStop event
+	MotionStop = "motion-stop"
+	// StepperMoveDone event
+	StepperMoveDone = "move-done"
+	// HD44780WriteDone event
+	HD44780WriteDone = "write-done"
+)
+
+// directions and levels of a gobot.DigitalPinner
+const (
+	pinIn   = "in"
+	pinOut  = "out"
+	pinLow  = 0
+	pinHigh = 1
 )
 
 // PwmWriter interface represents an Adaptor which has Pwm capabilities
@@ -57,6 +77,12 @@ type DigitalWriter interface {
 	DigitalWrite(string, byte) (err error)
 }
 
+// DigitalPortWriter interface represents an Adaptor, e.g. a port expander,
+// which can write several digital pins with a single transaction
+type DigitalPortWriter interface {
+	DigitalWritePins(pins []string, vals []byte) (err error)
+}
+
 // DigitalReader interface represents an Adaptor which has DigitalRead capabilities
 type DigitalReader interface {
 	DigitalRead(string) (val int, err error)
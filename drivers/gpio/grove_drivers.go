@@ -1,9 +1,5 @@
 package gpio
 
-import (
-	"time"
-)
-
 // GroveRelayDriver represents a Relay with a Grove connector
 type GroveRelayDriver struct {
 	*RelayDriver
@@ -63,7 +59,8 @@ type GroveButtonDriver struct {
 //
 // Optionally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
-func NewGroveButtonDriver(a DigitalReader, pin string, v ...time.Duration) *GroveButtonDriver {
+//  ButtonOption: See NewButtonDriver
+func NewGroveButtonDriver(a DigitalReader, pin string, v ...interface{}) *GroveButtonDriver {
 	return &GroveButtonDriver{
 		ButtonDriver: NewButtonDriver(a, pin, v...),
 	}
@@ -80,7 +77,8 @@ type GroveTouchDriver struct {
 //
 // Optionally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
-func NewGroveTouchDriver(a DigitalReader, pin string, v ...time.Duration) *GroveTouchDriver {
+//  ButtonOption: See NewButtonDriver
+func NewGroveTouchDriver(a DigitalReader, pin string, v ...interface{}) *GroveTouchDriver {
 	return &GroveTouchDriver{
 		ButtonDriver: NewButtonDriver(a, pin, v...),
 	}
@@ -97,7 +95,8 @@ type GroveMagneticSwitchDriver struct {
 //
 // Optionally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
-func NewGroveMagneticSwitchDriver(a DigitalReader, pin string, v ...time.Duration) *GroveMagneticSwitchDriver {
+//  ButtonOption: See NewButtonDriver
+func NewGroveMagneticSwitchDriver(a DigitalReader, pin string, v ...interface{}) *GroveMagneticSwitchDriver {
 	return &GroveMagneticSwitchDriver{
 		ButtonDriver: NewButtonDriver(a, pin, v...),
 	}
@@ -0,0 +1,314 @@
+package gpio
+
+import (
+	"math"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// HBridgeForward is the state of a motor turning forward
+	HBridgeForward = "forward"
+	// HBridgeBackward is the state of a motor turning backward
+	HBridgeBackward = "backward"
+	// HBridgeBrake is the state of a motor with shorted windings
+	HBridgeBrake = "brake"
+	// HBridgeCoast is the state of a motor with disconnected windings
+	HBridgeCoast = "coast"
+)
+
+// HBridgeMotorDriver represents a DC motor behind one channel of a H-bridge
+// driver board like the L298N or TB6612FNG, which is controlled by the
+// direction inputs IN1 and IN2 and an optional PWM (enable) input.
+// Without PWM pin, the PWM signal for the speed is written to IN1 or IN2,
+// as needed for the DRV8833 or a L298N with jumpered enable input.
+type HBridgeMotorDriver struct {
+	*Driver
+	in1Pin     string
+	in2Pin     string
+	pwmPin     string
+	standbyPin string
+	state      string
+	speed      byte
+	mutex      gobot.Mutex
+}
+
+// NewHBridgeMotorDriver returns a new HBridgeMotorDriver given a DigitalWriter
+// and the IN1 and IN2 pins. The speed needs a PwmWriter.
+//
+// Optionally accepts:
+// 	string: PWM (enable) pin, like ENA of the L298N or PWMA of the TB6612FNG
+//
+// Adds the following API Commands:
+// 	"Forward" - See HBridgeMotorDriver.Forward
+// 	"Backward" - See HBridgeMotorDriver.Backward
+// 	"Brake" - See HBridgeMotorDriver.Brake
+// 	"Coast" - See HBridgeMotorDriver.Coast
+func NewHBridgeMotorDriver(a DigitalWriter, in1Pin string, in2Pin string, v ...string) *HBridgeMotorDriver {
+	m := &HBridgeMotorDriver{
+		Driver: NewDriver(a, "HBridgeMotor"),
+		in1Pin: in1Pin,
+		in2Pin: in2Pin,
+		state:  HBridgeCoast,
+	}
+	m.afterStart = m.initialize
+	m.beforeHalt = m.shutdown
+
+	if len(v) > 0 {
+		m.pwmPin = v[0]
+	}
+
+	m.AddCommand("Forward", func(params map[string]interface{}) interface{} {
+		return m.Forward(byte(params["speed"].(float64)))
+	})
+	m.AddCommandSchema("Forward", gobot.CommandSchema{"speed": gobot.CommandParamNumber})
+	m.AddCommand("Backward", func(params map[string]interface{}) interface{} {
+		return m.Backward(byte(params["speed"].(float64)))
+	})
+	m.AddCommandSchema("Backward", gobot.CommandSchema{"speed": gobot.CommandParamNumber})
+	m.AddCommand("Brake", func(params map[string]interface{}) interface{} {
+		return m.Brake()
+	})
+	m.AddCommand("Coast", func(params map[string]interface{}) interface{} {
+		return m.Coast()
+	})
+
+	return m
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (m *HBridgeMotorDriver) Contentions() uint64 {
+	return m.Driver.Contentions() + m.mutex.Contentions()
+}
+
+// SetStandbyPin sets the active low standby pin, like STBY of the TB6612FNG,
+// which is written on Start and Halt
+func (m *HBridgeMotorDriver) SetStandbyPin(pin string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.standbyPin = pin
+}
+
+// initialize leaves the standby mode, if a standby pin is set, and lets the motor coast
+func (m *HBridgeMotorDriver) initialize() (err error) {
+	if err = m.standby(1); err != nil {
+		return
+	}
+	return m.Coast()
+}
+
+// shutdown lets the motor coast and enters the standby mode, if a standby pin is set
+func (m *HBridgeMotorDriver) shutdown() (err error) {
+	if err = m.Coast(); err != nil {
+		return
+	}
+	return m.standby(0)
+}
+
+// Forward turns the motor forward with the given speed between 0 and 255
+func (m *HBridgeMotorDriver) Forward(speed byte) (err error) {
+	return m.drive(HBridgeForward, speed)
+}
+
+// Backward turns the motor backward with the given speed between 0 and 255
+func (m *HBridgeMotorDriver) Backward(speed byte) (err error) {
+	return m.drive(HBridgeBackward, speed)
+}
+
+// SetSpeed turns the motor forward for positive and backward for negative
+// speeds between -255 and 255, 0 lets the motor coast
+func (m *HBridgeMotorDriver) SetSpeed(speed int) (err error) {
+	switch {
+	case speed > 0:
+		return m.Forward(byte(math.Min(float64(speed), 255)))
+	case speed < 0:
+		return m.Backward(byte(math.Min(float64(-speed), 255)))
+	}
+	return m.Coast()
+}
+
+// Brake stops the motor actively by shorting its windings
+func (m *HBridgeMotorDriver) Brake() (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if err = m.write(1, 1, 255); err != nil {
+		return
+	}
+	m.state, m.speed = HBridgeBrake, 0
+	return
+}
+
+// Coast lets the motor run out freely by disconnecting its windings
+func (m *HBridgeMotorDriver) Coast() (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if err = m.write(0, 0, 0); err != nil {
+		return
+	}
+	m.state, m.speed = HBridgeCoast, 0
+	return
+}
+
+// State returns one of HBridgeForward, HBridgeBackward, HBridgeBrake or HBridgeCoast
+func (m *HBridgeMotorDriver) State() string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.state
+}
+
+// Speed returns the current speed between 0 and 255
+func (m *HBridgeMotorDriver) Speed() byte {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.speed
+}
+
+func (m *HBridgeMotorDriver) drive(state string, speed byte) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var in1, in2 byte = 1, 0
+	if state == HBridgeBackward {
+		in1, in2 = 0, 1
+	}
+	if err = m.write(in1, in2, speed); err != nil {
+		return
+	}
+	m.state, m.speed = state, speed
+	return
+}
+
+// write sets the direction inputs and the speed, without PWM pin the speed is
+// written to the active direction input
+func (m *HBridgeMotorDriver) write(in1, in2 byte, speed byte) (err error) {
+	if m.pwmPin != "" {
+		if err = m.digitalWrite(m.in1Pin, in1); err != nil {
+			return
+		}
+		if err = m.digitalWrite(m.in2Pin, in2); err != nil {
+			return
+		}
+		return m.pwmWrite(m.pwmPin, speed)
+	}
+
+	if in1 == in2 || speed == 255 {
+		if err = m.digitalWrite(m.in1Pin, in1); err != nil {
+			return
+		}
+		return m.digitalWrite(m.in2Pin, in2)
+	}
+	if in1 == 1 {
+		if err = m.digitalWrite(m.in2Pin, 0); err != nil {
+			return
+		}
+		return m.pwmWrite(m.in1Pin, speed)
+	}
+	if err = m.digitalWrite(m.in1Pin, 0); err != nil {
+		return
+	}
+	return m.pwmWrite(m.in2Pin, speed)
+}
+
+func (m *HBridgeMotorDriver) standby(level byte) (err error) {
+	m.mutex.Lock()
+	pin := m.standbyPin
+	m.mutex.Unlock()
+
+	if pin == "" {
+		return
+	}
+	return m.digitalWrite(pin, level)
+}
+
+// DifferentialDriveDriver represents the two motors of a differential drive
+// robot, which is steered by driving the left and right wheels with different speeds.
+type DifferentialDriveDriver struct {
+	*Driver
+	left  *HBridgeMotorDriver
+	right *HBridgeMotorDriver
+}
+
+// NewDifferentialDriveDriver returns a new DifferentialDriveDriver given the
+// motor drivers of the left and right wheels. The connection of the driver is
+// the one of the left motor.
+//
+// Adds the following API Commands:
+// 	"Drive" - See DifferentialDriveDriver.Drive
+// 	"Stop" - See DifferentialDriveDriver.Stop
+func NewDifferentialDriveDriver(left *HBridgeMotorDriver, right *HBridgeMotorDriver) *DifferentialDriveDriver {
+	d := &DifferentialDriveDriver{
+		Driver: NewDriver(left.connection, "DifferentialDrive"),
+		left:   left,
+		right:  right,
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	d.AddCommand("Drive", func(params map[string]interface{}) interface{} {
+		return d.Drive(params["throttle"].(float64), params["turn"].(float64))
+	})
+	d.AddCommandSchema("Drive", gobot.CommandSchema{"throttle": gobot.CommandParamNumber, "turn": gobot.CommandParamNumber})
+	d.AddCommand("Stop", func(params map[string]interface{}) interface{} {
+		return d.Stop()
+	})
+
+	return d
+}
+
+// initialize starts both motors
+func (d *DifferentialDriveDriver) initialize() (err error) {
+	if err = d.left.Start(); err != nil {
+		return
+	}
+	return d.right.Start()
+}
+
+// shutdown halts both motors
+func (d *DifferentialDriveDriver) shutdown() (err error) {
+	if err = d.left.Halt(); err != nil {
+		return
+	}
+	return d.right.Halt()
+}
+
+// Left returns the motor driver of the left wheel
+func (d *DifferentialDriveDriver) Left() *HBridgeMotorDriver { return d.left }
+
+// Right returns the motor driver of the right wheel
+func (d *DifferentialDriveDriver) Right() *HBridgeMotorDriver { return d.right }
+
+// Drive mixes the throttle (-1 backward to 1 forward) and the turn (-1 left to
+// 1 right) into the speeds of both wheels. The speeds are scaled down
+// proportionally if one of them exceeds the maximum.
+func (d *DifferentialDriveDriver) Drive(throttle float64, turn float64) (err error) {
+	left := throttle + turn
+	right := throttle - turn
+	if max := math.Max(math.Abs(left), math.Abs(right)); max > 1 {
+		left /= max
+		right /= max
+	}
+	return d.Tank(left, right)
+}
+
+// Tank sets the speeds of the left and right wheels between -1 and 1
+func (d *DifferentialDriveDriver) Tank(left float64, right float64) (err error) {
+	if err = d.left.SetSpeed(int(math.Round(left * 255))); err != nil {
+		return
+	}
+	return d.right.SetSpeed(int(math.Round(right * 255)))
+}
+
+// Spin turns the robot in place, clockwise for positive speeds between -1 and 1
+func (d *DifferentialDriveDriver) Spin(speed float64) (err error) {
+	return d.Tank(speed, -speed)
+}
+
+// Stop brakes both motors
+func (d *DifferentialDriveDriver) Stop() (err error) {
+	if err = d.left.Brake(); err != nil {
+		return
+	}
+	return d.right.Brake()
+}
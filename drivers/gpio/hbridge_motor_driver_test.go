@@ -0,0 +1,177 @@
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*HBridgeMotorDriver)(nil)
+var _ gobot.Driver = (*DifferentialDriveDriver)(nil)
+
+func initTestHBridgeMotorDriver(pwmPin ...string) (*HBridgeMotorDriver, *gpioTestAdaptor, *[]string) {
+	var writes []string
+	a := newGpioTestAdaptor()
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		writes = append(writes, fmt.Sprintf("%s=%d", pin, val))
+		return
+	})
+	a.TestAdaptorPwmWrite(func(pin string, val byte) (err error) {
+		writes = append(writes, fmt.Sprintf("%s~%d", pin, val))
+		return
+	})
+	return NewHBridgeMotorDriver(a, "in1", "in2", pwmPin...), a, &writes
+}
+
+func TestHBridgeMotorDriver(t *testing.T) {
+	d, a, _ := initTestHBridgeMotorDriver("pwm")
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.pwmPin, "pwm")
+	gobottest.Assert(t, d.State(), HBridgeCoast)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "HBridgeMotor"), true)
+
+	d.SetName("left")
+	gobottest.Assert(t, d.Name(), "left")
+}
+
+func TestHBridgeMotorDriverWithPwmPin(t *testing.T) {
+	d, _, writes := initTestHBridgeMotorDriver("pwm")
+
+	gobottest.Assert(t, d.Forward(100), nil)
+	gobottest.Assert(t, *writes, []string{"in1=1", "in2=0", "pwm~100"})
+	gobottest.Assert(t, d.State(), HBridgeForward)
+	gobottest.Assert(t, d.Speed(), byte(100))
+
+	*writes = nil
+	gobottest.Assert(t, d.Backward(50), nil)
+	gobottest.Assert(t, *writes, []string{"in1=0", "in2=1", "pwm~50"})
+	gobottest.Assert(t, d.State(), HBridgeBackward)
+
+	*writes = nil
+	gobottest.Assert(t, d.Brake(), nil)
+	gobottest.Assert(t, *writes, []string{"in1=1", "in2=1", "pwm~255"})
+	gobottest.Assert(t, d.State(), HBridgeBrake)
+	gobottest.Assert(t, d.Speed(), byte(0))
+
+	*writes = nil
+	gobottest.Assert(t, d.Coast(), nil)
+	gobottest.Assert(t, *writes, []string{"in1=0", "in2=0", "pwm~0"})
+	gobottest.Assert(t, d.State(), HBridgeCoast)
+}
+
+func TestHBridgeMotorDriverWithoutPwmPin(t *testing.T) {
+	d, _, writes := initTestHBridgeMotorDriver()
+
+	gobottest.Assert(t, d.Forward(100), nil)
+	gobottest.Assert(t, *writes, []string{"in2=0", "in1~100"})
+
+	*writes = nil
+	gobottest.Assert(t, d.Backward(255), nil)
+	gobottest.Assert(t, *writes, []string{"in1=0", "in2=1"})
+
+	*writes = nil
+	gobottest.Assert(t, d.Backward(20), nil)
+	gobottest.Assert(t, *writes, []string{"in1=0", "in2~20"})
+
+	*writes = nil
+	gobottest.Assert(t, d.Brake(), nil)
+	gobottest.Assert(t, *writes, []string{"in1=1", "in2=1"})
+}
+
+func TestHBridgeMotorDriverSetSpeed(t *testing.T) {
+	d, _, _ := initTestHBridgeMotorDriver("pwm")
+
+	gobottest.Assert(t, d.SetSpeed(300), nil)
+	gobottest.Assert(t, d.State(), HBridgeForward)
+	gobottest.Assert(t, d.Speed(), byte(255))
+
+	gobottest.Assert(t, d.SetSpeed(-120), nil)
+	gobottest.Assert(t, d.State(), HBridgeBackward)
+	gobottest.Assert(t, d.Speed(), byte(120))
+
+	gobottest.Assert(t, d.SetSpeed(0), nil)
+	gobottest.Assert(t, d.State(), HBridgeCoast)
+}
+
+func TestHBridgeMotorDriverStartHalt(t *testing.T) {
+	d, _, writes := initTestHBridgeMotorDriver("pwm")
+	d.SetStandbyPin("stby")
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, *writes, []string{"stby=1", "in1=0", "in2=0", "pwm~0"})
+
+	*writes = nil
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, *writes, []string{"in1=0", "in2=0", "pwm~0", "stby=0"})
+}
+
+func TestHBridgeMotorDriverCommands(t *testing.T) {
+	d, _, _ := initTestHBridgeMotorDriver("pwm")
+
+	gobottest.Assert(t, d.Command("Forward")(map[string]interface{}{"speed": 10.0}), nil)
+	gobottest.Assert(t, d.State(), HBridgeForward)
+	gobottest.Assert(t, d.Command("Backward")(map[string]interface{}{"speed": 10.0}), nil)
+	gobottest.Assert(t, d.State(), HBridgeBackward)
+	gobottest.Assert(t, d.Command("Brake")(nil), nil)
+	gobottest.Assert(t, d.State(), HBridgeBrake)
+	gobottest.Assert(t, d.Command("Coast")(nil), nil)
+	gobottest.Assert(t, d.State(), HBridgeCoast)
+}
+
+func TestHBridgeMotorDriverErrors(t *testing.T) {
+	d, a, _ := initTestHBridgeMotorDriver("pwm")
+	a.TestAdaptorPwmWrite(func(string, byte) (err error) {
+		return errors.New("pwm error")
+	})
+	gobottest.Assert(t, d.Forward(10), errors.New("pwm error"))
+	gobottest.Assert(t, d.State(), HBridgeCoast)
+
+	d = NewHBridgeMotorDriver(&gpioTestDigitalWriter{}, "in1", "in2")
+	gobottest.Assert(t, d.Forward(10), ErrPwmWriteUnsupported)
+	gobottest.Assert(t, d.Forward(255), nil)
+}
+
+func TestDifferentialDriveDriver(t *testing.T) {
+	left, a, _ := initTestHBridgeMotorDriver("pwmA")
+	right := NewHBridgeMotorDriver(a, "in3", "in4", "pwmB")
+	d := NewDifferentialDriveDriver(left, right)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Left(), left)
+	gobottest.Assert(t, d.Right(), right)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "DifferentialDrive"), true)
+	d.SetName("base")
+	gobottest.Assert(t, d.Name(), "base")
+
+	gobottest.Assert(t, d.Start(), nil)
+
+	gobottest.Assert(t, d.Drive(1, 0), nil)
+	gobottest.Assert(t, left.Speed(), byte(255))
+	gobottest.Assert(t, right.Speed(), byte(255))
+
+	// turning right at full throttle slows the right wheel down
+	gobottest.Assert(t, d.Drive(1, 0.5), nil)
+	gobottest.Assert(t, left.State(), HBridgeForward)
+	gobottest.Assert(t, left.Speed(), byte(255))
+	gobottest.Assert(t, right.Speed(), byte(85))
+
+	gobottest.Assert(t, d.Spin(-0.5), nil)
+	gobottest.Assert(t, left.State(), HBridgeBackward)
+	gobottest.Assert(t, right.State(), HBridgeForward)
+	gobottest.Assert(t, right.Speed(), byte(128))
+
+	gobottest.Assert(t, d.Command("Drive")(map[string]interface{}{"throttle": -1.0, "turn": 0.0}), nil)
+	gobottest.Assert(t, left.State(), HBridgeBackward)
+	gobottest.Assert(t, right.State(), HBridgeBackward)
+
+	gobottest.Assert(t, d.Command("Stop")(nil), nil)
+	gobottest.Assert(t, left.State(), HBridgeBrake)
+	gobottest.Assert(t, right.State(), HBridgeBrake)
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, left.State(), HBridgeCoast)
+	gobottest.Assert(t, right.State(), HBridgeCoast)
+}
@@ -1,9 +1,13 @@
 package gpio
 
 import (
+	"bytes"
 	"errors"
-	"gobot.io/x/gobot"
+	"fmt"
+	"sync"
 	"time"
+
+	"gobot.io/x/gobot"
 )
 
 const (
@@ -41,6 +45,13 @@ const (
 	HD44780_2NDLINEOFFSET = 0x40
 )
 
+// hd44780QueueSize is the number of messages queued by WriteAsync
+const hd44780QueueSize = 16
+
+// hd44780LineLength is the length of a DDRAM line in 2-line mode, in 1-line
+// mode the whole DDRAM of two lines is used
+const hd44780LineLength = 40
+
 // data bus mode
 type HD44780BusMode int
 
@@ -61,22 +72,35 @@ type HD44780DataPin struct {
 	D7 string
 }
 
+// HD44780Option is an option of the HD44780Driver
+type HD44780Option func(*HD44780Driver)
+
 // HD44780Driver is the gobot driver for the HD44780 LCD controller
 // Datasheet: https://www.sparkfun.com/datasheets/LCD/HD44780.pdf
 type HD44780Driver struct {
-	name        string
+	*Driver
 	cols        int
 	rows        int
 	rowOffsets  [4]int
 	busMode     HD44780BusMode
 	pinRS       *DirectPinDriver
 	pinEN       *DirectPinDriver
+	pinBL       *DirectPinDriver
 	pinDataBits []*DirectPinDriver
 	displayCtrl int
 	displayFunc int
 	displayMode int
-	connection  gobot.Connection
-	gobot.Commander
+	rs          byte
+	// buffer holds the characters to show by Flush, shown the characters on
+	// the display, it is nil when they are unknown after a direct write
+	buffer []byte
+	shown  []byte
+	mutex  gobot.Mutex
+	// queueMutex protects the queue, which is processed while mutex is locked
+	queueMutex sync.Mutex
+	queue      chan string
+	halt       chan bool
+	gobot.Eventer
 }
 
 // NewHD44780Driver return a new HD44780Driver
@@ -87,17 +111,22 @@ type HD44780Driver struct {
 // pinRS: register select pin
 // pinEN: clock enable pin
 // pinDataBits: databit pins
-func NewHD44780Driver(a gobot.Connection, cols int, rows int, busMode HD44780BusMode, pinRS string, pinEN string, pinDataBits HD44780DataPin) *HD44780Driver {
+//
+// Optionally accepts:
+//  WithHD44780RowOffsets([4]int): DDRAM addresses of the rows
+func NewHD44780Driver(a gobot.Connection, cols int, rows int, busMode HD44780BusMode, pinRS string, pinEN string, pinDataBits HD44780DataPin, options ...HD44780Option) *HD44780Driver {
 	h := &HD44780Driver{
-		name:       "HD44780Driver",
-		cols:       cols,
-		rows:       rows,
-		busMode:    busMode,
-		pinRS:      NewDirectPinDriver(a, pinRS),
-		pinEN:      NewDirectPinDriver(a, pinEN),
-		connection: a,
-		Commander:  gobot.NewCommander(),
+		Driver:  NewDriver(a, "HD44780Driver"),
+		cols:    cols,
+		rows:    rows,
+		busMode: busMode,
+		pinRS:   NewDirectPinDriver(a, pinRS),
+		pinEN:   NewDirectPinDriver(a, pinEN),
+		Eventer: gobot.NewEventer(),
+		buffer:  bytes.Repeat([]byte{' '}, cols*rows),
 	}
+	h.afterStart = h.initialize
+	h.beforeHalt = h.shutdown
 
 	if h.busMode == HD44780_4BITMODE {
 		h.pinDataBits = make([]*DirectPinDriver, 4)
@@ -122,33 +151,66 @@ func NewHD44780Driver(a gobot.Connection, cols int, rows int, busMode HD44780Bus
 	h.rowOffsets[2] = 0x00 + cols
 	h.rowOffsets[3] = HD44780_2NDLINEOFFSET + cols
 
+	for _, option := range options {
+		option(h)
+	}
+
+	h.AddEvent(HD44780WriteDone)
+	h.AddEvent(Error)
+
 	/* TODO : Add commands */
 
 	return h
 }
 
-// Halt implements the Driver interface
-func (h *HD44780Driver) Halt() error { return nil }
+// Contentions returns how often a caller had to wait for the driver
+func (h *HD44780Driver) Contentions() uint64 {
+	return h.Driver.Contentions() + h.mutex.Contentions()
+}
 
-// Name returns the HD44780Driver name
-func (h *HD44780Driver) Name() string { return h.name }
+// WithHD44780RowOffsets option sets the DDRAM addresses of the rows, which
+// default to 0x00, 0x40, cols and 0x40+cols. Some 16x4 displays use the
+// addresses of a 20x4 display, 0x00, 0x40, 0x14 and 0x54.
+func WithHD44780RowOffsets(offsets [4]int) HD44780Option {
+	return func(h *HD44780Driver) {
+		h.rowOffsets = offsets
+	}
+}
 
-// SetName sets the HD44780Driver name
-func (h *HD44780Driver) SetName(n string) { h.name = n }
+// shutdown stops the processing of the messages queued by WriteAsync, the
+// pending messages are dropped
+func (h *HD44780Driver) shutdown() error {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	if h.halt != nil {
+		close(h.halt)
+		h.halt = nil
+		h.queue = nil
+	}
 
-// Connecton returns the HD44780Driver Connection
-func (h *HD44780Driver) Connection() gobot.Connection {
-	return h.connection
+	return nil
 }
 
-// Start initializes the HD44780 LCD controller
+// initialize initializes the HD44780 LCD controller and starts the processing of
+// the messages queued by WriteAsync
 // refer to page 45/46 of hitachi HD44780 datasheet
-func (h *HD44780Driver) Start() (err error) {
+//
+// Emits the Events:
+//	write-done string - The message written by WriteAsync
+//	error error - On error writing a message of WriteAsync
+func (h *HD44780Driver) initialize() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	for _, bitPin := range h.pinDataBits {
 		if bitPin.Pin() == "" {
 			return errors.New("Initialization error")
 		}
 	}
+	if err := h.validateGeometry(); err != nil {
+		return err
+	}
 
 	time.Sleep(50 * time.Millisecond)
 
@@ -172,17 +234,17 @@ func (h *HD44780Driver) Start() (err error) {
 			return err
 		}
 	} else {
-		if err := h.SendCommand(0x30); err != nil {
+		if err := h.sendCommand(0x30); err != nil {
 			return err
 		}
 		time.Sleep(5 * time.Millisecond)
 
-		if err := h.SendCommand(0x30); err != nil {
+		if err := h.sendCommand(0x30); err != nil {
 			return err
 		}
 		time.Sleep(100 * time.Microsecond)
 
-		if err := h.SendCommand(0x30); err != nil {
+		if err := h.sendCommand(0x30); err != nil {
 			return err
 		}
 	}
@@ -204,21 +266,80 @@ func (h *HD44780Driver) Start() (err error) {
 	h.displayCtrl = HD44780_DISPLAYON | HD44780_BLINKOFF | HD44780_CURSOROFF
 	h.displayMode = HD44780_ENTRYLEFT | HD44780_ENTRYSHIFTDECREMENT
 
-	if err := h.SendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl); err != nil {
+	if err := h.sendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl); err != nil {
 		return err
 	}
-	if err := h.SendCommand(HD44780_FUNCTIONSET | h.displayFunc); err != nil {
+	if err := h.sendCommand(HD44780_FUNCTIONSET | h.displayFunc); err != nil {
 		return err
 	}
-	if err := h.SendCommand(HD44780_ENTRYMODESET | h.displayMode); err != nil {
+	if err := h.sendCommand(HD44780_ENTRYMODESET | h.displayMode); err != nil {
+		return err
+	}
+	if err := h.clear(); err != nil {
 		return err
 	}
 
-	return h.Clear()
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+	if h.halt == nil {
+		h.queue = make(chan string, hd44780QueueSize)
+		h.halt = make(chan bool)
+		go h.processQueue(h.queue, h.halt)
+	}
+
+	return nil
 }
 
 // Write output text to the display
 func (h *HD44780Driver) Write(message string) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.write(message)
+}
+
+// WriteAsync queues text to output to the display and returns without
+// waiting for the output, the write-done event is published when the text
+// is written. The driver must be started.
+func (h *HD44780Driver) WriteAsync(message string) (err error) {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	if h.queue == nil {
+		return errors.New("Driver not started")
+	}
+	select {
+	case h.queue <- message:
+		return nil
+	default:
+		return errors.New("Write queue is full")
+	}
+}
+
+// processQueue writes the queued messages until halted
+func (h *HD44780Driver) processQueue(queue chan string, halt chan bool) {
+	for {
+		select {
+		case <-halt:
+			return
+		case message := <-queue:
+			h.mutex.Lock()
+			err := h.write(message)
+			h.mutex.Unlock()
+
+			if err != nil {
+				h.Publish(h.Event(Error), err)
+				continue
+			}
+			h.Publish(h.Event(HD44780WriteDone), message)
+		}
+	}
+}
+
+// write outputs text to the display
+func (h *HD44780Driver) write(message string) (err error) {
+	h.shown = nil
+
 	col := 0
 	if (h.displayMode & HD44780_ENTRYLEFT) == 0 {
 		col = h.cols - 1
@@ -228,12 +349,12 @@ func (h *HD44780Driver) Write(message string) (err error) {
 	for _, c := range message {
 		if c == '\n' {
 			row++
-			if err := h.SetCursor(col, row); err != nil {
+			if err := h.setCursor(col, row); err != nil {
 				return err
 			}
 			continue
 		}
-		if err := h.WriteChar(int(c)); err != nil {
+		if err := h.writeChar(int(c)); err != nil {
 			return err
 		}
 	}
@@ -241,19 +362,92 @@ func (h *HD44780Driver) Write(message string) (err error) {
 	return nil
 }
 
-// Clear clear the display
+// Clear clear the display and the buffer
 func (h *HD44780Driver) Clear() (err error) {
-	if err := h.SendCommand(HD44780_CLEARDISPLAY); err != nil {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.clear()
+}
+
+// clear clears the display and the buffer
+func (h *HD44780Driver) clear() (err error) {
+	h.shown = nil
+	if err := h.sendCommand(HD44780_CLEARDISPLAY); err != nil {
 		return err
 	}
 	time.Sleep(2 * time.Millisecond)
 
+	h.buffer = bytes.Repeat([]byte{' '}, h.cols*h.rows)
+	h.shown = bytes.Repeat([]byte{' '}, h.cols*h.rows)
+
+	return nil
+}
+
+// WriteAt writes text to the buffer at the specified position, the text is
+// cut at the end of the row. The display is updated by Flush.
+func (h *HD44780Driver) WriteAt(col int, row int, text string) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if col < 0 || row < 0 || col >= h.cols || row >= h.rows {
+		return errors.New("Invalid position value")
+	}
+
+	for _, c := range text {
+		if col >= h.cols {
+			break
+		}
+		h.buffer[row*h.cols+col] = byte(c)
+		col++
+	}
+
+	return nil
+}
+
+// Flush outputs the changed characters of the buffer to the display, all
+// characters are output after a direct write to the display, e.g. by Write
+func (h *HD44780Driver) Flush() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	step := 1
+	if (h.displayMode & HD44780_ENTRYLEFT) == 0 {
+		step = -1
+	}
+
+	for row := 0; row < h.rows; row++ {
+		cursor := -1
+		for col := 0; col < h.cols; col++ {
+			i := row*h.cols + col
+			if h.shown != nil && h.shown[i] == h.buffer[i] {
+				continue
+			}
+			if cursor != col {
+				if err := h.setCursor(col, row); err != nil {
+					return err
+				}
+			}
+			if err := h.writeShown(i); err != nil {
+				return err
+			}
+			cursor = col + step
+		}
+	}
+
+	if h.shown == nil {
+		h.shown = append([]byte(nil), h.buffer...)
+	}
+
 	return nil
 }
 
 // Home return cursor to home
 func (h *HD44780Driver) Home() (err error) {
-	if err := h.SendCommand(HD44780_RETURNHOME); err != nil {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.sendCommand(HD44780_RETURNHOME); err != nil {
 		return err
 	}
 	time.Sleep(2 * time.Millisecond)
@@ -263,71 +457,108 @@ func (h *HD44780Driver) Home() (err error) {
 
 // SetCursor move the cursor to the specified position
 func (h *HD44780Driver) SetCursor(col int, row int) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.setCursor(col, row)
+}
+
+// setCursor moves the cursor to the specified position
+func (h *HD44780Driver) setCursor(col int, row int) (err error) {
 	if col < 0 || row < 0 || col >= h.cols || row >= h.rows {
 		return errors.New("Invalid position value")
 	}
 
-	return h.SendCommand(HD44780_SETDDRAMADDR | col + h.rowOffsets[row])
+	return h.sendCommand(HD44780_SETDDRAMADDR | col + h.rowOffsets[row])
 }
 
 // Display turn the display on and off
 func (h *HD44780Driver) Display(on bool) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if on {
 		h.displayCtrl |= HD44780_DISPLAYON
 	} else {
 		h.displayCtrl &= ^HD44780_DISPLAYON
 	}
 
-	return h.SendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl)
+	return h.sendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl)
 }
 
 // Cursor turn the cursor on and off
 func (h *HD44780Driver) Cursor(on bool) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if on {
 		h.displayCtrl |= HD44780_CURSORON
 	} else {
 		h.displayCtrl &= ^HD44780_CURSORON
 	}
 
-	return h.SendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl)
+	return h.sendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl)
 }
 
 // Blink turn the blink on and off
 func (h *HD44780Driver) Blink(on bool) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if on {
 		h.displayCtrl |= HD44780_BLINKON
 	} else {
 		h.displayCtrl &= ^HD44780_BLINKON
 	}
 
-	return h.SendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl)
+	return h.sendCommand(HD44780_DISPLAYCONTROL | h.displayCtrl)
 }
 
 // ScrollLeft scroll text left
 func (h *HD44780Driver) ScrollLeft() (err error) {
-	return h.SendCommand(HD44780_CURSORSHIFT | HD44780_DISPLAYMOVE | HD44780_MOVELEFT)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.sendCommand(HD44780_CURSORSHIFT | HD44780_DISPLAYMOVE | HD44780_MOVELEFT)
 }
 
 // ScrollRight scroll text right
 func (h *HD44780Driver) ScrollRight() (err error) {
-	return h.SendCommand(HD44780_CURSORSHIFT | HD44780_DISPLAYMOVE | HD44780_MOVERIGHT)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.sendCommand(HD44780_CURSORSHIFT | HD44780_DISPLAYMOVE | HD44780_MOVERIGHT)
 }
 
 // LeftToRight display text from left to right
 func (h *HD44780Driver) LeftToRight() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	h.displayMode |= HD44780_ENTRYLEFT
-	return h.SendCommand(HD44780_ENTRYMODESET | h.displayMode)
+	return h.sendCommand(HD44780_ENTRYMODESET | h.displayMode)
 }
 
 // RightToLeft display text from right to left
 func (h *HD44780Driver) RightToLeft() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	h.displayMode &= ^HD44780_ENTRYLEFT
-	return h.SendCommand(HD44780_ENTRYMODESET | h.displayMode)
+	return h.sendCommand(HD44780_ENTRYMODESET | h.displayMode)
 }
 
 // SendCommand send control command
 func (h *HD44780Driver) SendCommand(data int) (err error) {
-	if err := h.pinRS.Off(); err != nil {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.sendCommand(data)
+}
+
+// sendCommand sends a control command
+func (h *HD44780Driver) sendCommand(data int) (err error) {
+	if err := h.setRS(0); err != nil {
 		return err
 	}
 	if h.busMode == HD44780_4BITMODE {
@@ -341,7 +572,31 @@ func (h *HD44780Driver) SendCommand(data int) (err error) {
 
 // WriteChar output a character to the display
 func (h *HD44780Driver) WriteChar(data int) (err error) {
-	if err := h.pinRS.On(); err != nil {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.shown = nil
+
+	return h.writeChar(data)
+}
+
+// writeShown outputs the character of the buffer at the index and records
+// it as shown
+func (h *HD44780Driver) writeShown(i int) (err error) {
+	if err := h.writeChar(int(h.buffer[i])); err != nil {
+		h.shown = nil
+		return err
+	}
+	if h.shown != nil {
+		h.shown[i] = h.buffer[i]
+	}
+
+	return nil
+}
+
+// writeChar outputs a character to the display
+func (h *HD44780Driver) writeChar(data int) (err error) {
+	if err := h.setRS(1); err != nil {
 		return err
 	}
 	if h.busMode == HD44780_4BITMODE {
@@ -355,16 +610,19 @@ func (h *HD44780Driver) WriteChar(data int) (err error) {
 
 // CreateChar create custom character
 func (h *HD44780Driver) CreateChar(pos int, charMap [8]byte) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if pos > 7 {
 		return errors.New("can't set a custom character at a position greater than 7")
 	}
 
-	if err := h.SendCommand(HD44780_SETCGRAMADDR | (pos << 3)); err != nil {
+	if err := h.sendCommand(HD44780_SETCGRAMADDR | (pos << 3)); err != nil {
 		return err
 	}
 
 	for i := range charMap {
-		if err := h.WriteChar(int(charMap[i])); err != nil {
+		if err := h.writeChar(int(charMap[i])); err != nil {
 			return err
 		}
 	}
@@ -372,8 +630,83 @@ func (h *HD44780Driver) CreateChar(pos int, charMap [8]byte) (err error) {
 	return nil
 }
 
+// validateGeometry checks the size of the display and that each row fits
+// into a DDRAM line
+func (h *HD44780Driver) validateGeometry() error {
+	lineLength := hd44780LineLength
+	if h.rows == 1 {
+		lineLength = 2 * hd44780LineLength
+	}
+	if h.cols < 1 || h.cols > lineLength || h.rows < 1 || h.rows > len(h.rowOffsets) ||
+		h.cols*h.rows > 2*hd44780LineLength {
+		return fmt.Errorf("Invalid display size %dx%d", h.cols, h.rows)
+	}
+
+	for row := 0; row < h.rows; row++ {
+		offset := h.rowOffsets[row]
+		if h.rows > 1 && offset >= HD44780_2NDLINEOFFSET {
+			offset -= HD44780_2NDLINEOFFSET
+		}
+		if offset < 0 || offset+h.cols > lineLength {
+			return fmt.Errorf("Invalid offset 0x%02X of row %d", h.rowOffsets[row], row)
+		}
+	}
+
+	return nil
+}
+
+// SetBacklightPin sets the pin the backlight transistor is connected to
+func (h *HD44780Driver) SetBacklightPin(pin string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.pinBL = NewDirectPinDriver(h.Connection(), pin)
+}
+
+// Backlight turn the backlight on and off
+func (h *HD44780Driver) Backlight(on bool) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.pinBL == nil {
+		return errors.New("No backlight pin set")
+	}
+	if on {
+		return h.pinBL.On()
+	}
+
+	return h.pinBL.Off()
+}
+
+// BacklightBrightness dims the backlight by PWM from 0 (off) to 255 (full
+// brightness), the connection must support PwmWrite for the backlight pin
+func (h *HD44780Driver) BacklightBrightness(level byte) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.pinBL == nil {
+		return errors.New("No backlight pin set")
+	}
+
+	return h.pinBL.PwmWrite(level)
+}
+
+// setRS sets the register select pin, for a connection which can write
+// several pins at once this is deferred to the next write of the data bits
+func (h *HD44780Driver) setRS(val byte) (err error) {
+	if _, ok := h.connection.(DigitalPortWriter); ok {
+		h.rs = val
+		return nil
+	}
+	return h.pinRS.DigitalWrite(val)
+}
+
 // WriteBits output data to data-pins
 func (h *HD44780Driver) writeBits(data int) (err error) {
+	if writer, ok := h.connection.(DigitalPortWriter); ok {
+		return h.writePortBits(writer, data)
+	}
+
 	for i, pin := range h.pinDataBits {
 		if ((data >> i) & 0x01) == 0x01 {
 			if err := pin.On(); err != nil {
@@ -389,6 +722,35 @@ func (h *HD44780Driver) writeBits(data int) (err error) {
 	return h.triggerPulse()
 }
 
+// writePortBits outputs the register select and data bits together with the
+// enable pulse in three port writes, instead of one write per pin
+func (h *HD44780Driver) writePortBits(writer DigitalPortWriter, data int) (err error) {
+	pins := make([]string, 0, len(h.pinDataBits)+2)
+	vals := make([]byte, 0, len(h.pinDataBits)+2)
+	pins = append(pins, h.pinRS.Pin(), h.pinEN.Pin())
+	vals = append(vals, h.rs, 0)
+	for i, pin := range h.pinDataBits {
+		pins = append(pins, pin.Pin())
+		vals = append(vals, byte((data>>i)&0x01))
+	}
+	if err := writer.DigitalWritePins(pins, vals); err != nil {
+		return err
+	}
+	time.Sleep(1 * time.Microsecond)
+
+	if err := writer.DigitalWritePins(pins[1:2], []byte{1}); err != nil {
+		return err
+	}
+	time.Sleep(1 * time.Microsecond)
+
+	if err := writer.DigitalWritePins(pins[1:2], []byte{0}); err != nil {
+		return err
+	}
+	time.Sleep(1 * time.Microsecond)
+
+	return nil
+}
+
 // triggerPulse trigger enable pulse
 func (h *HD44780Driver) triggerPulse() (err error) {
 	if err := h.pinEN.Off(); err != nil {
@@ -3,7 +3,9 @@ package gpio
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -26,7 +28,7 @@ func initTestHD44780Driver4BitModeWithStubbedAdaptor() (*HD44780Driver, *gpioTes
 		D7: "12",
 	}
 
-	return NewHD44780Driver(adaptor, 2, 16, HD44780_4BITMODE, "13", "15", dataPins), adaptor
+	return NewHD44780Driver(adaptor, 16, 2, HD44780_4BITMODE, "13", "15", dataPins), adaptor
 }
 
 func initTestHD44780Driver8BitModeWithStubbedAdaptor() (*HD44780Driver, *gpioTestAdaptor) {
@@ -42,7 +44,7 @@ func initTestHD44780Driver8BitModeWithStubbedAdaptor() (*HD44780Driver, *gpioTes
 		D7: "12",
 	}
 
-	return NewHD44780Driver(adaptor, 2, 16, HD44780_8BITMODE, "13", "15", dataPins), adaptor
+	return NewHD44780Driver(adaptor, 16, 2, HD44780_8BITMODE, "13", "15", dataPins), adaptor
 }
 
 // --------- TESTS
@@ -88,7 +90,7 @@ func TestHD44780DriverStartError(t *testing.T) {
 		D6: "16",
 		D7: "",
 	}
-	d = NewHD44780Driver(a, 2, 16, HD44780_4BITMODE, "13", "15", pins)
+	d = NewHD44780Driver(a, 16, 2, HD44780_4BITMODE, "13", "15", pins)
 	gobottest.Assert(t, d.Start(), errors.New("Initialization error"))
 
 	pins = HD44780DataPin{
@@ -101,7 +103,7 @@ func TestHD44780DriverStartError(t *testing.T) {
 		D6: "16",
 		D7: "",
 	}
-	d = NewHD44780Driver(a, 2, 16, HD44780_8BITMODE, "13", "15", pins)
+	d = NewHD44780Driver(a, 16, 2, HD44780_8BITMODE, "13", "15", pins)
 	gobottest.Assert(t, d.Start(), errors.New("Initialization error"))
 }
 
@@ -151,16 +153,67 @@ func TestHD44780DriverHome(t *testing.T) {
 func TestHD44780DriverSetCursor(t *testing.T) {
 	d := initTestHD44780Driver()
 	d.Start()
-	gobottest.Assert(t, d.SetCursor(0, 3), nil)
+	gobottest.Assert(t, d.SetCursor(3, 1), nil)
 }
 
 func TestHD44780DriverSetCursorInvalid(t *testing.T) {
 	d := initTestHD44780Driver()
 	d.Start()
-	gobottest.Assert(t, d.SetCursor(-1, 3), errors.New("Invalid position value"))
-	gobottest.Assert(t, d.SetCursor(2, 3), errors.New("Invalid position value"))
+	gobottest.Assert(t, d.SetCursor(-1, 1), errors.New("Invalid position value"))
+	gobottest.Assert(t, d.SetCursor(16, 1), errors.New("Invalid position value"))
 	gobottest.Assert(t, d.SetCursor(0, -1), errors.New("Invalid position value"))
-	gobottest.Assert(t, d.SetCursor(0, 16), errors.New("Invalid position value"))
+	gobottest.Assert(t, d.SetCursor(0, 2), errors.New("Invalid position value"))
+}
+
+func TestHD44780DriverRowOffsets(t *testing.T) {
+	board := gobottest.NewBoard()
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+
+	// the nibbles latched at the falling edges of EN
+	var nibbles []int
+	en := 0
+	board.OnWrite("15", func(val int) {
+		if en == 1 && val == 0 {
+			nibble := board.Level("22") | board.Level("18")<<1 | board.Level("16")<<2 | board.Level("12")<<3
+			nibbles = append(nibbles, nibble)
+		}
+		en = val
+	})
+
+	// set DDRAM address 0x14 and 0x67
+	d := NewHD44780Driver(board, 20, 4, HD44780_4BITMODE, "13", "15", dataPins)
+	gobottest.Assert(t, d.Start(), nil)
+	nibbles = nil
+	gobottest.Assert(t, d.SetCursor(0, 2), nil)
+	gobottest.Assert(t, d.SetCursor(19, 3), nil)
+	gobottest.Assert(t, nibbles, []int{0x9, 0x4, 0xE, 0x7})
+
+	// set DDRAM address 0x14 and 0x54
+	d = NewHD44780Driver(board, 16, 4, HD44780_4BITMODE, "13", "15", dataPins,
+		WithHD44780RowOffsets([4]int{0x00, 0x40, 0x14, 0x54}))
+	gobottest.Assert(t, d.Start(), nil)
+	nibbles = nil
+	gobottest.Assert(t, d.SetCursor(0, 2), nil)
+	gobottest.Assert(t, d.SetCursor(0, 3), nil)
+	gobottest.Assert(t, nibbles, []int{0x9, 0x4, 0xD, 0x4})
+}
+
+func TestHD44780DriverGeometryError(t *testing.T) {
+	a := newGpioTestAdaptor()
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+
+	d := NewHD44780Driver(a, 2, 16, HD44780_4BITMODE, "13", "15", dataPins)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid display size 2x16"))
+
+	d = NewHD44780Driver(a, 41, 2, HD44780_4BITMODE, "13", "15", dataPins)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid display size 41x2"))
+
+	d = NewHD44780Driver(a, 80, 1, HD44780_4BITMODE, "13", "15", dataPins)
+	gobottest.Assert(t, d.Start(), nil)
+
+	d = NewHD44780Driver(a, 20, 4, HD44780_4BITMODE, "13", "15", dataPins,
+		WithHD44780RowOffsets([4]int{0x00, 0x40, 0x20, 0x54}))
+	gobottest.Assert(t, d.Start(), errors.New("Invalid offset 0x20 of row 2"))
 }
 
 func TestHD44780DriverDisplayOn(t *testing.T) {
@@ -248,3 +301,196 @@ func TestHD44780DriverCreateCharError(t *testing.T) {
 	charMap := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
 	gobottest.Assert(t, d.CreateChar(8, charMap), errors.New("can't set a custom character at a position greater than 7"))
 }
+
+func TestHD44780DriverBacklight(t *testing.T) {
+	d, a := initTestHD44780Driver4BitModeWithStubbedAdaptor()
+	gobottest.Assert(t, d.Backlight(true), errors.New("No backlight pin set"))
+	gobottest.Assert(t, d.BacklightBrightness(128), errors.New("No backlight pin set"))
+
+	writes := make(map[string]byte)
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		writes[pin] = val
+		return
+	})
+	a.TestAdaptorPwmWrite(func(pin string, val byte) (err error) {
+		writes[pin] = val
+		return
+	})
+	d.SetBacklightPin("7")
+	gobottest.Assert(t, d.Backlight(true), nil)
+	gobottest.Assert(t, writes["7"], byte(1))
+	gobottest.Assert(t, d.Backlight(false), nil)
+	gobottest.Assert(t, writes["7"], byte(0))
+	gobottest.Assert(t, d.BacklightBrightness(128), nil)
+	gobottest.Assert(t, writes["7"], byte(128))
+}
+
+func TestHD44780DriverBacklightBrightnessUnsupported(t *testing.T) {
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+	d := NewHD44780Driver(&gpioTestDigitalWriter{}, 16, 2, HD44780_4BITMODE, "13", "15", dataPins)
+	d.SetBacklightPin("7")
+	gobottest.Assert(t, d.Backlight(true), nil)
+	gobottest.Assert(t, d.BacklightBrightness(128), ErrPwmWriteUnsupported)
+}
+
+func TestHD44780DriverWriteAsync(t *testing.T) {
+	d, a := initTestHD44780Driver4BitModeWithStubbedAdaptor()
+	gobottest.Assert(t, d.WriteAsync("hello"), errors.New("Driver not started"))
+
+	done := make(chan interface{}, 1)
+	failed := make(chan interface{}, 1)
+	d.On(d.Event(HD44780WriteDone), func(data interface{}) { done <- data })
+	d.On(d.Event(Error), func(data interface{}) { failed <- data })
+	gobottest.Assert(t, d.Start(), nil)
+
+	gobottest.Assert(t, d.WriteAsync("hello"), nil)
+	select {
+	case data := <-done:
+		gobottest.Assert(t, data, "hello")
+	case <-time.After(time.Second):
+		t.Errorf("write-done event was not published")
+	}
+
+	a.TestAdaptorDigitalWrite(func(string, byte) (err error) {
+		return errors.New("write error")
+	})
+	gobottest.Assert(t, d.WriteAsync("gobot"), nil)
+	select {
+	case data := <-failed:
+		gobottest.Assert(t, data, errors.New("write error"))
+	case <-time.After(time.Second):
+		t.Errorf("error event was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.WriteAsync("hello"), errors.New("Driver not started"))
+}
+
+func TestHD44780DriverWriteAsyncQueueFull(t *testing.T) {
+	d, a := initTestHD44780Driver4BitModeWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	// block the first write until the queue is filled
+	var once sync.Once
+	blocked := make(chan bool)
+	release := make(chan bool)
+	a.TestAdaptorDigitalWrite(func(string, byte) (err error) {
+		once.Do(func() {
+			blocked <- true
+			<-release
+		})
+		return
+	})
+	gobottest.Assert(t, d.WriteAsync("first"), nil)
+	<-blocked
+
+	for i := 0; i < hd44780QueueSize; i++ {
+		gobottest.Assert(t, d.WriteAsync("next"), nil)
+	}
+	gobottest.Assert(t, d.WriteAsync("full"), errors.New("Write queue is full"))
+	close(release)
+}
+
+// hd44780PortTestAdaptor records the batched writes of a port expander
+type hd44780PortTestAdaptor struct {
+	gpioTestAdaptor
+	writes []map[string]byte
+}
+
+func (a *hd44780PortTestAdaptor) DigitalWritePins(pins []string, vals []byte) (err error) {
+	w := make(map[string]byte)
+	for i, pin := range pins {
+		w[pin] = vals[i]
+	}
+	a.writes = append(a.writes, w)
+	return
+}
+
+func TestHD44780DriverPortWriter(t *testing.T) {
+	a := &hd44780PortTestAdaptor{}
+	a.testAdaptorDigitalWrite = func(string, byte) (err error) {
+		t.Errorf("single pin write on a port writer")
+		return
+	}
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+	d := NewHD44780Driver(a, 16, 2, HD44780_4BITMODE, "13", "15", dataPins)
+
+	gobottest.Assert(t, d.WriteChar(0x41), nil)
+	gobottest.Assert(t, len(a.writes), 6)
+	gobottest.Assert(t, a.writes[0], map[string]byte{"13": 1, "15": 0, "22": 0, "18": 0, "16": 1, "12": 0})
+	gobottest.Assert(t, a.writes[1], map[string]byte{"15": 1})
+	gobottest.Assert(t, a.writes[2], map[string]byte{"15": 0})
+	gobottest.Assert(t, a.writes[3], map[string]byte{"13": 1, "15": 0, "22": 1, "18": 0, "16": 0, "12": 0})
+
+	a.writes = nil
+	gobottest.Assert(t, d.SendCommand(0x01), nil)
+	gobottest.Assert(t, a.writes[0]["13"], byte(0))
+	gobottest.Assert(t, a.writes[3]["22"], byte(1))
+}
+
+func TestHD44780DriverFlush(t *testing.T) {
+	a := &hd44780PortTestAdaptor{}
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+	d := NewHD44780Driver(a, 16, 2, HD44780_4BITMODE, "13", "15", dataPins)
+	gobottest.Assert(t, d.Start(), nil)
+
+	// a command or character takes two nibbles of three port writes each
+	a.writes = nil
+	gobottest.Assert(t, d.WriteAt(0, 1, "Hi"), nil)
+	gobottest.Assert(t, len(a.writes), 0)
+	gobottest.Assert(t, d.Flush(), nil)
+	gobottest.Assert(t, len(a.writes), 3*6)
+
+	a.writes = nil
+	gobottest.Assert(t, d.Flush(), nil)
+	gobottest.Assert(t, len(a.writes), 0)
+
+	gobottest.Assert(t, d.WriteAt(1, 1, "o"), nil)
+	gobottest.Assert(t, d.WriteAt(4, 1, "!"), nil)
+	gobottest.Assert(t, d.Flush(), nil)
+	gobottest.Assert(t, len(a.writes), 4*6)
+
+	gobottest.Assert(t, d.WriteAt(14, 0, "abc"), nil)
+	gobottest.Assert(t, string(d.buffer[:16]), "              ab")
+	gobottest.Assert(t, d.WriteAt(16, 0, "x"), errors.New("Invalid position value"))
+
+	// all characters are output after a direct write
+	a.writes = nil
+	gobottest.Assert(t, d.Write("x"), nil)
+	gobottest.Assert(t, d.Flush(), nil)
+	gobottest.Assert(t, len(a.writes), (1+2*(1+16))*6)
+
+	gobottest.Assert(t, d.Clear(), nil)
+	gobottest.Assert(t, string(d.buffer[16:]), "                ")
+}
+
+func TestHD44780DriverPinSequence(t *testing.T) {
+	board := gobottest.NewBoard()
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+	d := NewHD44780Driver(board, 16, 2, HD44780_4BITMODE, "13", "15", dataPins)
+	gobottest.Assert(t, d.Start(), nil)
+
+	// the nibbles and register selects latched at the falling edges of EN
+	var nibbles, rs []int
+	en := 0
+	board.OnWrite("15", func(val int) {
+		if en == 1 && val == 0 {
+			nibble := board.Level("22") | board.Level("18")<<1 | board.Level("16")<<2 | board.Level("12")<<3
+			nibbles = append(nibbles, nibble)
+			rs = append(rs, board.Level("13"))
+		}
+		en = val
+	})
+
+	gobottest.Assert(t, d.WriteChar('A'), nil)
+	gobottest.Assert(t, nibbles, []int{0x4, 0x1})
+	gobottest.Assert(t, rs, []int{1, 1})
+
+	nibbles, rs = nil, nil
+	board.ClearTransactions()
+	gobottest.Assert(t, d.SendCommand(HD44780_CLEARDISPLAY), nil)
+	gobottest.Assert(t, nibbles, []int{0x0, 0x1})
+	gobottest.Assert(t, rs, []int{0, 0})
+	gobottest.Assert(t, board.Writes("15"), []int{0, 1, 0, 0, 1, 0})
+}
@@ -0,0 +1,268 @@
+package gpio
+
+import (
+	"errors"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// HX711GainA128 selects channel A with a gain of 128
+	HX711GainA128 = 1
+	// HX711GainB32 selects channel B with a gain of 32
+	HX711GainB32 = 2
+	// HX711GainA64 selects channel A with a gain of 64
+	HX711GainA64 = 3
+)
+
+// ErrHX711NotReady is the error resulting when the HX711 has no conversion
+// ready within the timeout, e.g. because it is not connected or powered down
+var ErrHX711NotReady = errors.New("HX711 conversion is not ready")
+
+// HX711Driver is the gobot driver for the HX711 24 bit ADC for load cells.
+// The clock and data pins are bit-banged, so the adaptor should provide fast
+// digital writes, because the clock must not stay high for longer than 60us.
+//
+// Datasheet: https://cdn.sparkfun.com/datasheets/Sensors/ForceFlex/hx711_english.pdf
+type HX711Driver struct {
+	*Driver
+	pinData      *DirectPinDriver
+	pinClock     *DirectPinDriver
+	gain         int
+	offset       float64
+	scale        float64
+	readyTimeout time.Duration
+}
+
+// NewHX711Driver returns a new HX711Driver given a gobot.Connection which
+// supports DigitalRead and DigitalWrite and the data (DOUT) and clock (PD_SCK) pins.
+// By default channel A with a gain of 128 is used and the weight is reported
+// in raw units, until a scale is set or calibrated.
+//
+// Adds the following API Commands:
+// 	"Tare" - See HX711Driver.Tare, the optional param "times" defaults to 1
+// 	"Weight" - See HX711Driver.Weight, the optional param "times" defaults to 1
+func NewHX711Driver(a gobot.Connection, dataPin string, clockPin string) *HX711Driver {
+	h := &HX711Driver{
+		Driver:       NewDriver(a, "HX711"),
+		pinData:      NewDirectPinDriver(a, dataPin),
+		pinClock:     NewDirectPinDriver(a, clockPin),
+		gain:         HX711GainA128,
+		scale:        1,
+		readyTimeout: time.Second,
+	}
+	// the HX711 is powered up on Start and powered down on Halt
+	h.afterStart = h.initialize
+	h.beforeHalt = h.shutdown
+
+	h.AddCommand("Tare", func(params map[string]interface{}) interface{} {
+		return h.Tare(int(gobot.CommandParamFloat(params["times"])))
+	})
+	h.AddCommand("Weight", func(params map[string]interface{}) interface{} {
+		val, err := h.Weight(int(gobot.CommandParamFloat(params["times"])))
+		return map[string]interface{}{"val": val, "err": err}
+	})
+
+	return h
+}
+
+// PowerUp powers up the HX711, the gain is reset to channel A with a gain of 128
+func (h *HX711Driver) PowerUp() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.initialize()
+}
+
+// PowerDown powers down the HX711 by holding the clock high
+func (h *HX711Driver) PowerDown() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.shutdown()
+}
+
+// initialize powers up the HX711, the mutex must be held by the caller
+func (h *HX711Driver) initialize() (err error) {
+	h.gain = HX711GainA128
+	return h.pinClock.Off()
+}
+
+// shutdown powers down the HX711, the mutex must be held by the caller
+func (h *HX711Driver) shutdown() (err error) {
+	if err = h.pinClock.Off(); err != nil {
+		return
+	}
+	if err = h.pinClock.On(); err != nil {
+		return
+	}
+	time.Sleep(100 * time.Microsecond)
+	return
+}
+
+// SetGain selects the channel and gain, one of HX711GainA128, HX711GainB32 or
+// HX711GainA64. A conversion is read and discarded, because the HX711 applies
+// the selection to the conversion following the current one.
+func (h *HX711Driver) SetGain(gain int) (err error) {
+	if gain < HX711GainA128 || gain > HX711GainA64 {
+		return errors.New("HX711 gain must be one of HX711GainA128, HX711GainB32 or HX711GainA64")
+	}
+
+	h.mutex.Lock()
+	h.gain = gain
+	h.mutex.Unlock()
+
+	_, err = h.ReadRaw()
+	return
+}
+
+// Gain returns the selected channel and gain
+func (h *HX711Driver) Gain() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.gain
+}
+
+// SetOffset sets the raw value of the empty scale
+func (h *HX711Driver) SetOffset(offset float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.offset = offset
+}
+
+// Offset returns the raw value of the empty scale
+func (h *HX711Driver) Offset() float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.offset
+}
+
+// SetScale sets the raw units per unit of weight
+func (h *HX711Driver) SetScale(scale float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.scale = scale
+}
+
+// Scale returns the raw units per unit of weight
+func (h *HX711Driver) Scale() float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.scale
+}
+
+// ReadRaw waits for the next conversion and returns the signed 24 bit value
+func (h *HX711Driver) ReadRaw() (val int, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err = h.waitReady(); err != nil {
+		return
+	}
+
+	var raw uint32
+	for i := 0; i < 24; i++ {
+		bit, err := h.clockBit()
+		if err != nil {
+			return 0, err
+		}
+		raw = raw<<1 | uint32(bit)
+	}
+
+	// additional pulses select channel and gain of the next conversion
+	for i := 0; i < h.gain; i++ {
+		if _, err = h.clockBit(); err != nil {
+			return
+		}
+	}
+
+	// sign extension of the two's complement 24 bit value
+	return int(int32(raw<<8) >> 8), nil
+}
+
+// ReadAverage returns the average of the given number of raw conversions
+func (h *HX711Driver) ReadAverage(times int) (val float64, err error) {
+	if times < 1 {
+		times = 1
+	}
+
+	sum := 0
+	for i := 0; i < times; i++ {
+		raw, err := h.ReadRaw()
+		if err != nil {
+			return 0, err
+		}
+		sum += raw
+	}
+	return float64(sum) / float64(times), nil
+}
+
+// Tare sets the offset to the average of the given number of conversions, so
+// the current load is reported as zero weight
+func (h *HX711Driver) Tare(times int) (err error) {
+	avg, err := h.ReadAverage(times)
+	if err != nil {
+		return
+	}
+	h.SetOffset(avg)
+	return
+}
+
+// Calibrate sets the scale given a known weight which is currently placed on
+// the (tared) scale, using the average of the given number of conversions
+func (h *HX711Driver) Calibrate(knownWeight float64, times int) (err error) {
+	if knownWeight == 0 {
+		return errors.New("HX711 calibration weight must not be zero")
+	}
+
+	avg, err := h.ReadAverage(times)
+	if err != nil {
+		return
+	}
+	h.SetScale((avg - h.Offset()) / knownWeight)
+	return
+}
+
+// Weight returns the weight using the average of the given number of conversions
+func (h *HX711Driver) Weight(times int) (weight float64, err error) {
+	avg, err := h.ReadAverage(times)
+	if err != nil {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return (avg - h.offset) / h.scale, nil
+}
+
+// waitReady polls the data pin until the HX711 pulls it low
+func (h *HX711Driver) waitReady() (err error) {
+	deadline := time.Now().Add(h.readyTimeout)
+	for {
+		val, err := h.pinData.DigitalRead()
+		if err != nil {
+			return err
+		}
+		if val == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrHX711NotReady
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// clockBit sends a clock pulse and returns the data bit shifted out by the HX711
+func (h *HX711Driver) clockBit() (bit int, err error) {
+	if err = h.pinClock.On(); err != nil {
+		return
+	}
+	if bit, err = h.pinData.DigitalRead(); err != nil {
+		h.pinClock.Off()
+		return
+	}
+	err = h.pinClock.Off()
+	return
+}
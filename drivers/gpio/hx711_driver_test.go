@@ -0,0 +1,160 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*HX711Driver)(nil)
+
+// hx711Simulator shifts out the values on the data pin "1" when clocked on pin "2"
+type hx711Simulator struct {
+	mtx        sync.Mutex
+	values     []int32
+	clock      bool
+	pulses     int
+	gainPulses []int
+}
+
+func (s *hx711Simulator) digitalWrite(pin string, val byte) (err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if pin == "2" {
+		s.clock = val == 1
+		if s.clock {
+			s.pulses++
+		}
+	}
+	return
+}
+
+func (s *hx711Simulator) digitalRead(pin string) (val int, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if !s.clock && s.pulses > 24 {
+		// ready check of the next conversion
+		s.gainPulses = append(s.gainPulses, s.pulses-24)
+		s.pulses = 0
+		if len(s.values) > 1 {
+			s.values = s.values[1:]
+		}
+	}
+	if s.pulses == 0 || s.pulses > 24 {
+		return 0, nil
+	}
+	return int(uint32(s.values[0])>>uint(24-s.pulses)) & 1, nil
+}
+
+func initTestHX711DriverWithSimulator(values ...int32) (*HX711Driver, *hx711Simulator) {
+	a := newGpioTestAdaptor()
+	s := &hx711Simulator{values: values}
+	a.TestAdaptorDigitalWrite(s.digitalWrite)
+	a.TestAdaptorDigitalRead(s.digitalRead)
+	return NewHX711Driver(a, "1", "2"), s
+}
+
+func TestHX711Driver(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewHX711Driver(a, "1", "2")
+	gobottest.Assert(t, d.Connection(), a)
+	gobottest.Assert(t, d.Gain(), HX711GainA128)
+	gobottest.Assert(t, d.Scale(), 1.0)
+	gobottest.Assert(t, d.Offset(), 0.0)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "HX711"), true)
+
+	d.SetName("scale")
+	gobottest.Assert(t, d.Name(), "scale")
+	gobottest.Refute(t, d.Command("Tare"), nil)
+	gobottest.Refute(t, d.Command("Weight"), nil)
+	// "times" is optional, so it is not declared by a schema
+	gobottest.Assert(t, d.CommandSchema("Tare").Validate(map[string]interface{}{}), map[string]string{})
+	gobottest.Assert(t, d.CommandSchema("Weight").Validate(map[string]interface{}{}), map[string]string{})
+}
+
+func TestHX711DriverStartHalt(t *testing.T) {
+	var writes []byte
+	a := newGpioTestAdaptor()
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		writes = append(writes, val)
+		return
+	})
+	d := NewHX711Driver(a, "1", "2")
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, writes, []byte{0})
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, writes, []byte{0, 0, 1})
+
+	gobottest.Assert(t, d.PowerUp(), nil)
+	gobottest.Assert(t, d.PowerDown(), nil)
+	gobottest.Assert(t, writes, []byte{0, 0, 1, 0, 0, 1})
+}
+
+func TestHX711DriverReadRaw(t *testing.T) {
+	d, s := initTestHX711DriverWithSimulator(0x123456, -1000)
+
+	val, err := d.ReadRaw()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0x123456)
+
+	val, err = d.ReadRaw()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, -1000)
+	gobottest.Assert(t, s.gainPulses, []int{1})
+}
+
+func TestHX711DriverReadRawNotReady(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewHX711Driver(a, "1", "2")
+	d.readyTimeout = 10 * time.Millisecond
+
+	_, err := d.ReadRaw()
+	gobottest.Assert(t, err, ErrHX711NotReady)
+
+	a.TestAdaptorDigitalRead(func(pin string) (val int, err error) {
+		return 0, errors.New("read error")
+	})
+	_, err = d.ReadRaw()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestHX711DriverSetGain(t *testing.T) {
+	d, s := initTestHX711DriverWithSimulator(100)
+
+	gobottest.Assert(t, d.SetGain(HX711GainB32), nil)
+	gobottest.Assert(t, d.Gain(), HX711GainB32)
+	d.ReadRaw()
+	gobottest.Assert(t, d.SetGain(HX711GainA64), nil)
+	d.ReadRaw()
+	gobottest.Assert(t, s.gainPulses, []int{2, 2, 3})
+
+	gobottest.Refute(t, d.SetGain(4), nil)
+}
+
+func TestHX711DriverTareCalibrateWeight(t *testing.T) {
+	d, _ := initTestHX711DriverWithSimulator(1000, 1010, 990, 1000, 3000, 3000, 5000)
+
+	gobottest.Assert(t, d.Tare(4), nil)
+	gobottest.Assert(t, d.Offset(), 1000.0)
+
+	gobottest.Assert(t, d.Calibrate(100, 2), nil)
+	gobottest.Assert(t, d.Scale(), 20.0)
+
+	weight, err := d.Weight(1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, weight, 200.0)
+
+	gobottest.Refute(t, d.Calibrate(0, 1), nil)
+
+	ret := d.Command("Weight")(map[string]interface{}{"times": 2}).(map[string]interface{})
+	gobottest.Assert(t, ret["val"], 200.0)
+	gobottest.Assert(t, ret["err"], nil)
+
+	gobottest.Assert(t, d.Command("Tare")(map[string]interface{}{}), nil)
+	gobottest.Assert(t, d.Offset(), 5000.0)
+}
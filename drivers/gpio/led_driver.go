@@ -4,11 +4,9 @@ import "gobot.io/x/gobot"
 
 // LedDriver represents a digital Led
 type LedDriver struct {
-	pin        string
-	name       string
-	connection DigitalWriter
-	high       bool
-	gobot.Commander
+	*Driver
+	pin  string
+	high bool
 }
 
 // NewLedDriver return a new LedDriver given a DigitalWriter and pin.
@@ -20,17 +18,16 @@ type LedDriver struct {
 //	"Off" - See LedDriver.Off
 func NewLedDriver(a DigitalWriter, pin string) *LedDriver {
 	l := &LedDriver{
-		name:       gobot.DefaultName("LED"),
-		pin:        pin,
-		connection: a,
-		high:       false,
-		Commander:  gobot.NewCommander(),
+		Driver: NewDriver(a, "LED"),
+		pin:    pin,
+		high:   false,
 	}
 
 	l.AddCommand("Brightness", func(params map[string]interface{}) interface{} {
 		level := byte(params["level"].(float64))
 		return l.Brightness(level)
 	})
+	l.AddCommandSchema("Brightness", gobot.CommandSchema{"level": gobot.CommandParamNumber})
 
 	l.AddCommand("Toggle", func(params map[string]interface{}) interface{} {
 		return l.Toggle()
@@ -47,26 +44,9 @@ func NewLedDriver(a DigitalWriter, pin string) *LedDriver {
 	return l
 }
 
-// Start implements the Driver interface
-func (l *LedDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface
-func (l *LedDriver) Halt() (err error) { return }
-
-// Name returns the LedDrivers name
-func (l *LedDriver) Name() string { return l.name }
-
-// SetName sets the LedDrivers name
-func (l *LedDriver) SetName(n string) { l.name = n }
-
 // Pin returns the LedDrivers name
 func (l *LedDriver) Pin() string { return l.pin }
 
-// Connection returns the LedDrivers Connection
-func (l *LedDriver) Connection() gobot.Connection {
-	return l.connection.(gobot.Connection)
-}
-
 // State return true if the led is On and false if the led is Off
 func (l *LedDriver) State() bool {
 	return l.high
@@ -74,7 +54,7 @@ func (l *LedDriver) State() bool {
 
 // On sets the led to a high state.
 func (l *LedDriver) On() (err error) {
-	if err = l.connection.DigitalWrite(l.Pin(), 1); err != nil {
+	if err = l.digitalWrite(l.Pin(), 1); err != nil {
 		return
 	}
 	l.high = true
@@ -83,7 +63,7 @@ func (l *LedDriver) On() (err error) {
 
 // Off sets the led to a low state.
 func (l *LedDriver) Off() (err error) {
-	if err = l.connection.DigitalWrite(l.Pin(), 0); err != nil {
+	if err = l.digitalWrite(l.Pin(), 0); err != nil {
 		return
 	}
 	l.high = false
@@ -1,6 +1,7 @@
 package gpio
 
 import (
+	"fmt"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -8,12 +9,10 @@ import (
 
 // MakeyButtonDriver Represents a Makey Button
 type MakeyButtonDriver struct {
-	name       string
-	pin        string
-	halt       chan bool
-	connection DigitalReader
-	Active     bool
-	interval   time.Duration
+	*Driver
+	pin    string
+	poller *gobot.Poller
+	Active bool
 	gobot.Eventer
 }
 
@@ -22,19 +21,27 @@ type MakeyButtonDriver struct {
 //
 // Optionally accepts:
 //  time.Duration: Interval at which the ButtonDriver is polled for new information
-func NewMakeyButtonDriver(a DigitalReader, pin string, v ...time.Duration) *MakeyButtonDriver {
+//  gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+func NewMakeyButtonDriver(a DigitalReader, pin string, v ...interface{}) *MakeyButtonDriver {
 	m := &MakeyButtonDriver{
-		name:       gobot.DefaultName("MakeyButton"),
-		connection: a,
-		pin:        pin,
-		Active:     false,
-		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		Driver:  NewDriver(a, "MakeyButton"),
+		pin:     pin,
+		Active:  false,
+		Eventer: gobot.NewEventer(),
+		poller:  gobot.NewPoller(10 * time.Millisecond),
 	}
+	m.afterStart = m.initialize
+	m.beforeHalt = m.shutdown
 
-	if len(v) > 0 {
-		m.interval = v[0]
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			m.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			m.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for gpio.NewMakeyButtonDriver", opt))
+		}
 	}
 
 	m.AddEvent(Error)
@@ -44,56 +51,38 @@ func NewMakeyButtonDriver(a DigitalReader, pin string, v ...time.Duration) *Make
 	return m
 }
 
-// Name returns the MakeyButtonDrivers name
-func (b *MakeyButtonDriver) Name() string { return b.name }
-
-// SetName sets the MakeyButtonDrivers name
-func (b *MakeyButtonDriver) SetName(n string) { b.name = n }
-
 // Pin returns the MakeyButtonDrivers pin
 func (b *MakeyButtonDriver) Pin() string { return b.pin }
 
-// Connection returns the MakeyButtonDrivers Connection
-func (b *MakeyButtonDriver) Connection() gobot.Connection { return b.connection.(gobot.Connection) }
-
-// Start starts the MakeyButtonDriver and polls the state of the button at the given interval.
+// initialize starts to poll the state of the button at the given interval.
 //
 // Emits the Events:
 // 	Push int - On button push
 //	Release int - On button release
 //	Error error - On button error
-func (b *MakeyButtonDriver) Start() (err error) {
+func (b *MakeyButtonDriver) initialize() (err error) {
 	state := 1
-	go func() {
-		timer := time.NewTimer(b.interval)
-		timer.Stop()
-		for {
-			newValue, err := b.connection.DigitalRead(b.Pin())
-			if err != nil {
-				b.Publish(Error, err)
-			} else if newValue != state && newValue != -1 {
-				state = newValue
-				if newValue == 0 {
-					b.Active = true
-					b.Publish(ButtonPush, newValue)
-				} else {
-					b.Active = false
-					b.Publish(ButtonRelease, newValue)
-				}
-			}
-			timer.Reset(b.interval)
-			select {
-			case <-timer.C:
-			case <-b.halt:
-				return
+	b.poller.Start(func() error {
+		newValue, err := b.digitalRead(b.Pin())
+		if err != nil {
+			b.Publish(Error, err)
+		} else if newValue != state && newValue != -1 {
+			state = newValue
+			if newValue == 0 {
+				b.Active = true
+				b.Publish(ButtonPush, newValue)
+			} else {
+				b.Active = false
+				b.Publish(ButtonRelease, newValue)
 			}
 		}
-	}()
+		return err
+	})
 	return
 }
 
-// Halt stops polling the makey button for new information
-func (b *MakeyButtonDriver) Halt() (err error) {
-	b.halt <- true
+// shutdown stops polling the makey button for new information
+func (b *MakeyButtonDriver) shutdown() (err error) {
+	b.poller.Stop()
 	return
 }
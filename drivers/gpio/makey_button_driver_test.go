@@ -19,27 +19,26 @@ func initTestMakeyButtonDriver() *MakeyButtonDriver {
 
 func TestMakeyButtonDriverHalt(t *testing.T) {
 	d := initTestMakeyButtonDriver()
-	done := make(chan struct{})
-	go func() {
-		<-d.halt
-		close(done)
-	}()
+	gobottest.Assert(t, d.Start(), nil)
 	gobottest.Assert(t, d.Halt(), nil)
-	select {
-	case <-done:
-	case <-time.After(makeyTestDelay * time.Millisecond):
-		t.Errorf("MakeyButton was not halted")
-	}
+	gobottest.Assert(t, d.poller.Running(), false)
 }
 
 func TestMakeyButtonDriver(t *testing.T) {
 	d := initTestMakeyButtonDriver()
 	gobottest.Assert(t, d.Pin(), "1")
 	gobottest.Refute(t, d.Connection(), nil)
-	gobottest.Assert(t, d.interval, 10*time.Millisecond)
+	gobottest.Assert(t, d.poller.Interval(), 10*time.Millisecond)
 
 	d = NewMakeyButtonDriver(newGpioTestAdaptor(), "1", 30*time.Second)
-	gobottest.Assert(t, d.interval, 30*time.Second)
+	gobottest.Assert(t, d.poller.Interval(), 30*time.Second)
+}
+
+func TestMakeyButtonDriverUnsupportedOption(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover(), "unsupported option int for gpio.NewMakeyButtonDriver")
+	}()
+	NewMakeyButtonDriver(newGpioTestAdaptor(), "1", 100)
 }
 
 func TestMakeyButtonDriverStart(t *testing.T) {
@@ -107,7 +106,7 @@ func TestMakeyButtonDriverStart(t *testing.T) {
 		return
 	})
 
-	d.halt <- true
+	gobottest.Assert(t, d.Halt(), nil)
 
 	select {
 	case <-sem:
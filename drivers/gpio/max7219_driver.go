@@ -25,34 +25,31 @@ const (
 //
 // Datasheet: https://datasheets.maximintegrated.com/en/ds/MAX7219-MAX7221.pdf
 type MAX7219Driver struct {
-	pinClock   *DirectPinDriver
-	pinData    *DirectPinDriver
-	pinCS      *DirectPinDriver
-	name       string
-	count      uint
-	connection gobot.Connection
-	gobot.Commander
+	*Driver
+	pinClock *DirectPinDriver
+	pinData  *DirectPinDriver
+	pinCS    *DirectPinDriver
+	count    uint
 }
 
 // NewMAX7219Driver return a new MAX7219Driver given a gobot.Connection, pins and how many chips are chained
 func NewMAX7219Driver(a gobot.Connection, clockPin string, dataPin string, csPin string, count uint) *MAX7219Driver {
 	t := &MAX7219Driver{
-		name:       gobot.DefaultName("MAX7219Driver"),
-		pinClock:   NewDirectPinDriver(a, clockPin),
-		pinData:    NewDirectPinDriver(a, dataPin),
-		pinCS:      NewDirectPinDriver(a, csPin),
-		count:      count,
-		connection: a,
-		Commander:  gobot.NewCommander(),
+		Driver:   NewDriver(a, "MAX7219Driver"),
+		pinClock: NewDirectPinDriver(a, clockPin),
+		pinData:  NewDirectPinDriver(a, dataPin),
+		pinCS:    NewDirectPinDriver(a, csPin),
+		count:    count,
 	}
+	t.afterStart = t.initialize
 
 	/* TODO : Add commands */
 
 	return t
 }
 
-// Start initializes the max7219, it uses a SPI-like communication protocol
-func (a *MAX7219Driver) Start() (err error) {
+// initialize initializes the max7219, it uses a SPI-like communication protocol
+func (a *MAX7219Driver) initialize() (err error) {
 	a.pinData.On()
 	a.pinClock.On()
 	a.pinCS.On()
@@ -67,20 +64,6 @@ func (a *MAX7219Driver) Start() (err error) {
 	return
 }
 
-// Halt implements the Driver interface
-func (a *MAX7219Driver) Halt() (err error) { return }
-
-// Name returns the MAX7219Drivers name
-func (a *MAX7219Driver) Name() string { return a.name }
-
-// SetName sets the MAX7219Drivers name
-func (a *MAX7219Driver) SetName(n string) { a.name = n }
-
-// Connection returns the MAX7219Driver Connection
-func (a *MAX7219Driver) Connection() gobot.Connection {
-	return a.connection
-}
-
 // SetIntensity changes the intensity (from 1 to 7) of the display
 func (a *MAX7219Driver) SetIntensity(level byte) {
 	if level > 15 {
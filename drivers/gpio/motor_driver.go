@@ -1,13 +1,8 @@
 package gpio
 
-import (
-	"gobot.io/x/gobot"
-)
-
 // MotorDriver Represents a Motor
 type MotorDriver struct {
-	name             string
-	connection       DigitalWriter
+	*Driver
 	SpeedPin         string
 	SwitchPin        string
 	DirectionPin     string
@@ -22,8 +17,7 @@ type MotorDriver struct {
 // NewMotorDriver return a new MotorDriver given a DigitalWriter and pin
 func NewMotorDriver(a DigitalWriter, speedPin string) *MotorDriver {
 	return &MotorDriver{
-		name:             gobot.DefaultName("Motor"),
-		connection:       a,
+		Driver:           NewDriver(a, "Motor"),
 		SpeedPin:         speedPin,
 		CurrentState:     0,
 		CurrentSpeed:     0,
@@ -32,21 +26,6 @@ func NewMotorDriver(a DigitalWriter, speedPin string) *MotorDriver {
 	}
 }
 
-// Name returns the MotorDrivers name
-func (m *MotorDriver) Name() string { return m.name }
-
-// SetName sets the MotorDrivers name
-func (m *MotorDriver) SetName(n string) { m.name = n }
-
-// Connection returns the MotorDrivers Connection
-func (m *MotorDriver) Connection() gobot.Connection { return m.connection.(gobot.Connection) }
-
-// Start implements the Driver interface
-func (m *MotorDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface
-func (m *MotorDriver) Halt() (err error) { return }
-
 // Off turns the motor off or sets the motor to a 0 speed
 func (m *MotorDriver) Off() (err error) {
 	if m.isDigital() {
@@ -149,7 +128,7 @@ func (m *MotorDriver) Direction(direction string) (err error) {
 		} else {
 			level = 0
 		}
-		err = m.connection.DigitalWrite(m.DirectionPin, level)
+		err = m.digitalWrite(m.DirectionPin, level)
 	} else {
 		var forwardLevel, backwardLevel byte
 		switch direction {
@@ -163,11 +142,11 @@ func (m *MotorDriver) Direction(direction string) (err error) {
 			forwardLevel = 0
 			backwardLevel = 0
 		}
-		err = m.connection.DigitalWrite(m.ForwardPin, forwardLevel)
+		err = m.digitalWrite(m.ForwardPin, forwardLevel)
 		if err != nil {
 			return
 		}
-		err = m.connection.DigitalWrite(m.BackwardPin, backwardLevel)
+		err = m.digitalWrite(m.BackwardPin, backwardLevel)
 		if err != nil {
 			return
 		}
@@ -202,7 +181,7 @@ func (m *MotorDriver) changeState(state byte) (err error) {
 			err = m.Direction("none")
 		}
 	} else {
-		err = m.connection.DigitalWrite(m.SpeedPin, state)
+		err = m.digitalWrite(m.SpeedPin, state)
 	}
 
 	return
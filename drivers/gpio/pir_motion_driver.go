@@ -1,6 +1,7 @@
 package gpio
 
 import (
+	"fmt"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -8,97 +9,119 @@ import (
 
 // PIRMotionDriver represents a digital Proximity Infra Red (PIR) motion detecter
 type PIRMotionDriver struct {
-	Active     bool
-	pin        string
-	name       string
-	halt       chan bool
-	interval   time.Duration
-	connection DigitalReader
+	*Driver
+	Active    bool
+	pin       string
+	poller    *gobot.Poller
+	retrigger time.Duration
 	gobot.Eventer
 }
 
+// PIRMotionOption is an option of the PIRMotionDriver
+type PIRMotionOption func(*PIRMotionDriver)
+
 // NewPIRMotionDriver returns a new PIRMotionDriver with a polling interval of
 // 10 Milliseconds given a DigitalReader and pin.
 //
 // Optionally accepts:
 //  time.Duration: Interval at which the PIRMotionDriver is polled for new information
-func NewPIRMotionDriver(a DigitalReader, pin string, v ...time.Duration) *PIRMotionDriver {
+//  gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//  WithRetriggerWindow(time.Duration): Quiet time after which a motion is considered to be stopped
+func NewPIRMotionDriver(a DigitalReader, pin string, v ...interface{}) *PIRMotionDriver {
 	b := &PIRMotionDriver{
-		name:       gobot.DefaultName("PIRMotion"),
-		connection: a,
-		pin:        pin,
-		Active:     false,
-		Eventer:    gobot.NewEventer(),
-		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
+		Driver:  NewDriver(a, "PIRMotion"),
+		pin:     pin,
+		Active:  false,
+		Eventer: gobot.NewEventer(),
+		poller:  gobot.NewPoller(10 * time.Millisecond),
 	}
+	b.afterStart = b.initialize
+	b.beforeHalt = b.shutdown
 
-	if len(v) > 0 {
-		b.interval = v[0]
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			b.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			b.poller.Apply(o)
+		case PIRMotionOption:
+			o(b)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for gpio.NewPIRMotionDriver", opt))
+		}
 	}
 
 	b.AddEvent(MotionDetected)
 	b.AddEvent(MotionStopped)
+	b.AddEvent(MotionStart)
+	b.AddEvent(MotionStop)
 	b.AddEvent(Error)
 
 	return b
 }
 
-// Start starts the PIRMotionDriver and polls the state of the sensor at the given interval.
+// WithRetriggerWindow option sets the quiet time, which must pass without a
+// detection before the MotionStop event is published. Detections within the
+// window extend the running motion, because most PIR sensors pull their
+// output low for a short time even while the motion continues.
+func WithRetriggerWindow(d time.Duration) PIRMotionOption {
+	return func(p *PIRMotionDriver) {
+		p.retrigger = d
+	}
+}
+
+// initialize starts to poll the state of the sensor at the given interval.
 //
 // Emits the Events:
 // 	MotionDetected - On motion detected
 //	MotionStopped int - On motion stopped
+//	MotionStart int - On motion detected after the retrigger window has passed
+//	MotionStop int - On no motion detected within the retrigger window
 //	Error error - On button error
 //
 // The PIRMotionDriver will send the MotionDetected event over and over,
 // just as long as motion is still being detected.
 // It will only send the MotionStopped event once, however, until
 // motion starts being detected again
-func (p *PIRMotionDriver) Start() (err error) {
-	go func() {
-		for {
-			newValue, err := p.connection.DigitalRead(p.Pin())
-			if err != nil {
-				p.Publish(Error, err)
+func (p *PIRMotionDriver) initialize() (err error) {
+	inMotion := false
+	var lastDetection time.Time
+	p.poller.Start(func() error {
+		newValue, err := p.digitalRead(p.Pin())
+		if err != nil {
+			p.Publish(Error, err)
+		}
+		switch newValue {
+		case 1:
+			lastDetection = time.Now()
+			if !inMotion {
+				inMotion = true
+				p.Publish(MotionStart, newValue)
 			}
-			switch newValue {
-			case 1:
-				if !p.Active {
-					p.Active = true
-					p.Publish(MotionDetected, newValue)
-				}
-			case 0:
-				if p.Active {
-					p.Active = false
-					p.Publish(MotionStopped, newValue)
-				}
+			if !p.Active {
+				p.Active = true
+				p.Publish(MotionDetected, newValue)
 			}
-
-			select {
-			case <-time.After(p.interval):
-			case <-p.halt:
-				return
+		case 0:
+			if p.Active {
+				p.Active = false
+				p.Publish(MotionStopped, newValue)
+			}
+			if inMotion && time.Since(lastDetection) >= p.retrigger {
+				inMotion = false
+				p.Publish(MotionStop, newValue)
 			}
 		}
-	}()
+		return err
+	})
 	return
 }
 
-// Halt stops polling the button for new information
-func (p *PIRMotionDriver) Halt() (err error) {
-	p.halt <- true
+// shutdown stops polling the button for new information
+func (p *PIRMotionDriver) shutdown() (err error) {
+	p.poller.Stop()
 	return
 }
 
-// Name returns the PIRMotionDriver name
-func (p *PIRMotionDriver) Name() string { return p.name }
-
-// SetName sets the PIRMotionDriver name
-func (p *PIRMotionDriver) SetName(n string) { p.name = n }
-
 // Pin returns the PIRMotionDriver pin
 func (p *PIRMotionDriver) Pin() string { return p.pin }
-
-// Connection returns the PIRMotionDriver Connection
-func (p *PIRMotionDriver) Connection() gobot.Connection { return p.connection.(gobot.Connection) }
@@ -3,6 +3,7 @@ package gpio
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,10 +21,9 @@ func initTestPIRMotionDriver() *PIRMotionDriver {
 
 func TestPIRMotionDriverHalt(t *testing.T) {
 	d := initTestPIRMotionDriver()
-	go func() {
-		<-d.halt
-	}()
+	gobottest.Assert(t, d.Start(), nil)
 	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.poller.Running(), false)
 }
 
 func TestPIRMotionDriver(t *testing.T) {
@@ -31,7 +31,7 @@ func TestPIRMotionDriver(t *testing.T) {
 	gobottest.Refute(t, d.Connection(), nil)
 
 	d = NewPIRMotionDriver(newGpioTestAdaptor(), "1", 30*time.Second)
-	gobottest.Assert(t, d.interval, 30*time.Second)
+	gobottest.Assert(t, d.poller.Interval(), 30*time.Second)
 }
 
 func TestPIRMotionDriverStart(t *testing.T) {
@@ -99,3 +99,43 @@ func TestPIRDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestPIRMotionDriverRetriggerWindow(t *testing.T) {
+	d := NewPIRMotionDriver(newGpioTestAdaptor(), "1", time.Millisecond, WithRetriggerWindow(50*time.Millisecond))
+	gobottest.Assert(t, d.poller.Interval(), time.Millisecond)
+	gobottest.Assert(t, d.retrigger, 50*time.Millisecond)
+}
+
+func TestPIRMotionDriverMotionStartStop(t *testing.T) {
+	var mtx sync.Mutex
+	var events []string
+	seq := []int{1, 1, 0, 0, 1, 0, 0, 1, 0}
+
+	a := newGpioTestAdaptor()
+	a.TestAdaptorDigitalRead(func(string) (val int, err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		val = seq[0]
+		if len(seq) > 1 {
+			seq = seq[1:]
+		}
+		return
+	})
+	d := NewPIRMotionDriver(a, "1", 5*time.Millisecond, WithRetriggerWindow(30*time.Millisecond))
+	for _, e := range []string{MotionStart, MotionStop} {
+		event := e
+		d.On(event, func(data interface{}) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			events = append(events, event)
+		})
+	}
+
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(150 * time.Millisecond)
+	d.Halt()
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	gobottest.Assert(t, events, []string{MotionStart, MotionStop})
+}
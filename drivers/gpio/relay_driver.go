@@ -1,15 +1,11 @@
 package gpio
 
-import "gobot.io/x/gobot"
-
 // RelayDriver represents a digital relay
 type RelayDriver struct {
-	pin        string
-	name       string
-	connection DigitalWriter
-	high       bool
-	Inverted   bool
-	gobot.Commander
+	*Driver
+	pin      string
+	high     bool
+	Inverted bool
 }
 
 // NewRelayDriver return a new RelayDriver given a DigitalWriter and pin.
@@ -20,12 +16,10 @@ type RelayDriver struct {
 //	"Off" - See RelayDriver.Off
 func NewRelayDriver(a DigitalWriter, pin string) *RelayDriver {
 	l := &RelayDriver{
-		name:       gobot.DefaultName("Relay"),
-		pin:        pin,
-		connection: a,
-		high:       false,
-		Inverted:   false,
-		Commander:  gobot.NewCommander(),
+		Driver:   NewDriver(a, "Relay"),
+		pin:      pin,
+		high:     false,
+		Inverted: false,
 	}
 
 	l.AddCommand("Toggle", func(params map[string]interface{}) interface{} {
@@ -43,26 +37,9 @@ func NewRelayDriver(a DigitalWriter, pin string) *RelayDriver {
 	return l
 }
 
-// Start implements the Driver interface
-func (l *RelayDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface
-func (l *RelayDriver) Halt() (err error) { return }
-
-// Name returns the RelayDrivers name
-func (l *RelayDriver) Name() string { return l.name }
-
-// SetName sets the RelayDrivers name
-func (l *RelayDriver) SetName(n string) { l.name = n }
-
 // Pin returns the RelayDrivers name
 func (l *RelayDriver) Pin() string { return l.pin }
 
-// Connection returns the RelayDrivers Connection
-func (l *RelayDriver) Connection() gobot.Connection {
-	return l.connection.(gobot.Connection)
-}
-
 // State return true if the relay is On and false if the relay is Off
 func (l *RelayDriver) State() bool {
 	if l.Inverted {
@@ -77,7 +54,7 @@ func (l *RelayDriver) On() (err error) {
 	if l.Inverted {
 		newValue = 0
 	}
-	if err = l.connection.DigitalWrite(l.Pin(), newValue); err != nil {
+	if err = l.digitalWrite(l.Pin(), newValue); err != nil {
 		return
 	}
 
@@ -96,7 +73,7 @@ func (l *RelayDriver) Off() (err error) {
 	if l.Inverted {
 		newValue = 1
 	}
-	if err = l.connection.DigitalWrite(l.Pin(), newValue); err != nil {
+	if err = l.digitalWrite(l.Pin(), newValue); err != nil {
 		return
 	}
 
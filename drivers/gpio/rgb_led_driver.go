@@ -1,19 +1,19 @@
 package gpio
 
-import "gobot.io/x/gobot"
+import (
+	"gobot.io/x/gobot"
+)
 
 // RgbLedDriver represents a digital RGB Led
 type RgbLedDriver struct {
+	*Driver
 	pinRed     string
 	redColor   byte
 	pinGreen   string
 	greenColor byte
 	pinBlue    string
 	blueColor  byte
-	name       string
-	connection DigitalWriter
 	high       bool
-	gobot.Commander
 }
 
 // NewRgbLedDriver return a new RgbLedDriver given a DigitalWriter and
@@ -26,21 +26,24 @@ type RgbLedDriver struct {
 //	"Off" - See RgbLedDriver.Off
 func NewRgbLedDriver(a DigitalWriter, redPin string, greenPin string, bluePin string) *RgbLedDriver {
 	l := &RgbLedDriver{
-		name:       gobot.DefaultName("RGBLED"),
-		pinRed:     redPin,
-		pinGreen:   greenPin,
-		pinBlue:    bluePin,
-		connection: a,
-		high:       false,
-		Commander:  gobot.NewCommander(),
+		Driver:   NewDriver(a, "RGBLED"),
+		pinRed:   redPin,
+		pinGreen: greenPin,
+		pinBlue:  bluePin,
+		high:     false,
 	}
 
 	l.AddCommand("SetRGB", func(params map[string]interface{}) interface{} {
-		r := byte(params["r"].(int))
-		g := byte(params["g"].(int))
-		b := byte(params["b"].(int))
+		r := byte(gobot.CommandParamFloat(params["r"]))
+		g := byte(gobot.CommandParamFloat(params["g"]))
+		b := byte(gobot.CommandParamFloat(params["b"]))
 		return l.SetRGB(r, g, b)
 	})
+	l.AddCommandSchema("SetRGB", gobot.CommandSchema{
+		"r": gobot.CommandParamNumber,
+		"g": gobot.CommandParamNumber,
+		"b": gobot.CommandParamNumber,
+	})
 
 	l.AddCommand("Toggle", func(params map[string]interface{}) interface{} {
 		return l.Toggle()
@@ -57,18 +60,6 @@ func NewRgbLedDriver(a DigitalWriter, redPin string, greenPin string, bluePin st
 	return l
 }
 
-// Start implements the Driver interface
-func (l *RgbLedDriver) Start() (err error) { return }
-
-// Halt implements the Driver interface
-func (l *RgbLedDriver) Halt() (err error) { return }
-
-// Name returns the RGBLEDDrivers name
-func (l *RgbLedDriver) Name() string { return l.name }
-
-// SetName sets the RGBLEDDrivers name
-func (l *RgbLedDriver) SetName(n string) { l.name = n }
-
 // Pin returns the RgbLedDrivers pins
 func (l *RgbLedDriver) Pin() string { return "r=" + l.pinRed + ", g=" + l.pinGreen + ", b=" + l.pinBlue }
 
@@ -81,11 +72,6 @@ func (l *RgbLedDriver) GreenPin() string { return l.pinGreen }
 // BluePin returns the RgbLedDrivers bluePin
 func (l *RgbLedDriver) BluePin() string { return l.pinBlue }
 
-// Connection returns the RgbLedDriver Connection
-func (l *RgbLedDriver) Connection() gobot.Connection {
-	return l.connection.(gobot.Connection)
-}
-
 // State return true if the led is On and false if the led is Off
 func (l *RgbLedDriver) State() bool {
 	return l.high
@@ -139,10 +125,7 @@ func (l *RgbLedDriver) Toggle() (err error) {
 
 // SetLevel sets the led to the specified color level
 func (l *RgbLedDriver) SetLevel(pin string, level byte) (err error) {
-	if writer, ok := l.connection.(PwmWriter); ok {
-		return writer.PwmWrite(pin, level)
-	}
-	return ErrPwmWriteUnsupported
+	return l.pwmWrite(pin, level)
 }
 
 // SetRGB sets the Red Green Blue value of the LED.
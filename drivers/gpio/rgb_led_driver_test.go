@@ -52,6 +52,12 @@ func TestRgbLedDriver(t *testing.T) {
 
 	err = d.Command("SetRGB")(map[string]interface{}{"r": 0xff, "g": 0xff, "b": 0xff})
 	gobottest.Assert(t, err.(error), errors.New("pwm error"))
+
+	// numbers of a JSON request are decoded as float64
+	d.Command("SetRGB")(map[string]interface{}{"r": 255.0, "g": 0.0, "b": 0.0})
+	gobottest.Assert(t, d.redColor, byte(255))
+	gobottest.Assert(t, d.greenColor, byte(0))
+	gobottest.Assert(t, len(d.CommandSchema("SetRGB").Validate(map[string]interface{}{"r": "1"})), 3)
 }
 
 func TestRgbLedDriverStart(t *testing.T) {
@@ -1,36 +1,120 @@
 package gpio
 
-import "gobot.io/x/gobot"
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// servoFrame is the interval between two intermediate positions of a move,
+// which is the usual period of a servo signal
+const servoFrame = 20 * time.Millisecond
+
+const (
+	// servoMaxPeriod is the longest period of a PWM pin, which is accepted
+	// when the period can't be set to servoFrame
+	servoMaxPeriod = 25 * time.Millisecond
+
+	servoDefaultMinPulse = 500 * time.Microsecond
+	servoDefaultMaxPulse = 2500 * time.Microsecond
+)
+
+// ServoSignal describes the signal generated for a servo
+type ServoSignal struct {
+	// PWMPin is true, when the signal is generated by a PWM pin of the
+	// adaptor, otherwise it is written by ServoWrite, which may be a
+	// software PWM and the period and pulse are unknown
+	PWMPin bool
+	Period time.Duration
+	Pulse  time.Duration
+}
+
+// EasingFunc maps the elapsed part of a move between 0 and 1 to the part of
+// the distance between 0 and 1 which should be reached at that time
+type EasingFunc func(t float64) float64
+
+// EaseLinear moves with constant speed
+func EaseLinear(t float64) float64 { return t }
+
+// EaseInQuad accelerates from zero speed
+func EaseInQuad(t float64) float64 { return t * t }
+
+// EaseOutQuad decelerates to zero speed
+func EaseOutQuad(t float64) float64 { return t * (2 - t) }
+
+// EaseInOutQuad accelerates until halfway, then decelerates
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInOutSine accelerates and decelerates along a sine curve
+func EaseInOutSine(t float64) float64 { return -(math.Cos(math.Pi*t) - 1) / 2 }
 
 // ServoDriver Represents a Servo
 type ServoDriver struct {
-	name       string
-	pin        string
-	connection ServoWriter
-	gobot.Commander
+	*Driver
+	pin       string
+	maxSpeed  float64
+	usePWMPin bool
+	pwmPin    gobot.PWMPinner
+	period    time.Duration
+	minPulse  time.Duration
+	maxPulse  time.Duration
+	tolerance time.Duration
+	halt      chan bool
+	done      chan bool
+	mutex     gobot.Mutex
+	// moveMutex serializes stopping a running move and starting the next one
+	moveMutex sync.Mutex
+	gobot.Eventer
 	CurrentAngle byte
 }
 
-// NewServoDriver returns a new ServoDriver given a ServoWriter and pin.
+// ServoOption is an option of the ServoDriver
+type ServoOption func(*ServoDriver)
+
+// NewServoDriver returns a new ServoDriver given a ServoWriter and pin. When
+// the adaptor provides a PWM pin for the servo pin, the signal is generated by
+// the PWM pin, otherwise by ServoWrite of the adaptor.
+//
+// Optionally accepts:
+//  ServoOption: Options of the servo, e.g. WithServoWrite()
 //
 // Adds the following API Commands:
 // 	"Move" - See ServoDriver.Move
 //		"Min" - See ServoDriver.Min
 //		"Center" - See ServoDriver.Center
 //		"Max" - See ServoDriver.Max
-func NewServoDriver(a ServoWriter, pin string) *ServoDriver {
+func NewServoDriver(a ServoWriter, pin string, options ...ServoOption) *ServoDriver {
 	s := &ServoDriver{
-		name:         gobot.DefaultName("Servo"),
-		connection:   a,
+		Driver:       NewDriver(a, "Servo"),
 		pin:          pin,
-		Commander:    gobot.NewCommander(),
+		usePWMPin:    true,
+		minPulse:     servoDefaultMinPulse,
+		maxPulse:     servoDefaultMaxPulse,
+		Eventer:      gobot.NewEventer(),
 		CurrentAngle: 0,
 	}
+	s.afterStart = s.initialize
+	s.beforeHalt = s.shutdown
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.AddEvent(Error)
 
 	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
 		angle := byte(params["angle"].(float64))
 		return s.Move(angle)
 	})
+	s.AddCommandSchema("Move", gobot.CommandSchema{"angle": gobot.CommandParamNumber})
 	s.AddCommand("Min", func(params map[string]interface{}) interface{} {
 		return s.Min()
 	})
@@ -45,31 +129,287 @@ func NewServoDriver(a ServoWriter, pin string) *ServoDriver {
 
 }
 
-// Name returns the ServoDrivers name
-func (s *ServoDriver) Name() string { return s.name }
+// Contentions returns how often a caller had to wait for the driver
+func (s *ServoDriver) Contentions() uint64 {
+	return s.Driver.Contentions() + s.mutex.Contentions()
+}
+
+// WithServoPWMPin option generates the signal by a PWM pin of the adaptor,
+// when the adaptor provides one for the servo pin. The angle is mapped to the
+// pulse range set by SetPulseRange then, instead of the mapping of
+// ServoWrite of the adaptor.
+//
+// Deprecated: a PWM pin is used by default, see WithServoWrite.
+func WithServoPWMPin() ServoOption {
+	return func(s *ServoDriver) {
+		s.usePWMPin = true
+	}
+}
 
-// SetName sets the ServoDrivers name
-func (s *ServoDriver) SetName(n string) { s.name = n }
+// WithServoWrite option generates the signal by ServoWrite of the adaptor,
+// even if the adaptor provides a PWM pin for the servo pin, e.g. to keep the
+// angle mapping of the adaptor.
+func WithServoWrite() ServoOption {
+	return func(s *ServoDriver) {
+		s.usePWMPin = false
+	}
+}
 
 // Pin returns the ServoDrivers pin
 func (s *ServoDriver) Pin() string { return s.pin }
 
-// Connection returns the ServoDrivers connection
-func (s *ServoDriver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
+// initialize prepares the signal of the servo. With an adaptor, which
+// provides a PWM pin for the servo pin, the signal is generated by the PWM pin
+// with the usual servo period of 20ms, otherwise or with the WithServoWrite
+// option ServoWrite of the adaptor is used.
+func (s *ServoDriver) initialize() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.pwmPin = nil
+	if !s.usePWMPin {
+		return
+	}
+	provider, ok := s.connection.(gobot.PWMPinnerProvider)
+	if !ok {
+		return
+	}
+	pin, err := provider.PWMPin(s.pin)
+	if err != nil {
+		// no PWM at this pin, fall back to ServoWrite
+		return nil
+	}
+	if pin.SetPeriod(uint32(servoFrame)) == nil {
+		s.period = servoFrame
+	} else {
+		// some adaptors have a fixed period, which may be usable as well
+		period, err := pin.Period()
+		if err != nil || time.Duration(period) < s.maxPulse || time.Duration(period) > servoMaxPeriod {
+			return nil
+		}
+		s.period = time.Duration(period)
+	}
+	s.pwmPin = pin
+	return
+}
+
+// shutdown stops a running move
+func (s *ServoDriver) shutdown() (err error) {
+	s.moveMutex.Lock()
+	defer s.moveMutex.Unlock()
 
-// Start implements the Driver interface
-func (s *ServoDriver) Start() (err error) { return }
+	s.stop()
+	return
+}
 
-// Halt implements the Driver interface
-func (s *ServoDriver) Halt() (err error) { return }
+// SetMaxSpeed limits the speed of all moves to the given degrees per second,
+// moves are interpolated in the background then. 0 disables the limit.
+func (s *ServoDriver) SetMaxSpeed(degreesPerSecond float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.maxSpeed = math.Max(degreesPerSecond, 0)
+}
 
-// Move sets the servo to the specified angle. Acceptable angles are 0-180
+// SetPulseRange sets the pulse widths of the servo signal at 0 and 180
+// degrees, defaults are 0.5ms and 2.5ms. The range is used, when the signal is
+// generated by a PWM pin, see NewServoDriver.
+func (s *ServoDriver) SetPulseRange(min, max time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.minPulse, s.maxPulse = min, max
+}
+
+// SetVerifyTolerance enables the verification of the signal of a PWM pin.
+// After each write, the period and pulse width are read back, a deviation of
+// more than the tolerance is returned as error. 0 disables the verification.
+func (s *ServoDriver) SetVerifyTolerance(tolerance time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tolerance = tolerance
+}
+
+// Signal returns how the signal of the servo is generated. For a PWM pin
+// the period and pulse width are read back from the pin.
+func (s *ServoDriver) Signal() (signal ServoSignal, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pwmPin == nil {
+		return
+	}
+	signal.PWMPin = true
+	period, err := s.pwmPin.Period()
+	if err != nil {
+		return
+	}
+	duty, err := s.pwmPin.DutyCycle()
+	if err != nil {
+		return
+	}
+	signal.Period, signal.Pulse = time.Duration(period), time.Duration(duty)
+	return
+}
+
+// Move sets the servo to the specified angle. Acceptable angles are 0-180.
+// If a max speed is set, the move is interpolated in the background.
 func (s *ServoDriver) Move(angle uint8) (err error) {
 	if !(angle >= 0 && angle <= 180) {
 		return ErrServoOutOfRange
 	}
+
+	s.mutex.Lock()
+	maxSpeed := s.maxSpeed
+	s.mutex.Unlock()
+	if maxSpeed > 0 {
+		return s.MoveWithEasing(angle, 0, EaseLinear)
+	}
+
+	s.moveMutex.Lock()
+	defer s.moveMutex.Unlock()
+
+	s.stop()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.CurrentAngle = angle
-	return s.connection.ServoWrite(s.Pin(), angle)
+	return s.write(angle)
+}
+
+// MoveWithEasing moves the servo to the specified angle within the given
+// duration in the background, the intermediate positions are calculated by the
+// easing function, e.g. EaseInOutQuad. The duration is extended if needed to
+// keep the max speed. A running move is stopped before.
+// Emits the Events:
+//	Error error - Event is emitted when writing an intermediate position fails.
+func (s *ServoDriver) MoveWithEasing(angle uint8, duration time.Duration, easing EasingFunc) (err error) {
+	if !(angle >= 0 && angle <= 180) {
+		return ErrServoOutOfRange
+	}
+
+	s.moveMutex.Lock()
+	defer s.moveMutex.Unlock()
+
+	s.stop()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	start := s.CurrentAngle
+	if s.maxSpeed > 0 {
+		min := time.Duration(math.Abs(float64(angle)-float64(start)) / s.maxSpeed * float64(time.Second))
+		if duration < min {
+			duration = min
+		}
+	}
+	if duration < servoFrame {
+		s.CurrentAngle = angle
+		return s.write(angle)
+	}
+
+	s.halt = make(chan bool)
+	s.done = make(chan bool)
+	go s.ease(start, angle, duration, easing, s.halt, s.done)
+	return
+}
+
+// Wait blocks until a running move is completed or halted
+func (s *ServoDriver) Wait() {
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// stop stops a running move, the moveMutex must be held by the caller
+func (s *ServoDriver) stop() {
+	s.mutex.Lock()
+	halt, done := s.halt, s.done
+	s.mutex.Unlock()
+
+	if done == nil {
+		return
+	}
+	select {
+	case halt <- true:
+	case <-done:
+	}
+	<-done
+}
+
+func (s *ServoDriver) ease(start, target uint8, duration time.Duration, easing EasingFunc, halt chan bool, done chan bool) {
+	defer close(done)
+
+	ticker := time.NewTicker(servoFrame)
+	defer ticker.Stop()
+
+	begin := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+		case <-halt:
+			return
+		}
+
+		t := math.Min(float64(time.Since(begin))/float64(duration), 1)
+		pos := float64(start) + (float64(target)-float64(start))*easing(t)
+		angle := byte(math.Round(math.Min(math.Max(pos, 0), 180)))
+
+		s.mutex.Lock()
+		var err error
+		if angle != s.CurrentAngle || t == 1 {
+			s.CurrentAngle = angle
+			err = s.write(angle)
+		}
+		s.mutex.Unlock()
+
+		if err != nil {
+			s.Publish(s.Event(Error), err)
+			return
+		}
+		if t == 1 {
+			return
+		}
+	}
+}
+
+// write sets the angle by the PWM pin or by ServoWrite, the mutex must be
+// held by the caller
+func (s *ServoDriver) write(angle uint8) (err error) {
+	if s.pwmPin == nil {
+		return s.servoWrite(s.Pin(), angle)
+	}
+
+	pulse := s.minPulse + time.Duration(float64(s.maxPulse-s.minPulse)*float64(angle)/180)
+	if err := s.pwmPin.SetDutyCycle(uint32(pulse)); err != nil {
+		return err
+	}
+	if s.tolerance <= 0 {
+		return nil
+	}
+
+	period, err := s.pwmPin.Period()
+	if err != nil {
+		return err
+	}
+	duty, err := s.pwmPin.DutyCycle()
+	if err != nil {
+		return err
+	}
+	if servoDeviation(time.Duration(period), s.period) > s.tolerance ||
+		servoDeviation(time.Duration(duty), pulse) > s.tolerance {
+		return fmt.Errorf("Servo signal at pin %s has period %v and pulse %v instead of %v and %v",
+			s.pin, time.Duration(period), time.Duration(duty), s.period, pulse)
+	}
+	return nil
+}
+
+func servoDeviation(a, b time.Duration) time.Duration {
+	if a > b {
+		return a - b
+	}
+	return b - a
 }
 
 // Min sets the servo to it's minimum position
@@ -2,8 +2,11 @@ package gpio
 
 import (
 	"errors"
+	"math"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -87,3 +90,231 @@ func TestServoDriverSetName(t *testing.T) {
 	d.SetName("mybot")
 	gobottest.Assert(t, d.Name(), "mybot")
 }
+
+func TestServoDriverEasingFuncs(t *testing.T) {
+	for _, f := range []EasingFunc{EaseLinear, EaseInQuad, EaseOutQuad, EaseInOutQuad, EaseInOutSine} {
+		gobottest.Assert(t, math.Abs(f(0)) < 1e-9, true)
+		gobottest.Assert(t, math.Abs(f(1)-1) < 1e-9, true)
+	}
+	gobottest.Assert(t, EaseInQuad(0.5), 0.25)
+	gobottest.Assert(t, EaseOutQuad(0.5), 0.75)
+	gobottest.Assert(t, EaseInOutQuad(0.25), 0.125)
+	gobottest.Assert(t, EaseInOutQuad(0.75), 0.875)
+}
+
+func TestServoDriverMoveWithEasing(t *testing.T) {
+	var mtx sync.Mutex
+	var angles []byte
+	a := newGpioTestAdaptor()
+	a.TestAdaptorServoWrite(func(pin string, val byte) (err error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		angles = append(angles, val)
+		return
+	})
+	d := NewServoDriver(a, "1")
+
+	gobottest.Assert(t, d.MoveWithEasing(90, 200*time.Millisecond, EaseInOutQuad), nil)
+	d.Wait()
+	gobottest.Assert(t, d.CurrentAngle, uint8(90))
+
+	mtx.Lock()
+	gobottest.Assert(t, len(angles) > 3, true)
+	gobottest.Assert(t, angles[len(angles)-1], byte(90))
+	for i := 1; i < len(angles); i++ {
+		gobottest.Assert(t, angles[i] >= angles[i-1], true)
+	}
+	mtx.Unlock()
+
+	// too short for intermediate positions
+	gobottest.Assert(t, d.MoveWithEasing(10, 0, EaseLinear), nil)
+	gobottest.Assert(t, d.CurrentAngle, uint8(10))
+
+	gobottest.Assert(t, d.MoveWithEasing(200, time.Second, EaseLinear), ErrServoOutOfRange)
+}
+
+func TestServoDriverMoveWithEasingHalt(t *testing.T) {
+	d := initTestServoDriver()
+	gobottest.Assert(t, d.MoveWithEasing(180, time.Second, EaseLinear), nil)
+	time.Sleep(100 * time.Millisecond)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	angle := d.CurrentAngle
+	gobottest.Assert(t, angle > 0 && angle < 180, true)
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, d.CurrentAngle, angle)
+}
+
+func TestServoDriverMoveWithEasingError(t *testing.T) {
+	a := newGpioTestAdaptor()
+	d := NewServoDriver(a, "1")
+	a.TestAdaptorServoWrite(func(string, byte) (err error) {
+		return errors.New("pwm error")
+	})
+
+	sem := make(chan bool, 1)
+	d.Once(d.Event(Error), func(data interface{}) {
+		gobottest.Assert(t, data.(error).Error(), "pwm error")
+		sem <- true
+	})
+	gobottest.Assert(t, d.MoveWithEasing(90, 100*time.Millisecond, EaseLinear), nil)
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("Servo Event \"Error\" was not published")
+	}
+}
+
+func TestServoDriverMaxSpeed(t *testing.T) {
+	d := initTestServoDriver()
+	d.SetMaxSpeed(600)
+
+	start := time.Now()
+	gobottest.Assert(t, d.Move(60), nil)
+	gobottest.Assert(t, d.CurrentAngle < 60, true)
+	d.Wait()
+	gobottest.Assert(t, d.CurrentAngle, uint8(60))
+	gobottest.Assert(t, time.Since(start) >= 100*time.Millisecond, true)
+
+	d.SetMaxSpeed(0)
+	gobottest.Assert(t, d.Move(0), nil)
+	gobottest.Assert(t, d.CurrentAngle, uint8(0))
+}
+
+// servoTestPWMPin records the duty cycle, the period can be fixed
+type servoTestPWMPin struct {
+	period      uint32
+	duty        uint32
+	fixedPeriod bool
+	dutyOffset  uint32
+}
+
+func (p *servoTestPWMPin) Export() error                    { return nil }
+func (p *servoTestPWMPin) Unexport() error                  { return nil }
+func (p *servoTestPWMPin) Enable(bool) error                { return nil }
+func (p *servoTestPWMPin) Polarity() (string, error)        { return "normal", nil }
+func (p *servoTestPWMPin) InvertPolarity(invert bool) error { return nil }
+func (p *servoTestPWMPin) Period() (uint32, error)          { return p.period, nil }
+func (p *servoTestPWMPin) DutyCycle() (uint32, error)       { return p.duty + p.dutyOffset, nil }
+
+func (p *servoTestPWMPin) SetPeriod(period uint32) error {
+	if p.fixedPeriod {
+		return errors.New("fixed period")
+	}
+	p.period = period
+	return nil
+}
+
+func (p *servoTestPWMPin) SetDutyCycle(duty uint32) error {
+	p.duty = duty
+	return nil
+}
+
+type servoPWMTestAdaptor struct {
+	gpioTestAdaptor
+	pins map[string]*servoTestPWMPin
+}
+
+func (a *servoPWMTestAdaptor) PWMPin(pin string) (gobot.PWMPinner, error) {
+	if p, ok := a.pins[pin]; ok {
+		return p, nil
+	}
+	return nil, errors.New("Not a PWM pin")
+}
+
+func TestServoDriverPWMPin(t *testing.T) {
+	pin := &servoTestPWMPin{period: 500000}
+	a := &servoPWMTestAdaptor{pins: map[string]*servoTestPWMPin{"1": pin}}
+	a.testAdaptorServoWrite = func(string, byte) (err error) {
+		t.Errorf("ServoWrite used with a PWM pin")
+		return
+	}
+	d := NewServoDriver(a, "1")
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, pin.period, uint32(20*time.Millisecond))
+
+	gobottest.Assert(t, d.Move(90), nil)
+	gobottest.Assert(t, pin.duty, uint32(1500*time.Microsecond))
+	d.SetPulseRange(time.Millisecond, 2*time.Millisecond)
+	gobottest.Assert(t, d.Max(), nil)
+	gobottest.Assert(t, pin.duty, uint32(2*time.Millisecond))
+
+	signal, err := d.Signal()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, signal, ServoSignal{PWMPin: true, Period: 20 * time.Millisecond, Pulse: 2 * time.Millisecond})
+
+	// verification of the signal
+	d.SetVerifyTolerance(10 * time.Microsecond)
+	pin.dutyOffset = uint32(5 * time.Microsecond)
+	gobottest.Assert(t, d.Min(), nil)
+	pin.dutyOffset = uint32(50 * time.Microsecond)
+	gobottest.Assert(t, d.Min(), errors.New("Servo signal at pin 1 has period 20ms and pulse 1.05ms instead of 20ms and 1ms"))
+}
+
+func TestServoDriverPWMPinFallback(t *testing.T) {
+	var writes []byte
+	a := &servoPWMTestAdaptor{pins: map[string]*servoTestPWMPin{
+		"2": {period: uint32(10 * time.Millisecond), fixedPeriod: true},
+		"3": {period: uint32(time.Millisecond), fixedPeriod: true},
+	}}
+	a.testAdaptorServoWrite = func(pin string, val byte) (err error) {
+		writes = append(writes, val)
+		return
+	}
+
+	// a fixed period is used, when it fits for a servo
+	d := NewServoDriver(a, "2")
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Move(0), nil)
+	gobottest.Assert(t, a.pins["2"].duty, uint32(500*time.Microsecond))
+	gobottest.Assert(t, writes, []byte(nil))
+
+	// no or an unusable PWM pin falls back to ServoWrite
+	for _, pin := range []string{"1", "3"} {
+		d = NewServoDriver(a, pin)
+		gobottest.Assert(t, d.Start(), nil)
+		gobottest.Assert(t, d.Move(45), nil)
+		signal, err := d.Signal()
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, signal.PWMPin, false)
+	}
+	gobottest.Assert(t, writes, []byte{45, 45})
+}
+
+func TestServoDriverWithServoWrite(t *testing.T) {
+	var writes []byte
+	a := &servoPWMTestAdaptor{pins: map[string]*servoTestPWMPin{"1": {}}}
+	a.testAdaptorServoWrite = func(pin string, val byte) (err error) {
+		writes = append(writes, val)
+		return
+	}
+	d := NewServoDriver(a, "1", WithServoWrite())
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Move(90), nil)
+	gobottest.Assert(t, writes, []byte{90})
+	gobottest.Assert(t, a.pins["1"].duty, uint32(0))
+}
+
+func TestServoDriverMoveWithEasingConcurrent(t *testing.T) {
+	d := initTestServoDriver()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.MoveWithEasing(180, time.Second, EaseLinear)
+		}()
+	}
+	wg.Wait()
+
+	// no move is left running after Halt
+	gobottest.Assert(t, d.Halt(), nil)
+	d.mutex.Lock()
+	angle := d.CurrentAngle
+	d.mutex.Unlock()
+	time.Sleep(3 * servoFrame)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	gobottest.Assert(t, d.CurrentAngle, angle)
+}
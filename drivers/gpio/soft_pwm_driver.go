@@ -0,0 +1,222 @@
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// SoftPWMMaxFrequency is the maximum frequency in Hz of the SoftPWMDriver
+const SoftPWMMaxFrequency = 1000.0
+
+// SoftPWMDriver generates a PWM signal on a pin of a DigitalWriter by toggling
+// the pin in a goroutine. It can be used for LEDs and slow actuators on pins
+// without hardware PWM. The timing depends on the scheduler of the OS, so it
+// is not suitable for servos or other devices which need an exact pulse width.
+type SoftPWMDriver struct {
+	*Driver
+	pin       string
+	frequency float64
+	duty      float64
+	level     int
+	halt      chan bool
+	running   bool
+	mutex     gobot.Mutex
+	gobot.Eventer
+}
+
+// NewSoftPWMDriver returns a new SoftPWMDriver with a frequency of 100Hz and a
+// duty cycle of 0 given a DigitalWriter and pin.
+//
+// Optionally accepts:
+// 	float64: frequency of the PWM signal in Hz
+//
+// Adds the following API Commands:
+//	"DutyCycle" - See SoftPWMDriver.SetDutyCycle
+//	"PwmWrite" - See SoftPWMDriver.PwmWrite
+func NewSoftPWMDriver(a DigitalWriter, pin string, v ...float64) *SoftPWMDriver {
+	s := &SoftPWMDriver{
+		Driver:    NewDriver(a, "SoftPWM"),
+		pin:       pin,
+		frequency: 100,
+		level:     -1,
+		Eventer:   gobot.NewEventer(),
+	}
+	s.afterStart = s.initialize
+	s.beforeHalt = s.shutdown
+
+	if len(v) > 0 {
+		s.SetFrequency(v[0])
+	}
+
+	s.AddEvent(Error)
+
+	s.AddCommand("DutyCycle", func(params map[string]interface{}) interface{} {
+		s.SetDutyCycle(gobot.CommandParamFloat(params["duty"]))
+		return nil
+	})
+	s.AddCommandSchema("DutyCycle", gobot.CommandSchema{"duty": gobot.CommandParamNumber})
+
+	s.AddCommand("PwmWrite", func(params map[string]interface{}) interface{} {
+		level := byte(gobot.CommandParamFloat(params["level"]))
+		return s.PwmWrite(s.pin, level)
+	})
+	s.AddCommandSchema("PwmWrite", gobot.CommandSchema{"level": gobot.CommandParamNumber})
+
+	return s
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (s *SoftPWMDriver) Contentions() uint64 {
+	return s.Driver.Contentions() + s.mutex.Contentions()
+}
+
+// Pin returns the SoftPWMDrivers pin
+func (s *SoftPWMDriver) Pin() string { return s.pin }
+
+// initialize starts the goroutine which generates the PWM signal
+// Emits the Events:
+//	Error error - Event is emitted when writing to the pin fails, it is
+//	emitted again only after a successful write.
+func (s *SoftPWMDriver) initialize() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return
+	}
+	s.running = true
+	s.halt = make(chan bool)
+	go s.run(s.halt)
+	return
+}
+
+// shutdown stops the PWM signal and sets the pin to low
+func (s *SoftPWMDriver) shutdown() (err error) {
+	s.mutex.Lock()
+	if !s.running {
+		s.mutex.Unlock()
+		return
+	}
+	s.running = false
+	halt := s.halt
+	s.mutex.Unlock()
+
+	halt <- true
+	return s.write(0)
+}
+
+// SetFrequency sets the frequency of the PWM signal in Hz
+func (s *SoftPWMDriver) SetFrequency(frequency float64) (err error) {
+	if frequency <= 0 || frequency > SoftPWMMaxFrequency {
+		return fmt.Errorf("SoftPWM frequency must be between 0 and %.0fHz", SoftPWMMaxFrequency)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.frequency = frequency
+	return
+}
+
+// Frequency returns the frequency of the PWM signal in Hz
+func (s *SoftPWMDriver) Frequency() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.frequency
+}
+
+// SetDutyCycle sets the ratio of the high phase to the period, between 0 and 1
+func (s *SoftPWMDriver) SetDutyCycle(duty float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.duty = math.Min(math.Max(duty, 0), 1)
+}
+
+// DutyCycle returns the ratio of the high phase to the period
+func (s *SoftPWMDriver) DutyCycle() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.duty
+}
+
+// PwmWrite sets the duty cycle given a value between 0 and 255, like
+// PwmWriter.PwmWrite does for hardware PWM. The pin must be the pin of the driver.
+func (s *SoftPWMDriver) PwmWrite(pin string, level byte) (err error) {
+	if pin != s.pin {
+		return errors.New("SoftPWM is not running on pin " + pin)
+	}
+	s.SetDutyCycle(float64(level) / 255)
+	return
+}
+
+// DigitalWrite sets the duty cycle to 0 or 1, like DigitalWriter.DigitalWrite
+// does. The pin must be the pin of the driver.
+func (s *SoftPWMDriver) DigitalWrite(pin string, level byte) (err error) {
+	if pin != s.pin {
+		return errors.New("SoftPWM is not running on pin " + pin)
+	}
+	if level > 0 {
+		s.SetDutyCycle(1)
+	} else {
+		s.SetDutyCycle(0)
+	}
+	return
+}
+
+func (s *SoftPWMDriver) run(halt chan bool) {
+	timer := time.NewTimer(0)
+	<-timer.C
+	wait := func(d time.Duration) bool {
+		timer.Reset(d)
+		select {
+		case <-timer.C:
+			return true
+		case <-halt:
+			timer.Stop()
+			return false
+		}
+	}
+
+	failed := false
+	write := func(level int) {
+		err := s.write(level)
+		if err != nil && !failed {
+			s.Publish(s.Event(Error), err)
+		}
+		failed = err != nil
+	}
+
+	for {
+		s.mutex.Lock()
+		period := time.Duration(float64(time.Second) / s.frequency)
+		high := time.Duration(float64(period) * s.duty)
+		s.mutex.Unlock()
+
+		if high > 0 {
+			write(1)
+			if !wait(high) {
+				return
+			}
+		}
+		if high < period {
+			write(0)
+			if !wait(period - high) {
+				return
+			}
+		}
+	}
+}
+
+// write sets the level of the pin, if it was changed
+func (s *SoftPWMDriver) write(level int) (err error) {
+	if level == s.level {
+		return
+	}
+	if err = s.digitalWrite(s.pin, byte(level)); err == nil {
+		s.level = level
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*SoftPWMDriver)(nil)
+var _ PwmWriter = (*SoftPWMDriver)(nil)
+var _ DigitalWriter = (*SoftPWMDriver)(nil)
+
+type softPWMTestWrites struct {
+	mtx    sync.Mutex
+	levels []byte
+}
+
+func (w *softPWMTestWrites) count(level byte) (n int) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	for _, l := range w.levels {
+		if l == level {
+			n++
+		}
+	}
+	return
+}
+
+func initTestSoftPWMDriver(v ...float64) (*SoftPWMDriver, *softPWMTestWrites) {
+	a := newGpioTestAdaptor()
+	w := &softPWMTestWrites{}
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		w.mtx.Lock()
+		defer w.mtx.Unlock()
+		w.levels = append(w.levels, val)
+		return
+	})
+	return NewSoftPWMDriver(a, "3", v...), w
+}
+
+func TestSoftPWMDriver(t *testing.T) {
+	d, _ := initTestSoftPWMDriver()
+	gobottest.Assert(t, d.Pin(), "3")
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.Frequency(), 100.0)
+	gobottest.Assert(t, d.DutyCycle(), 0.0)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "SoftPWM"), true)
+
+	d.SetName("dimmer")
+	gobottest.Assert(t, d.Name(), "dimmer")
+
+	d, _ = initTestSoftPWMDriver(50)
+	gobottest.Assert(t, d.Frequency(), 50.0)
+}
+
+func TestSoftPWMDriverSetFrequency(t *testing.T) {
+	d, _ := initTestSoftPWMDriver()
+	gobottest.Assert(t, d.SetFrequency(400), nil)
+	gobottest.Assert(t, d.Frequency(), 400.0)
+	gobottest.Refute(t, d.SetFrequency(0), nil)
+	gobottest.Refute(t, d.SetFrequency(SoftPWMMaxFrequency+1), nil)
+	gobottest.Assert(t, d.Frequency(), 400.0)
+}
+
+func TestSoftPWMDriverDutyCycle(t *testing.T) {
+	d, _ := initTestSoftPWMDriver()
+	d.SetDutyCycle(1.5)
+	gobottest.Assert(t, d.DutyCycle(), 1.0)
+	d.SetDutyCycle(-1)
+	gobottest.Assert(t, d.DutyCycle(), 0.0)
+
+	gobottest.Assert(t, d.PwmWrite("3", 51), nil)
+	gobottest.Assert(t, d.DutyCycle(), 0.2)
+	gobottest.Refute(t, d.PwmWrite("4", 51), nil)
+
+	gobottest.Assert(t, d.DigitalWrite("3", 1), nil)
+	gobottest.Assert(t, d.DutyCycle(), 1.0)
+	gobottest.Assert(t, d.DigitalWrite("3", 0), nil)
+	gobottest.Assert(t, d.DutyCycle(), 0.0)
+	gobottest.Refute(t, d.DigitalWrite("4", 1), nil)
+
+	d.Command("DutyCycle")(map[string]interface{}{"duty": 0.75})
+	gobottest.Assert(t, d.DutyCycle(), 0.75)
+	gobottest.Assert(t, d.Command("PwmWrite")(map[string]interface{}{"level": 255.0}), nil)
+	gobottest.Assert(t, d.DutyCycle(), 1.0)
+	d.Command("DutyCycle")(map[string]interface{}{"duty": 0})
+	gobottest.Assert(t, d.DutyCycle(), 0.0)
+	gobottest.Assert(t, d.Command("PwmWrite")(map[string]interface{}{"level": 51}), nil)
+	gobottest.Assert(t, d.DutyCycle(), 0.2)
+}
+
+func TestSoftPWMDriverStartHalt(t *testing.T) {
+	d, w := initTestSoftPWMDriver(200)
+	d.SetDutyCycle(0.5)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(100 * time.Millisecond)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	gobottest.Assert(t, w.count(1) > 2, true)
+	gobottest.Assert(t, w.count(0) > 2, true)
+	gobottest.Assert(t, w.levels[len(w.levels)-1], byte(0))
+}
+
+func TestSoftPWMDriverConstantLevel(t *testing.T) {
+	d, w := initTestSoftPWMDriver(200)
+	d.SetDutyCycle(1)
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, w.count(1), 1)
+	gobottest.Assert(t, w.count(0), 0)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, w.count(0), 1)
+}
+
+func TestSoftPWMDriverWriteError(t *testing.T) {
+	a := newGpioTestAdaptor()
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		return errors.New("write error")
+	})
+	d := NewSoftPWMDriver(a, "3", 200)
+	d.SetDutyCycle(0.5)
+
+	errs := make(chan interface{}, 10)
+	d.On(d.Event(Error), func(data interface{}) { errs <- data })
+	gobottest.Assert(t, d.Start(), nil)
+	time.Sleep(50 * time.Millisecond)
+	d.Halt()
+
+	select {
+	case data := <-errs:
+		gobottest.Assert(t, data, errors.New("write error"))
+	case <-time.After(time.Second):
+		t.Errorf("SoftPWM Event \"Error\" was not published")
+	}
+	// the error is published only once while writing fails
+	time.Sleep(10 * time.Millisecond)
+	gobottest.Assert(t, len(errs), 0)
+}
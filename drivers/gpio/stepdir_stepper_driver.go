@@ -0,0 +1,421 @@
+package gpio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// StepDirA4988Microsteps are the levels of the MS1, MS2 and MS3 pins of the
+// A4988 for each microstep factor
+var StepDirA4988Microsteps = map[uint][]byte{
+	1:  {0, 0, 0},
+	2:  {1, 0, 0},
+	4:  {0, 1, 0},
+	8:  {1, 1, 0},
+	16: {1, 1, 1},
+}
+
+// StepDirDRV8825Microsteps are the levels of the M0, M1 and M2 pins of the
+// DRV8825 for each microstep factor
+var StepDirDRV8825Microsteps = map[uint][]byte{
+	1:  {0, 0, 0},
+	2:  {1, 0, 0},
+	4:  {0, 1, 0},
+	8:  {1, 1, 0},
+	16: {0, 0, 1},
+	32: {1, 0, 1},
+}
+
+// StepDirTMC2209Microsteps are the levels of the MS1 and MS2 pins of the
+// TMC2209 in standalone mode for each microstep factor
+var StepDirTMC2209Microsteps = map[uint][]byte{
+	8:  {0, 0},
+	16: {1, 1},
+	32: {1, 0},
+	64: {0, 1},
+}
+
+// StepDirStepperDriver represents a stepper motor behind a driver board with
+// STEP and DIR inputs, like the A4988, DRV8825 or TMC2209. Moves run in the
+// background with a trapezoidal speed profile.
+type StepDirStepperDriver struct {
+	*Driver
+	stepPin      string
+	dirPin       string
+	enablePin    string
+	microPins    []string
+	stepsPerRev  uint
+	microsteps   uint
+	speed        float64
+	acceleration float64
+	position     int64
+	direction    int
+	moving       bool
+	halt         chan bool
+	done         chan bool
+	mutex        gobot.Mutex
+	// moveMutex serializes stopping a running move and starting the next one
+	moveMutex sync.Mutex
+	gobot.Eventer
+}
+
+// NewStepDirStepperDriver returns a new StepDirStepperDriver given a
+// DigitalWriter, the STEP and DIR pins and the number of full steps per
+// revolution of the motor. By default the speed is one revolution per second
+// and the acceleration is not limited.
+//
+// Adds the following API Commands:
+//	"Move" - See StepDirStepperDriver.Move
+//	"MoveTo" - See StepDirStepperDriver.MoveTo
+//	"Halt" - See StepDirStepperDriver.Halt
+func NewStepDirStepperDriver(a DigitalWriter, stepPin string, dirPin string, stepsPerRev uint) *StepDirStepperDriver {
+	s := &StepDirStepperDriver{
+		Driver:      NewDriver(a, "StepDirStepper"),
+		stepPin:     stepPin,
+		dirPin:      dirPin,
+		stepsPerRev: stepsPerRev,
+		microsteps:  1,
+		speed:       float64(stepsPerRev),
+		Eventer:     gobot.NewEventer(),
+	}
+	s.afterStart = s.Enable
+	s.beforeHalt = s.shutdown
+
+	s.AddEvent(StepperMoveDone)
+	s.AddEvent(Error)
+
+	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
+		return s.Move(int64(gobot.CommandParamFloat(params["steps"])))
+	})
+	s.AddCommandSchema("Move", gobot.CommandSchema{"steps": gobot.CommandParamNumber})
+	s.AddCommand("MoveTo", func(params map[string]interface{}) interface{} {
+		return s.MoveTo(int64(gobot.CommandParamFloat(params["position"])))
+	})
+	s.AddCommandSchema("MoveTo", gobot.CommandSchema{"position": gobot.CommandParamNumber})
+	s.AddCommand("Halt", func(params map[string]interface{}) interface{} {
+		return s.Halt()
+	})
+
+	return s
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (s *StepDirStepperDriver) Contentions() uint64 {
+	return s.Driver.Contentions() + s.mutex.Contentions()
+}
+
+// shutdown stops a running move immediately and disables the driver board, if
+// an enable pin is set
+func (s *StepDirStepperDriver) shutdown() (err error) {
+	s.moveMutex.Lock()
+	s.stop()
+	s.moveMutex.Unlock()
+	return s.Disable()
+}
+
+// SetEnablePin sets the active low ENABLE pin of the driver board
+func (s *StepDirStepperDriver) SetEnablePin(pin string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.enablePin = pin
+}
+
+// Enable enables the outputs of the driver board, if an enable pin is set
+func (s *StepDirStepperDriver) Enable() (err error) {
+	s.mutex.Lock()
+	pin := s.enablePin
+	s.mutex.Unlock()
+
+	if pin == "" {
+		return
+	}
+	return s.digitalWrite(pin, 0)
+}
+
+// Disable disables the outputs of the driver board, if an enable pin is set,
+// so the motor can be turned freely
+func (s *StepDirStepperDriver) Disable() (err error) {
+	s.mutex.Lock()
+	pin := s.enablePin
+	s.mutex.Unlock()
+
+	if pin == "" {
+		return
+	}
+	return s.digitalWrite(pin, 1)
+}
+
+// SetMicrostepPins sets the pins which select the microstep factor, e.g.
+// MS1, MS2 and MS3 of the A4988
+func (s *StepDirStepperDriver) SetMicrostepPins(pins ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.microPins = pins
+}
+
+// SetMicrosteps writes the levels of the given table, e.g. StepDirA4988Microsteps,
+// to the microstep pins. Speed, acceleration and positions are given in
+// microsteps afterwards.
+func (s *StepDirStepperDriver) SetMicrosteps(factor uint, table map[uint][]byte) (err error) {
+	levels, ok := table[factor]
+	if !ok {
+		return fmt.Errorf("Microstep factor %d is not supported", factor)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(levels) != len(s.microPins) {
+		return errors.New("Number of microstep pins does not match the table")
+	}
+	for i, pin := range s.microPins {
+		if err = s.digitalWrite(pin, levels[i]); err != nil {
+			return
+		}
+	}
+	s.microsteps = factor
+	return
+}
+
+// Microsteps returns the microstep factor
+func (s *StepDirStepperDriver) Microsteps() uint {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.microsteps
+}
+
+// SetSpeed sets the maximum speed in steps per second
+func (s *StepDirStepperDriver) SetSpeed(stepsPerSecond float64) (err error) {
+	if stepsPerSecond <= 0 {
+		return errors.New("Speed must be greater than zero")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.speed = stepsPerSecond
+	return
+}
+
+// SetRPM sets the maximum speed in revolutions per minute
+func (s *StepDirStepperDriver) SetRPM(rpm float64) (err error) {
+	return s.SetSpeed(rpm * float64(s.stepsPerRev*s.Microsteps()) / 60)
+}
+
+// Speed returns the maximum speed in steps per second
+func (s *StepDirStepperDriver) Speed() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.speed
+}
+
+// SetAcceleration sets the acceleration and deceleration in steps per second²,
+// 0 disables the acceleration ramps
+func (s *StepDirStepperDriver) SetAcceleration(stepsPerSecond2 float64) (err error) {
+	if stepsPerSecond2 < 0 {
+		return errors.New("Acceleration must not be negative")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.acceleration = stepsPerSecond2
+	return
+}
+
+// Acceleration returns the acceleration in steps per second²
+func (s *StepDirStepperDriver) Acceleration() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.acceleration
+}
+
+// Position returns the current position in steps
+func (s *StepDirStepperDriver) Position() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.position
+}
+
+// SetPosition sets the current position in steps, e.g. after homing
+func (s *StepDirStepperDriver) SetPosition(position int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.position = position
+}
+
+// IsMoving returns whether a move is running
+func (s *StepDirStepperDriver) IsMoving() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.moving
+}
+
+// Move starts a move by the given number of steps relative to the current
+// position, a running move is stopped before
+func (s *StepDirStepperDriver) Move(steps int64) (err error) {
+	s.moveMutex.Lock()
+	defer s.moveMutex.Unlock()
+
+	s.stop()
+	return s.start(s.Position() + steps)
+}
+
+// MoveTo starts a move to the given absolute position and returns immediately,
+// a running move is stopped before.
+// Emits the Events:
+//	StepperMoveDone int64 - Event is emitted with the position when the move is completed.
+//	Error error - Event is emitted when writing to a pin fails.
+func (s *StepDirStepperDriver) MoveTo(position int64) (err error) {
+	s.moveMutex.Lock()
+	defer s.moveMutex.Unlock()
+
+	s.stop()
+	return s.start(position)
+}
+
+// start starts the goroutine of a move, the moveMutex must be held by the
+// caller
+func (s *StepDirStepperDriver) start(position int64) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if position == s.position {
+		s.Publish(s.Event(StepperMoveDone), s.position)
+		return
+	}
+
+	direction := 1
+	if position < s.position {
+		direction = -1
+	}
+	if direction != s.direction {
+		var level byte
+		if direction > 0 {
+			level = 1
+		}
+		if err = s.digitalWrite(s.dirPin, level); err != nil {
+			return
+		}
+		s.direction = direction
+	}
+
+	s.moving = true
+	s.halt = make(chan bool)
+	s.done = make(chan bool)
+	go s.run(position, s.halt, s.done)
+	return
+}
+
+// Wait blocks until the running move is completed or halted
+func (s *StepDirStepperDriver) Wait() {
+	s.mutex.Lock()
+	done := s.done
+	s.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// stop halts a running move and waits for the goroutine to return, the
+// moveMutex must be held by the caller
+func (s *StepDirStepperDriver) stop() {
+	s.mutex.Lock()
+	halt, done, moving := s.halt, s.done, s.moving
+	s.mutex.Unlock()
+
+	if !moving {
+		return
+	}
+	select {
+	case halt <- true:
+	case <-done:
+	}
+	<-done
+}
+
+func (s *StepDirStepperDriver) run(target int64, halt chan bool, done chan bool) {
+	defer close(done)
+
+	s.mutex.Lock()
+	maxSpeed, acceleration := s.speed, s.acceleration
+	s.mutex.Unlock()
+
+	timer := time.NewTimer(0)
+	<-timer.C
+	defer timer.Stop()
+
+	var speed float64
+	for {
+		s.mutex.Lock()
+		remaining := target - s.position
+		s.mutex.Unlock()
+		if remaining < 0 {
+			remaining = -remaining
+		}
+
+		if remaining == 0 {
+			s.finish()
+			s.Publish(s.Event(StepperMoveDone), target)
+			return
+		}
+
+		speed = stepDirNextSpeed(speed, maxSpeed, acceleration, remaining)
+
+		if err := s.pulse(); err != nil {
+			s.finish()
+			s.Publish(s.Event(Error), err)
+			return
+		}
+
+		timer.Reset(time.Duration(float64(time.Second) / speed))
+		select {
+		case <-timer.C:
+		case <-halt:
+			s.finish()
+			return
+		}
+	}
+}
+
+// stepDirNextSpeed returns the speed for the next step of a trapezoidal
+// profile, it is decelerated when the remaining steps are needed to stop
+func stepDirNextSpeed(speed float64, maxSpeed float64, acceleration float64, remaining int64) float64 {
+	if acceleration == 0 {
+		return maxSpeed
+	}
+
+	stopSteps := speed * speed / (2 * acceleration)
+	if float64(remaining) <= stopSteps {
+		speed = math.Sqrt(math.Max(speed*speed-2*acceleration, 0))
+	} else {
+		speed = math.Sqrt(speed*speed + 2*acceleration)
+	}
+
+	// the first and last steps would take too long without a minimum speed
+	return math.Min(math.Max(speed, math.Sqrt(2*acceleration)), maxSpeed)
+}
+
+func (s *StepDirStepperDriver) pulse() (err error) {
+	if err = s.digitalWrite(s.stepPin, 1); err != nil {
+		return
+	}
+	if err = s.digitalWrite(s.stepPin, 0); err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	s.position += int64(s.direction)
+	s.mutex.Unlock()
+	return
+}
+
+func (s *StepDirStepperDriver) finish() {
+	s.mutex.Lock()
+	s.moving = false
+	s.mutex.Unlock()
+}
@@ -0,0 +1,204 @@
+package gpio
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*StepDirStepperDriver)(nil)
+
+type stepDirTestPins struct {
+	mtx    sync.Mutex
+	levels map[string][]byte
+}
+
+func (p *stepDirTestPins) get(pin string) []byte {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.levels[pin]
+}
+
+func initTestStepDirStepperDriver() (*StepDirStepperDriver, *gpioTestAdaptor, *stepDirTestPins) {
+	a := newGpioTestAdaptor()
+	p := &stepDirTestPins{levels: make(map[string][]byte)}
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		p.mtx.Lock()
+		defer p.mtx.Unlock()
+		p.levels[pin] = append(p.levels[pin], val)
+		return
+	})
+	return NewStepDirStepperDriver(a, "1", "2", 200), a, p
+}
+
+func TestStepDirStepperDriver(t *testing.T) {
+	d, a, _ := initTestStepDirStepperDriver()
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Speed(), 200.0)
+	gobottest.Assert(t, d.Acceleration(), 0.0)
+	gobottest.Assert(t, d.Microsteps(), uint(1))
+	gobottest.Assert(t, d.Position(), int64(0))
+	gobottest.Assert(t, d.IsMoving(), false)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "StepDirStepper"), true)
+
+	d.SetName("axis")
+	gobottest.Assert(t, d.Name(), "axis")
+}
+
+func TestStepDirStepperDriverEnable(t *testing.T) {
+	d, _, p := initTestStepDirStepperDriver()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, len(p.get("3")), 0)
+
+	d.SetEnablePin("3")
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, p.get("3"), []byte{0, 1})
+}
+
+func TestStepDirStepperDriverSpeed(t *testing.T) {
+	d, _, _ := initTestStepDirStepperDriver()
+	gobottest.Assert(t, d.SetSpeed(400), nil)
+	gobottest.Assert(t, d.Speed(), 400.0)
+	gobottest.Refute(t, d.SetSpeed(0), nil)
+	gobottest.Assert(t, d.SetRPM(30), nil)
+	gobottest.Assert(t, d.Speed(), 100.0)
+	gobottest.Assert(t, d.SetAcceleration(1000), nil)
+	gobottest.Assert(t, d.Acceleration(), 1000.0)
+	gobottest.Refute(t, d.SetAcceleration(-1), nil)
+}
+
+func TestStepDirStepperDriverMicrosteps(t *testing.T) {
+	d, _, p := initTestStepDirStepperDriver()
+	d.SetMicrostepPins("4", "5", "6")
+	gobottest.Assert(t, d.SetMicrosteps(8, StepDirA4988Microsteps), nil)
+	gobottest.Assert(t, d.Microsteps(), uint(8))
+	gobottest.Assert(t, p.get("4"), []byte{1})
+	gobottest.Assert(t, p.get("5"), []byte{1})
+	gobottest.Assert(t, p.get("6"), []byte{0})
+
+	gobottest.Assert(t, d.SetRPM(60), nil)
+	gobottest.Assert(t, d.Speed(), 1600.0)
+
+	gobottest.Refute(t, d.SetMicrosteps(32, StepDirA4988Microsteps), nil)
+	gobottest.Refute(t, d.SetMicrosteps(16, StepDirTMC2209Microsteps), nil)
+	gobottest.Assert(t, d.Microsteps(), uint(8))
+}
+
+func TestStepDirStepperDriverMoveTo(t *testing.T) {
+	d, _, p := initTestStepDirStepperDriver()
+	d.SetSpeed(2000)
+	d.SetAcceleration(20000)
+
+	sem := make(chan int64, 1)
+	d.Once(d.Event(StepperMoveDone), func(data interface{}) {
+		sem <- data.(int64)
+	})
+	gobottest.Assert(t, d.MoveTo(50), nil)
+
+	select {
+	case pos := <-sem:
+		gobottest.Assert(t, pos, int64(50))
+	case <-time.After(2 * time.Second):
+		t.Errorf("StepDirStepper Event \"MoveDone\" was not published")
+	}
+	gobottest.Assert(t, d.Position(), int64(50))
+	gobottest.Assert(t, d.IsMoving(), false)
+	gobottest.Assert(t, len(p.get("1")), 100)
+	gobottest.Assert(t, p.get("2"), []byte{1})
+
+	gobottest.Assert(t, d.Move(-20), nil)
+	d.Wait()
+	gobottest.Assert(t, d.Position(), int64(30))
+	gobottest.Assert(t, p.get("2"), []byte{1, 0})
+
+	gobottest.Assert(t, d.Command("MoveTo")(map[string]interface{}{"position": 30.0}), nil)
+	gobottest.Assert(t, d.IsMoving(), false)
+	gobottest.Assert(t, d.Command("Move")(map[string]interface{}{"steps": 5}), nil)
+	d.Wait()
+	gobottest.Assert(t, d.Position(), int64(35))
+}
+
+func TestStepDirStepperDriverMoveToConcurrent(t *testing.T) {
+	d, _, _ := initTestStepDirStepperDriver()
+	d.SetSpeed(1000)
+
+	// each move stops the running one, only one move runs at any time
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(position int64) {
+			defer wg.Done()
+			d.MoveTo(position)
+		}(int64(i * 100))
+	}
+	wg.Wait()
+	gobottest.Assert(t, d.IsMoving(), true)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.IsMoving(), false)
+
+	pos := d.Position()
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, d.Position(), pos)
+}
+
+func TestStepDirStepperDriverHalt(t *testing.T) {
+	d, _, _ := initTestStepDirStepperDriver()
+	d.SetSpeed(100)
+
+	gobottest.Assert(t, d.MoveTo(1000), nil)
+	time.Sleep(50 * time.Millisecond)
+	gobottest.Assert(t, d.IsMoving(), true)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.IsMoving(), false)
+
+	pos := d.Position()
+	gobottest.Assert(t, pos > 0 && pos < 1000, true)
+	time.Sleep(30 * time.Millisecond)
+	gobottest.Assert(t, d.Position(), pos)
+}
+
+func TestStepDirStepperDriverMoveError(t *testing.T) {
+	d, a, _ := initTestStepDirStepperDriver()
+	a.TestAdaptorDigitalWrite(func(pin string, val byte) (err error) {
+		if pin == "1" {
+			return errors.New("write error")
+		}
+		return
+	})
+
+	sem := make(chan bool, 1)
+	d.Once(d.Event(Error), func(data interface{}) {
+		gobottest.Assert(t, data.(error).Error(), "write error")
+		sem <- true
+	})
+	gobottest.Assert(t, d.MoveTo(10), nil)
+
+	select {
+	case <-sem:
+	case <-time.After(1 * time.Second):
+		t.Errorf("StepDirStepper Event \"Error\" was not published")
+	}
+	d.Wait()
+	gobottest.Assert(t, d.Position(), int64(0))
+}
+
+func TestStepDirNextSpeed(t *testing.T) {
+	// constant speed without acceleration
+	gobottest.Assert(t, stepDirNextSpeed(0, 500, 0, 100), 500.0)
+
+	// accelerate from standstill
+	speed := stepDirNextSpeed(0, 500, 1000, 100)
+	gobottest.Assert(t, speed > 0 && speed < 500, true)
+
+	// limited by max speed
+	gobottest.Assert(t, stepDirNextSpeed(499, 500, 1000, 1000), 500.0)
+
+	// decelerate near the target
+	gobottest.Assert(t, stepDirNextSpeed(400, 500, 1000, 10) < 400, true)
+}
@@ -5,7 +5,6 @@ import (
 	"math"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -48,17 +47,15 @@ var StepperModes = struct {
 
 // StepperDriver object
 type StepperDriver struct {
-	name        string
+	*Driver
 	pins        [4]string
-	connection  DigitalWriter
 	phase       phase
 	stepsPerRev uint
 	moving      bool
 	direction   string
 	stepNum     int
 	speed       uint
-	mutex       *sync.Mutex
-	gobot.Commander
+	mutex       *gobot.Mutex
 }
 
 // NewStepperDriver returns a new StepperDriver given a
@@ -68,8 +65,7 @@ type StepperDriver struct {
 // Steps - No of steps per revolution of Stepper motor
 func NewStepperDriver(a DigitalWriter, pins [4]string, phase phase, stepsPerRev uint) *StepperDriver {
 	s := &StepperDriver{
-		name:        gobot.DefaultName("Stepper"),
-		connection:  a,
+		Driver:      NewDriver(a, "Stepper"),
 		pins:        pins,
 		phase:       phase,
 		stepsPerRev: stepsPerRev,
@@ -77,15 +73,16 @@ func NewStepperDriver(a DigitalWriter, pins [4]string, phase phase, stepsPerRev
 		direction:   "forward",
 		stepNum:     0,
 		speed:       1,
-		mutex:       &sync.Mutex{},
-		Commander:   gobot.NewCommander(),
+		mutex:       &gobot.Mutex{},
 	}
+	s.beforeHalt = s.shutdown
 	s.speed = s.GetMaxSpeed()
 
 	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
 		steps, _ := strconv.Atoi(params["steps"].(string))
 		return s.Move(steps)
 	})
+	s.AddCommandSchema("Move", gobot.CommandSchema{"steps": gobot.CommandParamString})
 	s.AddCommand("Run", func(params map[string]interface{}) interface{} {
 		return s.Run()
 	})
@@ -96,17 +93,10 @@ func NewStepperDriver(a DigitalWriter, pins [4]string, phase phase, stepsPerRev
 	return s
 }
 
-// Name of StepperDriver
-func (s *StepperDriver) Name() string { return s.name }
-
-// SetName sets name for StepperDriver
-func (s *StepperDriver) SetName(n string) { s.name = n }
-
-// Connection returns StepperDriver's connection
-func (s *StepperDriver) Connection() gobot.Connection { return s.connection.(gobot.Connection) }
-
-// Start implements the Driver interface and keeps running the stepper till halt is called
-func (s *StepperDriver) Start() (err error) { return }
+// Contentions returns how often a caller had to wait for the driver
+func (s *StepperDriver) Contentions() uint64 {
+	return s.Driver.Contentions() + s.mutex.Contentions()
+}
 
 // Run continuously runs the stepper
 func (s *StepperDriver) Run() (err error) {
@@ -134,8 +124,8 @@ func (s *StepperDriver) Run() (err error) {
 	return
 }
 
-// Halt implements the Driver interface and halts the motion of the Stepper
-func (s *StepperDriver) Halt() (err error) {
+// shutdown halts the motion of the Stepper
+func (s *StepperDriver) shutdown() (err error) {
 	s.mutex.Lock()
 	s.moving = false
 	s.mutex.Unlock()
@@ -177,7 +167,7 @@ func (s *StepperDriver) step() error {
 	r := int(math.Abs(float64(s.stepNum))) % len(s.phase)
 
 	for i, v := range s.phase[r] {
-		if err := s.connection.DigitalWrite(s.pins[i], v); err != nil {
+		if err := s.digitalWrite(s.pins[i], v); err != nil {
 			return err
 		}
 	}
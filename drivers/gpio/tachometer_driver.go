@@ -0,0 +1,176 @@
+package gpio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// TachometerDriver measures the rate of pulses, e.g. of a wheel encoder, a fan
+// or a flow meter, by a hardware counter of the Linux counter subsystem. The
+// pulses are counted by the kernel, so no pulse is lost between two polls.
+type TachometerDriver struct {
+	*Driver
+	device              int
+	count               int
+	pulsesPerRevolution int
+	counter             gobot.CounterPinner
+	poller              *gobot.Poller
+	rate                float64
+	rateMutex           sync.Mutex
+	gobot.Eventer
+}
+
+// TachometerOption is an option of the TachometerDriver
+type TachometerOption func(*TachometerDriver)
+
+// NewTachometerDriver returns a new TachometerDriver with a polling interval
+// of 500 Milliseconds given a CounterProvider, the counter device and count.
+//
+// Optionally accepts:
+//  time.Duration: Interval at which the rate is calculated
+//  gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//  WithPulsesPerRevolution(int): Number of pulses of one revolution, defaults to 1
+//
+// Adds the following API Commands:
+// 	"Count" - See TachometerDriver.Count
+// 	"Rate" - See TachometerDriver.Rate
+// 	"RPM" - See TachometerDriver.RPM
+func NewTachometerDriver(a gobot.CounterProvider, device, count int, v ...interface{}) *TachometerDriver {
+	d := &TachometerDriver{
+		Driver:              NewDriver(a, "Tachometer"),
+		device:              device,
+		count:               count,
+		pulsesPerRevolution: 1,
+		poller:              gobot.NewPoller(500 * time.Millisecond),
+		Eventer:             gobot.NewEventer(),
+	}
+	d.afterStart = d.initialize
+	d.beforeHalt = d.shutdown
+
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		case TachometerOption:
+			o(d)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for gpio.NewTachometerDriver", opt))
+		}
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+
+	d.AddCommand("Count", func(params map[string]interface{}) interface{} {
+		val, err := d.Count()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Rate", func(params map[string]interface{}) interface{} {
+		return d.Rate()
+	})
+	d.AddCommand("RPM", func(params map[string]interface{}) interface{} {
+		return d.RPM()
+	})
+
+	return d
+}
+
+// WithPulsesPerRevolution option sets the number of pulses of one revolution,
+// e.g. 2 for most PC fans or the resolution of an encoder
+func WithPulsesPerRevolution(n int) TachometerOption {
+	return func(d *TachometerDriver) {
+		d.pulsesPerRevolution = n
+	}
+}
+
+// initialize enables the counter and starts to calculate the rate at the
+// given interval.
+//
+// Emits the Events:
+//	Data float64 - Revolutions per minute, on each poll
+//	Error error - On counter error
+func (d *TachometerDriver) initialize() (err error) {
+	if d.counter, err = d.connection.(gobot.CounterProvider).Counter(d.device, d.count); err != nil {
+		return err
+	}
+	if err = d.counter.Enable(true); err != nil {
+		return err
+	}
+
+	// the first poll, which is immediately, takes the count to start from
+	first := true
+	var last int
+	var lastTime time.Time
+	d.poller.Start(func() error {
+		val, err := d.counter.Count()
+		now := time.Now()
+		if err != nil {
+			d.Publish(Error, err)
+			return err
+		}
+		if first {
+			first = false
+			last, lastTime = val, now
+			return nil
+		}
+		pulses := val - last
+		if pulses < 0 {
+			// the count was preset or has wrapped at its ceiling
+			pulses = val
+		}
+		rate := float64(pulses) / now.Sub(lastTime).Seconds()
+		last, lastTime = val, now
+
+		d.rateMutex.Lock()
+		d.rate = rate
+		d.rateMutex.Unlock()
+		d.Publish(Data, d.RPM())
+		return nil
+	})
+	return
+}
+
+// shutdown stops the rate calculation and disables the counter
+func (d *TachometerDriver) shutdown() (err error) {
+	d.poller.Stop()
+	if d.counter != nil {
+		err = d.counter.Enable(false)
+	}
+	return
+}
+
+// Count returns the current value of the hardware count
+func (d *TachometerDriver) Count() (int, error) {
+	counter, err := d.connection.(gobot.CounterProvider).Counter(d.device, d.count)
+	if err != nil {
+		return 0, err
+	}
+	return counter.Count()
+}
+
+// Rate returns the pulses per second of the last interval
+func (d *TachometerDriver) Rate() float64 {
+	d.rateMutex.Lock()
+	defer d.rateMutex.Unlock()
+	return d.rate
+}
+
+// RPM returns the revolutions per minute of the last interval
+func (d *TachometerDriver) RPM() float64 {
+	return d.Rate() * 60 / float64(d.pulsesPerRevolution)
+}
+
+// Telemetry returns the pulses per second and the revolutions per minute of
+// the last interval
+func (d *TachometerDriver) Telemetry() map[string]interface{} {
+	rate := d.Rate()
+	return map[string]interface{}{
+		"rate": rate,
+		"rpm":  rate * 60 / float64(d.pulsesPerRevolution),
+	}
+}
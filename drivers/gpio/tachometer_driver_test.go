@@ -0,0 +1,141 @@
+package gpio
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*TachometerDriver)(nil)
+var _ gobot.Telemeter = (*TachometerDriver)(nil)
+
+type gpioTestCounter struct {
+	mtx      sync.Mutex
+	val      int
+	enabled  bool
+	countErr error
+}
+
+func (c *gpioTestCounter) Count() (int, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.val, c.countErr
+}
+func (c *gpioTestCounter) SetCount(val int) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.val = val
+	return nil
+}
+func (c *gpioTestCounter) Enable(enable bool) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.enabled = enable
+	return nil
+}
+func (c *gpioTestCounter) Function() (string, error)  { return "increase", nil }
+func (c *gpioTestCounter) SetFunction(f string) error { return nil }
+
+func (c *gpioTestCounter) add(pulses int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.val += pulses
+}
+
+type gpioTestCounterAdaptor struct {
+	gpioTestBareAdaptor
+	counter *gpioTestCounter
+	err     error
+}
+
+func (a *gpioTestCounterAdaptor) Counter(device int, count int) (gobot.CounterPinner, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return a.counter, nil
+}
+
+func newGpioTestCounterAdaptor() *gpioTestCounterAdaptor {
+	return &gpioTestCounterAdaptor{counter: &gpioTestCounter{}}
+}
+
+func TestTachometerDriver(t *testing.T) {
+	d := NewTachometerDriver(newGpioTestCounterAdaptor(), 0, 0)
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.poller.Interval(), 500*time.Millisecond)
+	gobottest.Assert(t, d.pulsesPerRevolution, 1)
+
+	d = NewTachometerDriver(newGpioTestCounterAdaptor(), 0, 0, time.Second, WithPulsesPerRevolution(2))
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
+	gobottest.Assert(t, d.pulsesPerRevolution, 2)
+}
+
+func TestTachometerDriverStart(t *testing.T) {
+	a := newGpioTestCounterAdaptor()
+	a.counter.val = 100
+	d := NewTachometerDriver(a, 0, 0, 50*time.Millisecond, WithPulsesPerRevolution(2))
+	rpm := make(chan interface{}, 10)
+	d.On(Data, func(data interface{}) { rpm <- data })
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, a.counter.enabled, true)
+	// after the first poll took the count to start from
+	time.Sleep(10 * time.Millisecond)
+	a.counter.add(10)
+
+	select {
+	case data := <-rpm:
+		// 10 pulses in about 50ms are about 6000 RPM with 2 pulses per revolution
+		gobottest.Assert(t, data.(float64) > 1000 && data.(float64) < 7000, true)
+		gobottest.Assert(t, data.(float64), d.Rate()*30)
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+
+	ret := d.Command("Count")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["val"].(int), 110)
+	gobottest.Assert(t, ret["err"], nil)
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, a.counter.enabled, false)
+	gobottest.Assert(t, d.poller.Running(), false)
+}
+
+func TestTachometerDriverStartError(t *testing.T) {
+	a := newGpioTestCounterAdaptor()
+	a.err = errors.New("no counter")
+	d := NewTachometerDriver(a, 1, 0)
+	gobottest.Assert(t, d.Start(), errors.New("no counter"))
+	_, err := d.Count()
+	gobottest.Assert(t, err, errors.New("no counter"))
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestTachometerDriverCountError(t *testing.T) {
+	a := newGpioTestCounterAdaptor()
+	d := NewTachometerDriver(a, 0, 0, 10*time.Millisecond)
+	errs := make(chan interface{}, 10)
+	d.On(Error, func(data interface{}) {
+		select {
+		case errs <- data:
+		default:
+		}
+	})
+
+	gobottest.Assert(t, d.Start(), nil)
+	a.counter.mtx.Lock()
+	a.counter.countErr = errors.New("read error")
+	a.counter.mtx.Unlock()
+
+	select {
+	case data := <-errs:
+		gobottest.Assert(t, data, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("Error event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
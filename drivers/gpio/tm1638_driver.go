@@ -33,34 +33,31 @@ const (
 // Ported from the Arduino driver https://github.com/rjbatista/tm1638-library
 
 type TM1638Driver struct {
+	*Driver
 	pinClock  *DirectPinDriver
-	pinData    *DirectPinDriver
-	pinStrobe  *DirectPinDriver
-	fonts      map[string]byte
-	name       string
-	connection gobot.Connection
-	gobot.Commander
+	pinData   *DirectPinDriver
+	pinStrobe *DirectPinDriver
+	fonts     map[string]byte
 }
 
 // NewTM1638Driver return a new TM1638Driver given a gobot.Connection and the clock, data and strobe pins
 func NewTM1638Driver(a gobot.Connection, clockPin string, dataPin string, strobePin string) *TM1638Driver {
 	t := &TM1638Driver{
-		name:       gobot.DefaultName("TM1638"),
+		Driver:    NewDriver(a, "TM1638"),
 		pinClock:  NewDirectPinDriver(a, clockPin),
-		pinData:    NewDirectPinDriver(a, dataPin),
-		pinStrobe:  NewDirectPinDriver(a, strobePin),
-		fonts:      NewTM1638Fonts(),
-		connection: a,
-		Commander:  gobot.NewCommander(),
+		pinData:   NewDirectPinDriver(a, dataPin),
+		pinStrobe: NewDirectPinDriver(a, strobePin),
+		fonts:     NewTM1638Fonts(),
 	}
+	t.afterStart = t.initialize
 
 	/* TODO : Add commands */
 
 	return t
 }
 
-// Start initializes the tm1638, it uses a SPI-like communication protocol
-func (t *TM1638Driver) Start() (err error) {
+// initialize initializes the tm1638, it uses a SPI-like communication protocol
+func (t *TM1638Driver) initialize() (err error) {
 
 	t.pinStrobe.On()
 	t.pinClock.On()
@@ -78,20 +75,6 @@ func (t *TM1638Driver) Start() (err error) {
 	return
 }
 
-// Halt implements the Driver interface
-func (t *TM1638Driver) Halt() (err error) { return }
-
-// Name returns the TM1638Drivers name
-func (t *TM1638Driver) Name() string { return t.name }
-
-// SetName sets the TM1638Drivers name
-func (t *TM1638Driver) SetName(n string) { t.name = n }
-
-// Connection returns the TM1638Driver Connection
-func (t *TM1638Driver) Connection() gobot.Connection {
-	return t.connection
-}
-
 // sendCommand is an auxiliary function to send commands to the TM1638 module
 func (t *TM1638Driver) sendCommand(cmd byte) {
 	t.pinStrobe.Off()
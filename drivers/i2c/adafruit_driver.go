@@ -221,7 +221,9 @@ func (a *AdafruitMotorHatDriver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (a *AdafruitMotorHatDriver) Halt() (err error) { return }
+func (a *AdafruitMotorHatDriver) Halt() (err error) {
+	return closeConnection(&a.motorHatConnection, &a.servoHatConnection)
+}
 
 // setPWM sets the start (on) and end (off) of the high-segment of the PWM pulse
 // on the specific channel (pin).
@@ -45,6 +45,18 @@ type ADS1x15Driver struct {
 	DefaultGain     int
 	DefaultDataRate int
 	Config
+	// buffers reused by each read, protected by the mutex
+	writeBuf [3]byte
+	readBuf  [2]byte
+	// the last conversion in V by the mux value
+	conversions map[int]float64
+	mutex       gobot.Mutex
+}
+
+// ads1x15MuxNames are the names of the mux values, as accepted by AnalogRead
+var ads1x15MuxNames = map[int]string{
+	0: "0-1", 1: "0-3", 2: "1-3", 3: "2-3",
+	4: "0", 5: "1", 6: "2", 7: "3",
 }
 
 // NewADS1015Driver creates a new driver for the ADS1015 (12-bit ADC)
@@ -132,6 +144,7 @@ func newADS1x15Driver(a Connector, options ...func(Config)) *ADS1x15Driver {
 			16:    0.256,
 		},
 		DefaultGain: 1,
+		conversions: map[int]float64{},
 
 		Config: NewConfig(),
 	}
@@ -165,8 +178,13 @@ func (d *ADS1x15Driver) SetName(n string) { d.name = n }
 // Connection returns the connection for the Driver
 func (d *ADS1x15Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (d *ADS1x15Driver) Contentions() uint64 { return d.mutex.Contentions() }
+
 // Halt returns true if devices is halted successfully
-func (d *ADS1x15Driver) Halt() (err error) { return }
+func (d *ADS1x15Driver) Halt() (err error) {
+	return closeConnection(&d.connection)
+}
 
 // WithADS1x15Gain option sets the ADS1x15Driver gain option.
 // Valid gain settings are any of the ADS1x15RegConfigPga* values
@@ -196,6 +214,18 @@ func WithADS1x15DataRate(val int) func(Config) {
 	}
 }
 
+// ApplyOptions applies the options, e.g. WithADS1x15Gain, while the driver is
+// running. They take effect with the next read.
+func (d *ADS1x15Driver) ApplyOptions(options ...func(Config)) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, option := range options {
+		option(d)
+	}
+	return nil
+}
+
 // BestGainForVoltage returns the gain the most adapted to read up to the specified difference of potential.
 func (d *ADS1x15Driver) BestGainForVoltage(voltage float64) (bestGain int, err error) {
 	var max float64
@@ -339,9 +369,13 @@ func (d *ADS1x15Driver) rawRead(mux int, gain int, dataRate int) (value float64,
 	config |= dataRateConf
 	config |= ads1x15ConfigCompQueDisable // Disable comparator mode.
 
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	// Send the config value to start the ADC conversion.
 	// Explicitly break the 16-bit value down to a big endian pair of bytes.
-	if _, err = d.connection.Write([]byte{ads1x15PointerConfig, byte((config >> 8) & 0xFF), byte(config & 0xFF)}); err != nil {
+	d.writeBuf = [3]byte{ads1x15PointerConfig, byte((config >> 8) & 0xFF), byte(config & 0xFF)}
+	if _, err = d.connection.Write(d.writeBuf[:]); err != nil {
 		return
 	}
 
@@ -350,11 +384,12 @@ func (d *ADS1x15Driver) rawRead(mux int, gain int, dataRate int) (value float64,
 	time.Sleep(time.Duration(1000000/dataRate+100) * time.Microsecond)
 
 	// Retrieve the result.
-	if _, err = d.connection.Write([]byte{ads1x15PointerConversion}); err != nil {
+	d.writeBuf[0] = ads1x15PointerConversion
+	if _, err = d.connection.Write(d.writeBuf[:1]); err != nil {
 		return
 	}
 
-	data := make([]byte, 2)
+	data := d.readBuf[:]
 	_, err = d.connection.Read(data)
 	if err != nil {
 		return
@@ -368,10 +403,24 @@ func (d *ADS1x15Driver) rawRead(mux int, gain int, dataRate int) (value float64,
 	}
 
 	value = d.converter(data) * voltageMultiplier
+	d.conversions[mux] = value
 
 	return
 }
 
+// Telemetry returns the last conversion in V of each read channel, by the
+// channel names of AnalogRead, e.g. "0" or "0-1"
+func (d *ADS1x15Driver) Telemetry() map[string]interface{} {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	telemetry := map[string]interface{}{}
+	for mux, value := range d.conversions {
+		telemetry[ads1x15MuxNames[mux]] = value
+	}
+	return telemetry
+}
+
 func (d *ADS1x15Driver) checkChannel(channel int) (err error) {
 	if channel < 0 || channel > 3 {
 		err = errors.New("Invalid channel, must be between 0 and 3")
@@ -16,6 +16,12 @@ var _ gobot.Driver = (*ADS1x15Driver)(nil)
 // that supports the AnalogReader interface
 var _ aio.AnalogReader = (*ADS1x15Driver)(nil)
 
+// and applies options while running
+var _ OptionApplier = (*ADS1x15Driver)(nil)
+
+// and the Telemeter interface
+var _ gobot.Telemeter = (*ADS1x15Driver)(nil)
+
 // --------- HELPERS
 func initTestADS1015Driver() (driver *ADS1x15Driver) {
 	driver, _ = initTestADS1015DriverWithStubbedAdaptor()
@@ -174,6 +180,43 @@ func TestADS1115DriverAnalogRead(t *testing.T) {
 	gobottest.Refute(t, err.Error(), nil)
 }
 
+func TestADS1x15DriverTelemetry(t *testing.T) {
+	d, adaptor := initTestADS1115DriverWithStubbedAdaptor()
+	d.Start()
+	gobottest.Assert(t, len(d.Telemetry()), 0)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x7F, 0xFF})
+		return 2, nil
+	}
+	d.AnalogRead("0")
+	d.AnalogRead("2-3")
+
+	telemetry := d.Telemetry()
+	gobottest.Assert(t, len(telemetry), 2)
+	gobottest.Refute(t, telemetry["0"], nil)
+	gobottest.Refute(t, telemetry["2-3"], nil)
+}
+
+func TestADS1x15DriverRawReadTransactions(t *testing.T) {
+	d, adaptor := initTestADS1015DriverWithStubbedAdaptor()
+	d.Start()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x12, 0x34})
+		return 2, nil
+	}
+	adaptor.replayGolden(t, "ads1015_raw_read")
+
+	_, err := d.Read(0, 1, 1600)
+	gobottest.Assert(t, err, nil)
+	_, err = d.ReadDifference(1, 2, 3300)
+	gobottest.Assert(t, err, nil)
+	_, err = d.ReadWithDefaults(3)
+	gobottest.Assert(t, err, nil)
+
+	adaptor.verifyGolden(t, "ads1015_raw_read")
+}
+
 func TestADS1x15DriverAnalogReadError(t *testing.T) {
 	d, a := initTestADS1015DriverWithStubbedAdaptor()
 	d.Start()
@@ -211,6 +254,15 @@ func TestADS1x15DriverAnalogReadWriteError(t *testing.T) {
 	gobottest.Assert(t, err, errors.New("write error"))
 }
 
+func TestADS1x15DriverApplyOptions(t *testing.T) {
+	d, _ := initTestADS1015DriverWithStubbedAdaptor()
+	d.Start()
+
+	gobottest.Assert(t, d.ApplyOptions(WithADS1x15Gain(2), WithADS1x15DataRate(920)), nil)
+	gobottest.Assert(t, d.DefaultGain, 2)
+	gobottest.Assert(t, d.DefaultDataRate, 920)
+}
+
 func TestADS1x15DriverBestGainForVoltage(t *testing.T) {
 	d, _ := initTestADS1015DriverWithStubbedAdaptor()
 
@@ -77,6 +77,7 @@ type ADXL345Driver struct {
 	name       string
 	connector  Connector
 	connection Connection
+	mutex      gobot.Mutex
 
 	powerCtl   adxl345PowerCtl
 	dataFormat adxl345DataFormat
@@ -155,8 +156,14 @@ func (h *ADXL345Driver) SetName(n string) { h.name = n }
 // Connection returns the connection for the Driver
 func (h *ADXL345Driver) Connection() gobot.Connection { return h.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (h *ADXL345Driver) Contentions() uint64 { return h.mutex.Contentions() }
+
 // Start initialized the adxl345
 func (h *ADXL345Driver) Start() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 	address := h.GetAddressOrDefault(ADXL345AddressLow)
 
@@ -182,8 +189,11 @@ func (h *ADXL345Driver) Start() (err error) {
 
 // Stop adxl345
 func (h *ADXL345Driver) Stop() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	h.powerCtl.measure = 0
-	if h.connection == nil {
+	if !connected(h.connection) {
 		return errors.New("connection not available")
 	}
 	if _, err := h.connection.Write([]byte{ADXL345_REG_POWER_CTL, h.powerCtl.toByte()}); err != nil {
@@ -196,17 +206,23 @@ func (h *ADXL345Driver) Stop() (err error) {
 // Halt returns true if devices is halted successfully
 func (h *ADXL345Driver) Halt() (err error) {
 	h.Stop()
-	return
+	return closeConnection(&h.connection)
 }
 
 // XYZ returns the adjusted x, y and z axis from the adxl345
 func (h *ADXL345Driver) XYZ() (float64, float64, float64, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	err := h.update()
 	return h.x, h.y, h.z, err
 }
 
 // XYZ returns the raw x,y and z axis from the adxl345
 func (h *ADXL345Driver) RawXYZ() (int16, int16, int16, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	err := h.update()
 	return h.rawX, h.rawY, h.rawZ, err
 }
@@ -214,7 +230,7 @@ func (h *ADXL345Driver) RawXYZ() (int16, int16, int16, error) {
 // update the cached values for the axis to avoid errors if the connection is not available (polling too frequently)
 func (h *ADXL345Driver) update() (err error) {
 
-	if h.connection == nil {
+	if !connected(h.connection) {
 		return errors.New("connection not available")
 	}
 
@@ -239,6 +255,9 @@ func (h *ADXL345Driver) update() (err error) {
 
 // SetRate change the current rate of the sensor
 func (h *ADXL345Driver) UseLowPower(power bool) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if power {
 		h.bwRate.lowPower = 1
 	} else {
@@ -252,6 +271,9 @@ func (h *ADXL345Driver) UseLowPower(power bool) (err error) {
 
 // SetRate change the current rate of the sensor
 func (h *ADXL345Driver) SetRate(rate byte) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if rate <= ADXL345_RATE_3200HZ {
 		return errors.New("not a valid rate")
 	}
@@ -264,6 +286,9 @@ func (h *ADXL345Driver) SetRate(rate byte) (err error) {
 
 // SetRange change the current range of the sensor
 func (h *ADXL345Driver) SetRange(sensorRange byte) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
 	if sensorRange != ADXL345_RANGE_2G &&
 		sensorRange != ADXL345_RANGE_4G &&
 		sensorRange != ADXL345_RANGE_8G &&
@@ -151,3 +151,16 @@ func TestADXL345DriverSetRange(t *testing.T) {
 	d.Start()
 	gobottest.Assert(t, d.SetRange(ADXL345_RANGE_16G), nil)
 }
+
+func TestADXL345DriverConcurrency(t *testing.T) {
+	d, _ := initTestADXL345DriverWithStubbedAdaptor()
+	d.Start()
+
+	gobottest.Hammer(t, 4, 20,
+		func() { d.XYZ() },
+		func() { d.RawXYZ() },
+		func() { d.UseLowPower(true) },
+		func() { d.SetRate(ADXL345_RATE_200HZ) },
+		func() { d.SetRange(ADXL345_RANGE_4G) },
+	)
+}
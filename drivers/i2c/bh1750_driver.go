@@ -84,7 +84,9 @@ func (h *BH1750Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *BH1750Driver) Halt() (err error) { return }
+func (h *BH1750Driver) Halt() (err error) {
+	return closeConnection(&h.connection)
+}
 
 // RawSensorData returns the raw value from the bh1750
 func (h *BH1750Driver) RawSensorData() (level int, err error) {
@@ -15,6 +15,7 @@ type BlinkMDriver struct {
 	connection Connection
 	Config
 	gobot.Commander
+	gobot.CommandSchemer
 }
 
 // NewBlinkMDriver creates a new BlinkMDriver.
@@ -28,22 +29,30 @@ type BlinkMDriver struct {
 //
 func NewBlinkMDriver(a Connector, options ...func(Config)) *BlinkMDriver {
 	b := &BlinkMDriver{
-		name:      gobot.DefaultName("BlinkM"),
-		Commander: gobot.NewCommander(),
-		connector: a,
-		Config:    NewConfig(),
+		name:           gobot.DefaultName("BlinkM"),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		connector:      a,
+		Config:         NewConfig(),
 	}
 
 	for _, option := range options {
 		option(b)
 	}
 
+	colorSchema := gobot.CommandSchema{
+		"red":   gobot.CommandParamNumber,
+		"green": gobot.CommandParamNumber,
+		"blue":  gobot.CommandParamNumber,
+	}
+
 	b.AddCommand("Rgb", func(params map[string]interface{}) interface{} {
 		red := byte(params["red"].(float64))
 		green := byte(params["green"].(float64))
 		blue := byte(params["blue"].(float64))
 		return b.Rgb(red, green, blue)
 	})
+	b.AddCommandSchema("Rgb", colorSchema)
 
 	b.AddCommand("Fade", func(params map[string]interface{}) interface{} {
 		red := byte(params["red"].(float64))
@@ -51,6 +60,7 @@ func NewBlinkMDriver(a Connector, options ...func(Config)) *BlinkMDriver {
 		blue := byte(params["blue"].(float64))
 		return b.Fade(red, green, blue)
 	})
+	b.AddCommandSchema("Fade", colorSchema)
 
 	b.AddCommand("FirmwareVersion", func(params map[string]interface{}) interface{} {
 		version, err := b.FirmwareVersion()
@@ -91,7 +101,9 @@ func (b *BlinkMDriver) Start() (err error) {
 }
 
 // Halt returns true if device is halted successfully
-func (b *BlinkMDriver) Halt() (err error) { return }
+func (b *BlinkMDriver) Halt() (err error) {
+	return closeConnection(&b.connection)
+}
 
 // Rgb sets color using r,g,b params
 func (b *BlinkMDriver) Rgb(red byte, green byte, blue byte) (err error) {
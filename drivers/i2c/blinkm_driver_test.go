@@ -10,6 +10,8 @@ import (
 
 var _ gobot.Driver = (*BlinkMDriver)(nil)
 
+var _ gobot.CommandSchemer = (*BlinkMDriver)(nil)
+
 // --------- HELPERS
 func initTestBlinkMDriver() (driver *BlinkMDriver) {
 	driver, _ = initTestBlinkDriverWithStubbedAdaptor()
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"time"
+
+	"gobot.io/x/gobot/drivers/sensor"
 )
 
 const bme280RegisterControlHumidity = 0xF2
@@ -28,7 +31,8 @@ type bmeHumidityCalibrationCoefficients struct {
 //
 type BME280Driver struct {
 	*BMP280Driver
-	hc *bmeHumidityCalibrationCoefficients
+	hc                   *bmeHumidityCalibrationCoefficients
+	humidityOversampling BMP280Oversampling
 }
 
 // NewBME280Driver creates a new driver with specified i2c interface.
@@ -38,11 +42,16 @@ type BME280Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithBME280HumidityOversampling(BMP280Oversampling):	defaults to x16
+//
+// Accepts the options of the BMP280Driver for the temperature and pressure,
+// the IIR filter, the standby time and the forced mode.
 //
 func NewBME280Driver(c Connector, options ...func(Config)) *BME280Driver {
 	b := &BME280Driver{
-		BMP280Driver: NewBMP280Driver(c),
-		hc:           &bmeHumidityCalibrationCoefficients{},
+		BMP280Driver:         NewBMP280Driver(c),
+		hc:                   &bmeHumidityCalibrationCoefficients{},
+		humidityOversampling: BMP280Oversampling16,
 	}
 
 	for _, option := range options {
@@ -53,6 +62,19 @@ func NewBME280Driver(c Connector, options ...func(Config)) *BME280Driver {
 	return b
 }
 
+// WithBME280HumidityOversampling option sets the oversampling of the humidity
+// measurement
+func WithBME280HumidityOversampling(val BMP280Oversampling) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*BME280Driver)
+		if ok {
+			d.humidityOversampling = val
+		} else {
+			panic("trying to set humidity oversampling for non-BME280Driver")
+		}
+	}
+}
+
 // Start initializes the BME280 and loads the calibration coefficients.
 func (d *BME280Driver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
@@ -75,6 +97,9 @@ func (d *BME280Driver) Start() (err error) {
 
 // Humidity returns the current humidity in percentage of relative humidity
 func (d *BME280Driver) Humidity() (humidity float32, err error) {
+	if err = d.measure(); err != nil {
+		return 0.0, err
+	}
 	var rawH uint32
 	if rawH, err = d.rawHumidity(); err != nil {
 		return 0.0, err
@@ -83,6 +108,14 @@ func (d *BME280Driver) Humidity() (humidity float32, err error) {
 	return
 }
 
+// Hygrometer returns the driver as sensor.Hygrometer
+func (d *BME280Driver) Hygrometer() sensor.Hygrometer {
+	return sensor.HygrometerFunc(func() (float64, error) {
+		humidity, err := d.Humidity()
+		return float64(humidity), err
+	})
+}
+
 // read the humidity calibration coefficients.
 func (d *BME280Driver) initHumidity() (err error) {
 	var coefficients []byte
@@ -112,7 +145,12 @@ func (d *BME280Driver) initHumidity() (err error) {
 	d.hc.h4 = 0 + (int16(addrE4) << 4) | (int16(addrE5 & 0x0F))
 	d.hc.h5 = 0 + (int16(addrE6) << 4) | (int16(addrE5) >> 4)
 
-	d.connection.WriteByteData(bme280RegisterControlHumidity, 0x3F)
+	if err = d.connection.WriteByteData(bme280RegisterControlHumidity, uint8(d.humidityOversampling)); err != nil {
+		return err
+	}
+	if d.humidityOversampling != BMP280OversamplingSkipped {
+		d.measureTime += bmp280OversamplingTime(d.humidityOversampling) + 575*time.Microsecond
+	}
 
 	// The 'ctrl_hum' register sets the humidity data acquisition options of
 	// the device. Changes to this register only become effective after a write
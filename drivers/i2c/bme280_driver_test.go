@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -99,6 +100,9 @@ func TestBME280DriverMeasurements(t *testing.T) {
 	hum, err := bme280.Humidity()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, hum, float32(51.20179))
+	percent, err := bme280.Hygrometer().Humidity()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, percent, float64(hum))
 }
 
 func TestBME280DriverInitH1Error(t *testing.T) {
@@ -198,3 +202,15 @@ func TestBME280DriverOptions(t *testing.T) {
 	b := NewBME280Driver(newI2cTestAdaptor(), WithBus(2))
 	gobottest.Assert(t, b.GetBusOrDefault(1), 2)
 }
+
+func TestBME280DriverHumidityOversampling(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	bme280 := NewBME280Driver(adaptor, WithBME280HumidityOversampling(BMP280Oversampling2), WithBMP280ForcedMode())
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 1, nil
+	}
+	gobottest.Assert(t, bme280.Start(), nil)
+	gobottest.Assert(t, bme280.forced, true)
+	gobottest.Assert(t, bytes.Contains(adaptor.written, []byte{bme280RegisterControlHumidity, 0x02}), true)
+	gobottest.Assert(t, bme280.measureTime, 46100*time.Microsecond)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
 )
 
 const bmp180Address = 0x77
@@ -135,7 +136,7 @@ func (d *BMP180Driver) initialization() (err error) {
 
 // Halt halts the device.
 func (d *BMP180Driver) Halt() (err error) {
-	return nil
+	return closeConnection(&d.connection)
 }
 
 // Temperature returns the current temperature, in celsius degrees.
@@ -160,6 +161,22 @@ func (d *BMP180Driver) Pressure() (pressure float32, err error) {
 	return d.calculatePressure(rawTemp, rawPressure, d.Mode), nil
 }
 
+// Thermometer returns the driver as sensor.Thermometer
+func (d *BMP180Driver) Thermometer() sensor.Thermometer {
+	return sensor.ThermometerFunc(func() (float64, error) {
+		temp, err := d.Temperature()
+		return float64(temp), err
+	})
+}
+
+// Barometer returns the driver as sensor.Barometer
+func (d *BMP180Driver) Barometer() sensor.Barometer {
+	return sensor.BarometerFunc(func() (float64, error) {
+		press, err := d.Pressure()
+		return float64(press), err
+	})
+}
+
 func (d *BMP180Driver) rawTemp() (int16, error) {
 	if _, err := d.connection.Write([]byte{bmp180RegisterCtl, bmp180CmdTemp}); err != nil {
 		return 0, err
@@ -99,6 +99,12 @@ func TestBMP180DriverMeasurements(t *testing.T) {
 	pressure, err := bmp180.Pressure()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, pressure, float32(69964))
+	celsius, err := bmp180.Thermometer().Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, float64(temp))
+	pascal, err := bmp180.Barometer().Pressure()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pascal, float64(pressure))
 }
 
 func TestBMP180DriverTemperatureError(t *testing.T) {
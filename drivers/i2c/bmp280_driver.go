@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"math"
+	"time"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
 )
 
 const (
@@ -15,6 +17,51 @@ const (
 	bmp280RegisterTempData     = 0xfa
 	bmp280RegisterCalib00      = 0x88
 	bmp280SeaLevelPressure     = 1013.25
+
+	bmp280ModeSleep  = 0x00
+	bmp280ModeForced = 0x01
+	bmp280ModeNormal = 0x03
+)
+
+// BMP280Oversampling is the oversampling of a measurement
+type BMP280Oversampling uint8
+
+// BMP280Oversampling oversampling values
+const (
+	BMP280OversamplingSkipped BMP280Oversampling = 0 // no measurement
+	BMP280Oversampling1       BMP280Oversampling = 1 // x1 sample
+	BMP280Oversampling2       BMP280Oversampling = 2 // x2 samples
+	BMP280Oversampling4       BMP280Oversampling = 3 // x4 samples
+	BMP280Oversampling8       BMP280Oversampling = 4 // x8 samples
+	BMP280Oversampling16      BMP280Oversampling = 5 // x16 samples
+)
+
+// BMP280FilterCoefficient is the coefficient of the IIR filter
+type BMP280FilterCoefficient uint8
+
+// BMP280FilterCoefficient filter coefficients
+const (
+	BMP280FilterOff BMP280FilterCoefficient = 0
+	BMP280Filter2   BMP280FilterCoefficient = 1
+	BMP280Filter4   BMP280FilterCoefficient = 2
+	BMP280Filter8   BMP280FilterCoefficient = 3
+	BMP280Filter16  BMP280FilterCoefficient = 4
+)
+
+// BMP280StandbyTime is the inactive time between two measurements in normal
+// mode
+type BMP280StandbyTime uint8
+
+// BMP280StandbyTime standby times, the last two differ for the BME280
+const (
+	BMP280Standby0_5ms  BMP280StandbyTime = 0
+	BMP280Standby62_5ms BMP280StandbyTime = 1
+	BMP280Standby125ms  BMP280StandbyTime = 2
+	BMP280Standby250ms  BMP280StandbyTime = 3
+	BMP280Standby500ms  BMP280StandbyTime = 4
+	BMP280Standby1000ms BMP280StandbyTime = 5
+	BMP280Standby2000ms BMP280StandbyTime = 6 // 10ms for the BME280
+	BMP280Standby4000ms BMP280StandbyTime = 7 // 20ms for the BME280
 )
 
 type bmp280CalibrationCoefficients struct {
@@ -39,7 +86,14 @@ type BMP280Driver struct {
 	connection Connection
 	Config
 
-	tpc *bmp280CalibrationCoefficients
+	tpc               *bmp280CalibrationCoefficients
+	tempOversampling  BMP280Oversampling
+	pressOversampling BMP280Oversampling
+	filter            BMP280FilterCoefficient
+	standby           BMP280StandbyTime
+	forced            bool
+	// measureTime is the maximum duration of a measurement in forced mode
+	measureTime time.Duration
 }
 
 // NewBMP280Driver creates a new driver with specified i2c interface.
@@ -49,13 +103,22 @@ type BMP280Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithBMP280TemperatureOversampling(BMP280Oversampling):	defaults to x1
+//		i2c.WithBMP280PressureOversampling(BMP280Oversampling):	defaults to x16
+//		i2c.WithBMP280Filter(BMP280FilterCoefficient):	coefficient of the IIR filter, defaults to off
+//		i2c.WithBMP280Standby(BMP280StandbyTime):	standby time in normal mode, defaults to 0.5ms
+//		i2c.WithBMP280ForcedMode():	measure only on a read, the sensor sleeps in between
 //
 func NewBMP280Driver(c Connector, options ...func(Config)) *BMP280Driver {
 	b := &BMP280Driver{
-		name:      gobot.DefaultName("BMP280"),
-		connector: c,
-		Config:    NewConfig(),
-		tpc:       &bmp280CalibrationCoefficients{},
+		name:              gobot.DefaultName("BMP280"),
+		connector:         c,
+		Config:            NewConfig(),
+		tpc:               &bmp280CalibrationCoefficients{},
+		tempOversampling:  BMP280Oversampling1,
+		pressOversampling: BMP280Oversampling16,
+		filter:            BMP280FilterOff,
+		standby:           BMP280Standby0_5ms,
 	}
 
 	for _, option := range options {
@@ -66,6 +129,78 @@ func NewBMP280Driver(c Connector, options ...func(Config)) *BMP280Driver {
 	return b
 }
 
+// WithBMP280TemperatureOversampling option sets the oversampling of the
+// temperature measurement
+func WithBMP280TemperatureOversampling(val BMP280Oversampling) func(Config) {
+	return func(c Config) {
+		if d := bmp280DriverOf(c); d != nil {
+			d.tempOversampling = val
+		} else {
+			panic("trying to set temperature oversampling for non-BMP280Driver")
+		}
+	}
+}
+
+// WithBMP280PressureOversampling option sets the oversampling of the
+// pressure measurement
+func WithBMP280PressureOversampling(val BMP280Oversampling) func(Config) {
+	return func(c Config) {
+		if d := bmp280DriverOf(c); d != nil {
+			d.pressOversampling = val
+		} else {
+			panic("trying to set pressure oversampling for non-BMP280Driver")
+		}
+	}
+}
+
+// WithBMP280Filter option sets the coefficient of the IIR filter, which
+// suppresses short pressure changes, e.g. by wind or slamming doors
+func WithBMP280Filter(val BMP280FilterCoefficient) func(Config) {
+	return func(c Config) {
+		if d := bmp280DriverOf(c); d != nil {
+			d.filter = val
+		} else {
+			panic("trying to set filter for non-BMP280Driver")
+		}
+	}
+}
+
+// WithBMP280Standby option sets the inactive time between two measurements
+// in normal mode
+func WithBMP280Standby(val BMP280StandbyTime) func(Config) {
+	return func(c Config) {
+		if d := bmp280DriverOf(c); d != nil {
+			d.standby = val
+		} else {
+			panic("trying to set standby time for non-BMP280Driver")
+		}
+	}
+}
+
+// WithBMP280ForcedMode option sets the forced mode, where each read triggers
+// a single measurement and the sensor sleeps in between to save power
+func WithBMP280ForcedMode() func(Config) {
+	return func(c Config) {
+		if d := bmp280DriverOf(c); d != nil {
+			d.forced = true
+		} else {
+			panic("trying to set forced mode for non-BMP280Driver")
+		}
+	}
+}
+
+// bmp280DriverOf returns the BMP280Driver of a BMP280Driver or BME280Driver
+// to apply an option to, nil for other drivers
+func bmp280DriverOf(c Config) *BMP280Driver {
+	switch d := c.(type) {
+	case *BMP280Driver:
+		return d
+	case *BME280Driver:
+		return d.BMP280Driver
+	}
+	return nil
+}
+
 // Name returns the name of the device.
 func (d *BMP280Driver) Name() string {
 	return d.name
@@ -99,11 +234,14 @@ func (d *BMP280Driver) Start() (err error) {
 
 // Halt halts the device.
 func (d *BMP280Driver) Halt() (err error) {
-	return nil
+	return closeConnection(&d.connection)
 }
 
 // Temperature returns the current temperature, in celsius degrees.
 func (d *BMP280Driver) Temperature() (temp float32, err error) {
+	if err = d.measure(); err != nil {
+		return 0.0, err
+	}
 	var rawT int32
 	if rawT, err = d.rawTemp(); err != nil {
 		return 0.0, err
@@ -114,6 +252,9 @@ func (d *BMP280Driver) Temperature() (temp float32, err error) {
 
 // Pressure returns the current barometric pressure, in Pa
 func (d *BMP280Driver) Pressure() (press float32, err error) {
+	if err = d.measure(); err != nil {
+		return 0.0, err
+	}
 	var rawT, rawP int32
 	if rawT, err = d.rawTemp(); err != nil {
 		return 0.0, err
@@ -126,6 +267,22 @@ func (d *BMP280Driver) Pressure() (press float32, err error) {
 	return d.calculatePress(rawP, tFine), nil
 }
 
+// Thermometer returns the driver as sensor.Thermometer
+func (d *BMP280Driver) Thermometer() sensor.Thermometer {
+	return sensor.ThermometerFunc(func() (float64, error) {
+		temp, err := d.Temperature()
+		return float64(temp), err
+	})
+}
+
+// Barometer returns the driver as sensor.Barometer
+func (d *BMP280Driver) Barometer() sensor.Barometer {
+	return sensor.BarometerFunc(func() (float64, error) {
+		press, err := d.Pressure()
+		return float64(press), err
+	})
+}
+
 // Altitude returns the current altitude in meters based on the
 // current barometric pressure and estimated pressure at sea level.
 // Calculation is based on code from Adafruit BME280 library
@@ -138,7 +295,8 @@ func (d *BMP280Driver) Altitude() (alt float32, err error) {
 	return
 }
 
-// initialization reads the calibration coefficients.
+// initialization reads the calibration coefficients and configures the
+// measurements.
 func (d *BMP280Driver) initialization() (err error) {
 	var coefficients []byte
 	if coefficients, err = d.read(bmp280RegisterCalib00, 24); err != nil {
@@ -158,11 +316,61 @@ func (d *BMP280Driver) initialization() (err error) {
 	binary.Read(buf, binary.LittleEndian, &d.tpc.p8)
 	binary.Read(buf, binary.LittleEndian, &d.tpc.p9)
 
-	d.connection.WriteByteData(bmp280RegisterControl, 0x3F)
+	// the configuration may be ignored in normal mode, e.g. after a restart
+	if err = d.connection.WriteByteData(bmp280RegisterControl, bmp280ModeSleep); err != nil {
+		return err
+	}
+	config := uint8(d.standby)<<5 | uint8(d.filter)<<2
+	if err = d.connection.WriteByteData(bmp280RegisterConfig, config); err != nil {
+		return err
+	}
+	mode := uint8(bmp280ModeNormal)
+	if d.forced {
+		mode = bmp280ModeSleep
+	}
+	if err = d.connection.WriteByteData(bmp280RegisterControl, d.ctrlMeas(mode)); err != nil {
+		return err
+	}
+
+	// maximum measurement time by the datasheet of the BMP280
+	d.measureTime = 1250*time.Microsecond + bmp280OversamplingTime(d.tempOversampling)
+	if d.pressOversampling != BMP280OversamplingSkipped {
+		d.measureTime += bmp280OversamplingTime(d.pressOversampling) + 575*time.Microsecond
+	}
 
 	return nil
 }
 
+// measure triggers a measurement in forced mode and waits for its end, in
+// normal mode the latest measurement is read.
+func (d *BMP280Driver) measure() (err error) {
+	if !d.forced {
+		return nil
+	}
+	if err = d.connection.WriteByteData(bmp280RegisterControl, d.ctrlMeas(bmp280ModeForced)); err != nil {
+		return err
+	}
+	time.Sleep(d.measureTime)
+
+	return nil
+}
+
+func (d *BMP280Driver) ctrlMeas(mode uint8) uint8 {
+	return uint8(d.tempOversampling)<<5 | uint8(d.pressOversampling)<<2 | mode
+}
+
+// bmp280OversamplingTime returns the maximum duration of the samples of an
+// oversampled measurement
+func bmp280OversamplingTime(val BMP280Oversampling) time.Duration {
+	if val == BMP280OversamplingSkipped {
+		return 0
+	}
+	if val > BMP280Oversampling16 {
+		val = BMP280Oversampling16
+	}
+	return time.Duration(1<<(val-1)) * 2300 * time.Microsecond
+}
+
 func (d *BMP280Driver) rawTemp() (temp int32, err error) {
 	var data []byte
 	var tp0, tp1, tp2 byte
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -96,6 +97,37 @@ func TestBMP280DriverMeasurements(t *testing.T) {
 	alt, err := bmp280.Altitude()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, alt, float32(149.22713))
+	celsius, err := bmp280.Thermometer().Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, float64(temp))
+	pascal, err := bmp280.Barometer().Pressure()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pascal, float64(pressure))
+}
+
+func TestBMP280DriverConfiguration(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	bmp280 := NewBMP280Driver(adaptor,
+		WithBMP280TemperatureOversampling(BMP280Oversampling2),
+		WithBMP280PressureOversampling(BMP280Oversampling4),
+		WithBMP280Filter(BMP280Filter8),
+		WithBMP280Standby(BMP280Standby250ms))
+	gobottest.Assert(t, bmp280.Start(), nil)
+	// sleep mode, config and ctrl_meas in normal mode
+	gobottest.Assert(t, adaptor.written[1:], []byte{0xf4, 0x00, 0xf5, 0x6c, 0xf4, 0x4f})
+}
+
+func TestBMP280DriverForcedMode(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	bmp280 := NewBMP280Driver(adaptor, WithBMP280ForcedMode())
+	gobottest.Assert(t, bmp280.Start(), nil)
+	gobottest.Assert(t, adaptor.written[1:], []byte{0xf4, 0x00, 0xf5, 0x00, 0xf4, 0x34})
+	gobottest.Assert(t, bmp280.measureTime, 40925*time.Microsecond)
+
+	// a read triggers a measurement
+	adaptor.written = nil
+	bmp280.Temperature()
+	gobottest.Assert(t, adaptor.written[:3], []byte{0xf4, 0x35, bmp280RegisterTempData})
 }
 
 func TestBMP280DriverTemperatureWriteError(t *testing.T) {
@@ -0,0 +1,30 @@
+// +build hil
+
+package i2c_test
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/gobottest/hil"
+)
+
+func TestBMP280HIL(t *testing.T) {
+	a := hil.NewAdaptor(t)
+	defer a.Finalize()
+	a.ProbeI2c(t, 0x77)
+
+	d := i2c.NewBMP280Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	// plausible values in a lab
+	temp, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp > 0 && temp < 50, true)
+
+	press, err := d.Pressure()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, press > 80000 && press < 110000, true)
+}
@@ -151,7 +151,7 @@ func (d *BMP388Driver) Start() (err error) {
 
 // Halt halts the device.
 func (d *BMP388Driver) Halt() (err error) {
-	return nil
+	return closeConnection(&d.connection)
 }
 
 // Temperature returns the current temperature, in celsius degrees.
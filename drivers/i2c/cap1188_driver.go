@@ -0,0 +1,416 @@
+package i2c
+
+import (
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// CAP1188DefaultAddress is the address of a CAP1188 with the ADDR_COMM pin
+// left open, e.g. on the Adafruit breakout
+const CAP1188DefaultAddress = 0x29
+
+const (
+	// CAP1188Touched event is published with the channel when a touch starts
+	CAP1188Touched = "touched"
+	// CAP1188Released event is published with the channel when a touch ends
+	CAP1188Released = "released"
+)
+
+const (
+	cap1188RegMainControl    = 0x00
+	cap1188RegInputStatus    = 0x03
+	cap1188RegDeltaCount     = 0x10 // 0x10..0x17
+	cap1188RegSensitivity    = 0x1F
+	cap1188RegMultipleTouch  = 0x2A
+	cap1188RegThreshold      = 0x30 // 0x30..0x37
+	cap1188RegLEDLinking     = 0x72
+	cap1188RegLEDOutput      = 0x74
+	cap1188RegProductID      = 0xFD
+	cap1188RegManufacturerID = 0xFE
+
+	cap1188MainControlINT = 0x01
+	cap1188MultipleBlock  = 0x80
+
+	cap1188ManufacturerID = 0x5D
+	cap1188ProductID      = 0x50
+	cap1166ProductID      = 0x51
+)
+
+// CAP1188Driver is a driver for the CAP1188 and CAP1166 capacitive touch
+// controllers with 8 respectively 6 touch inputs and LED outputs. The touch
+// inputs are polled by the status register or, if an ALERT pin is set, by the
+// ALERT output of the controller.
+type CAP1188Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	gobot.Eventer
+	mutex       gobot.Mutex
+	interval    time.Duration
+	channels    int
+	sensitivity int
+	multiTouch  int
+	linkedLEDs  bool
+	alertReader DigitalReader
+	alertPin    string
+	touched     uint8
+	halt        chan bool
+}
+
+// NewCAP1188Driver creates a new driver with specified i2c interface
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithCAP1188Sensitivity(int):	sensitivity 0 (lowest) to 7 (highest), defaults to 5
+//		i2c.WithCAP1188MultiTouch(int):	number of simultaneous touches 1..4, defaults to 1
+//		i2c.WithCAP1188LinkedLEDs():	the LEDs show the state of the touch inputs
+//		i2c.WithCAP1188AlertPin(DigitalReader, string):	pin the ALERT output is connected to
+//		i2c.WithCAP1188PollInterval(time.Duration):	interval of the polling, defaults to 10ms
+//
+func NewCAP1188Driver(a Connector, options ...func(Config)) *CAP1188Driver {
+	d := &CAP1188Driver{
+		name:        gobot.DefaultName("CAP1188"),
+		connector:   a,
+		Config:      NewConfig(),
+		Eventer:     gobot.NewEventer(),
+		interval:    10 * time.Millisecond,
+		channels:    8,
+		sensitivity: 5,
+		multiTouch:  1,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(CAP1188Touched)
+	d.AddEvent(CAP1188Released)
+	d.AddEvent(Error)
+
+	return d
+}
+
+// WithCAP1188Sensitivity option sets the sensitivity of all inputs from 0,
+// the lowest, to 7, the highest
+func WithCAP1188Sensitivity(sensitivity int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*CAP1188Driver)
+		if ok {
+			d.sensitivity = sensitivity
+		} else {
+			panic("trying to set sensitivity for non-CAP1188Driver")
+		}
+	}
+}
+
+// WithCAP1188MultiTouch option sets the number of simultaneous touches 1..4,
+// more touches are blocked
+func WithCAP1188MultiTouch(touches int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*CAP1188Driver)
+		if ok {
+			d.multiTouch = touches
+		} else {
+			panic("trying to set multi touch for non-CAP1188Driver")
+		}
+	}
+}
+
+// WithCAP1188LinkedLEDs option links each LED output to its touch input
+func WithCAP1188LinkedLEDs() func(Config) {
+	return func(c Config) {
+		d, ok := c.(*CAP1188Driver)
+		if ok {
+			d.linkedLEDs = true
+		} else {
+			panic("trying to set linked LEDs for non-CAP1188Driver")
+		}
+	}
+}
+
+// WithCAP1188AlertPin option sets the pin the ALERT output (active low) is
+// connected to. The status register is only read while the pin is active.
+func WithCAP1188AlertPin(reader DigitalReader, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*CAP1188Driver)
+		if ok {
+			d.alertReader, d.alertPin = reader, pin
+		} else {
+			panic("trying to set alert pin for non-CAP1188Driver")
+		}
+	}
+}
+
+// WithCAP1188PollInterval option sets the interval at which the status
+// register or the ALERT pin is polled
+func WithCAP1188PollInterval(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*CAP1188Driver)
+		if ok {
+			d.interval = interval
+		} else {
+			panic("trying to set poll interval for non-CAP1188Driver")
+		}
+	}
+}
+
+// Name returns the name for this Driver
+func (d *CAP1188Driver) Name() string { return d.name }
+
+// SetName sets the name for this Driver
+func (d *CAP1188Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection for this Driver
+func (d *CAP1188Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Contentions returns how often a caller had to wait for the driver
+func (d *CAP1188Driver) Contentions() uint64 { return d.mutex.Contentions() }
+
+// Start identifies and configures the controller and starts polling the
+// touch inputs.
+//
+// Emits the Events:
+//	touched int - The channel of a new touch
+//	released int - The channel of an ended touch
+//	error error - On error reading the touch inputs
+func (d *CAP1188Driver) Start() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(CAP1188DefaultAddress)
+
+	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+		return err
+	}
+
+	manufacturer, err := d.readRegister(cap1188RegManufacturerID)
+	if err != nil {
+		return err
+	}
+	product, err := d.readRegister(cap1188RegProductID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case manufacturer == cap1188ManufacturerID && product == cap1188ProductID:
+		d.channels = 8
+	case manufacturer == cap1188ManufacturerID && product == cap1166ProductID:
+		d.channels = 6
+	default:
+		return fmt.Errorf("Unknown CAP11xx device 0x%02X/0x%02X", manufacturer, product)
+	}
+
+	if d.sensitivity < 0 || d.sensitivity > 7 {
+		return fmt.Errorf("Invalid CAP1188 sensitivity %d", d.sensitivity)
+	}
+	if d.multiTouch < 1 || d.multiTouch > 4 {
+		return fmt.Errorf("Invalid CAP1188 multi touch %d", d.multiTouch)
+	}
+	// DELTA_SENSE is 0 for the highest (128x) and 7 for the lowest (1x)
+	// sensitivity, the base shift keeps its default of 15
+	if err = d.writeRegister(cap1188RegSensitivity, byte(7-d.sensitivity)<<4|0x0F); err != nil {
+		return err
+	}
+	if err = d.writeRegister(cap1188RegMultipleTouch, cap1188MultipleBlock|byte(d.multiTouch-1)<<2); err != nil {
+		return err
+	}
+	var linking byte
+	if d.linkedLEDs {
+		linking = d.channelMask()
+	}
+	if err = d.writeRegister(cap1188RegLEDLinking, linking); err != nil {
+		return err
+	}
+	if err = d.clearInterrupt(); err != nil {
+		return err
+	}
+
+	d.touched = 0
+	d.halt = make(chan bool)
+	go d.poll(d.halt)
+	return
+}
+
+// Halt stops polling the touch inputs and releases the connection
+func (d *CAP1188Driver) Halt() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.halt != nil {
+		close(d.halt)
+		d.halt = nil
+	}
+	return closeConnection(&d.connection)
+}
+
+// Channels returns the number of touch inputs, 8 for the CAP1188 and 6 for
+// the CAP1166
+func (d *CAP1188Driver) Channels() int { return d.channels }
+
+// Touched returns the touched inputs, bit 0 is channel 1
+func (d *CAP1188Driver) Touched() (uint8, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readTouched()
+}
+
+// IsTouched returns whether the channel 1..8 is touched
+func (d *CAP1188Driver) IsTouched(channel int) (bool, error) {
+	if err := d.checkChannel(channel); err != nil {
+		return false, err
+	}
+	touched, err := d.Touched()
+	return touched&(1<<uint(channel-1)) != 0, err
+}
+
+// SetThreshold sets the delta count the channel 1..8 is touched at, the
+// default is 64
+func (d *CAP1188Driver) SetThreshold(channel int, threshold uint8) error {
+	if err := d.checkChannel(channel); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeRegister(cap1188RegThreshold+uint8(channel-1), threshold&0x7F)
+}
+
+// DeltaCount returns the delta count of the channel 1..8, which is compared
+// with the threshold
+func (d *CAP1188Driver) DeltaCount(channel int) (int8, error) {
+	if err := d.checkChannel(channel); err != nil {
+		return 0, err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	val, err := d.readRegister(cap1188RegDeltaCount + uint8(channel-1))
+	return int8(val), err
+}
+
+// SetLED switches the LED 1..8 on or off, which is not linked to its input
+func (d *CAP1188Driver) SetLED(led int, on bool) error {
+	if err := d.checkChannel(led); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	leds, err := d.readRegister(cap1188RegLEDOutput)
+	if err != nil {
+		return err
+	}
+	if on {
+		leds |= 1 << uint(led-1)
+	} else {
+		leds &^= 1 << uint(led-1)
+	}
+	return d.writeRegister(cap1188RegLEDOutput, leds)
+}
+
+// poll publishes the touched and released events until halted
+func (d *CAP1188Driver) poll(halt chan bool) {
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(d.interval):
+		}
+
+		if d.alertReader != nil {
+			val, err := d.alertReader.DigitalRead(d.alertPin)
+			if err != nil {
+				d.Publish(d.Event(Error), err)
+				continue
+			}
+			if val != 0 {
+				continue
+			}
+		}
+
+		d.mutex.Lock()
+		if d.halt != halt {
+			// halted while waiting for the lock
+			d.mutex.Unlock()
+			return
+		}
+		touched, err := d.readTouched()
+		changed := touched ^ d.touched
+		d.touched = touched
+		d.mutex.Unlock()
+
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+			continue
+		}
+		for i := 0; i < d.channels; i++ {
+			if changed&(1<<uint(i)) == 0 {
+				continue
+			}
+			if touched&(1<<uint(i)) != 0 {
+				d.Publish(d.Event(CAP1188Touched), i+1)
+			} else {
+				d.Publish(d.Event(CAP1188Released), i+1)
+			}
+		}
+	}
+}
+
+// readTouched reads the input status and clears the interrupt, the status
+// bits of released inputs are held until then
+func (d *CAP1188Driver) readTouched() (uint8, error) {
+	touched, err := d.readRegister(cap1188RegInputStatus)
+	if err != nil {
+		return 0, err
+	}
+	return touched & d.channelMask(), d.clearInterrupt()
+}
+
+func (d *CAP1188Driver) clearInterrupt() error {
+	ctrl, err := d.readRegister(cap1188RegMainControl)
+	if err != nil {
+		return err
+	}
+	if ctrl&cap1188MainControlINT == 0 {
+		return nil
+	}
+	return d.writeRegister(cap1188RegMainControl, ctrl&^cap1188MainControlINT)
+}
+
+func (d *CAP1188Driver) channelMask() uint8 {
+	return uint8(0xFF >> uint(8-d.channels))
+}
+
+func (d *CAP1188Driver) checkChannel(channel int) error {
+	if channel < 1 || channel > d.channels {
+		return fmt.Errorf("Invalid CAP1188 channel %d", channel)
+	}
+	return nil
+}
+
+func (d *CAP1188Driver) writeRegister(reg uint8, val uint8) (err error) {
+	_, err = d.connection.Write([]byte{reg, val})
+	return
+}
+
+func (d *CAP1188Driver) readRegister(reg uint8) (uint8, error) {
+	if _, err := d.connection.Write([]byte{reg}); err != nil {
+		return 0, err
+	}
+	buf := []byte{0}
+	n, err := d.connection.Read(buf)
+	if err != nil {
+		return 0, err
+	}
+	if n != 1 {
+		return 0, ErrNotEnoughBytes
+	}
+	return buf[0], nil
+}
@@ -0,0 +1,214 @@
+package i2c
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*CAP1188Driver)(nil)
+
+// cap1188TestRegisters simulates the register map of a CAP1188
+type cap1188TestRegisters struct {
+	mtx     sync.Mutex
+	regs    [256]byte
+	pointer byte
+}
+
+func newCAP1188TestRegisters(product byte) *cap1188TestRegisters {
+	r := &cap1188TestRegisters{}
+	r.regs[cap1188RegManufacturerID] = cap1188ManufacturerID
+	r.regs[cap1188RegProductID] = product
+	return r
+}
+
+func (r *cap1188TestRegisters) write(b []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.pointer = b[0]
+	if len(b) > 1 {
+		r.regs[b[0]] = b[1]
+	}
+	return len(b), nil
+}
+
+func (r *cap1188TestRegisters) read(b []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	b[0] = r.regs[r.pointer]
+	return 1, nil
+}
+
+func (r *cap1188TestRegisters) get(reg byte) byte {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.regs[reg]
+}
+
+func (r *cap1188TestRegisters) set(reg, val byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.regs[reg] = val
+}
+
+func initTestCAP1188DriverWithRegisters(product byte, options ...func(Config)) (*CAP1188Driver, *cap1188TestRegisters) {
+	regs := newCAP1188TestRegisters(product)
+	adaptor := newI2cTestAdaptor()
+	adaptor.Testi2cWriteImpl(regs.write)
+	adaptor.Testi2cReadImpl(regs.read)
+	return NewCAP1188Driver(adaptor, options...), regs
+}
+
+func TestNewCAP1188Driver(t *testing.T) {
+	d := NewCAP1188Driver(newI2cTestAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.sensitivity, 5)
+	gobottest.Assert(t, d.multiTouch, 1)
+	gobottest.Assert(t, d.interval, 10*time.Millisecond)
+
+	d = NewCAP1188Driver(newI2cTestAdaptor(), WithCAP1188Sensitivity(7), WithCAP1188MultiTouch(2),
+		WithCAP1188LinkedLEDs(), WithCAP1188PollInterval(time.Second))
+	gobottest.Assert(t, d.sensitivity, 7)
+	gobottest.Assert(t, d.multiTouch, 2)
+	gobottest.Assert(t, d.linkedLEDs, true)
+	gobottest.Assert(t, d.interval, time.Second)
+}
+
+func TestCAP1188DriverStart(t *testing.T) {
+	d, regs := initTestCAP1188DriverWithRegisters(cap1188ProductID, WithCAP1188Sensitivity(7),
+		WithCAP1188MultiTouch(2), WithCAP1188LinkedLEDs())
+	regs.set(cap1188RegMainControl, cap1188MainControlINT)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Channels(), 8)
+	gobottest.Assert(t, regs.get(cap1188RegSensitivity), byte(0x0F))
+	gobottest.Assert(t, regs.get(cap1188RegMultipleTouch), byte(0x84))
+	gobottest.Assert(t, regs.get(cap1188RegLEDLinking), byte(0xFF))
+	gobottest.Assert(t, regs.get(cap1188RegMainControl), byte(0x00))
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, connected(d.connection), false)
+	_, err := d.Touched()
+	gobottest.Assert(t, err, ErrNotStarted)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	d, regs = initTestCAP1188DriverWithRegisters(cap1166ProductID, WithCAP1188LinkedLEDs())
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Channels(), 6)
+	gobottest.Assert(t, regs.get(cap1188RegSensitivity), byte(0x2F))
+	gobottest.Assert(t, regs.get(cap1188RegLEDLinking), byte(0x3F))
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestCAP1188DriverStartError(t *testing.T) {
+	d, _ := initTestCAP1188DriverWithRegisters(0x42)
+	gobottest.Assert(t, d.Start().Error(), "Unknown CAP11xx device 0x5D/0x42")
+
+	d, _ = initTestCAP1188DriverWithRegisters(cap1188ProductID, WithCAP1188MultiTouch(5))
+	gobottest.Assert(t, d.Start().Error(), "Invalid CAP1188 multi touch 5")
+
+	adaptor := newI2cTestAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	d = NewCAP1188Driver(adaptor)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestCAP1188DriverEvents(t *testing.T) {
+	d, regs := initTestCAP1188DriverWithRegisters(cap1188ProductID, WithCAP1188PollInterval(time.Millisecond))
+	touched := make(chan interface{}, 8)
+	released := make(chan interface{}, 8)
+	d.On(d.Event(CAP1188Touched), func(data interface{}) { touched <- data })
+	d.On(d.Event(CAP1188Released), func(data interface{}) { released <- data })
+	gobottest.Assert(t, d.Start(), nil)
+
+	regs.set(cap1188RegInputStatus, 0x05)
+	for _, want := range []int{1, 3} {
+		select {
+		case data := <-touched:
+			gobottest.Assert(t, data, want)
+		case <-time.After(time.Second):
+			t.Errorf("touched event for channel %d was not published", want)
+		}
+	}
+
+	regs.set(cap1188RegInputStatus, 0x04)
+	select {
+	case data := <-released:
+		gobottest.Assert(t, data, 1)
+	case <-time.After(time.Second):
+		t.Errorf("released event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+type cap1188TestAlert struct {
+	mtx sync.Mutex
+	val int
+}
+
+func (a *cap1188TestAlert) DigitalRead(pin string) (int, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.val, nil
+}
+
+func TestCAP1188DriverAlertPin(t *testing.T) {
+	alert := &cap1188TestAlert{val: 1}
+	d, regs := initTestCAP1188DriverWithRegisters(cap1188ProductID, WithCAP1188PollInterval(time.Millisecond),
+		WithCAP1188AlertPin(alert, "7"))
+	touched := make(chan interface{}, 8)
+	d.On(d.Event(CAP1188Touched), func(data interface{}) { touched <- data })
+	gobottest.Assert(t, d.Start(), nil)
+
+	regs.set(cap1188RegInputStatus, 0x80)
+	select {
+	case <-touched:
+		t.Errorf("touched event was published while ALERT is inactive")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	alert.mtx.Lock()
+	alert.val = 0
+	alert.mtx.Unlock()
+	select {
+	case data := <-touched:
+		gobottest.Assert(t, data, 8)
+	case <-time.After(time.Second):
+		t.Errorf("touched event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestCAP1188DriverChannels(t *testing.T) {
+	// the polling does not interfere within the test
+	d, regs := initTestCAP1188DriverWithRegisters(cap1166ProductID, WithCAP1188PollInterval(time.Hour))
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	regs.set(cap1188RegInputStatus, 0xC2)
+	touched, err := d.Touched()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, touched, uint8(0x02))
+	is, err := d.IsTouched(2)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, is, true)
+
+	gobottest.Assert(t, d.SetThreshold(3, 0x20), nil)
+	gobottest.Assert(t, regs.get(cap1188RegThreshold+2), byte(0x20))
+	gobottest.Assert(t, d.SetThreshold(7, 0x20).Error(), "Invalid CAP1188 channel 7")
+
+	regs.set(cap1188RegDeltaCount+5, 0xF0)
+	delta, err := d.DeltaCount(6)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, delta, int8(-16))
+
+	gobottest.Assert(t, d.SetLED(2, true), nil)
+	gobottest.Assert(t, d.SetLED(4, true), nil)
+	gobottest.Assert(t, d.SetLED(2, false), nil)
+	gobottest.Assert(t, regs.get(cap1188RegLEDOutput), byte(0x08))
+	_, err = d.IsTouched(0)
+	gobottest.Assert(t, err.Error(), "Invalid CAP1188 channel 0")
+}
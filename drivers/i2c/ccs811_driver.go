@@ -38,6 +38,8 @@ const (
 	ccs811RegEnvData = 0x05
 	//Register that holds the NTC value used for temperature calcualtions
 	ccs811RegNtc = 0x06
+	//Two byte read/write register which contains an encoded version of the current baseline used in Algorithm Calculations.
+	ccs811RegBaseline = 0x11
 	//Asserting the SW_RESET will restart the CCS811 in Boot mode to enable new application firmware to be downloaded.
 	ccs811RegSwReset = 0xFF
 	//Single byte read only register which holds the HW ID which is 0x81 for this family of CCS81x devices.
@@ -121,6 +123,9 @@ type CCS811Driver struct {
 	measMode           *CCS811MeasMode
 	ntcResistanceValue uint32
 	Config
+	// mutex protects the options and the running state
+	mutex   gobot.Mutex
+	running bool
 }
 
 //NewCCS811Driver creates a new driver for the CCS811 (air quality sensor)
@@ -160,6 +165,9 @@ func WithCCS811NTCResistance(val uint32) func(Config) {
 
 //Start initializes the sensor
 func (d *CCS811Driver) Start() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(ccs811DefaultAddress)
 
@@ -167,7 +175,26 @@ func (d *CCS811Driver) Start() (err error) {
 		return err
 	}
 
-	return d.initialize()
+	if err = d.initialize(); err != nil {
+		return err
+	}
+	d.running = true
+	return
+}
+
+//ApplyOptions applies the options, e.g. WithCCS811MeasMode, while the driver is
+//running and writes the measurement mode to the device.
+func (d *CCS811Driver) ApplyOptions(options ...func(Config)) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, option := range options {
+		option(d)
+	}
+	if !d.running {
+		return nil
+	}
+	return d.updateMeasMode()
 }
 
 //Name returns the Name for the Driver
@@ -179,8 +206,17 @@ func (d *CCS811Driver) SetName(n string) { d.name = n }
 //Connection returns the connection for the Driver
 func (d *CCS811Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (d *CCS811Driver) Contentions() uint64 { return d.mutex.Contentions() }
+
 //Halt returns true if devices is halted successfully
-func (d *CCS811Driver) Halt() (err error) { return }
+func (d *CCS811Driver) Halt() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.running = false
+	return closeConnection(&d.connection)
+}
 
 //GetHardwareVersion returns the hardware version of the device in the form of 0x1X
 func (d *CCS811Driver) GetHardwareVersion() (uint8, error) {
@@ -275,6 +311,40 @@ func (d *CCS811Driver) HasData() (bool, error) {
 	return true, nil
 }
 
+//GetBaseline returns the current baseline of the algorithm calculations. The value is encoded by the device
+//and should be saved and restored by SetBaseline, e.g. after a reboot, to avoid a new burn-in.
+func (d *CCS811Driver) GetBaseline() (uint16, error) {
+
+	data, err := d.read(ccs811RegBaseline, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	return (uint16(data[0]) << 8) | uint16(data[1]), nil
+}
+
+//SetBaseline restores a baseline previously returned by GetBaseline.
+func (d *CCS811Driver) SetBaseline(baseline uint16) error {
+	return d.connection.WriteBlockData(ccs811RegBaseline, []byte{byte(baseline >> 8), byte(baseline)})
+}
+
+//SetEnvironmentalData writes the relative humidity in percent and the temperature in celsius, e.g. from a
+//companion sensor, which are used to compensate the gas data.
+func (d *CCS811Driver) SetEnvironmentalData(humidity float32, temperature float32) error {
+	if humidity < 0 || humidity > 100 {
+		return fmt.Errorf("The humidity %v is out of the range 0 to 100", humidity)
+	}
+	if temperature < -25 || temperature >= 102 {
+		return fmt.Errorf("The temperature %v is out of the range -25 to 102", temperature)
+	}
+
+	// Both values are in units of 1/512, the temperature has an offset of 25 degrees
+	hum := uint16(humidity*512 + 0.5)
+	temp := uint16((temperature+25)*512 + 0.5)
+
+	return d.connection.WriteBlockData(ccs811RegEnvData, []byte{byte(hum >> 8), byte(hum), byte(temp >> 8), byte(temp)})
+}
+
 //EnableExternalInterrupt enables the external output hardware interrupt pin 3.
 func (d *CCS811Driver) EnableExternalInterrupt() error {
 	d.measMode.intDataRdy = 1
@@ -254,3 +254,65 @@ func TestCCS811DriverHasData(t *testing.T) {
 	}
 
 }
+
+func TestCCS811DriverBaseline(t *testing.T) {
+	d, adaptor := initTestCCS811DriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) { return 0, nil }
+	d.Start()
+
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x84, 0x7B})
+		return 2, nil
+	}
+	baseline, err := d.GetBaseline()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, baseline, uint16(0x847B))
+	gobottest.Assert(t, adaptor.written, []byte{ccs811RegBaseline})
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, d.SetBaseline(baseline), nil)
+	gobottest.Assert(t, adaptor.written, []byte{ccs811RegBaseline, 0x84, 0x7B})
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("Error")
+	}
+	_, err = d.GetBaseline()
+	gobottest.Assert(t, err, errors.New("Error"))
+}
+
+func TestCCS811DriverSetEnvironmentalData(t *testing.T) {
+	d, adaptor := initTestCCS811DriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) { return 0, nil }
+	d.Start()
+
+	// the example of the datasheet, 48.5% and 25.5C
+	adaptor.written = []byte{}
+	gobottest.Assert(t, d.SetEnvironmentalData(48.5, 25.5), nil)
+	gobottest.Assert(t, adaptor.written, []byte{ccs811RegEnvData, 0x61, 0x00, 0x65, 0x00})
+
+	gobottest.Refute(t, d.SetEnvironmentalData(101, 25), nil)
+	gobottest.Refute(t, d.SetEnvironmentalData(50, -30), nil)
+}
+
+func TestCCS811DriverApplyOptions(t *testing.T) {
+	d, adaptor := initTestCCS811DriverWithStubbedAdaptor()
+	adaptor.i2cWriteImpl = func([]byte) (int, error) { return 0, nil }
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{ccs811HwIDCode})
+		return 1, nil
+	}
+	gobottest.Assert(t, d.Start(), nil)
+
+	// the measurement mode is written while running
+	adaptor.written = []byte{}
+	gobottest.Assert(t, d.ApplyOptions(WithCCS811MeasMode(CCS811DriveMode10Sec)), nil)
+	gobottest.Assert(t, adaptor.written, []byte{ccs811RegMeasMode, d.measMode.GetMeasMode()})
+
+	// and used by the next Start after Halt
+	gobottest.Assert(t, d.Halt(), nil)
+	adaptor.written = []byte{}
+	gobottest.Assert(t, d.ApplyOptions(WithCCS811MeasMode(CCS811DriveMode60Sec)), nil)
+	gobottest.Assert(t, len(adaptor.written), 0)
+	gobottest.Assert(t, d.measMode.driveMode, CCS811DriveMode(CCS811DriveMode60Sec))
+}
@@ -77,6 +77,7 @@ type DRV2605LDriver struct {
 	name       string
 	connector  Connector
 	connection Connection
+	mutex      gobot.Mutex
 	Config
 }
 
@@ -118,6 +119,9 @@ func (d *DRV2605LDriver) Connection() gobot.Connection {
 	return d.connector.(gobot.Connection)
 }
 
+// Contentions returns how often a caller had to wait for the driver
+func (d *DRV2605LDriver) Contentions() uint64 { return d.mutex.Contentions() }
+
 // Start initializes the device.
 func (d *DRV2605LDriver) Start() (err error) {
 	if err := d.initialize(); err != nil {
@@ -177,6 +181,9 @@ func (d *DRV2605LDriver) initialize() (err error) {
 // SetMode sets the device in one of the eight modes as described in the
 // datasheet. Defaults to mode 0, internal trig.
 func (d *DRV2605LDriver) SetMode(newMode DRV2605Mode) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	mode, err := d.connection.ReadByteData(drv2605RegMode)
 	if err != nil {
 		return err
@@ -194,6 +201,9 @@ func (d *DRV2605LDriver) SetMode(newMode DRV2605Mode) (err error) {
 
 // SetStandbyMode controls device low power mode
 func (d *DRV2605LDriver) SetStandbyMode(standby bool) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
 	modeVal, err := d.connection.ReadByteData(drv2605RegMode)
 	if err != nil {
 		return err
@@ -241,6 +251,8 @@ func (d *DRV2605LDriver) SetSequence(waveforms []uint8) (err error) {
 	if len(waveforms) > 8 {
 		waveforms = waveforms[0:8]
 	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 	for i, w := range waveforms {
 		if err = d.connection.WriteByteData(uint8(drv2605RegWaveSeq1+i), w); err != nil {
 			return err
@@ -257,14 +269,16 @@ func (d *DRV2605LDriver) Go() (err error) {
 
 // Halt halts the device.
 func (d *DRV2605LDriver) Halt() (err error) {
-	if d.connection != nil {
+	if connected(d.connection) {
 		// stop playback
 		if err = d.connection.WriteByteData(drv2605RegGo, 0); err != nil {
 			return err
 		}
 
 		// enter standby
-		return d.SetStandbyMode(true)
+		if err = d.SetStandbyMode(true); err != nil {
+			return err
+		}
 	}
-	return
+	return closeConnection(&d.connection)
 }
@@ -168,3 +168,25 @@ func TestDRV2605LDriverGo(t *testing.T) {
 	d.Start()
 	gobottest.Assert(t, d.Go(), nil)
 }
+
+func TestDRV2605LDriverConcurrency(t *testing.T) {
+	d, adaptor := initTestDriverAndAdaptor()
+	d.Start()
+
+	// simulates the mode register, which is read-modified-written
+	var mode byte
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = mode
+		return 1, nil
+	}
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		mode = b[0]
+		return 1, nil
+	}
+
+	gobottest.Hammer(t, 4, 50,
+		func() { d.SetMode(DRV2605ModePWMAnalog) },
+		func() { d.SetStandbyMode(true) },
+	)
+	gobottest.Assert(t, mode, byte(drv2605Standby|DRV2605ModePWMAnalog))
+}
@@ -0,0 +1,385 @@
+package i2c
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sigurn/crc8"
+	"gobot.io/x/gobot"
+)
+
+// DS2482DefaultAddress is the address of a DS2482 with AD0 and AD1 low
+const DS2482DefaultAddress = 0x18
+
+const (
+	ds2482DeviceReset      = 0xF0
+	ds2482SetReadPointer   = 0xE1
+	ds2482WriteConfig      = 0xD2
+	ds2482ChannelSelect    = 0xC3
+	ds2482OneWireReset     = 0xB4
+	ds2482OneWireBit       = 0x87
+	ds2482OneWireWriteByte = 0xA5
+	ds2482OneWireReadByte  = 0x96
+	ds2482OneWireTriplet   = 0x78
+
+	ds2482RegData = 0xE1
+
+	ds2482Status1WB = 0x01 // 1-Wire busy
+	ds2482StatusPPD = 0x02 // presence pulse detected
+	ds2482StatusSD  = 0x04 // short detected
+	ds2482StatusRST = 0x10 // device reset
+	ds2482StatusSBR = 0x20 // single bit result
+	ds2482StatusTSB = 0x40 // triplet second bit
+	ds2482StatusDIR = 0x80 // branch direction taken
+
+	ds2482ConfigAPU = 0x01 // active pullup
+	ds2482Config1WS = 0x08 // overdrive speed
+
+	ds2482BusyTimeout = 20 * time.Millisecond
+
+	// 1-Wire ROM commands
+	oneWireSearchROM = 0xF0
+	oneWireMatchROM  = 0x55
+	oneWireSkipROM   = 0xCC
+)
+
+var (
+	// ErrDS2482Busy is returned when a 1-Wire operation does not complete in time
+	ErrDS2482Busy = errors.New("DS2482 1-Wire busy timeout")
+	// ErrDS2482Short is returned when a short of the 1-Wire line is detected on reset
+	ErrDS2482Short = errors.New("DS2482 1-Wire short detected")
+	// ErrDS2482Search is returned when the devices do not answer a search consistently
+	ErrDS2482Search = errors.New("DS2482 1-Wire search failed")
+	// ErrDS2482CRC is returned for a ROM ID with a wrong CRC
+	ErrDS2482CRC = errors.New("DS2482 1-Wire ROM CRC mismatch")
+)
+
+var ds2482ChannelCodes = []struct{ sel, check byte }{
+	{0xF0, 0xB8}, {0xE1, 0xB1}, {0xD2, 0xAA}, {0xC3, 0xA3},
+	{0xB4, 0x9C}, {0xA5, 0x95}, {0x96, 0x8E}, {0x87, 0x87},
+}
+
+// DS2482Driver is a driver for the DS2482-100 and DS2482-800 I2C to 1-Wire
+// bridges. It provides the 1-Wire primitives (reset, bit, byte, search and
+// ROM selection) to talk to 1-Wire devices, e.g. DS18B20 sensors, on boards
+// without a native 1-Wire bus.
+type DS2482Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	config   byte
+	crcTable *crc8.Table
+}
+
+// NewDS2482Driver creates a new driver with specified i2c interface
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithDS2482ActivePullup():	enable the active pullup for long lines
+//		i2c.WithDS2482Overdrive():	use the 1-Wire overdrive speed
+//
+func NewDS2482Driver(a Connector, options ...func(Config)) *DS2482Driver {
+	d := &DS2482Driver{
+		name:      gobot.DefaultName("DS2482"),
+		connector: a,
+		Config:    NewConfig(),
+		crcTable:  crc8.MakeTable(crc8.CRC8_MAXIM),
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithDS2482ActivePullup option enables the active pullup of the 1-Wire line,
+// recommended for more than one device or long lines.
+func WithDS2482ActivePullup() func(Config) {
+	return func(c Config) {
+		d, ok := c.(*DS2482Driver)
+		if ok {
+			d.config |= ds2482ConfigAPU
+		} else {
+			panic("trying to set active pullup for non-DS2482Driver")
+		}
+	}
+}
+
+// WithDS2482Overdrive option selects the 1-Wire overdrive speed.
+func WithDS2482Overdrive() func(Config) {
+	return func(c Config) {
+		d, ok := c.(*DS2482Driver)
+		if ok {
+			d.config |= ds2482Config1WS
+		} else {
+			panic("trying to set overdrive for non-DS2482Driver")
+		}
+	}
+}
+
+// Name returns the name for this Driver
+func (d *DS2482Driver) Name() string { return d.name }
+
+// SetName sets the name for this Driver
+func (d *DS2482Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection for this Driver
+func (d *DS2482Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start resets and configures the DS2482
+func (d *DS2482Driver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(DS2482DefaultAddress)
+
+	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+		return err
+	}
+
+	if _, err = d.connection.Write([]byte{ds2482DeviceReset}); err != nil {
+		return err
+	}
+	status, err := d.connection.ReadByte()
+	if err != nil {
+		return err
+	}
+	if status&ds2482StatusRST == 0 {
+		return fmt.Errorf("DS2482 did not reset, status 0x%02X", status)
+	}
+
+	return d.writeConfig(d.config)
+}
+
+// Halt stops the driver and releases the connection
+func (d *DS2482Driver) Halt() (err error) { return closeConnection(&d.connection) }
+
+// SelectChannel selects the 1-Wire channel 0..7 of a DS2482-800
+func (d *DS2482Driver) SelectChannel(channel int) error {
+	if channel < 0 || channel >= len(ds2482ChannelCodes) {
+		return fmt.Errorf("Invalid DS2482 channel %d", channel)
+	}
+	code := ds2482ChannelCodes[channel]
+	if _, err := d.connection.Write([]byte{ds2482ChannelSelect, code.sel}); err != nil {
+		return err
+	}
+	check, err := d.connection.ReadByte()
+	if err != nil {
+		return err
+	}
+	if check != code.check {
+		return fmt.Errorf("DS2482 channel %d not selected", channel)
+	}
+	return nil
+}
+
+// Reset sends a reset pulse on the 1-Wire line and returns whether a device
+// answered with a presence pulse
+func (d *DS2482Driver) Reset() (presence bool, err error) {
+	status, err := d.command(ds2482OneWireReset)
+	if err != nil {
+		return false, err
+	}
+	if status&ds2482StatusSD != 0 {
+		return false, ErrDS2482Short
+	}
+	return status&ds2482StatusPPD != 0, nil
+}
+
+// WriteBit writes a single bit to the 1-Wire line
+func (d *DS2482Driver) WriteBit(bit bool) error {
+	_, err := d.ReadBit(bit)
+	return err
+}
+
+// ReadBit generates a single time slot, writing bit and returning the bit
+// read. To read, bit must be true.
+func (d *DS2482Driver) ReadBit(bit bool) (bool, error) {
+	var param byte
+	if bit {
+		param = 0x80
+	}
+	status, err := d.command(ds2482OneWireBit, param)
+	if err != nil {
+		return false, err
+	}
+	return status&ds2482StatusSBR != 0, nil
+}
+
+// WriteByte writes a byte to the 1-Wire line, least significant bit first
+func (d *DS2482Driver) WriteByte(val byte) error {
+	_, err := d.command(ds2482OneWireWriteByte, val)
+	return err
+}
+
+// ReadByte reads a byte from the 1-Wire line
+func (d *DS2482Driver) ReadByte() (byte, error) {
+	if _, err := d.command(ds2482OneWireReadByte); err != nil {
+		return 0, err
+	}
+	if _, err := d.connection.Write([]byte{ds2482SetReadPointer, ds2482RegData}); err != nil {
+		return 0, err
+	}
+	return d.connection.ReadByte()
+}
+
+// Write writes the bytes to the 1-Wire line
+func (d *DS2482Driver) Write(data []byte) error {
+	for _, b := range data {
+		if err := d.WriteByte(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read reads n bytes from the 1-Wire line
+func (d *DS2482Driver) Read(n int) ([]byte, error) {
+	data := make([]byte, n)
+	for i := range data {
+		b, err := d.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		data[i] = b
+	}
+	return data, nil
+}
+
+// Select resets the 1-Wire line and addresses the device with the ROM ID,
+// the family code is the least significant byte
+func (d *DS2482Driver) Select(rom uint64) error {
+	if err := d.reset(); err != nil {
+		return err
+	}
+	cmd := make([]byte, 9)
+	cmd[0] = oneWireMatchROM
+	binary.LittleEndian.PutUint64(cmd[1:], rom)
+	return d.Write(cmd)
+}
+
+// Skip resets the 1-Wire line and addresses all devices, e.g. for a single
+// device or to start a conversion of all sensors at once
+func (d *DS2482Driver) Skip() error {
+	if err := d.reset(); err != nil {
+		return err
+	}
+	return d.WriteByte(oneWireSkipROM)
+}
+
+// Search returns the ROM IDs of all devices on the 1-Wire line, the family
+// code is the least significant byte
+func (d *DS2482Driver) Search() ([]uint64, error) {
+	roms := []uint64{}
+	var rom uint64
+	lastDiscrepancy := 0
+
+	for {
+		presence, err := d.Reset()
+		if err != nil {
+			return nil, err
+		}
+		if !presence {
+			return roms, nil
+		}
+		if err := d.WriteByte(oneWireSearchROM); err != nil {
+			return nil, err
+		}
+
+		var next uint64
+		lastZero := 0
+		for bit := 1; bit <= 64; bit++ {
+			var dir bool
+			if bit < lastDiscrepancy {
+				dir = rom&(1<<uint(bit-1)) != 0
+			} else {
+				dir = bit == lastDiscrepancy
+			}
+			var param byte
+			if dir {
+				param = 0x80
+			}
+			status, err := d.command(ds2482OneWireTriplet, param)
+			if err != nil {
+				return nil, err
+			}
+			id := status&ds2482StatusSBR != 0
+			cmp := status&ds2482StatusTSB != 0
+			taken := status&ds2482StatusDIR != 0
+			if id && cmp {
+				return nil, ErrDS2482Search
+			}
+			if !id && !cmp && !taken {
+				lastZero = bit
+			}
+			if taken {
+				next |= 1 << uint(bit-1)
+			}
+		}
+
+		id := make([]byte, 8)
+		binary.LittleEndian.PutUint64(id, next)
+		if crc8.Checksum(id[:7], d.crcTable) != id[7] {
+			return nil, ErrDS2482CRC
+		}
+		roms = append(roms, next)
+
+		rom = next
+		lastDiscrepancy = lastZero
+		if lastDiscrepancy == 0 {
+			return roms, nil
+		}
+	}
+}
+
+func (d *DS2482Driver) reset() error {
+	presence, err := d.Reset()
+	if err != nil {
+		return err
+	}
+	if !presence {
+		return errors.New("No 1-Wire device present")
+	}
+	return nil
+}
+
+// writeConfig writes the configuration, the upper nibble is the complement
+// of the lower one
+func (d *DS2482Driver) writeConfig(config byte) error {
+	if _, err := d.connection.Write([]byte{ds2482WriteConfig, config | ^config<<4}); err != nil {
+		return err
+	}
+	check, err := d.connection.ReadByte()
+	if err != nil {
+		return err
+	}
+	if check != config {
+		return fmt.Errorf("DS2482 configuration 0x%02X not written", config)
+	}
+	return nil
+}
+
+// command sends a 1-Wire command and waits until the 1-Wire line is idle,
+// the read pointer is at the status register afterwards
+func (d *DS2482Driver) command(cmd ...byte) (status byte, err error) {
+	if _, err = d.connection.Write(cmd); err != nil {
+		return
+	}
+	deadline := time.Now().Add(ds2482BusyTimeout)
+	for {
+		if status, err = d.connection.ReadByte(); err != nil {
+			return
+		}
+		if status&ds2482Status1WB == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			return status, ErrDS2482Busy
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package i2c
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/sigurn/crc8"
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*DS2482Driver)(nil)
+
+// ds2482TestBridge simulates a DS2482 with 1-Wire devices on its line
+type ds2482TestBridge struct {
+	roms     []uint64
+	short    bool
+	register byte
+	data     byte
+	config   byte
+	written  []byte
+	active   []bool
+	bit      uint
+	search   bool
+}
+
+func newDS2482TestBridge(roms ...uint64) *ds2482TestBridge {
+	return &ds2482TestBridge{roms: roms, data: 0x5A}
+}
+
+func (b *ds2482TestBridge) write(p []byte) (int, error) {
+	switch p[0] {
+	case ds2482DeviceReset:
+		b.register = ds2482StatusRST
+	case ds2482WriteConfig:
+		b.config = p[1] & 0x0F
+		b.register = b.config
+	case ds2482ChannelSelect:
+		for _, c := range ds2482ChannelCodes {
+			if c.sel == p[1] {
+				b.register = c.check
+			}
+		}
+	case ds2482SetReadPointer:
+		b.register = b.data
+	case ds2482OneWireReset:
+		b.register = 0
+		if len(b.roms) > 0 {
+			b.register |= ds2482StatusPPD
+		}
+		if b.short {
+			b.register |= ds2482StatusSD
+		}
+		b.search = false
+	case ds2482OneWireWriteByte:
+		b.written = append(b.written, p[1])
+		if p[1] == oneWireSearchROM {
+			b.search = true
+			b.bit = 0
+			b.active = make([]bool, len(b.roms))
+			for i := range b.active {
+				b.active[i] = true
+			}
+		}
+		b.register = 0
+	case ds2482OneWireReadByte:
+		b.register = 0
+	case ds2482OneWireBit:
+		b.register = 0
+		if p[1]&0x80 != 0 {
+			b.register = ds2482StatusSBR
+		}
+	case ds2482OneWireTriplet:
+		b.register = b.triplet(p[1]&0x80 != 0)
+	}
+	return len(p), nil
+}
+
+// triplet reads the wired-AND of the bit and its complement of all devices
+// still taking part in the search and selects the branch
+func (b *ds2482TestBridge) triplet(dir bool) (status byte) {
+	id, cmp := true, true
+	for i, rom := range b.roms {
+		if b.active[i] {
+			set := rom&(1<<b.bit) != 0
+			id = id && set
+			cmp = cmp && !set
+		}
+	}
+	if id != cmp {
+		dir = id
+	}
+	if id {
+		status |= ds2482StatusSBR
+	}
+	if cmp {
+		status |= ds2482StatusTSB
+	}
+	if dir {
+		status |= ds2482StatusDIR
+	}
+	for i, rom := range b.roms {
+		if (rom&(1<<b.bit) != 0) != dir {
+			b.active[i] = false
+		}
+	}
+	b.bit++
+	return
+}
+
+func (b *ds2482TestBridge) read(p []byte) (int, error) {
+	p[0] = b.register
+	return 1, nil
+}
+
+func ds2482TestROM(family byte, serial uint64) uint64 {
+	id := make([]byte, 8)
+	binary.LittleEndian.PutUint64(id, serial<<8|uint64(family))
+	id[7] = crc8.Checksum(id[:7], crc8.MakeTable(crc8.CRC8_MAXIM))
+	return binary.LittleEndian.Uint64(id)
+}
+
+func initTestDS2482DriverWithBridge(bridge *ds2482TestBridge, options ...func(Config)) *DS2482Driver {
+	adaptor := newI2cTestAdaptor()
+	adaptor.Testi2cWriteImpl(bridge.write)
+	adaptor.Testi2cReadImpl(bridge.read)
+	return NewDS2482Driver(adaptor, options...)
+}
+
+func TestNewDS2482Driver(t *testing.T) {
+	d := NewDS2482Driver(newI2cTestAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.GetAddressOrDefault(DS2482DefaultAddress), DS2482DefaultAddress)
+
+	d = NewDS2482Driver(newI2cTestAdaptor(), WithDS2482ActivePullup(), WithDS2482Overdrive())
+	gobottest.Assert(t, d.config, byte(ds2482ConfigAPU|ds2482Config1WS))
+}
+
+func TestDS2482DriverStart(t *testing.T) {
+	bridge := newDS2482TestBridge()
+	d := initTestDS2482DriverWithBridge(bridge, WithDS2482ActivePullup())
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, bridge.config, byte(ds2482ConfigAPU))
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, connected(d.connection), false)
+	gobottest.Assert(t, d.SelectChannel(0), ErrNotStarted)
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestDS2482DriverStartError(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d := NewDS2482Driver(adaptor)
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		b[0] = 0
+		return 1, nil
+	})
+	gobottest.Assert(t, d.Start().Error(), "DS2482 did not reset, status 0x00")
+
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestDS2482DriverSelectChannel(t *testing.T) {
+	d := initTestDS2482DriverWithBridge(newDS2482TestBridge())
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.SelectChannel(5), nil)
+	gobottest.Assert(t, d.SelectChannel(8).Error(), "Invalid DS2482 channel 8")
+}
+
+func TestDS2482DriverReset(t *testing.T) {
+	bridge := newDS2482TestBridge()
+	d := initTestDS2482DriverWithBridge(bridge)
+	gobottest.Assert(t, d.Start(), nil)
+
+	presence, err := d.Reset()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, presence, false)
+
+	bridge.roms = []uint64{ds2482TestROM(0x28, 1)}
+	presence, err = d.Reset()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, presence, true)
+
+	bridge.short = true
+	_, err = d.Reset()
+	gobottest.Assert(t, err, ErrDS2482Short)
+}
+
+func TestDS2482DriverBitsAndBytes(t *testing.T) {
+	bridge := newDS2482TestBridge(ds2482TestROM(0x28, 1))
+	d := initTestDS2482DriverWithBridge(bridge)
+	gobottest.Assert(t, d.Start(), nil)
+
+	bit, err := d.ReadBit(true)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, bit, true)
+	gobottest.Assert(t, d.WriteBit(false), nil)
+
+	gobottest.Assert(t, d.Write([]byte{0x44, 0xBE}), nil)
+	gobottest.Assert(t, bridge.written, []byte{0x44, 0xBE})
+
+	data, err := d.Read(2)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, data, []byte{0x5A, 0x5A})
+
+	bridge.written = nil
+	gobottest.Assert(t, d.Skip(), nil)
+	gobottest.Assert(t, bridge.written, []byte{oneWireSkipROM})
+
+	bridge.written = nil
+	gobottest.Assert(t, d.Select(0x8877665544332228), nil)
+	gobottest.Assert(t, bridge.written, []byte{oneWireMatchROM, 0x28, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88})
+}
+
+func TestDS2482DriverBusy(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	d := NewDS2482Driver(adaptor)
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		b[0] = ds2482StatusRST
+		return 1, nil
+	})
+	gobottest.Assert(t, d.Start().Error(), "DS2482 configuration 0x00 not written")
+
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		b[0] = ds2482Status1WB
+		return 1, nil
+	})
+	_, err := d.Reset()
+	gobottest.Assert(t, err, ErrDS2482Busy)
+}
+
+func TestDS2482DriverSearch(t *testing.T) {
+	roms := []uint64{
+		ds2482TestROM(0x28, 0x0000000000A1),
+		ds2482TestROM(0x28, 0x0000000000B2),
+		ds2482TestROM(0x10, 0x00000000C3D4),
+		ds2482TestROM(0x3B, 0x0123456789AB),
+	}
+	d := initTestDS2482DriverWithBridge(newDS2482TestBridge(roms...))
+	gobottest.Assert(t, d.Start(), nil)
+
+	found, err := d.Search()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(found), len(roms))
+	for _, rom := range roms {
+		contained := false
+		for _, f := range found {
+			contained = contained || f == rom
+		}
+		gobottest.Assert(t, contained, true)
+	}
+}
+
+func TestDS2482DriverSearchEmpty(t *testing.T) {
+	d := initTestDS2482DriverWithBridge(newDS2482TestBridge())
+	gobottest.Assert(t, d.Start(), nil)
+
+	found, err := d.Search()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(found), 0)
+}
+
+func TestDS2482DriverSearchCRCError(t *testing.T) {
+	d := initTestDS2482DriverWithBridge(newDS2482TestBridge(0xFF00000000000028))
+	gobottest.Assert(t, d.Start(), nil)
+
+	_, err := d.Search()
+	gobottest.Assert(t, err, ErrDS2482CRC)
+}
@@ -0,0 +1,56 @@
+package i2c
+
+import "gobot.io/x/gobot"
+
+// directions of the pins of a port expander
+const (
+	expanderPinIn  = "in"
+	expanderPinOut = "out"
+)
+
+// portExpander is the interface of the port expander drivers, whose pins are
+// given as strings like "A7" and which are connections of the gpio drivers
+type portExpander interface {
+	DigitalRead(pin string) (val int, err error)
+	DigitalWrite(pin string, val byte) (err error)
+	// setPinDirection configures the pin as input "in" or output "out"
+	setPinDirection(pin string, dir string) (err error)
+}
+
+// expanderPin is a pin of a port expander as gobot.DigitalPinner, so the
+// drivers using digital pins run unchanged behind the port expander
+type expanderPin struct {
+	expander portExpander
+	pin      string
+}
+
+// newExpanderPin returns the pin of the port expander configured with the
+// direction
+func newExpanderPin(expander portExpander, pin string, dir string) (gobot.DigitalPinner, error) {
+	p := &expanderPin{expander: expander, pin: pin}
+	if err := p.Direction(dir); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Export does nothing, the pins of a port expander need no export
+func (p *expanderPin) Export() error { return nil }
+
+// Unexport does nothing, the pins of a port expander need no export
+func (p *expanderPin) Unexport() error { return nil }
+
+// Direction configures the pin as input "in" or output "out"
+func (p *expanderPin) Direction(dir string) error {
+	return p.expander.setPinDirection(p.pin, dir)
+}
+
+// Read reads the value of the pin
+func (p *expanderPin) Read() (int, error) {
+	return p.expander.DigitalRead(p.pin)
+}
+
+// Write writes the value to the pin
+func (p *expanderPin) Write(val int) error {
+	return p.expander.DigitalWrite(p.pin, byte(val))
+}
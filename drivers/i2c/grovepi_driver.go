@@ -3,7 +3,6 @@ package i2c
 import (
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -32,7 +31,7 @@ type GrovePiDriver struct {
 	name        string
 	digitalPins map[int]string
 	analogPins  map[int]string
-	mutex       *sync.Mutex
+	mutex       *gobot.Mutex
 	connector   Connector
 	connection  Connection
 	Config
@@ -51,7 +50,7 @@ func NewGrovePiDriver(a Connector, options ...func(Config)) *GrovePiDriver {
 		name:        gobot.DefaultName("GrovePi"),
 		digitalPins: make(map[int]string),
 		analogPins:  make(map[int]string),
-		mutex:       &sync.Mutex{},
+		mutex:       &gobot.Mutex{},
 		connector:   a,
 		Config:      NewConfig(),
 	}
@@ -73,6 +72,9 @@ func (d *GrovePiDriver) SetName(n string) { d.name = n }
 // Connection returns the connection for the Driver
 func (d *GrovePiDriver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (d *GrovePiDriver) Contentions() uint64 { return d.mutex.Contentions() }
+
 // Start initialized the GrovePi
 func (d *GrovePiDriver) Start() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
@@ -87,7 +89,9 @@ func (d *GrovePiDriver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (d *GrovePiDriver) Halt() (err error) { return }
+func (d *GrovePiDriver) Halt() (err error) {
+	return closeConnection(&d.connection)
+}
 
 // Connect is here to implement the Adaptor interface.
 func (d *GrovePiDriver) Connect() (err error) {
@@ -257,7 +261,7 @@ func (d *GrovePiDriver) readUltrasonic(pin byte, duration int) (val int, err err
 		return 0, err
 	}
 
-	return int(data[1]) * 255 + int(data[2]), err
+	return int(data[1])*255 + int(data[2]), err
 }
 
 // readDigital reads digitally from the GrovePi.
@@ -1,11 +1,23 @@
 package i2c
 
 import (
+	"bufio"
+	"encoding/hex"
 	"errors"
+	"flag"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"testing"
 )
 
+// updateGolden makes verifyGolden write the recorded transactions to the
+// golden files instead of comparing them, run "go test -update" to use it
+var updateGolden = flag.Bool("update", false, "update the golden files of the i2c transactions")
+
 var rgb = map[string]interface{}{
 	"red":   1.0,
 	"green": 1.0,
@@ -27,6 +39,11 @@ type i2cTestAdaptor struct {
 	i2cConnectErr bool
 	i2cReadImpl   func([]byte) (int, error)
 	i2cWriteImpl  func([]byte) (int, error)
+	// the number of closed connections
+	closed int
+	// the transactions since replayGolden, one line each, see record
+	recording    bool
+	transactions []string
 }
 
 func (t *i2cTestAdaptor) Testi2cConnectErr(val bool) {
@@ -50,17 +67,23 @@ func (t *i2cTestAdaptor) Testi2cWriteImpl(f func([]byte) (int, error)) {
 func (t *i2cTestAdaptor) Read(b []byte) (count int, err error) {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
-	return t.i2cReadImpl(b)
+	count, err = t.i2cReadImpl(b)
+	t.recordRead("read", b, count, err)
+	return
 }
 
 func (t *i2cTestAdaptor) Write(b []byte) (count int, err error) {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 	t.written = append(t.written, b...)
+	t.record("write", b)
 	return t.i2cWriteImpl(b)
 }
 
 func (t *i2cTestAdaptor) Close() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.closed++
 	return nil
 }
 
@@ -69,6 +92,7 @@ func (t *i2cTestAdaptor) ReadByte() (val byte, err error) {
 	defer t.mtx.Unlock()
 	bytes := []byte{0}
 	bytesRead, err := t.i2cReadImpl(bytes)
+	t.recordRead("read_byte", bytes, bytesRead, err)
 	if err != nil {
 		return 0, err
 	}
@@ -84,6 +108,7 @@ func (t *i2cTestAdaptor) ReadByteData(reg uint8) (val uint8, err error) {
 	defer t.mtx.Unlock()
 	bytes := []byte{0}
 	bytesRead, err := t.i2cReadImpl(bytes)
+	t.recordRead(fmt.Sprintf("read_byte_data %02x", reg), bytes, bytesRead, err)
 	if err != nil {
 		return 0, err
 	}
@@ -99,6 +124,7 @@ func (t *i2cTestAdaptor) ReadWordData(reg uint8) (val uint16, err error) {
 	defer t.mtx.Unlock()
 	bytes := []byte{0, 0}
 	bytesRead, err := t.i2cReadImpl(bytes)
+	t.recordRead(fmt.Sprintf("read_word_data %02x", reg), bytes, bytesRead, err)
 	if err != nil {
 		return 0, err
 	}
@@ -114,6 +140,7 @@ func (t *i2cTestAdaptor) WriteByte(val byte) (err error) {
 	defer t.mtx.Unlock()
 	t.written = append(t.written, val)
 	bytes := []byte{val}
+	t.record("write_byte", bytes)
 	_, err = t.i2cWriteImpl(bytes)
 	return
 }
@@ -124,6 +151,7 @@ func (t *i2cTestAdaptor) WriteByteData(reg uint8, val uint8) (err error) {
 	t.written = append(t.written, reg)
 	t.written = append(t.written, val)
 	bytes := []byte{val}
+	t.record(fmt.Sprintf("write_byte_data %02x", reg), bytes)
 	_, err = t.i2cWriteImpl(bytes)
 	return
 }
@@ -137,6 +165,7 @@ func (t *i2cTestAdaptor) WriteWordData(reg uint8, val uint16) (err error) {
 	t.written = append(t.written, low)
 	t.written = append(t.written, high)
 	bytes := []byte{low, high}
+	t.record(fmt.Sprintf("write_word_data %02x", reg), bytes)
 	_, err = t.i2cWriteImpl(bytes)
 	return
 }
@@ -146,6 +175,7 @@ func (t *i2cTestAdaptor) WriteBlockData(reg uint8, b []byte) (err error) {
 	defer t.mtx.Unlock()
 	t.written = append(t.written, reg)
 	t.written = append(t.written, b...)
+	t.record(fmt.Sprintf("write_block_data %02x", reg), b)
 	_, err = t.i2cWriteImpl(b)
 	return
 }
@@ -166,6 +196,125 @@ func (t *i2cTestAdaptor) SetName(n string)      { t.name = n }
 func (t *i2cTestAdaptor) Connect() (err error)  { return }
 func (t *i2cTestAdaptor) Finalize() (err error) { return }
 
+// record adds a transaction, e.g. "write_block_data 10: 01 02"
+func (t *i2cTestAdaptor) record(op string, data []byte) {
+	if !t.recording {
+		return
+	}
+	t.transactions = append(t.transactions, op+": "+fmt.Sprintf("% x", data))
+}
+
+// recordRead adds a read transaction with the data returned by the read
+func (t *i2cTestAdaptor) recordRead(op string, data []byte, count int, err error) {
+	if !t.recording {
+		return
+	}
+	if err != nil {
+		t.transactions = append(t.transactions, op+": error "+err.Error())
+		return
+	}
+	if count < 0 || count > len(data) {
+		count = len(data)
+	}
+	t.record(op, data[:count])
+}
+
+func goldenFile(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+func readGolden(tb testing.TB, name string) []string {
+	tb.Helper()
+	f, err := os.Open(goldenFile(name))
+	if err != nil {
+		tb.Fatalf("golden file of %s not readable, run \"go test -update\" to create it: %v", name, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// replayGolden starts the recording of the transactions and serves the
+// reads of the driver with the data of the golden file, so no read
+// implementation is needed. When updating the golden files, the read
+// implementation of the test is used.
+func (t *i2cTestAdaptor) replayGolden(tb testing.TB, name string) {
+	tb.Helper()
+	t.mtx.Lock()
+	t.recording = true
+	t.transactions = nil
+	t.mtx.Unlock()
+
+	if *updateGolden {
+		return
+	}
+	var reads []string
+	for _, line := range readGolden(tb, name) {
+		if strings.HasPrefix(line, "read") {
+			reads = append(reads, line[strings.Index(line, ": ")+2:])
+		}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.i2cReadImpl = func(b []byte) (int, error) {
+		if len(reads) == 0 {
+			return 0, errors.New("No more reads in golden file")
+		}
+		read := reads[0]
+		reads = reads[1:]
+		if strings.HasPrefix(read, "error ") {
+			return 0, errors.New(strings.TrimPrefix(read, "error "))
+		}
+		data, err := hex.DecodeString(strings.Replace(read, " ", "", -1))
+		if err != nil {
+			return 0, err
+		}
+		return copy(b, data), nil
+	}
+}
+
+// verifyGolden compares the recorded transactions with the golden file, or
+// writes them to the golden file when updating
+func (t *i2cTestAdaptor) verifyGolden(tb testing.TB, name string) {
+	tb.Helper()
+	t.mtx.Lock()
+	recorded := append([]string{}, t.transactions...)
+	t.mtx.Unlock()
+
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			tb.Fatal(err)
+		}
+		content := strings.Join(recorded, "\n") + "\n"
+		if err := ioutil.WriteFile(goldenFile(name), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+		return
+	}
+
+	golden := readGolden(tb, name)
+	for i := 0; i < len(recorded) || i < len(golden); i++ {
+		var got, want string
+		if i < len(recorded) {
+			got = recorded[i]
+		}
+		if i < len(golden) {
+			want = golden[i]
+		}
+		if got != want {
+			tb.Errorf("transaction %d of %s is \"%s\", should be \"%s\" (%d recorded, %d in golden file)",
+				i, name, got, want, len(recorded), len(golden))
+			return
+		}
+	}
+}
+
 func newI2cTestAdaptor() *i2cTestAdaptor {
 	return &i2cTestAdaptor{
 		i2cConnectErr: false,
@@ -1,6 +1,9 @@
 package i2c
 
-import "gobot.io/x/gobot"
+import (
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
+)
 
 const hmc6352Address = 0x21
 
@@ -60,10 +63,27 @@ func (h *HMC6352Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *HMC6352Driver) Halt() (err error) { return }
+func (h *HMC6352Driver) Halt() (err error) {
+	return closeConnection(&h.connection)
+}
 
 // Heading returns the current heading
 func (h *HMC6352Driver) Heading() (heading uint16, err error) {
+	raw, err := h.rawHeading()
+	return raw / 10, err
+}
+
+// Magnetometer returns the driver as sensor.Magnetometer, the heading has a
+// resolution of 0.1 degrees
+func (h *HMC6352Driver) Magnetometer() sensor.Magnetometer {
+	return sensor.MagnetometerFunc(func() (float64, error) {
+		raw, err := h.rawHeading()
+		return float64(raw) / 10, err
+	})
+}
+
+// rawHeading returns the current heading in tenths of degrees
+func (h *HMC6352Driver) rawHeading() (heading uint16, err error) {
 	if _, err = h.connection.Write([]byte("A")); err != nil {
 		return
 	}
@@ -73,7 +93,7 @@ func (h *HMC6352Driver) Heading() (heading uint16, err error) {
 		return
 	}
 	if bytesRead == 2 {
-		heading = (uint16(buf[1]) + uint16(buf[0])*256) / 10
+		heading = uint16(buf[1]) + uint16(buf[0])*256
 		return
 	}
 
@@ -74,6 +74,9 @@ func TestHMC6352DriverHeading(t *testing.T) {
 	heading, _ := hmc.Heading()
 	gobottest.Assert(t, heading, uint16(2534))
 
+	degrees, _ := hmc.Magnetometer().Heading()
+	gobottest.Assert(t, degrees, 2534.5)
+
 	// when len(data) is not 2
 	hmc, adaptor = initTestHMC6352DriverWithStubbedAdaptor()
 
@@ -24,6 +24,7 @@ var (
 	ErrNotEnoughBytes  = errors.New("Not enough bytes read")
 	ErrNotReady        = errors.New("Device is not ready")
 	ErrInvalidPosition = errors.New("Invalid position value")
+	ErrNotStarted      = errors.New("Driver is not started")
 )
 
 type I2cOperations interface {
@@ -55,6 +56,26 @@ type Connector interface {
 	GetDefaultBus() int
 }
 
+// OptionApplier is the interface of the drivers, which apply options while
+// they are running, instead of being recreated and restarted. The hardware
+// registers of a started driver are re-initialized where needed.
+type OptionApplier interface {
+	ApplyOptions(options ...func(Config)) error
+}
+
+// DigitalReader is the interface of the adaptor an output pin of a device,
+// e.g. an interrupt pin, is connected to. It equals gpio.DigitalReader,
+// which can not be imported here.
+type DigitalReader interface {
+	DigitalRead(string) (val int, err error)
+}
+
+// DigitalWriter is the interface of the adaptor an input pin of a device,
+// e.g. an output enable pin, is connected to. It equals gpio.DigitalWriter.
+type DigitalWriter interface {
+	DigitalWrite(string, byte) (err error)
+}
+
 // Connection is a connection to an I2C device with a specified address
 // on a specific bus. Used as an alternative to the I2c interface.
 // Implements I2cOperations to talk to the device, wrapping the
@@ -66,6 +87,9 @@ type i2cConnection struct {
 	bus     I2cDevice
 	address int
 	mutex   *sync.Mutex
+	// set for connections shared by Connections
+	owner *Connections
+	refs  int
 }
 
 // NewConnection creates and returns a new connection to a specific
@@ -74,6 +98,117 @@ func NewConnection(bus I2cDevice, address int) (connection *i2cConnection) {
 	return &i2cConnection{bus: bus, address: address, mutex: &sync.Mutex{}}
 }
 
+type connectionKey struct {
+	bus     I2cDevice
+	address int
+}
+
+// Connections caches the connections of an Adaptor per bus and address.
+// Repeated calls of GetConnection for a device, e.g. by a driver which is
+// started again after Halt, return the same connection. All connections to
+// a bus share one lock, so the transfers to different addresses can't
+// interfere. The zero value is ready to use.
+type Connections struct {
+	mutex sync.Mutex
+	conns map[connectionKey]*i2cConnection
+	locks map[I2cDevice]*sync.Mutex
+}
+
+// Get returns the shared connection to the device at the address on the
+// bus. The connection is released by Close, the bus itself is kept open,
+// because it is owned by the Adaptor.
+func (c *Connections) Get(bus I2cDevice, address int) Connection {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.conns == nil {
+		c.conns = make(map[connectionKey]*i2cConnection)
+		c.locks = make(map[I2cDevice]*sync.Mutex)
+	}
+	key := connectionKey{bus: bus, address: address}
+	conn, ok := c.conns[key]
+	if !ok {
+		lock, ok := c.locks[bus]
+		if !ok {
+			lock = &sync.Mutex{}
+			c.locks[bus] = lock
+		}
+		conn = &i2cConnection{bus: bus, address: address, mutex: lock, owner: c}
+		c.conns[key] = conn
+	}
+	conn.refs++
+	return conn
+}
+
+// Close drops all connections, it is called by Finalize of the Adaptor
+// before the buses are closed.
+func (c *Connections) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, conn := range c.conns {
+		conn.refs = 0
+	}
+	c.conns = nil
+	c.locks = nil
+}
+
+// release drops a reference of the connection and removes the connection
+// with the last one
+func (c *Connections) release(conn *i2cConnection) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if conn.refs == 0 {
+		return
+	}
+	conn.refs--
+	if conn.refs == 0 {
+		delete(c.conns, connectionKey{bus: conn.bus, address: conn.address})
+	}
+}
+
+// closeConnection releases the connection of a driver on Halt, which frees
+// the address for re-probing. The connection is replaced by a halted one
+// after it was closed, so halting twice does not release it twice and the
+// methods of the halted driver fail with ErrNotStarted. Start acquires a new
+// connection, so a halted driver can be resumed. A driver which was never
+// started has no connection.
+func closeConnection(connections ...*Connection) (err error) {
+	for _, connection := range connections {
+		if !connected(*connection) {
+			continue
+		}
+		if e := (*connection).Close(); e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+		*connection = haltedConnection{}
+	}
+	return
+}
+
+// connected returns whether the driver of the connection is started
+func connected(connection Connection) bool {
+	return connection != nil && connection != Connection(haltedConnection{})
+}
+
+// haltedConnection is the connection of a halted driver
+type haltedConnection struct{}
+
+func (haltedConnection) Read(data []byte) (int, error)             { return 0, ErrNotStarted }
+func (haltedConnection) Write(data []byte) (int, error)            { return 0, ErrNotStarted }
+func (haltedConnection) Close() error                              { return nil }
+func (haltedConnection) ReadByte() (byte, error)                   { return 0, ErrNotStarted }
+func (haltedConnection) ReadByteData(reg uint8) (uint8, error)     { return 0, ErrNotStarted }
+func (haltedConnection) ReadWordData(reg uint8) (uint16, error)    { return 0, ErrNotStarted }
+func (haltedConnection) WriteByte(val byte) error                  { return ErrNotStarted }
+func (haltedConnection) WriteByteData(reg uint8, val uint8) error  { return ErrNotStarted }
+func (haltedConnection) WriteWordData(reg uint8, val uint16) error { return ErrNotStarted }
+func (haltedConnection) WriteBlockData(reg uint8, b []byte) error  { return ErrNotStarted }
+
 // Read data from an i2c device.
 func (c *i2cConnection) Read(data []byte) (read int, err error) {
 	c.mutex.Lock()
@@ -98,8 +233,14 @@ func (c *i2cConnection) Write(data []byte) (written int, err error) {
 	return
 }
 
-// Close connection to i2c device.
+// Close connection to i2c device. A shared connection just releases its
+// reference, the bus is closed by the Adaptor.
 func (c *i2cConnection) Close() error {
+	if c.owner != nil {
+		c.owner.release(c)
+		return nil
+	}
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -0,0 +1,39 @@
+// +build !windows
+
+package i2c
+
+import "testing"
+
+func BenchmarkConnectionReadByteData(b *testing.B) {
+	c := NewConnection(initI2CDevice(), 0x06)
+
+	for i := 0; i < b.N; i++ {
+		c.ReadByteData(0x01)
+	}
+}
+
+func BenchmarkConnectionWriteByteData(b *testing.B) {
+	c := NewConnection(initI2CDevice(), 0x06)
+
+	for i := 0; i < b.N; i++ {
+		c.WriteByteData(0x01, byte(i))
+	}
+}
+
+func BenchmarkConnectionRead(b *testing.B) {
+	c := NewConnection(initI2CDevice(), 0x06)
+	buf := make([]byte, 6)
+
+	for i := 0; i < b.N; i++ {
+		c.Read(buf)
+	}
+}
+
+func BenchmarkConnectionWrite(b *testing.B) {
+	c := NewConnection(initI2CDevice(), 0x06)
+	buf := []byte{0x01, 0x02, 0x03}
+
+	for i := 0; i < b.N; i++ {
+		c.Write(buf)
+	}
+}
@@ -173,3 +173,57 @@ func TestI2CWriteBlockDataAddressError(t *testing.T) {
 	err := c.WriteBlockData(0x01, []byte{0x01, 0x02})
 	gobottest.Assert(t, err, errors.New("Setting address failed with syscall.Errno operation not permitted"))
 }
+
+func TestI2CConnections(t *testing.T) {
+	var conns Connections
+	bus := initI2CDevice()
+	c1 := conns.Get(bus, 0x66)
+	c2 := conns.Get(bus, 0x66)
+	c3 := conns.Get(bus, 0x67)
+	gobottest.Assert(t, c1 == c2, true)
+	gobottest.Assert(t, c1 == c3, false)
+	// all connections of a bus share the lock
+	gobottest.Assert(t, c1.(*i2cConnection).mutex == c3.(*i2cConnection).mutex, true)
+	gobottest.Assert(t, conns.Get(initI2CDevice(), 0x66) == c1, false)
+
+	// the connection is released with the last reference
+	gobottest.Assert(t, c1.Close(), nil)
+	gobottest.Assert(t, conns.Get(bus, 0x66) == c1, true)
+	gobottest.Assert(t, c1.Close(), nil)
+	gobottest.Assert(t, c2.Close(), nil)
+	gobottest.Assert(t, conns.Get(bus, 0x66) == c1, false)
+
+	// the bus is still open
+	_, err := c3.Write([]byte{0x01})
+	gobottest.Assert(t, err, nil)
+
+	conns.Close()
+	gobottest.Assert(t, c3.Close(), nil)
+	gobottest.Assert(t, conns.Get(bus, 0x67) == c3, false)
+}
+
+func TestI2CCloseConnection(t *testing.T) {
+	var conns Connections
+	bus := initI2CDevice()
+	c := conns.Get(bus, 0x66)
+
+	other := conns.Get(bus, 0x66)
+
+	var none Connection
+	gobottest.Assert(t, closeConnection(&none, &c), nil)
+	gobottest.Assert(t, connected(c), false)
+	gobottest.Assert(t, none == nil, true)
+
+	// the halted connection fails instead of being nil
+	_, err := c.Write([]byte{0x01})
+	gobottest.Assert(t, err, ErrNotStarted)
+	_, err = c.ReadByteData(0x01)
+	gobottest.Assert(t, err, ErrNotStarted)
+
+	// closing again does not release the reference of the other driver
+	gobottest.Assert(t, closeConnection(&c), nil)
+	gobottest.Assert(t, conns.Get(bus, 0x66) == other, true)
+	gobottest.Assert(t, other.Close(), nil)
+	gobottest.Assert(t, other.Close(), nil)
+	gobottest.Assert(t, conns.Get(bus, 0x66) == other, false)
+}
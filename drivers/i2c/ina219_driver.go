@@ -0,0 +1,446 @@
+package i2c
+
+// INA219Driver is a driver for the Texas Instruments INA219 device. The INA219 is a current and
+// power monitor with an I2C and SMBUS compatible interface.
+//
+// INA219 data sheet and specifications can be found at http://www.ti.com/product/INA219
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	ina219Address           uint8  = 0x40   // 1000000 (A0+A1=GND)
+	ina219RegConfig         uint8  = 0x00   // CONFIG REGISTER (R/W)
+	ina219RegShuntVoltage   uint8  = 0x01   // SHUNT VOLTAGE REGISTER (R)
+	ina219RegBusVoltage     uint8  = 0x02   // BUS VOLTAGE REGISTER (R)
+	ina219RegPower          uint8  = 0x03   // POWER REGISTER (R)
+	ina219RegCurrent        uint8  = 0x04   // CURRENT REGISTER (R)
+	ina219RegCalibration    uint8  = 0x05   // CALIBRATION REGISTER (R/W)
+	ina219ConfigReset       uint16 = 0x8000 // Reset Bit
+	ina219ConfigBVoltRange  uint16 = 0x2000 // Bus Voltage Range 32V, 16V if not set
+	ina219ConfigGain8       uint16 = 0x1800 // PGA gain /8, shunt voltage range +-320mV
+	ina219ConfigBADC12Bit   uint16 = 0x0180 // Bus ADC 12 bit, 532us
+	ina219ConfigSADC12Bit   uint16 = 0x0018 // Shunt ADC 12 bit, 532us
+	ina219ConfigModeShBusCo uint16 = 0x0007 // Shunt and bus voltage, continuous
+	ina219BusVoltageOvf     uint16 = 0x0001 // Math Overflow Flag of the bus voltage register
+
+	// the calibration value is 0.04096 / (current LSB * shunt resistance), see spec 8.5.1
+	ina219CalibrationScale = 0.04096
+
+	ina219DefaultShuntResistance = 0.1 // 0.1 Ohm
+	ina219DefaultMaxCurrent      = 3.2 // 3.2 A, the full range of +-320mV at 0.1 Ohm
+)
+
+const (
+	// INA219Overcurrent event, published with the current in mA when it exceeds the alert limit
+	INA219Overcurrent = "overcurrent"
+	// INA219Overpower event, published with the power in mW when it exceeds the alert limit
+	INA219Overpower = "overpower"
+)
+
+// INA219Driver is a driver for the INA219 current and power monitoring device.
+type INA219Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	gobot.Eventer
+	shuntResistance float64
+	maxCurrent      float64
+	// current LSB in A, the power LSB is 20 times the current LSB
+	currentLSB   float64
+	currentAlert float64
+	powerAlert   float64
+	interval     time.Duration
+	// mutex protects the options, the calibration and the running state
+	mutex   gobot.Mutex
+	running bool
+	halt    chan bool
+	// done waits for the alert checks, which use the connection
+	done sync.WaitGroup
+}
+
+// NewINA219Driver creates a new driver with the specified i2c interface.
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):		bus to use with this driver
+//		i2c.WithAddress(int):		address to use with this driver
+//		i2c.WithINA219ShuntResistance(float64):	shunt resistance in Ohm, defaults to 0.1
+//		i2c.WithINA219MaxCurrent(float64):	maximum expected current in A, defaults to 3.2
+//		i2c.WithINA219CurrentAlert(float64):	current in mA for the INA219Overcurrent event
+//		i2c.WithINA219PowerAlert(float64):	power in mW for the INA219Overpower event
+//		i2c.WithINA219AlertInterval(time.Duration):	interval of the alert checks, defaults to 100ms
+func NewINA219Driver(c Connector, options ...func(Config)) *INA219Driver {
+	i := &INA219Driver{
+		name:            gobot.DefaultName("INA219"),
+		connector:       c,
+		Config:          NewConfig(),
+		Eventer:         gobot.NewEventer(),
+		shuntResistance: ina219DefaultShuntResistance,
+		maxCurrent:      ina219DefaultMaxCurrent,
+		interval:        100 * time.Millisecond,
+	}
+
+	for _, option := range options {
+		option(i)
+	}
+
+	i.AddEvent(INA219Overcurrent)
+	i.AddEvent(INA219Overpower)
+	i.AddEvent(Error)
+
+	return i
+}
+
+// WithINA219ShuntResistance option sets the resistance of the shunt in Ohm.
+func WithINA219ShuntResistance(ohm float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA219Driver)
+		if ok {
+			d.shuntResistance = ohm
+		} else {
+			panic("trying to set shunt resistance for non-INA219Driver")
+		}
+	}
+}
+
+// WithINA219MaxCurrent option sets the maximum expected current in A, which
+// defines the resolution of the current and power registers.
+func WithINA219MaxCurrent(ampere float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA219Driver)
+		if ok {
+			d.maxCurrent = ampere
+		} else {
+			panic("trying to set max current for non-INA219Driver")
+		}
+	}
+}
+
+// WithINA219CurrentAlert option sets the current in mA, above which the
+// INA219Overcurrent event is published. The INA219 has no alert pin, so the
+// current is checked with the alert interval.
+func WithINA219CurrentAlert(milliAmpere float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA219Driver)
+		if ok {
+			d.currentAlert = milliAmpere
+		} else {
+			panic("trying to set current alert for non-INA219Driver")
+		}
+	}
+}
+
+// WithINA219PowerAlert option sets the power in mW, above which the
+// INA219Overpower event is published.
+func WithINA219PowerAlert(milliWatt float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA219Driver)
+		if ok {
+			d.powerAlert = milliWatt
+		} else {
+			panic("trying to set power alert for non-INA219Driver")
+		}
+	}
+}
+
+// WithINA219AlertInterval option sets the interval of the alert checks.
+func WithINA219AlertInterval(interval time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*INA219Driver)
+		if ok {
+			d.interval = interval
+		} else {
+			panic("trying to set alert interval for non-INA219Driver")
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (i *INA219Driver) Name() string {
+	return i.name
+}
+
+// SetName sets the name of the device.
+func (i *INA219Driver) SetName(name string) {
+	i.name = name
+}
+
+// Connection returns the connection of the device.
+func (i *INA219Driver) Connection() gobot.Connection {
+	return i.connector.(gobot.Connection)
+}
+
+// Contentions returns how often a caller had to wait for the driver
+func (i *INA219Driver) Contentions() uint64 { return i.mutex.Contentions() }
+
+// Start initializes and calibrates the INA219 and starts the alert checks, if
+// an alert is configured.
+func (i *INA219Driver) Start() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	var err error
+	bus := i.GetBusOrDefault(i.connector.GetDefaultBus())
+	address := i.GetAddressOrDefault(int(ina219Address))
+
+	if i.connection, err = i.connector.GetConnection(address, bus); err != nil {
+		return err
+	}
+
+	if err := i.initialize(); err != nil {
+		return err
+	}
+
+	if err := i.calibrate(i.shuntResistance, i.maxCurrent); err != nil {
+		return err
+	}
+
+	i.startAlerts()
+	i.running = true
+	return nil
+}
+
+// Halt stops the alert checks.
+func (i *INA219Driver) Halt() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.running = false
+	i.stopAlerts()
+	return closeConnection(&i.connection)
+}
+
+// ApplyOptions applies the options, e.g. WithINA219CurrentAlert, while the
+// driver is running. The device is calibrated again and the alert checks are
+// restarted with the new alerts and interval.
+func (i *INA219Driver) ApplyOptions(options ...func(Config)) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for _, option := range options {
+		option(i)
+	}
+	if !i.running {
+		return nil
+	}
+
+	i.stopAlerts()
+	if err := i.calibrate(i.shuntResistance, i.maxCurrent); err != nil {
+		return err
+	}
+	i.startAlerts()
+	return nil
+}
+
+// Calibrate sets the shunt resistance in Ohm and the maximum expected current
+// in A and writes the calibration register. The current and power registers
+// read 0 until the device is calibrated.
+func (i *INA219Driver) Calibrate(shuntResistance float64, maxCurrent float64) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if !i.running {
+		return i.calibrate(shuntResistance, maxCurrent)
+	}
+	// the alert checks use the current LSB of the calibration
+	i.stopAlerts()
+	if err := i.calibrate(shuntResistance, maxCurrent); err != nil {
+		return err
+	}
+	i.startAlerts()
+	return nil
+}
+
+// calibrate writes the calibration register, the mutex must be held
+func (i *INA219Driver) calibrate(shuntResistance float64, maxCurrent float64) error {
+	if shuntResistance <= 0 || maxCurrent <= 0 {
+		return errors.New("shunt resistance and max current must be positive")
+	}
+
+	// the current register is a 16-bit signed value
+	currentLSB := maxCurrent / 32768
+	calibration := ina219CalibrationScale / (currentLSB * shuntResistance)
+	if calibration > 0xFFFE {
+		return errors.New("calibration out of range, max current is too small")
+	}
+	// bit 0 of the calibration register is not used
+	cal := uint16(calibration) &^ 1
+
+	if err := i.writeWordToRegister(ina219RegCalibration, cal); err != nil {
+		return err
+	}
+	i.shuntResistance = shuntResistance
+	i.maxCurrent = maxCurrent
+	// the LSB of the truncated calibration value
+	i.currentLSB = ina219CalibrationScale / (float64(cal) * shuntResistance)
+
+	return nil
+}
+
+// GetBusVoltage gets the bus voltage in Volts
+func (i *INA219Driver) GetBusVoltage() (float64, error) {
+	val, err := i.readWordFromRegister(ina219RegBusVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	// the upper 13 bits with LSB 4mV
+	return float64(val>>3) * .004, nil
+}
+
+// GetShuntVoltage gets the shunt voltage in mV
+func (i *INA219Driver) GetShuntVoltage() (float64, error) {
+	val, err := i.readWordFromRegister(ina219RegShuntVoltage)
+	if err != nil {
+		return 0, err
+	}
+
+	// LSB 10uV
+	return float64(int16(val)) * .01, nil
+}
+
+// GetCurrent gets the current in mA from the current register
+func (i *INA219Driver) GetCurrent() (float64, error) {
+	i.mutex.Lock()
+	currentLSB := i.currentLSB
+	i.mutex.Unlock()
+
+	return i.current(currentLSB)
+}
+
+// GetPower gets the power in mW from the power register
+func (i *INA219Driver) GetPower() (float64, error) {
+	i.mutex.Lock()
+	currentLSB := i.currentLSB
+	i.mutex.Unlock()
+
+	return i.power(currentLSB)
+}
+
+// GetLoadVoltage gets the load voltage in Volts
+func (i *INA219Driver) GetLoadVoltage() (float64, error) {
+	bv, err := i.GetBusVoltage()
+	if err != nil {
+		return 0, err
+	}
+
+	sv, err := i.GetShuntVoltage()
+	if err != nil {
+		return 0, err
+	}
+
+	return bv + (sv / 1000.0), nil
+}
+
+// current reads the current in mA with the current LSB of the calibration
+func (i *INA219Driver) current(currentLSB float64) (float64, error) {
+	val, err := i.readCalibratedRegister(ina219RegCurrent)
+	if err != nil {
+		return 0, err
+	}
+	return float64(int16(val)) * currentLSB * 1000, nil
+}
+
+// power reads the power in mW with the current LSB of the calibration
+func (i *INA219Driver) power(currentLSB float64) (float64, error) {
+	val, err := i.readCalibratedRegister(ina219RegPower)
+	if err != nil {
+		return 0, err
+	}
+	return float64(val) * currentLSB * 20 * 1000, nil
+}
+
+// readCalibratedRegister reads the current or the power register, which
+// are invalid when the calculation has overflowed
+func (i *INA219Driver) readCalibratedRegister(reg uint8) (uint16, error) {
+	bus, err := i.readWordFromRegister(ina219RegBusVoltage)
+	if err != nil {
+		return 0, err
+	}
+	if bus&ina219BusVoltageOvf != 0 {
+		return 0, errors.New("math overflow, max current is too small")
+	}
+
+	return i.readWordFromRegister(reg)
+}
+
+// startAlerts starts the alert checks, if an alert is configured
+func (i *INA219Driver) startAlerts() {
+	if i.currentAlert > 0 || i.powerAlert > 0 {
+		i.halt = make(chan bool)
+		i.done.Add(1)
+		go i.checkAlerts(i.halt, i.interval, i.currentAlert, i.powerAlert, i.currentLSB)
+	}
+}
+
+// stopAlerts stops the alert checks and waits until they have returned
+func (i *INA219Driver) stopAlerts() {
+	if i.halt != nil {
+		close(i.halt)
+		i.halt = nil
+	}
+	i.done.Wait()
+}
+
+// checkAlerts publishes the alert events until halted. It does not lock the
+// mutex, which is held while the checks are stopped.
+func (i *INA219Driver) checkAlerts(halt chan bool, interval time.Duration,
+	currentAlert, powerAlert, currentLSB float64) {
+	defer i.done.Done()
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(interval):
+		}
+
+		if currentAlert > 0 {
+			if current, err := i.current(currentLSB); err != nil {
+				i.Publish(i.Event(Error), err)
+			} else if current > currentAlert {
+				i.Publish(i.Event(INA219Overcurrent), current)
+			}
+		}
+		if powerAlert > 0 {
+			if power, err := i.power(currentLSB); err != nil {
+				i.Publish(i.Event(Error), err)
+			} else if power > powerAlert {
+				i.Publish(i.Event(INA219Overpower), power)
+			}
+		}
+	}
+}
+
+// reads word from supplied register address
+func (i *INA219Driver) readWordFromRegister(reg uint8) (uint16, error) {
+	val, err := i.connection.ReadWordData(reg)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(((val & 0x00FF) << 8) | ((val & 0xFF00) >> 8)), nil
+}
+
+// writes word to supplied register address
+func (i *INA219Driver) writeWordToRegister(reg uint8, val uint16) error {
+	return i.connection.WriteBlockData(reg, []byte{byte(val >> 8), byte(val & 0x00FF)})
+}
+
+// initialize initializes the INA219 device for the 32V and 320mV ranges
+func (i *INA219Driver) initialize() error {
+	config := ina219ConfigBVoltRange |
+		ina219ConfigGain8 |
+		ina219ConfigBADC12Bit |
+		ina219ConfigSADC12Bit |
+		ina219ConfigModeShBusCo
+
+	return i.writeWordToRegister(ina219RegConfig, config)
+}
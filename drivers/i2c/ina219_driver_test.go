@@ -0,0 +1,260 @@
+package i2c
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*INA219Driver)(nil)
+var _ gobot.ContentionCounter = (*INA219Driver)(nil)
+
+func initTestINA219Driver() *INA219Driver {
+	d, _ := initTestINA219DriverWithStubbedAdaptor()
+	return d
+}
+
+func initTestINA219DriverWithStubbedAdaptor() (*INA219Driver, *i2cTestAdaptor) {
+	a := newI2cTestAdaptor()
+	// current LSB of 0.1mA
+	return NewINA219Driver(a, WithINA219MaxCurrent(3.2768)), a
+}
+
+// ina219Reads returns the words in the given order, in the byte order of the
+// SMBus
+func ina219Reads(words ...uint16) func([]byte) (int, error) {
+	return func(b []byte) (int, error) {
+		if len(words) == 0 {
+			return 0, errors.New("no more reads")
+		}
+		b[0], b[1] = byte(words[0]>>8), byte(words[0])
+		words = words[1:]
+		return 2, nil
+	}
+}
+
+func assertINA219Value(t *testing.T, v float64, expected float64) {
+	if math.Abs(v-expected) > 1e-9 {
+		t.Errorf("%v should equal %v", v, expected)
+	}
+}
+
+func TestNewINA219Driver(t *testing.T) {
+	var d interface{} = NewINA219Driver(newI2cTestAdaptor())
+	if _, ok := d.(*INA219Driver); !ok {
+		t.Error("NewINA219Driver() should return a *INA219Driver")
+	}
+}
+
+func TestINA219DriverOptions(t *testing.T) {
+	d := NewINA219Driver(newI2cTestAdaptor(), WithBus(2), WithINA219ShuntResistance(0.01),
+		WithINA219CurrentAlert(500), WithINA219PowerAlert(2000), WithINA219AlertInterval(time.Second))
+	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
+	gobottest.Assert(t, d.shuntResistance, 0.01)
+	gobottest.Assert(t, d.currentAlert, 500.0)
+	gobottest.Assert(t, d.powerAlert, 2000.0)
+	gobottest.Assert(t, d.interval, time.Second)
+}
+
+func TestINA219DriverName(t *testing.T) {
+	d := initTestINA219Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "INA219"), true)
+	d.SetName("foobot")
+	gobottest.Assert(t, d.Name(), "foobot")
+}
+
+func TestINA219DriverStart(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, a.written, []byte{
+		ina219RegConfig, 0x39, 0x9F,
+		ina219RegCalibration, 0x10, 0x00,
+	})
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestINA219DriverHalted(t *testing.T) {
+	d := initTestINA219Driver()
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	_, err := d.GetBusVoltage()
+	gobottest.Assert(t, err, ErrNotStarted)
+}
+
+func TestINA219DriverStartConnectError(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	a.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+}
+
+func TestINA219DriverStartWriteError(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	a.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, d.Start(), errors.New("write error"))
+}
+
+func TestINA219DriverCalibrate(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	// 0.04096 / (2A / 32768 * 0.01 Ohm) = 67108, too large
+	gobottest.Assert(t, d.Calibrate(0.01, 2),
+		errors.New("calibration out of range, max current is too small"))
+	gobottest.Assert(t, d.Calibrate(0, 2),
+		errors.New("shunt resistance and max current must be positive"))
+
+	// 0.04096 / (10A / 32768 * 0.01 Ohm) = 13421.77, the calibration is even
+	a.written = []byte{}
+	gobottest.Assert(t, d.Calibrate(0.01, 10), nil)
+	gobottest.Assert(t, a.written, []byte{ina219RegCalibration, 0x34, 0x6C})
+	assertINA219Value(t, d.currentLSB, 0.04096/(13420*0.01))
+}
+
+func TestINA219DriverGetBusVoltage(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	// 3000 * 4mV with the conversion ready bit
+	a.i2cReadImpl = ina219Reads(3000<<3 | 0x02)
+	v, err := d.GetBusVoltage()
+	gobottest.Assert(t, err, nil)
+	assertINA219Value(t, v, 12)
+
+	a.i2cReadImpl = func([]byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = d.GetBusVoltage()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
+func TestINA219DriverGetShuntVoltage(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	a.i2cReadImpl = ina219Reads(0xFC18) // -1000 * 10uV
+	v, err := d.GetShuntVoltage()
+	gobottest.Assert(t, err, nil)
+	assertINA219Value(t, v, -10)
+}
+
+func TestINA219DriverGetCurrent(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	a.i2cReadImpl = ina219Reads(3000<<3, 5000)
+	v, err := d.GetCurrent()
+	gobottest.Assert(t, err, nil)
+	assertINA219Value(t, v, 500)
+
+	// the calculation has overflowed
+	a.i2cReadImpl = ina219Reads(3000<<3 | 0x01)
+	_, err = d.GetCurrent()
+	gobottest.Assert(t, err, errors.New("math overflow, max current is too small"))
+}
+
+func TestINA219DriverGetPower(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	// LSB of 2mW
+	a.i2cReadImpl = ina219Reads(3000<<3, 3000)
+	v, err := d.GetPower()
+	gobottest.Assert(t, err, nil)
+	assertINA219Value(t, v, 6000)
+}
+
+func TestINA219DriverGetLoadVoltage(t *testing.T) {
+	d, a := initTestINA219DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	a.i2cReadImpl = ina219Reads(3000<<3, 1000)
+	v, err := d.GetLoadVoltage()
+	gobottest.Assert(t, err, nil)
+	assertINA219Value(t, v, 12.01)
+}
+
+func TestINA219DriverCurrentAlert(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a, WithINA219MaxCurrent(3.2768),
+		WithINA219CurrentAlert(400), WithINA219AlertInterval(time.Millisecond))
+	// the bus voltage register and the current register read 0x1000
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x10, 0x00})
+		return 2, nil
+	}
+
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	current := gobottest.WaitForEvent(t, d, INA219Overcurrent, time.Second)
+	assertINA219Value(t, current.(float64), 409.6)
+}
+
+func TestINA219DriverApplyOptions(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a, WithINA219MaxCurrent(3.2768))
+	a.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x10, 0x00})
+		return 2, nil
+	}
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+
+	// the calibration register is written again
+	a.written = []byte{}
+	gobottest.Assert(t, d.ApplyOptions(WithINA219CurrentAlert(400),
+		WithINA219AlertInterval(time.Millisecond)), nil)
+	gobottest.Assert(t, a.written[0], uint8(ina219RegCalibration))
+
+	current := gobottest.WaitForEvent(t, d, INA219Overcurrent, time.Second)
+	assertINA219Value(t, current.(float64), 409.6)
+}
+
+func TestINA219DriverApplyOptionsHalted(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	// the options are used by the next Start
+	a.written = []byte{}
+	gobottest.Assert(t, d.ApplyOptions(WithINA219CurrentAlert(400)), nil)
+	gobottest.Assert(t, len(a.written), 0)
+	gobottest.Assert(t, d.currentAlert, 400.0)
+}
+
+func TestINA219DriverPowerAlertError(t *testing.T) {
+	a := newI2cTestAdaptor()
+	d := NewINA219Driver(a, WithINA219PowerAlert(1000), WithINA219AlertInterval(time.Millisecond))
+	a.i2cReadImpl = func([]byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+
+	gobottest.Assert(t, d.Start(), nil)
+	defer d.Halt()
+	gobottest.Assert(t, gobottest.WaitForEvent(t, d, Error, time.Second), errors.New("read error"))
+}
+
+func TestINA219DriverContentions(t *testing.T) {
+	d := initTestINA219Driver()
+	gobottest.Assert(t, d.Contentions(), uint64(0))
+
+	d.mutex.Lock()
+	started := make(chan error)
+	go func() {
+		started <- d.Start()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	d.mutex.Unlock()
+	gobottest.Assert(t, <-started, nil)
+	gobottest.Assert(t, d.Contentions(), uint64(1))
+}
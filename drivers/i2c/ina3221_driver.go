@@ -107,7 +107,7 @@ func (i *INA3221Driver) Start() error {
 
 // Halt halts the device.
 func (i *INA3221Driver) Halt() error {
-	return nil
+	return closeConnection(&i.connection)
 }
 
 // GetBusVoltage gets the bus voltage in Volts
@@ -13,6 +13,13 @@ const (
 	REG_GREEN = 0x03
 	REG_BLUE  = 0x02
 
+	// registers of the PCA9633 backlight controller
+	REG_MODE1  = 0x00
+	REG_MODE2  = 0x01
+	REG_OUTPUT = 0x08
+	// all LEDs are controlled by their PWM registers
+	OUTPUT_PWM_ALL = 0xAA
+
 	LCD_CLEARDISPLAY        = 0x01
 	LCD_RETURNHOME          = 0x02
 	LCD_ENTRYMODESET        = 0x04
@@ -76,6 +83,7 @@ type JHD1313M1Driver struct {
 	connector Connector
 	Config
 	gobot.Commander
+	gobot.CommandSchemer
 	lcdAddress    int
 	lcdConnection Connection
 	rgbAddress    int
@@ -88,15 +96,18 @@ type JHD1313M1Driver struct {
 //
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithAddress(int):	address of the LCD controller, defaults to 0x3E
+//		i2c.WithJHD1313M1RGBAddress(int):	address of the backlight controller, defaults to 0x62
 //
 func NewJHD1313M1Driver(a Connector, options ...func(Config)) *JHD1313M1Driver {
 	j := &JHD1313M1Driver{
-		name:       gobot.DefaultName("JHD1313M1"),
-		connector:  a,
-		Config:     NewConfig(),
-		Commander:  gobot.NewCommander(),
-		lcdAddress: 0x3E,
-		rgbAddress: 0x62,
+		name:           gobot.DefaultName("JHD1313M1"),
+		connector:      a,
+		Config:         NewConfig(),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		lcdAddress:     0x3E,
+		rgbAddress:     0x62,
 	}
 
 	for _, option := range options {
@@ -109,6 +120,11 @@ func NewJHD1313M1Driver(a Connector, options ...func(Config)) *JHD1313M1Driver {
 		b, _ := strconv.Atoi(params["b"].(string))
 		return j.SetRGB(r, g, b)
 	})
+	j.AddCommandSchema("SetRGB", gobot.CommandSchema{
+		"r": gobot.CommandParamString,
+		"g": gobot.CommandParamString,
+		"b": gobot.CommandParamString,
+	})
 	j.AddCommand("Clear", func(params map[string]interface{}) interface{} {
 		return j.Clear()
 	})
@@ -119,18 +135,34 @@ func NewJHD1313M1Driver(a Connector, options ...func(Config)) *JHD1313M1Driver {
 		msg := params["msg"].(string)
 		return j.Write(msg)
 	})
+	j.AddCommandSchema("Write", gobot.CommandSchema{"msg": gobot.CommandParamString})
 	j.AddCommand("SetPosition", func(params map[string]interface{}) interface{} {
 		pos, _ := strconv.Atoi(params["pos"].(string))
 		return j.SetPosition(pos)
 	})
+	j.AddCommandSchema("SetPosition", gobot.CommandSchema{"pos": gobot.CommandParamString})
 	j.AddCommand("Scroll", func(params map[string]interface{}) interface{} {
 		lr, _ := strconv.ParseBool(params["lr"].(string))
 		return j.Scroll(lr)
 	})
+	j.AddCommandSchema("Scroll", gobot.CommandSchema{"lr": gobot.CommandParamString})
 
 	return j
 }
 
+// WithJHD1313M1RGBAddress option sets the address of the backlight
+// controller, e.g. 0x30 for later revisions of the Grove LCD RGB Backlight.
+func WithJHD1313M1RGBAddress(address int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*JHD1313M1Driver)
+		if ok {
+			d.rgbAddress = address
+		} else {
+			panic("trying to set rgb address for non-JHD1313M1Driver")
+		}
+	}
+}
+
 // Name returns the name the JHD1313M1 Driver was given when created.
 func (h *JHD1313M1Driver) Name() string { return h.name }
 
@@ -146,7 +178,7 @@ func (h *JHD1313M1Driver) Connection() gobot.Connection {
 func (h *JHD1313M1Driver) Start() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
 
-	if h.lcdConnection, err = h.connector.GetConnection(h.lcdAddress, bus); err != nil {
+	if h.lcdConnection, err = h.connector.GetConnection(h.GetAddressOrDefault(h.lcdAddress), bus); err != nil {
 		return err
 	}
 
@@ -193,13 +225,13 @@ func (h *JHD1313M1Driver) Start() (err error) {
 		return err
 	}
 
-	if err := h.setReg(0, 0); err != nil {
+	if err := h.setReg(REG_MODE1, 0); err != nil {
 		return err
 	}
-	if err := h.setReg(1, 0); err != nil {
+	if err := h.setReg(REG_MODE2, 0); err != nil {
 		return err
 	}
-	if err := h.setReg(0x08, 0xAA); err != nil {
+	if err := h.setReg(REG_OUTPUT, OUTPUT_PWM_ALL); err != nil {
 		return err
 	}
 
@@ -282,8 +314,10 @@ func (h *JHD1313M1Driver) Scroll(lr bool) error {
 	return err
 }
 
-// Halt is a noop function.
-func (h *JHD1313M1Driver) Halt() error { return nil }
+// Halt releases the connections of the display.
+func (h *JHD1313M1Driver) Halt() error {
+	return closeConnection(&h.lcdConnection, &h.rgbConnection)
+}
 
 // SetCustomChar sets one of the 8 CGRAM locations with a custom character.
 // The custom character can be used by writing a byte of value 0 to 7.
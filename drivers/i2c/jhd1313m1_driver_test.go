@@ -52,6 +52,29 @@ func TestJHD1313MDriverOptions(t *testing.T) {
 	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
 }
 
+// addressRecorder records the addresses of the connections
+type addressRecorder struct {
+	*i2cTestAdaptor
+	addresses []int
+}
+
+func (a *addressRecorder) GetConnection(address int, bus int) (Connection, error) {
+	a.addresses = append(a.addresses, address)
+	return a.i2cTestAdaptor.GetConnection(address, bus)
+}
+
+func TestJHD1313MDriverAddresses(t *testing.T) {
+	a := &addressRecorder{i2cTestAdaptor: newI2cTestAdaptor()}
+	d := NewJHD1313M1Driver(a)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, a.addresses, []int{0x3E, 0x62})
+
+	a = &addressRecorder{i2cTestAdaptor: newI2cTestAdaptor()}
+	d = NewJHD1313M1Driver(a, WithAddress(0x3F), WithJHD1313M1RGBAddress(0x30))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, a.addresses, []int{0x3F, 0x30})
+}
+
 func TestJHD1313MDriverStart(t *testing.T) {
 	d := initTestJHD1313M1Driver()
 	gobottest.Assert(t, d.Start(), nil)
@@ -1,7 +1,6 @@
 package i2c
 
 import (
-	"bytes"
 	"encoding/binary"
 
 	"gobot.io/x/gobot"
@@ -19,8 +18,34 @@ const l3gd20hEnableX = 0x01
 // Control Register 4
 const l3gd20hRegisterCtl4 = 0x23
 
+// Control Register 5
+const l3gd20hRegisterCtl5 = 0x24
+const l3gd20hFIFOEnable = 0x40
+
 const l3gd20hRegisterOutXLSB = 0x28 | 0x80 // set auto-increment bit.
 
+// FIFO control and source register
+const l3gd20hRegisterFIFOCtl = 0x2E
+const l3gd20hFIFOModeBypass = 0x00
+const l3gd20hFIFOModeStream = 0x40
+const l3gd20hRegisterFIFOSrc = 0x2F
+const l3gd20hFIFOOverrun = 0x40
+const l3gd20hFIFOStoredMask = 0x1F
+
+// l3gd20hFIFOSize is the number of samples the FIFO can hold
+const l3gd20hFIFOSize = 32
+
+// L3GD20HFIFOOverflow event, published when samples of the FIFO were
+// overwritten before they were read
+const L3GD20HFIFOOverflow = "fifo-overflow"
+
+// L3GD20HSample is one sample of the FIFO in degrees per second
+type L3GD20HSample struct {
+	X float32
+	Y float32
+	Z float32
+}
+
 const (
 	// L3GD20HScale250dps is the 250 degress-per-second scale.
 	L3GD20HScale250dps L3GD20HScale = 0x00
@@ -40,7 +65,13 @@ type L3GD20HDriver struct {
 	connector  Connector
 	connection Connection
 	Config
+	gobot.Eventer
 	scale L3GD20HScale
+	// buffers reused by each read, protected by the mutex
+	writeBuf [1]byte
+	readBuf  [6]byte
+	fifoBuf  [l3gd20hFIFOSize * 6]byte
+	mutex    gobot.Mutex
 }
 
 // NewL3GD20HDriver creates a new Gobot driver for the
@@ -58,6 +89,7 @@ func NewL3GD20HDriver(c Connector, options ...func(Config)) *L3GD20HDriver {
 		name:      gobot.DefaultName("L3GD20H"),
 		connector: c,
 		Config:    NewConfig(),
+		Eventer:   gobot.NewEventer(),
 		scale:     L3GD20HScale250dps,
 	}
 
@@ -65,6 +97,8 @@ func NewL3GD20HDriver(c Connector, options ...func(Config)) *L3GD20HDriver {
 		option(l)
 	}
 
+	l.AddEvent(L3GD20HFIFOOverflow)
+
 	// TODO: add commands to API
 	return l
 }
@@ -84,6 +118,9 @@ func (d *L3GD20HDriver) Connection() gobot.Connection {
 	return d.connector.(gobot.Connection)
 }
 
+// Contentions returns how often a caller had to wait for the driver
+func (d *L3GD20HDriver) Contentions() uint64 { return d.mutex.Contentions() }
+
 // Scale returns the scale sensitivity of the device.
 func (d *L3GD20HDriver) Scale() L3GD20HScale {
 	return d.scale
@@ -104,26 +141,26 @@ func (d *L3GD20HDriver) Start() (err error) {
 
 // Halt halts the device.
 func (d *L3GD20HDriver) Halt() (err error) {
-	return nil
+	return closeConnection(&d.connection)
 }
 
 // XYZ returns the current change in degrees per second, for the 3 axis.
 func (d *L3GD20HDriver) XYZ() (x float32, y float32, z float32, err error) {
-	if _, err = d.connection.Write([]byte{l3gd20hRegisterOutXLSB}); err != nil {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.writeBuf[0] = l3gd20hRegisterOutXLSB
+	if _, err = d.connection.Write(d.writeBuf[:]); err != nil {
 		return 0, 0, 0, err
 	}
-	measurements := make([]byte, 6)
+	measurements := d.readBuf[:]
 	if _, err = d.connection.Read(measurements); err != nil {
 		return 0, 0, 0, err
 	}
 
-	var rawX int16
-	var rawY int16
-	var rawZ int16
-	buf := bytes.NewBuffer(measurements)
-	binary.Read(buf, binary.LittleEndian, &rawX)
-	binary.Read(buf, binary.LittleEndian, &rawY)
-	binary.Read(buf, binary.LittleEndian, &rawZ)
+	rawX := int16(binary.LittleEndian.Uint16(measurements[0:]))
+	rawY := int16(binary.LittleEndian.Uint16(measurements[2:]))
+	rawZ := int16(binary.LittleEndian.Uint16(measurements[4:]))
 
 	// Sensitivity values from the mechanical characteristics in the datasheet.
 	sensitivity := d.getSensitivity()
@@ -131,6 +168,80 @@ func (d *L3GD20HDriver) XYZ() (x float32, y float32, z float32, err error) {
 	return float32(rawX) * sensitivity, float32(rawY) * sensitivity, float32(rawZ) * sensitivity, nil
 }
 
+// EnableFIFO enables the FIFO in stream mode, it collects up to 32 samples
+// until they are read by ReadFIFO.
+func (d *L3GD20HDriver) EnableFIFO() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, err = d.connection.Write([]byte{l3gd20hRegisterCtl5, l3gd20hFIFOEnable}); err != nil {
+		return
+	}
+	_, err = d.connection.Write([]byte{l3gd20hRegisterFIFOCtl, l3gd20hFIFOModeStream})
+	return
+}
+
+// DisableFIFO disables the FIFO, XYZ reads the current sample again.
+func (d *L3GD20HDriver) DisableFIFO() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, err = d.connection.Write([]byte{l3gd20hRegisterFIFOCtl, l3gd20hFIFOModeBypass}); err != nil {
+		return
+	}
+	_, err = d.connection.Write([]byte{l3gd20hRegisterCtl5, 0x00})
+	return
+}
+
+// ReadFIFO returns all samples collected by the FIFO, the oldest first. The
+// L3GD20HFIFOOverflow event is published, when samples were lost since the
+// last read.
+func (d *L3GD20HDriver) ReadFIFO() (samples []L3GD20HSample, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.writeBuf[0] = l3gd20hRegisterFIFOSrc
+	if _, err = d.connection.Write(d.writeBuf[:]); err != nil {
+		return nil, err
+	}
+	src := d.readBuf[:1]
+	if _, err = d.connection.Read(src); err != nil {
+		return nil, err
+	}
+
+	stored := int(src[0] & l3gd20hFIFOStoredMask)
+	if src[0]&l3gd20hFIFOOverrun != 0 {
+		d.Publish(d.Event(L3GD20HFIFOOverflow), nil)
+		stored = l3gd20hFIFOSize
+	}
+	if stored == 0 {
+		return nil, nil
+	}
+
+	// the address rolls back to OUT_X_L after OUT_Z_H, so all samples are
+	// read by one burst
+	d.writeBuf[0] = l3gd20hRegisterOutXLSB
+	if _, err = d.connection.Write(d.writeBuf[:]); err != nil {
+		return nil, err
+	}
+	measurements := d.fifoBuf[:stored*6]
+	if _, err = d.connection.Read(measurements); err != nil {
+		return nil, err
+	}
+
+	sensitivity := d.getSensitivity()
+	samples = make([]L3GD20HSample, stored)
+	for i := range samples {
+		sample := measurements[i*6:]
+		samples[i] = L3GD20HSample{
+			X: float32(int16(binary.LittleEndian.Uint16(sample[0:]))) * sensitivity,
+			Y: float32(int16(binary.LittleEndian.Uint16(sample[2:]))) * sensitivity,
+			Z: float32(int16(binary.LittleEndian.Uint16(sample[4:]))) * sensitivity,
+		}
+	}
+	return samples, nil
+}
+
 func (d *L3GD20HDriver) initialization() (err error) {
 	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
 	address := d.GetAddressOrDefault(l3gd20hAddress)
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -101,6 +102,11 @@ func TestL3GD20HDriverMeasurement(t *testing.T) {
 	gobottest.Assert(t, x, float32(rawX)*sensitivity)
 	gobottest.Assert(t, y, float32(rawY)*sensitivity)
 	gobottest.Assert(t, z, float32(rawZ)*sensitivity)
+
+	// the buffers are reused
+	adaptor.i2cReadImpl = func(b []byte) (int, error) { return len(b), nil }
+	allocs := testing.AllocsPerRun(100, func() { d.XYZ() })
+	gobottest.Assert(t, allocs, 0.0)
 }
 
 func TestL3GD20HDriverMeasurementError(t *testing.T) {
@@ -135,3 +141,76 @@ func TestL3GD20HDriverOptions(t *testing.T) {
 	d := NewL3GD20HDriver(newI2cTestAdaptor(), WithBus(2))
 	gobottest.Assert(t, d.GetBusOrDefault(1), 2)
 }
+
+func TestL3GD20HDriverFIFO(t *testing.T) {
+	d, adaptor := initTestL3GD20HDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, d.EnableFIFO(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x24, 0x40, 0x2E, 0x40})
+
+	// two samples are stored
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		switch adaptor.written[len(adaptor.written)-1] {
+		case l3gd20hRegisterFIFOSrc:
+			b[0] = 0x02
+		case l3gd20hRegisterOutXLSB:
+			copy(b, []byte{0x64, 0x00, 0x9C, 0xFF, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC8, 0x00})
+		}
+		return len(b), nil
+	}
+	overflow := gobottest.ListenForEvent(d, L3GD20HFIFOOverflow)
+	samples, err := d.ReadFIFO()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, samples, []L3GD20HSample{
+		{X: 100 * 0.00875, Y: -100 * 0.00875, Z: 0},
+		{X: 0, Y: 0, Z: 200 * 0.00875},
+	})
+	gobottest.Assert(t, adaptor.written, []byte{l3gd20hRegisterFIFOSrc, l3gd20hRegisterOutXLSB})
+	overflow.AssertNone(t, 10*time.Millisecond)
+
+	// the FIFO is empty
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x20
+		return 1, nil
+	}
+	samples, err = d.ReadFIFO()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(samples), 0)
+	gobottest.Assert(t, adaptor.written, []byte{l3gd20hRegisterFIFOSrc})
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, d.DisableFIFO(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x2E, 0x00, 0x24, 0x00})
+}
+
+func TestL3GD20HDriverFIFOOverflow(t *testing.T) {
+	d, adaptor := initTestL3GD20HDriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	// the overrun flag and 31 stored samples, all 32 samples are read
+	read := 0
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		if adaptor.written[len(adaptor.written)-1] == l3gd20hRegisterFIFOSrc {
+			b[0] = 0x40 | 0x1F
+		} else {
+			read = len(b)
+		}
+		return len(b), nil
+	}
+	overflow := gobottest.ListenForEvent(d, L3GD20HFIFOOverflow)
+	samples, err := d.ReadFIFO()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(samples), 32)
+	gobottest.Assert(t, read, 32*6)
+	overflow.Wait(t, time.Second)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, err = d.ReadFIFO()
+	gobottest.Assert(t, err, errors.New("read error"))
+}
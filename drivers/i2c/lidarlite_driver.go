@@ -1,19 +1,62 @@
 package i2c
 
 import (
+	"errors"
+	"sync"
+
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
 
 	"time"
 )
 
 const lidarliteAddress = 0x62
 
+const (
+	lidarliteRegAcqCommand = 0x00
+	lidarliteRegStatus     = 0x01
+	// the maximum acquisition count, SIG_COUNT_VAL of v3 and ACQUISITION_COUNT of v4
+	lidarliteRegSigCountVal      = 0x02
+	lidarliteV4RegAcquisitionCnt = 0x05
+	// the distance, high byte first on v3, low byte first on v4
+	lidarliteRegFullDelayHigh   = 0x0F
+	lidarliteRegFullDelayLow    = 0x10
+	lidarliteV4RegFullDelayLow  = 0x10
+	lidarliteStatusBusy         = 0x01
+	lidarliteCmdMeasureWithBias = 0x04
+	// the v4 has no receiver bias correction, 0x04 is its only measurement command
+	lidarliteV4CmdMeasure  = 0x04
+	lidarliteV4BusyTimeout = 100 * time.Millisecond
+)
+
+// LIDARLiteDistance event, published with the distance in cm by the
+// continuous measurement
+const LIDARLiteDistance = "distance"
+
 // LIDARLiteDriver is the Gobot driver for the LIDARLite I2C LIDAR device.
 type LIDARLiteDriver struct {
 	name       string
 	connector  Connector
 	connection Connection
 	Config
+	gobot.Eventer
+	v4    bool
+	mutex gobot.Mutex
+	halt  chan bool
+	// done waits for the continuous measurement, which uses the connection
+	done sync.WaitGroup
+}
+
+// WithLIDARLiteV4 option selects the register map of the LIDAR-Lite v4 LED.
+func WithLIDARLiteV4() func(Config) {
+	return func(c Config) {
+		d, ok := c.(*LIDARLiteDriver)
+		if ok {
+			d.v4 = true
+		} else {
+			panic("trying to set v4 for non-LIDARLiteDriver")
+		}
+	}
 }
 
 // NewLIDARLiteDriver creates a new driver for the LIDARLite I2C LIDAR device.
@@ -24,18 +67,23 @@ type LIDARLiteDriver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithLIDARLiteV4():	use the register map of the LIDAR-Lite v4 LED
 //
 func NewLIDARLiteDriver(a Connector, options ...func(Config)) *LIDARLiteDriver {
 	l := &LIDARLiteDriver{
 		name:      gobot.DefaultName("LIDARLite"),
 		connector: a,
 		Config:    NewConfig(),
+		Eventer:   gobot.NewEventer(),
 	}
 
 	for _, option := range options {
 		option(l)
 	}
 
+	l.AddEvent(LIDARLiteDistance)
+	l.AddEvent(Error)
+
 	// TODO: add commands to API
 	return l
 }
@@ -49,6 +97,9 @@ func (h *LIDARLiteDriver) SetName(n string) { h.name = n }
 // Connection returns the connection for the Driver
 func (h *LIDARLiteDriver) Connection() gobot.Connection { return h.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (h *LIDARLiteDriver) Contentions() uint64 { return h.mutex.Contentions() }
+
 // Start initialized the LIDAR
 func (h *LIDARLiteDriver) Start() (err error) {
 	bus := h.GetBusOrDefault(h.connector.GetDefaultBus())
@@ -61,17 +112,82 @@ func (h *LIDARLiteDriver) Start() (err error) {
 	return
 }
 
-// Halt returns true if devices is halted successfully
-func (h *LIDARLiteDriver) Halt() (err error) { return }
+// Halt stops the continuous measurement
+func (h *LIDARLiteDriver) Halt() (err error) {
+	h.StopContinuous()
+	return closeConnection(&h.connection)
+}
+
+// SetMaxAcquisitionCount sets the maximum number of acquisitions of a
+// measurement, fewer acquisitions are faster and less sensitive
+func (h *LIDARLiteDriver) SetMaxAcquisitionCount(count uint8) (err error) {
+	reg := byte(lidarliteRegSigCountVal)
+	if h.v4 {
+		reg = lidarliteV4RegAcquisitionCnt
+	}
+	_, err = h.connection.Write([]byte{reg, count})
+	return
+}
+
+// StartContinuous starts measuring the distance with the interval, each
+// distance is published by the LIDARLiteDistance event
+func (h *LIDARLiteDriver) StartContinuous(interval time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.halt != nil {
+		close(h.halt)
+	}
+	h.halt = make(chan bool)
+
+	h.done.Add(1)
+	go func(halt chan bool) {
+		defer h.done.Done()
+		for {
+			select {
+			case <-halt:
+				return
+			case <-time.After(interval):
+			}
+
+			distance, err := h.Distance()
+			select {
+			case <-halt:
+				// stopped while measuring
+				return
+			default:
+			}
+			if err != nil {
+				h.Publish(h.Event(Error), err)
+				continue
+			}
+			h.Publish(h.Event(LIDARLiteDistance), distance)
+		}
+	}(h.halt)
+}
+
+// StopContinuous stops the continuous measurement
+func (h *LIDARLiteDriver) StopContinuous() {
+	h.mutex.Lock()
+	if h.halt != nil {
+		close(h.halt)
+		h.halt = nil
+	}
+	h.mutex.Unlock()
+	h.done.Wait()
+}
 
 // Distance returns the current distance in cm
 func (h *LIDARLiteDriver) Distance() (distance int, err error) {
-	if _, err = h.connection.Write([]byte{0x00, 0x04}); err != nil {
+	if h.v4 {
+		return h.distanceV4()
+	}
+
+	if _, err = h.connection.Write([]byte{lidarliteRegAcqCommand, lidarliteCmdMeasureWithBias}); err != nil {
 		return
 	}
 	time.Sleep(20 * time.Millisecond)
 
-	if _, err = h.connection.Write([]byte{0x0F}); err != nil {
+	if _, err = h.connection.Write([]byte{lidarliteRegFullDelayHigh}); err != nil {
 		return
 	}
 
@@ -86,7 +202,7 @@ func (h *LIDARLiteDriver) Distance() (distance int, err error) {
 		return
 	}
 
-	if _, err = h.connection.Write([]byte{0x10}); err != nil {
+	if _, err = h.connection.Write([]byte{lidarliteRegFullDelayLow}); err != nil {
 		return
 	}
 
@@ -105,3 +221,59 @@ func (h *LIDARLiteDriver) Distance() (distance int, err error) {
 
 	return
 }
+
+// DistanceSensor returns the driver as sensor.DistanceSensor
+func (h *LIDARLiteDriver) DistanceSensor() sensor.DistanceSensor {
+	return sensor.DistanceSensorFunc(func() (float64, error) {
+		distance, err := h.Distance()
+		return float64(distance), err
+	})
+}
+
+// distanceV4 measures the distance with the v4 register map, the end of the
+// measurement is signaled by the busy flag of the status register
+func (h *LIDARLiteDriver) distanceV4() (distance int, err error) {
+	if _, err = h.connection.Write([]byte{lidarliteRegAcqCommand, lidarliteV4CmdMeasure}); err != nil {
+		return
+	}
+
+	timeout := time.Now().Add(lidarliteV4BusyTimeout)
+	for {
+		status, err := h.readV4(lidarliteRegStatus, 1)
+		if err != nil {
+			return 0, err
+		}
+		if status[0]&lidarliteStatusBusy == 0 {
+			break
+		}
+		if time.Now().After(timeout) {
+			return 0, errors.New("LIDAR-Lite measurement timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	data, err := h.readV4(lidarliteV4RegFullDelayLow, 2)
+	if err != nil {
+		return
+	}
+
+	distance = int(data[1])<<8 | int(data[0])
+	return
+}
+
+// readV4 reads n bytes from the register, the v4 increments the register
+// address automatically
+func (h *LIDARLiteDriver) readV4(reg byte, n int) ([]byte, error) {
+	if _, err := h.connection.Write([]byte{reg}); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n)
+	bytesRead, err := h.connection.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	if bytesRead != n {
+		return nil, ErrNotEnoughBytes
+	}
+	return buf, nil
+}
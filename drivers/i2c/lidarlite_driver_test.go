@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -77,6 +78,11 @@ func TestLIDARLiteDriverDistance(t *testing.T) {
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, distance, int(25345))
 
+	first = true
+	cm, err := hmc.DistanceSensor().Distance()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, cm, float64(25345))
+
 	// when insufficient bytes have been read
 	hmc, adaptor = initTestLIDARLiteDriverWithStubbedAdaptor()
 
@@ -165,3 +171,80 @@ func TestLIDARLiteDriverOptions(t *testing.T) {
 	l := NewLIDARLiteDriver(newI2cTestAdaptor(), WithBus(2))
 	gobottest.Assert(t, l.GetBusOrDefault(1), 2)
 }
+
+func TestLIDARLiteDriverV4Distance(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	l := NewLIDARLiteDriver(adaptor, WithLIDARLiteV4())
+	gobottest.Assert(t, l.Start(), nil)
+
+	busy := 2
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		switch adaptor.written[len(adaptor.written)-1] {
+		case lidarliteRegStatus:
+			if busy > 0 {
+				busy--
+				b[0] = lidarliteStatusBusy
+			} else {
+				b[0] = 0
+			}
+		case lidarliteV4RegFullDelayLow:
+			copy(b, []byte{0x01, 0x63})
+		}
+		return len(b), nil
+	}
+
+	distance, err := l.Distance()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, distance, int(25345))
+	gobottest.Assert(t, busy, 0)
+	gobottest.Assert(t, adaptor.written, []byte{0x00, 0x04, 0x01, 0x01, 0x01, 0x10})
+}
+
+func TestLIDARLiteDriverV4DistanceTimeout(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	l := NewLIDARLiteDriver(adaptor, WithLIDARLiteV4())
+	gobottest.Assert(t, l.Start(), nil)
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = lidarliteStatusBusy
+		return len(b), nil
+	}
+
+	_, err := l.Distance()
+	gobottest.Assert(t, err, errors.New("LIDAR-Lite measurement timed out"))
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, nil
+	}
+	_, err = l.Distance()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+}
+
+func TestLIDARLiteDriverSetMaxAcquisitionCount(t *testing.T) {
+	l, adaptor := initTestLIDARLiteDriverWithStubbedAdaptor()
+	gobottest.Assert(t, l.Start(), nil)
+	gobottest.Assert(t, l.SetMaxAcquisitionCount(0x80), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x02, 0x80})
+
+	adaptor = newI2cTestAdaptor()
+	l = NewLIDARLiteDriver(adaptor, WithLIDARLiteV4())
+	gobottest.Assert(t, l.Start(), nil)
+	gobottest.Assert(t, l.SetMaxAcquisitionCount(0x80), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x05, 0x80})
+}
+
+func TestLIDARLiteDriverContinuous(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	l := NewLIDARLiteDriver(adaptor, WithLIDARLiteV4())
+	gobottest.Assert(t, l.Start(), nil)
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x00, 0x01})
+		return len(b), nil
+	}
+
+	l.StartContinuous(time.Millisecond)
+	gobottest.Assert(t, gobottest.WaitForEvent(t, l, LIDARLiteDistance, time.Second), 256)
+
+	gobottest.Assert(t, l.Halt(), nil)
+	gobottest.AssertNoEvent(t, l, LIDARLiteDistance, 20*time.Millisecond)
+}
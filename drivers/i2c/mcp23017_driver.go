@@ -1,7 +1,9 @@
 package i2c
 
 import (
+	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"gobot.io/x/gobot"
@@ -58,7 +60,11 @@ type MCP23017Driver struct {
 	Config
 	MCPConf MCP23017Config
 	gobot.Commander
+	gobot.CommandSchemer
 	gobot.Eventer
+	// last known register values, to skip needless writes of WritePort
+	regs  map[uint8]uint8
+	mutex gobot.Mutex
 }
 
 // WithMCP23017Bank option sets the MCP23017Driver bank option
@@ -162,12 +168,14 @@ func WithMCP23017Intpol(val uint8) func(Config) {
 //
 func NewMCP23017Driver(a Connector, options ...func(Config)) *MCP23017Driver {
 	m := &MCP23017Driver{
-		name:      gobot.DefaultName("MCP23017"),
-		connector: a,
-		Config:    NewConfig(),
-		MCPConf:   MCP23017Config{},
-		Commander: gobot.NewCommander(),
-		Eventer:   gobot.NewEventer(),
+		name:           gobot.DefaultName("MCP23017"),
+		connector:      a,
+		Config:         NewConfig(),
+		MCPConf:        MCP23017Config{},
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		Eventer:        gobot.NewEventer(),
+		regs:           make(map[uint8]uint8),
 	}
 
 	for _, option := range options {
@@ -175,19 +183,28 @@ func NewMCP23017Driver(a Connector, options ...func(Config)) *MCP23017Driver {
 	}
 
 	m.AddCommand("WriteGPIO", func(params map[string]interface{}) interface{} {
-		pin := params["pin"].(uint8)
-		val := params["val"].(uint8)
+		pin := uint8(gobot.CommandParamFloat(params["pin"]))
+		val := uint8(gobot.CommandParamFloat(params["val"]))
 		port := params["port"].(string)
 		err := m.WriteGPIO(pin, val, port)
 		return map[string]interface{}{"err": err}
 	})
+	m.AddCommandSchema("WriteGPIO", gobot.CommandSchema{
+		"pin":  gobot.CommandParamNumber,
+		"val":  gobot.CommandParamNumber,
+		"port": gobot.CommandParamString,
+	})
 
 	m.AddCommand("ReadGPIO", func(params map[string]interface{}) interface{} {
-		pin := params["pin"].(uint8)
+		pin := uint8(gobot.CommandParamFloat(params["pin"]))
 		port := params["port"].(string)
 		val, err := m.ReadGPIO(pin, port)
 		return map[string]interface{}{"val": val, "err": err}
 	})
+	m.AddCommandSchema("ReadGPIO", gobot.CommandSchema{
+		"pin":  gobot.CommandParamNumber,
+		"port": gobot.CommandParamString,
+	})
 
 	return m
 }
@@ -201,8 +218,13 @@ func (m *MCP23017Driver) SetName(n string) { m.name = n }
 // Connection returns the I2c connection.
 func (m *MCP23017Driver) Connection() gobot.Connection { return m.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (m *MCP23017Driver) Contentions() uint64 { return m.mutex.Contentions() }
+
 // Halt stops the driver.
-func (m *MCP23017Driver) Halt() (err error) { return }
+func (m *MCP23017Driver) Halt() (err error) {
+	return closeConnection(&m.connection)
+}
 
 // Start writes the device configuration.
 func (m *MCP23017Driver) Start() (err error) {
@@ -213,6 +235,9 @@ func (m *MCP23017Driver) Start() (err error) {
 	if err != nil {
 		return err
 	}
+	m.mutex.Lock()
+	m.regs = make(map[uint8]uint8)
+	m.mutex.Unlock()
 	// Set IOCON register with MCP23017 configuration.
 	ioconReg := m.getPort("A").IOCON // IOCON address is the same for Port A or B.
 	ioconVal := m.MCPConf.getUint8Value()
@@ -224,6 +249,8 @@ func (m *MCP23017Driver) Start() (err error) {
 
 // WriteGPIO writes a value to a gpio pin (0-7) and a port (A or B).
 func (m *MCP23017Driver) WriteGPIO(pin uint8, val uint8, portStr string) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	selectedPort := m.getPort(portStr)
 	// read current value of IODIR register
 	iodir, err := m.read(selectedPort.IODIR)
@@ -257,9 +284,129 @@ func (m *MCP23017Driver) WriteGPIO(pin uint8, val uint8, portStr string) (err er
 	return nil
 }
 
+// WritePort writes the bits given by mask of a port (A or B) at once and
+// configures them as outputs. Registers are only written when their value
+// changes, which saves most of the bus traffic for e.g. a LCD on the port.
+func (m *MCP23017Driver) WritePort(portStr string, mask uint8, val uint8) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.writePort(m.getPort(portStr), mask, val)
+}
+
+func (m *MCP23017Driver) writePort(selectedPort port, mask uint8, val uint8) (err error) {
+	iodir, err := m.cachedRead(selectedPort.IODIR)
+	if err != nil {
+		return err
+	}
+	if iodir&mask != 0 {
+		if err := m.write(selectedPort.IODIR, 0, iodir&^mask); err != nil {
+			return err
+		}
+	}
+	olat, err := m.cachedRead(selectedPort.OLAT)
+	if err != nil {
+		return err
+	}
+	if olatVal := olat&^mask | val&mask; olatVal != olat {
+		if err := m.write(selectedPort.OLAT, 0, olatVal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DigitalWrite writes a value to a pin given as port and number, e.g. "A7"
+// or "B0". This allows to use the MCP23017 as connection of gpio drivers.
+func (m *MCP23017Driver) DigitalWrite(pin string, val byte) (err error) {
+	return m.DigitalWritePins([]string{pin}, []byte{val})
+}
+
+// DigitalWritePins writes the values to the pins, e.g. "A7" or "B0", with at
+// most one write of the output latch per port.
+func (m *MCP23017Driver) DigitalWritePins(pins []string, vals []byte) (err error) {
+	var masks, bits [2]uint8
+	for i, pin := range pins {
+		p, bit, err := mcp23017ParsePin(pin)
+		if err != nil {
+			return err
+		}
+		masks[p] = setBit(masks[p], bit)
+		if vals[i] != 0 {
+			bits[p] = setBit(bits[p], bit)
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for p, portStr := range []string{"A", "B"} {
+		if masks[p] == 0 {
+			continue
+		}
+		if err := m.writePort(m.getPort(portStr), masks[p], bits[p]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DigitalRead reads the value of a pin given as port and number, e.g. "A7"
+// or "B0", and configures it as input. This allows to use the MCP23017 as
+// connection of gpio drivers like the ButtonDriver.
+func (m *MCP23017Driver) DigitalRead(pin string) (val int, err error) {
+	p, bit, err := mcp23017ParsePin(pin)
+	if err != nil {
+		return 0, err
+	}
+	v, err := m.ReadGPIO(bit, []string{"A", "B"}[p])
+	return int(v), err
+}
+
+// DigitalPin returns a pin given as port and number, e.g. "A7", configured
+// with the direction "in" or "out", which implements the
+// gobot.DigitalPinnerProvider interface.
+func (m *MCP23017Driver) DigitalPin(pin string, dir string) (gobot.DigitalPinner, error) {
+	return newExpanderPin(m, pin, dir)
+}
+
+// setPinDirection sets or clears the bit of the pin in the IODIR register
+func (m *MCP23017Driver) setPinDirection(pin string, dir string) (err error) {
+	p, bit, err := mcp23017ParsePin(pin)
+	if err != nil {
+		return err
+	}
+	if dir != expanderPinIn && dir != expanderPinOut {
+		return fmt.Errorf("Invalid direction '%s' for MCP23017 pin '%s'", dir, pin)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	selectedPort := m.getPort([]string{"A", "B"}[p])
+	iodir, err := m.cachedRead(selectedPort.IODIR)
+	if err != nil {
+		return err
+	}
+	iodirVal := clearBit(iodir, bit)
+	if dir == expanderPinIn {
+		iodirVal = setBit(iodir, bit)
+	}
+	if iodirVal == iodir {
+		return nil
+	}
+	return m.write(selectedPort.IODIR, bit, iodirVal)
+}
+
+// Connect implements the gobot.Connection interface, so the driver can be
+// passed to gpio drivers. The device is connected by Start.
+func (m *MCP23017Driver) Connect() (err error) { return }
+
+// Finalize implements the gobot.Connection interface.
+func (m *MCP23017Driver) Finalize() (err error) { return }
+
 // ReadGPIO reads a value from a given gpio pin (0-7) and a
 // port (A or B).
 func (m *MCP23017Driver) ReadGPIO(pin uint8, portStr string) (val uint8, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	selectedPort := m.getPort(portStr)
 	// read current value of IODIR register
 	iodir, err := m.read(selectedPort.IODIR)
@@ -288,6 +435,8 @@ func (m *MCP23017Driver) ReadGPIO(pin uint8, portStr string) (val uint8, err err
 // val = 1 pull up enabled.
 // val = 0 pull up disabled.
 func (m *MCP23017Driver) SetPullUp(pin uint8, val uint8, portStr string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	selectedPort := m.getPort(portStr)
 	return m.write(selectedPort.GPPU, pin, val)
 }
@@ -296,6 +445,8 @@ func (m *MCP23017Driver) SetPullUp(pin uint8, val uint8, portStr string) error {
 // val = 1 opposite logic state of the input pin.
 // val = 0 same logic state of the input pin.
 func (m *MCP23017Driver) SetGPIOPolarity(pin uint8, val uint8, portStr string) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	selectedPort := m.getPort(portStr)
 	return m.write(selectedPort.IPOL, pin, val)
 }
@@ -307,11 +458,22 @@ func (m *MCP23017Driver) write(reg uint8, pin uint8, val uint8) (err error) {
 		log.Printf("write: MCP address: 0x%X, register:0x%X,value: 0x%X\n", m.GetAddressOrDefault(mcp23017Address), reg, val)
 	}
 	if _, err = m.connection.Write([]uint8{reg, val}); err != nil {
+		delete(m.regs, reg)
 		return err
 	}
+	m.regs[reg] = val
 	return nil
 }
 
+// cachedRead returns the last known value of a register, the register is
+// only read from the device when the value is unknown.
+func (m *MCP23017Driver) cachedRead(reg uint8) (val uint8, err error) {
+	if val, ok := m.regs[reg]; ok {
+		return val, nil
+	}
+	return m.read(reg)
+}
+
 // read get the data from a given register
 func (m *MCP23017Driver) read(reg uint8) (val uint8, err error) {
 	buf := []byte{0}
@@ -329,6 +491,7 @@ func (m *MCP23017Driver) read(reg uint8) (val uint8, err error) {
 	if debug {
 		log.Printf("reading: MCP address: 0x%X, register:0x%X,value: 0x%X\n", m.GetAddressOrDefault(mcp23017Address), reg, buf)
 	}
+	m.regs[reg] = buf[0]
 	return buf[0], nil
 }
 
@@ -346,6 +509,23 @@ func (m *MCP23017Driver) getPort(portStr string) (selectedPort port) {
 	}
 }
 
+// mcp23017ParsePin returns the port index (0 for A, 1 for B) and the bit of
+// a pin like "A7".
+func mcp23017ParsePin(pin string) (p int, bit uint8, err error) {
+	if len(pin) == 2 {
+		n, err := strconv.Atoi(pin[1:])
+		if err == nil && n < 8 {
+			switch strings.ToUpper(pin[:1]) {
+			case "A":
+				return 0, uint8(n), nil
+			case "B":
+				return 1, uint8(n), nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("Invalid MCP23017 pin '%s'", pin)
+}
+
 // getUint8Value returns the configuration data as a packed value.
 func (mc *MCP23017Config) getUint8Value() uint8 {
 	return mc.Bank<<7 | mc.Mirror<<6 | mc.Seqop<<5 | mc.Disslw<<4 | mc.Haen<<3 | mc.Odr<<2 | mc.Intpol<<1
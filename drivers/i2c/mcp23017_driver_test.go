@@ -8,10 +8,16 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/gobottest"
 )
 
 var _ gobot.Driver = (*MCP23017Driver)(nil)
+
+// and is a connection of the gpio drivers
+var _ gpio.DigitalReader = (*MCP23017Driver)(nil)
+var _ gpio.DigitalWriter = (*MCP23017Driver)(nil)
+var _ gobot.DigitalPinnerProvider = (*MCP23017Driver)(nil)
 var (
 	pinValPort = map[string]interface{}{
 		"pin":  uint8(7),
@@ -122,6 +128,12 @@ func TestMCP23017DriverCommandsReadGPIO(t *testing.T) {
 	}
 	result := mcp.Command("ReadGPIO")(pinPort)
 	gobottest.Assert(t, result.(map[string]interface{})["err"], nil)
+
+	// numbers of a JSON request are decoded as float64
+	params := map[string]interface{}{"pin": 7.0, "port": "A"}
+	gobottest.Assert(t, len(mcp.CommandSchema("ReadGPIO").Validate(params)), 0)
+	result = mcp.Command("ReadGPIO")(params)
+	gobottest.Assert(t, result.(map[string]interface{})["err"], nil)
 }
 
 func TestMCP23017DriverWriteGPIO(t *testing.T) {
@@ -300,6 +312,66 @@ func TestMCP23017DriverWrite(t *testing.T) {
 	log.SetOutput(os.Stdout)
 }
 
+func TestMCP23017DriverWritePort(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	gobottest.Assert(t, mcp.Start(), nil)
+	reads := 0
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		reads++
+		b[0] = 0xFF
+		return len(b), nil
+	}
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		return len(b), nil
+	}
+	adaptor.written = nil
+
+	// the registers are read once, then the cached values are used
+	gobottest.Assert(t, mcp.WritePort("A", 0x0F, 0x05), nil)
+	gobottest.Assert(t, reads, 2)
+	gobottest.Assert(t, adaptor.written, []byte{0x00, 0x00, 0xF0, 0x14, 0x14, 0xF5})
+
+	adaptor.written = nil
+	gobottest.Assert(t, mcp.WritePort("A", 0x0F, 0x05), nil)
+	gobottest.Assert(t, mcp.WritePort("A", 0x01, 0x01), nil)
+	gobottest.Assert(t, adaptor.written, []byte(nil))
+	gobottest.Assert(t, mcp.WritePort("A", 0x01, 0x00), nil)
+	gobottest.Assert(t, reads, 2)
+	gobottest.Assert(t, adaptor.written, []byte{0x14, 0xF4})
+
+	// write error
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, mcp.WritePort("A", 0x01, 0x01), errors.New("write error"))
+}
+
+func TestMCP23017DriverDigitalWritePins(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	gobottest.Assert(t, mcp.Start(), nil)
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return len(b), nil
+	}
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		return len(b), nil
+	}
+	adaptor.written = nil
+
+	err := mcp.DigitalWritePins([]string{"A0", "a1", "B7"}, []byte{1, 0, 1})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x00, 0x14, 0x14, 0x01,
+		0x01, 0x15, 0x15, 0x80,
+	})
+
+	adaptor.written = nil
+	gobottest.Assert(t, mcp.DigitalWrite("A1", 1), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x14, 0x03})
+
+	gobottest.Assert(t, mcp.DigitalWrite("C1", 1), errors.New("Invalid MCP23017 pin 'C1'"))
+	gobottest.Assert(t, mcp.DigitalWrite("A8", 1), errors.New("Invalid MCP23017 pin 'A8'"))
+}
+
 func TestMCP23017DriverReadPort(t *testing.T) {
 	// read
 	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
@@ -378,3 +450,54 @@ func TestMCP23017DriverSetName(t *testing.T) {
 	d.SetName("TESTME")
 	gobottest.Assert(t, d.Name(), "TESTME")
 }
+
+func TestMCP23017DriverDigitalRead(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	gobottest.Assert(t, mcp.Start(), nil)
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0x80
+		return 1, nil
+	}
+
+	val, err := mcp.DigitalRead("A7")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	val, err = mcp.DigitalRead("B6")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0)
+
+	_, err = mcp.DigitalRead("C1")
+	gobottest.Assert(t, err, errors.New("Invalid MCP23017 pin 'C1'"))
+}
+
+func TestMCP23017DriverDigitalPin(t *testing.T) {
+	mcp, adaptor := initTestMCP23017DriverWithStubbedAdaptor(0)
+	gobottest.Assert(t, mcp.Start(), nil)
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		b[0] = 0xFF
+		return 1, nil
+	}
+
+	// the pin is configured as output by clearing its IODIR bit
+	adaptor.written = []byte{}
+	pin, err := mcp.DigitalPin("B0", "out")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x01, 0x01, 0xFE})
+
+	// and back as input, with the cached IODIR
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pin.Direction("in"), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x01, 0xFF})
+
+	gobottest.Assert(t, pin.Direction("none"), errors.New("Invalid direction 'none' for MCP23017 pin 'B0'"))
+	gobottest.Assert(t, pin.Export(), nil)
+	gobottest.Assert(t, pin.Unexport(), nil)
+
+	val, err := pin.Read()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, pin.Write(0), nil)
+
+	_, err = mcp.DigitalPin("B8", "in")
+	gobottest.Assert(t, err, errors.New("Invalid MCP23017 pin 'B8'"))
+}
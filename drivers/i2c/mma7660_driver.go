@@ -95,7 +95,9 @@ func (h *MMA7660Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *MMA7660Driver) Halt() (err error) { return }
+func (h *MMA7660Driver) Halt() (err error) {
+	return closeConnection(&h.connection)
+}
 
 // Acceleration returns the acceleration of the provided x, y, z
 func (h *MMA7660Driver) Acceleration(x, y, z float64) (ax, ay, az float64) {
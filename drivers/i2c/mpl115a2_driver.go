@@ -2,6 +2,7 @@ package i2c
 
 import (
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
 
 	"bytes"
 	"encoding/binary"
@@ -85,7 +86,9 @@ func (h *MPL115A2Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (h *MPL115A2Driver) Halt() (err error) { return }
+func (h *MPL115A2Driver) Halt() (err error) {
+	return closeConnection(&h.connection)
+}
 
 // Pressure fetches the latest data from the MPL115A2, and returns the pressure
 func (h *MPL115A2Driver) Pressure() (p float32, err error) {
@@ -99,6 +102,23 @@ func (h *MPL115A2Driver) Temperature() (t float32, err error) {
 	return
 }
 
+// Thermometer returns the driver as sensor.Thermometer
+func (h *MPL115A2Driver) Thermometer() sensor.Thermometer {
+	return sensor.ThermometerFunc(func() (float64, error) {
+		t, err := h.Temperature()
+		return float64(t), err
+	})
+}
+
+// Barometer returns the driver as sensor.Barometer, the pressure is
+// converted from kPa to Pa
+func (h *MPL115A2Driver) Barometer() sensor.Barometer {
+	return sensor.BarometerFunc(func() (float64, error) {
+		p, err := h.Pressure()
+		return float64(p) * 1000, err
+	})
+}
+
 func (h *MPL115A2Driver) initialization() (err error) {
 	var coA0 int16
 	var coB1 int16
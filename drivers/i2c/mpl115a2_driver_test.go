@@ -85,6 +85,11 @@ func TestMPL115A2DriverReadData(t *testing.T) {
 	temp, _ := mpl.Temperature()
 	gobottest.Assert(t, press, float32(50.007942))
 	gobottest.Assert(t, temp, float32(116.58878))
+
+	pascal, _ := mpl.Barometer().Pressure()
+	celsius, _ := mpl.Thermometer().Temperature()
+	gobottest.Assert(t, pascal, float64(press)*1000)
+	gobottest.Assert(t, celsius, float64(temp))
 }
 
 func TestMPL115A2DriverReadDataError(t *testing.T) {
@@ -1,8 +1,9 @@
 package i2c
 
 import (
-	"bytes"
 	"encoding/binary"
+	"errors"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -26,12 +27,68 @@ const MPU6050_ACCEL_FS_2 = 0x00
 const MPU6050_PWR1_SLEEP_BIT = 6
 const MPU6050_PWR1_ENABLE_BIT = 0
 
+const MPU6050_RA_MOT_THR = 0x1F
+const MPU6050_RA_MOT_DUR = 0x20
+const MPU6050_RA_ZRMOT_THR = 0x21
+const MPU6050_RA_ZRMOT_DUR = 0x22
+const MPU6050_RA_INT_PIN_CFG = 0x37
+const MPU6050_RA_INT_ENABLE = 0x38
+const MPU6050_RA_INT_STATUS = 0x3A
+const MPU6050_RA_MOT_DETECT_STATUS = 0x61
+const MPU6050_RA_USER_CTRL = 0x6A
+const MPU6050_RA_BANK_SEL = 0x6D
+const MPU6050_RA_MEM_START_ADDR = 0x6E
+const MPU6050_RA_MEM_R_W = 0x6F
+const MPU6050_RA_DMP_CFG_1 = 0x70
+const MPU6050_RA_DMP_CFG_2 = 0x71
+const MPU6050_RA_FIFO_COUNTH = 0x72
+const MPU6050_RA_FIFO_R_W = 0x74
+
+const MPU6050_INTCFG_LATCH_INT_EN = 0x20
+const MPU6050_INTERRUPT_MOT = 0x40
+const MPU6050_INTERRUPT_ZMOT = 0x20
+const MPU6050_INTERRUPT_FIFO_OFLOW = 0x10
+const MPU6050_INTERRUPT_DMP_INT = 0x02
+const MPU6050_MOTION_ZRMOT = 0x01
+const MPU6050_USERCTRL_DMP_EN = 0x80
+const MPU6050_USERCTRL_FIFO_EN = 0x40
+const MPU6050_USERCTRL_DMP_RESET = 0x08
+const MPU6050_USERCTRL_FIFO_RESET = 0x04
+
+const (
+	// MPU6050Motion event, published when a motion was detected
+	MPU6050Motion = "motion"
+	// MPU6050ZeroMotion event, published with true when the zero motion
+	// starts and with false when it ends
+	MPU6050ZeroMotion = "zero-motion"
+	// MPU6050Quaternion event, published with the Quaternion of each packet
+	// of the DMP
+	MPU6050Quaternion = "quaternion"
+)
+
+// the DMP memory is written in banks of 256 bytes, in chunks of 16 bytes
+const (
+	mpu6050DMPBankSize  = 256
+	mpu6050DMPChunkSize = 16
+	// start address of the DMP program, as used by the MotionApps firmware
+	mpu6050DMPStartAddress = 0x0400
+	mpu6050FIFOSize        = 1024
+)
+
 type ThreeDData struct {
 	X int16
 	Y int16
 	Z int16
 }
 
+// Quaternion is the orientation calculated by the DMP
+type Quaternion struct {
+	W float64
+	X float64
+	Y float64
+	Z float64
+}
+
 // MPU6050Driver is a new Gobot Driver for an MPU6050 I2C Accelerometer/Gyroscope.
 type MPU6050Driver struct {
 	name       string
@@ -43,6 +100,50 @@ type MPU6050Driver struct {
 	Gyroscope     ThreeDData
 	Temperature   int16
 	gobot.Eventer
+	// buffers reused by each read, protected by the mutex
+	writeBuf [1]byte
+	readBuf  [14]byte
+	mutex    gobot.Mutex
+	// the INT pin, polled with the interval
+	intReader DigitalReader
+	intPin    string
+	intEnable uint8
+	// the firmware of the DMP and the size of its FIFO packets
+	dmpFirmware   []byte
+	dmpPacketSize int
+	halt          chan bool
+	// done waits for the polling of the INT pin, which uses the connection
+	done sync.WaitGroup
+}
+
+// WithMPU6050InterruptPin option sets the pin the INT output of the MPU6050 is
+// connected to. The pin is polled and the interrupts are handled, see
+// HandleInterrupt.
+func WithMPU6050InterruptPin(reader DigitalReader, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MPU6050Driver)
+		if ok {
+			d.intReader, d.intPin = reader, pin
+		} else {
+			panic("trying to set interrupt pin for non-MPU6050Driver")
+		}
+	}
+}
+
+// WithMPU6050DMP option enables the Digital Motion Processor. The firmware
+// is not part of gobot, it is the image of the InvenSense MotionApps, e.g.
+// as published with the MPU6050 library of I2Cdevlib. The packetSize is the
+// size of the FIFO packets written by that firmware, e.g. 42 for
+// MotionApps 2.0 and 28 for MotionApps 6.12.
+func WithMPU6050DMP(firmware []byte, packetSize int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MPU6050Driver)
+		if ok {
+			d.dmpFirmware, d.dmpPacketSize = firmware, packetSize
+		} else {
+			panic("trying to set DMP firmware for non-MPU6050Driver")
+		}
+	}
 }
 
 // NewMPU6050Driver creates a new Gobot Driver for an MPU6050 I2C Accelerometer/Gyroscope.
@@ -60,12 +161,18 @@ func NewMPU6050Driver(a Connector, options ...func(Config)) *MPU6050Driver {
 		connector: a,
 		Config:    NewConfig(),
 		Eventer:   gobot.NewEventer(),
+		interval:  10 * time.Millisecond,
 	}
 
 	for _, option := range options {
 		option(m)
 	}
 
+	m.AddEvent(MPU6050Motion)
+	m.AddEvent(MPU6050ZeroMotion)
+	m.AddEvent(MPU6050Quaternion)
+	m.AddEvent(Error)
+
 	// TODO: add commands to API
 	return m
 }
@@ -79,34 +186,187 @@ func (h *MPU6050Driver) SetName(n string) { h.name = n }
 // Connection returns the connection for the device.
 func (h *MPU6050Driver) Connection() gobot.Connection { return h.connector.(gobot.Connection) }
 
-// Start writes initialization bytes to sensor
+// Contentions returns how often a caller had to wait for the driver
+func (h *MPU6050Driver) Contentions() uint64 { return h.mutex.Contentions() }
+
+// Start writes initialization bytes to sensor, loads the DMP firmware and
+// starts polling the INT pin, if configured
 func (h *MPU6050Driver) Start() (err error) {
 	if err := h.initialize(); err != nil {
 		return err
 	}
 
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.intEnable = 0
+	if h.intReader != nil {
+		// the interrupt is held until INT_STATUS is read
+		if err := h.writeRegister(MPU6050_RA_INT_PIN_CFG, MPU6050_INTCFG_LATCH_INT_EN); err != nil {
+			return err
+		}
+	}
+	if h.dmpFirmware != nil {
+		if err := h.initializeDMP(); err != nil {
+			return err
+		}
+	}
+	if h.intReader != nil {
+		h.halt = make(chan bool)
+		h.done.Add(1)
+		go h.pollInterrupt(h.halt)
+	}
+
 	return
 }
 
-// Halt returns true if devices is halted successfully
-func (h *MPU6050Driver) Halt() (err error) { return }
+// Halt stops polling the INT pin
+func (h *MPU6050Driver) Halt() (err error) {
+	h.mutex.Lock()
+	if h.halt != nil {
+		close(h.halt)
+		h.halt = nil
+	}
+	h.mutex.Unlock()
+	// HandleInterrupt locks the mutex
+	h.done.Wait()
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return closeConnection(&h.connection)
+}
+
+// ConfigureMotion enables the motion interrupt. A motion is detected when
+// the acceleration exceeds the threshold (in steps of 2mg) for the duration
+// (in steps of 1ms).
+func (h *MPU6050Driver) ConfigureMotion(threshold uint8, duration uint8) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err = h.writeRegister(MPU6050_RA_MOT_THR, threshold); err != nil {
+		return
+	}
+	if err = h.writeRegister(MPU6050_RA_MOT_DUR, duration); err != nil {
+		return
+	}
+	return h.enableInterrupts(MPU6050_INTERRUPT_MOT)
+}
+
+// ConfigureZeroMotion enables the zero motion interrupt. A zero motion is
+// detected when the acceleration stays below the threshold (in steps of
+// 2mg) for the duration (in steps of 64ms).
+func (h *MPU6050Driver) ConfigureZeroMotion(threshold uint8, duration uint8) (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err = h.writeRegister(MPU6050_RA_ZRMOT_THR, threshold); err != nil {
+		return
+	}
+	if err = h.writeRegister(MPU6050_RA_ZRMOT_DUR, duration); err != nil {
+		return
+	}
+	return h.enableInterrupts(MPU6050_INTERRUPT_ZMOT)
+}
+
+// HandleInterrupt reads and clears the interrupt status and publishes the
+// events of the interrupts. It is called when the INT pin is active, see
+// WithMPU6050InterruptPin, or can be called by an own interrupt handling.
+func (h *MPU6050Driver) HandleInterrupt() (err error) {
+	h.mutex.Lock()
+	status, err := h.readRegister(MPU6050_RA_INT_STATUS)
+	if err != nil {
+		h.mutex.Unlock()
+		return err
+	}
+	var motionStatus uint8
+	if status&MPU6050_INTERRUPT_ZMOT != 0 {
+		if motionStatus, err = h.readRegister(MPU6050_RA_MOT_DETECT_STATUS); err != nil {
+			h.mutex.Unlock()
+			return err
+		}
+	}
+	h.mutex.Unlock()
+
+	if status&MPU6050_INTERRUPT_MOT != 0 {
+		h.Publish(h.Event(MPU6050Motion), true)
+	}
+	if status&MPU6050_INTERRUPT_ZMOT != 0 {
+		h.Publish(h.Event(MPU6050ZeroMotion), motionStatus&MPU6050_MOTION_ZRMOT != 0)
+	}
+	if status&MPU6050_INTERRUPT_DMP_INT != 0 && h.dmpFirmware != nil {
+		q, err := h.Quaternion()
+		if err == ErrNotReady {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		h.Publish(h.Event(MPU6050Quaternion), q)
+	}
+	return nil
+}
+
+// Quaternion returns the latest orientation calculated by the DMP, older
+// packets in the FIFO are dropped. ErrNotReady is returned, when the FIFO
+// contains no complete packet.
+func (h *MPU6050Driver) Quaternion() (q Quaternion, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.dmpFirmware == nil {
+		return q, errors.New("DMP not enabled")
+	}
+
+	buf := make([]byte, h.dmpPacketSize)
+	if err = h.readRegisters(MPU6050_RA_FIFO_COUNTH, buf[:2]); err != nil {
+		return
+	}
+	count := int(binary.BigEndian.Uint16(buf))
+	if count >= mpu6050FIFOSize {
+		// the packets are misaligned after an overflow
+		if err = h.writeRegister(MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_RESET); err != nil {
+			return
+		}
+		if err = h.writeRegister(MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_EN|MPU6050_USERCTRL_DMP_EN); err != nil {
+			return
+		}
+		return q, errors.New("FIFO overflow")
+	}
+	if count < h.dmpPacketSize {
+		return q, ErrNotReady
+	}
+	for ; count >= h.dmpPacketSize; count -= h.dmpPacketSize {
+		if err = h.readRegisters(MPU6050_RA_FIFO_R_W, buf); err != nil {
+			return
+		}
+	}
+
+	// the components are 32 bit values, the upper 16 bits are scaled by 2^14
+	component := func(i int) float64 {
+		return float64(int16(binary.BigEndian.Uint16(buf[i*4:]))) / 16384
+	}
+	return Quaternion{W: component(0), X: component(1), Y: component(2), Z: component(3)}, nil
+}
 
 // GetData fetches the latest data from the MPU6050
 func (h *MPU6050Driver) GetData() (err error) {
-	if _, err = h.connection.Write([]byte{MPU6050_RA_ACCEL_XOUT_H}); err != nil {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.writeBuf[0] = MPU6050_RA_ACCEL_XOUT_H
+	if _, err = h.connection.Write(h.writeBuf[:]); err != nil {
 		return
 	}
 
-	data := make([]byte, 14)
+	data := h.readBuf[:]
 	_, err = h.connection.Read(data)
 	if err != nil {
 		return
 	}
 
-	buf := bytes.NewBuffer(data)
-	binary.Read(buf, binary.BigEndian, &h.Accelerometer)
-	binary.Read(buf, binary.BigEndian, &h.Temperature)
-	binary.Read(buf, binary.BigEndian, &h.Gyroscope)
+	h.Accelerometer = mpu6050ThreeDData(data[0:])
+	h.Temperature = int16(binary.BigEndian.Uint16(data[6:]))
+	h.Gyroscope = mpu6050ThreeDData(data[8:])
 	h.convertToCelsius()
 	return
 }
@@ -154,6 +414,88 @@ func (h *MPU6050Driver) initialize() (err error) {
 	return nil
 }
 
+// initializeDMP loads the firmware and enables the DMP and its FIFO
+func (h *MPU6050Driver) initializeDMP() (err error) {
+	for addr := 0; addr < len(h.dmpFirmware); addr += mpu6050DMPChunkSize {
+		end := addr + mpu6050DMPChunkSize
+		if end > len(h.dmpFirmware) {
+			end = len(h.dmpFirmware)
+		}
+		if err = h.writeRegister(MPU6050_RA_BANK_SEL, uint8(addr/mpu6050DMPBankSize)); err != nil {
+			return
+		}
+		if err = h.writeRegister(MPU6050_RA_MEM_START_ADDR, uint8(addr%mpu6050DMPBankSize)); err != nil {
+			return
+		}
+		if err = h.connection.WriteBlockData(MPU6050_RA_MEM_R_W, h.dmpFirmware[addr:end]); err != nil {
+			return
+		}
+	}
+
+	if err = h.writeRegister(MPU6050_RA_DMP_CFG_1, uint8(mpu6050DMPStartAddress>>8)); err != nil {
+		return
+	}
+	if err = h.writeRegister(MPU6050_RA_DMP_CFG_2, uint8(mpu6050DMPStartAddress&0xFF)); err != nil {
+		return
+	}
+	if err = h.enableInterrupts(MPU6050_INTERRUPT_DMP_INT); err != nil {
+		return
+	}
+	if err = h.writeRegister(MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_RESET|MPU6050_USERCTRL_DMP_RESET); err != nil {
+		return
+	}
+	return h.writeRegister(MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_EN|MPU6050_USERCTRL_DMP_EN)
+}
+
+// pollInterrupt handles the interrupts while the INT pin is active
+func (h *MPU6050Driver) pollInterrupt(halt chan bool) {
+	defer h.done.Done()
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(h.interval):
+		}
+
+		val, err := h.intReader.DigitalRead(h.intPin)
+		if err != nil {
+			h.Publish(h.Event(Error), err)
+			continue
+		}
+		if val == 1 {
+			if err := h.HandleInterrupt(); err != nil {
+				h.Publish(h.Event(Error), err)
+			}
+		}
+	}
+}
+
+// enableInterrupts adds the interrupts to the enabled ones
+func (h *MPU6050Driver) enableInterrupts(interrupts uint8) error {
+	h.intEnable |= interrupts
+	return h.writeRegister(MPU6050_RA_INT_ENABLE, h.intEnable)
+}
+
+func (h *MPU6050Driver) writeRegister(reg uint8, val uint8) (err error) {
+	_, err = h.connection.Write([]byte{reg, val})
+	return
+}
+
+func (h *MPU6050Driver) readRegister(reg uint8) (uint8, error) {
+	buf := []byte{0}
+	err := h.readRegisters(reg, buf)
+	return buf[0], err
+}
+
+func (h *MPU6050Driver) readRegisters(reg uint8, buf []byte) (err error) {
+	h.writeBuf[0] = reg
+	if _, err = h.connection.Write(h.writeBuf[:]); err != nil {
+		return
+	}
+	_, err = h.connection.Read(buf)
+	return
+}
+
 // The temperature sensor is -40 to +85 degrees Celsius.
 // It is a signed integer.
 // According to the datasheet:
@@ -162,3 +504,12 @@ func (h *MPU6050Driver) initialize() (err error) {
 func (h *MPU6050Driver) convertToCelsius() {
 	h.Temperature = (h.Temperature + 12412) / 340
 }
+
+// mpu6050ThreeDData decodes the big endian values of the 3 axis
+func mpu6050ThreeDData(data []byte) ThreeDData {
+	return ThreeDData{
+		X: int16(binary.BigEndian.Uint16(data[0:])),
+		Y: int16(binary.BigEndian.Uint16(data[2:])),
+		Z: int16(binary.BigEndian.Uint16(data[4:])),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
@@ -23,6 +24,18 @@ func initTestMPU6050DriverWithStubbedAdaptor() (*MPU6050Driver, *i2cTestAdaptor)
 	return NewMPU6050Driver(adaptor), adaptor
 }
 
+// simulateMPU6050Registers serves the reads from the registers, the
+// register is the last byte written
+func simulateMPU6050Registers(adaptor *i2cTestAdaptor, registers map[byte][]byte) {
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		if len(adaptor.written) == 0 {
+			return 0, errors.New("no register selected")
+		}
+		copy(b, registers[adaptor.written[len(adaptor.written)-1]])
+		return len(b), nil
+	}
+}
+
 // --------- TESTS
 
 func TestNewMPU6050Driver(t *testing.T) {
@@ -111,3 +124,193 @@ func TestMPU6050DriverSetName(t *testing.T) {
 	mpu.SetName("TESTME")
 	gobottest.Assert(t, mpu.Name(), "TESTME")
 }
+
+func TestMPU6050DriverGetData(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.Start()
+
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x00, 0x01, 0xFF, 0xFE, 0x01, 0x00, 0xCF, 0x84, 0x00, 0x02, 0x80, 0x00, 0x7F, 0xFF})
+		return len(b), nil
+	}
+
+	gobottest.Assert(t, mpu.GetData(), nil)
+	gobottest.Assert(t, mpu.Accelerometer, ThreeDData{X: 1, Y: -2, Z: 256})
+	gobottest.Assert(t, mpu.Gyroscope, ThreeDData{X: 2, Y: -32768, Z: 32767})
+	gobottest.Assert(t, mpu.Temperature, int16(0))
+
+	// the buffers are reused
+	allocs := testing.AllocsPerRun(100, func() { mpu.GetData() })
+	gobottest.Assert(t, allocs, 0.0)
+}
+
+func TestMPU6050DriverConfigureMotion(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.Start()
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, mpu.ConfigureMotion(20, 40), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		MPU6050_RA_MOT_THR, 20,
+		MPU6050_RA_MOT_DUR, 40,
+		MPU6050_RA_INT_ENABLE, MPU6050_INTERRUPT_MOT,
+	})
+
+	// the enabled interrupts are kept
+	adaptor.written = []byte{}
+	gobottest.Assert(t, mpu.ConfigureZeroMotion(4, 2), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		MPU6050_RA_ZRMOT_THR, 4,
+		MPU6050_RA_ZRMOT_DUR, 2,
+		MPU6050_RA_INT_ENABLE, MPU6050_INTERRUPT_MOT | MPU6050_INTERRUPT_ZMOT,
+	})
+
+	adaptor.i2cWriteImpl = func([]byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, mpu.ConfigureMotion(20, 40), errors.New("write error"))
+}
+
+func TestMPU6050DriverHandleInterrupt(t *testing.T) {
+	mpu, adaptor := initTestMPU6050DriverWithStubbedAdaptor()
+	mpu.Start()
+	simulateMPU6050Registers(adaptor, map[byte][]byte{
+		MPU6050_RA_INT_STATUS:        {MPU6050_INTERRUPT_MOT | MPU6050_INTERRUPT_ZMOT},
+		MPU6050_RA_MOT_DETECT_STATUS: {MPU6050_MOTION_ZRMOT},
+	})
+
+	motion := gobottest.ListenForEvent(mpu, MPU6050Motion)
+	zeroMotion := gobottest.ListenForEvent(mpu, MPU6050ZeroMotion)
+	gobottest.Assert(t, mpu.HandleInterrupt(), nil)
+	gobottest.Assert(t, motion.Wait(t, time.Second), true)
+	gobottest.Assert(t, zeroMotion.Wait(t, time.Second), true)
+
+	// the end of the zero motion
+	simulateMPU6050Registers(adaptor, map[byte][]byte{
+		MPU6050_RA_INT_STATUS: {MPU6050_INTERRUPT_ZMOT},
+	})
+	motion = gobottest.ListenForEvent(mpu, MPU6050Motion)
+	zeroMotion = gobottest.ListenForEvent(mpu, MPU6050ZeroMotion)
+	gobottest.Assert(t, mpu.HandleInterrupt(), nil)
+	gobottest.Assert(t, zeroMotion.Wait(t, time.Second), false)
+	motion.AssertNone(t, 10*time.Millisecond)
+
+	adaptor.i2cReadImpl = func([]byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	gobottest.Assert(t, mpu.HandleInterrupt(), errors.New("read error"))
+}
+
+func TestMPU6050DriverInterruptPin(t *testing.T) {
+	board := gobottest.NewBoard()
+	adaptor := newI2cTestAdaptor()
+	mpu := NewMPU6050Driver(adaptor, WithMPU6050InterruptPin(board, "7"))
+	simulateMPU6050Registers(adaptor, map[byte][]byte{
+		MPU6050_RA_INT_STATUS: {MPU6050_INTERRUPT_MOT},
+	})
+
+	gobottest.Assert(t, mpu.Start(), nil)
+	defer mpu.Halt()
+	gobottest.Assert(t, strings.Contains(string(adaptor.written),
+		string([]byte{MPU6050_RA_INT_PIN_CFG, MPU6050_INTCFG_LATCH_INT_EN})), true)
+
+	motion := gobottest.ListenForEvent(mpu, MPU6050Motion)
+	motion.AssertNone(t, 30*time.Millisecond)
+	board.SetLevel("7", 1)
+	gobottest.Assert(t, motion.Wait(t, time.Second), true)
+}
+
+func TestMPU6050DriverInterruptPinError(t *testing.T) {
+	board := gobottest.NewBoard()
+	board.SetError("7", errors.New("pin error"))
+	mpu := NewMPU6050Driver(newI2cTestAdaptor(), WithMPU6050InterruptPin(board, "7"))
+
+	gobottest.Assert(t, mpu.Start(), nil)
+	defer mpu.Halt()
+	gobottest.Assert(t, gobottest.WaitForEvent(t, mpu, Error, time.Second), errors.New("pin error"))
+}
+
+func TestMPU6050DriverStartDMP(t *testing.T) {
+	firmware := make([]byte, 300)
+	for i := range firmware {
+		firmware[i] = byte(i)
+	}
+	adaptor := newI2cTestAdaptor()
+	mpu := NewMPU6050Driver(adaptor, WithMPU6050DMP(firmware, 42))
+
+	gobottest.Assert(t, mpu.Start(), nil)
+	written := adaptor.written
+	// the first chunk of the second bank
+	chunk := append([]byte{
+		MPU6050_RA_BANK_SEL, 1,
+		MPU6050_RA_MEM_START_ADDR, 0,
+		MPU6050_RA_MEM_R_W}, firmware[256:272]...)
+	gobottest.Assert(t, strings.Contains(string(written), string(chunk)), true)
+	// the last chunk is shorter
+	chunk = append([]byte{
+		MPU6050_RA_BANK_SEL, 1,
+		MPU6050_RA_MEM_START_ADDR, 32,
+		MPU6050_RA_MEM_R_W}, firmware[288:]...)
+	gobottest.Assert(t, strings.Contains(string(written), string(chunk)), true)
+	gobottest.Assert(t, written[len(written)-10:], []byte{
+		MPU6050_RA_DMP_CFG_1, 0x04,
+		MPU6050_RA_DMP_CFG_2, 0x00,
+		MPU6050_RA_INT_ENABLE, MPU6050_INTERRUPT_DMP_INT,
+		MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_RESET | MPU6050_USERCTRL_DMP_RESET,
+		MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_EN | MPU6050_USERCTRL_DMP_EN,
+	})
+}
+
+func TestMPU6050DriverQuaternion(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	mpu := NewMPU6050Driver(adaptor, WithMPU6050DMP([]byte{0x00}, 16))
+	mpu.Start()
+
+	// the DMP writes two packets, the latest one is used
+	packets := [][]byte{
+		{0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		{0x20, 0x00, 0x12, 0x34, 0xE0, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0xF0, 0x00, 0x00, 0x00},
+	}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		switch adaptor.written[len(adaptor.written)-1] {
+		case MPU6050_RA_FIFO_COUNTH:
+			copy(b, []byte{0x00, byte(16 * len(packets))})
+		case MPU6050_RA_FIFO_R_W:
+			copy(b, packets[0])
+			packets = packets[1:]
+		}
+		return len(b), nil
+	}
+
+	q, err := mpu.Quaternion()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, q, Quaternion{W: 0.5, X: -0.5, Y: 0.25, Z: -0.25})
+
+	// no complete packet
+	q, err = mpu.Quaternion()
+	gobottest.Assert(t, err, ErrNotReady)
+}
+
+func TestMPU6050DriverQuaternionOverflow(t *testing.T) {
+	adaptor := newI2cTestAdaptor()
+	mpu := NewMPU6050Driver(adaptor, WithMPU6050DMP([]byte{0x00}, 42))
+	mpu.Start()
+	simulateMPU6050Registers(adaptor, map[byte][]byte{
+		MPU6050_RA_FIFO_COUNTH: {0x04, 0x00},
+	})
+
+	adaptor.written = []byte{}
+	_, err := mpu.Quaternion()
+	gobottest.Assert(t, err, errors.New("FIFO overflow"))
+	gobottest.Assert(t, adaptor.written, []byte{
+		MPU6050_RA_FIFO_COUNTH,
+		MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_RESET,
+		MPU6050_RA_USER_CTRL, MPU6050_USERCTRL_FIFO_EN | MPU6050_USERCTRL_DMP_EN,
+	})
+}
+
+func TestMPU6050DriverQuaternionWithoutDMP(t *testing.T) {
+	mpu := initTestMPU6050Driver()
+	_, err := mpu.Quaternion()
+	gobottest.Assert(t, err, errors.New("DMP not enabled"))
+}
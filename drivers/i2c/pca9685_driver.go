@@ -1,7 +1,10 @@
 package i2c
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot"
@@ -9,6 +12,10 @@ import (
 
 const pca9685Address = 0x40
 
+// pca9685DefaultPeriod is the PWM period in ns after power on, the
+// prescaler defaults to 200Hz
+const pca9685DefaultPeriod = 5000000
+
 const (
 	PCA9685_MODE1        = 0x00
 	PCA9685_MODE2        = 0x01
@@ -30,8 +37,16 @@ const (
 	PCA9685_ALLCALL = 0x01
 	PCA9685_INVRT   = 0x10
 	PCA9685_OUTDRV  = 0x04
+
+	// PCA9685_FULL is the full ON bit of LEDn_ON_H and the full OFF bit of
+	// LEDn_OFF_H, full OFF has precedence
+	PCA9685_FULL = 0x10
 )
 
+// ErrNoOutputEnablePin is returned when the outputs are enabled or
+// disabled without an output enable pin
+var ErrNoOutputEnablePin = errors.New("No output enable pin")
+
 // PCA9685Driver is a Gobot Driver for the PCA9685 16-channel 12-bit PWM/Servo controller.
 //
 // For example, here is the Adafruit board that uses this chip:
@@ -43,6 +58,27 @@ type PCA9685Driver struct {
 	connection Connection
 	Config
 	gobot.Commander
+	gobot.CommandSchemer
+	oeWriter DigitalWriter
+	oePin    string
+	// the PWM period in ns, which is shared by all channels
+	period  uint32
+	pwmPins map[int]*pca9685PwmPin
+	mutex   gobot.Mutex
+}
+
+// WithPCA9685OutputEnablePin option sets the pin the active low OE input of
+// the PCA9685 is connected to. The outputs are enabled by Start and blanked
+// by Halt, see also EnableOutputs and DisableOutputs.
+func WithPCA9685OutputEnablePin(writer DigitalWriter, pin string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*PCA9685Driver)
+		if ok {
+			d.oeWriter, d.oePin = writer, pin
+		} else {
+			panic("trying to set output enable pin for non-PCA9685Driver")
+		}
+	}
 }
 
 // NewPCA9685Driver creates a new driver with specified i2c interface
@@ -52,39 +88,53 @@ type PCA9685Driver struct {
 // Optional params:
 //		i2c.WithBus(int):	bus to use with this driver
 //		i2c.WithAddress(int):	address to use with this driver
+//		i2c.WithPCA9685OutputEnablePin(DigitalWriter, string):	pin connected to OE
 //
 func NewPCA9685Driver(a Connector, options ...func(Config)) *PCA9685Driver {
 	p := &PCA9685Driver{
-		name:      gobot.DefaultName("PCA9685"),
-		connector: a,
-		Config:    NewConfig(),
-		Commander: gobot.NewCommander(),
+		name:           gobot.DefaultName("PCA9685"),
+		connector:      a,
+		Config:         NewConfig(),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		period:         pca9685DefaultPeriod,
+		pwmPins:        make(map[int]*pca9685PwmPin),
 	}
 
 	for _, option := range options {
 		option(p)
 	}
 
+	pinSchema := gobot.CommandSchema{"pin": gobot.CommandParamString, "val": gobot.CommandParamString}
+
 	p.AddCommand("PwmWrite", func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(string)
 		val, _ := strconv.Atoi(params["val"].(string))
 		return p.PwmWrite(pin, byte(val))
 	})
+	p.AddCommandSchema("PwmWrite", pinSchema)
 	p.AddCommand("ServoWrite", func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(string)
 		val, _ := strconv.Atoi(params["val"].(string))
 		return p.ServoWrite(pin, byte(val))
 	})
+	p.AddCommandSchema("ServoWrite", pinSchema)
 	p.AddCommand("SetPWM", func(params map[string]interface{}) interface{} {
 		channel, _ := strconv.Atoi(params["channel"].(string))
 		on, _ := strconv.Atoi(params["on"].(string))
 		off, _ := strconv.Atoi(params["off"].(string))
 		return p.SetPWM(channel, uint16(on), uint16(off))
 	})
+	p.AddCommandSchema("SetPWM", gobot.CommandSchema{
+		"channel": gobot.CommandParamString,
+		"on":      gobot.CommandParamString,
+		"off":     gobot.CommandParamString,
+	})
 	p.AddCommand("SetPWMFreq", func(params map[string]interface{}) interface{} {
 		freq, _ := strconv.ParseFloat(params["freq"].(string), 32)
 		return p.SetPWMFreq(float32(freq))
 	})
+	p.AddCommandSchema("SetPWMFreq", gobot.CommandSchema{"freq": gobot.CommandParamString})
 
 	return p
 }
@@ -98,6 +148,9 @@ func (p *PCA9685Driver) SetName(n string) { p.name = n }
 // Connection returns the connection for the Driver
 func (p *PCA9685Driver) Connection() gobot.Connection { return p.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (p *PCA9685Driver) Contentions() uint64 { return p.mutex.Contentions() }
+
 // Start initializes the pca9685
 func (p *PCA9685Driver) Start() (err error) {
 	bus := p.GetBusOrDefault(p.connector.GetDefaultBus())
@@ -137,13 +190,43 @@ func (p *PCA9685Driver) Start() (err error) {
 
 	time.Sleep(5 * time.Millisecond)
 
+	if p.oeWriter != nil {
+		return p.EnableOutputs()
+	}
+
 	return
 }
 
 // Halt stops the device
 func (p *PCA9685Driver) Halt() (err error) {
-	_, err = p.connection.Write([]byte{PCA9685_ALLLED_OFF_H, 0x10})
-	return
+	if err = p.SetAllFullOff(); err != nil {
+		return
+	}
+
+	if p.oeWriter != nil {
+		if err = p.DisableOutputs(); err != nil {
+			return
+		}
+	}
+
+	return closeConnection(&p.connection)
+}
+
+// EnableOutputs enables the outputs by the OE pin
+func (p *PCA9685Driver) EnableOutputs() error {
+	if p.oeWriter == nil {
+		return ErrNoOutputEnablePin
+	}
+	return p.oeWriter.DigitalWrite(p.oePin, 0)
+}
+
+// DisableOutputs blanks the outputs by the OE pin, the channels keep their
+// settings
+func (p *PCA9685Driver) DisableOutputs() error {
+	if p.oeWriter == nil {
+		return ErrNoOutputEnablePin
+	}
+	return p.oeWriter.DigitalWrite(p.oePin, 1)
 }
 
 // SetPWM sets a specific channel to a pwm value from 0-4095.
@@ -201,6 +284,26 @@ func (p *PCA9685Driver) SetAllPWM(on uint16, off uint16) (err error) {
 	return
 }
 
+// SetFullOn sets a specific channel to be always on, without PWM
+func (p *PCA9685Driver) SetFullOn(channel int) (err error) {
+	return p.setFullOn(byte(PCA9685_LED0_ON_H+4*channel), byte(PCA9685_LED0_OFF_H+4*channel))
+}
+
+// SetFullOff sets a specific channel to be always off, without PWM
+func (p *PCA9685Driver) SetFullOff(channel int) (err error) {
+	return p.setFullOff(byte(PCA9685_LED0_OFF_H + 4*channel))
+}
+
+// SetAllFullOn sets all channels to be always on, without PWM
+func (p *PCA9685Driver) SetAllFullOn() (err error) {
+	return p.setFullOn(PCA9685_ALLLED_ON_H, PCA9685_ALLLED_OFF_H)
+}
+
+// SetAllFullOff sets all channels to be always off, without PWM
+func (p *PCA9685Driver) SetAllFullOff() (err error) {
+	return p.setFullOff(PCA9685_ALLLED_OFF_H)
+}
+
 // SetPWMFreq sets the PWM frequency in Hz
 func (p *PCA9685Driver) SetPWMFreq(freq float32) error {
 	// IC oscillator frequency is 25 MHz
@@ -213,41 +316,52 @@ func (p *PCA9685Driver) SetPWMFreq(freq float32) error {
 	// Round value to nearest whole
 	prescale := byte(prescalevel + 0.5)
 
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1)}); err != nil {
-		return err
-	}
-	oldmode, err := p.connection.ReadByte()
+	oldmode, err := p.readMode1()
 	if err != nil {
 		return err
 	}
 
-	// Put oscillator in sleep mode, clear bit 7 here to avoid overwriting
-	// previous setting
-	newmode := (oldmode & 0x7F) | 0x10
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(newmode)}); err != nil {
+	// The prescaler can only be written in sleep mode, writing 0 to the
+	// restart bit has no effect
+	newmode := (oldmode &^ PCA9685_RESTART) | PCA9685_SLEEP
+	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), newmode}); err != nil {
 		return err
 	}
 	// Write prescaler value
 	if _, err := p.connection.Write([]byte{byte(PCA9685_PRESCALE), prescale}); err != nil {
 		return err
 	}
-	// Put back to old settings
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode)}); err != nil {
+	// Wake up with the old settings
+	wakemode := oldmode &^ (PCA9685_RESTART | PCA9685_SLEEP)
+	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), wakemode}); err != nil {
 		return err
 	}
 
+	// The oscillator needs 500us to stabilize
 	time.Sleep(5 * time.Millisecond)
 
-	// Enable response to All Call address, enable auto-increment, clear restart
-	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), byte(oldmode | 0x80)}); err != nil {
+	p.mutex.Lock()
+	p.period = uint32(1e9 / freq)
+	p.mutex.Unlock()
+
+	// The restart bit is set, if PWM channels were active before the sleep,
+	// writing 1 restarts them
+	mode, err := p.readMode1()
+	if err != nil {
 		return err
 	}
+	if mode&PCA9685_RESTART != 0 {
+		if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1), wakemode | PCA9685_RESTART}); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 // PwmWrite writes a PWM signal to the specified channel aka "pin".
 // Value values are from 0-255, to conform to the PwmWriter interface.
+// 0 and 255 set the channel to full off and full on.
 // If you need finer control, please look at SetPWM().
 //
 func (p *PCA9685Driver) PwmWrite(pin string, val byte) (err error) {
@@ -255,10 +369,29 @@ func (p *PCA9685Driver) PwmWrite(pin string, val byte) (err error) {
 	if err != nil {
 		return
 	}
+	switch val {
+	case 0:
+		return p.SetFullOff(i)
+	case 255:
+		return p.SetFullOn(i)
+	}
 	v := gobot.ToScale(gobot.FromScale(float64(val), 0, 255), 0, 4095)
 	return p.SetPWM(i, 0, uint16(v))
 }
 
+// DigitalWrite sets the specified channel aka "pin" full on for values other
+// than 0, else full off, to conform to the DigitalWriter interface.
+func (p *PCA9685Driver) DigitalWrite(pin string, val byte) (err error) {
+	i, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+	if val == 0 {
+		return p.SetFullOff(i)
+	}
+	return p.SetFullOn(i)
+}
+
 // ServoWrite writes a servo signal to the specified channel aka "pin".
 // Valid values are from 0-180, to conform to the ServoWriter interface.
 // If you need finer control, please look at SetPWM().
@@ -271,3 +404,142 @@ func (p *PCA9685Driver) ServoWrite(pin string, val byte) (err error) {
 	v := gobot.ToScale(gobot.FromScale(float64(val), 0, 180), 200, 500)
 	return p.SetPWM(i, 0, uint16(v))
 }
+
+// setFullOn sets the full ON bit before clearing the full OFF bit, so there
+// is no PWM in between
+func (p *PCA9685Driver) setFullOn(onH byte, offH byte) (err error) {
+	if _, err = p.connection.Write([]byte{onH, PCA9685_FULL}); err != nil {
+		return
+	}
+	_, err = p.connection.Write([]byte{offH, 0x00})
+	return
+}
+
+// setFullOff sets the full OFF bit, which has precedence over all other
+// settings
+func (p *PCA9685Driver) setFullOff(offH byte) (err error) {
+	_, err = p.connection.Write([]byte{offH, PCA9685_FULL})
+	return
+}
+
+func (p *PCA9685Driver) readMode1() (byte, error) {
+	if _, err := p.connection.Write([]byte{byte(PCA9685_MODE1)}); err != nil {
+		return 0, err
+	}
+	return p.connection.ReadByte()
+}
+
+// PWMPin returns the channel "0".."15" as a PWM pin, so the gpio drivers like
+// the ServoDriver and the LedDriver can use the channels of the PCA9685. The
+// period is shared by all channels, setting it for one pin sets the PWM
+// frequency of all channels.
+func (p *PCA9685Driver) PWMPin(pin string) (gobot.PWMPinner, error) {
+	channel, err := strconv.Atoi(pin)
+	if err != nil || channel < 0 || channel > 15 {
+		return nil, fmt.Errorf("Invalid PCA9685 channel %s", pin)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pwmPin, ok := p.pwmPins[channel]
+	if !ok {
+		pwmPin = &pca9685PwmPin{driver: p, channel: channel, enabled: true}
+		p.pwmPins[channel] = pwmPin
+	}
+	return pwmPin, nil
+}
+
+// pca9685PwmPin is a channel of the PCA9685 as gobot.PWMPinner
+type pca9685PwmPin struct {
+	driver   *PCA9685Driver
+	channel  int
+	enabled  bool
+	inverted bool
+	duty     uint32
+	mutex    sync.Mutex
+}
+
+func (p *pca9685PwmPin) Export() error   { return nil }
+func (p *pca9685PwmPin) Unexport() error { return nil }
+
+// Enable sets the channel full off when disabled and writes the duty cycle
+// again when enabled
+func (p *pca9685PwmPin) Enable(enable bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.enabled = enable
+	return p.write()
+}
+
+func (p *pca9685PwmPin) Polarity() (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.inverted {
+		return "inverted", nil
+	}
+	return "normal", nil
+}
+
+// InvertPolarity inverts the duty cycle of the channel, the INVRT bit of the
+// PCA9685 would invert all channels
+func (p *pca9685PwmPin) InvertPolarity(invert bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.inverted = invert
+	return p.write()
+}
+
+func (p *pca9685PwmPin) Period() (uint32, error) {
+	p.driver.mutex.Lock()
+	defer p.driver.mutex.Unlock()
+
+	return p.driver.period, nil
+}
+
+// SetPeriod sets the PWM frequency of the PCA9685, it applies to all channels
+func (p *pca9685PwmPin) SetPeriod(period uint32) error {
+	if period == 0 {
+		return errors.New("PCA9685 period must be greater than 0")
+	}
+	return p.driver.SetPWMFreq(float32(1e9 / float64(period)))
+}
+
+func (p *pca9685PwmPin) DutyCycle() (uint32, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.duty, nil
+}
+
+func (p *pca9685PwmPin) SetDutyCycle(duty uint32) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.duty = duty
+	return p.write()
+}
+
+// write writes the duty cycle in 1/4096 of the period to the channel
+func (p *pca9685PwmPin) write() error {
+	period, _ := p.Period()
+	duty := p.duty
+	if duty > period {
+		duty = period
+	}
+	if p.inverted {
+		duty = period - duty
+	}
+
+	switch {
+	case !p.enabled || duty == 0:
+		return p.driver.SetFullOff(p.channel)
+	case duty == period:
+		return p.driver.SetFullOn(p.channel)
+	}
+	off := uint16(uint64(duty) * 4096 / uint64(period))
+	return p.driver.SetPWM(p.channel, 0, off)
+}
@@ -16,6 +16,10 @@ var _ gobot.Driver = (*PCA9685Driver)(nil)
 // and also the PwmWriter and ServoWriter interfaces
 var _ gpio.PwmWriter = (*PCA9685Driver)(nil)
 var _ gpio.ServoWriter = (*PCA9685Driver)(nil)
+var _ gpio.DigitalWriter = (*PCA9685Driver)(nil)
+
+// and provides its channels as PWM pins
+var _ gobot.PWMPinnerProvider = (*PCA9685Driver)(nil)
 
 // --------- HELPERS
 func initTestPCA9685Driver() (driver *PCA9685Driver) {
@@ -28,6 +32,16 @@ func initTestPCA9685DriverWithStubbedAdaptor() (*PCA9685Driver, *i2cTestAdaptor)
 	return NewPCA9685Driver(adaptor), adaptor
 }
 
+func initTestStartedPCA9685Driver(t *testing.T) (*PCA9685Driver, *i2cTestAdaptor) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01})
+		return 1, nil
+	}
+	gobottest.Assert(t, pca.Start(), nil)
+	return pca, adaptor
+}
+
 // --------- TESTS
 
 func TestNewPCA9685Driver(t *testing.T) {
@@ -176,3 +190,162 @@ func TestPCA9685DriverCommands(t *testing.T) {
 	err = pca.Command("SetPWMFreq")(map[string]interface{}{"freq": "60"})
 	gobottest.Assert(t, err, nil)
 }
+
+func TestPCA9685DriverSetFull(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01})
+		return 1, nil
+	}
+	gobottest.Assert(t, pca.Start(), nil)
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pca.SetFullOn(1), nil)
+	gobottest.Assert(t, pca.SetFullOff(1), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_LED0_ON_H + 4, PCA9685_FULL, PCA9685_LED0_OFF_H + 4, 0x00,
+		PCA9685_LED0_OFF_H + 4, PCA9685_FULL,
+	})
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pca.SetAllFullOn(), nil)
+	gobottest.Assert(t, pca.SetAllFullOff(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_ALLLED_ON_H, PCA9685_FULL, PCA9685_ALLLED_OFF_H, 0x00,
+		PCA9685_ALLLED_OFF_H, PCA9685_FULL,
+	})
+
+	// PwmWrite uses the full bits for 0% and 100%
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pca.PwmWrite("2", 255), nil)
+	gobottest.Assert(t, pca.PwmWrite("2", 0), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_LED0_ON_H + 8, PCA9685_FULL, PCA9685_LED0_OFF_H + 8, 0x00,
+		PCA9685_LED0_OFF_H + 8, PCA9685_FULL,
+	})
+}
+
+func TestPCA9685DriverOutputEnablePin(t *testing.T) {
+	board := gobottest.NewBoard()
+	adaptor := newI2cTestAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01})
+		return 1, nil
+	}
+	pca := NewPCA9685Driver(adaptor, WithPCA9685OutputEnablePin(board, "11"))
+
+	gobottest.Assert(t, pca.Start(), nil)
+	gobottest.Assert(t, pca.DisableOutputs(), nil)
+	gobottest.Assert(t, pca.EnableOutputs(), nil)
+	gobottest.Assert(t, pca.Halt(), nil)
+	gobottest.Assert(t, board.Writes("11"), []int{0, 1, 0, 1})
+
+	pca = initTestPCA9685Driver()
+	gobottest.Assert(t, pca.EnableOutputs(), ErrNoOutputEnablePin)
+	gobottest.Assert(t, pca.DisableOutputs(), ErrNoOutputEnablePin)
+}
+
+func TestPCA9685DriverSetPWMFreqRestart(t *testing.T) {
+	pca, adaptor := initTestPCA9685DriverWithStubbedAdaptor()
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x01})
+		return 1, nil
+	}
+	gobottest.Assert(t, pca.Start(), nil)
+
+	// the restart bit is set by the device, when the PWM was active
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{PCA9685_RESTART | PCA9685_ALLCALL})
+		return 1, nil
+	}
+	gobottest.Assert(t, pca.SetPWMFreq(60), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_MODE1,
+		PCA9685_MODE1, PCA9685_SLEEP | PCA9685_ALLCALL,
+		PCA9685_PRESCALE, 101,
+		PCA9685_MODE1, PCA9685_ALLCALL,
+		PCA9685_MODE1,
+		PCA9685_MODE1, PCA9685_RESTART | PCA9685_ALLCALL,
+	})
+
+	// no restart, when the PWM was not active
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{PCA9685_ALLCALL})
+		return 1, nil
+	}
+	gobottest.Assert(t, pca.SetPWMFreq(60), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_MODE1,
+		PCA9685_MODE1, PCA9685_SLEEP | PCA9685_ALLCALL,
+		PCA9685_PRESCALE, 101,
+		PCA9685_MODE1, PCA9685_ALLCALL,
+		PCA9685_MODE1,
+	})
+}
+
+func TestPCA9685DriverDigitalWrite(t *testing.T) {
+	pca, adaptor := initTestStartedPCA9685Driver(t)
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pca.DigitalWrite("1", 1), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_LED0_ON_H + 4, PCA9685_FULL, PCA9685_LED0_OFF_H + 4, 0x00,
+	})
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pca.DigitalWrite("1", 0), nil)
+	gobottest.Assert(t, adaptor.written, []byte{PCA9685_LED0_OFF_H + 4, PCA9685_FULL})
+}
+
+func TestPCA9685DriverPWMPin(t *testing.T) {
+	pca, adaptor := initTestStartedPCA9685Driver(t)
+
+	_, err := pca.PWMPin("16")
+	gobottest.Assert(t, err, errors.New("Invalid PCA9685 channel 16"))
+
+	pin, err := pca.PWMPin("2")
+	gobottest.Assert(t, err, nil)
+	period, _ := pin.Period()
+	gobottest.Assert(t, period, uint32(pca9685DefaultPeriod))
+
+	// half of the period
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pin.SetDutyCycle(pca9685DefaultPeriod/2), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		PCA9685_LED0_ON_L + 8, 0x00, PCA9685_LED0_ON_H + 8, 0x00,
+		PCA9685_LED0_OFF_L + 8, 0x00, PCA9685_LED0_OFF_H + 8, 0x08,
+	})
+	duty, _ := pin.DutyCycle()
+	gobottest.Assert(t, duty, uint32(pca9685DefaultPeriod/2))
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pin.Enable(false), nil)
+	gobottest.Assert(t, adaptor.written, []byte{PCA9685_LED0_OFF_H + 8, PCA9685_FULL})
+
+	// the inverted duty cycle of 0 is full on
+	gobottest.Assert(t, pin.SetDutyCycle(0), nil)
+	adaptor.written = []byte{}
+	gobottest.Assert(t, pin.Enable(true), nil)
+	gobottest.Assert(t, pin.InvertPolarity(true), nil)
+	polarity, _ := pin.Polarity()
+	gobottest.Assert(t, polarity, "inverted")
+	gobottest.Assert(t, adaptor.written[len(adaptor.written)-4:], []byte{
+		PCA9685_LED0_ON_H + 8, PCA9685_FULL, PCA9685_LED0_OFF_H + 8, 0x00,
+	})
+}
+
+func TestPCA9685DriverServoDriver(t *testing.T) {
+	pca, _ := initTestStartedPCA9685Driver(t)
+
+	servo := gpio.NewServoDriver(pca, "3")
+	gobottest.Assert(t, servo.Start(), nil)
+	gobottest.Assert(t, servo.Move(90), nil)
+
+	pin, _ := pca.PWMPin("3")
+	period, _ := pin.Period()
+	gobottest.Assert(t, period, uint32(20000000))
+	duty, _ := pin.DutyCycle()
+	gobottest.Assert(t, duty, uint32(1500000))
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/sigurn/crc8"
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
 )
 
 const (
@@ -135,7 +136,9 @@ func (d *SHT2xDriver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (d *SHT2xDriver) Halt() (err error) { return }
+func (d *SHT2xDriver) Halt() (err error) {
+	return closeConnection(&d.connection)
+}
 
 func (d *SHT2xDriver) Accuracy() byte { return d.accuracy }
 
@@ -143,7 +146,7 @@ func (d *SHT2xDriver) Accuracy() byte { return d.accuracy }
 func (d *SHT2xDriver) SetAccuracy(acc byte) (err error) {
 	d.accuracy = acc
 
-	if d.connection != nil {
+	if connected(d.connection) {
 		err = d.sendAccuracy()
 	}
 
@@ -189,6 +192,22 @@ func (d *SHT2xDriver) Humidity() (humidity float32, err error) {
 	return
 }
 
+// Thermometer returns the driver as sensor.Thermometer
+func (d *SHT2xDriver) Thermometer() sensor.Thermometer {
+	return sensor.ThermometerFunc(func() (float64, error) {
+		temp, err := d.Temperature()
+		return float64(temp), err
+	})
+}
+
+// Hygrometer returns the driver as sensor.Hygrometer
+func (d *SHT2xDriver) Hygrometer() sensor.Hygrometer {
+	return sensor.HygrometerFunc(func() (float64, error) {
+		humidity, err := d.Humidity()
+		return float64(humidity), err
+	})
+}
+
 // sendCommandDelayGetResponse is a helper function to reduce duplicated code
 func (d *SHT2xDriver) readSensor(cmd byte) (read uint16, err error) {
 	if err = d.connection.WriteByte(cmd); err != nil {
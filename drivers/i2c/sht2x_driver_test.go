@@ -86,6 +86,12 @@ func TestSHT2xDriverMeasurements(t *testing.T) {
 	hum, err := sht2x.Humidity()
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, hum, float32(40.279907))
+	celsius, err := sht2x.Thermometer().Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, celsius, float64(temp))
+	percent, err := sht2x.Hygrometer().Humidity()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, percent, float64(hum))
 }
 
 func TestSHT2xDriverAccuracy(t *testing.T) {
@@ -44,6 +44,73 @@ const SHT3xAccuracyMedium = 0x0b
 // SHT3xAccuracyHigh is the high accuracy and slowest sample setting
 const SHT3xAccuracyHigh = 0x00
 
+// SHT3xMPS05 is the periodic mode with 0.5 measurements per second
+const SHT3xMPS05 = 0x20
+
+// SHT3xMPS1 is the periodic mode with 1 measurement per second
+const SHT3xMPS1 = 0x21
+
+// SHT3xMPS2 is the periodic mode with 2 measurements per second
+const SHT3xMPS2 = 0x22
+
+// SHT3xMPS4 is the periodic mode with 4 measurements per second
+const SHT3xMPS4 = 0x23
+
+// SHT3xMPS10 is the periodic mode with 10 measurements per second
+const SHT3xMPS10 = 0x27
+
+// SHT3xAlertHighSet is the limit, above which the ALERT pin is set
+const SHT3xAlertHighSet = 0x1d
+
+// SHT3xAlertHighClear is the limit, below which the ALERT pin is cleared
+const SHT3xAlertHighClear = 0x16
+
+// SHT3xAlertLowClear is the limit, above which the ALERT pin is cleared
+const SHT3xAlertLowClear = 0x0b
+
+// SHT3xAlertLowSet is the limit, below which the ALERT pin is set
+const SHT3xAlertLowSet = 0x00
+
+// the second byte of the periodic mode commands for the accuracies, which
+// are the repeatabilities of the measurement
+var sht3xPeriodicRepeatability = map[byte]map[byte]byte{
+	SHT3xMPS05: {SHT3xAccuracyHigh: 0x32, SHT3xAccuracyMedium: 0x24, SHT3xAccuracyLow: 0x2f},
+	SHT3xMPS1:  {SHT3xAccuracyHigh: 0x30, SHT3xAccuracyMedium: 0x26, SHT3xAccuracyLow: 0x2d},
+	SHT3xMPS2:  {SHT3xAccuracyHigh: 0x36, SHT3xAccuracyMedium: 0x20, SHT3xAccuracyLow: 0x2b},
+	SHT3xMPS4:  {SHT3xAccuracyHigh: 0x34, SHT3xAccuracyMedium: 0x22, SHT3xAccuracyLow: 0x29},
+	SHT3xMPS10: {SHT3xAccuracyHigh: 0x37, SHT3xAccuracyMedium: 0x21, SHT3xAccuracyLow: 0x2a},
+}
+
+// the second byte of the read commands of the alert limits
+var sht3xAlertLimitRead = map[byte]byte{
+	SHT3xAlertHighSet:   0x1f,
+	SHT3xAlertHighClear: 0x14,
+	SHT3xAlertLowClear:  0x09,
+	SHT3xAlertLowSet:    0x02,
+}
+
+// SHT3xStatus is the content of the status register
+type SHT3xStatus struct {
+	// AlertPending is true, if at least one alert is pending
+	AlertPending bool
+	Heater       bool
+	// HumidityAlert and TemperatureAlert are true, if the value is
+	// outside of the alert limits
+	HumidityAlert    bool
+	TemperatureAlert bool
+	// Reset is true, if a reset was detected since the last ClearStatus
+	Reset bool
+	// CommandFailed is true, if the last command was not processed
+	CommandFailed bool
+	// ChecksumFailed is true, if the checksum of the last write failed
+	ChecksumFailed bool
+}
+
+var (
+	ErrInvalidMPS   = errors.New("Invalid measurements per second")
+	ErrInvalidLimit = errors.New("Invalid alert limit")
+)
+
 var (
 	crc8Params         = crc8.Params{0x31, 0xff, false, false, 0x00, 0xf7, "CRC-8/SENSIRON"}
 	ErrInvalidAccuracy = errors.New("Invalid accuracy")
@@ -110,7 +177,9 @@ func (s *SHT3xDriver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (s *SHT3xDriver) Halt() (err error) { return }
+func (s *SHT3xDriver) Halt() (err error) {
+	return closeConnection(&s.connection)
+}
 
 // SetAddress sets the address of the device
 func (s *SHT3xDriver) SetAddress(address int) { s.sht3xAddress = address }
@@ -175,21 +244,123 @@ func (s *SHT3xDriver) Sample() (temp float32, rh float32, err error) {
 		return
 	}
 
+	return s.convert(ret[0], ret[1])
+}
+
+// StartPeriodic starts the periodic data acquisition with the given
+// measurements per second and the accuracy as repeatability. The samples
+// are read by FetchSample, until StopPeriodic is called.
+func (s *SHT3xDriver) StartPeriodic(mps byte) (err error) {
+	repeatability, ok := sht3xPeriodicRepeatability[mps]
+	if !ok {
+		return ErrInvalidMPS
+	}
+	_, err = s.connection.Write([]byte{mps, repeatability[s.accuracy]})
+	return
+}
+
+// StartART starts the periodic data acquisition with the accelerated
+// response time, 4 measurements per second.
+func (s *SHT3xDriver) StartART() (err error) {
+	_, err = s.connection.Write([]byte{0x2b, 0x32})
+	return
+}
+
+// StopPeriodic stops the periodic data acquisition
+func (s *SHT3xDriver) StopPeriodic() (err error) {
+	_, err = s.connection.Write([]byte{0x30, 0x93})
+	return
+}
+
+// FetchSample returns the latest sample of the periodic data acquisition.
+// The read fails, if there is no new sample since the last fetch.
+func (s *SHT3xDriver) FetchSample() (temp float32, rh float32, err error) {
+	ret, err := s.sendCommandDelayGetResponse([]byte{0xe0, 0x00}, nil, 2)
+	if nil != err {
+		return
+	}
+
+	return s.convert(ret[0], ret[1])
+}
+
+// SetAlertLimit programs one of the alert limit registers with the
+// temperature in the Units and the relative humidity. Only the 9 most
+// significant bits of the temperature and the 7 of the humidity are stored.
+func (s *SHT3xDriver) SetAlertLimit(limit byte, temp float32, rh float32) (err error) {
+	if _, ok := sht3xAlertLimitRead[limit]; !ok {
+		return ErrInvalidLimit
+	}
+
+	tempSample, err := s.temperatureSample(temp)
+	if err != nil {
+		return
+	}
+	rhSample := humiditySample(rh)
+
+	word := (rhSample & 0xfe00) | (tempSample >> 7)
+	data := []byte{byte(word >> 8), byte(word)}
+	crc := crc8.Checksum(data, s.crcTable)
+	_, err = s.connection.Write([]byte{0x61, limit, data[0], data[1], crc})
+	return
+}
+
+// AlertLimit returns the temperature in the Units and the relative
+// humidity of one of the alert limit registers
+func (s *SHT3xDriver) AlertLimit(limit byte) (temp float32, rh float32, err error) {
+	if _, ok := sht3xAlertLimitRead[limit]; !ok {
+		err = ErrInvalidLimit
+		return
+	}
+
+	ret, err := s.sendCommandDelayGetResponse([]byte{0xe1, sht3xAlertLimitRead[limit]}, nil, 1)
+	if nil != err {
+		return
+	}
+
+	return s.convert((ret[0]&0x01ff)<<7, ret[0]&0xfe00)
+}
+
+// Status returns the status register of the device
+func (s *SHT3xDriver) Status() (status SHT3xStatus, err error) {
+	sr, err := s.getStatusRegister()
+	if err != nil {
+		return
+	}
+
+	status = SHT3xStatus{
+		AlertPending:     sr&(1<<15) != 0,
+		Heater:           sr&(1<<13) != 0,
+		HumidityAlert:    sr&(1<<11) != 0,
+		TemperatureAlert: sr&(1<<10) != 0,
+		Reset:            sr&(1<<4) != 0,
+		CommandFailed:    sr&(1<<1) != 0,
+		ChecksumFailed:   sr&(1<<0) != 0,
+	}
+	return
+}
+
+// ClearStatus clears the alert and reset flags of the status register
+func (s *SHT3xDriver) ClearStatus() (err error) {
+	_, err = s.connection.Write([]byte{0x30, 0x41})
+	return
+}
+
+// convert returns the temperature in the Units and the relative humidity
+// of the samples
+func (s *SHT3xDriver) convert(tempSample uint16, rhSample uint16) (temp float32, rh float32, err error) {
 	// From the datasheet:
 	// RH = 100 * Srh / (2^16 - 1)
-	rhSample := uint64(ret[1])
-	rh = float32((uint64(1000000)*rhSample)/uint64(0xffff)) / 10000.0
+	rh = float32((uint64(1000000)*uint64(rhSample))/uint64(0xffff)) / 10000.0
 
-	tempSample := uint64(ret[0])
 	switch s.Units {
 	case "C":
 		// From the datasheet:
 		// T[C] = -45 + 175 * (St / (2^16 - 1))
-		temp = float32((uint64(1750000)*tempSample)/uint64(0xffff)-uint64(450000)) / 10000.0
+		temp = float32((int64(1750000)*int64(tempSample))/int64(0xffff)-int64(450000)) / 10000.0
 	case "F":
 		// From the datasheet:
 		// T[F] = -49 + 315 * (St / (2^16 - 1))
-		temp = float32((uint64(3150000)*tempSample)/uint64(0xffff)-uint64(490000)) / 10000.0
+		temp = float32((int64(3150000)*int64(tempSample))/int64(0xffff)-int64(490000)) / 10000.0
 	default:
 		err = ErrInvalidTemp
 	}
@@ -197,6 +368,36 @@ func (s *SHT3xDriver) Sample() (temp float32, rh float32, err error) {
 	return
 }
 
+// temperatureSample returns the sample of the temperature in the Units
+func (s *SHT3xDriver) temperatureSample(temp float32) (uint16, error) {
+	var st float32
+	switch s.Units {
+	case "C":
+		st = (temp + 45) / 175
+	case "F":
+		st = (temp + 49) / 315
+	default:
+		return 0, ErrInvalidTemp
+	}
+	return sht3xSample(st), nil
+}
+
+// humiditySample returns the sample of the relative humidity
+func humiditySample(rh float32) uint16 {
+	return sht3xSample(rh / 100)
+}
+
+// sht3xSample returns the sample of the ratio to the full range
+func sht3xSample(ratio float32) uint16 {
+	if ratio <= 0 {
+		return 0
+	}
+	if ratio >= 1 {
+		return 0xffff
+	}
+	return uint16(ratio*0xffff + 0.5)
+}
+
 // getStatusRegister returns the device status register
 func (s *SHT3xDriver) getStatusRegister() (status uint16, err error) {
 	ret, err := s.sendCommandDelayGetResponse([]byte{0xf3, 0x2d}, nil, 1)
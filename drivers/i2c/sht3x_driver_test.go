@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sigurn/crc8"
 	"gobot.io/x/gobot"
 	"gobot.io/x/gobot/gobottest"
 )
@@ -270,3 +271,92 @@ func TestSHT3xDriverSerialNumber(t *testing.T) {
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, sn, uint32(0x2000beef))
 }
+
+func TestSHT3xDriverPeriodic(t *testing.T) {
+	sht3x, adaptor := initTestSHT3xDriverWithStubbedAdaptor()
+	gobottest.Assert(t, sht3x.Start(), nil)
+
+	sht3x.SetAccuracy(SHT3xAccuracyMedium)
+	gobottest.Assert(t, sht3x.StartPeriodic(SHT3xMPS10), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x27, 0x21})
+	gobottest.Assert(t, sht3x.StartPeriodic(0x24), ErrInvalidMPS)
+
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xbe, 0xef, 0x92, 0xbe, 0xef, 0x92})
+		return 6, nil
+	}
+	temp, rh, err := sht3x.FetchSample()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, adaptor.written, []byte{0xe0, 0x00})
+	gobottest.Assert(t, temp, float32(85.523003))
+	gobottest.Assert(t, rh, float32(74.5845))
+
+	// no new sample, the device does not acknowledge the read
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		return 0, errors.New("read error")
+	}
+	_, _, err = sht3x.FetchSample()
+	gobottest.Assert(t, err, errors.New("read error"))
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, sht3x.StartART(), nil)
+	gobottest.Assert(t, sht3x.StopPeriodic(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x2b, 0x32, 0x30, 0x93})
+}
+
+func TestSHT3xDriverAlertLimit(t *testing.T) {
+	sht3x, adaptor := initTestSHT3xDriverWithStubbedAdaptor()
+	gobottest.Assert(t, sht3x.Start(), nil)
+
+	// the default limit of the datasheet, 60C and 80%
+	gobottest.Assert(t, sht3x.SetAlertLimit(SHT3xAlertHighSet, 60, 80), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x61, 0x1d, 0xcd, 0x33, 0xfd})
+	gobottest.Assert(t, sht3x.SetAlertLimit(0x01, 60, 80), ErrInvalidLimit)
+
+	adaptor.written = []byte{}
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0xcd, 0x33, 0xfd})
+		return 3, nil
+	}
+	temp, rh, err := sht3x.AlertLimit(SHT3xAlertHighClear)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, adaptor.written, []byte{0xe1, 0x14})
+	gobottest.Assert(t, temp, float32(59.9332))
+	gobottest.Assert(t, rh, float32(79.6887))
+
+	// below 0C
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x00, 0x20})
+		b[2] = crc8.Checksum(b[:2], sht3x.crcTable)
+		return 3, nil
+	}
+	temp, _, err = sht3x.AlertLimit(SHT3xAlertLowSet)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, float32(-34.0624))
+
+	_, _, err = sht3x.AlertLimit(0x01)
+	gobottest.Assert(t, err, ErrInvalidLimit)
+
+	sht3x.Units = "K"
+	gobottest.Assert(t, sht3x.SetAlertLimit(SHT3xAlertLowSet, 0, 0), ErrInvalidTemp)
+}
+
+func TestSHT3xDriverStatus(t *testing.T) {
+	sht3x, adaptor := initTestSHT3xDriverWithStubbedAdaptor()
+	gobottest.Assert(t, sht3x.Start(), nil)
+
+	// alert pending by the temperature
+	adaptor.i2cReadImpl = func(b []byte) (int, error) {
+		copy(b, []byte{0x84, 0x00, 0x00})
+		b[2] = crc8.Checksum(b[:2], sht3x.crcTable)
+		return 3, nil
+	}
+	status, err := sht3x.Status()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, status, SHT3xStatus{AlertPending: true, TemperatureAlert: true})
+
+	adaptor.written = []byte{}
+	gobottest.Assert(t, sht3x.ClearStatus(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x30, 0x41})
+}
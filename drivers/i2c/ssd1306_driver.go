@@ -184,12 +184,18 @@ type SSD1306Driver struct {
 	connection Connection
 	Config
 	gobot.Commander
+	gobot.CommandSchemer
 	initSequence  *SSD1306Init
 	displayWidth  int
 	displayHeight int
 	externalVCC   bool
 	pageSize      int
 	buffer        *DisplayBuffer
+	// the back buffer for Swap, created by BackBuffer
+	back *DisplayBuffer
+	// the content of the display RAM, nil if unknown
+	shown []byte
+	mutex gobot.Mutex
 }
 
 // NewSSD1306Driver creates a new SSD1306Driver.
@@ -206,13 +212,14 @@ type SSD1306Driver struct {
 //
 func NewSSD1306Driver(a Connector, options ...func(Config)) *SSD1306Driver {
 	s := &SSD1306Driver{
-		name:          gobot.DefaultName("SSD1306"),
-		Commander:     gobot.NewCommander(),
-		connector:     a,
-		Config:        NewConfig(),
-		displayHeight: ssd1306Height,
-		displayWidth:  ssd1306Width,
-		externalVCC:   ssd1306ExternalVCC,
+		name:           gobot.DefaultName("SSD1306"),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		connector:      a,
+		Config:         NewConfig(),
+		displayHeight:  ssd1306Height,
+		displayWidth:   ssd1306Width,
+		externalVCC:    ssd1306ExternalVCC,
 	}
 	// set options
 	for _, option := range options {
@@ -240,17 +247,23 @@ func NewSSD1306Driver(a Connector, options ...func(Config)) *SSD1306Driver {
 		return map[string]interface{}{}
 	})
 	s.AddCommand("SetContrast", func(params map[string]interface{}) interface{} {
-		contrast := byte(params["contrast"].(byte))
+		contrast := byte(gobot.CommandParamFloat(params["contrast"]))
 		err := s.SetContrast(contrast)
 		return map[string]interface{}{"err": err}
 	})
+	s.AddCommandSchema("SetContrast", gobot.CommandSchema{"contrast": gobot.CommandParamNumber})
 	s.AddCommand("Set", func(params map[string]interface{}) interface{} {
-		x := int(params["x"].(int))
-		y := int(params["y"].(int))
-		c := int(params["c"].(int))
+		x := int(gobot.CommandParamFloat(params["x"]))
+		y := int(gobot.CommandParamFloat(params["y"]))
+		c := int(gobot.CommandParamFloat(params["c"]))
 		s.Set(x, y, c)
 		return nil
 	})
+	s.AddCommandSchema("Set", gobot.CommandSchema{
+		"x": gobot.CommandParamNumber,
+		"y": gobot.CommandParamNumber,
+		"c": gobot.CommandParamNumber,
+	})
 	return s
 }
 
@@ -263,6 +276,9 @@ func (s *SSD1306Driver) SetName(n string) { s.name = n }
 // Connection returns the connection for the Driver.
 func (s *SSD1306Driver) Connection() gobot.Connection { return s.connector.(gobot.Connection) }
 
+// Contentions returns how often a caller had to wait for the driver
+func (s *SSD1306Driver) Contentions() uint64 { return s.mutex.Contentions() }
+
 // Start starts the Driver up, and writes start command
 func (s *SSD1306Driver) Start() (err error) {
 	// check device size for supported resolutions
@@ -298,7 +314,9 @@ func (s *SSD1306Driver) Start() (err error) {
 }
 
 // Halt returns true if device is halted successfully
-func (s *SSD1306Driver) Halt() (err error) { return nil }
+func (s *SSD1306Driver) Halt() (err error) {
+	return closeConnection(&s.connection)
+}
 
 // WithSSD1306DisplayWidth option sets the SSD1306Driver DisplayWidth option.
 func WithSSD1306DisplayWidth(val int) func(Config) {
@@ -346,6 +364,9 @@ func (s *SSD1306Driver) Init() (err error) {
 	if err = s.commands([]byte{ssd1306PageAddr, 0, (byte(s.buffer.height / s.pageSize)) - 1}); err != nil {
 		return err
 	}
+	s.mutex.Lock()
+	s.shown = nil
+	s.mutex.Unlock()
 	return nil
 }
 
@@ -387,10 +408,90 @@ func (s *SSD1306Driver) SetContrast(contrast byte) (err error) {
 	return
 }
 
-// Display sends the memory buffer to the display.
+// Display sends the memory buffer to the display. Only the rectangle of the
+// pages and columns, which changed since the last Display, is transmitted.
 func (s *SSD1306Driver) Display() (err error) {
-	_, err = s.connection.Write(append([]byte{0x40}, s.buffer.buffer...))
-	return err
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.display()
+}
+
+// BackBuffer returns the back buffer, which can be drawn while the display
+// shows the buffer. Swap shows the back buffer.
+func (s *SSD1306Driver) BackBuffer() *DisplayBuffer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.back == nil {
+		s.back = NewDisplayBuffer(s.displayWidth, s.displayHeight, s.pageSize)
+	}
+	return s.back
+}
+
+// Swap exchanges the buffer and the back buffer and displays the new buffer.
+// Afterwards the back buffer contains the previously displayed frame.
+func (s *SSD1306Driver) Swap() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.back == nil {
+		s.back = NewDisplayBuffer(s.displayWidth, s.displayHeight, s.pageSize)
+	}
+	s.buffer, s.back = s.back, s.buffer
+	return s.display()
+}
+
+// display sends the dirty rectangle of the buffer
+func (s *SSD1306Driver) display() (err error) {
+	buf := s.buffer.buffer
+	width := s.buffer.width
+	pages := s.buffer.height / s.pageSize
+	if len(buf) != width*pages {
+		// a buffer of another size was set, send it as it is
+		s.shown = nil
+		_, err = s.connection.Write(append([]byte{0x40}, buf...))
+		return err
+	}
+
+	x0, x1, p0, p1 := 0, width-1, 0, pages-1
+	if s.shown != nil {
+		x0, x1, p0, p1 = width, -1, pages, -1
+		for i := range buf {
+			if buf[i] == s.shown[i] {
+				continue
+			}
+			x, p := i%width, i/width
+			if x < x0 {
+				x0 = x
+			}
+			if x > x1 {
+				x1 = x
+			}
+			if p < p0 {
+				p0 = p
+			}
+			p1 = p
+		}
+		if x1 < 0 {
+			// nothing changed
+			return nil
+		}
+	}
+
+	// the address pointer wraps within the column and page window
+	if err = s.commands([]byte{ssd1306ColumnAddr, byte(x0), byte(x1), ssd1306PageAddr, byte(p0), byte(p1)}); err != nil {
+		return err
+	}
+	data := make([]byte, 0, 1+(x1-x0+1)*(p1-p0+1))
+	data = append(data, 0x40)
+	for p := p0; p <= p1; p++ {
+		data = append(data, buf[p*width+x0:p*width+x1+1]...)
+	}
+	if _, err = s.connection.Write(data); err != nil {
+		// the content of the display RAM is unknown
+		s.shown = nil
+		return err
+	}
+	s.shown = append(s.shown[:0], buf...)
+	return nil
 }
 
 // ShowImage takes a standard Go image and displays it in monochrome.
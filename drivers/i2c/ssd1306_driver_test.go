@@ -286,4 +286,81 @@ func TestSSD1306DriverCommandsSet(t *testing.T) {
 		"c": int(1),
 	})
 	gobottest.Assert(t, s.buffer.buffer[0], byte(1))
+
+	// numbers of a JSON request are decoded as float64
+	params := map[string]interface{}{"x": 1.0, "y": 0.0, "c": 1.0}
+	gobottest.Assert(t, len(s.CommandSchema("Set").Validate(params)), 0)
+	s.Command("Set")(params)
+	gobottest.Assert(t, s.buffer.buffer[1], byte(1))
+}
+
+func TestSSD1306DriverDisplayDirtyRectangle(t *testing.T) {
+	s, adaptor := initTestSSD1306DriverWithStubbedAdaptor(128, 64, false)
+	gobottest.Assert(t, s.Start(), nil)
+
+	// the first Display sends the whole buffer
+	adaptor.written = []byte{}
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, adaptor.written[:12], []byte{
+		0x80, ssd1306ColumnAddr, 0x80, 0, 0x80, 127,
+		0x80, ssd1306PageAddr, 0x80, 0, 0x80, 7,
+	})
+	gobottest.Assert(t, len(adaptor.written), 12+1+1024)
+
+	// unchanged
+	adaptor.written = []byte{}
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, len(adaptor.written), 0)
+
+	// columns 3 to 5 of the pages 1 and 2
+	s.Set(3, 8, 1)
+	s.Set(5, 23, 1)
+	adaptor.written = []byte{}
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{
+		0x80, ssd1306ColumnAddr, 0x80, 3, 0x80, 5,
+		0x80, ssd1306PageAddr, 0x80, 1, 0x80, 2,
+		0x40, 0x01, 0x00, 0x00, 0x00, 0x00, 0x80,
+	})
+
+	// after a write error everything is sent again
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) {
+		if b[0] == 0x40 {
+			return 0, errors.New("write error")
+		}
+		return len(b), nil
+	}
+	s.Set(0, 0, 1)
+	gobottest.Assert(t, s.Display(), errors.New("write error"))
+	adaptor.i2cWriteImpl = func(b []byte) (int, error) { return len(b), nil }
+	adaptor.written = []byte{}
+	gobottest.Assert(t, s.Display(), nil)
+	gobottest.Assert(t, len(adaptor.written), 12+1+1024)
+}
+
+func TestSSD1306DriverSwap(t *testing.T) {
+	s, adaptor := initTestSSD1306DriverWithStubbedAdaptor(96, 16, false)
+	gobottest.Assert(t, s.Start(), nil)
+	s.Set(0, 0, 1)
+	gobottest.Assert(t, s.Display(), nil)
+
+	back := s.BackBuffer()
+	gobottest.Assert(t, back.Size(), 192)
+	back.SetPixel(95, 15, 1)
+
+	// the changes are the first and the last column
+	expected := []byte{
+		0x80, ssd1306ColumnAddr, 0x80, 0, 0x80, 95,
+		0x80, ssd1306PageAddr, 0x80, 0, 0x80, 1,
+		0x40,
+	}
+	expected = append(expected, make([]byte, 192)...)
+	expected[len(expected)-1] = 0x80
+	adaptor.written = []byte{}
+	gobottest.Assert(t, s.Swap(), nil)
+	gobottest.Assert(t, adaptor.written, expected)
+
+	// the back buffer contains the previous frame
+	gobottest.Assert(t, s.BackBuffer().buffer[0], byte(0x01))
+	gobottest.Assert(t, s.buffer.buffer[191], byte(0x80))
 }
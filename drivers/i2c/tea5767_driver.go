@@ -0,0 +1,314 @@
+package i2c
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// TEA5767DefaultAddress is the fixed I2C address of the TEA5767
+const TEA5767DefaultAddress = 0x60
+
+const (
+	// TEA5767SearchLevelLow stops a search at a signal level of 5 (ADC output)
+	TEA5767SearchLevelLow = 1
+	// TEA5767SearchLevelMid stops a search at a signal level of 7
+	TEA5767SearchLevelMid = 2
+	// TEA5767SearchLevelHigh stops a search at a signal level of 10
+	TEA5767SearchLevelHigh = 3
+)
+
+const (
+	// write register bits
+	tea5767Mute    = 0x80 // byte 1
+	tea5767Search  = 0x40 // byte 1
+	tea5767Up      = 0x80 // byte 3
+	tea5767HLSI    = 0x10 // byte 3, high side injection
+	tea5767Mono    = 0x08 // byte 3
+	tea5767Standby = 0x40 // byte 4
+	tea5767Japan   = 0x20 // byte 4, band limits
+	tea5767XTAL    = 0x10 // byte 4, 32.768 kHz crystal
+	tea5767SNC     = 0x02 // byte 4, stereo noise cancelling
+
+	// read register bits
+	tea5767Ready     = 0x80 // byte 1
+	tea5767BandLimit = 0x40 // byte 1
+	tea5767Stereo    = 0x80 // byte 3
+
+	tea5767IF          = 225000 // intermediate frequency in Hz
+	tea5767RefFreq     = 32768  // reference frequency in Hz
+	tea5767SearchTime  = 2 * time.Second
+	tea5767SearchSleep = 20 * time.Millisecond
+)
+
+// ErrTEA5767BandLimit is returned when a search reaches the end of the band
+var ErrTEA5767BandLimit = errors.New("TEA5767 search reached the band limit")
+
+// TEA5767Status is the state of the tuner as read from the TEA5767
+type TEA5767Status struct {
+	// Frequency in MHz
+	Frequency float64
+	// Ready is true when a station is found or the PLL is locked
+	Ready bool
+	// BandLimit is true when a search reached the end of the band
+	BandLimit bool
+	// Stereo is true when a stereo signal is received
+	Stereo bool
+	// Level is the signal level 0..15
+	Level int
+}
+
+// TEA5767Driver is a driver for the TEA5767 FM radio tuner
+type TEA5767Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	Config
+	pll         uint16
+	mute        bool
+	mono        bool
+	standby     bool
+	japan       bool
+	searchLevel byte
+}
+
+// NewTEA5767Driver creates a new driver with specified i2c interface
+// Params:
+//		conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//		i2c.WithBus(int):	bus to use with this driver
+//		i2c.WithTEA5767JapanBand():	use the japanese band 76..91 MHz instead of 87.5..108 MHz
+//		i2c.WithTEA5767SearchLevel(int):	signal level a search stops at, defaults to TEA5767SearchLevelMid
+//
+func NewTEA5767Driver(a Connector, options ...func(Config)) *TEA5767Driver {
+	d := &TEA5767Driver{
+		name:        gobot.DefaultName("TEA5767"),
+		connector:   a,
+		Config:      NewConfig(),
+		searchLevel: TEA5767SearchLevelMid,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// WithTEA5767JapanBand option selects the japanese FM band 76..91 MHz
+func WithTEA5767JapanBand() func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TEA5767Driver)
+		if ok {
+			d.japan = true
+		} else {
+			panic("trying to set japan band for non-TEA5767Driver")
+		}
+	}
+}
+
+// WithTEA5767SearchLevel option sets the signal level a search stops at, one
+// of TEA5767SearchLevelLow, TEA5767SearchLevelMid or TEA5767SearchLevelHigh
+func WithTEA5767SearchLevel(level int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*TEA5767Driver)
+		if ok {
+			d.searchLevel = byte(level) & 0x03
+		} else {
+			panic("trying to set search level for non-TEA5767Driver")
+		}
+	}
+}
+
+// Name returns the name for this Driver
+func (d *TEA5767Driver) Name() string { return d.name }
+
+// SetName sets the name for this Driver
+func (d *TEA5767Driver) SetName(n string) { d.name = n }
+
+// Connection returns the connection for this Driver
+func (d *TEA5767Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start initializes the TEA5767, tuned to the lowest frequency of the band
+func (d *TEA5767Driver) Start() (err error) {
+	bus := d.GetBusOrDefault(d.connector.GetDefaultBus())
+	address := d.GetAddressOrDefault(TEA5767DefaultAddress)
+
+	if d.connection, err = d.connector.GetConnection(address, bus); err != nil {
+		return err
+	}
+
+	min, _ := d.band()
+	return d.SetFrequency(min)
+}
+
+// Halt puts the TEA5767 into standby and releases the connection
+func (d *TEA5767Driver) Halt() (err error) {
+	if connected(d.connection) {
+		if err = d.Standby(true); err != nil {
+			return err
+		}
+	}
+	return closeConnection(&d.connection)
+}
+
+// SetFrequency tunes to the frequency in MHz
+func (d *TEA5767Driver) SetFrequency(mhz float64) error {
+	min, max := d.band()
+	if mhz < min || mhz > max {
+		return fmt.Errorf("Frequency %.1f MHz out of band %.1f..%.1f MHz", mhz, min, max)
+	}
+	d.pll = tea5767PLL(mhz)
+	return d.write(false, false)
+}
+
+// Frequency returns the tuned frequency in MHz
+func (d *TEA5767Driver) Frequency() (float64, error) {
+	status, err := d.Status()
+	return status.Frequency, err
+}
+
+// Mute mutes or unmutes the audio output
+func (d *TEA5767Driver) Mute(mute bool) error {
+	d.mute = mute
+	return d.write(false, false)
+}
+
+// Mono forces mono reception, which reduces the noise of weak stations
+func (d *TEA5767Driver) Mono(mono bool) error {
+	d.mono = mono
+	return d.write(false, false)
+}
+
+// Standby switches the TEA5767 into or out of standby
+func (d *TEA5767Driver) Standby(standby bool) error {
+	d.standby = standby
+	return d.write(false, false)
+}
+
+// SearchUp searches the next station above the tuned frequency and returns
+// its frequency in MHz
+func (d *TEA5767Driver) SearchUp() (float64, error) {
+	return d.search(true)
+}
+
+// SearchDown searches the next station below the tuned frequency and returns
+// its frequency in MHz
+func (d *TEA5767Driver) SearchDown() (float64, error) {
+	return d.search(false)
+}
+
+// Status reads the state of the tuner
+func (d *TEA5767Driver) Status() (status TEA5767Status, err error) {
+	buf := make([]byte, 5)
+	n, err := d.connection.Read(buf)
+	if err != nil {
+		return
+	}
+	if n != len(buf) {
+		return status, ErrNotEnoughBytes
+	}
+
+	pll := uint16(buf[0]&0x3F)<<8 | uint16(buf[1])
+	status.Frequency = tea5767Frequency(pll)
+	status.Ready = buf[0]&tea5767Ready != 0
+	status.BandLimit = buf[0]&tea5767BandLimit != 0
+	status.Stereo = buf[2]&tea5767Stereo != 0
+	status.Level = int(buf[3] >> 4)
+	return
+}
+
+// search starts a search from 100 kHz beyond the tuned frequency and waits
+// until it stops at a station or at the band limit
+func (d *TEA5767Driver) search(up bool) (float64, error) {
+	min, max := d.band()
+	mhz := tea5767Frequency(d.pll)
+	if up {
+		mhz = math.Min(mhz+0.1, max)
+	} else {
+		mhz = math.Max(mhz-0.1, min)
+	}
+	d.pll = tea5767PLL(mhz)
+	if err := d.write(true, up); err != nil {
+		return 0, err
+	}
+
+	timeout := time.Now().Add(tea5767SearchTime)
+	for {
+		status, err := d.Status()
+		if err != nil {
+			return 0, err
+		}
+		if status.Ready {
+			d.pll = tea5767PLL(status.Frequency)
+			// leave the search mode, tuned to the station found
+			if err := d.write(false, false); err != nil {
+				return 0, err
+			}
+			if status.BandLimit {
+				return status.Frequency, ErrTEA5767BandLimit
+			}
+			return status.Frequency, nil
+		}
+		if time.Now().After(timeout) {
+			return 0, errors.New("TEA5767 search timeout")
+		}
+		time.Sleep(tea5767SearchSleep)
+	}
+}
+
+// write writes all 5 control bytes
+func (d *TEA5767Driver) write(search, up bool) error {
+	buf := []byte{
+		byte(d.pll>>8) & 0x3F,
+		byte(d.pll),
+		tea5767HLSI,
+		tea5767XTAL | tea5767SNC,
+		0,
+	}
+	if d.mute {
+		buf[0] |= tea5767Mute
+	}
+	if search {
+		buf[0] |= tea5767Search
+		buf[2] |= d.searchLevel << 5
+		if up {
+			buf[2] |= tea5767Up
+		}
+	}
+	if d.mono {
+		buf[2] |= tea5767Mono
+	}
+	if d.standby {
+		buf[3] |= tea5767Standby
+	}
+	if d.japan {
+		buf[3] |= tea5767Japan
+	}
+	_, err := d.connection.Write(buf)
+	return err
+}
+
+// band returns the limits of the FM band in MHz
+func (d *TEA5767Driver) band() (min, max float64) {
+	if d.japan {
+		return 76, 91
+	}
+	return 87.5, 108
+}
+
+// tea5767PLL returns the PLL word for the frequency with high side injection
+func tea5767PLL(mhz float64) uint16 {
+	return uint16(math.Round(4 * (mhz*1e6 + tea5767IF) / tea5767RefFreq))
+}
+
+// tea5767Frequency returns the frequency in MHz of the PLL word rounded to
+// 100 kHz, the channel spacing of the FM band
+func tea5767Frequency(pll uint16) float64 {
+	hz := float64(pll)*tea5767RefFreq/4 - tea5767IF
+	return math.Round(hz/1e5) / 10
+}
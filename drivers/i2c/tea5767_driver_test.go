@@ -0,0 +1,122 @@
+package i2c
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*TEA5767Driver)(nil)
+
+func initTestTEA5767DriverWithStubbedAdaptor(options ...func(Config)) (*TEA5767Driver, *i2cTestAdaptor) {
+	adaptor := newI2cTestAdaptor()
+	return NewTEA5767Driver(adaptor, options...), adaptor
+}
+
+func TestNewTEA5767Driver(t *testing.T) {
+	d := NewTEA5767Driver(newI2cTestAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.searchLevel, byte(TEA5767SearchLevelMid))
+
+	d = NewTEA5767Driver(newI2cTestAdaptor(), WithTEA5767JapanBand(), WithTEA5767SearchLevel(TEA5767SearchLevelHigh))
+	gobottest.Assert(t, d.japan, true)
+	gobottest.Assert(t, d.searchLevel, byte(TEA5767SearchLevelHigh))
+}
+
+func TestTEA5767DriverStart(t *testing.T) {
+	d, adaptor := initTestTEA5767DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	// 87.5 MHz
+	gobottest.Assert(t, adaptor.written, []byte{0x29, 0xD5, 0x10, 0x12, 0x00})
+
+	adaptor.written = nil
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x29, 0xD5, 0x10, 0x52, 0x00})
+	gobottest.Assert(t, connected(d.connection), false)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	d, adaptor = initTestTEA5767DriverWithStubbedAdaptor()
+	adaptor.Testi2cConnectErr(true)
+	gobottest.Assert(t, d.Start(), errors.New("Invalid i2c connection"))
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestTEA5767DriverSetFrequency(t *testing.T) {
+	d, adaptor := initTestTEA5767DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+
+	adaptor.written = nil
+	gobottest.Assert(t, d.SetFrequency(100.0), nil)
+	gobottest.Assert(t, adaptor.written, []byte{0x2F, 0xCA, 0x10, 0x12, 0x00})
+
+	adaptor.written = nil
+	gobottest.Assert(t, d.Mute(true), nil)
+	gobottest.Assert(t, d.Mono(true), nil)
+	gobottest.Assert(t, adaptor.written[5:], []byte{0xAF, 0xCA, 0x18, 0x12, 0x00})
+
+	gobottest.Assert(t, d.SetFrequency(80).Error(), "Frequency 80.0 MHz out of band 87.5..108.0 MHz")
+
+	d, _ = initTestTEA5767DriverWithStubbedAdaptor(WithTEA5767JapanBand())
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.SetFrequency(80), nil)
+}
+
+func TestTEA5767DriverStatus(t *testing.T) {
+	d, adaptor := initTestTEA5767DriverWithStubbedAdaptor()
+	gobottest.Assert(t, d.Start(), nil)
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		copy(b, []byte{0xAF, 0xCA, 0x86, 0xA0, 0x00})
+		return 5, nil
+	})
+
+	status, err := d.Status()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, status, TEA5767Status{Frequency: 100.0, Ready: true, Stereo: true, Level: 10})
+
+	mhz, err := d.Frequency()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, mhz, 100.0)
+
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		return 2, nil
+	})
+	_, err = d.Status()
+	gobottest.Assert(t, err, ErrNotEnoughBytes)
+}
+
+func TestTEA5767DriverSearch(t *testing.T) {
+	d, adaptor := initTestTEA5767DriverWithStubbedAdaptor(WithTEA5767SearchLevel(TEA5767SearchLevelHigh))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.SetFrequency(100.0), nil)
+
+	polls := 0
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		polls++
+		if polls < 3 {
+			copy(b, []byte{0x2F, 0xCA, 0x00, 0x00, 0x00})
+		} else {
+			// 101.1 MHz found
+			copy(b, []byte{0xB0, 0x51, 0x80, 0xB0, 0x00})
+		}
+		return 5, nil
+	})
+
+	adaptor.written = nil
+	mhz, err := d.SearchUp()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, mhz, 101.1)
+	// search from 100.1 MHz up with high stop level, then tuned to 101.1 MHz
+	gobottest.Assert(t, adaptor.written[:5], []byte{0x6F, 0xD7, 0xF0, 0x12, 0x00})
+	gobottest.Assert(t, adaptor.written[5:], []byte{0x30, 0x51, 0x10, 0x12, 0x00})
+
+	adaptor.Testi2cReadImpl(func(b []byte) (int, error) {
+		// band limit reached
+		copy(b, []byte{0xE9, 0xD5, 0x00, 0x00, 0x00})
+		return 5, nil
+	})
+	mhz, err = d.SearchDown()
+	gobottest.Assert(t, err, ErrTEA5767BandLimit)
+	gobottest.Assert(t, mhz, 87.5)
+}
@@ -107,7 +107,9 @@ func (s *TH02Driver) Start() (err error) {
 }
 
 // Halt returns true if devices is halted successfully
-func (s *TH02Driver) Halt() (err error) { return }
+func (s *TH02Driver) Halt() (err error) {
+	return closeConnection(&s.connection)
+}
 
 // SetAddress sets the address of the device
 func (s *TH02Driver) SetAddress(address int) { s.addr = byte(address) }
@@ -266,7 +266,19 @@ func (d *TSL2561Driver) Start() (err error) {
 
 // Halt stops the device
 func (d *TSL2561Driver) Halt() error {
-	return nil
+	return closeConnection(&d.connection)
+}
+
+// ApplyOptions applies the options, e.g. WithTSL2561Gain16X, while the driver
+// is running and writes the gain and integration time to the device.
+func (d *TSL2561Driver) ApplyOptions(options ...func(Config)) error {
+	for _, option := range options {
+		option(d)
+	}
+	if !connected(d.connection) {
+		return nil
+	}
+	return d.SetIntegrationTime(d.integrationTime)
 }
 
 // SetIntegrationTime sets integrations time for the TSL2561
@@ -27,6 +27,9 @@ type WiichuckDriver struct {
 	Config
 	interval  time.Duration
 	pauseTime time.Duration
+	halt      chan bool
+	// done waits for the reading, which uses the connection
+	done sync.WaitGroup
 	gobot.Eventer
 	mtx      sync.Mutex
 	joystick map[string]float64
@@ -94,8 +97,20 @@ func (w *WiichuckDriver) Start() (err error) {
 		return err
 	}
 
+	w.mtx.Lock()
+	halt := make(chan bool)
+	w.halt = halt
+	w.mtx.Unlock()
+
+	w.done.Add(1)
 	go func() {
+		defer w.done.Done()
 		for {
+			select {
+			case <-halt:
+				return
+			default:
+			}
 			if _, err := w.connection.Write([]byte{0x40, 0x00}); err != nil {
 				w.Publish(w.Event(Error), err)
 				continue
@@ -124,8 +139,17 @@ func (w *WiichuckDriver) Start() (err error) {
 	return
 }
 
-// Halt returns true if driver is halted successfully
-func (w *WiichuckDriver) Halt() (err error) { return }
+// Halt stops reading the Wiichuck and releases the connection
+func (w *WiichuckDriver) Halt() (err error) {
+	w.mtx.Lock()
+	if w.halt != nil {
+		close(w.halt)
+		w.halt = nil
+	}
+	w.mtx.Unlock()
+	w.done.Wait()
+	return closeConnection(&w.connection)
+}
 
 // Joystick returns the current value for the joystick
 func (w *WiichuckDriver) Joystick() map[string]float64 {
@@ -89,6 +89,25 @@ func TestWiichuckDriverHalt(t *testing.T) {
 	gobottest.Assert(t, wii.Halt(), nil)
 }
 
+func TestWiichuckDriverHaltReleasesConnection(t *testing.T) {
+	wii, adaptor := initTestWiichuckDriverWithStubbedAdaptor()
+	gobottest.Assert(t, wii.Start(), nil)
+	gobottest.Assert(t, wii.Halt(), nil)
+	adaptor.mtx.Lock()
+	gobottest.Assert(t, adaptor.closed, 1)
+	adaptor.mtx.Unlock()
+
+	// halting twice closes the connection only once
+	gobottest.Assert(t, wii.Halt(), nil)
+	adaptor.mtx.Lock()
+	gobottest.Assert(t, adaptor.closed, 1)
+	adaptor.mtx.Unlock()
+
+	// the driver can be resumed
+	gobottest.Assert(t, wii.Start(), nil)
+	gobottest.Assert(t, wii.Halt(), nil)
+}
+
 func TestWiichuckDriverCanParse(t *testing.T) {
 	wii := initTestWiichuckDriver()
 
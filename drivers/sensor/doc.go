@@ -0,0 +1,16 @@
+/*
+Package sensor provides the interfaces of common sensor capabilities, which
+are implemented by the drivers of the i2c, spi and gpio packages, directly or
+by the adapters returned by the drivers.
+
+Application code can be written against these interfaces instead of the
+concrete driver types, for example:
+
+	var t sensor.Thermometer = i2c.NewBMP280Driver(adaptor).Thermometer()
+	celsius, err := t.Temperature()
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot
+*/
+package sensor // import "gobot.io/x/gobot/drivers/sensor"
@@ -0,0 +1,118 @@
+package sensor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Unit is the unit of a measurement
+type Unit string
+
+const (
+	// Celsius is the unit of temperatures in celsius degrees
+	Celsius Unit = "°C"
+	// Fahrenheit is the unit of temperatures in fahrenheit degrees
+	Fahrenheit Unit = "°F"
+	// Pascal is the unit of pressures in Pa
+	Pascal Unit = "Pa"
+	// Hectopascal is the unit of pressures in hPa
+	Hectopascal Unit = "hPa"
+	// Millimeter is the unit of distances in mm
+	Millimeter Unit = "mm"
+	// Centimeter is the unit of distances in cm
+	Centimeter Unit = "cm"
+	// Percent is the unit of relative values like the humidity
+	Percent Unit = "%"
+	// Degree is the unit of angles like the heading
+	Degree Unit = "°"
+)
+
+// ErrIncompatibleUnit is the error resulting when a measurement is converted
+// to a unit of another quantity
+var ErrIncompatibleUnit = errors.New("incompatible unit")
+
+// Measurement is a value with its unit and the time it was measured
+type Measurement struct {
+	Value float64
+	Unit  Unit
+	Time  time.Time
+}
+
+// NewMeasurement returns a new Measurement of the value with the unit,
+// measured now
+func NewMeasurement(value float64, unit Unit) Measurement {
+	return Measurement{Value: value, Unit: unit, Time: time.Now()}
+}
+
+// String returns the value and the unit, e.g. "21.5 °C"
+func (m Measurement) String() string {
+	return fmt.Sprintf("%g %s", m.Value, m.Unit)
+}
+
+// Convert returns the measurement converted to the unit, the time is kept.
+// Returns ErrIncompatibleUnit, when the unit is not of the same quantity.
+func (m Measurement) Convert(unit Unit) (Measurement, error) {
+	if m.Unit == unit {
+		return m, nil
+	}
+
+	var value float64
+	switch {
+	case m.Unit == Celsius && unit == Fahrenheit:
+		value = CelsiusToFahrenheit(m.Value)
+	case m.Unit == Fahrenheit && unit == Celsius:
+		value = FahrenheitToCelsius(m.Value)
+	case m.Unit == Pascal && unit == Hectopascal:
+		value = m.Value / 100
+	case m.Unit == Hectopascal && unit == Pascal:
+		value = m.Value * 100
+	case m.Unit == Millimeter && unit == Centimeter:
+		value = m.Value / 10
+	case m.Unit == Centimeter && unit == Millimeter:
+		value = m.Value * 10
+	default:
+		return m, ErrIncompatibleUnit
+	}
+
+	return Measurement{Value: value, Unit: unit, Time: m.Time}, nil
+}
+
+// CelsiusToFahrenheit converts a temperature from celsius to fahrenheit
+func CelsiusToFahrenheit(c float64) float64 { return c*9/5 + 32 }
+
+// FahrenheitToCelsius converts a temperature from fahrenheit to celsius
+func FahrenheitToCelsius(f float64) float64 { return (f - 32) * 5 / 9 }
+
+// ReadTemperature reads the temperature of the thermometer in celsius degrees
+func ReadTemperature(t Thermometer) (Measurement, error) {
+	return read(t.Temperature, Celsius)
+}
+
+// ReadPressure reads the pressure of the barometer in Pa
+func ReadPressure(b Barometer) (Measurement, error) {
+	return read(b.Pressure, Pascal)
+}
+
+// ReadHumidity reads the relative humidity of the hygrometer in percent
+func ReadHumidity(h Hygrometer) (Measurement, error) {
+	return read(h.Humidity, Percent)
+}
+
+// ReadDistance reads the distance of the distance sensor in cm
+func ReadDistance(d DistanceSensor) (Measurement, error) {
+	return read(d.Distance, Centimeter)
+}
+
+// ReadHeading reads the heading of the magnetometer in degrees
+func ReadHeading(m Magnetometer) (Measurement, error) {
+	return read(m.Heading, Degree)
+}
+
+func read(f func() (float64, error), unit Unit) (Measurement, error) {
+	value, err := f()
+	if err != nil {
+		return Measurement{}, err
+	}
+	return NewMeasurement(value, unit), nil
+}
@@ -0,0 +1,61 @@
+package sensor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+type testThermometer struct {
+	temp float64
+	err  error
+}
+
+func (t *testThermometer) Temperature() (float64, error) { return t.temp, t.err }
+
+func TestNewMeasurement(t *testing.T) {
+	before := time.Now()
+	m := NewMeasurement(21.5, Celsius)
+	gobottest.Assert(t, m.Value, 21.5)
+	gobottest.Assert(t, m.Unit, Celsius)
+	gobottest.Assert(t, m.Time.Before(before), false)
+	gobottest.Assert(t, m.String(), "21.5 °C")
+}
+
+func TestMeasurementConvert(t *testing.T) {
+	var tests = []struct {
+		from     Measurement
+		to       Unit
+		expected float64
+	}{
+		{Measurement{Value: 100, Unit: Celsius}, Fahrenheit, 212},
+		{Measurement{Value: -40, Unit: Fahrenheit}, Celsius, -40},
+		{Measurement{Value: 101325, Unit: Pascal}, Hectopascal, 1013.25},
+		{Measurement{Value: 1013.25, Unit: Hectopascal}, Pascal, 101325},
+		{Measurement{Value: 25, Unit: Millimeter}, Centimeter, 2.5},
+		{Measurement{Value: 2.5, Unit: Centimeter}, Millimeter, 25},
+		{Measurement{Value: 42, Unit: Percent}, Percent, 42},
+	}
+	for _, test := range tests {
+		m, err := test.from.Convert(test.to)
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, m.Value, test.expected)
+		gobottest.Assert(t, m.Unit, test.to)
+	}
+
+	_, err := NewMeasurement(20, Celsius).Convert(Pascal)
+	gobottest.Assert(t, err, ErrIncompatibleUnit)
+}
+
+func TestReadTemperature(t *testing.T) {
+	m, err := ReadTemperature(&testThermometer{temp: 20.5})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, m.Value, 20.5)
+	gobottest.Assert(t, m.Unit, Celsius)
+	gobottest.Refute(t, m.Time.IsZero(), true)
+
+	_, err = ReadTemperature(&testThermometer{err: errors.New("read error")})
+	gobottest.Assert(t, err, errors.New("read error"))
+}
@@ -0,0 +1,68 @@
+package sensor
+
+// Thermometer is a sensor which measures the temperature
+type Thermometer interface {
+	// Temperature returns the current temperature in celsius degrees
+	Temperature() (float64, error)
+}
+
+// Barometer is a sensor which measures the barometric pressure
+type Barometer interface {
+	// Pressure returns the current barometric pressure in Pa
+	Pressure() (float64, error)
+}
+
+// Hygrometer is a sensor which measures the relative humidity
+type Hygrometer interface {
+	// Humidity returns the current relative humidity in percent
+	Humidity() (float64, error)
+}
+
+// DistanceSensor is a sensor which measures the distance to an object
+type DistanceSensor interface {
+	// Distance returns the current distance in cm
+	Distance() (float64, error)
+}
+
+// Magnetometer is a sensor which measures the direction of the magnetic
+// field of the earth
+type Magnetometer interface {
+	// Heading returns the current heading in degrees from the magnetic
+	// north, clockwise
+	Heading() (float64, error)
+}
+
+// ThermometerFunc is an adapter to use a function, which returns the
+// temperature in celsius degrees, as Thermometer
+type ThermometerFunc func() (float64, error)
+
+// Temperature calls f()
+func (f ThermometerFunc) Temperature() (float64, error) { return f() }
+
+// BarometerFunc is an adapter to use a function, which returns the pressure
+// in Pa, as Barometer
+type BarometerFunc func() (float64, error)
+
+// Pressure calls f()
+func (f BarometerFunc) Pressure() (float64, error) { return f() }
+
+// HygrometerFunc is an adapter to use a function, which returns the relative
+// humidity in percent, as Hygrometer
+type HygrometerFunc func() (float64, error)
+
+// Humidity calls f()
+func (f HygrometerFunc) Humidity() (float64, error) { return f() }
+
+// DistanceSensorFunc is an adapter to use a function, which returns the
+// distance in cm, as DistanceSensor
+type DistanceSensorFunc func() (float64, error)
+
+// Distance calls f()
+func (f DistanceSensorFunc) Distance() (float64, error) { return f() }
+
+// MagnetometerFunc is an adapter to use a function, which returns the heading
+// in degrees, as Magnetometer
+type MagnetometerFunc func() (float64, error)
+
+// Heading calls f()
+func (f MagnetometerFunc) Heading() (float64, error) { return f() }
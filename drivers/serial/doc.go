@@ -0,0 +1,11 @@
+/*
+Package serial provides Gobot drivers for devices connected by a serial port (UART).
+
+Installing:
+
+	go get -d -u gobot.io/x/gobot
+
+For further information refer to serial README:
+https://github.com/hybridgroup/gobot/blob/master/drivers/serial/README.md
+*/
+package serial // import "gobot.io/x/gobot/drivers/serial"
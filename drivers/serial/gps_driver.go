@@ -0,0 +1,534 @@
+package serial
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+// maxSentenceLength is the longest line accepted, NMEA 0183 allows 82
+// characters but some receivers send longer proprietary sentences
+const maxSentenceLength = 256
+
+// ErrChecksum is the error published for a sentence with a wrong checksum
+var ErrChecksum = errors.New("NMEA checksum mismatch")
+
+// GPSPosition is a position reported by a GPS receiver. Latitude and
+// Longitude are given in decimal degrees (south and west are negative),
+// Altitude in meters above mean sea level.
+type GPSPosition struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+}
+
+// GPSSatellite is a satellite in view of a GPS receiver. Elevation and
+// Azimuth are given in degrees, SNR in dB, -1 if the satellite is not tracked.
+type GPSSatellite struct {
+	Talker    string
+	PRN       int
+	Elevation int
+	Azimuth   int
+	SNR       int
+}
+
+// GPSDriver represents a GPS receiver sending NMEA 0183 sentences on a
+// serial port
+type GPSDriver struct {
+	name       string
+	connection SerialReader
+	poller     *gobot.Poller
+	buffer     []byte
+	mutex      sync.Mutex
+	fix        bool
+	quality    int
+	time       time.Time
+	position   GPSPosition
+	speed      float64
+	course     float64
+	inUse      int
+	hdop       float64
+	inView     map[string][]GPSSatellite
+	pending    map[string][]GPSSatellite
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewGPSDriver returns a new GPSDriver with a polling interval of
+// 10 Milliseconds given a SerialReader, e.g. a serialport.Adaptor.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the serial port is polled for new data
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//
+// Adds the following API Commands:
+// 	"Fix" - See GPSDriver.Fix
+// 	"Position" - See GPSDriver.Position
+// 	"Speed" - See GPSDriver.Speed
+// 	"Satellites" - See GPSDriver.Satellites
+func NewGPSDriver(a SerialReader, v ...interface{}) *GPSDriver {
+	d := &GPSDriver{
+		name:       gobot.DefaultName("GPS"),
+		connection: a,
+		poller:     gobot.NewPoller(10 * time.Millisecond),
+		inView:     make(map[string][]GPSSatellite),
+		pending:    make(map[string][]GPSSatellite),
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for serial.NewGPSDriver", opt))
+		}
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+	d.AddEvent(Fix)
+	d.AddEvent(Position)
+	d.AddEvent(Speed)
+	d.AddEvent(Satellites)
+
+	d.AddCommand("Fix", func(params map[string]interface{}) interface{} {
+		return d.Fix()
+	})
+	d.AddCommand("Position", func(params map[string]interface{}) interface{} {
+		return d.Position()
+	})
+	d.AddCommand("Speed", func(params map[string]interface{}) interface{} {
+		return d.Speed()
+	})
+	d.AddCommand("Satellites", func(params map[string]interface{}) interface{} {
+		return d.Satellites()
+	})
+
+	return d
+}
+
+// Start starts the GPSDriver and reads NMEA sentences from the serial port
+// at the given interval.
+// Emits the Events:
+//	Data string - Event is emitted for each sentence with a valid checksum.
+//	Fix bool - Event is emitted when the receiver gets or loses a fix.
+//	Position GPSPosition - Event is emitted for each RMC or GGA sentence with a fix.
+//	Speed float64 - Event is emitted for each RMC sentence with a fix, in knots.
+//	Satellites []GPSSatellite - Event is emitted for each complete GSV sequence.
+//	Error error - Event is emitted on error reading from the port or parsing a sentence.
+func (d *GPSDriver) Start() (err error) {
+	buf := make([]byte, maxSentenceLength)
+	d.poller.Start(func() error {
+		n, err := d.connection.SerialRead(buf)
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+			return err
+		}
+		d.feed(buf[:n])
+		return nil
+	})
+	return
+}
+
+// Halt stops polling the serial port for new data
+func (d *GPSDriver) Halt() (err error) {
+	d.poller.Stop()
+	return
+}
+
+// Name returns the GPSDrivers name
+func (d *GPSDriver) Name() string { return d.name }
+
+// SetName sets the GPSDrivers name
+func (d *GPSDriver) SetName(n string) { d.name = n }
+
+// Connection returns the GPSDrivers Connection
+func (d *GPSDriver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Fix returns whether the receiver has a valid position fix
+func (d *GPSDriver) Fix() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.fix
+}
+
+// Quality returns the fix quality of the last GGA sentence, 0 for no fix,
+// 1 for GPS and 2 for DGPS
+func (d *GPSDriver) Quality() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.quality
+}
+
+// Time returns the UTC time of the last RMC sentence with a fix
+func (d *GPSDriver) Time() time.Time {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.time
+}
+
+// Position returns the last reported position
+func (d *GPSDriver) Position() GPSPosition {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.position
+}
+
+// Speed returns the last reported speed over ground in knots
+func (d *GPSDriver) Speed() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.speed
+}
+
+// Course returns the last reported course over ground in degrees
+func (d *GPSDriver) Course() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.course
+}
+
+// SatellitesInUse returns the number of satellites used for the fix
+func (d *GPSDriver) SatellitesInUse() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.inUse
+}
+
+// HDOP returns the horizontal dilution of precision of the fix
+func (d *GPSDriver) HDOP() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.hdop
+}
+
+// Telemetry returns the fix and the last reported position, speed and course
+func (d *GPSDriver) Telemetry() map[string]interface{} {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return map[string]interface{}{
+		Fix:                 d.fix,
+		"quality":           d.quality,
+		"latitude":          d.position.Latitude,
+		"longitude":         d.position.Longitude,
+		"altitude":          d.position.Altitude,
+		"speed":             d.speed,
+		"course":            d.course,
+		"satellites_in_use": d.inUse,
+		"hdop":              d.hdop,
+	}
+}
+
+// Satellites returns the satellites in view of all constellations, ordered
+// by talker and PRN
+func (d *GPSDriver) Satellites() []GPSSatellite {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.satellites()
+}
+
+func (d *GPSDriver) satellites() []GPSSatellite {
+	sats := []GPSSatellite{}
+	for _, s := range d.inView {
+		sats = append(sats, s...)
+	}
+	sort.Slice(sats, func(i, j int) bool {
+		if sats[i].Talker != sats[j].Talker {
+			return sats[i].Talker < sats[j].Talker
+		}
+		return sats[i].PRN < sats[j].PRN
+	})
+	return sats
+}
+
+// feed appends data read from the port and parses each complete line
+func (d *GPSDriver) feed(data []byte) {
+	d.buffer = append(d.buffer, data...)
+	for {
+		i := bytes.IndexByte(d.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimSpace(string(d.buffer[:i]))
+		d.buffer = d.buffer[i+1:]
+		if line != "" {
+			d.parseSentence(line)
+		}
+	}
+	if len(d.buffer) > maxSentenceLength {
+		d.buffer = nil
+	}
+}
+
+func (d *GPSDriver) parseSentence(line string) {
+	fields, err := splitSentence(line)
+	if err != nil {
+		d.Publish(d.Event(Error), err)
+		return
+	}
+	d.Publish(d.Event(Data), line)
+
+	if len(fields[0]) != 5 {
+		return
+	}
+	talker, kind := fields[0][:2], fields[0][2:]
+	switch kind {
+	case "RMC":
+		err = d.parseRMC(fields)
+	case "GGA":
+		err = d.parseGGA(fields)
+	case "GSV":
+		err = d.parseGSV(talker, fields)
+	}
+	if err != nil {
+		d.Publish(d.Event(Error), fmt.Errorf("%s: %v", fields[0], err))
+	}
+}
+
+// parseRMC handles the recommended minimum sentence:
+// time, status, lat, N/S, lon, E/W, speed, course, date, ...
+func (d *GPSDriver) parseRMC(f []string) error {
+	if len(f) < 10 {
+		return errors.New("too few fields")
+	}
+	fix := f[2] == "A"
+	if !fix {
+		d.setFix(false)
+		return nil
+	}
+	lat, err := parseCoordinate(f[3], f[4])
+	if err != nil {
+		return err
+	}
+	lon, err := parseCoordinate(f[5], f[6])
+	if err != nil {
+		return err
+	}
+	speed, err := parseFloat(f[7])
+	if err != nil {
+		return err
+	}
+	course, err := parseFloat(f[8])
+	if err != nil {
+		return err
+	}
+	t, err := parseTime(f[9], f[1])
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	d.time = t
+	d.position.Latitude = lat
+	d.position.Longitude = lon
+	d.speed = speed
+	d.course = course
+	pos := d.position
+	d.mutex.Unlock()
+
+	d.setFix(true)
+	d.Publish(d.Event(Position), pos)
+	d.Publish(d.Event(Speed), speed)
+	return nil
+}
+
+// parseGGA handles the fix data sentence:
+// time, lat, N/S, lon, E/W, quality, satellites, HDOP, altitude, M, ...
+func (d *GPSDriver) parseGGA(f []string) error {
+	if len(f) < 11 {
+		return errors.New("too few fields")
+	}
+	quality, err := parseInt(f[6])
+	if err != nil {
+		return err
+	}
+	inUse, err := parseInt(f[7])
+	if err != nil {
+		return err
+	}
+	hdop, err := parseFloat(f[8])
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	d.quality = quality
+	d.inUse = inUse
+	d.hdop = hdop
+	d.mutex.Unlock()
+
+	if quality == 0 {
+		d.setFix(false)
+		return nil
+	}
+	lat, err := parseCoordinate(f[2], f[3])
+	if err != nil {
+		return err
+	}
+	lon, err := parseCoordinate(f[4], f[5])
+	if err != nil {
+		return err
+	}
+	alt, err := parseFloat(f[9])
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	d.position = GPSPosition{Latitude: lat, Longitude: lon, Altitude: alt}
+	pos := d.position
+	d.mutex.Unlock()
+
+	d.setFix(true)
+	d.Publish(d.Event(Position), pos)
+	return nil
+}
+
+// parseGSV handles the satellites in view sentences:
+// number of sentences, sentence number, satellites in view,
+// then up to 4 times PRN, elevation, azimuth, SNR
+func (d *GPSDriver) parseGSV(talker string, f []string) error {
+	if len(f) < 4 {
+		return errors.New("too few fields")
+	}
+	total, err := parseInt(f[1])
+	if err != nil {
+		return err
+	}
+	num, err := parseInt(f[2])
+	if err != nil {
+		return err
+	}
+
+	sats := []GPSSatellite{}
+	for i := 4; i < len(f); i += 4 {
+		if f[i] == "" {
+			continue
+		}
+		s := GPSSatellite{Talker: talker, SNR: -1}
+		if s.PRN, err = strconv.Atoi(f[i]); err != nil {
+			return err
+		}
+		if i+1 < len(f) {
+			if s.Elevation, err = parseInt(f[i+1]); err != nil {
+				return err
+			}
+		}
+		if i+2 < len(f) {
+			if s.Azimuth, err = parseInt(f[i+2]); err != nil {
+				return err
+			}
+		}
+		if i+3 < len(f) && f[i+3] != "" {
+			if s.SNR, err = strconv.Atoi(f[i+3]); err != nil {
+				return err
+			}
+		}
+		sats = append(sats, s)
+	}
+
+	d.mutex.Lock()
+	if num == 1 {
+		d.pending[talker] = nil
+	}
+	d.pending[talker] = append(d.pending[talker], sats...)
+	if num != total {
+		d.mutex.Unlock()
+		return nil
+	}
+	d.inView[talker] = d.pending[talker]
+	delete(d.pending, talker)
+	all := d.satellites()
+	d.mutex.Unlock()
+
+	d.Publish(d.Event(Satellites), all)
+	return nil
+}
+
+func (d *GPSDriver) setFix(fix bool) {
+	d.mutex.Lock()
+	changed := d.fix != fix
+	d.fix = fix
+	d.mutex.Unlock()
+
+	if changed {
+		d.Publish(d.Event(Fix), fix)
+	}
+}
+
+// splitSentence validates the checksum of a "$...*hh" sentence and returns
+// its comma separated fields, the first being the talker and sentence type
+func splitSentence(line string) ([]string, error) {
+	if !strings.HasPrefix(line, "$") && !strings.HasPrefix(line, "!") {
+		return nil, fmt.Errorf("Not a NMEA sentence: %q", line)
+	}
+	body := line[1:]
+	if i := strings.LastIndex(body, "*"); i >= 0 {
+		want, err := strconv.ParseUint(body[i+1:], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid NMEA checksum: %q", line)
+		}
+		body = body[:i]
+		var sum byte
+		for j := 0; j < len(body); j++ {
+			sum ^= body[j]
+		}
+		if sum != byte(want) {
+			return nil, ErrChecksum
+		}
+	}
+	return strings.Split(body, ","), nil
+}
+
+// parseCoordinate converts "ddmm.mmmm" or "dddmm.mmmm" and the hemisphere
+// into decimal degrees
+func parseCoordinate(value, hemisphere string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	deg := math.Floor(v / 100)
+	deg += (v - deg*100) / 60
+	switch hemisphere {
+	case "S", "W":
+		deg = -deg
+	case "N", "E":
+	default:
+		return 0, fmt.Errorf("Invalid hemisphere %q", hemisphere)
+	}
+	return deg, nil
+}
+
+// parseTime converts the date "ddmmyy" and the time "hhmmss.ss" into UTC
+func parseTime(date, clock string) (time.Time, error) {
+	return time.Parse("020106150405", date+clock)
+}
+
+// parseFloat returns 0 for an empty field
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseInt returns 0 for an empty field
+func parseInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,174 @@
+package serial
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*GPSDriver)(nil)
+var _ gobot.Telemeter = (*GPSDriver)(nil)
+
+const (
+	testRMC  = "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A\r\n"
+	testGGA  = "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n"
+	testGSV1 = "$GPGSV,2,1,08,01,40,083,46,02,17,308,41,12,07,344,39,14,22,228,45*75\r\n"
+	testGSV2 = "$GPGSV,2,2,08,15,10,100,,16,05,200,30,17,60,050,44,18,45,150,40*7B\r\n"
+	testVoid = "$GPRMC,123520,V,,,,,,,230394,,*39\r\n"
+)
+
+func withinTolerance(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+func TestGPSDriver(t *testing.T) {
+	d := NewGPSDriver(newSerialTestAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.poller.Interval(), 10*time.Millisecond)
+
+	d = NewGPSDriver(newSerialTestAdaptor(), 30*time.Second)
+	gobottest.Assert(t, d.poller.Interval(), 30*time.Second)
+
+	d = NewGPSDriver(newSerialTestAdaptor(), gobot.WithPollInterval(time.Second))
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
+
+	d.SetName("mygps")
+	gobottest.Assert(t, d.Name(), "mygps")
+}
+
+func TestGPSDriverRMC(t *testing.T) {
+	d := NewGPSDriver(newSerialTestAdaptor())
+	fix := make(chan interface{}, 1)
+	speed := make(chan interface{}, 1)
+	d.On(d.Event(Fix), func(data interface{}) { fix <- data })
+	d.On(d.Event(Speed), func(data interface{}) { speed <- data })
+
+	d.feed([]byte(testRMC[:20]))
+	gobottest.Assert(t, d.Fix(), false)
+	d.feed([]byte(testRMC[20:]))
+
+	gobottest.Assert(t, d.Fix(), true)
+	gobottest.Assert(t, withinTolerance(d.Position().Latitude, 48.1173), true)
+	gobottest.Assert(t, withinTolerance(d.Position().Longitude, 11.516666666), true)
+	gobottest.Assert(t, d.Speed(), 22.4)
+	gobottest.Assert(t, d.Course(), 84.4)
+	gobottest.Assert(t, d.Time(), time.Date(1994, 3, 23, 12, 35, 19, 0, time.UTC))
+	gobottest.Assert(t, d.Command("Fix")(nil), true)
+
+	select {
+	case data := <-fix:
+		gobottest.Assert(t, data, true)
+	case <-time.After(time.Second):
+		t.Errorf("Fix event was not published")
+	}
+	select {
+	case data := <-speed:
+		gobottest.Assert(t, data, 22.4)
+	case <-time.After(time.Second):
+		t.Errorf("Speed event was not published")
+	}
+
+	d.feed([]byte(testVoid))
+	gobottest.Assert(t, d.Fix(), false)
+}
+
+func TestGPSDriverGGA(t *testing.T) {
+	d := NewGPSDriver(newSerialTestAdaptor())
+	position := make(chan interface{}, 1)
+	d.On(d.Event(Position), func(data interface{}) { position <- data })
+
+	d.feed([]byte(testGGA))
+	gobottest.Assert(t, d.Fix(), true)
+	gobottest.Assert(t, d.Quality(), 1)
+	gobottest.Assert(t, d.SatellitesInUse(), 8)
+	gobottest.Assert(t, d.HDOP(), 0.9)
+	gobottest.Assert(t, d.Position().Altitude, 545.4)
+	gobottest.Assert(t, d.Telemetry()["satellites_in_use"], 8)
+
+	select {
+	case data := <-position:
+		gobottest.Assert(t, withinTolerance(data.(GPSPosition).Latitude, 48.1173), true)
+	case <-time.After(time.Second):
+		t.Errorf("Position event was not published")
+	}
+}
+
+func TestGPSDriverGSV(t *testing.T) {
+	d := NewGPSDriver(newSerialTestAdaptor())
+	sats := make(chan interface{}, 1)
+	d.On(d.Event(Satellites), func(data interface{}) { sats <- data })
+
+	d.feed([]byte(testGSV1))
+	gobottest.Assert(t, len(d.Satellites()), 0)
+	d.feed([]byte(testGSV2))
+
+	s := d.Satellites()
+	gobottest.Assert(t, len(s), 8)
+	gobottest.Assert(t, s[0], GPSSatellite{Talker: "GP", PRN: 1, Elevation: 40, Azimuth: 83, SNR: 46})
+	gobottest.Assert(t, s[4].SNR, -1)
+
+	select {
+	case data := <-sats:
+		gobottest.Assert(t, len(data.([]GPSSatellite)), 8)
+	case <-time.After(time.Second):
+		t.Errorf("Satellites event was not published")
+	}
+}
+
+func TestGPSDriverChecksumError(t *testing.T) {
+	d := NewGPSDriver(newSerialTestAdaptor())
+	errs := make(chan interface{}, 1)
+	d.On(d.Event(Error), func(data interface{}) { errs <- data })
+
+	d.feed([]byte("$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6B\r\n"))
+	gobottest.Assert(t, d.Fix(), false)
+
+	select {
+	case data := <-errs:
+		gobottest.Assert(t, data, ErrChecksum)
+	case <-time.After(time.Second):
+		t.Errorf("Error event was not published")
+	}
+}
+
+func TestGPSDriverStart(t *testing.T) {
+	a := newSerialTestAdaptor()
+	d := NewGPSDriver(a, time.Millisecond)
+	fix := make(chan interface{}, 1)
+	d.On(d.Event(Fix), func(data interface{}) { fix <- data })
+
+	gobottest.Assert(t, d.Start(), nil)
+	a.TestAdaptorSerialData(testGGA + testRMC)
+
+	select {
+	case data := <-fix:
+		gobottest.Assert(t, data, true)
+	case <-time.After(time.Second):
+		t.Errorf("Fix event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestGPSDriverReadError(t *testing.T) {
+	a := newSerialTestAdaptor()
+	a.err = errors.New("read error")
+	d := NewGPSDriver(a, time.Millisecond)
+	errs := make(chan interface{}, 1)
+	d.On(d.Event(Error), func(data interface{}) {
+		select {
+		case errs <- data:
+		default:
+		}
+	})
+
+	gobottest.Assert(t, d.Start(), nil)
+	select {
+	case data := <-errs:
+		gobottest.Assert(t, data, errors.New("read error"))
+	case <-time.After(time.Second):
+		t.Errorf("Error event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,55 @@
+package serial
+
+import "sync"
+
+type serialTestAdaptor struct {
+	name string
+	mtx  sync.Mutex
+	data []byte
+	err  error
+}
+
+func (t *serialTestAdaptor) TestAdaptorSerialData(s string) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.data = append(t.data, s...)
+}
+
+func (t *serialTestAdaptor) SerialRead(b []byte) (n int, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.err != nil {
+		return 0, t.err
+	}
+	n = copy(b, t.data)
+	t.data = t.data[n:]
+	return
+}
+func (t *serialTestAdaptor) Connect() (err error)  { return }
+func (t *serialTestAdaptor) Finalize() (err error) { return }
+func (t *serialTestAdaptor) Name() string          { return t.name }
+func (t *serialTestAdaptor) SetName(n string)      { t.name = n }
+
+func newSerialTestAdaptor() *serialTestAdaptor {
+	return &serialTestAdaptor{}
+}
+
+type serialTestWriteAdaptor struct {
+	*serialTestAdaptor
+	written  []byte
+	response func(req []byte) []byte
+}
+
+func (t *serialTestWriteAdaptor) SerialWrite(b []byte) (n int, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.written = append(t.written, b...)
+	if t.response != nil {
+		t.data = append(t.data, t.response(b)...)
+	}
+	return len(b), nil
+}
+
+func newSerialTestWriteAdaptor() *serialTestWriteAdaptor {
+	return &serialTestWriteAdaptor{serialTestAdaptor: newSerialTestAdaptor()}
+}
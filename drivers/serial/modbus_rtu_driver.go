@@ -0,0 +1,352 @@
+package serial
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	modbusReadCoils              = 0x01
+	modbusReadDiscreteInputs     = 0x02
+	modbusReadHoldingRegisters   = 0x03
+	modbusReadInputRegisters     = 0x04
+	modbusWriteSingleCoil        = 0x05
+	modbusWriteSingleRegister    = 0x06
+	modbusWriteMultipleCoils     = 0x0F
+	modbusWriteMultipleRegisters = 0x10
+
+	modbusExceptionFlag = 0x80
+	modbusMaxFrame      = 256
+)
+
+var (
+	// ErrModbusTimeout is returned when a slave does not answer within the timeout
+	ErrModbusTimeout = errors.New("Modbus response timeout")
+	// ErrModbusCRC is returned for a response with a wrong CRC
+	ErrModbusCRC = errors.New("Modbus CRC mismatch")
+	// ErrModbusResponse is returned for a response which does not match the request
+	ErrModbusResponse = errors.New("Unexpected Modbus response")
+)
+
+// ModbusException is the error returned when a slave answers a request with
+// an exception response
+type ModbusException struct {
+	Function byte
+	Code     byte
+}
+
+var modbusExceptionText = map[byte]string{
+	1:  "illegal function",
+	2:  "illegal data address",
+	3:  "illegal data value",
+	4:  "slave device failure",
+	5:  "acknowledge",
+	6:  "slave device busy",
+	8:  "memory parity error",
+	10: "gateway path unavailable",
+	11: "gateway target device failed to respond",
+}
+
+func (e *ModbusException) Error() string {
+	return fmt.Sprintf("Modbus exception %d (%s) for function %d", e.Code, modbusExceptionText[e.Code], e.Function)
+}
+
+// ModbusOption is an option of the ModbusRTUDriver
+type ModbusOption func(*ModbusRTUDriver)
+
+// ModbusRTUDriver is a Modbus RTU master talking to slaves on a serial bus,
+// e.g. a RS-485 line. Requests are serialized, only one request is on the
+// bus at a time.
+type ModbusRTUDriver struct {
+	name       string
+	connection SerialReadWriter
+	timeout    time.Duration
+	mutex      sync.Mutex
+	gobot.Commander
+	gobot.CommandSchemer
+}
+
+// NewModbusRTUDriver returns a new ModbusRTUDriver given a SerialReadWriter,
+// e.g. a serialport.Adaptor. The serial port must be configured (baud rate,
+// parity) by the adaptor, see serialport.WithSerialMode.
+//
+// Optionally accepts:
+//  WithModbusTimeout(time.Duration): Time to wait for a response, defaults to 1 second
+//
+// Adds the following API Commands:
+// 	"ReadHoldingRegisters" - See ModbusRTUDriver.ReadHoldingRegisters
+// 	"ReadInputRegisters" - See ModbusRTUDriver.ReadInputRegisters
+// 	"WriteSingleRegister" - See ModbusRTUDriver.WriteSingleRegister
+func NewModbusRTUDriver(a SerialReadWriter, options ...ModbusOption) *ModbusRTUDriver {
+	d := &ModbusRTUDriver{
+		name:           gobot.DefaultName("ModbusRTU"),
+		connection:     a,
+		timeout:        time.Second,
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	readSchema := gobot.CommandSchema{
+		"slave":   gobot.CommandParamNumber,
+		"address": gobot.CommandParamNumber,
+		"count":   gobot.CommandParamNumber,
+	}
+
+	d.AddCommand("ReadHoldingRegisters", func(params map[string]interface{}) interface{} {
+		slave, address, count := modbusParams(params)
+		val, err := d.ReadHoldingRegisters(slave, address, count)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommandSchema("ReadHoldingRegisters", readSchema)
+	d.AddCommand("ReadInputRegisters", func(params map[string]interface{}) interface{} {
+		slave, address, count := modbusParams(params)
+		val, err := d.ReadInputRegisters(slave, address, count)
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommandSchema("ReadInputRegisters", readSchema)
+	d.AddCommand("WriteSingleRegister", func(params map[string]interface{}) interface{} {
+		slave, address, _ := modbusParams(params)
+		return d.WriteSingleRegister(slave, address, uint16(gobot.CommandParamFloat(params["value"])))
+	})
+	d.AddCommandSchema("WriteSingleRegister", gobot.CommandSchema{
+		"slave":   gobot.CommandParamNumber,
+		"address": gobot.CommandParamNumber,
+		"value":   gobot.CommandParamNumber,
+	})
+
+	return d
+}
+
+// WithModbusTimeout option sets the time to wait for the response of a slave
+func WithModbusTimeout(timeout time.Duration) ModbusOption {
+	return func(d *ModbusRTUDriver) {
+		d.timeout = timeout
+	}
+}
+
+// Start initializes the ModbusRTUDriver
+func (d *ModbusRTUDriver) Start() (err error) { return }
+
+// Halt halts the ModbusRTUDriver
+func (d *ModbusRTUDriver) Halt() (err error) { return }
+
+// Name returns the ModbusRTUDrivers name
+func (d *ModbusRTUDriver) Name() string { return d.name }
+
+// SetName sets the ModbusRTUDrivers name
+func (d *ModbusRTUDriver) SetName(n string) { d.name = n }
+
+// Connection returns the ModbusRTUDrivers Connection
+func (d *ModbusRTUDriver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// ReadCoils reads count coils of the slave starting at address
+func (d *ModbusRTUDriver) ReadCoils(slave byte, address, count uint16) ([]bool, error) {
+	data, err := d.transaction(slave, modbusReadCoils, modbusUint16s(address, count))
+	if err != nil {
+		return nil, err
+	}
+	return modbusBits(data, count)
+}
+
+// ReadDiscreteInputs reads count discrete inputs of the slave starting at address
+func (d *ModbusRTUDriver) ReadDiscreteInputs(slave byte, address, count uint16) ([]bool, error) {
+	data, err := d.transaction(slave, modbusReadDiscreteInputs, modbusUint16s(address, count))
+	if err != nil {
+		return nil, err
+	}
+	return modbusBits(data, count)
+}
+
+// ReadHoldingRegisters reads count holding registers of the slave starting at address
+func (d *ModbusRTUDriver) ReadHoldingRegisters(slave byte, address, count uint16) ([]uint16, error) {
+	data, err := d.transaction(slave, modbusReadHoldingRegisters, modbusUint16s(address, count))
+	if err != nil {
+		return nil, err
+	}
+	return modbusRegisters(data, count)
+}
+
+// ReadInputRegisters reads count input registers of the slave starting at address
+func (d *ModbusRTUDriver) ReadInputRegisters(slave byte, address, count uint16) ([]uint16, error) {
+	data, err := d.transaction(slave, modbusReadInputRegisters, modbusUint16s(address, count))
+	if err != nil {
+		return nil, err
+	}
+	return modbusRegisters(data, count)
+}
+
+// WriteSingleCoil switches the coil of the slave at address on or off
+func (d *ModbusRTUDriver) WriteSingleCoil(slave byte, address uint16, value bool) error {
+	var v uint16
+	if value {
+		v = 0xFF00
+	}
+	_, err := d.transaction(slave, modbusWriteSingleCoil, modbusUint16s(address, v))
+	return err
+}
+
+// WriteSingleRegister writes the holding register of the slave at address
+func (d *ModbusRTUDriver) WriteSingleRegister(slave byte, address, value uint16) error {
+	_, err := d.transaction(slave, modbusWriteSingleRegister, modbusUint16s(address, value))
+	return err
+}
+
+// WriteMultipleCoils writes the coils of the slave starting at address
+func (d *ModbusRTUDriver) WriteMultipleCoils(slave byte, address uint16, values []bool) error {
+	bits := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			bits[i/8] |= 1 << uint(i%8)
+		}
+	}
+	req := append(modbusUint16s(address, uint16(len(values))), byte(len(bits)))
+	_, err := d.transaction(slave, modbusWriteMultipleCoils, append(req, bits...))
+	return err
+}
+
+// WriteMultipleRegisters writes the holding registers of the slave starting at address
+func (d *ModbusRTUDriver) WriteMultipleRegisters(slave byte, address uint16, values []uint16) error {
+	req := append(modbusUint16s(address, uint16(len(values))), byte(2*len(values)))
+	_, err := d.transaction(slave, modbusWriteMultipleRegisters, append(req, modbusUint16s(values...)...))
+	return err
+}
+
+// transaction sends a request to the slave and returns the data of the
+// response, that is without slave address, function code and CRC
+func (d *ModbusRTUDriver) transaction(slave, function byte, data []byte) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// discard what is left over from an earlier, timed out response
+	buf := make([]byte, modbusMaxFrame)
+	for {
+		n, err := d.connection.SerialRead(buf)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			break
+		}
+	}
+
+	req := append([]byte{slave, function}, data...)
+	req = append(req, modbusCRC(req)...)
+	if _, err := d.connection.SerialWrite(req); err != nil {
+		return nil, err
+	}
+
+	resp := []byte{}
+	deadline := time.Now().Add(d.timeout)
+	for {
+		n, err := d.connection.SerialRead(buf)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, buf[:n]...)
+		if l := modbusResponseLength(resp); l > 0 && len(resp) >= l {
+			resp = resp[:l]
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrModbusTimeout
+		}
+		if n == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	l := len(resp)
+	if binary.LittleEndian.Uint16(resp[l-2:]) != binary.LittleEndian.Uint16(modbusCRC(resp[:l-2])) {
+		return nil, ErrModbusCRC
+	}
+	if resp[0] != slave || resp[1]&^modbusExceptionFlag != function {
+		return nil, ErrModbusResponse
+	}
+	if resp[1]&modbusExceptionFlag != 0 {
+		return nil, &ModbusException{Function: function, Code: resp[2]}
+	}
+	return resp[2 : l-2], nil
+}
+
+// modbusResponseLength returns the length of the response frame, 0 if it is
+// not known yet
+func modbusResponseLength(resp []byte) int {
+	if len(resp) < 2 {
+		return 0
+	}
+	if resp[1]&modbusExceptionFlag != 0 {
+		return 5
+	}
+	switch resp[1] {
+	case modbusReadCoils, modbusReadDiscreteInputs, modbusReadHoldingRegisters, modbusReadInputRegisters:
+		if len(resp) < 3 {
+			return 0
+		}
+		return 3 + int(resp[2]) + 2
+	}
+	return 8
+}
+
+// modbusCRC returns the CRC-16/MODBUS of the data in transmission order
+func modbusCRC(data []byte) []byte {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xA001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return []byte{byte(crc), byte(crc >> 8)}
+}
+
+func modbusUint16s(values ...uint16) []byte {
+	b := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.BigEndian.PutUint16(b[2*i:], v)
+	}
+	return b
+}
+
+// modbusBits unpacks the byte count prefixed bits of a read response
+func modbusBits(data []byte, count uint16) ([]bool, error) {
+	if len(data) < 1 || int(data[0]) != (int(count)+7)/8 || len(data) != 1+int(data[0]) {
+		return nil, ErrModbusResponse
+	}
+	bits := make([]bool, count)
+	for i := range bits {
+		bits[i] = data[1+i/8]&(1<<uint(i%8)) != 0
+	}
+	return bits, nil
+}
+
+// modbusRegisters unpacks the byte count prefixed registers of a read response
+func modbusRegisters(data []byte, count uint16) ([]uint16, error) {
+	if len(data) < 1 || int(data[0]) != 2*int(count) || len(data) != 1+int(data[0]) {
+		return nil, ErrModbusResponse
+	}
+	regs := make([]uint16, count)
+	for i := range regs {
+		regs[i] = binary.BigEndian.Uint16(data[1+2*i:])
+	}
+	return regs, nil
+}
+
+func modbusParams(params map[string]interface{}) (slave byte, address, count uint16) {
+	slave = byte(gobot.CommandParamFloat(params["slave"]))
+	address = uint16(gobot.CommandParamFloat(params["address"]))
+	count = uint16(gobot.CommandParamFloat(params["count"]))
+	return
+}
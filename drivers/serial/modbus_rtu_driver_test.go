@@ -0,0 +1,151 @@
+package serial
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*ModbusRTUDriver)(nil)
+
+func withModbusCRC(frame ...byte) []byte {
+	return append(frame, modbusCRC(frame)...)
+}
+
+func TestModbusRTUDriver(t *testing.T) {
+	d := NewModbusRTUDriver(newSerialTestWriteAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.timeout, time.Second)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+
+	d = NewModbusRTUDriver(newSerialTestWriteAdaptor(), WithModbusTimeout(10*time.Millisecond))
+	gobottest.Assert(t, d.timeout, 10*time.Millisecond)
+}
+
+func TestModbusCRC(t *testing.T) {
+	gobottest.Assert(t, modbusCRC([]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0A}), []byte{0xC5, 0xCD})
+}
+
+func TestModbusRTUDriverReadHoldingRegisters(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(0x11, 0x03, 0x04, 0x02, 0x2B, 0x00, 0x64)
+	}
+	d := NewModbusRTUDriver(a)
+
+	regs, err := d.ReadHoldingRegisters(0x11, 0x006B, 2)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, regs, []uint16{0x022B, 0x0064})
+	gobottest.Assert(t, a.written, withModbusCRC(0x11, 0x03, 0x00, 0x6B, 0x00, 0x02))
+
+	ret := d.Command("ReadHoldingRegisters")(map[string]interface{}{
+		"slave": 17.0, "address": 107.0, "count": 2.0}).(map[string]interface{})
+	gobottest.Assert(t, ret["val"].([]uint16), []uint16{0x022B, 0x0064})
+	gobottest.Assert(t, ret["err"], nil)
+}
+
+func TestModbusRTUDriverReadInputRegisters(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(0x01, 0x04, 0x02, 0x00, 0x0A)
+	}
+	d := NewModbusRTUDriver(a)
+
+	regs, err := d.ReadInputRegisters(1, 8, 1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, regs, []uint16{10})
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x04, 0x00, 0x08, 0x00, 0x01))
+}
+
+func TestModbusRTUDriverReadCoils(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(0x01, req[1], 0x02, 0x05, 0x01)
+	}
+	d := NewModbusRTUDriver(a)
+
+	coils, err := d.ReadCoils(1, 0x13, 10)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, coils, []bool{true, false, true, false, false, false, false, false, true, false})
+
+	inputs, err := d.ReadDiscreteInputs(1, 0, 9)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, len(inputs), 9)
+
+	_, err = d.ReadCoils(1, 0, 20)
+	gobottest.Assert(t, err, ErrModbusResponse)
+}
+
+func TestModbusRTUDriverWrite(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(req[:6]...)
+	}
+	d := NewModbusRTUDriver(a)
+
+	gobottest.Assert(t, d.WriteSingleCoil(1, 0xAC, true), nil)
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x05, 0x00, 0xAC, 0xFF, 0x00))
+
+	a.written = nil
+	gobottest.Assert(t, d.WriteSingleRegister(1, 1, 3), nil)
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x06, 0x00, 0x01, 0x00, 0x03))
+
+	a.written = nil
+	gobottest.Assert(t, d.WriteMultipleCoils(1, 0x13, []bool{true, false, true, true, false, false, true, true, true, false}), nil)
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x0F, 0x00, 0x13, 0x00, 0x0A, 0x02, 0xCD, 0x01))
+
+	a.written = nil
+	gobottest.Assert(t, d.WriteMultipleRegisters(1, 1, []uint16{0x000A, 0x0102}), nil)
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x10, 0x00, 0x01, 0x00, 0x02, 0x04, 0x00, 0x0A, 0x01, 0x02))
+
+	a.written = nil
+	gobottest.Assert(t, d.Command("WriteSingleRegister")(map[string]interface{}{
+		"slave": 1.0, "address": 2.0, "value": 300.0}), nil)
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x06, 0x00, 0x02, 0x01, 0x2C))
+
+	// params given by Go code instead of JSON are integers
+	a.written = nil
+	gobottest.Assert(t, d.Command("WriteSingleRegister")(map[string]interface{}{
+		"slave": 1, "address": 2, "value": 300}), nil)
+	gobottest.Assert(t, a.written, withModbusCRC(0x01, 0x06, 0x00, 0x02, 0x01, 0x2C))
+}
+
+func TestModbusRTUDriverException(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(0x01, 0x83, 0x02)
+	}
+	d := NewModbusRTUDriver(a)
+
+	_, err := d.ReadHoldingRegisters(1, 0xFFFF, 1)
+	gobottest.Assert(t, err, &ModbusException{Function: 3, Code: 2})
+	gobottest.Assert(t, err.Error(), "Modbus exception 2 (illegal data address) for function 3")
+}
+
+func TestModbusRTUDriverErrors(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	d := NewModbusRTUDriver(a, WithModbusTimeout(10*time.Millisecond))
+
+	_, err := d.ReadHoldingRegisters(1, 0, 1)
+	gobottest.Assert(t, err, ErrModbusTimeout)
+
+	a.response = func(req []byte) []byte {
+		return []byte{0x01, 0x06, 0x00, 0x01, 0x00, 0x03, 0x00, 0x00}
+	}
+	gobottest.Assert(t, d.WriteSingleRegister(1, 1, 3), ErrModbusCRC)
+
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(0x02, 0x06, 0x00, 0x01, 0x00, 0x03)
+	}
+	gobottest.Assert(t, d.WriteSingleRegister(1, 1, 3), ErrModbusResponse)
+
+	// stale data of an earlier response is discarded
+	a.response = func(req []byte) []byte {
+		return withModbusCRC(req[:6]...)
+	}
+	a.TestAdaptorSerialData("garbage")
+	gobottest.Assert(t, d.WriteSingleRegister(1, 1, 3), nil)
+}
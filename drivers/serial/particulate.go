@@ -0,0 +1,38 @@
+package serial
+
+import "gobot.io/x/gobot"
+
+// ParticulateMatter is a reading of a particulate matter sensor, the mass
+// concentrations of particles up to 1.0, 2.5 and 10 µm are given in µg/m³.
+// Sensors which do not measure PM1.0 report 0.
+type ParticulateMatter struct {
+	PM1  float64
+	PM25 float64
+	PM10 float64
+}
+
+// telemetry returns the concentrations by the names of their events
+func (pm ParticulateMatter) telemetry() map[string]interface{} {
+	return map[string]interface{}{
+		"pm1": pm.PM1,
+		PM25:  pm.PM25,
+		PM10:  pm.PM10,
+	}
+}
+
+// serialWrite writes the command to the connection if it is a SerialWriter
+func serialWrite(c SerialReader, cmd []byte) (err error) {
+	w, ok := c.(SerialWriter)
+	if !ok {
+		return ErrSerialWriteUnsupported
+	}
+	_, err = w.SerialWrite(cmd)
+	return
+}
+
+// publishParticulateMatter publishes the Data, PM25 and PM10 events of a reading
+func publishParticulateMatter(e gobot.Eventer, pm ParticulateMatter) {
+	e.Publish(e.Event(Data), pm)
+	e.Publish(e.Event(PM25), pm.PM25)
+	e.Publish(e.Event(PM10), pm.PM10)
+}
@@ -0,0 +1,203 @@
+package serial
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	pms5003FrameLength = 32
+	pms5003Head1       = 0x42
+	pms5003Head2       = 0x4D
+	pms5003SleepCmd    = 0xE4
+)
+
+// ErrPMS5003Checksum is the error published for a frame with a wrong checksum
+var ErrPMS5003Checksum = errors.New("PMS5003 checksum mismatch")
+
+// PMS5003Driver represents a Plantower PMS5003 particulate matter sensor.
+// The reported mass concentrations are the values under atmospheric
+// environment.
+type PMS5003Driver struct {
+	name       string
+	connection SerialReader
+	poller     *gobot.Poller
+	buffer     []byte
+	mutex      sync.Mutex
+	reading    ParticulateMatter
+	particles  [6]int
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewPMS5003Driver returns a new PMS5003Driver with a polling interval of
+// 100 Milliseconds given a SerialReader, e.g. a serialport.Adaptor with the
+// default 9600 baud. Sleep and Wake need an adaptor which is also a
+// SerialWriter.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the serial port is polled for new data
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//
+// Adds the following API Commands:
+// 	"Reading" - See PMS5003Driver.Reading
+// 	"Sleep" - See PMS5003Driver.Sleep
+// 	"Wake" - See PMS5003Driver.Wake
+func NewPMS5003Driver(a SerialReader, v ...interface{}) *PMS5003Driver {
+	d := &PMS5003Driver{
+		name:       gobot.DefaultName("PMS5003"),
+		connection: a,
+		poller:     gobot.NewPoller(100 * time.Millisecond),
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for serial.NewPMS5003Driver", opt))
+		}
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(PM25)
+	d.AddEvent(PM10)
+	d.AddEvent(Error)
+
+	d.AddCommand("Reading", func(params map[string]interface{}) interface{} {
+		return d.Reading()
+	})
+	d.AddCommand("Sleep", func(params map[string]interface{}) interface{} {
+		return d.Sleep()
+	})
+	d.AddCommand("Wake", func(params map[string]interface{}) interface{} {
+		return d.Wake()
+	})
+
+	return d
+}
+
+// Start starts the PMS5003Driver and reads frames from the serial port at the
+// given interval.
+// Emits the Events:
+//	Data ParticulateMatter - Event is emitted for each measurement.
+//	PM25 float64 - Event is emitted for each measurement, in µg/m³.
+//	PM10 float64 - Event is emitted for each measurement, in µg/m³.
+//	Error error - Event is emitted on error reading from the port or on a wrong checksum.
+func (d *PMS5003Driver) Start() (err error) {
+	buf := make([]byte, 64)
+	d.poller.Start(func() error {
+		n, err := d.connection.SerialRead(buf)
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+			return err
+		}
+		d.feed(buf[:n])
+		return nil
+	})
+	return
+}
+
+// Halt stops polling the serial port for new data
+func (d *PMS5003Driver) Halt() (err error) {
+	d.poller.Stop()
+	return
+}
+
+// Name returns the PMS5003Drivers name
+func (d *PMS5003Driver) Name() string { return d.name }
+
+// SetName sets the PMS5003Drivers name
+func (d *PMS5003Driver) SetName(n string) { d.name = n }
+
+// Connection returns the PMS5003Drivers Connection
+func (d *PMS5003Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Reading returns the last measurement
+func (d *PMS5003Driver) Reading() ParticulateMatter {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.reading
+}
+
+// Telemetry returns the last measurement in µg/m³
+func (d *PMS5003Driver) Telemetry() map[string]interface{} {
+	return d.Reading().telemetry()
+}
+
+// Particles returns the number of particles beyond 0.3, 0.5, 1.0, 2.5, 5.0
+// and 10 µm in 0.1 l of air of the last measurement
+func (d *PMS5003Driver) Particles() [6]int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.particles
+}
+
+// Sleep switches off the fan of the sensor, no measurements are reported
+// until Wake is called
+func (d *PMS5003Driver) Sleep() error {
+	return serialWrite(d.connection, pms5003Command(pms5003SleepCmd, 0))
+}
+
+// Wake switches the sensor back to work mode, the first measurement is
+// reported after the fan has settled for about 30 seconds
+func (d *PMS5003Driver) Wake() error {
+	return serialWrite(d.connection, pms5003Command(pms5003SleepCmd, 1))
+}
+
+// feed appends data read from the port and parses each complete frame:
+// 42 4D, frame length, 13 big endian data words, checksum
+func (d *PMS5003Driver) feed(data []byte) {
+	d.buffer = append(d.buffer, data...)
+	for len(d.buffer) >= pms5003FrameLength {
+		if d.buffer[0] != pms5003Head1 || d.buffer[1] != pms5003Head2 ||
+			binary.BigEndian.Uint16(d.buffer[2:]) != pms5003FrameLength-4 {
+			d.buffer = d.buffer[1:]
+			continue
+		}
+		frame := d.buffer[:pms5003FrameLength]
+		d.buffer = d.buffer[pms5003FrameLength:]
+
+		if pms5003Checksum(frame[:30]) != binary.BigEndian.Uint16(frame[30:]) {
+			d.Publish(d.Event(Error), ErrPMS5003Checksum)
+			continue
+		}
+
+		word := func(i int) int { return int(binary.BigEndian.Uint16(frame[4+2*i:])) }
+		pm := ParticulateMatter{
+			PM1:  float64(word(3)),
+			PM25: float64(word(4)),
+			PM10: float64(word(5)),
+		}
+		d.mutex.Lock()
+		d.reading = pm
+		for i := range d.particles {
+			d.particles[i] = word(6 + i)
+		}
+		d.mutex.Unlock()
+		publishParticulateMatter(d, pm)
+	}
+}
+
+// pms5003Command returns the frame of a command: 42 4D CMD DATAH DATAL LRCH LRCL
+func pms5003Command(cmd byte, data uint16) []byte {
+	frame := []byte{pms5003Head1, pms5003Head2, cmd, byte(data >> 8), byte(data), 0, 0}
+	binary.BigEndian.PutUint16(frame[5:], pms5003Checksum(frame[:5]))
+	return frame
+}
+
+func pms5003Checksum(data []byte) (sum uint16) {
+	for _, b := range data {
+		sum += uint16(b)
+	}
+	return
+}
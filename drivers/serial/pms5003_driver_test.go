@@ -0,0 +1,78 @@
+package serial
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*PMS5003Driver)(nil)
+var _ gobot.Telemeter = (*PMS5003Driver)(nil)
+
+// atmospheric PM1.0 4 µg/m³, PM2.5 11 µg/m³, PM10 19 µg/m³
+var testPMS5003Frame = []byte{0x42, 0x4D, 0x00, 0x1C, 0x00, 0x05, 0x00, 0x0C, 0x00, 0x14,
+	0x00, 0x04, 0x00, 0x0B, 0x00, 0x13, 0x04, 0xB0, 0x01, 0x5E, 0x00, 0x50, 0x00, 0x0A,
+	0x00, 0x03, 0x00, 0x01, 0x00, 0x00, 0x02, 0x63}
+
+func TestPMS5003Driver(t *testing.T) {
+	d := NewPMS5003Driver(newSerialTestAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.poller.Interval(), 100*time.Millisecond)
+
+	d = NewPMS5003Driver(newSerialTestAdaptor(), gobot.WithPollInterval(time.Second))
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
+}
+
+func TestPMS5003DriverFeed(t *testing.T) {
+	d := NewPMS5003Driver(newSerialTestAdaptor())
+	data := make(chan interface{}, 1)
+	d.On(d.Event(Data), func(v interface{}) { data <- v })
+
+	// garbage and a split frame
+	d.feed(append([]byte{0x42, 0x00}, testPMS5003Frame[:10]...))
+	gobottest.Assert(t, d.Reading(), ParticulateMatter{})
+	d.feed(testPMS5003Frame[10:])
+
+	gobottest.Assert(t, d.Reading(), ParticulateMatter{PM1: 4, PM25: 11, PM10: 19})
+	gobottest.Assert(t, d.Particles(), [6]int{1200, 350, 80, 10, 3, 1})
+	select {
+	case v := <-data:
+		gobottest.Assert(t, v, ParticulateMatter{PM1: 4, PM25: 11, PM10: 19})
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+}
+
+func TestPMS5003DriverChecksumError(t *testing.T) {
+	d := NewPMS5003Driver(newSerialTestAdaptor())
+	errs := make(chan interface{}, 1)
+	d.On(d.Event(Error), func(data interface{}) { errs <- data })
+
+	frame := append([]byte{}, testPMS5003Frame...)
+	frame[31]++
+	d.feed(frame)
+	gobottest.Assert(t, d.Reading(), ParticulateMatter{})
+	select {
+	case data := <-errs:
+		gobottest.Assert(t, data, ErrPMS5003Checksum)
+	case <-time.After(time.Second):
+		t.Errorf("Error event was not published")
+	}
+}
+
+func TestPMS5003DriverSleepWake(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	d := NewPMS5003Driver(a)
+
+	gobottest.Assert(t, d.Command("Sleep")(nil), nil)
+	gobottest.Assert(t, a.written, []byte{0x42, 0x4D, 0xE4, 0x00, 0x00, 0x01, 0x73})
+
+	a.written = nil
+	gobottest.Assert(t, d.Wake(), nil)
+	gobottest.Assert(t, a.written, []byte{0x42, 0x4D, 0xE4, 0x00, 0x01, 0x01, 0x74})
+
+	d = NewPMS5003Driver(newSerialTestAdaptor())
+	gobottest.Assert(t, d.Wake(), ErrSerialWriteUnsupported)
+}
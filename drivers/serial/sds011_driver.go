@@ -0,0 +1,203 @@
+package serial
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	sds011FrameLength   = 10
+	sds011CommandLength = 19
+	sds011Head          = 0xAA
+	sds011Tail          = 0xAB
+	sds011Data          = 0xC0
+	sds011Reply         = 0xC5
+	sds011Command       = 0xB4
+	sds011SleepWork     = 0x06
+)
+
+// ErrSDS011Checksum is the error published for a frame with a wrong checksum
+var ErrSDS011Checksum = errors.New("SDS011 checksum mismatch")
+
+// SDS011Driver represents a Nova Fitness SDS011 particulate matter sensor
+type SDS011Driver struct {
+	name       string
+	connection SerialReader
+	poller     *gobot.Poller
+	buffer     []byte
+	mutex      sync.Mutex
+	reading    ParticulateMatter
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewSDS011Driver returns a new SDS011Driver with a polling interval of
+// 100 Milliseconds given a SerialReader, e.g. a serialport.Adaptor with the
+// default 9600 baud. The sensor reports once a second in its default active
+// mode. Sleep and Wake need an adaptor which is also a SerialWriter.
+//
+// Optionally accepts:
+// 	time.Duration: Interval at which the serial port is polled for new data
+// 	gobot.PollOption: Options of the polling, e.g. gobot.WithPollInterval(time.Duration)
+//
+// Adds the following API Commands:
+// 	"Reading" - See SDS011Driver.Reading
+// 	"Sleep" - See SDS011Driver.Sleep
+// 	"Wake" - See SDS011Driver.Wake
+func NewSDS011Driver(a SerialReader, v ...interface{}) *SDS011Driver {
+	d := &SDS011Driver{
+		name:       gobot.DefaultName("SDS011"),
+		connection: a,
+		poller:     gobot.NewPoller(100 * time.Millisecond),
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+
+	for _, opt := range v {
+		switch o := opt.(type) {
+		case time.Duration:
+			d.poller.Apply(gobot.WithPollInterval(o))
+		case gobot.PollOption:
+			d.poller.Apply(o)
+		default:
+			panic(fmt.Sprintf("unsupported option %T for serial.NewSDS011Driver", opt))
+		}
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(PM25)
+	d.AddEvent(PM10)
+	d.AddEvent(Error)
+
+	d.AddCommand("Reading", func(params map[string]interface{}) interface{} {
+		return d.Reading()
+	})
+	d.AddCommand("Sleep", func(params map[string]interface{}) interface{} {
+		return d.Sleep()
+	})
+	d.AddCommand("Wake", func(params map[string]interface{}) interface{} {
+		return d.Wake()
+	})
+
+	return d
+}
+
+// Start starts the SDS011Driver and reads frames from the serial port at the
+// given interval.
+// Emits the Events:
+//	Data ParticulateMatter - Event is emitted for each measurement.
+//	PM25 float64 - Event is emitted for each measurement, in µg/m³.
+//	PM10 float64 - Event is emitted for each measurement, in µg/m³.
+//	Error error - Event is emitted on error reading from the port or on a wrong checksum.
+func (d *SDS011Driver) Start() (err error) {
+	buf := make([]byte, 64)
+	d.poller.Start(func() error {
+		n, err := d.connection.SerialRead(buf)
+		if err != nil {
+			d.Publish(d.Event(Error), err)
+			return err
+		}
+		d.feed(buf[:n])
+		return nil
+	})
+	return
+}
+
+// Halt stops polling the serial port for new data
+func (d *SDS011Driver) Halt() (err error) {
+	d.poller.Stop()
+	return
+}
+
+// Name returns the SDS011Drivers name
+func (d *SDS011Driver) Name() string { return d.name }
+
+// SetName sets the SDS011Drivers name
+func (d *SDS011Driver) SetName(n string) { d.name = n }
+
+// Connection returns the SDS011Drivers Connection
+func (d *SDS011Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Reading returns the last measurement
+func (d *SDS011Driver) Reading() ParticulateMatter {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.reading
+}
+
+// Telemetry returns the last measurement in µg/m³
+func (d *SDS011Driver) Telemetry() map[string]interface{} {
+	return d.Reading().telemetry()
+}
+
+// Sleep switches off the fan and the laser of the sensor, no measurements
+// are reported until Wake is called
+func (d *SDS011Driver) Sleep() error {
+	return serialWrite(d.connection, sds011SleepWorkCommand(false))
+}
+
+// Wake switches the sensor back to work mode
+func (d *SDS011Driver) Wake() error {
+	return serialWrite(d.connection, sds011SleepWorkCommand(true))
+}
+
+// feed appends data read from the port and parses each complete frame:
+// AA C0 PM25L PM25H PM10L PM10H ID1 ID2 CS AB
+func (d *SDS011Driver) feed(data []byte) {
+	d.buffer = append(d.buffer, data...)
+	for len(d.buffer) >= sds011FrameLength {
+		if d.buffer[0] != sds011Head || d.buffer[sds011FrameLength-1] != sds011Tail ||
+			(d.buffer[1] != sds011Data && d.buffer[1] != sds011Reply) {
+			d.buffer = d.buffer[1:]
+			continue
+		}
+		frame := d.buffer[:sds011FrameLength]
+		d.buffer = d.buffer[sds011FrameLength:]
+
+		if sds011Checksum(frame[2:8]) != frame[8] {
+			d.Publish(d.Event(Error), ErrSDS011Checksum)
+			continue
+		}
+		if frame[1] != sds011Data {
+			continue
+		}
+
+		pm := ParticulateMatter{
+			PM25: float64(uint16(frame[3])<<8|uint16(frame[2])) / 10,
+			PM10: float64(uint16(frame[5])<<8|uint16(frame[4])) / 10,
+		}
+		d.mutex.Lock()
+		d.reading = pm
+		d.mutex.Unlock()
+		publishParticulateMatter(d, pm)
+	}
+}
+
+// sds011SleepWorkCommand returns the frame which sets the sleep or work mode
+// of all sensors on the port
+func sds011SleepWorkCommand(work bool) []byte {
+	cmd := make([]byte, sds011CommandLength)
+	cmd[0] = sds011Head
+	cmd[1] = sds011Command
+	cmd[2] = sds011SleepWork
+	cmd[3] = 1 // set mode
+	if work {
+		cmd[4] = 1
+	}
+	cmd[15] = 0xFF // device ID, all devices
+	cmd[16] = 0xFF
+	cmd[17] = sds011Checksum(cmd[2:17])
+	cmd[18] = sds011Tail
+	return cmd
+}
+
+func sds011Checksum(data []byte) (sum byte) {
+	for _, b := range data {
+		sum += b
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package serial
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*SDS011Driver)(nil)
+var _ gobot.Telemeter = (*SDS011Driver)(nil)
+
+// PM2.5 12.3 µg/m³, PM10 45.6 µg/m³
+var testSDS011Frame = []byte{0xAA, 0xC0, 0x7B, 0x00, 0xC8, 0x01, 0x12, 0x34, 0x8A, 0xAB}
+
+func TestSDS011Driver(t *testing.T) {
+	d := NewSDS011Driver(newSerialTestAdaptor())
+	gobottest.Refute(t, d.Connection(), nil)
+	gobottest.Assert(t, d.poller.Interval(), 100*time.Millisecond)
+
+	d = NewSDS011Driver(newSerialTestAdaptor(), time.Second)
+	gobottest.Assert(t, d.poller.Interval(), time.Second)
+}
+
+func TestSDS011DriverFeed(t *testing.T) {
+	d := NewSDS011Driver(newSerialTestAdaptor())
+	pm25 := make(chan interface{}, 1)
+	d.On(d.Event(PM25), func(data interface{}) { pm25 <- data })
+
+	// garbage and a split frame
+	d.feed(append([]byte{0x00, 0xAB}, testSDS011Frame[:4]...))
+	gobottest.Assert(t, d.Reading(), ParticulateMatter{})
+	d.feed(testSDS011Frame[4:])
+
+	gobottest.Assert(t, d.Reading(), ParticulateMatter{PM25: 12.3, PM10: 45.6})
+	gobottest.Assert(t, d.Command("Reading")(nil), ParticulateMatter{PM25: 12.3, PM10: 45.6})
+	gobottest.Assert(t, d.Telemetry()[PM25], 12.3)
+	gobottest.Assert(t, d.Telemetry()[PM10], 45.6)
+	select {
+	case data := <-pm25:
+		gobottest.Assert(t, data, 12.3)
+	case <-time.After(time.Second):
+		t.Errorf("PM25 event was not published")
+	}
+}
+
+func TestSDS011DriverChecksumError(t *testing.T) {
+	d := NewSDS011Driver(newSerialTestAdaptor())
+	errs := make(chan interface{}, 1)
+	d.On(d.Event(Error), func(data interface{}) { errs <- data })
+
+	frame := append([]byte{}, testSDS011Frame...)
+	frame[8]++
+	d.feed(frame)
+	gobottest.Assert(t, d.Reading(), ParticulateMatter{})
+	select {
+	case data := <-errs:
+		gobottest.Assert(t, data, ErrSDS011Checksum)
+	case <-time.After(time.Second):
+		t.Errorf("Error event was not published")
+	}
+}
+
+func TestSDS011DriverSleepWake(t *testing.T) {
+	a := newSerialTestWriteAdaptor()
+	d := NewSDS011Driver(a)
+
+	gobottest.Assert(t, d.Sleep(), nil)
+	gobottest.Assert(t, a.written, []byte{0xAA, 0xB4, 0x06, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x05, 0xAB})
+
+	a.written = nil
+	gobottest.Assert(t, d.Command("Wake")(nil), nil)
+	gobottest.Assert(t, a.written, []byte{0xAA, 0xB4, 0x06, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0x06, 0xAB})
+
+	d = NewSDS011Driver(newSerialTestAdaptor())
+	gobottest.Assert(t, d.Sleep(), ErrSerialWriteUnsupported)
+}
+
+func TestSDS011DriverStart(t *testing.T) {
+	a := newSerialTestAdaptor()
+	d := NewSDS011Driver(a, time.Millisecond)
+	pm10 := make(chan interface{}, 1)
+	d.On(d.Event(PM10), func(data interface{}) { pm10 <- data })
+
+	gobottest.Assert(t, d.Start(), nil)
+	a.TestAdaptorSerialData(string(testSDS011Frame))
+	select {
+	case data := <-pm10:
+		gobottest.Assert(t, data, 45.6)
+	case <-time.After(time.Second):
+		t.Errorf("PM10 event was not published")
+	}
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,52 @@
+package serial
+
+import (
+	"errors"
+)
+
+var (
+	// ErrSerialWriteUnsupported is error resulting when a driver attempts to use
+	// hardware capabilities which a connection does not support
+	ErrSerialWriteUnsupported = errors.New("SerialWrite is not supported by this platform")
+)
+
+const (
+	// Error event
+	Error = "error"
+	// Data event
+	Data = "data"
+	// Fix event
+	Fix = "fix"
+	// Position event
+	Position = "position"
+	// Speed event
+	Speed = "speed"
+	// Satellites event
+	Satellites = "satellites"
+	// PM25 event
+	PM25 = "pm25"
+	// PM10 event
+	PM10 = "pm10"
+)
+
+// SerialReader interface represents an Adaptor which is able to read from a
+// serial port. SerialRead returns the number of bytes read into the buffer,
+// 0 when no data is available at the moment.
+type SerialReader interface {
+	//gobot.Adaptor
+	SerialRead(b []byte) (n int, err error)
+}
+
+// SerialWriter interface represents an Adaptor which is able to write to a
+// serial port.
+type SerialWriter interface {
+	//gobot.Adaptor
+	SerialWrite(b []byte) (n int, err error)
+}
+
+// SerialReadWriter interface represents an Adaptor which is able to read
+// from and write to a serial port.
+type SerialReadWriter interface {
+	SerialReader
+	SerialWriter
+}
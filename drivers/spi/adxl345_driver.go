@@ -0,0 +1,34 @@
+package spi
+
+import (
+	"gobot.io/x/gobot/drivers/i2c"
+)
+
+const (
+	adxl345MaxSpeed = 5000000
+	adxl345Mode     = 3
+	adxl345Read     = 0x80
+	adxl345Multi    = 0x40
+)
+
+// NewADXL345Driver creates a new driver for the ADXL345 accelerometer, which is
+// connected by 4-wire SPI. The driver is the i2c.ADXL345Driver, only the
+// transfers are done by SPI, which allows the higher data rates.
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                   bus to use with this driver
+//      spi.WithChip(int):                  chip to use with this driver
+//      spi.WithMode(int):                  mode to use with this driver (defaults to 3)
+//      spi.WithBits(int):                  number of bits to use with this driver
+//      spi.WithSpeed(int64):               speed in Hz to use with this driver (defaults to 5MHz)
+//
+func NewADXL345Driver(a Connector, options ...func(Config)) *i2c.ADXL345Driver {
+	b := newI2cBridge(a, adxl345Mode, adxl345MaxSpeed, adxl345Read, adxl345Multi)
+	for _, option := range options {
+		option(b)
+	}
+	return i2c.NewADXL345Driver(b)
+}
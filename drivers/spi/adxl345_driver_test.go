@@ -0,0 +1,127 @@
+package spi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// adxl345Simulator simulates the registers of an ADXL345 connected by SPI
+type adxl345Simulator struct {
+	mtx       sync.Mutex
+	registers [64]byte
+	txs       [][]byte
+	err       error
+}
+
+func (s *adxl345Simulator) Close() error { return nil }
+
+func (s *adxl345Simulator) Tx(w, r []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.txs = append(s.txs, append([]byte{}, w...))
+	reg := w[0] &^ (adxl345Read | adxl345Multi)
+	if w[0]&adxl345Read != 0 {
+		copy(r[1:], s.registers[reg:])
+	} else {
+		copy(s.registers[reg:], w[1:])
+	}
+	return nil
+}
+
+type adxl345TestConnector struct {
+	spiTestConnector
+	mode  int
+	speed int64
+}
+
+func (ctr *adxl345TestConnector) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (device Connection, err error) {
+	ctr.mode, ctr.speed = mode, maxSpeed
+	return ctr.conn, ctr.err
+}
+
+func initTestADXL345Driver(options ...func(Config)) (*i2c.ADXL345Driver, *adxl345Simulator, *adxl345TestConnector) {
+	sim := &adxl345Simulator{}
+	ctr := &adxl345TestConnector{}
+	ctr.conn = sim
+	return NewADXL345Driver(ctr, options...), sim, ctr
+}
+
+func TestADXL345Driver(t *testing.T) {
+	d, sim, ctr := initTestADXL345Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "ADXL345"), true)
+	gobottest.Refute(t, d.Connection(), nil)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, ctr.mode, 3)
+	gobottest.Assert(t, ctr.speed, int64(5000000))
+	// BW_RATE, POWER_CTL and DATA_FORMAT
+	gobottest.Assert(t, sim.txs, [][]byte{{0x2C, 0x1A}, {0x2D, 0x08}, {0x31, 0x00}})
+
+	d, _, ctr = initTestADXL345Driver(WithMode(0), WithSpeed(1000000))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, ctr.mode, 0)
+	gobottest.Assert(t, ctr.speed, int64(1000000))
+
+	d, _, ctr = initTestADXL345Driver()
+	ctr.err = errors.New("no spi")
+	gobottest.Assert(t, d.Start(), errors.New("no spi"))
+}
+
+func TestADXL345DriverXYZ(t *testing.T) {
+	d, sim, _ := initTestADXL345Driver()
+	d.Start()
+	copy(sim.registers[0x32:], []byte{0x00, 0x01, 0x00, 0xFF, 0x80, 0x00})
+	sim.txs = nil
+
+	x, y, z, err := d.XYZ()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, x, 1.0)
+	gobottest.Assert(t, y, -1.0)
+	gobottest.Assert(t, z, 0.5)
+	// the data registers are read in one multi-byte transfer
+	gobottest.Assert(t, sim.txs, [][]byte{{0xF2, 0, 0, 0, 0, 0, 0}})
+
+	sim.err = errors.New("tx error")
+	_, _, _, err = d.XYZ()
+	gobottest.Assert(t, err, errors.New("tx error"))
+}
+
+func TestI2cBridgeConnection(t *testing.T) {
+	sim := &adxl345Simulator{}
+	c := &i2cBridgeConnection{conn: sim, readBit: 0x80, multiBit: 0x40}
+
+	gobottest.Assert(t, c.WriteByteData(0x10, 0x12), nil)
+	gobottest.Assert(t, c.WriteWordData(0x11, 0x5634), nil)
+	gobottest.Assert(t, c.WriteBlockData(0x13, []byte{0x78, 0x9A}), nil)
+	gobottest.Assert(t, sim.txs, [][]byte{{0x10, 0x12}, {0x51, 0x34, 0x56}, {0x53, 0x78, 0x9A}})
+
+	val, err := c.ReadByteData(0x11)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, uint8(0x34))
+	word, err := c.ReadWordData(0x11)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, word, uint16(0x5634))
+
+	gobottest.Assert(t, c.WriteByte(0x13), nil)
+	val, err = c.ReadByte()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, uint8(0x78))
+
+	n, err := c.Write([]byte{0x10})
+	gobottest.Assert(t, n, 1)
+	gobottest.Assert(t, err, nil)
+	data := make([]byte, 3)
+	n, err = c.Read(data)
+	gobottest.Assert(t, n, 3)
+	gobottest.Assert(t, data, []byte{0x12, 0x34, 0x56})
+	gobottest.Assert(t, c.Close(), nil)
+}
@@ -3,6 +3,7 @@ package spi
 import (
 	"image/color"
 	"math"
+	"sync"
 
 	"gobot.io/x/gobot"
 )
@@ -17,6 +18,11 @@ type APA102Driver struct {
 
 	vals       []color.RGBA
 	brightness uint8
+	scale      float64
+	gamma      [3][256]uint8
+	// the gamma tables combined with the global brightness scale
+	levels [3][256]uint8
+	mutex  sync.Mutex
 }
 
 // NewAPA102Driver creates a new Gobot Driver for APA102 RGB LEDs.
@@ -32,6 +38,8 @@ type APA102Driver struct {
 //      spi.WithMode(int):    	mode to use with this driver.
 //      spi.WithBits(int):    	number of bits to use with this driver.
 //      spi.WithSpeed(int64):   speed in Hz to use with this driver.
+//      spi.WithAPA102Gamma(float64): gamma correction to apply to all colors (defaults to 1, no correction).
+//      spi.WithAPA102GlobalBrightness(float64): scale between 0 and 1 for all colors (defaults to 1).
 //
 func NewAPA102Driver(a Connector, count int, bright uint8, options ...func(Config)) *APA102Driver {
 	d := &APA102Driver{
@@ -39,14 +47,53 @@ func NewAPA102Driver(a Connector, count int, bright uint8, options ...func(Confi
 		connector:  a,
 		vals:       make([]color.RGBA, count),
 		brightness: uint8(math.Min(float64(bright), 31)),
+		scale:      1,
 		Config:     NewConfig(),
 	}
+	table := APA102GammaTable(1)
+	d.gamma = [3][256]uint8{table, table, table}
 	for _, option := range options {
 		option(d)
 	}
+	d.updateLevels()
 	return d
 }
 
+// WithAPA102Gamma option sets the gamma correction for all colors of the strip.
+func WithAPA102Gamma(gamma float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*APA102Driver)
+		if ok {
+			table := APA102GammaTable(gamma)
+			d.gamma = [3][256]uint8{table, table, table}
+		} else {
+			panic("unable to set gamma for apa102")
+		}
+	}
+}
+
+// WithAPA102GlobalBrightness option sets the global brightness scale of the strip.
+func WithAPA102GlobalBrightness(scale float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*APA102Driver)
+		if ok {
+			d.scale = math.Max(0, math.Min(scale, 1))
+		} else {
+			panic("unable to set global brightness for apa102")
+		}
+	}
+}
+
+// APA102GammaTable returns a gamma correction table, which maps a color value
+// to the value to send to the LED. A gamma of 2.8 matches the perception of
+// the human eye well, a gamma of 1 returns the identity.
+func APA102GammaTable(gamma float64) (table [256]uint8) {
+	for i := range table {
+		table[i] = uint8(math.Pow(float64(i)/255, gamma)*255 + 0.5)
+	}
+	return
+}
+
 // Name returns the name of the device.
 func (d *APA102Driver) Name() string { return d.name }
 
@@ -80,22 +127,79 @@ func (d *APA102Driver) Halt() (err error) {
 // A subsequent call to Draw is required to transmit values
 // to the LED strip.
 func (d *APA102Driver) SetRGBA(i int, v color.RGBA) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 	d.vals[i] = v
 }
 
+// SetFrame sets the colors of all LEDs and transmits them to the LED strip
+// at once. This avoids the flicker of incremental updates on long strips.
+// Missing values turn the LEDs off, surplus values are ignored.
+func (d *APA102Driver) SetFrame(vals []color.RGBA) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	n := copy(d.vals, vals)
+	for i := n; i < len(d.vals); i++ {
+		d.vals[i] = color.RGBA{}
+	}
+	return d.draw()
+}
+
+// SetGamma sets the gamma correction for all colors of the strip.
+func (d *APA102Driver) SetGamma(gamma float64) {
+	table := APA102GammaTable(gamma)
+	d.SetGammaTables(table, table, table)
+}
+
+// SetGammaTables sets a separate correction table for each color of the strip,
+// e.g. to adjust the white balance.
+func (d *APA102Driver) SetGammaTables(r, g, b [256]uint8) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.gamma = [3][256]uint8{r, g, b}
+	d.updateLevels()
+}
+
+// SetGlobalBrightness scales all colors of the strip by the value between 0
+// and 1. In contrast to SetBrightness, the full range of the 5-bit brightness
+// is kept for dimmed colors.
+func (d *APA102Driver) SetGlobalBrightness(scale float64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.scale = math.Max(0, math.Min(scale, 1))
+	d.updateLevels()
+}
+
+// GlobalBrightness returns the global brightness scale.
+func (d *APA102Driver) GlobalBrightness() float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.scale
+}
+
 // SetBrightness sets the ith LED's brightness to the given value.
 // Must be between 0 and 31.
 func (d *APA102Driver) SetBrightness(i uint8) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 	d.brightness = uint8(math.Min(float64(i), 31))
 }
 
 // Brightness return driver brightness value.
 func (d *APA102Driver) Brightness() uint8 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
 	return d.brightness
 }
 
 // Draw displays the RGBA values set on the actual LED strip.
 func (d *APA102Driver) Draw() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.draw()
+}
+
+func (d *APA102Driver) draw() error {
 	// TODO(jbd): dotstar allows other RGBA alignments, support those layouts.
 	n := len(d.vals)
 
@@ -112,9 +216,9 @@ func (d *APA102Driver) Draw() error {
 		} else {
 			tx[j] = 0xe0 + byte(d.brightness)
 		}
-		tx[j+1] = c.B
-		tx[j+2] = c.G
-		tx[j+3] = c.R
+		tx[j+1] = d.levels[2][c.B]
+		tx[j+2] = d.levels[1][c.G]
+		tx[j+3] = d.levels[0][c.R]
 	}
 
 	// end frame with at least n/2 0xff vals
@@ -122,5 +226,13 @@ func (d *APA102Driver) Draw() error {
 		tx[i] = 0xff
 	}
 
-	return d.connection.Tx(tx, nil)
+	return writeChunked(d.connection, tx)
+}
+
+func (d *APA102Driver) updateLevels() {
+	for c := range d.levels {
+		for i, v := range d.gamma[c] {
+			d.levels[c][i] = uint8(float64(v)*d.scale + 0.5)
+		}
+	}
 }
@@ -37,3 +37,70 @@ func TestDriverLEDs(t *testing.T) {
 
 	gobottest.Assert(t, d.Draw(), nil)
 }
+
+func initTestAPA102Driver(count int, options ...func(Config)) (*APA102Driver, *spiTestRecorder) {
+	rec := &spiTestRecorder{}
+	d := NewAPA102Driver(&spiTestConnector{conn: rec}, count, 31, options...)
+	d.Start()
+	return d, rec
+}
+
+func TestAPA102DriverGamma(t *testing.T) {
+	table := APA102GammaTable(1)
+	gobottest.Assert(t, table[0], uint8(0))
+	gobottest.Assert(t, table[128], uint8(128))
+	gobottest.Assert(t, table[255], uint8(255))
+	table = APA102GammaTable(2.8)
+	gobottest.Assert(t, table[128], uint8(37))
+	gobottest.Assert(t, table[255], uint8(255))
+
+	d, rec := initTestAPA102Driver(1, WithAPA102Gamma(2.8))
+	d.SetRGBA(0, color.RGBA{R: 128, G: 255, B: 0})
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, rec.written()[4:8], []byte{0xFF, 0, 255, 37})
+
+	var dark [256]uint8
+	d.SetGammaTables(APA102GammaTable(1), dark, APA102GammaTable(1))
+	d.SetRGBA(0, color.RGBA{R: 128, G: 255, B: 10})
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, rec.written()[4:8], []byte{0xFF, 10, 0, 128})
+
+	d.SetGamma(1)
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, rec.written()[4:8], []byte{0xFF, 10, 255, 128})
+}
+
+func TestAPA102DriverGlobalBrightness(t *testing.T) {
+	d, rec := initTestAPA102Driver(1, WithAPA102GlobalBrightness(0.5))
+	gobottest.Assert(t, d.GlobalBrightness(), 0.5)
+	d.SetRGBA(0, color.RGBA{R: 255, G: 100, B: 1, A: 7})
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, rec.written()[4:8], []byte{0xE7, 1, 50, 128})
+
+	d.SetGlobalBrightness(2)
+	gobottest.Assert(t, d.GlobalBrightness(), 1.0)
+	d.SetGlobalBrightness(-1)
+	gobottest.Assert(t, d.GlobalBrightness(), 0.0)
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, rec.written()[4:8], []byte{0xE7, 0, 0, 0})
+}
+
+func TestAPA102DriverSetFrame(t *testing.T) {
+	d, rec := initTestAPA102Driver(3)
+	d.SetRGBA(2, color.RGBA{R: 1, G: 2, B: 3})
+	err := d.SetFrame([]color.RGBA{{R: 10}, {G: 20}})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, rec.written(), []byte{
+		0x00, 0x00, 0x00, 0x00,
+		0xFF, 0, 0, 10,
+		0xFF, 0, 20, 0,
+		0xFF, 0, 0, 0,
+		0xFF, 0xFF,
+	})
+
+	// long strips are transmitted in chunks
+	d, rec = initTestAPA102Driver(2000)
+	gobottest.Assert(t, d.SetFrame(make([]color.RGBA, 3000)), nil)
+	gobottest.Assert(t, rec.txs, 3)
+	gobottest.Assert(t, len(rec.written()), 4*2001+1001)
+}
@@ -0,0 +1,148 @@
+package spi
+
+import (
+	"encoding/binary"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/i2c"
+)
+
+// i2cBridge lets an i2c driver talk to a device, which provides the same
+// registers by SPI. The first byte of a SPI transfer is the register address
+// with a read bit and a bit for multi-byte transfers.
+type i2cBridge struct {
+	Connector
+	gobot.Connection
+	Config
+	mode     int
+	maxSpeed int64
+	readBit  byte
+	multiBit byte
+}
+
+func newI2cBridge(a Connector, mode int, maxSpeed int64, readBit byte, multiBit byte) *i2cBridge {
+	b := &i2cBridge{
+		Connector: a,
+		Config:    NewConfig(),
+		mode:      mode,
+		maxSpeed:  maxSpeed,
+		readBit:   readBit,
+		multiBit:  multiBit,
+	}
+	if c, ok := a.(gobot.Connection); ok {
+		b.Connection = c
+	}
+	return b
+}
+
+// GetConnection returns a connection to the SPI device, the i2c address and
+// bus are ignored.
+func (b *i2cBridge) GetConnection(address int, bus int) (i2c.Connection, error) {
+	spiBus := b.GetBusOrDefault(b.GetSpiDefaultBus())
+	chip := b.GetChipOrDefault(b.GetSpiDefaultChip())
+	mode := b.GetModeOrDefault(b.mode)
+	bits := b.GetBitsOrDefault(b.GetSpiDefaultBits())
+	maxSpeed := b.GetSpeedOrDefault(b.maxSpeed)
+
+	conn, err := b.GetSpiConnection(spiBus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return nil, err
+	}
+	return &i2cBridgeConnection{conn: conn, readBit: b.readBit, multiBit: b.multiBit}, nil
+}
+
+// GetDefaultBus returns the default SPI bus.
+func (b *i2cBridge) GetDefaultBus() int {
+	return b.GetSpiDefaultBus()
+}
+
+// i2cBridgeConnection implements the i2c operations by SPI transfers. As with
+// i2c, a write of a single byte sets the register for the following reads.
+type i2cBridgeConnection struct {
+	conn     Connection
+	readBit  byte
+	multiBit byte
+	reg      byte
+}
+
+func (c *i2cBridgeConnection) Close() error {
+	return c.conn.Close()
+}
+
+func (c *i2cBridgeConnection) Read(data []byte) (int, error) {
+	if err := c.readRegisters(c.reg, data); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (c *i2cBridgeConnection) Write(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	if err := c.writeRegisters(data[0], data[1:]...); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (c *i2cBridgeConnection) ReadByte() (byte, error) {
+	data := make([]byte, 1)
+	err := c.readRegisters(c.reg, data)
+	return data[0], err
+}
+
+func (c *i2cBridgeConnection) ReadByteData(reg uint8) (uint8, error) {
+	data := make([]byte, 1)
+	err := c.readRegisters(reg, data)
+	return data[0], err
+}
+
+func (c *i2cBridgeConnection) ReadWordData(reg uint8) (uint16, error) {
+	data := make([]byte, 2)
+	err := c.readRegisters(reg, data)
+	return binary.LittleEndian.Uint16(data), err
+}
+
+func (c *i2cBridgeConnection) WriteByte(val byte) error {
+	return c.writeRegisters(val)
+}
+
+func (c *i2cBridgeConnection) WriteByteData(reg uint8, val uint8) error {
+	return c.writeRegisters(reg, val)
+}
+
+func (c *i2cBridgeConnection) WriteWordData(reg uint8, val uint16) error {
+	return c.writeRegisters(reg, byte(val), byte(val>>8))
+}
+
+func (c *i2cBridgeConnection) WriteBlockData(reg uint8, data []byte) error {
+	return c.writeRegisters(reg, data...)
+}
+
+func (c *i2cBridgeConnection) readRegisters(reg byte, data []byte) error {
+	c.reg = reg
+	w := make([]byte, len(data)+1)
+	w[0] = reg | c.readBit
+	if len(data) > 1 {
+		w[0] |= c.multiBit
+	}
+	r := make([]byte, len(w))
+	if err := c.conn.Tx(w, r); err != nil {
+		return err
+	}
+	copy(data, r[1:])
+	return nil
+}
+
+func (c *i2cBridgeConnection) writeRegisters(reg byte, data ...byte) error {
+	c.reg = reg
+	if len(data) == 0 {
+		return nil
+	}
+	w := append([]byte{reg}, data...)
+	if len(data) > 1 {
+		w[0] |= c.multiBit
+	}
+	return c.conn.Tx(w, nil)
+}
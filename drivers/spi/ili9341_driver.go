@@ -0,0 +1,473 @@
+package spi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	// default values
+	ili9341Width  = 240
+	ili9341Height = 320
+	ili9341DcPin  = "16" // for raspberry pi
+	ili9341RstPin = "18" // for raspberry pi
+
+	ili9341SoftwareReset  = 0x01
+	ili9341SleepOut       = 0x11
+	ili9341GammaSet       = 0x26
+	ili9341DisplayOff     = 0x28
+	ili9341DisplayOn      = 0x29
+	ili9341ColumnAddr     = 0x2A
+	ili9341PageAddr       = 0x2B
+	ili9341MemoryWrite    = 0x2C
+	ili9341MemoryAccess   = 0x36
+	ili9341VerticalScroll = 0x37
+	ili9341PixelFormat    = 0x3A
+	ili9341FrameControl   = 0xB1
+	ili9341FunctionCtrl   = 0xB6
+	ili9341PowerControl1  = 0xC0
+	ili9341PowerControl2  = 0xC1
+	ili9341VCOMControl1   = 0xC5
+	ili9341VCOMControl2   = 0xC7
+	ili9341PositiveGamma  = 0xE0
+	ili9341NegativeGamma  = 0xE1
+
+	// RGB565
+	ili9341PixelFormat16Bit = 0x55
+	// memory access control bits for the row/column and color order
+	ili9341MADCTLMY  = 0x80
+	ili9341MADCTLMX  = 0x40
+	ili9341MADCTLMV  = 0x20
+	ili9341MADCTLBGR = 0x08
+)
+
+// ili9341Init is the power, timing and gamma setup of the usual 2.4" and
+// 2.8" modules, each entry is a command followed by its parameters
+var ili9341Init = [][]byte{
+	{0xEF, 0x03, 0x80, 0x02},
+	{0xCF, 0x00, 0xC1, 0x30},
+	{0xED, 0x64, 0x03, 0x12, 0x81},
+	{0xE8, 0x85, 0x00, 0x78},
+	{0xCB, 0x39, 0x2C, 0x00, 0x34, 0x02},
+	{0xF7, 0x20},
+	{0xEA, 0x00, 0x00},
+	{ili9341PowerControl1, 0x23},
+	{ili9341PowerControl2, 0x10},
+	{ili9341VCOMControl1, 0x3E, 0x28},
+	{ili9341VCOMControl2, 0x86},
+	{ili9341VerticalScroll, 0x00},
+	{ili9341PixelFormat, ili9341PixelFormat16Bit},
+	{ili9341FrameControl, 0x00, 0x18},
+	{ili9341FunctionCtrl, 0x08, 0x82, 0x27},
+	{0xF2, 0x00},
+	{ili9341GammaSet, 0x01},
+	{ili9341PositiveGamma, 0x0F, 0x31, 0x2B, 0x0C, 0x0E, 0x08, 0x4E, 0xF1, 0x37, 0x07, 0x10, 0x03, 0x0E, 0x09, 0x00},
+	{ili9341NegativeGamma, 0x00, 0x0E, 0x14, 0x03, 0x11, 0x07, 0x31, 0xC1, 0x48, 0x08, 0x0F, 0x0C, 0x31, 0x36, 0x0F},
+}
+
+// ILI9341Driver is a Gobot Driver for a ILI9341 TFT display with 240x320
+// pixels. The drawing is done in a backbuffer, Display sends only the part
+// which has changed since the last call to the display.
+type ILI9341Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	dcDriver   *gpio.DirectPinDriver
+	rstDriver  *gpio.DirectPinDriver
+	rotation   int
+	DCPin      string
+	RSTPin     string
+	buffer     *image.RGBA
+	shown      *image.RGBA
+	mutex      sync.Mutex
+	Config
+	gobot.Commander
+	gobot.CommandSchemer
+}
+
+// NewILI9341Driver creates a new ILI9341Driver.
+//
+// Params:
+//      conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                   bus to use with this driver
+//      spi.WithChip(int):                  chip to use with this driver
+//      spi.WithMode(int):                  mode to use with this driver
+//      spi.WithBits(int):                  number of bits to use with this driver
+//      spi.WithSpeed(int64):               speed in Hz to use with this driver
+//      spi.WithILI9341Rotation(int):       rotation of 0, 90, 180 or 270 degrees (defaults to 0)
+//      spi.WithILI9341DCPin(string):       gpio pin number connected to dc pin on display (defaults to "16")
+//      spi.WithILI9341RstPin(string):      gpio pin number connected to rst pin on display (defaults to "18")
+//
+func NewILI9341Driver(a gobot.Adaptor, options ...func(Config)) *ILI9341Driver {
+	// cast adaptor to spi connector since we also need the adaptor for gpio
+	b, ok := a.(Connector)
+	if !ok {
+		panic("unable to get gobot connector for ili9341")
+	}
+	s := &ILI9341Driver{
+		name:           gobot.DefaultName("ILI9341"),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		connector:      b,
+		DCPin:          ili9341DcPin,
+		RSTPin:         ili9341RstPin,
+		Config:         NewConfig(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.dcDriver = gpio.NewDirectPinDriver(a, s.DCPin)
+	s.rstDriver = gpio.NewDirectPinDriver(a, s.RSTPin)
+	s.buffer = image.NewRGBA(s.bounds())
+
+	s.AddCommand("Display", func(params map[string]interface{}) interface{} {
+		err := s.Display()
+		return map[string]interface{}{"err": err}
+	})
+	s.AddCommand("On", func(params map[string]interface{}) interface{} {
+		err := s.On()
+		return map[string]interface{}{"err": err}
+	})
+	s.AddCommand("Off", func(params map[string]interface{}) interface{} {
+		err := s.Off()
+		return map[string]interface{}{"err": err}
+	})
+	s.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		s.Clear(color.Black)
+		return nil
+	})
+	s.AddCommand("DrawText", func(params map[string]interface{}) interface{} {
+		x, _ := params["x"].(float64)
+		y, _ := params["y"].(float64)
+		text, _ := params["text"].(string)
+		s.DrawText(image.Pt(int(x), int(y)), text, color.White, 1)
+		return nil
+	})
+	s.AddCommandSchema("DrawText", gobot.CommandSchema{
+		"x":    gobot.CommandParamNumber,
+		"y":    gobot.CommandParamNumber,
+		"text": gobot.CommandParamString,
+	})
+	return s
+}
+
+// WithILI9341Rotation option sets the rotation of the display in degrees.
+func WithILI9341Rotation(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ILI9341Driver)
+		if ok {
+			d.rotation = val
+		} else {
+			panic("unable to set rotation for ili9341")
+		}
+	}
+}
+
+// WithILI9341DCPin option sets the ILI9341Driver DC pin option.
+func WithILI9341DCPin(val string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ILI9341Driver)
+		if ok {
+			d.DCPin = val
+		} else {
+			panic("unable to set dc pin for ili9341")
+		}
+	}
+}
+
+// WithILI9341RstPin option sets the ILI9341Driver RST pin option.
+func WithILI9341RstPin(val string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ILI9341Driver)
+		if ok {
+			d.RSTPin = val
+		} else {
+			panic("unable to set rst pin for ili9341")
+		}
+	}
+}
+
+// Name returns the Name for the Driver
+func (s *ILI9341Driver) Name() string { return s.name }
+
+// SetName sets the Name for the Driver
+func (s *ILI9341Driver) SetName(n string) { s.name = n }
+
+// Connection returns the connection for the Driver
+func (s *ILI9341Driver) Connection() gobot.Connection { return s.connector.(gobot.Connection) }
+
+// Start sets up the needed connection, and initializes the device.
+func (s *ILI9341Driver) Start() (err error) {
+	if err = s.checkRotation(s.rotation); err != nil {
+		return
+	}
+
+	bus := s.GetBusOrDefault(s.connector.GetSpiDefaultBus())
+	chip := s.GetChipOrDefault(s.connector.GetSpiDefaultChip())
+	mode := s.GetModeOrDefault(s.connector.GetSpiDefaultMode())
+	bits := s.GetBitsOrDefault(s.connector.GetSpiDefaultBits())
+	maxSpeed := s.GetSpeedOrDefault(s.connector.GetSpiDefaultMaxSpeed())
+
+	s.connection, err = s.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err = s.reset(); err != nil {
+		return
+	}
+	if err = s.command(ili9341SoftwareReset); err != nil {
+		return
+	}
+	time.Sleep(150 * time.Millisecond)
+	for _, c := range ili9341Init {
+		if err = s.command(c[0], c[1:]...); err != nil {
+			return
+		}
+	}
+	if err = s.command(ili9341MemoryAccess, s.memoryAccess()); err != nil {
+		return
+	}
+	if err = s.command(ili9341SleepOut); err != nil {
+		return
+	}
+	time.Sleep(150 * time.Millisecond)
+	// the content of the display memory is unknown now
+	s.shown = nil
+	return s.command(ili9341DisplayOn)
+}
+
+// Halt turns the display off.
+func (s *ILI9341Driver) Halt() (err error) {
+	if s.connection == nil {
+		return
+	}
+	return s.Off()
+}
+
+// On turns on the display.
+func (s *ILI9341Driver) On() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.command(ili9341DisplayOn)
+}
+
+// Off turns off the display.
+func (s *ILI9341Driver) Off() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.command(ili9341DisplayOff)
+}
+
+// Bounds returns the size of the display in the current rotation.
+func (s *ILI9341Driver) Bounds() image.Rectangle {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.buffer.Bounds()
+}
+
+// Buffer returns the backbuffer, which can be drawn on, e.g. with the image/draw
+// package. Call Display to show the changes. The backbuffer is replaced by
+// SetRotation.
+func (s *ILI9341Driver) Buffer() *image.RGBA {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.buffer
+}
+
+// SetRotation sets the rotation of 0, 90, 180 or 270 degrees and clears the backbuffer.
+func (s *ILI9341Driver) SetRotation(rotation int) (err error) {
+	if err = s.checkRotation(rotation); err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.rotation = rotation
+	s.buffer = image.NewRGBA(s.bounds())
+	s.shown = nil
+	if s.connection == nil {
+		return
+	}
+	return s.command(ili9341MemoryAccess, s.memoryAccess())
+}
+
+// Clear fills the backbuffer with the color.
+func (s *ILI9341Driver) Clear(c color.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	FillRect(s.buffer, s.buffer.Bounds(), c)
+}
+
+// DrawImage draws the image into the backbuffer with its upper left corner at the point.
+func (s *ILI9341Driver) DrawImage(at image.Point, img image.Image) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	r := image.Rectangle{Min: at, Max: at.Add(img.Bounds().Size())}
+	draw.Draw(s.buffer, r, img, img.Bounds().Min, draw.Src)
+}
+
+// DrawLine draws a line from p0 to p1 into the backbuffer.
+func (s *ILI9341Driver) DrawLine(p0 image.Point, p1 image.Point, c color.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	DrawLine(s.buffer, p0, p1, c)
+}
+
+// DrawRect draws the outline of the rectangle into the backbuffer.
+func (s *ILI9341Driver) DrawRect(r image.Rectangle, c color.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	DrawRect(s.buffer, r, c)
+}
+
+// FillRect fills the rectangle of the backbuffer with the color.
+func (s *ILI9341Driver) FillRect(r image.Rectangle, c color.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	FillRect(s.buffer, r, c)
+}
+
+// DrawText draws the text with the embedded 5x7 font into the backbuffer,
+// see the DrawText function for details.
+func (s *ILI9341Driver) DrawText(at image.Point, text string, c color.Color, scale int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	DrawText(s.buffer, at, text, c, scale)
+}
+
+// Display sends the part of the backbuffer, which has changed since the last
+// call, to the display. The whole backbuffer is sent on the first call.
+func (s *ILI9341Driver) Display() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r := s.buffer.Bounds()
+	if s.shown != nil {
+		r = s.changes()
+	}
+	if err = s.displayRect(r); err != nil {
+		return
+	}
+	if s.shown == nil {
+		s.shown = image.NewRGBA(s.buffer.Bounds())
+	}
+	copy(s.shown.Pix, s.buffer.Pix)
+	return
+}
+
+// DisplayRect sends the part of the backbuffer within the rectangle to the display.
+func (s *ILI9341Driver) DisplayRect(r image.Rectangle) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r = r.Canon().Intersect(s.buffer.Bounds())
+	if err = s.displayRect(r); err != nil {
+		return
+	}
+	if s.shown != nil {
+		draw.Draw(s.shown, r, s.buffer, r.Min, draw.Src)
+	}
+	return
+}
+
+func (s *ILI9341Driver) displayRect(r image.Rectangle) (err error) {
+	if r.Empty() {
+		return
+	}
+
+	x0, y0 := r.Min.X, r.Min.Y
+	x1, y1 := r.Max.X-1, r.Max.Y-1
+	if err = s.command(ili9341ColumnAddr, byte(x0>>8), byte(x0), byte(x1>>8), byte(x1)); err != nil {
+		return
+	}
+	if err = s.command(ili9341PageAddr, byte(y0>>8), byte(y0), byte(y1>>8), byte(y1)); err != nil {
+		return
+	}
+
+	return s.command(ili9341MemoryWrite, rgb565(s.buffer, r)...)
+}
+
+// changes returns the smallest rectangle containing all pixels of the
+// backbuffer, which differ from the shown ones
+func (s *ILI9341Driver) changes() image.Rectangle {
+	var r image.Rectangle
+	b := s.buffer.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if s.buffer.RGBAAt(x, y) != s.shown.RGBAAt(x, y) {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+func (s *ILI9341Driver) checkRotation(rotation int) error {
+	switch rotation {
+	case 0, 90, 180, 270:
+		return nil
+	}
+	return fmt.Errorf("Rotation must be one of: 0, 90, 180, 270")
+}
+
+func (s *ILI9341Driver) bounds() image.Rectangle {
+	if s.rotation == 90 || s.rotation == 270 {
+		return image.Rect(0, 0, ili9341Height, ili9341Width)
+	}
+	return image.Rect(0, 0, ili9341Width, ili9341Height)
+}
+
+func (s *ILI9341Driver) memoryAccess() byte {
+	switch s.rotation {
+	case 90:
+		return ili9341MADCTLMV | ili9341MADCTLBGR
+	case 180:
+		return ili9341MADCTLMY | ili9341MADCTLBGR
+	case 270:
+		return ili9341MADCTLMX | ili9341MADCTLMY | ili9341MADCTLMV | ili9341MADCTLBGR
+	}
+	return ili9341MADCTLMX | ili9341MADCTLBGR
+}
+
+func (s *ILI9341Driver) reset() (err error) {
+	if err = s.rstDriver.DigitalWrite(0); err != nil {
+		return
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err = s.rstDriver.DigitalWrite(1); err != nil {
+		return
+	}
+	time.Sleep(120 * time.Millisecond)
+	return
+}
+
+// command sends the command followed by its parameters or data
+func (s *ILI9341Driver) command(cmd byte, data ...byte) (err error) {
+	if err = s.dcDriver.DigitalWrite(0); err != nil {
+		return
+	}
+	if err = s.connection.Tx([]byte{cmd}, nil); err != nil {
+		return
+	}
+	if len(data) == 0 {
+		return
+	}
+	if err = s.dcDriver.DigitalWrite(1); err != nil {
+		return
+	}
+	return writeChunked(s.connection, data)
+}
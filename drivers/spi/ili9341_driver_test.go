@@ -0,0 +1,147 @@
+package spi
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*ILI9341Driver)(nil)
+
+func initTestILI9341Driver(options ...func(Config)) (*ILI9341Driver, *displayTestAdaptor) {
+	a := newDisplayTestAdaptor()
+	return NewILI9341Driver(a, options...), a
+}
+
+func TestILI9341Driver(t *testing.T) {
+	d, a := initTestILI9341Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "ILI9341"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 240, 320))
+	gobottest.Assert(t, d.DCPin, "16")
+	gobottest.Assert(t, d.RSTPin, "18")
+
+	d.SetName("tft")
+	gobottest.Assert(t, d.Name(), "tft")
+
+	d, _ = initTestILI9341Driver(WithILI9341Rotation(270), WithILI9341DCPin("22"), WithILI9341RstPin("23"))
+	gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 320, 240))
+	gobottest.Assert(t, d.DCPin, "22")
+	gobottest.Assert(t, d.RSTPin, "23")
+}
+
+func TestILI9341DriverStart(t *testing.T) {
+	d, a := initTestILI9341Driver(WithILI9341Rotation(90))
+	gobottest.Assert(t, d.Start(), nil)
+
+	gobottest.Assert(t, a.commands[0].cmd, byte(ili9341SoftwareReset))
+	gobottest.Assert(t, a.commands[len(a.commands)-1].cmd, byte(ili9341DisplayOn))
+	data, _ := a.command(ili9341PixelFormat)
+	gobottest.Assert(t, data, []byte{0x55})
+	data, _ = a.command(ili9341MemoryAccess)
+	gobottest.Assert(t, data, []byte{0x28})
+	_, ok := a.command(ili9341SleepOut)
+	gobottest.Assert(t, ok, true)
+
+	a.reset()
+	gobottest.Assert(t, d.Halt(), nil)
+	_, ok = a.command(ili9341DisplayOff)
+	gobottest.Assert(t, ok, true)
+
+	d, _ = initTestILI9341Driver(WithILI9341Rotation(45))
+	gobottest.Assert(t, d.Start(), errors.New("Rotation must be one of: 0, 90, 180, 270"))
+
+	d, a = initTestILI9341Driver()
+	a.err = errors.New("tx error")
+	gobottest.Assert(t, d.Start(), errors.New("tx error"))
+}
+
+func TestILI9341DriverDisplay(t *testing.T) {
+	d, a := initTestILI9341Driver()
+	d.Start()
+	a.reset()
+
+	// the whole backbuffer is sent first
+	d.Clear(color.RGBA{B: 0xFF, A: 0xFF})
+	gobottest.Assert(t, d.Display(), nil)
+	data, _ := a.command(ili9341ColumnAddr)
+	gobottest.Assert(t, data, []byte{0, 0, 0, 239})
+	data, _ = a.command(ili9341PageAddr)
+	gobottest.Assert(t, data, []byte{0, 0, 1, 63})
+	data, _ = a.command(ili9341MemoryWrite)
+	gobottest.Assert(t, len(data), 240*320*2)
+	gobottest.Assert(t, data[:2], []byte{0x00, 0x1F})
+	gobottest.Assert(t, a.maxTx, maxTxSize)
+
+	// only the changes afterwards
+	a.reset()
+	d.DrawLine(image.Pt(10, 20), image.Pt(12, 22), color.White)
+	d.DrawRect(image.Rect(30, 5, 33, 8), color.White)
+	gobottest.Assert(t, d.Display(), nil)
+	data, _ = a.command(ili9341ColumnAddr)
+	gobottest.Assert(t, data, []byte{0, 10, 0, 32})
+	data, _ = a.command(ili9341PageAddr)
+	gobottest.Assert(t, data, []byte{0, 5, 0, 22})
+	data, _ = a.command(ili9341MemoryWrite)
+	gobottest.Assert(t, len(data), 23*18*2)
+
+	a.reset()
+	gobottest.Assert(t, d.Display(), nil)
+	gobottest.Assert(t, len(a.commands), 0)
+
+	d.FillRect(image.Rect(0, 0, 2, 2), color.Black)
+	gobottest.Assert(t, d.DisplayRect(image.Rect(0, 0, 2, 2)), nil)
+	data, _ = a.command(ili9341MemoryWrite)
+	gobottest.Assert(t, data, make([]byte, 8))
+	a.reset()
+	gobottest.Assert(t, d.Display(), nil)
+	gobottest.Assert(t, len(a.commands), 0)
+
+	// a new rotation needs the whole backbuffer again
+	gobottest.Assert(t, d.SetRotation(180), nil)
+	data, _ = a.command(ili9341MemoryAccess)
+	gobottest.Assert(t, data, []byte{0x88})
+	gobottest.Assert(t, d.Display(), nil)
+	data, _ = a.command(ili9341MemoryWrite)
+	gobottest.Assert(t, len(data), 240*320*2)
+	gobottest.Refute(t, d.SetRotation(30), nil)
+
+	gobottest.Assert(t, d.Command("Display")(nil), map[string]interface{}{"err": nil})
+}
+
+func TestILI9341DriverDrawText(t *testing.T) {
+	d, _ := initTestILI9341Driver()
+	white := color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+	d.Command("DrawText")(map[string]interface{}{"x": 1.0, "y": 2.0, "text": "|"})
+	// the column 2 of '|' is set for all 7 rows
+	gobottest.Assert(t, d.Buffer().RGBAAt(3, 2), white)
+	gobottest.Assert(t, d.Buffer().RGBAAt(3, 8), white)
+	gobottest.Assert(t, d.Buffer().RGBAAt(3, 9), color.RGBA{})
+	gobottest.Assert(t, d.Buffer().RGBAAt(2, 2), color.RGBA{})
+}
+
+func TestDrawText(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 30, 32))
+	white := color.Gray{Y: 0xFF}
+
+	DrawText(img, image.Pt(0, 0), "-\n-", white, 2)
+	// '-' is the 4th row of all columns
+	gobottest.Assert(t, img.GrayAt(0, 6), white)
+	gobottest.Assert(t, img.GrayAt(9, 7), white)
+	gobottest.Assert(t, img.GrayAt(10, 6), color.Gray{})
+	gobottest.Assert(t, img.GrayAt(0, 5), color.Gray{})
+	// the newline starts 8 scaled rows below
+	gobottest.Assert(t, img.GrayAt(0, 16+6), white)
+	gobottest.Assert(t, img.GrayAt(0, 16+5), color.Gray{})
+
+	img = image.NewGray(image.Rect(0, 0, 12, 8))
+	DrawText(img, image.Pt(0, 0), "\x01", white, 0)
+	// unknown characters are drawn as '?'
+	gobottest.Assert(t, img.GrayAt(1, 0), white)
+}
@@ -0,0 +1,401 @@
+package spi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// thermocouple types
+	MAX31856TypeB = 0x00
+	MAX31856TypeE = 0x01
+	MAX31856TypeJ = 0x02
+	MAX31856TypeK = 0x03
+	MAX31856TypeN = 0x04
+	MAX31856TypeR = 0x05
+	MAX31856TypeS = 0x06
+	MAX31856TypeT = 0x07
+
+	// fault bits of the fault status and fault mask register
+	MAX31856FaultCJRange = 0x80
+	MAX31856FaultTCRange = 0x40
+	MAX31856FaultCJHigh  = 0x20
+	MAX31856FaultCJLow   = 0x10
+	MAX31856FaultTCHigh  = 0x08
+	MAX31856FaultTCLow   = 0x04
+	MAX31856FaultVoltage = 0x02
+	MAX31856FaultOpen    = 0x01
+
+	max31856RegCR0         = 0x00
+	max31856RegCR1         = 0x01
+	max31856RegMask        = 0x02
+	max31856RegCJOffset    = 0x09
+	max31856RegCJTemp      = 0x0A
+	max31856RegTCTemp      = 0x0C
+	max31856RegFaultStatus = 0x0F
+	max31856Write          = 0x80
+
+	max31856CR0Auto        = 0x80
+	max31856CR0OpenFault   = 0x10
+	max31856CR0FaultClear  = 0x02
+	max31856CR0Filter50Hz  = 0x01
+	max31856CR1AveragesPos = 4
+)
+
+var max31856Averages = map[int]byte{1: 0x00, 2: 0x01, 4: 0x02, 8: 0x03, 16: 0x04}
+
+var max31856Faults = []struct {
+	bit  byte
+	text string
+}{
+	{MAX31856FaultCJRange, "cold junction out of range"},
+	{MAX31856FaultTCRange, "thermocouple out of range"},
+	{MAX31856FaultCJHigh, "cold junction high"},
+	{MAX31856FaultCJLow, "cold junction low"},
+	{MAX31856FaultTCHigh, "thermocouple high"},
+	{MAX31856FaultTCLow, "thermocouple low"},
+	{MAX31856FaultVoltage, "over or under voltage"},
+	{MAX31856FaultOpen, "thermocouple open"},
+}
+
+// MAX31856FaultError is returned when the MAX31856 detects a fault, it
+// contains the fault status register
+type MAX31856FaultError byte
+
+func (f MAX31856FaultError) Error() string {
+	var faults []string
+	for _, fault := range max31856Faults {
+		if byte(f)&fault.bit != 0 {
+			faults = append(faults, fault.text)
+		}
+	}
+	return fmt.Sprintf("MAX31856 fault 0x%02X: %s", byte(f), strings.Join(faults, ", "))
+}
+
+// MAX31856Driver is a driver for the MAX31856 thermocouple-to-digital
+// converter, which supports the thermocouple types B, E, J, K, N, R, S and T
+// with cold-junction compensation and linearization. The device converts
+// continuously, the driver polls the temperature and publishes it as Data
+// event, detected faults as Fault event.
+type MAX31856Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	tcType     byte
+	averages   int
+	filter50Hz bool
+	faultMask  byte
+	interval   time.Duration
+	halt       chan bool
+	done       chan bool
+	mutex      sync.Mutex
+	Config
+	gobot.Commander
+	gobot.Eventer
+}
+
+// NewMAX31856Driver creates a new Gobot Driver for the MAX31856 thermocouple-to-digital converter.
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                        bus to use with this driver
+//      spi.WithChip(int):                       chip to use with this driver
+//      spi.WithMode(int):                       mode to use with this driver (defaults to 1)
+//      spi.WithBits(int):                       number of bits to use with this driver
+//      spi.WithSpeed(int64):                    speed in Hz to use with this driver
+//      spi.WithMAX31856Type(byte):              one of the MAX31856Type* values (defaults to type K)
+//      spi.WithMAX31856Averages(int):           averaged samples 1, 2, 4, 8 or 16 (defaults to 1)
+//      spi.WithMAX31856Filter50Hz(bool):        filter 50Hz instead of 60Hz (defaults to false)
+//      spi.WithMAX31856FaultMask(byte):         faults which do not assert the FAULT pin (defaults to none)
+//      spi.WithMAX31856Interval(time.Duration): polling interval, 0 disables polling (defaults to 1s)
+//
+func NewMAX31856Driver(a Connector, options ...func(Config)) *MAX31856Driver {
+	d := &MAX31856Driver{
+		name:      gobot.DefaultName("MAX31856"),
+		connector: a,
+		tcType:    MAX31856TypeK,
+		averages:  1,
+		interval:  time.Second,
+		Config:    NewConfig(),
+		Commander: gobot.NewCommander(),
+		Eventer:   gobot.NewEventer(),
+	}
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Fault)
+	d.AddEvent(Error)
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		val, err := d.Temperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("ColdJunctionTemperature", func(params map[string]interface{}) interface{} {
+		val, err := d.ColdJunctionTemperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// WithMAX31856Type option sets the thermocouple type to one of the MAX31856Type* values.
+func WithMAX31856Type(val byte) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31856Driver)
+		if ok {
+			d.tcType = val
+		} else {
+			panic("unable to set type for max31856")
+		}
+	}
+}
+
+// WithMAX31856Averages option sets the number of samples, which are averaged
+// for each conversion.
+func WithMAX31856Averages(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31856Driver)
+		if ok {
+			d.averages = val
+		} else {
+			panic("unable to set averages for max31856")
+		}
+	}
+}
+
+// WithMAX31856Filter50Hz option sets the notch filter to 50Hz instead of 60Hz.
+func WithMAX31856Filter50Hz(val bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31856Driver)
+		if ok {
+			d.filter50Hz = val
+		} else {
+			panic("unable to set filter for max31856")
+		}
+	}
+}
+
+// WithMAX31856FaultMask option sets the faults (MAX31856Fault* bits except the
+// range faults) which do not assert the FAULT pin.
+func WithMAX31856FaultMask(val byte) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31856Driver)
+		if ok {
+			d.faultMask = val
+		} else {
+			panic("unable to set fault mask for max31856")
+		}
+	}
+}
+
+// WithMAX31856Interval option sets the polling interval, 0 disables polling.
+func WithMAX31856Interval(val time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31856Driver)
+		if ok {
+			d.interval = val
+		} else {
+			panic("unable to set interval for max31856")
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *MAX31856Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *MAX31856Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the device.
+func (d *MAX31856Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start initializes the driver, configures the device for automatic
+// conversion and starts polling.
+func (d *MAX31856Driver) Start() (err error) {
+	if d.tcType > MAX31856TypeT {
+		return fmt.Errorf("Thermocouple type must be one of the MAX31856Type* values")
+	}
+	averages, ok := max31856Averages[d.averages]
+	if !ok {
+		return fmt.Errorf("Averages must be one of: 1, 2, 4, 8, 16")
+	}
+
+	bus := d.GetBusOrDefault(d.connector.GetSpiDefaultBus())
+	chip := d.GetChipOrDefault(d.connector.GetSpiDefaultChip())
+	// the MAX31856 supports mode 1 and 3 only
+	mode := d.GetModeOrDefault(1)
+	bits := d.GetBitsOrDefault(d.connector.GetSpiDefaultBits())
+	maxSpeed := d.GetSpeedOrDefault(d.connector.GetSpiDefaultMaxSpeed())
+
+	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// CR0, CR1 and MASK
+	err = d.writeRegister(max31856RegCR0, d.cr0()|max31856CR0FaultClear,
+		averages<<max31856CR1AveragesPos|d.tcType, d.faultMask&0x3F)
+	if err != nil {
+		return
+	}
+	if d.interval > 0 {
+		d.halt = make(chan bool)
+		d.done = make(chan bool)
+		go d.poll(d.halt, d.done)
+	}
+	return
+}
+
+// Halt stops polling and the automatic conversion.
+func (d *MAX31856Driver) Halt() (err error) {
+	d.mutex.Lock()
+	halt, done := d.halt, d.done
+	d.mutex.Unlock()
+
+	if done != nil {
+		halt <- true
+		<-done
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.done = nil
+	if d.connection == nil {
+		return
+	}
+	return d.writeRegister(max31856RegCR0, d.cr0()&^max31856CR0Auto)
+}
+
+// Temperature returns the linearized and cold-junction compensated
+// temperature of the thermocouple in °C. A MAX31856FaultError is returned, if
+// a fault is detected.
+func (d *MAX31856Driver) Temperature() (temp float64, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// LTCBH, LTCBM, LTCBL and SR
+	data, err := d.readRegisters(max31856RegTCTemp, 4)
+	if err != nil {
+		return
+	}
+	if data[3] != 0 {
+		return 0, MAX31856FaultError(data[3])
+	}
+	// signed 19 bit with 0.0078125°C resolution
+	raw := int32(uint32(data[0])<<24|uint32(data[1])<<16|uint32(data[2])<<8) >> 13
+	return float64(raw) * 0.0078125, nil
+}
+
+// ColdJunctionTemperature returns the temperature of the cold junction in °C.
+func (d *MAX31856Driver) ColdJunctionTemperature() (temp float64, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data, err := d.readRegisters(max31856RegCJTemp, 2)
+	if err != nil {
+		return
+	}
+	// signed 14 bit with 0.015625°C resolution
+	raw := int16(uint16(data[0])<<8|uint16(data[1])) >> 2
+	return float64(raw) * 0.015625, nil
+}
+
+// SetColdJunctionOffset sets the offset between -8°C and 7.9375°C, which is
+// added to the measured cold-junction temperature.
+func (d *MAX31856Driver) SetColdJunctionOffset(offset float64) (err error) {
+	if offset < -8 || offset > 7.9375 {
+		return fmt.Errorf("Cold-junction offset %f is not between -8 and 7.9375", offset)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeRegister(max31856RegCJOffset, byte(int8(offset*16)))
+}
+
+// SetFaultMask sets the faults, which do not assert the FAULT pin.
+func (d *MAX31856Driver) SetFaultMask(mask byte) (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.faultMask = mask
+	return d.writeRegister(max31856RegMask, mask&0x3F)
+}
+
+// FaultStatus returns the fault status register, see MAX31856Fault* for the bits.
+func (d *MAX31856Driver) FaultStatus() (status byte, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data, err := d.readRegisters(max31856RegFaultStatus, 1)
+	if err != nil {
+		return
+	}
+	return data[0], nil
+}
+
+// ClearFault clears the fault status register.
+func (d *MAX31856Driver) ClearFault() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeRegister(max31856RegCR0, d.cr0()|max31856CR0FaultClear)
+}
+
+func (d *MAX31856Driver) poll(halt chan bool, done chan bool) {
+	defer close(done)
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(d.interval):
+		}
+
+		temp, err := d.Temperature()
+		if fault, ok := err.(MAX31856FaultError); ok {
+			d.Publish(Fault, byte(fault))
+			if err = d.ClearFault(); err != nil {
+				d.Publish(Error, err)
+			}
+		} else if err != nil {
+			d.Publish(Error, err)
+		} else {
+			d.Publish(Data, temp)
+		}
+	}
+}
+
+func (d *MAX31856Driver) cr0() (cr0 byte) {
+	cr0 = max31856CR0Auto | max31856CR0OpenFault
+	if d.filter50Hz {
+		cr0 |= max31856CR0Filter50Hz
+	}
+	return
+}
+
+func (d *MAX31856Driver) readRegisters(reg byte, n int) ([]byte, error) {
+	tx := make([]byte, n+1)
+	tx[0] = reg
+	rx := make([]byte, n+1)
+	if err := d.connection.Tx(tx, rx); err != nil {
+		return nil, err
+	}
+	return rx[1:], nil
+}
+
+func (d *MAX31856Driver) writeRegister(reg byte, data ...byte) (err error) {
+	tx := append([]byte{reg | max31856Write}, data...)
+	return d.connection.Tx(tx, make([]byte, len(tx)))
+}
@@ -0,0 +1,186 @@
+package spi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MAX31856Driver)(nil)
+var _ sensor.Thermometer = (*MAX31856Driver)(nil)
+
+// max31856Simulator simulates the registers of a MAX31856
+type max31856Simulator struct {
+	mtx       sync.Mutex
+	registers [16]byte
+	err       error
+}
+
+func (s *max31856Simulator) Close() error { return nil }
+
+func (s *max31856Simulator) Tx(w, r []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	reg := w[0] &^ max31856Write
+	if w[0]&max31856Write == 0 {
+		copy(r[1:], s.registers[reg:])
+		return nil
+	}
+
+	copy(s.registers[reg:], w[1:])
+	if reg == max31856RegCR0 && w[1]&max31856CR0FaultClear != 0 {
+		s.registers[max31856RegFaultStatus] = 0
+		s.registers[max31856RegCR0] &^= max31856CR0FaultClear
+	}
+	return nil
+}
+
+func (s *max31856Simulator) set(reg int, data ...byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	copy(s.registers[reg:], data)
+}
+
+func (s *max31856Simulator) get(reg int, n int) []byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]byte{}, s.registers[reg:reg+n]...)
+}
+
+func initTestMAX31856Driver(options ...func(Config)) (*MAX31856Driver, *max31856Simulator) {
+	sim := &max31856Simulator{}
+	return NewMAX31856Driver(&spiTestConnector{conn: sim}, options...), sim
+}
+
+func TestMAX31856Driver(t *testing.T) {
+	d, _ := initTestMAX31856Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MAX31856"), true)
+	gobottest.Assert(t, d.tcType, byte(MAX31856TypeK))
+	gobottest.Assert(t, d.averages, 1)
+	gobottest.Assert(t, d.interval, time.Second)
+
+	d.SetName("thermocouple")
+	gobottest.Assert(t, d.Name(), "thermocouple")
+
+	d, _ = initTestMAX31856Driver(WithMAX31856Type(MAX31856TypeJ), WithMAX31856Averages(4),
+		WithMAX31856Filter50Hz(true), WithMAX31856FaultMask(0x3C), WithMAX31856Interval(0))
+	gobottest.Assert(t, d.tcType, byte(MAX31856TypeJ))
+	gobottest.Assert(t, d.averages, 4)
+	gobottest.Assert(t, d.filter50Hz, true)
+	gobottest.Assert(t, d.faultMask, byte(0x3C))
+	gobottest.Assert(t, d.interval, time.Duration(0))
+}
+
+func TestMAX31856DriverStartHalt(t *testing.T) {
+	d, sim := initTestMAX31856Driver(WithMAX31856Type(MAX31856TypeT), WithMAX31856Averages(16),
+		WithMAX31856Filter50Hz(true), WithMAX31856FaultMask(0xFC))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, sim.get(max31856RegCR0, 3), []byte{0x91, 0x47, 0x3C})
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, sim.get(max31856RegCR0, 1), []byte{0x11})
+
+	d, _ = initTestMAX31856Driver(WithMAX31856Type(8))
+	gobottest.Refute(t, d.Start(), nil)
+
+	d, _ = initTestMAX31856Driver(WithMAX31856Averages(3))
+	gobottest.Assert(t, d.Start(), errors.New("Averages must be one of: 1, 2, 4, 8, 16"))
+
+	d, sim = initTestMAX31856Driver()
+	sim.err = errors.New("tx error")
+	gobottest.Assert(t, d.Start(), errors.New("tx error"))
+}
+
+func TestMAX31856DriverTemperature(t *testing.T) {
+	d, sim := initTestMAX31856Driver(WithMAX31856Interval(0))
+	d.Start()
+
+	// 1600°C and -250°C from the datasheet
+	sim.set(max31856RegTCTemp, 0x64, 0x00, 0x00, 0x00)
+	temp, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, 1600.0)
+
+	sim.set(max31856RegTCTemp, 0xF0, 0x60, 0x00)
+	temp, err = d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, -250.0)
+
+	// 127.984375°C and -55°C from the datasheet
+	sim.set(max31856RegCJTemp, 0x7F, 0xFC)
+	temp, err = d.ColdJunctionTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, 127.984375)
+
+	sim.set(max31856RegCJTemp, 0xC9, 0x00)
+	temp, err = d.ColdJunctionTemperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, temp, -55.0)
+
+	sim.set(max31856RegFaultStatus, MAX31856FaultOpen|MAX31856FaultTCRange)
+	_, err = d.Temperature()
+	gobottest.Assert(t, err, MAX31856FaultError(0x41))
+	gobottest.Assert(t, err.Error(), "MAX31856 fault 0x41: thermocouple out of range, thermocouple open")
+
+	ret := d.Command("Temperature")(nil).(map[string]interface{})
+	gobottest.Refute(t, ret["err"], nil)
+	ret = d.Command("ColdJunctionTemperature")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["err"], nil)
+}
+
+func TestMAX31856DriverSettings(t *testing.T) {
+	d, sim := initTestMAX31856Driver(WithMAX31856Interval(0))
+	d.Start()
+
+	gobottest.Assert(t, d.SetColdJunctionOffset(-1.5), nil)
+	gobottest.Assert(t, sim.get(max31856RegCJOffset, 1), []byte{0xE8})
+	gobottest.Refute(t, d.SetColdJunctionOffset(8), nil)
+
+	gobottest.Assert(t, d.SetFaultMask(0xFF), nil)
+	gobottest.Assert(t, sim.get(max31856RegMask, 1), []byte{0x3F})
+
+	sim.set(max31856RegFaultStatus, MAX31856FaultVoltage)
+	status, err := d.FaultStatus()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, status, byte(MAX31856FaultVoltage))
+	gobottest.Assert(t, d.ClearFault(), nil)
+	status, _ = d.FaultStatus()
+	gobottest.Assert(t, status, byte(0))
+}
+
+func TestMAX31856DriverPolling(t *testing.T) {
+	d, sim := initTestMAX31856Driver(WithMAX31856Interval(time.Millisecond))
+
+	data := make(chan interface{}, 10)
+	faults := make(chan interface{}, 10)
+	d.On(d.Event(Data), func(val interface{}) { data <- val })
+	d.On(d.Event(Fault), func(val interface{}) { faults <- val })
+
+	sim.set(max31856RegTCTemp, 0x01, 0x90, 0x00)
+	gobottest.Assert(t, d.Start(), nil)
+	select {
+	case val := <-data:
+		gobottest.Assert(t, val, 25.0)
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+
+	sim.set(max31856RegFaultStatus, MAX31856FaultOpen)
+	select {
+	case val := <-faults:
+		gobottest.Assert(t, val, byte(MAX31856FaultOpen))
+	case <-time.After(time.Second):
+		t.Errorf("Fault event was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,423 @@
+package spi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// fault bits of the fault status register
+	MAX31865FaultHighThreshold = 0x80
+	MAX31865FaultLowThreshold  = 0x40
+	MAX31865FaultRefInHigh     = 0x20
+	MAX31865FaultRefInLow      = 0x10
+	MAX31865FaultRTDInLow      = 0x08
+	MAX31865FaultVoltage       = 0x04
+
+	max31865RegConfig      = 0x00
+	max31865RegRTD         = 0x01
+	max31865RegHighFault   = 0x03
+	max31865RegFaultStatus = 0x07
+	max31865Write          = 0x80
+
+	max31865ConfigBias       = 0x80
+	max31865ConfigAuto       = 0x40
+	max31865Config3Wire      = 0x10
+	max31865ConfigFaultCycle = 0x0C
+	max31865ConfigFaultAuto  = 0x04
+	max31865ConfigFaultClear = 0x02
+	max31865ConfigFilter50Hz = 0x01
+
+	// Callendar-Van Dusen coefficients of IEC 60751
+	max31865A = 3.9083e-3
+	max31865B = -5.775e-7
+	max31865C = -4.183e-12
+)
+
+var max31865Faults = []struct {
+	bit  byte
+	text string
+}{
+	{MAX31865FaultHighThreshold, "RTD high threshold"},
+	{MAX31865FaultLowThreshold, "RTD low threshold"},
+	{MAX31865FaultRefInHigh, "REFIN- > 0.85 x VBIAS"},
+	{MAX31865FaultRefInLow, "REFIN- < 0.85 x VBIAS, FORCE- open"},
+	{MAX31865FaultRTDInLow, "RTDIN- < 0.85 x VBIAS, FORCE- open"},
+	{MAX31865FaultVoltage, "over or under voltage"},
+}
+
+// MAX31865FaultError is returned when the MAX31865 detects a fault, it
+// contains the fault status register
+type MAX31865FaultError byte
+
+func (f MAX31865FaultError) Error() string {
+	var faults []string
+	for _, fault := range max31865Faults {
+		if byte(f)&fault.bit != 0 {
+			faults = append(faults, fault.text)
+		}
+	}
+	return fmt.Sprintf("MAX31865 fault 0x%02X: %s", byte(f), strings.Join(faults, ", "))
+}
+
+// MAX31865Driver is a driver for the MAX31865 RTD-to-digital converter, which
+// measures the resistance of a PT100 or PT1000 against a reference resistor.
+// The device converts continuously, the driver polls the temperature and
+// publishes it as Data event, detected faults as Fault event.
+type MAX31865Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	wires      int
+	nominal    float64
+	reference  float64
+	filter50Hz bool
+	interval   time.Duration
+	halt       chan bool
+	done       chan bool
+	mutex      sync.Mutex
+	Config
+	gobot.Commander
+	gobot.Eventer
+}
+
+// NewMAX31865Driver creates a new Gobot Driver for the MAX31865 RTD-to-digital converter.
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                      bus to use with this driver
+//      spi.WithChip(int):                     chip to use with this driver
+//      spi.WithMode(int):                     mode to use with this driver (defaults to 1)
+//      spi.WithBits(int):                     number of bits to use with this driver
+//      spi.WithSpeed(int64):                  speed in Hz to use with this driver
+//      spi.WithMAX31865Wires(int):            2, 3 or 4 wire RTD (defaults to 2)
+//      spi.WithMAX31865RTD(float64, float64): nominal and reference resistance (defaults to PT100 with 430 Ohm)
+//      spi.WithMAX31865Filter50Hz(bool):      filter 50Hz instead of 60Hz (defaults to false)
+//      spi.WithMAX31865Interval(time.Duration): polling interval, 0 disables polling (defaults to 1s)
+//
+func NewMAX31865Driver(a Connector, options ...func(Config)) *MAX31865Driver {
+	d := &MAX31865Driver{
+		name:      gobot.DefaultName("MAX31865"),
+		connector: a,
+		wires:     2,
+		nominal:   100,
+		reference: 430,
+		interval:  time.Second,
+		Config:    NewConfig(),
+		Commander: gobot.NewCommander(),
+		Eventer:   gobot.NewEventer(),
+	}
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Fault)
+	d.AddEvent(Error)
+
+	d.AddCommand("Temperature", func(params map[string]interface{}) interface{} {
+		val, err := d.Temperature()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommand("Resistance", func(params map[string]interface{}) interface{} {
+		val, err := d.Resistance()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// WithMAX31865Wires option sets the wiring of the RTD, 2, 3 or 4 wires.
+func WithMAX31865Wires(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31865Driver)
+		if ok {
+			d.wires = val
+		} else {
+			panic("unable to set wires for max31865")
+		}
+	}
+}
+
+// WithMAX31865RTD option sets the nominal resistance of the RTD at 0°C, e.g.
+// 100 for a PT100 or 1000 for a PT1000, and the reference resistance.
+func WithMAX31865RTD(nominal float64, reference float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31865Driver)
+		if ok {
+			d.nominal, d.reference = nominal, reference
+		} else {
+			panic("unable to set rtd for max31865")
+		}
+	}
+}
+
+// WithMAX31865Filter50Hz option sets the notch filter to 50Hz instead of 60Hz.
+func WithMAX31865Filter50Hz(val bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31865Driver)
+		if ok {
+			d.filter50Hz = val
+		} else {
+			panic("unable to set filter for max31865")
+		}
+	}
+}
+
+// WithMAX31865Interval option sets the polling interval, 0 disables polling.
+func WithMAX31865Interval(val time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MAX31865Driver)
+		if ok {
+			d.interval = val
+		} else {
+			panic("unable to set interval for max31865")
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *MAX31865Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *MAX31865Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the device.
+func (d *MAX31865Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start initializes the driver, enables the bias voltage and the automatic
+// conversion and starts polling.
+func (d *MAX31865Driver) Start() (err error) {
+	if d.wires < 2 || d.wires > 4 {
+		return fmt.Errorf("MAX31865 supports 2, 3 or 4 wires, not %d", d.wires)
+	}
+
+	bus := d.GetBusOrDefault(d.connector.GetSpiDefaultBus())
+	chip := d.GetChipOrDefault(d.connector.GetSpiDefaultChip())
+	// the MAX31865 supports mode 1 and 3 only
+	mode := d.GetModeOrDefault(1)
+	bits := d.GetBitsOrDefault(d.connector.GetSpiDefaultBits())
+	maxSpeed := d.GetSpeedOrDefault(d.connector.GetSpiDefaultMaxSpeed())
+
+	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err = d.writeRegister(max31865RegConfig, d.config()|max31865ConfigFaultClear); err != nil {
+		return
+	}
+	if d.interval > 0 {
+		d.halt = make(chan bool)
+		d.done = make(chan bool)
+		go d.poll(d.halt, d.done)
+	}
+	return
+}
+
+// Halt stops polling and disables the bias voltage.
+func (d *MAX31865Driver) Halt() (err error) {
+	d.mutex.Lock()
+	halt, done := d.halt, d.done
+	d.mutex.Unlock()
+
+	if done != nil {
+		halt <- true
+		<-done
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.done = nil
+	if d.connection == nil {
+		return
+	}
+	return d.writeRegister(max31865RegConfig, d.config()&^(max31865ConfigBias|max31865ConfigAuto))
+}
+
+// RawRTD returns the 15-bit ratio of the RTD and the reference resistance.
+// A MAX31865FaultError is returned, if a fault is detected.
+func (d *MAX31865Driver) RawRTD() (raw int, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data, err := d.readRegisters(max31865RegRTD, 2)
+	if err != nil {
+		return
+	}
+	if data[1]&0x01 != 0 {
+		status, err := d.readRegisters(max31865RegFaultStatus, 1)
+		if err != nil {
+			return 0, err
+		}
+		return 0, MAX31865FaultError(status[0])
+	}
+	return (int(data[0])<<8 | int(data[1])) >> 1, nil
+}
+
+// Resistance returns the resistance of the RTD in Ohm.
+func (d *MAX31865Driver) Resistance() (r float64, err error) {
+	raw, err := d.RawRTD()
+	if err != nil {
+		return
+	}
+	return float64(raw) * d.reference / 32768, nil
+}
+
+// Temperature returns the temperature of the RTD in °C, converted by the
+// Callendar-Van Dusen equation.
+func (d *MAX31865Driver) Temperature() (temp float64, err error) {
+	r, err := d.Resistance()
+	if err != nil {
+		return
+	}
+	return MAX31865Temperature(r, d.nominal), nil
+}
+
+// SetThresholds sets the low and high fault thresholds as 15-bit RTD values.
+func (d *MAX31865Driver) SetThresholds(low int, high int) (err error) {
+	if low < 0 || high > 0x7FFF || low > high {
+		return fmt.Errorf("Invalid thresholds %d, %d", low, high)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeRegister(max31865RegHighFault, byte(high>>7), byte(high<<1), byte(low>>7), byte(low<<1))
+}
+
+// FaultStatus returns the fault status register, see MAX31865Fault* for the bits.
+func (d *MAX31865Driver) FaultStatus() (status byte, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data, err := d.readRegisters(max31865RegFaultStatus, 1)
+	if err != nil {
+		return
+	}
+	return data[0], nil
+}
+
+// ClearFault clears the fault status register.
+func (d *MAX31865Driver) ClearFault() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.writeRegister(max31865RegConfig, d.config()|max31865ConfigFaultClear)
+}
+
+// DetectFaults runs the automatic fault detection cycle, which detects open
+// or shorted wires, and returns the fault status register.
+func (d *MAX31865Driver) DetectFaults() (status byte, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	// the automatic conversion must be stopped during the cycle
+	config := d.config() &^ max31865ConfigAuto
+	if err = d.writeRegister(max31865RegConfig, config|max31865ConfigFaultAuto); err != nil {
+		return
+	}
+	timeout := time.After(100 * time.Millisecond)
+	for {
+		data, err := d.readRegisters(max31865RegConfig, 1)
+		if err != nil {
+			return 0, err
+		}
+		if data[0]&max31865ConfigFaultCycle == 0 {
+			break
+		}
+		select {
+		case <-timeout:
+			return 0, fmt.Errorf("Timeout while waiting for the fault detection")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	data, err := d.readRegisters(max31865RegFaultStatus, 1)
+	if err != nil {
+		return
+	}
+	return data[0], d.writeRegister(max31865RegConfig, d.config())
+}
+
+// MAX31865Temperature converts the resistance of a platinum RTD with the
+// nominal resistance at 0°C into °C by the Callendar-Van Dusen equation.
+func MAX31865Temperature(r float64, nominal float64) float64 {
+	// solution of R = R0 * (1 + A*T + B*T²) for T >= 0°C
+	temp := (-max31865A + math.Sqrt(max31865A*max31865A-4*max31865B*(1-r/nominal))) / (2 * max31865B)
+	if temp >= 0 {
+		return temp
+	}
+
+	// below 0°C R = R0 * (1 + A*T + B*T² + C*(T-100)*T³), solved by Newton's method
+	for i := 0; i < 10; i++ {
+		f := nominal*(1+max31865A*temp+max31865B*temp*temp+max31865C*(temp-100)*temp*temp*temp) - r
+		df := nominal * (max31865A + 2*max31865B*temp + max31865C*(4*temp*temp*temp-300*temp*temp))
+		step := f / df
+		temp -= step
+		if math.Abs(step) < 1e-6 {
+			break
+		}
+	}
+	return temp
+}
+
+func (d *MAX31865Driver) poll(halt chan bool, done chan bool) {
+	defer close(done)
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(d.interval):
+		}
+
+		temp, err := d.Temperature()
+		if fault, ok := err.(MAX31865FaultError); ok {
+			d.Publish(Fault, byte(fault))
+			if err = d.ClearFault(); err != nil {
+				d.Publish(Error, err)
+			}
+		} else if err != nil {
+			d.Publish(Error, err)
+		} else {
+			d.Publish(Data, temp)
+		}
+	}
+}
+
+func (d *MAX31865Driver) config() (config byte) {
+	config = max31865ConfigBias | max31865ConfigAuto
+	if d.wires == 3 {
+		config |= max31865Config3Wire
+	}
+	if d.filter50Hz {
+		config |= max31865ConfigFilter50Hz
+	}
+	return
+}
+
+func (d *MAX31865Driver) readRegisters(reg byte, n int) ([]byte, error) {
+	tx := make([]byte, n+1)
+	tx[0] = reg
+	rx := make([]byte, n+1)
+	if err := d.connection.Tx(tx, rx); err != nil {
+		return nil, err
+	}
+	return rx[1:], nil
+}
+
+func (d *MAX31865Driver) writeRegister(reg byte, data ...byte) (err error) {
+	tx := append([]byte{reg | max31865Write}, data...)
+	return d.connection.Tx(tx, make([]byte, len(tx)))
+}
@@ -0,0 +1,203 @@
+package spi
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/sensor"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MAX31865Driver)(nil)
+var _ sensor.Thermometer = (*MAX31865Driver)(nil)
+
+// max31865Simulator simulates the registers of a MAX31865
+type max31865Simulator struct {
+	mtx       sync.Mutex
+	registers [8]byte
+	configs   []byte
+	detected  byte
+	err       error
+}
+
+func (s *max31865Simulator) Close() error { return nil }
+
+func (s *max31865Simulator) Tx(w, r []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	reg := w[0] &^ max31865Write
+	if w[0]&max31865Write == 0 {
+		copy(r[1:], s.registers[reg:])
+		return nil
+	}
+
+	copy(s.registers[reg:], w[1:])
+	if reg == max31865RegConfig {
+		config := w[1]
+		s.configs = append(s.configs, config)
+		if config&max31865ConfigFaultClear != 0 {
+			s.registers[max31865RegFaultStatus] = 0
+			s.registers[max31865RegRTD+1] &^= 0x01
+		}
+		if config&max31865ConfigFaultCycle != 0 {
+			s.registers[max31865RegFaultStatus] = s.detected
+		}
+		// the self-clearing bits
+		s.registers[max31865RegConfig] = config &^ (max31865ConfigFaultCycle | max31865ConfigFaultClear)
+	}
+	return nil
+}
+
+func (s *max31865Simulator) setRTD(raw int, fault byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.registers[max31865RegRTD] = byte(raw >> 7)
+	s.registers[max31865RegRTD+1] = byte(raw << 1)
+	if fault != 0 {
+		s.registers[max31865RegRTD+1] |= 0x01
+		s.registers[max31865RegFaultStatus] = fault
+	}
+}
+
+func initTestMAX31865Driver(options ...func(Config)) (*MAX31865Driver, *max31865Simulator) {
+	sim := &max31865Simulator{}
+	return NewMAX31865Driver(&spiTestConnector{conn: sim}, options...), sim
+}
+
+func TestMAX31865Driver(t *testing.T) {
+	d, _ := initTestMAX31865Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MAX31865"), true)
+	gobottest.Assert(t, d.wires, 2)
+	gobottest.Assert(t, d.nominal, 100.0)
+	gobottest.Assert(t, d.reference, 430.0)
+	gobottest.Assert(t, d.interval, time.Second)
+
+	d.SetName("rtd")
+	gobottest.Assert(t, d.Name(), "rtd")
+
+	d, _ = initTestMAX31865Driver(WithMAX31865Wires(3), WithMAX31865RTD(1000, 4300),
+		WithMAX31865Filter50Hz(true), WithMAX31865Interval(0))
+	gobottest.Assert(t, d.wires, 3)
+	gobottest.Assert(t, d.nominal, 1000.0)
+	gobottest.Assert(t, d.reference, 4300.0)
+	gobottest.Assert(t, d.filter50Hz, true)
+	gobottest.Assert(t, d.interval, time.Duration(0))
+}
+
+func TestMAX31865DriverStartHalt(t *testing.T) {
+	d, sim := initTestMAX31865Driver(WithMAX31865Wires(3), WithMAX31865Filter50Hz(true))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, sim.configs, []byte{0xD3, 0x11})
+
+	d, _ = initTestMAX31865Driver(WithMAX31865Wires(5))
+	gobottest.Assert(t, d.Start(), errors.New("MAX31865 supports 2, 3 or 4 wires, not 5"))
+
+	d, sim = initTestMAX31865Driver()
+	sim.err = errors.New("tx error")
+	gobottest.Assert(t, d.Start(), errors.New("tx error"))
+}
+
+func TestMAX31865DriverTemperature(t *testing.T) {
+	d, sim := initTestMAX31865Driver(WithMAX31865Interval(0))
+	d.Start()
+
+	// 100 Ohm is 0°C
+	sim.setRTD(7620, 0)
+	r, err := d.Resistance()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(r-100) < 0.01, true)
+	temp, err := d.Temperature()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, math.Abs(temp) < 0.05, true)
+
+	sim.setRTD(9000, 0)
+	raw, err := d.RawRTD()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, raw, 9000)
+
+	sim.setRTD(100, MAX31865FaultRefInLow|MAX31865FaultRTDInLow)
+	_, err = d.Temperature()
+	gobottest.Assert(t, err, MAX31865FaultError(0x18))
+	gobottest.Assert(t, err.Error(), "MAX31865 fault 0x18: REFIN- < 0.85 x VBIAS, FORCE- open, RTDIN- < 0.85 x VBIAS, FORCE- open")
+
+	ret := d.Command("Temperature")(nil).(map[string]interface{})
+	gobottest.Refute(t, ret["err"], nil)
+	gobottest.Assert(t, d.ClearFault(), nil)
+	ret = d.Command("Resistance")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["err"], nil)
+}
+
+func TestMAX31865Temperature(t *testing.T) {
+	// values of the IEC 60751 table
+	for r, temp := range map[float64]float64{18.52: -200, 60.26: -100, 92.16: -20, 100: 0, 138.51: 100, 247.09: 400, 390.48: 850} {
+		gobottest.Assert(t, math.Abs(MAX31865Temperature(r, 100)-temp) < 0.02, true)
+		gobottest.Assert(t, math.Abs(MAX31865Temperature(10*r, 1000)-temp) < 0.02, true)
+	}
+}
+
+func TestMAX31865DriverThresholds(t *testing.T) {
+	d, sim := initTestMAX31865Driver(WithMAX31865Interval(0))
+	d.Start()
+
+	gobottest.Assert(t, d.SetThresholds(0x1234, 0x7FFF), nil)
+	gobottest.Assert(t, sim.registers[max31865RegHighFault:max31865RegFaultStatus], []byte{0xFF, 0xFE, 0x24, 0x68})
+	gobottest.Refute(t, d.SetThresholds(10, 5), nil)
+	gobottest.Refute(t, d.SetThresholds(0, 0x8000), nil)
+}
+
+func TestMAX31865DriverFaults(t *testing.T) {
+	d, sim := initTestMAX31865Driver(WithMAX31865Interval(0))
+	d.Start()
+
+	sim.detected = MAX31865FaultRTDInLow
+	status, err := d.DetectFaults()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, status, byte(MAX31865FaultRTDInLow))
+	gobottest.Assert(t, sim.configs, []byte{0xC2, 0x84, 0xC0})
+
+	status, err = d.FaultStatus()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, status, byte(MAX31865FaultRTDInLow))
+
+	gobottest.Assert(t, d.ClearFault(), nil)
+	status, _ = d.FaultStatus()
+	gobottest.Assert(t, status, byte(0))
+}
+
+func TestMAX31865DriverPolling(t *testing.T) {
+	d, sim := initTestMAX31865Driver(WithMAX31865Interval(time.Millisecond))
+
+	data := make(chan interface{}, 10)
+	faults := make(chan interface{}, 10)
+	d.On(d.Event(Data), func(val interface{}) { data <- val })
+	d.On(d.Event(Fault), func(val interface{}) { faults <- val })
+
+	sim.setRTD(7620, 0)
+	gobottest.Assert(t, d.Start(), nil)
+	select {
+	case val := <-data:
+		gobottest.Assert(t, math.Abs(val.(float64)) < 0.05, true)
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+
+	sim.setRTD(0, MAX31865FaultLowThreshold)
+	select {
+	case val := <-faults:
+		gobottest.Assert(t, val, byte(MAX31865FaultLowThreshold))
+	case <-time.After(time.Second):
+		t.Errorf("Fault event was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
@@ -0,0 +1,627 @@
+package spi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+// MCP2515 operation modes
+const (
+	MCP2515ModeNormal     = 0x00
+	MCP2515ModeSleep      = 0x20
+	MCP2515ModeLoopback   = 0x40
+	MCP2515ModeListenOnly = 0x60
+	MCP2515ModeConfig     = 0x80
+)
+
+const (
+	mcp2515Reset         = 0xC0
+	mcp2515Read          = 0x03
+	mcp2515Write         = 0x02
+	mcp2515ReadRxBuffer  = 0x90
+	mcp2515LoadTxBuffer  = 0x40
+	mcp2515RequestToSend = 0x80
+	mcp2515ReadStatus    = 0xA0
+	mcp2515BitModify     = 0x05
+
+	mcp2515RegRXF0     = 0x00
+	mcp2515RegRXF3     = 0x10
+	mcp2515RegCANSTAT  = 0x0E
+	mcp2515RegCANCTRL  = 0x0F
+	mcp2515RegRXM0     = 0x20
+	mcp2515RegCNF3     = 0x28
+	mcp2515RegRXB0CTRL = 0x60
+	mcp2515RegRXB1CTRL = 0x70
+
+	// mask of the operation mode in CANCTRL and CANSTAT
+	mcp2515ModeMask = 0xE0
+	// receive buffer 0 full interrupt enable, receive buffer 1 full interrupt enable
+	mcp2515RxInterrupts = 0x03
+	// RXBnCTRL: receive any message, rollover from buffer 0 to buffer 1
+	mcp2515RxAny      = 0x60
+	mcp2515RxRollover = 0x04
+	// SIDL: extended identifier enable
+	mcp2515SIDLExtended = 0x08
+	// SIDL: standard frame remote transmit request
+	mcp2515SIDLRemote = 0x10
+	// DLC: remote transmit request
+	mcp2515DLCRemote = 0x40
+
+	// READ STATUS bits
+	mcp2515StatusRx0 = 0x01
+	mcp2515StatusRx1 = 0x02
+
+	mcp2515PollInterval = time.Millisecond
+)
+
+// mcp2515TxPending are the READ STATUS bits of the pending transmit requests of the buffers 0..2
+var mcp2515TxPending = []byte{0x04, 0x10, 0x40}
+
+// CANFrame is a frame sent or received on a CAN bus.
+type CANFrame struct {
+	// ID is the 11-bit standard or 29-bit extended identifier
+	ID       uint32
+	Extended bool
+	// Remote is set for a remote transmission request, which has no data
+	Remote bool
+	// Data has up to 8 bytes
+	Data []byte
+}
+
+// String returns the frame in the notation of candump, e.g. "123#DEADBEEF".
+func (f CANFrame) String() string {
+	id := fmt.Sprintf("%03X", f.ID)
+	if f.Extended {
+		id = fmt.Sprintf("%08X", f.ID)
+	}
+	if f.Remote {
+		return id + "#R"
+	}
+	return fmt.Sprintf("%s#%X", id, f.Data)
+}
+
+type mcp2515ID struct {
+	id       uint32
+	extended bool
+}
+
+// MCP2515Driver is a driver for the MCP2515 stand-alone CAN controller.
+// Received frames are published as Data event, when polling is enabled.
+type MCP2515Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	oscillator int64
+	bitrate    int
+	mode       byte
+	intPin     string
+	interval   time.Duration
+	masks      [2]*mcp2515ID
+	filters    [6]*mcp2515ID
+	halt       chan bool
+	done       chan bool
+	mutex      sync.Mutex
+	Config
+	gobot.Commander
+	gobot.CommandSchemer
+	gobot.Eventer
+}
+
+// NewMCP2515Driver creates a new Gobot Driver for the MCP2515 CAN controller.
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                   bus to use with this driver
+//      spi.WithChip(int):                  chip to use with this driver
+//      spi.WithMode(int):                  mode to use with this driver
+//      spi.WithBits(int):                  number of bits to use with this driver
+//      spi.WithSpeed(int64):               speed in Hz to use with this driver
+//      spi.WithMCP2515Oscillator(int64):   frequency of the crystal in Hz (defaults to 8MHz)
+//      spi.WithMCP2515Bitrate(int):        bitrate of the CAN bus in bit/s (defaults to 500000)
+//      spi.WithMCP2515Mode(byte):          one of the MCP2515Mode* operation modes (defaults to normal)
+//      spi.WithMCP2515IntPin(string):      gpio pin connected to INT, to poll the receive status only on interrupt
+//      spi.WithMCP2515Interval(duration):  interval to poll for received frames, 0 disables polling (defaults to 1ms)
+//
+func NewMCP2515Driver(a Connector, options ...func(Config)) *MCP2515Driver {
+	d := &MCP2515Driver{
+		name:           gobot.DefaultName("MCP2515"),
+		connector:      a,
+		oscillator:     8000000,
+		bitrate:        500000,
+		mode:           MCP2515ModeNormal,
+		interval:       mcp2515PollInterval,
+		Config:         NewConfig(),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		Eventer:        gobot.NewEventer(),
+	}
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+
+	d.AddCommand("Send", func(params map[string]interface{}) interface{} {
+		frame := CANFrame{ID: uint32(params["id"].(float64))}
+		if extended, ok := params["extended"].(bool); ok {
+			frame.Extended = extended
+		}
+		if data, ok := params["data"].([]interface{}); ok {
+			for _, b := range data {
+				frame.Data = append(frame.Data, byte(gobot.CommandParamFloat(b)))
+			}
+		}
+		err := d.Send(frame)
+		return map[string]interface{}{"err": err}
+	})
+	// "extended" and "data" are optional
+	d.AddCommandSchema("Send", gobot.CommandSchema{"id": gobot.CommandParamNumber})
+	return d
+}
+
+// WithMCP2515Oscillator option sets the frequency of the crystal in Hz.
+func WithMCP2515Oscillator(val int64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.oscillator = val
+		} else {
+			panic("unable to set oscillator for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515Bitrate option sets the bitrate of the CAN bus in bit/s.
+func WithMCP2515Bitrate(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.bitrate = val
+		} else {
+			panic("unable to set bitrate for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515Mode option sets the operation mode, e.g. MCP2515ModeLoopback for tests.
+func WithMCP2515Mode(val byte) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.mode = val
+		} else {
+			panic("unable to set mode for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515IntPin option sets the gpio pin connected to the INT output of the
+// device. The connector needs to be a gpio.DigitalReader.
+func WithMCP2515IntPin(val string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.intPin = val
+		} else {
+			panic("unable to set int pin for mcp2515")
+		}
+	}
+}
+
+// WithMCP2515Interval option sets the interval to poll for received frames.
+func WithMCP2515Interval(val time.Duration) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP2515Driver)
+		if ok {
+			d.interval = val
+		} else {
+			panic("unable to set interval for mcp2515")
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *MCP2515Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *MCP2515Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the device.
+func (d *MCP2515Driver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start initializes the driver, resets the device, configures the bit timing
+// and the filters and starts polling for received frames.
+func (d *MCP2515Driver) Start() (err error) {
+	cnf, err := mcp2515BitTiming(d.oscillator, d.bitrate)
+	if err != nil {
+		return err
+	}
+	switch d.mode {
+	case MCP2515ModeNormal, MCP2515ModeLoopback, MCP2515ModeListenOnly:
+	default:
+		return fmt.Errorf("Mode must be one of MCP2515ModeNormal, MCP2515ModeLoopback, MCP2515ModeListenOnly")
+	}
+	if d.intPin != "" {
+		if _, ok := d.connector.(gpio.DigitalReader); !ok {
+			return errors.New("INT pin needs a connector which is a DigitalReader")
+		}
+	}
+
+	bus := d.GetBusOrDefault(d.connector.GetSpiDefaultBus())
+	chip := d.GetChipOrDefault(d.connector.GetSpiDefaultChip())
+	mode := d.GetModeOrDefault(d.connector.GetSpiDefaultMode())
+	bits := d.GetBitsOrDefault(d.connector.GetSpiDefaultBits())
+	maxSpeed := d.GetSpeedOrDefault(d.connector.GetSpiDefaultMaxSpeed())
+
+	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err = d.connection.Tx([]byte{mcp2515Reset}, nil); err != nil {
+		return
+	}
+	// wait for the oscillator start-up
+	time.Sleep(5 * time.Millisecond)
+	// the device is in configuration mode after reset
+	status, err := d.readRegisters(mcp2515RegCANSTAT, 1)
+	if err != nil {
+		return
+	}
+	if status[0]&mcp2515ModeMask != MCP2515ModeConfig {
+		return errors.New("MCP2515 not found")
+	}
+
+	// CNF3, CNF2, CNF1 and CANINTE
+	if err = d.writeRegisters(mcp2515RegCNF3, cnf[2], cnf[1], cnf[0], mcp2515RxInterrupts); err != nil {
+		return
+	}
+	if err = d.writeFilters(); err != nil {
+		return
+	}
+	if err = d.setMode(d.mode); err != nil {
+		return
+	}
+
+	if d.interval > 0 {
+		d.halt = make(chan bool)
+		d.done = make(chan bool)
+		go d.poll(d.halt, d.done)
+	}
+	return
+}
+
+// Halt stops polling and puts the device into sleep mode.
+func (d *MCP2515Driver) Halt() (err error) {
+	d.mutex.Lock()
+	halt, done := d.halt, d.done
+	d.mutex.Unlock()
+
+	if done != nil {
+		halt <- true
+		<-done
+	}
+	if d.connection == nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.done = nil
+	return d.setMode(MCP2515ModeSleep)
+}
+
+// SetMask sets the acceptance mask 0 (for the filters 0 and 1) or 1 (for the
+// filters 2 to 5). Only the bits of an identifier which are set in the mask
+// are compared with the filters.
+func (d *MCP2515Driver) SetMask(n int, mask uint32, extended bool) (err error) {
+	if n < 0 || n >= len(d.masks) {
+		return fmt.Errorf("Mask %d does not exist", n)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.masks[n] = &mcp2515ID{id: mask, extended: extended}
+	return d.updateFilters()
+}
+
+// SetFilter sets the acceptance filter 0 to 5. When filters are set, only
+// frames which match one of them are received. Unset filters of a receive
+// buffer take the value of the first set filter.
+func (d *MCP2515Driver) SetFilter(n int, id uint32, extended bool) (err error) {
+	if n < 0 || n >= len(d.filters) {
+		return fmt.Errorf("Filter %d does not exist", n)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.filters[n] = &mcp2515ID{id: id, extended: extended}
+	return d.updateFilters()
+}
+
+// ClearFilters removes all masks and filters, so all frames are received.
+func (d *MCP2515Driver) ClearFilters() (err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.masks = [2]*mcp2515ID{}
+	d.filters = [6]*mcp2515ID{}
+	return d.updateFilters()
+}
+
+// Send loads the frame into a free transmit buffer and requests its transmission.
+func (d *MCP2515Driver) Send(frame CANFrame) (err error) {
+	if err = checkCANFrame(frame); err != nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status, err := d.readStatus()
+	if err != nil {
+		return
+	}
+	for n, pending := range mcp2515TxPending {
+		if status&pending != 0 {
+			continue
+		}
+		id := mcp2515IDBytes(frame.ID, frame.Extended)
+		dlc := byte(len(frame.Data))
+		if frame.Remote {
+			dlc = mcp2515DLCRemote
+		}
+		w := append([]byte{mcp2515LoadTxBuffer | byte(n<<1)}, id[:]...)
+		w = append(append(w, dlc), frame.Data...)
+		if err = d.connection.Tx(w, nil); err != nil {
+			return
+		}
+		return d.connection.Tx([]byte{mcp2515RequestToSend | 1<<uint(n)}, nil)
+	}
+	return errors.New("No free transmit buffer")
+}
+
+// Receive returns the next received frame or nil, if there is none. This is
+// meant to be used with polling disabled.
+func (d *MCP2515Driver) Receive() (frame *CANFrame, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.receive()
+}
+
+func (d *MCP2515Driver) poll(halt chan bool, done chan bool) {
+	defer close(done)
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(d.interval):
+		}
+
+		if d.intPin != "" {
+			// INT is active low
+			level, err := d.connector.(gpio.DigitalReader).DigitalRead(d.intPin)
+			if err != nil {
+				d.Publish(Error, err)
+				continue
+			}
+			if level != 0 {
+				continue
+			}
+		}
+
+		for {
+			d.mutex.Lock()
+			frame, err := d.receive()
+			d.mutex.Unlock()
+
+			if err != nil {
+				d.Publish(Error, err)
+			}
+			if frame == nil {
+				break
+			}
+			d.Publish(Data, *frame)
+		}
+	}
+}
+
+func (d *MCP2515Driver) receive() (frame *CANFrame, err error) {
+	status, err := d.readStatus()
+	if err != nil {
+		return
+	}
+	var buffer byte
+	switch {
+	case status&mcp2515StatusRx0 != 0:
+		buffer = 0
+	case status&mcp2515StatusRx1 != 0:
+		buffer = 1
+	default:
+		return
+	}
+
+	// reading the buffer clears its interrupt flag
+	r := make([]byte, 14)
+	if err = d.connection.Tx([]byte{mcp2515ReadRxBuffer | buffer<<2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, r); err != nil {
+		return
+	}
+	sidh, sidl, eid8, eid0, dlc := r[1], r[2], r[3], r[4], r[5]
+
+	frame = &CANFrame{ID: uint32(sidh)<<3 | uint32(sidl>>5)}
+	if sidl&mcp2515SIDLExtended != 0 {
+		frame.Extended = true
+		frame.ID = frame.ID<<18 | uint32(sidl&0x03)<<16 | uint32(eid8)<<8 | uint32(eid0)
+		frame.Remote = dlc&mcp2515DLCRemote != 0
+	} else {
+		frame.Remote = sidl&mcp2515SIDLRemote != 0
+	}
+	if !frame.Remote {
+		n := int(dlc & 0x0F)
+		if n > 8 {
+			n = 8
+		}
+		frame.Data = append([]byte{}, r[6:6+n]...)
+	}
+	return
+}
+
+// updateFilters writes the filters, if the device is started
+func (d *MCP2515Driver) updateFilters() (err error) {
+	if d.connection == nil {
+		return
+	}
+	if err = d.setMode(MCP2515ModeConfig); err != nil {
+		return
+	}
+	if err = d.writeFilters(); err != nil {
+		return
+	}
+	return d.setMode(d.mode)
+}
+
+// writeFilters writes masks, filters and the receive buffer control, which
+// must be done in configuration mode
+func (d *MCP2515Driver) writeFilters() (err error) {
+	// the filters 0 and 1 belong to buffer 0, the filters 2 to 5 to buffer 1
+	groups := [][]int{{0, 1}, {2, 3, 4, 5}}
+	var first *mcp2515ID
+	for _, group := range groups {
+		for _, n := range group {
+			if first == nil && d.filters[n] != nil {
+				first = d.filters[n]
+			}
+		}
+	}
+	if first == nil {
+		return d.writeRegisters(mcp2515RegRXB0CTRL, mcp2515RxAny|mcp2515RxRollover)
+	}
+
+	for m, group := range groups {
+		groupFirst := first
+		for _, n := range group {
+			if d.filters[n] != nil {
+				groupFirst = d.filters[n]
+				break
+			}
+		}
+		mask := mcp2515ID{}
+		if d.masks[m] != nil {
+			mask = *d.masks[m]
+		}
+		id := mcp2515IDBytes(mask.id, mask.extended)
+		if err = d.writeRegisters(mcp2515RegRXM0+byte(m*4), id[:]...); err != nil {
+			return
+		}
+		for _, n := range group {
+			filter := groupFirst
+			if d.filters[n] != nil {
+				filter = d.filters[n]
+			}
+			id := mcp2515IDBytes(filter.id, filter.extended)
+			reg := mcp2515RegRXF0 + byte(n*4)
+			if n >= 3 {
+				reg = mcp2515RegRXF3 + byte((n-3)*4)
+			}
+			if err = d.writeRegisters(reg, id[:]...); err != nil {
+				return
+			}
+		}
+	}
+	if err = d.writeRegisters(mcp2515RegRXB0CTRL, mcp2515RxRollover); err != nil {
+		return
+	}
+	return d.writeRegisters(mcp2515RegRXB1CTRL, 0)
+}
+
+func (d *MCP2515Driver) setMode(mode byte) (err error) {
+	if err = d.connection.Tx([]byte{mcp2515BitModify, mcp2515RegCANCTRL, mcp2515ModeMask, mode}, nil); err != nil {
+		return
+	}
+	status, err := d.readRegisters(mcp2515RegCANSTAT, 1)
+	if err != nil {
+		return
+	}
+	if status[0]&mcp2515ModeMask != mode {
+		return fmt.Errorf("MCP2515 did not change to mode 0x%02X", mode)
+	}
+	return
+}
+
+func (d *MCP2515Driver) readStatus() (byte, error) {
+	r := make([]byte, 2)
+	if err := d.connection.Tx([]byte{mcp2515ReadStatus, 0}, r); err != nil {
+		return 0, err
+	}
+	return r[1], nil
+}
+
+func (d *MCP2515Driver) readRegisters(reg byte, n int) ([]byte, error) {
+	w := make([]byte, 2+n)
+	w[0], w[1] = mcp2515Read, reg
+	r := make([]byte, len(w))
+	if err := d.connection.Tx(w, r); err != nil {
+		return nil, err
+	}
+	return r[2:], nil
+}
+
+func (d *MCP2515Driver) writeRegisters(reg byte, data ...byte) error {
+	return d.connection.Tx(append([]byte{mcp2515Write, reg}, data...), nil)
+}
+
+// mcp2515BitTiming returns CNF1, CNF2 and CNF3 for the bitrate with a sample
+// point at about 75%
+func mcp2515BitTiming(oscillator int64, bitrate int) ([3]byte, error) {
+	// a bit has 8 to 25 time quanta of 2*(BRP+1)/Fosc, prefer a higher resolution
+	for tq := int64(16); tq >= 8; tq-- {
+		div := 2 * tq * int64(bitrate)
+		if div == 0 || oscillator%div != 0 || oscillator/div > 64 {
+			continue
+		}
+		brp := oscillator/div - 1
+		ps2 := tq / 4
+		if ps2 < 2 {
+			ps2 = 2
+		}
+		ps1 := (tq - 1 - ps2) / 2
+		prop := tq - 1 - ps2 - ps1
+		// SJW of 1, BTLMODE to use PS2 from CNF3
+		return [3]byte{byte(brp), byte(0x80 | (ps1-1)<<3 | (prop - 1)), byte(ps2 - 1)}, nil
+	}
+	return [3]byte{}, fmt.Errorf("Bitrate %d is not possible with an oscillator of %d Hz", bitrate, oscillator)
+}
+
+// mcp2515IDBytes returns the identifier in the format of the SIDH, SIDL, EID8
+// and EID0 registers
+func mcp2515IDBytes(id uint32, extended bool) [4]byte {
+	if !extended {
+		return [4]byte{byte(id >> 3), byte(id << 5), 0, 0}
+	}
+	sid := id >> 18
+	return [4]byte{byte(sid >> 3), byte(sid<<5) | mcp2515SIDLExtended | byte(id>>16)&0x03, byte(id >> 8), byte(id)}
+}
+
+func checkCANFrame(frame CANFrame) error {
+	if frame.Extended && frame.ID >= 1<<29 {
+		return fmt.Errorf("Extended identifier 0x%X exceeds 29 bit", frame.ID)
+	}
+	if !frame.Extended && frame.ID >= 1<<11 {
+		return fmt.Errorf("Standard identifier 0x%X exceeds 11 bit", frame.ID)
+	}
+	if len(frame.Data) > 8 {
+		return fmt.Errorf("Data length %d exceeds 8 bytes", len(frame.Data))
+	}
+	return nil
+}
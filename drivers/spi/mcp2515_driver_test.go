@@ -0,0 +1,287 @@
+package spi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MCP2515Driver)(nil)
+
+const (
+	mcp2515RegCANINTE = 0x2B
+	mcp2515RegCANINTF = 0x2C
+	mcp2515RegTXB0    = 0x30
+	mcp2515RegRXB0    = 0x60
+)
+
+// mcp2515Simulator simulates the registers and the instructions of a MCP2515,
+// frames are sent to the receive buffer 0 in loopback mode
+type mcp2515Simulator struct {
+	mtx       sync.Mutex
+	registers [128]byte
+	sent      [][]byte
+	missing   bool
+	err       error
+}
+
+func (s *mcp2515Simulator) Close() error { return nil }
+
+func (s *mcp2515Simulator) Tx(w, r []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	switch {
+	case w[0] == mcp2515Reset:
+		s.registers = [128]byte{}
+		if !s.missing {
+			s.registers[mcp2515RegCANSTAT] = MCP2515ModeConfig
+			s.registers[mcp2515RegCANCTRL] = 0x87
+		}
+	case w[0] == mcp2515Read:
+		copy(r[2:], s.registers[w[1]:])
+	case w[0] == mcp2515Write:
+		copy(s.registers[w[1]:], w[2:])
+	case w[0] == mcp2515BitModify:
+		reg := &s.registers[w[1]]
+		*reg = *reg&^w[2] | w[3]&w[2]
+		if w[1] == mcp2515RegCANCTRL {
+			s.registers[mcp2515RegCANSTAT] = *reg & mcp2515ModeMask
+		}
+	case w[0] == mcp2515ReadStatus:
+		intf := s.registers[mcp2515RegCANINTF]
+		r[1] = intf & 0x03
+		for n, pending := range mcp2515TxPending {
+			if s.registers[mcp2515RegTXB0+n*0x10]&0x08 != 0 {
+				r[1] |= pending
+			}
+		}
+	case w[0]&0xF8 == mcp2515LoadTxBuffer:
+		copy(s.registers[mcp2515RegTXB0+1+int(w[0]>>1&0x03)*0x10:], w[1:])
+	case w[0]&0xF8 == mcp2515RequestToSend:
+		for n := 0; n < 3; n++ {
+			if w[0]&(1<<uint(n)) == 0 {
+				continue
+			}
+			buffer := s.registers[mcp2515RegTXB0+1+n*0x10 : mcp2515RegTXB0+14+n*0x10]
+			if s.registers[mcp2515RegCANSTAT] == MCP2515ModeLoopback {
+				copy(s.registers[mcp2515RegRXB0+1:], buffer)
+				s.registers[mcp2515RegCANINTF] |= 0x01
+			} else if s.registers[mcp2515RegCANSTAT] == MCP2515ModeNormal {
+				// without acknowledge, the transmission stays pending
+				s.registers[mcp2515RegTXB0+n*0x10] |= 0x08
+			}
+			s.sent = append(s.sent, append([]byte{}, buffer...))
+		}
+	case w[0]&0xF9 == mcp2515ReadRxBuffer:
+		n := int(w[0] >> 2 & 0x01)
+		copy(r[1:], s.registers[mcp2515RegRXB0+1+n*0x10:])
+		s.registers[mcp2515RegCANINTF] &^= 1 << uint(n)
+	}
+	return nil
+}
+
+func (s *mcp2515Simulator) receive(buffer int, data ...byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	copy(s.registers[mcp2515RegRXB0+1+buffer*0x10:], data)
+	s.registers[mcp2515RegCANINTF] |= 1 << uint(buffer)
+}
+
+func (s *mcp2515Simulator) register(reg int, n int) []byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]byte{}, s.registers[reg:reg+n]...)
+}
+
+type mcp2515TestConnector struct {
+	spiTestConnector
+	sim   *mcp2515Simulator
+	reads int
+}
+
+func (ctr *mcp2515TestConnector) DigitalRead(pin string) (int, error) {
+	ctr.sim.mtx.Lock()
+	defer ctr.sim.mtx.Unlock()
+	ctr.reads++
+	// INT is active low
+	if ctr.sim.registers[mcp2515RegCANINTF]&ctr.sim.registers[mcp2515RegCANINTE] != 0 {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func initTestMCP2515Driver(options ...func(Config)) (*MCP2515Driver, *mcp2515Simulator) {
+	sim := &mcp2515Simulator{}
+	ctr := &mcp2515TestConnector{sim: sim}
+	ctr.conn = sim
+	return NewMCP2515Driver(ctr, options...), sim
+}
+
+func TestMCP2515Driver(t *testing.T) {
+	d, _ := initTestMCP2515Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MCP2515"), true)
+	gobottest.Assert(t, d.oscillator, int64(8000000))
+	gobottest.Assert(t, d.bitrate, 500000)
+	gobottest.Assert(t, d.mode, byte(MCP2515ModeNormal))
+	gobottest.Assert(t, d.interval, time.Millisecond)
+
+	d.SetName("can")
+	gobottest.Assert(t, d.Name(), "can")
+
+	d, _ = initTestMCP2515Driver(WithMCP2515Oscillator(16000000), WithMCP2515Bitrate(125000),
+		WithMCP2515Mode(MCP2515ModeLoopback), WithMCP2515IntPin("22"), WithMCP2515Interval(0))
+	gobottest.Assert(t, d.oscillator, int64(16000000))
+	gobottest.Assert(t, d.bitrate, 125000)
+	gobottest.Assert(t, d.mode, byte(MCP2515ModeLoopback))
+	gobottest.Assert(t, d.intPin, "22")
+	gobottest.Assert(t, d.interval, time.Duration(0))
+}
+
+func TestMCP2515BitTiming(t *testing.T) {
+	cnf, err := mcp2515BitTiming(8000000, 500000)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, cnf, [3]byte{0x00, 0x8A, 0x01})
+
+	cnf, err = mcp2515BitTiming(16000000, 125000)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, cnf, [3]byte{0x03, 0xA5, 0x03})
+
+	_, err = mcp2515BitTiming(8000000, 1000000)
+	gobottest.Assert(t, err, errors.New("Bitrate 1000000 is not possible with an oscillator of 8000000 Hz"))
+}
+
+func TestMCP2515DriverStartHalt(t *testing.T) {
+	d, sim := initTestMCP2515Driver(WithMCP2515Interval(0))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, sim.register(mcp2515RegCNF3, 4), []byte{0x01, 0x8A, 0x00, 0x03})
+	gobottest.Assert(t, sim.register(mcp2515RegRXB0CTRL, 1), []byte{0x64})
+	gobottest.Assert(t, sim.register(mcp2515RegCANSTAT, 1), []byte{MCP2515ModeNormal})
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, sim.register(mcp2515RegCANSTAT, 1), []byte{MCP2515ModeSleep})
+
+	d, sim = initTestMCP2515Driver()
+	sim.missing = true
+	gobottest.Assert(t, d.Start(), errors.New("MCP2515 not found"))
+
+	d, sim = initTestMCP2515Driver()
+	sim.err = errors.New("tx error")
+	gobottest.Assert(t, d.Start(), errors.New("tx error"))
+
+	d, _ = initTestMCP2515Driver(WithMCP2515Mode(MCP2515ModeSleep))
+	gobottest.Refute(t, d.Start(), nil)
+
+	d, _ = initTestMCP2515Driver(WithMCP2515Bitrate(0))
+	gobottest.Refute(t, d.Start(), nil)
+
+	d = NewMCP2515Driver(&spiTestConnector{}, WithMCP2515IntPin("22"))
+	gobottest.Assert(t, d.Start(), errors.New("INT pin needs a connector which is a DigitalReader"))
+}
+
+func TestMCP2515DriverSendReceive(t *testing.T) {
+	d, sim := initTestMCP2515Driver(WithMCP2515Mode(MCP2515ModeLoopback), WithMCP2515Interval(0))
+	gobottest.Assert(t, d.Start(), nil)
+
+	frame, err := d.Receive()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, frame == nil, true)
+
+	for _, f := range []CANFrame{
+		{ID: 0x123, Data: []byte{0xDE, 0xAD, 0xBE, 0xEF}},
+		{ID: 0x7FF, Data: []byte{}},
+		{ID: 0x1ABCDEF0, Extended: true, Data: []byte{1, 2, 3, 4, 5, 6, 7, 8}},
+		{ID: 0x1ABCDEF0, Extended: true, Remote: true},
+	} {
+		gobottest.Assert(t, d.Send(f), nil)
+		frame, err = d.Receive()
+		gobottest.Assert(t, err, nil)
+		gobottest.Assert(t, *frame, f)
+	}
+	gobottest.Assert(t, sim.sent[0][:9], []byte{0x24, 0x60, 0x00, 0x00, 0x04, 0xDE, 0xAD, 0xBE, 0xEF})
+	gobottest.Assert(t, sim.sent[2][:5], []byte{0xD5, 0xE8, 0xDE, 0xF0, 0x08})
+
+	// a standard remote frame is marked in SIDL
+	sim.receive(1, 0x24, 0x70, 0x00, 0x00, 0x00)
+	frame, err = d.Receive()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, *frame, CANFrame{ID: 0x123, Remote: true})
+
+	gobottest.Assert(t, d.Send(CANFrame{ID: 0x800}), errors.New("Standard identifier 0x800 exceeds 11 bit"))
+	gobottest.Assert(t, d.Send(CANFrame{ID: 1 << 29, Extended: true}), errors.New("Extended identifier 0x20000000 exceeds 29 bit"))
+	gobottest.Assert(t, d.Send(CANFrame{Data: make([]byte, 9)}), errors.New("Data length 9 exceeds 8 bytes"))
+}
+
+func TestMCP2515DriverSendPending(t *testing.T) {
+	d, sim := initTestMCP2515Driver(WithMCP2515Interval(0))
+	gobottest.Assert(t, d.Start(), nil)
+
+	for i := 0; i < 3; i++ {
+		gobottest.Assert(t, d.Send(CANFrame{ID: uint32(i)}), nil)
+	}
+	gobottest.Assert(t, len(sim.sent), 3)
+	gobottest.Assert(t, d.Send(CANFrame{ID: 3}), errors.New("No free transmit buffer"))
+	gobottest.Assert(t, d.Command("Send")(map[string]interface{}{"id": 1.0}),
+		map[string]interface{}{"err": errors.New("No free transmit buffer")})
+}
+
+func TestMCP2515DriverFilters(t *testing.T) {
+	d, sim := initTestMCP2515Driver(WithMCP2515Interval(0))
+	// the filters are stored until start
+	gobottest.Assert(t, d.SetMask(0, 0x7FF, false), nil)
+	gobottest.Assert(t, d.SetFilter(0, 0x123, false), nil)
+	gobottest.Assert(t, d.Start(), nil)
+
+	gobottest.Assert(t, sim.register(mcp2515RegRXM0, 8), []byte{0xFF, 0xE0, 0, 0, 0, 0, 0, 0})
+	// unset filters take the first filter
+	gobottest.Assert(t, sim.register(mcp2515RegRXF0, 8), []byte{0x24, 0x60, 0, 0, 0x24, 0x60, 0, 0})
+	gobottest.Assert(t, sim.register(mcp2515RegRXF3, 4), []byte{0x24, 0x60, 0, 0})
+	gobottest.Assert(t, sim.register(mcp2515RegRXB0CTRL, 1), []byte{0x04})
+	gobottest.Assert(t, sim.register(mcp2515RegRXB1CTRL, 1), []byte{0x00})
+
+	gobottest.Assert(t, d.SetMask(1, 0x1FFFFFFF, true), nil)
+	gobottest.Assert(t, d.SetFilter(5, 0x1ABCDEF0, true), nil)
+	gobottest.Assert(t, sim.register(mcp2515RegRXM0+4, 4), []byte{0xFF, 0xEB, 0xFF, 0xFF})
+	gobottest.Assert(t, sim.register(mcp2515RegRXF3, 12), []byte{
+		0xD5, 0xE8, 0xDE, 0xF0, 0xD5, 0xE8, 0xDE, 0xF0, 0xD5, 0xE8, 0xDE, 0xF0})
+	gobottest.Assert(t, sim.register(mcp2515RegCANSTAT, 1), []byte{MCP2515ModeNormal})
+
+	gobottest.Assert(t, d.ClearFilters(), nil)
+	gobottest.Assert(t, sim.register(mcp2515RegRXB0CTRL, 1), []byte{0x64})
+
+	gobottest.Assert(t, d.SetMask(2, 0, false), errors.New("Mask 2 does not exist"))
+	gobottest.Assert(t, d.SetFilter(6, 0, false), errors.New("Filter 6 does not exist"))
+}
+
+func TestMCP2515DriverPolling(t *testing.T) {
+	d, sim := initTestMCP2515Driver(WithMCP2515IntPin("22"))
+	gobottest.Assert(t, d.Start(), nil)
+
+	frames := make(chan CANFrame, 10)
+	d.On(d.Event(Data), func(val interface{}) { frames <- val.(CANFrame) })
+
+	sim.receive(0, 0x24, 0x60, 0x00, 0x00, 0x01, 0x42)
+	select {
+	case frame := <-frames:
+		gobottest.Assert(t, frame, CANFrame{ID: 0x123, Data: []byte{0x42}})
+		gobottest.Assert(t, frame.String(), "123#42")
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestCANFrameString(t *testing.T) {
+	gobottest.Assert(t, CANFrame{ID: 0x1F, Data: []byte{0xDE, 0xAD}}.String(), "01F#DEAD")
+	gobottest.Assert(t, CANFrame{ID: 0x1ABCDEF0, Extended: true}.String(), "1ABCDEF0#")
+	gobottest.Assert(t, CANFrame{ID: 0x123, Remote: true}.String(), "123#R")
+}
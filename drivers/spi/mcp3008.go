@@ -3,6 +3,7 @@ package spi
 import (
 	"errors"
 	"strconv"
+	"sync"
 
 	"gobot.io/x/gobot"
 )
@@ -15,6 +16,8 @@ type MCP3008Driver struct {
 	name       string
 	connector  Connector
 	connection Connection
+	mutex      sync.Mutex
+	tx, rx     [3]byte
 	Config
 	gobot.Commander
 }
@@ -78,13 +81,15 @@ func (d *MCP3008Driver) Read(channel int) (result int, err error) {
 		return 0, errors.New("Invalid channel for read")
 	}
 
-	tx := make([]byte, 3)
+	// the buffers are reused, so streaming reads don't allocate
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	tx, rx := d.tx[:], d.rx[:]
 	tx[0] = 0x01
 	tx[1] = byte(8+channel) << 4
 	tx[2] = 0x00
 
-	rx := make([]byte, 3)
-
 	err = d.connection.Tx(tx, rx)
 	if err == nil && len(rx) == 3 {
 		result = int((rx[1]&0x3))<<8 + int(rx[2])
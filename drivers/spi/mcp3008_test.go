@@ -30,8 +30,24 @@ func TestMCP3008DriverHalt(t *testing.T) {
 }
 
 func TestMCP3008DriverRead(t *testing.T) {
-	d := initTestMCP3008Driver()
+	script := newSpiTestScript()
+	script.respond([]byte{0x01, 0x80}, 0x00, 0x03, 0xFF)
+	script.respond([]byte{0x01, 0xD0}, 0x00, 0x01, 0x2C)
+	d := NewMCP3008Driver(script)
 	d.Start()
 
-	// TODO: actual read test
+	val, err := d.Read(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1023)
+	val, err = d.Read(5)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 300)
+	val, err = d.Read(1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0)
+
+	// the transfer buffers are reused, the script records allocate
+	d.connection, _ = (&TestConnector{}).GetSpiConnection(0, 0, 0, 0, 0)
+	allocs := testing.AllocsPerRun(100, func() { d.Read(5) })
+	gobottest.Assert(t, allocs, 0.0)
 }
@@ -1,6 +1,7 @@
 package spi
 
 import (
+	"errors"
 	"testing"
 
 	"gobot.io/x/gobot"
@@ -29,9 +30,36 @@ func TestMCP3208DriverHalt(t *testing.T) {
 	gobottest.Assert(t, d.Halt(), nil)
 }
 
+func TestMCP3208DriverOptions(t *testing.T) {
+	script := newSpiTestScript()
+	d := NewMCP3208Driver(script, WithBus(1), WithChip(2), WithMode(3), WithBits(8), WithSpeed(1000000))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, script.bus, 1)
+	gobottest.Assert(t, script.chip, 2)
+	gobottest.Assert(t, script.mode, 3)
+	gobottest.Assert(t, script.bits, 8)
+	gobottest.Assert(t, script.speed, int64(1000000))
+}
+
 func TestMCP3208DriverRead(t *testing.T) {
-	d := initTestMCP3208Driver()
+	script := newSpiTestScript()
+	script.respond([]byte{0x06, 0x00}, 0x00, 0x0F, 0xFF)
+	script.respond([]byte{0x07, 0x80}, 0x00, 0x08, 0x00)
+	d := NewMCP3208Driver(script)
 	d.Start()
 
-	// TODO: actual read test
+	val, err := d.Read(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 4095)
+	val, err = d.Read(6)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 2048)
+	val, err = d.Read(3)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0)
+	gobottest.Assert(t, script.written(), [][]byte{{0x06, 0x00, 0x00}, {0x07, 0x80, 0x00}, {0x06, 0xC0, 0x00}})
+
+	script.err = errors.New("tx error")
+	_, err = d.Read(0)
+	gobottest.Assert(t, err, errors.New("tx error"))
 }
@@ -0,0 +1,546 @@
+package spi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	// MCP3561DriverMaxChannel is the number of channels of the MCP3561
+	MCP3561DriverMaxChannel = 2
+	// MCP3562DriverMaxChannel is the number of channels of the MCP3562
+	MCP3562DriverMaxChannel = 4
+	// MCP3564DriverMaxChannel is the number of channels of the MCP3564
+	MCP3564DriverMaxChannel = 8
+
+	// MCP356xDefaultAddress is the default device address of the MCP356x
+	MCP356xDefaultAddress = 0x01
+
+	// gain settings, the gain is applied to the difference of the inputs
+	MCP356xGain1_3 = 0x00
+	MCP356xGain1   = 0x01
+	MCP356xGain2   = 0x02
+	MCP356xGain4   = 0x03
+	MCP356xGain8   = 0x04
+	MCP356xGain16  = 0x05
+	MCP356xGain32  = 0x06
+	MCP356xGain64  = 0x07
+
+	// multiplexer inputs besides the channels 0..7
+	MCP356xMuxAGND   = 0x08
+	MCP356xMuxAVDD   = 0x09
+	MCP356xMuxREFINP = 0x0B
+	MCP356xMuxREFINM = 0x0C
+	MCP356xMuxTempP  = 0x0D
+	MCP356xMuxTempM  = 0x0E
+	MCP356xMuxVCM    = 0x0F
+
+	mcp356xRegADCData   = 0x00
+	mcp356xRegConfig0   = 0x01
+	mcp356xRegConfig3   = 0x04
+	mcp356xRegMux       = 0x06
+	mcp356xRegOffsetCal = 0x09
+	mcp356xRegGainCal   = 0x0A
+
+	mcp356xCmdFast       = 0x00
+	mcp356xCmdStaticRead = 0x01
+	mcp356xCmdWrite      = 0x02
+
+	mcp356xFastStart   = 0x0A
+	mcp356xFastStandby = 0x0B
+	mcp356xFastReset   = 0x0E
+
+	// CONFIG0: no shutdown, internal clock, no burnout current, standby
+	mcp356xConfig0 = 0xE2
+	// CONFIG2: boost 1x, no auto-zero, gain in bits 5..3
+	mcp356xConfig2 = 0x83
+	// CONFIG3: one-shot conversion with standby, 24-bit data, calibration in bits 1..0
+	mcp356xConfig3OneShot    = 0x80
+	mcp356xConfig3Continuous = 0xC0
+	// IRQ: IRQ output push-pull, fast commands and start interrupt enabled
+	mcp356xIRQ = 0x07
+
+	mcp356xStatusNotReady = 0x04
+	mcp356xPollInterval   = time.Millisecond
+	mcp356xTimeout        = time.Second
+)
+
+var mcp356xGainFactors = []float64{1.0 / 3, 1, 2, 4, 8, 16, 32, 64}
+
+var mcp356xOversamplings = map[int]byte{
+	32: 0x00, 64: 0x01, 128: 0x02, 256: 0x03, 512: 0x04, 1024: 0x05, 2048: 0x06, 4096: 0x07,
+	8192: 0x08, 16384: 0x09, 20480: 0x0A, 24576: 0x0B, 40960: 0x0C, 49152: 0x0D, 81920: 0x0E, 98304: 0x0F,
+}
+
+// MCP356xDriver is a driver for the MCP3561/2/4 24-bit delta-sigma A/D converters.
+// The conversion result is a signed 24-bit value, the voltage is given by the
+// reference voltage and the gain.
+type MCP356xDriver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	channels   int
+	address    byte
+	reference  float64
+	gain       byte
+	osr        int
+	irqPin     string
+	offsetCal  int
+	gainCal    float64
+	halt       chan bool
+	done       chan bool
+	mutex      sync.Mutex
+	Config
+	gobot.Commander
+	gobot.CommandSchemer
+	gobot.Eventer
+}
+
+// NewMCP3561Driver creates a new Gobot Driver for the MCP3561 A/D converter with 2 channels
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                   bus to use with this driver
+//      spi.WithChip(int):                  chip to use with this driver
+//      spi.WithMode(int):                  mode to use with this driver
+//      spi.WithBits(int):                  number of bits to use with this driver
+//      spi.WithSpeed(int64):               speed in Hz to use with this driver
+//      spi.WithMCP356xAddress(byte):       device address (defaults to 1)
+//      spi.WithMCP356xReference(float64):  reference voltage (defaults to 3.3V)
+//      spi.WithMCP356xGain(byte):          one of the MCP356xGain* values (defaults to MCP356xGain1)
+//      spi.WithMCP356xOversampling(int):   oversampling ratio between 32 and 98304 (defaults to 256)
+//      spi.WithMCP356xIRQPin(string):      gpio pin connected to IRQ, for continuous conversion
+//
+func NewMCP3561Driver(a Connector, options ...func(Config)) *MCP356xDriver {
+	return newMCP356xDriver(a, "MCP3561", MCP3561DriverMaxChannel, options...)
+}
+
+// NewMCP3562Driver creates a new Gobot Driver for the MCP3562 A/D converter with 4 channels,
+// see NewMCP3561Driver for the optional params
+func NewMCP3562Driver(a Connector, options ...func(Config)) *MCP356xDriver {
+	return newMCP356xDriver(a, "MCP3562", MCP3562DriverMaxChannel, options...)
+}
+
+// NewMCP3564Driver creates a new Gobot Driver for the MCP3564 A/D converter with 8 channels,
+// see NewMCP3561Driver for the optional params
+func NewMCP3564Driver(a Connector, options ...func(Config)) *MCP356xDriver {
+	return newMCP356xDriver(a, "MCP3564", MCP3564DriverMaxChannel, options...)
+}
+
+func newMCP356xDriver(a Connector, name string, channels int, options ...func(Config)) *MCP356xDriver {
+	d := &MCP356xDriver{
+		name:           gobot.DefaultName(name),
+		connector:      a,
+		channels:       channels,
+		address:        MCP356xDefaultAddress,
+		reference:      3.3,
+		gain:           MCP356xGain1,
+		osr:            256,
+		gainCal:        1,
+		Config:         NewConfig(),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		Eventer:        gobot.NewEventer(),
+	}
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddEvent(Data)
+	d.AddEvent(Error)
+
+	d.AddCommand("Read", func(params map[string]interface{}) interface{} {
+		val, err := d.Read(int(params["channel"].(float64)))
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommandSchema("Read", gobot.CommandSchema{"channel": gobot.CommandParamNumber})
+	d.AddCommand("ReadDifference", func(params map[string]interface{}) interface{} {
+		val, err := d.ReadDifference(int(params["plus"].(float64)), int(params["minus"].(float64)))
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	d.AddCommandSchema("ReadDifference", gobot.CommandSchema{
+		"plus":  gobot.CommandParamNumber,
+		"minus": gobot.CommandParamNumber,
+	})
+	return d
+}
+
+// WithMCP356xAddress option sets the device address of the MCP356xDriver.
+func WithMCP356xAddress(val byte) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP356xDriver)
+		if ok {
+			d.address = val & 0x03
+		} else {
+			panic("unable to set address for mcp356x")
+		}
+	}
+}
+
+// WithMCP356xReference option sets the reference voltage of the MCP356xDriver.
+func WithMCP356xReference(val float64) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP356xDriver)
+		if ok {
+			d.reference = val
+		} else {
+			panic("unable to set reference for mcp356x")
+		}
+	}
+}
+
+// WithMCP356xGain option sets the gain of the MCP356xDriver to one of the MCP356xGain* values.
+func WithMCP356xGain(val byte) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP356xDriver)
+		if ok {
+			d.gain = val
+		} else {
+			panic("unable to set gain for mcp356x")
+		}
+	}
+}
+
+// WithMCP356xOversampling option sets the oversampling ratio of the MCP356xDriver.
+// Higher ratios lead to less noise but fewer samples per second.
+func WithMCP356xOversampling(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP356xDriver)
+		if ok {
+			d.osr = val
+		} else {
+			panic("unable to set oversampling for mcp356x")
+		}
+	}
+}
+
+// WithMCP356xIRQPin option sets the gpio pin connected to the IRQ output of the
+// MCP356xDriver, which signals new data during continuous conversion.
+// The connector needs to be a gpio.DigitalReader.
+func WithMCP356xIRQPin(val string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*MCP356xDriver)
+		if ok {
+			d.irqPin = val
+		} else {
+			panic("unable to set irq pin for mcp356x")
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *MCP356xDriver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *MCP356xDriver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the device.
+func (d *MCP356xDriver) Connection() gobot.Connection { return d.connection.(gobot.Connection) }
+
+// Start initializes the driver, resets the device and writes the configuration.
+func (d *MCP356xDriver) Start() (err error) {
+	if int(d.gain) >= len(mcp356xGainFactors) {
+		return fmt.Errorf("Gain must be one of the MCP356xGain* values")
+	}
+	osr, ok := mcp356xOversamplings[d.osr]
+	if !ok {
+		return fmt.Errorf("Oversampling ratio %d is not supported", d.osr)
+	}
+	if d.irqPin != "" {
+		if _, ok := d.connector.(gpio.DigitalReader); !ok {
+			return errors.New("IRQ pin needs a connector which is a DigitalReader")
+		}
+	}
+
+	bus := d.GetBusOrDefault(d.connector.GetSpiDefaultBus())
+	chip := d.GetChipOrDefault(d.connector.GetSpiDefaultChip())
+	mode := d.GetModeOrDefault(d.connector.GetSpiDefaultMode())
+	bits := d.GetBitsOrDefault(d.connector.GetSpiDefaultBits())
+	maxSpeed := d.GetSpeedOrDefault(d.connector.GetSpiDefaultMaxSpeed())
+
+	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	status, err := d.fastCommand(mcp356xFastReset)
+	if err != nil {
+		return err
+	}
+	if (status>>4)&0x03 != d.address || (status>>3)&0x01 == d.address&0x01 {
+		return fmt.Errorf("MCP356x not found at device address %d", d.address)
+	}
+
+	if err = d.writeCalibration(); err != nil {
+		return err
+	}
+	// CONFIG0..CONFIG3 and IRQ
+	return d.writeRegisters(mcp356xRegConfig0, mcp356xConfig0, osr<<2, mcp356xConfig2|d.gain<<3,
+		mcp356xConfig3OneShot|d.calibrationBits(), mcp356xIRQ)
+}
+
+// Halt stops a continuous conversion and puts the device into standby.
+func (d *MCP356xDriver) Halt() (err error) {
+	if err = d.StopContinuous(); err != nil {
+		return
+	}
+	if d.connection == nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	_, err = d.fastCommand(mcp356xFastStandby)
+	return
+}
+
+// SetCalibration sets the offset (in LSB) and gain calibration (between 0 and 2)
+// which the device applies to all conversion results. The default of 0 and 1
+// disables the calibration.
+func (d *MCP356xDriver) SetCalibration(offset int, gain float64) (err error) {
+	if offset < -(1<<23) || offset >= 1<<23 {
+		return fmt.Errorf("Offset calibration %d exceeds 24 bit", offset)
+	}
+	if gain < 0 || gain >= 2 {
+		return fmt.Errorf("Gain calibration %f is not between 0 and 2", gain)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.offsetCal, d.gainCal = offset, gain
+	if d.connection == nil {
+		// written on Start
+		return
+	}
+	if err = d.writeCalibration(); err != nil {
+		return
+	}
+	config3 := byte(mcp356xConfig3OneShot)
+	if d.done != nil {
+		config3 = mcp356xConfig3Continuous
+	}
+	return d.writeRegisters(mcp356xRegConfig3, config3|d.calibrationBits())
+}
+
+// Read reads the voltage of the channel against AGND.
+func (d *MCP356xDriver) Read(channel int) (result float64, err error) {
+	return d.ReadDifference(channel, MCP356xMuxAGND)
+}
+
+// ReadDifference reads the voltage between the plus and minus input, which are
+// channels or one of the MCP356xMux* values.
+func (d *MCP356xDriver) ReadDifference(plus int, minus int) (result float64, err error) {
+	raw, err := d.ReadRaw(plus, minus)
+	if err != nil {
+		return 0, err
+	}
+	return d.voltage(raw), nil
+}
+
+// ReadRaw converts the voltage between the plus and minus input once and
+// returns the signed 24-bit result.
+func (d *MCP356xDriver) ReadRaw(plus int, minus int) (result int, err error) {
+	mux, err := d.mux(plus, minus)
+	if err != nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.done != nil {
+		return 0, errors.New("Continuous conversion is running")
+	}
+	if err = d.writeRegisters(mcp356xRegMux, mux); err != nil {
+		return
+	}
+	if _, err = d.fastCommand(mcp356xFastStart); err != nil {
+		return
+	}
+
+	timeout := time.After(mcp356xTimeout)
+	for {
+		value, ready, err := d.readData()
+		if err != nil || ready {
+			return value, err
+		}
+		select {
+		case <-timeout:
+			return 0, errors.New("Timeout while waiting for the conversion")
+		case <-time.After(mcp356xPollInterval):
+		}
+	}
+}
+
+// AnalogRead returns the signed 24-bit value of the channel against AGND
+func (d *MCP356xDriver) AnalogRead(pin string) (value int, err error) {
+	channel, err := strconv.Atoi(pin)
+	if err != nil {
+		return
+	}
+	return d.ReadRaw(channel, MCP356xMuxAGND)
+}
+
+// StartContinuous starts the continuous conversion of the voltage between the
+// plus and minus input. Each result is published as Data event in V. New data
+// is signaled by the IRQ pin or, without IRQ pin, polled from the status.
+func (d *MCP356xDriver) StartContinuous(plus int, minus int) (err error) {
+	mux, err := d.mux(plus, minus)
+	if err != nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.done != nil {
+		return errors.New("Continuous conversion is already running")
+	}
+	if err = d.writeRegisters(mcp356xRegMux, mux); err != nil {
+		return
+	}
+	if err = d.writeRegisters(mcp356xRegConfig3, mcp356xConfig3Continuous|d.calibrationBits()); err != nil {
+		return
+	}
+	if _, err = d.fastCommand(mcp356xFastStart); err != nil {
+		return
+	}
+
+	d.halt = make(chan bool)
+	d.done = make(chan bool)
+	go d.continuous(d.halt, d.done)
+	return
+}
+
+// StopContinuous stops a running continuous conversion
+func (d *MCP356xDriver) StopContinuous() (err error) {
+	d.mutex.Lock()
+	halt, done := d.halt, d.done
+	d.mutex.Unlock()
+
+	if done == nil {
+		return
+	}
+	halt <- true
+	<-done
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.done = nil
+	if _, err = d.fastCommand(mcp356xFastStandby); err != nil {
+		return
+	}
+	return d.writeRegisters(mcp356xRegConfig3, mcp356xConfig3OneShot|d.calibrationBits())
+}
+
+func (d *MCP356xDriver) continuous(halt chan bool, done chan bool) {
+	defer close(done)
+
+	for {
+		select {
+		case <-halt:
+			return
+		case <-time.After(mcp356xPollInterval):
+		}
+
+		if d.irqPin != "" {
+			// IRQ is active low
+			level, err := d.connector.(gpio.DigitalReader).DigitalRead(d.irqPin)
+			if err != nil {
+				d.Publish(Error, err)
+				continue
+			}
+			if level != 0 {
+				continue
+			}
+		}
+
+		d.mutex.Lock()
+		value, ready, err := d.readData()
+		d.mutex.Unlock()
+
+		if err != nil {
+			d.Publish(Error, err)
+		} else if ready {
+			d.Publish(Data, d.voltage(value))
+		}
+	}
+}
+
+func (d *MCP356xDriver) mux(plus int, minus int) (byte, error) {
+	for _, input := range []int{plus, minus} {
+		if (input < 0 || input >= d.channels) && (input < MCP356xMuxAGND || input > MCP356xMuxVCM || input == 0x0A) {
+			return 0, fmt.Errorf("Invalid input %d for %s", input, d.name)
+		}
+	}
+	return byte(plus<<4 | minus), nil
+}
+
+func (d *MCP356xDriver) voltage(raw int) float64 {
+	return float64(raw) * d.reference / (mcp356xGainFactors[d.gain] * (1 << 23))
+}
+
+func (d *MCP356xDriver) writeCalibration() (err error) {
+	off := uint32(d.offsetCal) & 0xFFFFFF
+	gain := uint32(d.gainCal * 0x800000)
+	if err = d.writeRegisters(mcp356xRegOffsetCal, byte(off>>16), byte(off>>8), byte(off)); err != nil {
+		return
+	}
+	return d.writeRegisters(mcp356xRegGainCal, byte(gain>>16), byte(gain>>8), byte(gain))
+}
+
+func (d *MCP356xDriver) calibrationBits() (bits byte) {
+	if d.offsetCal != 0 {
+		bits |= 0x02
+	}
+	if d.gainCal != 1 {
+		bits |= 0x01
+	}
+	return
+}
+
+// readData reads the conversion result, ready is false if no new data is available
+func (d *MCP356xDriver) readData() (value int, ready bool, err error) {
+	rx := make([]byte, 4)
+	if err = d.connection.Tx([]byte{d.command(mcp356xRegADCData, mcp356xCmdStaticRead), 0, 0, 0}, rx); err != nil {
+		return
+	}
+	if rx[0]&mcp356xStatusNotReady != 0 {
+		return 0, false, nil
+	}
+	value = int(rx[1])<<16 | int(rx[2])<<8 | int(rx[3])
+	if value&0x800000 != 0 {
+		value -= 1 << 24
+	}
+	return value, true, nil
+}
+
+func (d *MCP356xDriver) writeRegisters(reg byte, data ...byte) (err error) {
+	tx := append([]byte{d.command(reg, mcp356xCmdWrite)}, data...)
+	return d.connection.Tx(tx, make([]byte, len(tx)))
+}
+
+// fastCommand sends the command and returns the status byte
+func (d *MCP356xDriver) fastCommand(cmd byte) (status byte, err error) {
+	rx := make([]byte, 1)
+	if err = d.connection.Tx([]byte{d.command(cmd, mcp356xCmdFast)}, rx); err != nil {
+		return
+	}
+	return rx[0], nil
+}
+
+func (d *MCP356xDriver) command(reg byte, cmd byte) byte {
+	return d.address<<6 | reg<<2 | cmd
+}
@@ -0,0 +1,259 @@
+package spi
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/aio"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*MCP356xDriver)(nil)
+
+// must implement the AnalogReader interface
+var _ aio.AnalogReader = (*MCP356xDriver)(nil)
+
+// mcp356xSimulator simulates the registers and the conversion of a MCP356x
+type mcp356xSimulator struct {
+	mtx       sync.Mutex
+	address   byte
+	registers [16][]byte
+	value     int
+	ready     bool
+	fast      []byte
+	irq       int
+	err       error
+}
+
+func (s *mcp356xSimulator) Close() error { return nil }
+
+func (s *mcp356xSimulator) Tx(w, r []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	status := s.address<<4 | (^s.address&0x01)<<3
+	if !s.ready {
+		status |= mcp356xStatusNotReady
+	}
+	r[0] = status
+
+	reg := (w[0] >> 2) & 0x0F
+	switch w[0] & 0x03 {
+	case mcp356xCmdFast:
+		s.fast = append(s.fast, reg)
+		// in continuous mode new data is given by convert
+		config3 := s.registers[mcp356xRegConfig3]
+		if reg == mcp356xFastStart && config3[len(config3)-1]&0xC0 != 0xC0 {
+			s.ready = true
+		}
+	case mcp356xCmdStaticRead:
+		if reg == mcp356xRegADCData && s.ready {
+			v := uint32(s.value) & 0xFFFFFF
+			r[1], r[2], r[3] = byte(v>>16), byte(v>>8), byte(v)
+			s.ready = false
+			s.irq = 1
+		}
+	case mcp356xCmdWrite:
+		// incremental write of the following registers, TIMER, OFFSETCAL
+		// and GAINCAL have 24 bit
+		for data := w[1:]; len(data) > 0; reg++ {
+			size := 1
+			if reg >= 0x08 && reg <= mcp356xRegGainCal {
+				size = 3
+			}
+			s.registers[reg] = append(s.registers[reg], data[:size]...)
+			data = data[size:]
+		}
+	}
+	return nil
+}
+
+func (s *mcp356xSimulator) register(reg int) []byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.registers[reg]
+}
+
+func (s *mcp356xSimulator) convert(value int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.value, s.ready, s.irq = value, true, 0
+}
+
+type mcp356xTestConnector struct {
+	spiTestConnector
+	sim *mcp356xSimulator
+}
+
+func (ctr *mcp356xTestConnector) DigitalRead(pin string) (int, error) {
+	ctr.sim.mtx.Lock()
+	defer ctr.sim.mtx.Unlock()
+	return ctr.sim.irq, nil
+}
+
+func initTestMCP356xDriver(options ...func(Config)) (*MCP356xDriver, *mcp356xSimulator) {
+	sim := &mcp356xSimulator{address: MCP356xDefaultAddress, irq: 1}
+	ctr := &mcp356xTestConnector{sim: sim}
+	ctr.conn = sim
+	return NewMCP3562Driver(ctr, options...), sim
+}
+
+func TestMCP356xDriver(t *testing.T) {
+	d, _ := initTestMCP356xDriver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "MCP3562"), true)
+	gobottest.Assert(t, d.channels, MCP3562DriverMaxChannel)
+	gobottest.Assert(t, d.gain, byte(MCP356xGain1))
+	gobottest.Assert(t, d.osr, 256)
+
+	d.SetName("adc")
+	gobottest.Assert(t, d.Name(), "adc")
+
+	d = NewMCP3561Driver(&TestConnector{}, WithMCP356xGain(MCP356xGain4), WithMCP356xReference(2.4))
+	gobottest.Assert(t, d.channels, MCP3561DriverMaxChannel)
+	gobottest.Assert(t, d.gain, byte(MCP356xGain4))
+	gobottest.Assert(t, d.reference, 2.4)
+
+	d = NewMCP3564Driver(&TestConnector{}, WithMCP356xAddress(2), WithMCP356xOversampling(4096), WithMCP356xIRQPin("7"))
+	gobottest.Assert(t, d.channels, MCP3564DriverMaxChannel)
+	gobottest.Assert(t, d.address, byte(2))
+	gobottest.Assert(t, d.osr, 4096)
+	gobottest.Assert(t, d.irqPin, "7")
+}
+
+func TestMCP356xDriverStart(t *testing.T) {
+	d, sim := initTestMCP356xDriver(WithMCP356xGain(MCP356xGain8), WithMCP356xOversampling(1024))
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, sim.fast, []byte{mcp356xFastReset})
+	gobottest.Assert(t, sim.register(mcp356xRegConfig0), []byte{0xE2})
+	gobottest.Assert(t, sim.register(2), []byte{0x14})
+	gobottest.Assert(t, sim.register(3), []byte{0xA3})
+	gobottest.Assert(t, sim.register(mcp356xRegConfig3), []byte{0x80})
+	gobottest.Assert(t, sim.register(5), []byte{0x07})
+	gobottest.Assert(t, sim.register(mcp356xRegGainCal), []byte{0x80, 0x00, 0x00})
+}
+
+func TestMCP356xDriverStartError(t *testing.T) {
+	d, sim := initTestMCP356xDriver(WithMCP356xAddress(3))
+	gobottest.Assert(t, d.Start(), errors.New("MCP356x not found at device address 3"))
+
+	d, _ = initTestMCP356xDriver(WithMCP356xOversampling(100))
+	gobottest.Assert(t, d.Start(), errors.New("Oversampling ratio 100 is not supported"))
+
+	d, _ = initTestMCP356xDriver(WithMCP356xGain(8))
+	gobottest.Refute(t, d.Start(), nil)
+
+	d = NewMCP3561Driver(&TestConnector{}, WithMCP356xIRQPin("7"))
+	gobottest.Assert(t, d.Start(), errors.New("IRQ pin needs a connector which is a DigitalReader"))
+
+	d, sim = initTestMCP356xDriver()
+	sim.err = errors.New("tx error")
+	gobottest.Assert(t, d.Start(), errors.New("tx error"))
+}
+
+func TestMCP356xDriverRead(t *testing.T) {
+	d, sim := initTestMCP356xDriver(WithMCP356xReference(2.4), WithMCP356xGain(MCP356xGain2))
+	d.Start()
+
+	sim.value = 0x400000
+	val, err := d.Read(1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 0.6)
+	gobottest.Assert(t, sim.register(mcp356xRegMux), []byte{0x18})
+
+	sim.value = -0x200000
+	val, err = d.ReadDifference(2, 3)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, -0.3)
+	gobottest.Assert(t, sim.register(mcp356xRegMux), []byte{0x18, 0x23})
+
+	sim.value = 1234
+	raw, err := d.AnalogRead("0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, raw, 1234)
+
+	_, err = d.ReadRaw(MCP356xMuxTempP, MCP356xMuxTempM)
+	gobottest.Assert(t, err, nil)
+
+	_, err = d.Read(4)
+	gobottest.Assert(t, err, errors.New("Invalid input 4 for "+d.Name()))
+	_, err = d.ReadDifference(0, 0x0A)
+	gobottest.Refute(t, err, nil)
+	_, err = d.AnalogRead("x")
+	gobottest.Refute(t, err, nil)
+
+	ret := d.Command("Read")(map[string]interface{}{"channel": 0.0}).(map[string]interface{})
+	gobottest.Assert(t, ret["err"], nil)
+	ret = d.Command("ReadDifference")(map[string]interface{}{"plus": 0.0, "minus": 1.0}).(map[string]interface{})
+	gobottest.Assert(t, ret["err"], nil)
+}
+
+func TestMCP356xDriverCalibration(t *testing.T) {
+	d, sim := initTestMCP356xDriver()
+	gobottest.Assert(t, d.SetCalibration(-2, 1.5), nil)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, sim.register(mcp356xRegOffsetCal), []byte{0xFF, 0xFF, 0xFE})
+	gobottest.Assert(t, sim.register(mcp356xRegGainCal), []byte{0xC0, 0x00, 0x00})
+	gobottest.Assert(t, sim.register(mcp356xRegConfig3), []byte{0x83})
+
+	gobottest.Assert(t, d.SetCalibration(0, 1), nil)
+	gobottest.Assert(t, sim.register(mcp356xRegConfig3), []byte{0x83, 0x80})
+
+	gobottest.Refute(t, d.SetCalibration(1<<23, 1), nil)
+	gobottest.Refute(t, d.SetCalibration(0, 2), nil)
+}
+
+func TestMCP356xDriverContinuous(t *testing.T) {
+	d, sim := initTestMCP356xDriver(WithMCP356xIRQPin("7"))
+	gobottest.Assert(t, d.Start(), nil)
+
+	data := make(chan float64, 10)
+	d.On(d.Event(Data), func(data_ interface{}) {
+		data <- data_.(float64)
+	})
+
+	gobottest.Assert(t, d.StartContinuous(0, 1), nil)
+	gobottest.Assert(t, d.StartContinuous(0, 1), errors.New("Continuous conversion is already running"))
+	gobottest.Assert(t, sim.register(mcp356xRegConfig3), []byte{0x80, 0xC0})
+	_, err := d.Read(0)
+	gobottest.Assert(t, err, errors.New("Continuous conversion is running"))
+
+	sim.convert(0x7FFFFF)
+	select {
+	case val := <-data:
+		gobottest.Assert(t, val > 3.29 && val < 3.3, true)
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+
+	gobottest.Assert(t, d.Halt(), nil)
+	gobottest.Assert(t, sim.register(mcp356xRegConfig3), []byte{0x80, 0xC0, 0x80})
+	gobottest.Assert(t, sim.fast, []byte{mcp356xFastReset, mcp356xFastStart, mcp356xFastStandby, mcp356xFastStandby})
+}
+
+func TestMCP356xDriverContinuousPolling(t *testing.T) {
+	d, sim := initTestMCP356xDriver()
+	d.Start()
+
+	data := make(chan float64, 10)
+	d.On(d.Event(Data), func(data_ interface{}) {
+		data <- data_.(float64)
+	})
+
+	gobottest.Assert(t, d.StartContinuous(0, MCP356xMuxAGND), nil)
+	sim.convert(-0x800000)
+	select {
+	case val := <-data:
+		gobottest.Assert(t, val, -3.3)
+	case <-time.After(time.Second):
+		t.Errorf("Data event was not published")
+	}
+	gobottest.Assert(t, d.StopContinuous(), nil)
+	gobottest.Assert(t, d.StopContinuous(), nil)
+}
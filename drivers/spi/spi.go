@@ -11,9 +11,26 @@ const (
 	NotInitialized = -1
 )
 
+// maxTxSize is the maximum number of bytes of a single transfer, which is the
+// default buffer size of the Linux spidev driver
+const maxTxSize = 4096
+
+const (
+	// Data event
+	Data = "data"
+	// Error event
+	Error = "error"
+	// Fault event
+	Fault = "fault"
+)
+
 // Operations are the wrappers around the actual functions used by the SPI device interface
 type Operations interface {
 	Close() error
+	// Tx writes w and reads r in a single full-duplex transfer with the
+	// buffers of the caller, nothing is copied. When both are given, they
+	// must have the same length. A transfer is limited to maxTxSize bytes
+	// by spidev.
 	Tx(w, r []byte) error
 }
 
@@ -69,7 +86,9 @@ func (c *SpiConnection) Close() error {
 	return c.port.Close()
 }
 
-// Tx uses the SPI device to send/receive data.
+// Tx uses the SPI device to send/receive data. The buffers are passed to a
+// single SPI_IOC_MESSAGE ioctl, so buffers reused by the caller keep
+// streaming free of allocations.
 func (c *SpiConnection) Tx(w, r []byte) error {
 	return c.dev.Tx(w, r)
 }
@@ -86,3 +105,18 @@ func GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (Connecti
 	}
 	return NewConnection(p, c), nil
 }
+
+// writeChunked writes the data in transfers of at most maxTxSize bytes.
+func writeChunked(c Connection, data []byte) error {
+	for len(data) > 0 {
+		n := len(data)
+		if n > maxTxSize {
+			n = maxTxSize
+		}
+		if err := c.Tx(data[:n], nil); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package spi
+
+import "testing"
+
+func BenchmarkConnectionTx(b *testing.B) {
+	c, _ := (&TestConnector{}).GetSpiConnection(0, 0, 0, 8, 500000)
+	w := make([]byte, 32)
+	r := make([]byte, 32)
+
+	for i := 0; i < b.N; i++ {
+		c.Tx(w, r)
+	}
+}
+
+func BenchmarkMCP3008DriverRead(b *testing.B) {
+	d := NewMCP3008Driver(&TestConnector{})
+	d.Start()
+
+	for i := 0; i < b.N; i++ {
+		d.Read(i & 0x07)
+	}
+}
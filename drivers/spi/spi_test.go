@@ -1,6 +1,8 @@
 package spi
 
 import (
+	"sync"
+
 	"periph.io/x/periph/conn"
 	"periph.io/x/periph/conn/physic"
 	xspi "periph.io/x/periph/conn/spi"
@@ -71,3 +73,184 @@ func (c *TestSpiConnection) Connect(maxHz physic.Frequency, mode xspi.Mode, bits
 func (c *TestSpiConnection) LimitSpeed(maxHz physic.Frequency) error {
 	return nil
 }
+
+// spiTestConnector returns the given connection, e.g. a device simulator
+type spiTestConnector struct {
+	TestConnector
+	conn Connection
+	err  error
+}
+
+func (ctr *spiTestConnector) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (device Connection, err error) {
+	return ctr.conn, ctr.err
+}
+
+// spiTestRecorder records all data written to the connection
+type spiTestRecorder struct {
+	mtx  sync.Mutex
+	data []byte
+	txs  int
+}
+
+func (c *spiTestRecorder) Close() error { return nil }
+
+func (c *spiTestRecorder) Tx(w, r []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.data = append(c.data, w...)
+	c.txs++
+	return nil
+}
+
+func (c *spiTestRecorder) written() []byte {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	data := c.data
+	c.data, c.txs = nil, 0
+	return data
+}
+
+// spiTestScript answers each transfer with the response of the longest
+// command the written bytes start with, like a register map. It records the
+// transfers and the parameters of the connection.
+type spiTestScript struct {
+	TestConnector
+	mtx       sync.Mutex
+	responses map[string][]byte
+	transfers [][]byte
+	err       error
+	bus       int
+	chip      int
+	mode      int
+	bits      int
+	speed     int64
+}
+
+func newSpiTestScript() *spiTestScript {
+	return &spiTestScript{responses: make(map[string][]byte)}
+}
+
+func (s *spiTestScript) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (device Connection, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.bus, s.chip, s.mode, s.bits, s.speed = busNum, chipNum, mode, bits, maxSpeed
+	return s, nil
+}
+
+func (s *spiTestScript) Close() error { return nil }
+
+// respond sets the bytes read by transfers starting with cmd, the response
+// includes the bytes read while the command is written
+func (s *spiTestScript) respond(cmd []byte, resp ...byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.responses[string(cmd)] = resp
+}
+
+func (s *spiTestScript) Tx(w, r []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.transfers = append(s.transfers, append([]byte{}, w...))
+
+	for i := range r {
+		r[i] = 0
+	}
+	for n := len(w); n >= 0; n-- {
+		if resp, ok := s.responses[string(w[:n])]; ok {
+			copy(r, resp)
+			break
+		}
+	}
+	return nil
+}
+
+// written returns the written bytes of all transfers and resets them
+func (s *spiTestScript) written() [][]byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	transfers := s.transfers
+	s.transfers = nil
+	return transfers
+}
+
+type displayTestCommand struct {
+	cmd  byte
+	data []byte
+}
+
+// displayTestAdaptor records the commands and data sent to a display with
+// data/command pin
+type displayTestAdaptor struct {
+	TestConnector
+	mtx      sync.Mutex
+	name     string
+	dcPin    string
+	dc       byte
+	commands []displayTestCommand
+	maxTx    int
+	err      error
+}
+
+func newDisplayTestAdaptor() *displayTestAdaptor {
+	return &displayTestAdaptor{dcPin: "16"}
+}
+
+func (a *displayTestAdaptor) Connect() (err error)  { return }
+func (a *displayTestAdaptor) Finalize() (err error) { return }
+func (a *displayTestAdaptor) Name() string          { return a.name }
+func (a *displayTestAdaptor) SetName(n string)      { a.name = n }
+
+func (a *displayTestAdaptor) DigitalWrite(pin string, val byte) (err error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if pin == a.dcPin {
+		a.dc = val
+	}
+	return
+}
+
+func (a *displayTestAdaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (device Connection, err error) {
+	return a, nil
+}
+
+func (a *displayTestAdaptor) Close() error { return nil }
+
+func (a *displayTestAdaptor) Tx(w, r []byte) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.err != nil {
+		return a.err
+	}
+	if len(w) > a.maxTx {
+		a.maxTx = len(w)
+	}
+	if a.dc == 0 {
+		a.commands = append(a.commands, displayTestCommand{cmd: w[0]})
+	} else {
+		last := &a.commands[len(a.commands)-1]
+		last.data = append(last.data, w...)
+	}
+	return nil
+}
+
+// command returns the parameters of the last occurrence of the command
+func (a *displayTestAdaptor) command(cmd byte) (data []byte, ok bool) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	for _, c := range a.commands {
+		if c.cmd == cmd {
+			data, ok = c.data, true
+		}
+	}
+	return
+}
+
+func (a *displayTestAdaptor) reset() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.commands = nil
+}
@@ -170,6 +170,7 @@ type SSD1306Driver struct {
 	buffer        *DisplayBuffer
 	Config
 	gobot.Commander
+	gobot.CommandSchemer
 }
 
 // NewSSD1306Driver creates a new SSD1306Driver.
@@ -196,15 +197,16 @@ func NewSSD1306Driver(a gobot.Adaptor, options ...func(Config)) *SSD1306Driver {
 		panic("unable to get gobot connector for ssd1306")
 	}
 	s := &SSD1306Driver{
-		name:          gobot.DefaultName("SSD1306"),
-		Commander:     gobot.NewCommander(),
-		connector:     b,
-		DisplayWidth:  ssd1306Width,
-		DisplayHeight: ssd1306Height,
-		DCPin:         ssd1306DcPin,
-		RSTPin:        ssd1306RstPin,
-		ExternalVcc:   ssd1306ExternalVcc,
-		Config:        NewConfig(),
+		name:           gobot.DefaultName("SSD1306"),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
+		connector:      b,
+		DisplayWidth:   ssd1306Width,
+		DisplayHeight:  ssd1306Height,
+		DCPin:          ssd1306DcPin,
+		RSTPin:         ssd1306RstPin,
+		ExternalVcc:    ssd1306ExternalVcc,
+		Config:         NewConfig(),
 	}
 	for _, option := range options {
 		option(s)
@@ -230,17 +232,23 @@ func NewSSD1306Driver(a gobot.Adaptor, options ...func(Config)) *SSD1306Driver {
 		return map[string]interface{}{"err": err}
 	})
 	s.AddCommand("SetContrast", func(params map[string]interface{}) interface{} {
-		contrast := byte(params["contrast"].(byte))
+		contrast := byte(gobot.CommandParamFloat(params["contrast"]))
 		err := s.SetContrast(contrast)
 		return map[string]interface{}{"err": err}
 	})
+	s.AddCommandSchema("SetContrast", gobot.CommandSchema{"contrast": gobot.CommandParamNumber})
 	s.AddCommand("Set", func(params map[string]interface{}) interface{} {
-		x := int(params["x"].(int))
-		y := int(params["y"].(int))
-		c := int(params["c"].(int))
+		x := int(gobot.CommandParamFloat(params["x"]))
+		y := int(gobot.CommandParamFloat(params["y"]))
+		c := int(gobot.CommandParamFloat(params["c"]))
 		s.Set(x, y, c)
 		return nil
 	})
+	s.AddCommandSchema("Set", gobot.CommandSchema{
+		"x": gobot.CommandParamNumber,
+		"y": gobot.CommandParamNumber,
+		"c": gobot.CommandParamNumber,
+	})
 	return s
 }
 
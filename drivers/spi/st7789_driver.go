@@ -0,0 +1,413 @@
+package spi
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+)
+
+const (
+	// default values
+	st7789Width  = 240
+	st7789Height = 240
+	st7789DcPin  = "16" // for raspberry pi
+	st7789RstPin = "18" // for raspberry pi
+	// the frame memory has 240x320 pixels
+	st7789MemoryHeight = 320
+
+	st7789SoftwareReset   = 0x01
+	st7789SleepOut        = 0x11
+	st7789NormalDisplayOn = 0x13
+	st7789InversionOff    = 0x20
+	st7789InversionOn     = 0x21
+	st7789DisplayOff      = 0x28
+	st7789DisplayOn       = 0x29
+	st7789ColumnAddr      = 0x2A
+	st7789RowAddr         = 0x2B
+	st7789MemoryWrite     = 0x2C
+	st7789MemoryAccess    = 0x36
+	st7789PixelFormat     = 0x3A
+
+	// RGB565
+	st7789PixelFormat16Bit = 0x55
+	// memory access control bits for the row/column order
+	st7789MADCTLMY = 0x80
+	st7789MADCTLMX = 0x40
+	st7789MADCTLMV = 0x20
+)
+
+// ST7789Driver is a Gobot Driver for a ST7789 TFT display with 240x240 or
+// 240x320 pixels. The image is drawn into a framebuffer, which is sent to
+// the display as a whole or partially by a window.
+type ST7789Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	dcDriver   *gpio.DirectPinDriver
+	rstDriver  *gpio.DirectPinDriver
+	width      int
+	height     int
+	rotation   int
+	invert     bool
+	DCPin      string
+	RSTPin     string
+	buffer     *image.RGBA
+	mutex      sync.Mutex
+	Config
+	gobot.Commander
+}
+
+// NewST7789Driver creates a new ST7789Driver.
+//
+// Params:
+//      conn Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//      spi.WithBus(int):                   bus to use with this driver
+//      spi.WithChip(int):                  chip to use with this driver
+//      spi.WithMode(int):                  mode to use with this driver
+//      spi.WithBits(int):                  number of bits to use with this driver
+//      spi.WithSpeed(int64):               speed in Hz to use with this driver
+//      spi.WithST7789Size(int, int):       width and height of the display in portrait orientation (defaults to 240x240)
+//      spi.WithST7789Rotation(int):        rotation of 0, 90, 180 or 270 degrees (defaults to 0)
+//      spi.WithST7789Inversion(bool):      set to false for displays without inverted colors (defaults to true)
+//      spi.WithST7789DCPin(string):        gpio pin number connected to dc pin on display (defaults to "16")
+//      spi.WithST7789RstPin(string):       gpio pin number connected to rst pin on display (defaults to "18")
+//
+func NewST7789Driver(a gobot.Adaptor, options ...func(Config)) *ST7789Driver {
+	// cast adaptor to spi connector since we also need the adaptor for gpio
+	b, ok := a.(Connector)
+	if !ok {
+		panic("unable to get gobot connector for st7789")
+	}
+	s := &ST7789Driver{
+		name:      gobot.DefaultName("ST7789"),
+		Commander: gobot.NewCommander(),
+		connector: b,
+		width:     st7789Width,
+		height:    st7789Height,
+		invert:    true,
+		DCPin:     st7789DcPin,
+		RSTPin:    st7789RstPin,
+		Config:    NewConfig(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.dcDriver = gpio.NewDirectPinDriver(a, s.DCPin)
+	s.rstDriver = gpio.NewDirectPinDriver(a, s.RSTPin)
+	s.buffer = image.NewRGBA(s.bounds())
+
+	s.AddCommand("Display", func(params map[string]interface{}) interface{} {
+		err := s.Display()
+		return map[string]interface{}{"err": err}
+	})
+	s.AddCommand("On", func(params map[string]interface{}) interface{} {
+		err := s.On()
+		return map[string]interface{}{"err": err}
+	})
+	s.AddCommand("Off", func(params map[string]interface{}) interface{} {
+		err := s.Off()
+		return map[string]interface{}{"err": err}
+	})
+	s.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		s.Clear(color.Black)
+		return nil
+	})
+	return s
+}
+
+// WithST7789Size option sets the width and height of the display in portrait orientation.
+func WithST7789Size(width int, height int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ST7789Driver)
+		if ok {
+			d.width, d.height = width, height
+		} else {
+			panic("unable to set size for st7789")
+		}
+	}
+}
+
+// WithST7789Rotation option sets the rotation of the display in degrees.
+func WithST7789Rotation(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ST7789Driver)
+		if ok {
+			d.rotation = val
+		} else {
+			panic("unable to set rotation for st7789")
+		}
+	}
+}
+
+// WithST7789Inversion option sets the color inversion, which is needed by
+// most of the ST7789 displays.
+func WithST7789Inversion(val bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ST7789Driver)
+		if ok {
+			d.invert = val
+		} else {
+			panic("unable to set inversion for st7789")
+		}
+	}
+}
+
+// WithST7789DCPin option sets the ST7789Driver DC pin option.
+func WithST7789DCPin(val string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ST7789Driver)
+		if ok {
+			d.DCPin = val
+		} else {
+			panic("unable to set dc pin for st7789")
+		}
+	}
+}
+
+// WithST7789RstPin option sets the ST7789Driver RST pin option.
+func WithST7789RstPin(val string) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*ST7789Driver)
+		if ok {
+			d.RSTPin = val
+		} else {
+			panic("unable to set rst pin for st7789")
+		}
+	}
+}
+
+// Name returns the Name for the Driver
+func (s *ST7789Driver) Name() string { return s.name }
+
+// SetName sets the Name for the Driver
+func (s *ST7789Driver) SetName(n string) { s.name = n }
+
+// Connection returns the connection for the Driver
+func (s *ST7789Driver) Connection() gobot.Connection { return s.connector.(gobot.Connection) }
+
+// Start sets up the needed connection, and initializes the device.
+func (s *ST7789Driver) Start() (err error) {
+	if err = s.checkRotation(s.rotation); err != nil {
+		return
+	}
+
+	bus := s.GetBusOrDefault(s.connector.GetSpiDefaultBus())
+	chip := s.GetChipOrDefault(s.connector.GetSpiDefaultChip())
+	mode := s.GetModeOrDefault(s.connector.GetSpiDefaultMode())
+	bits := s.GetBitsOrDefault(s.connector.GetSpiDefaultBits())
+	maxSpeed := s.GetSpeedOrDefault(s.connector.GetSpiDefaultMaxSpeed())
+
+	s.connection, err = s.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err = s.reset(); err != nil {
+		return
+	}
+	if err = s.command(st7789SoftwareReset); err != nil {
+		return
+	}
+	time.Sleep(150 * time.Millisecond)
+	if err = s.command(st7789SleepOut); err != nil {
+		return
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err = s.command(st7789PixelFormat, st7789PixelFormat16Bit); err != nil {
+		return
+	}
+	if err = s.command(st7789MemoryAccess, s.memoryAccess()); err != nil {
+		return
+	}
+	inversion := byte(st7789InversionOff)
+	if s.invert {
+		inversion = st7789InversionOn
+	}
+	if err = s.command(inversion); err != nil {
+		return
+	}
+	if err = s.command(st7789NormalDisplayOn); err != nil {
+		return
+	}
+	return s.command(st7789DisplayOn)
+}
+
+// Halt turns the display off.
+func (s *ST7789Driver) Halt() (err error) {
+	if s.connection == nil {
+		return
+	}
+	return s.Off()
+}
+
+// On turns on the display.
+func (s *ST7789Driver) On() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.command(st7789DisplayOn)
+}
+
+// Off turns off the display.
+func (s *ST7789Driver) Off() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.command(st7789DisplayOff)
+}
+
+// Bounds returns the size of the display in the current rotation.
+func (s *ST7789Driver) Bounds() image.Rectangle {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.buffer.Bounds()
+}
+
+// Buffer returns the framebuffer, which can be drawn on, e.g. with the image/draw
+// package or DrawLine, DrawRect and FillRect. Call Display or DisplayRect to
+// show the changes. The framebuffer is replaced by SetRotation.
+func (s *ST7789Driver) Buffer() *image.RGBA {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.buffer
+}
+
+// SetRotation sets the rotation of 0, 90, 180 or 270 degrees and clears the framebuffer.
+func (s *ST7789Driver) SetRotation(rotation int) (err error) {
+	if err = s.checkRotation(rotation); err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.rotation = rotation
+	s.buffer = image.NewRGBA(s.bounds())
+	if s.connection == nil {
+		return
+	}
+	return s.command(st7789MemoryAccess, s.memoryAccess())
+}
+
+// Clear fills the framebuffer with the color.
+func (s *ST7789Driver) Clear(c color.Color) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	FillRect(s.buffer, s.buffer.Bounds(), c)
+}
+
+// DrawImage draws the image into the framebuffer with its upper left corner at the point.
+func (s *ST7789Driver) DrawImage(at image.Point, img image.Image) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	r := image.Rectangle{Min: at, Max: at.Add(img.Bounds().Size())}
+	draw.Draw(s.buffer, r, img, img.Bounds().Min, draw.Src)
+}
+
+// ShowImage draws the image into the framebuffer and sends it to the display.
+func (s *ST7789Driver) ShowImage(img image.Image) (err error) {
+	s.DrawImage(image.ZP, img)
+	return s.Display()
+}
+
+// Display sends the framebuffer to the display.
+func (s *ST7789Driver) Display() (err error) {
+	return s.DisplayRect(s.Bounds())
+}
+
+// DisplayRect sends the part of the framebuffer within the rectangle to the
+// display, which is faster for small changes.
+func (s *ST7789Driver) DisplayRect(r image.Rectangle) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r = r.Canon().Intersect(s.buffer.Bounds())
+	if r.Empty() {
+		return
+	}
+
+	x0, y0 := r.Min.X, r.Min.Y
+	x1, y1 := r.Max.X-1, r.Max.Y-1
+	// the 240x240 displays use a part of the frame memory, which is
+	// shifted when the order of rows or columns is reversed
+	switch s.rotation {
+	case 180:
+		y0, y1 = y0+st7789MemoryHeight-s.height, y1+st7789MemoryHeight-s.height
+	case 270:
+		x0, x1 = x0+st7789MemoryHeight-s.height, x1+st7789MemoryHeight-s.height
+	}
+
+	if err = s.command(st7789ColumnAddr, byte(x0>>8), byte(x0), byte(x1>>8), byte(x1)); err != nil {
+		return
+	}
+	if err = s.command(st7789RowAddr, byte(y0>>8), byte(y0), byte(y1>>8), byte(y1)); err != nil {
+		return
+	}
+
+	return s.command(st7789MemoryWrite, rgb565(s.buffer, r)...)
+}
+
+func (s *ST7789Driver) checkRotation(rotation int) error {
+	switch rotation {
+	case 0, 90, 180, 270:
+		return nil
+	}
+	return fmt.Errorf("Rotation must be one of: 0, 90, 180, 270")
+}
+
+func (s *ST7789Driver) bounds() image.Rectangle {
+	if s.rotation == 90 || s.rotation == 270 {
+		return image.Rect(0, 0, s.height, s.width)
+	}
+	return image.Rect(0, 0, s.width, s.height)
+}
+
+func (s *ST7789Driver) memoryAccess() byte {
+	switch s.rotation {
+	case 90:
+		return st7789MADCTLMX | st7789MADCTLMV
+	case 180:
+		return st7789MADCTLMX | st7789MADCTLMY
+	case 270:
+		return st7789MADCTLMY | st7789MADCTLMV
+	}
+	return 0
+}
+
+func (s *ST7789Driver) reset() (err error) {
+	if err = s.rstDriver.DigitalWrite(0); err != nil {
+		return
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err = s.rstDriver.DigitalWrite(1); err != nil {
+		return
+	}
+	time.Sleep(120 * time.Millisecond)
+	return
+}
+
+// command sends the command followed by its parameters or data
+func (s *ST7789Driver) command(cmd byte, data ...byte) (err error) {
+	if err = s.dcDriver.DigitalWrite(0); err != nil {
+		return
+	}
+	if err = s.connection.Tx([]byte{cmd}, nil); err != nil {
+		return
+	}
+	if len(data) == 0 {
+		return
+	}
+	if err = s.dcDriver.DigitalWrite(1); err != nil {
+		return
+	}
+	return writeChunked(s.connection, data)
+}
@@ -0,0 +1,150 @@
+package spi
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*ST7789Driver)(nil)
+
+func initTestST7789Driver(options ...func(Config)) (*ST7789Driver, *displayTestAdaptor) {
+	a := newDisplayTestAdaptor()
+	return NewST7789Driver(a, options...), a
+}
+
+func TestST7789Driver(t *testing.T) {
+	d, a := initTestST7789Driver()
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "ST7789"), true)
+	gobottest.Assert(t, d.Connection(), gobot.Connection(a))
+	gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 240, 240))
+	gobottest.Assert(t, d.DCPin, "16")
+	gobottest.Assert(t, d.RSTPin, "18")
+
+	d.SetName("tft")
+	gobottest.Assert(t, d.Name(), "tft")
+
+	d, _ = initTestST7789Driver(WithST7789Size(240, 320), WithST7789Rotation(90),
+		WithST7789Inversion(false), WithST7789DCPin("22"), WithST7789RstPin("23"))
+	gobottest.Assert(t, d.Bounds(), image.Rect(0, 0, 320, 240))
+	gobottest.Assert(t, d.invert, false)
+	gobottest.Assert(t, d.DCPin, "22")
+	gobottest.Assert(t, d.RSTPin, "23")
+}
+
+func TestST7789DriverStart(t *testing.T) {
+	d, a := initTestST7789Driver(WithST7789Rotation(180))
+	gobottest.Assert(t, d.Start(), nil)
+
+	var cmds []byte
+	for _, c := range a.commands {
+		cmds = append(cmds, c.cmd)
+	}
+	gobottest.Assert(t, cmds, []byte{st7789SoftwareReset, st7789SleepOut, st7789PixelFormat,
+		st7789MemoryAccess, st7789InversionOn, st7789NormalDisplayOn, st7789DisplayOn})
+	data, _ := a.command(st7789PixelFormat)
+	gobottest.Assert(t, data, []byte{0x55})
+	data, _ = a.command(st7789MemoryAccess)
+	gobottest.Assert(t, data, []byte{0xC0})
+
+	a.reset()
+	gobottest.Assert(t, d.Halt(), nil)
+	_, ok := a.command(st7789DisplayOff)
+	gobottest.Assert(t, ok, true)
+
+	d, _ = initTestST7789Driver(WithST7789Rotation(45))
+	gobottest.Assert(t, d.Start(), errors.New("Rotation must be one of: 0, 90, 180, 270"))
+
+	d, a = initTestST7789Driver()
+	a.err = errors.New("tx error")
+	gobottest.Assert(t, d.Start(), errors.New("tx error"))
+}
+
+func TestST7789DriverDisplay(t *testing.T) {
+	d, a := initTestST7789Driver()
+	d.Start()
+	a.reset()
+
+	d.Clear(color.RGBA{R: 0xFF, G: 0x00, B: 0xFF, A: 0xFF})
+	gobottest.Assert(t, d.Display(), nil)
+	data, _ := a.command(st7789ColumnAddr)
+	gobottest.Assert(t, data, []byte{0, 0, 0, 239})
+	data, _ = a.command(st7789RowAddr)
+	gobottest.Assert(t, data, []byte{0, 0, 0, 239})
+	data, _ = a.command(st7789MemoryWrite)
+	gobottest.Assert(t, len(data), 240*240*2)
+	gobottest.Assert(t, data[:2], []byte{0xF8, 0x1F})
+	// the pixels are sent in chunks
+	gobottest.Assert(t, a.maxTx, maxTxSize)
+
+	a.reset()
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(1, 0, color.RGBA{G: 0xFF, A: 0xFF})
+	d.DrawImage(image.Pt(10, 20), img)
+	gobottest.Assert(t, d.DisplayRect(image.Rect(10, 20, 12, 21)), nil)
+	data, _ = a.command(st7789ColumnAddr)
+	gobottest.Assert(t, data, []byte{0, 10, 0, 11})
+	data, _ = a.command(st7789RowAddr)
+	gobottest.Assert(t, data, []byte{0, 20, 0, 20})
+	data, _ = a.command(st7789MemoryWrite)
+	gobottest.Assert(t, data, []byte{0x00, 0x00, 0x07, 0xE0})
+
+	// outside of the display
+	a.reset()
+	gobottest.Assert(t, d.DisplayRect(image.Rect(300, 300, 310, 310)), nil)
+	gobottest.Assert(t, len(a.commands), 0)
+
+	gobottest.Assert(t, d.ShowImage(img), nil)
+	data, _ = a.command(st7789MemoryWrite)
+	gobottest.Assert(t, len(data), 240*240*2)
+	gobottest.Assert(t, data[:4], []byte{0x00, 0x00, 0x07, 0xE0})
+
+	gobottest.Assert(t, d.Command("Display")(nil), map[string]interface{}{"err": nil})
+	d.Command("Clear")(nil)
+	gobottest.Assert(t, d.Buffer().RGBAAt(1, 0), color.RGBA{A: 0xFF})
+}
+
+func TestST7789DriverRotation(t *testing.T) {
+	d, a := initTestST7789Driver()
+	d.Start()
+	a.reset()
+
+	gobottest.Assert(t, d.SetRotation(270), nil)
+	data, _ := a.command(st7789MemoryAccess)
+	gobottest.Assert(t, data, []byte{0xA0})
+
+	gobottest.Assert(t, d.DisplayRect(image.Rect(0, 0, 10, 10)), nil)
+	data, _ = a.command(st7789ColumnAddr)
+	gobottest.Assert(t, data, []byte{0, 80, 0, 89})
+
+	gobottest.Assert(t, d.SetRotation(90), nil)
+	data, _ = a.command(st7789MemoryAccess)
+	gobottest.Assert(t, data, []byte{0x60})
+	gobottest.Refute(t, d.SetRotation(30), nil)
+}
+
+func TestDraw(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 5, 5))
+	white := color.Gray{Y: 0xFF}
+
+	DrawLine(img, image.Pt(0, 0), image.Pt(4, 2), white)
+	for _, p := range []image.Point{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}} {
+		gobottest.Assert(t, img.GrayAt(p.X, p.Y), white)
+	}
+	gobottest.Assert(t, img.GrayAt(1, 1), color.Gray{})
+
+	img = image.NewGray(image.Rect(0, 0, 5, 5))
+	DrawRect(img, image.Rect(1, 1, 4, 4), white)
+	gobottest.Assert(t, img.GrayAt(1, 1), white)
+	gobottest.Assert(t, img.GrayAt(3, 3), white)
+	gobottest.Assert(t, img.GrayAt(2, 2), color.Gray{})
+
+	FillRect(img, image.Rect(1, 1, 4, 4), white)
+	gobottest.Assert(t, img.GrayAt(2, 2), white)
+	gobottest.Assert(t, img.GrayAt(4, 4), color.Gray{})
+}
@@ -0,0 +1,286 @@
+package spi
+
+import (
+	"fmt"
+	"image/color"
+	"sync"
+
+	"gobot.io/x/gobot"
+)
+
+const (
+	// the WS2812 reset time is at least 50µs, but newer versions
+	// like the WS2812B-V5 need 280µs
+	ws2812ResetMicroseconds = 300
+)
+
+// WS2812Writer is implemented by adaptors with a dedicated output for WS2812
+// LEDs, e.g. by PWM and DMA, which is more reliable than SPI on some boards.
+type WS2812Writer interface {
+	// WriteWS2812 sends the color bytes of a frame in the order of the wire.
+	WriteWS2812(data []byte) error
+}
+
+// WS2812WriterProvider is implemented by adaptors, which have a dedicated
+// output for WS2812 LEDs only when it is configured, e.g. by an option.
+type WS2812WriterProvider interface {
+	// WS2812Writer returns the output for WS2812 LEDs or nil, if none is
+	// configured.
+	WS2812Writer() WS2812Writer
+}
+
+// WS2812Driver is a driver for the WS2812 (NeoPixel) and SK6812 RGB and RGBW
+// LEDs. The 800kHz one wire protocol is encoded into a SPI bitstream, where
+// each bit of the protocol is sent as 3 bits at 2.4MHz or 4 bits at 3.2MHz,
+// so only the MOSI pin is used. If the adaptor is a WS2812Writer or provides
+// one, the colors are sent to its output instead and the SPI options are not
+// used.
+//
+// The whole frame is sent in one transfer, because a pause would latch the
+// LEDs. For long strips the buffer size of the spidev kernel module might
+// need to be increased, e.g. with "spidev.bufsiz=65536".
+type WS2812Driver struct {
+	name       string
+	connector  Connector
+	connection Connection
+	writer     WS2812Writer
+	Config
+	gobot.Commander
+
+	vals       [][4]uint8
+	rgbw       bool
+	encoding   int
+	brightness uint8
+	mutex      sync.Mutex
+}
+
+// NewWS2812Driver creates a new Gobot Driver for WS2812 or SK6812 LEDs.
+//
+// Params:
+//      a *Adaptor - the Adaptor to use with this Driver.
+//      count int - how many LEDs are in the strip controlled by this driver.
+//
+// Optional params:
+//      spi.WithBus(int):                   bus to use with this driver
+//      spi.WithChip(int):                  chip to use with this driver
+//      spi.WithMode(int):                  mode to use with this driver
+//      spi.WithBits(int):                  number of bits to use with this driver
+//      spi.WithSpeed(int64):               speed in Hz to use with this driver (defaults to the speed of the encoding)
+//      spi.WithWS2812RGBW(bool):           set to true for SK6812 LEDs with a white channel (defaults to false)
+//      spi.WithWS2812Encoding(int):        3 or 4 SPI bits per LED bit, for 2.4MHz or 3.2MHz (defaults to 3)
+//      spi.WithWS2812Brightness(uint8):    brightness to scale all colors with (defaults to 255)
+//
+func NewWS2812Driver(a Connector, count int, options ...func(Config)) *WS2812Driver {
+	d := &WS2812Driver{
+		name:       gobot.DefaultName("WS2812"),
+		connector:  a,
+		Commander:  gobot.NewCommander(),
+		vals:       make([][4]uint8, count),
+		encoding:   3,
+		brightness: 255,
+		Config:     NewConfig(),
+	}
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddCommand("Draw", func(params map[string]interface{}) interface{} {
+		err := d.Draw()
+		return map[string]interface{}{"err": err}
+	})
+	d.AddCommand("Clear", func(params map[string]interface{}) interface{} {
+		d.Clear()
+		err := d.Draw()
+		return map[string]interface{}{"err": err}
+	})
+	return d
+}
+
+// WithWS2812RGBW option sets the driver for LEDs with a white channel like the SK6812 RGBW.
+func WithWS2812RGBW(val bool) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*WS2812Driver)
+		if ok {
+			d.rgbw = val
+		} else {
+			panic("unable to set rgbw for ws2812")
+		}
+	}
+}
+
+// WithWS2812Encoding option sets the number of SPI bits per LED bit, which
+// can be 3 for 2.4MHz or 4 for 3.2MHz.
+func WithWS2812Encoding(val int) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*WS2812Driver)
+		if ok {
+			d.encoding = val
+		} else {
+			panic("unable to set encoding for ws2812")
+		}
+	}
+}
+
+// WithWS2812Brightness option sets the brightness to scale all colors with.
+func WithWS2812Brightness(val uint8) func(Config) {
+	return func(c Config) {
+		d, ok := c.(*WS2812Driver)
+		if ok {
+			d.brightness = val
+		} else {
+			panic("unable to set brightness for ws2812")
+		}
+	}
+}
+
+// Name returns the name of the device.
+func (d *WS2812Driver) Name() string { return d.name }
+
+// SetName sets the name of the device.
+func (d *WS2812Driver) SetName(n string) { d.name = n }
+
+// Connection returns the Connection of the device.
+func (d *WS2812Driver) Connection() gobot.Connection { return d.connector.(gobot.Connection) }
+
+// Start initializes the driver.
+func (d *WS2812Driver) Start() (err error) {
+	if d.encoding != 3 && d.encoding != 4 {
+		return fmt.Errorf("Encoding must be one of: 3, 4")
+	}
+
+	if w, ok := d.connector.(WS2812Writer); ok {
+		d.writer = w
+		return
+	}
+	if p, ok := d.connector.(WS2812WriterProvider); ok {
+		if w := p.WS2812Writer(); w != nil {
+			d.writer = w
+			return
+		}
+	}
+
+	bus := d.GetBusOrDefault(d.connector.GetSpiDefaultBus())
+	chip := d.GetChipOrDefault(d.connector.GetSpiDefaultChip())
+	mode := d.GetModeOrDefault(0)
+	bits := d.GetBitsOrDefault(8)
+	maxSpeed := d.GetSpeedOrDefault(d.speed())
+
+	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
+	return
+}
+
+// Halt stops the driver.
+func (d *WS2812Driver) Halt() (err error) {
+	return
+}
+
+// Count returns the number of LEDs.
+func (d *WS2812Driver) Count() int {
+	return len(d.vals)
+}
+
+// SetRGBA sets the ith LED's color to the given RGB value, the alpha value
+// is ignored. The white channel of RGBW LEDs is switched off. A subsequent
+// call to Draw is required to transmit values to the LED strip.
+func (d *WS2812Driver) SetRGBA(i int, v color.RGBA) {
+	d.SetRGBW(i, v.R, v.G, v.B, 0)
+}
+
+// SetRGBW sets the ith LED's color to the given RGB and white value, the
+// white value is ignored for RGB LEDs. A subsequent call to Draw is required
+// to transmit values to the LED strip.
+func (d *WS2812Driver) SetRGBW(i int, r, g, b, w uint8) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.vals[i] = [4]uint8{r, g, b, w}
+}
+
+// Fill sets all LEDs to the given RGB value.
+func (d *WS2812Driver) Fill(v color.RGBA) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for i := range d.vals {
+		d.vals[i] = [4]uint8{v.R, v.G, v.B, 0}
+	}
+}
+
+// Clear switches off all LEDs in the frame buffer.
+func (d *WS2812Driver) Clear() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for i := range d.vals {
+		d.vals[i] = [4]uint8{}
+	}
+}
+
+// SetBrightness sets the brightness to scale all colors with.
+func (d *WS2812Driver) SetBrightness(val uint8) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.brightness = val
+}
+
+// Brightness returns the brightness to scale all colors with.
+func (d *WS2812Driver) Brightness() uint8 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.brightness
+}
+
+// Draw displays the values set on the actual LED strip.
+func (d *WS2812Driver) Draw() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	colors := 3
+	if d.rgbw {
+		colors = 4
+	}
+	data := make([]byte, 0, len(d.vals)*colors)
+	for _, v := range d.vals {
+		// the LEDs expect the order green, red, blue (, white)
+		for _, c := range []uint8{v[1], v[0], v[2], v[3]}[:colors] {
+			data = append(data, uint8(uint16(c)*uint16(d.brightness)/255))
+		}
+	}
+	if d.writer != nil {
+		return d.writer.WriteWS2812(data)
+	}
+	return d.connection.Tx(d.encode(data), nil)
+}
+
+// speed returns the SPI speed for the encoding
+func (d *WS2812Driver) speed() int64 {
+	return int64(d.encoding) * 800000
+}
+
+// encode returns the SPI bitstream for the data, which is framed by a low
+// byte to settle the line and the low reset time to latch the LEDs
+func (d *WS2812Driver) encode(data []byte) []byte {
+	// a zero is sent as 100 or 1000, a one as 110 or 1110
+	zero, one := uint32(0x4), uint32(0x6)
+	if d.encoding == 4 {
+		zero, one = 0x8, 0xE
+	}
+	resetBytes := int(d.speed()*ws2812ResetMicroseconds/1000000+7) / 8
+
+	tx := make([]byte, 1, 1+len(data)*d.encoding+resetBytes)
+	var acc uint32
+	var n int
+	for _, b := range data {
+		for bit := 7; bit >= 0; bit-- {
+			acc <<= uint(d.encoding)
+			if b&(1<<uint(bit)) != 0 {
+				acc |= one
+			} else {
+				acc |= zero
+			}
+			n += d.encoding
+			for n >= 8 {
+				n -= 8
+				tx = append(tx, byte(acc>>uint(n)))
+			}
+		}
+	}
+	return append(tx, make([]byte, resetBytes)...)
+}
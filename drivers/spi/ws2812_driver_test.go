@@ -0,0 +1,143 @@
+package spi
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ gobot.Driver = (*WS2812Driver)(nil)
+
+func initTestWS2812Driver(count int, options ...func(Config)) (*WS2812Driver, *spiTestRecorder) {
+	rec := &spiTestRecorder{}
+	d := NewWS2812Driver(&spiTestConnector{conn: rec}, count, options...)
+	d.Start()
+	return d, rec
+}
+
+func TestWS2812Driver(t *testing.T) {
+	d, _ := initTestWS2812Driver(8)
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "WS2812"), true)
+	gobottest.Assert(t, d.Count(), 8)
+	gobottest.Assert(t, d.Brightness(), uint8(255))
+	gobottest.Assert(t, d.speed(), int64(2400000))
+
+	d.SetName("strip")
+	gobottest.Assert(t, d.Name(), "strip")
+
+	d, _ = initTestWS2812Driver(8, WithWS2812RGBW(true), WithWS2812Encoding(4), WithWS2812Brightness(64))
+	gobottest.Assert(t, d.rgbw, true)
+	gobottest.Assert(t, d.speed(), int64(3200000))
+	gobottest.Assert(t, d.Brightness(), uint8(64))
+	gobottest.Assert(t, d.Halt(), nil)
+}
+
+func TestWS2812DriverStart(t *testing.T) {
+	d := NewWS2812Driver(&spiTestConnector{err: errors.New("no spi")}, 1)
+	gobottest.Assert(t, d.Start(), errors.New("no spi"))
+
+	d = NewWS2812Driver(&spiTestConnector{}, 1, WithWS2812Encoding(5))
+	gobottest.Assert(t, d.Start(), errors.New("Encoding must be one of: 3, 4"))
+}
+
+func TestWS2812DriverEncoding(t *testing.T) {
+	d, rec := initTestWS2812Driver(1)
+	d.SetRGBA(0, color.RGBA{R: 0x00, G: 0x80, B: 0xFF})
+	gobottest.Assert(t, d.Draw(), nil)
+	data := rec.written()
+	// a leading low byte, green, red, blue and 90 bytes for 300µs reset
+	gobottest.Assert(t, len(data), 1+3*3+90)
+	gobottest.Assert(t, data[:10], []byte{0x00,
+		0xD2, 0x49, 0x24,
+		0x92, 0x49, 0x24,
+		0xDB, 0x6D, 0xB6})
+	gobottest.Assert(t, data[10:], make([]byte, 90))
+
+	d, rec = initTestWS2812Driver(1, WithWS2812Encoding(4), WithWS2812RGBW(true))
+	d.SetRGBW(0, 0x00, 0xF0, 0x01, 0xFF)
+	gobottest.Assert(t, d.Draw(), nil)
+	data = rec.written()
+	gobottest.Assert(t, len(data), 1+4*4+120)
+	gobottest.Assert(t, data[:17], []byte{0x00,
+		0xEE, 0xEE, 0x88, 0x88,
+		0x88, 0x88, 0x88, 0x88,
+		0x88, 0x88, 0x88, 0x8E,
+		0xEE, 0xEE, 0xEE, 0xEE})
+}
+
+func TestWS2812DriverFrame(t *testing.T) {
+	d, rec := initTestWS2812Driver(3, WithWS2812Brightness(128))
+	d.Fill(color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF})
+	gobottest.Assert(t, d.Draw(), nil)
+	// 0xFF scaled to 0x80
+	gobottest.Assert(t, rec.written()[1:4], []byte{0xD2, 0x49, 0x24})
+
+	d.SetBrightness(255)
+	d.Clear()
+	d.SetRGBA(2, color.RGBA{R: 0xFF})
+	gobottest.Assert(t, d.Draw(), nil)
+	// the frame is sent in one transfer
+	gobottest.Assert(t, rec.txs, 1)
+	data := rec.written()
+	gobottest.Assert(t, data[1:19], []byte{
+		0x92, 0x49, 0x24, 0x92, 0x49, 0x24, 0x92, 0x49, 0x24,
+		0x92, 0x49, 0x24, 0x92, 0x49, 0x24, 0x92, 0x49, 0x24})
+	gobottest.Assert(t, data[19:25], []byte{0x92, 0x49, 0x24, 0xDB, 0x6D, 0xB6})
+
+	gobottest.Assert(t, d.Command("Clear")(nil), map[string]interface{}{"err": nil})
+	gobottest.Assert(t, rec.written()[1:4], []byte{0x92, 0x49, 0x24})
+	gobottest.Assert(t, d.Command("Draw")(nil), map[string]interface{}{"err": nil})
+}
+
+// ws2812TestWriter is an adaptor with a dedicated WS2812 output
+type ws2812TestWriter struct {
+	spiTestConnector
+	data []byte
+}
+
+func (w *ws2812TestWriter) WriteWS2812(data []byte) error {
+	w.data = data
+	return nil
+}
+
+func TestWS2812DriverWriter(t *testing.T) {
+	a := &ws2812TestWriter{spiTestConnector: spiTestConnector{err: errors.New("no spi")}}
+	d := NewWS2812Driver(a, 2, WithWS2812RGBW(true))
+	gobottest.Assert(t, d.Start(), nil)
+
+	d.SetRGBW(1, 0x01, 0x02, 0x03, 0x04)
+	gobottest.Assert(t, d.Draw(), nil)
+	// the colors are not encoded, but still in the order of the wire
+	gobottest.Assert(t, a.data, []byte{0, 0, 0, 0, 0x02, 0x01, 0x03, 0x04})
+}
+
+// ws2812TestProvider is an adaptor with an optional WS2812 output
+type ws2812TestProvider struct {
+	spiTestConnector
+	writer WS2812Writer
+}
+
+func (p *ws2812TestProvider) WS2812Writer() WS2812Writer { return p.writer }
+
+func TestWS2812DriverWriterProvider(t *testing.T) {
+	// without a configured output the frames are sent by SPI
+	rec := &spiTestRecorder{}
+	a := &ws2812TestProvider{spiTestConnector: spiTestConnector{conn: rec}}
+	d := NewWS2812Driver(a, 1)
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, rec.txs, 1)
+
+	w := &ws2812TestWriter{}
+	a = &ws2812TestProvider{spiTestConnector: spiTestConnector{err: errors.New("no spi")}, writer: w}
+	d = NewWS2812Driver(a, 1)
+	gobottest.Assert(t, d.Start(), nil)
+
+	d.SetRGBA(0, color.RGBA{R: 0x01, G: 0x02, B: 0x03})
+	gobottest.Assert(t, d.Draw(), nil)
+	gobottest.Assert(t, w.data, []byte{0x02, 0x01, 0x03})
+}
@@ -1,9 +1,26 @@
 package gobot
 
-import "sync"
+import (
+	"path"
+	"reflect"
+	"sync"
+)
 
 type eventChannel chan *Event
 
+// EventFilter is a predicate evaluated for each event before it is delivered
+// to a subscriber, only events for which all filters return true are delivered.
+type EventFilter func(evt *Event) bool
+
+// MatchEvent returns an EventFilter accepting the events with a name matching
+// the pattern, e.g. "button_*". The pattern syntax is the one of path.Match.
+func MatchEvent(pattern string) EventFilter {
+	return func(evt *Event) bool {
+		matched, err := path.Match(pattern, evt.Name)
+		return err == nil && matched
+	}
+}
+
 type eventer struct {
 	// map of valid Event names
 	eventnames map[string]string
@@ -11,8 +28,8 @@ type eventer struct {
 	// new events get put in to the event channel
 	in eventChannel
 
-	// map of out channels used by subscribers
-	outs map[eventChannel]eventChannel
+	// map of out channels used by subscribers, with their filters
+	outs map[eventChannel][]EventFilter
 
 	// mutex to protect the eventChannel map
 	eventsMutex sync.Mutex
@@ -39,8 +56,8 @@ type Eventer interface {
 	// Publish new events to any subscriber
 	Publish(name string, data interface{})
 
-	// Subscribe to events
-	Subscribe() (events eventChannel)
+	// Subscribe to events, optionally only to the ones accepted by all filters
+	Subscribe(filters ...EventFilter) (events eventChannel)
 
 	// Unsubscribe from an event channel
 	Unsubscribe(events eventChannel)
@@ -52,12 +69,58 @@ type Eventer interface {
 	Once(name string, f func(s interface{})) (err error)
 }
 
+// QueueDepthReporter is the interface that describes an Eventer which reports
+// the number of its queued events, like the one returned by NewEventer
+type QueueDepthReporter interface {
+	QueueDepth() int
+}
+
+// EventQueueDepth returns the number of queued events of v, which is a
+// QueueDepthReporter or embeds one as its Eventer, like most drivers, the
+// robots and the master do. Returns false if the depth is not available.
+func EventQueueDepth(v interface{}) (depth int, ok bool) {
+	if q, ok := v.(QueueDepthReporter); ok {
+		return q.QueueDepth(), true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return 0, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return 0, false
+	}
+	field, found := rv.Type().FieldByName("Eventer")
+	if !found {
+		return 0, false
+	}
+	// walk the embedded fields, which might be nil pointers
+	for _, i := range field.Index {
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return 0, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(i)
+	}
+	if !rv.CanInterface() {
+		return 0, false
+	}
+	if q, ok := rv.Interface().(QueueDepthReporter); ok {
+		return q.QueueDepth(), true
+	}
+	return 0, false
+}
+
 // NewEventer returns a new Eventer.
 func NewEventer() Eventer {
 	evtr := &eventer{
 		eventnames: make(map[string]string),
 		in:         make(eventChannel, eventChanBufferSize),
-		outs:       make(map[eventChannel]eventChannel),
+		outs:       make(map[eventChannel][]EventFilter),
 	}
 
 	// goroutine to cascade "in" events to all "out" event channels
@@ -65,11 +128,20 @@ func NewEventer() Eventer {
 		for {
 			select {
 			case evt := <-evtr.in:
+				// the filters are evaluated without the lock, so a slow filter
+				// doesn't block Subscribe and Unsubscribe
 				evtr.eventsMutex.Lock()
-				for _, out := range evtr.outs {
-					out <- evt
+				outs := make(map[eventChannel][]EventFilter, len(evtr.outs))
+				for out, filters := range evtr.outs {
+					outs[out] = filters
 				}
 				evtr.eventsMutex.Unlock()
+
+				for out, filters := range outs {
+					if accepts(filters, evt) {
+						out <- evt
+					}
+				}
 			}
 		}
 	}()
@@ -104,12 +176,14 @@ func (e *eventer) Publish(name string, data interface{}) {
 	e.in <- evt
 }
 
-// Subscribe to any events from this eventer
-func (e *eventer) Subscribe() eventChannel {
+// Subscribe to any events from this eventer, which are accepted by all
+// filters. The filters are evaluated before delivery, so subscribers of
+// chatty drivers only receive the events they are interested in.
+func (e *eventer) Subscribe(filters ...EventFilter) eventChannel {
 	e.eventsMutex.Lock()
 	defer e.eventsMutex.Unlock()
 	out := make(eventChannel, eventChanBufferSize)
-	e.outs[out] = out
+	e.outs[out] = filters
 	return out
 }
 
@@ -120,6 +194,19 @@ func (e *eventer) Unsubscribe(events eventChannel) {
 	delete(e.outs, events)
 }
 
+// QueueDepth returns the number of events, which are published but not yet
+// delivered, or delivered but not yet received by a subscriber. A growing
+// depth indicates a subscriber which can't keep up with the publisher.
+func (e *eventer) QueueDepth() int {
+	e.eventsMutex.Lock()
+	defer e.eventsMutex.Unlock()
+	depth := len(e.in)
+	for out := range e.outs {
+		depth += len(out)
+	}
+	return depth
+}
+
 // On executes the event handler f when e is Published to.
 func (e *eventer) On(n string, f func(s interface{})) (err error) {
 	out := e.Subscribe()
@@ -153,3 +240,12 @@ func (e *eventer) Once(n string, f func(s interface{})) (err error) {
 
 	return
 }
+
+func accepts(filters []EventFilter, evt *Event) bool {
+	for _, filter := range filters {
+		if !filter(evt) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package gobot
+
+import "testing"
+
+func BenchmarkEventerPublish(b *testing.B) {
+	e := NewEventer()
+	e.AddEvent("test")
+	done := make(chan bool)
+	count := 0
+	e.On("test", func(data interface{}) {
+		count++
+		if count == b.N {
+			done <- true
+		}
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.Publish("test", i)
+	}
+	<-done
+}
+
+func BenchmarkEventerPublishNoSubscriber(b *testing.B) {
+	e := NewEventer()
+	e.AddEvent("test")
+
+	for i := 0; i < b.N; i++ {
+		e.Publish("test", i)
+	}
+}
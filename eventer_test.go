@@ -76,3 +76,102 @@ func TestEventerOnce(t *testing.T) {
 	case <-time.After(10 * time.Millisecond):
 	}
 }
+
+func TestEventerSubscribeFiltered(t *testing.T) {
+	e := NewEventer()
+	events := e.Subscribe(MatchEvent("button_*"), func(evt *Event) bool {
+		return evt.Data.(int) > 1
+	})
+	defer e.Unsubscribe(events)
+
+	e.Publish("button_push", 1)
+	e.Publish("encoder", 2)
+	e.Publish("button_release", 2)
+
+	select {
+	case evt := <-events:
+		gobottest.Assert(t, evt.Name, "button_release")
+		gobottest.Assert(t, evt.Data, 2)
+	case <-time.After(10 * time.Millisecond):
+		t.Errorf("Filtered event was not delivered")
+	}
+
+	select {
+	case evt := <-events:
+		t.Errorf("Unexpected event %v", evt.Name)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestEventerFilterWithoutLock(t *testing.T) {
+	e := NewEventer()
+	// a filter may use the eventer, e.g. to subscribe another channel
+	var other eventChannel
+	events := e.Subscribe(func(evt *Event) bool {
+		if other == nil {
+			other = e.Subscribe()
+		}
+		return true
+	})
+	defer e.Unsubscribe(events)
+
+	e.Publish("test", 1)
+	select {
+	case evt := <-events:
+		gobottest.Assert(t, evt.Data, 1)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("Event was not delivered")
+	}
+	e.Unsubscribe(other)
+}
+
+func TestEventerQueueDepth(t *testing.T) {
+	e := NewEventer().(QueueDepthReporter)
+	gobottest.Assert(t, e.QueueDepth(), 0)
+
+	events := e.(Eventer).Subscribe()
+	defer e.(Eventer).Unsubscribe(events)
+	e.(Eventer).Publish("test", 1)
+	e.(Eventer).Publish("test", 2)
+	time.Sleep(10 * time.Millisecond)
+	gobottest.Assert(t, e.QueueDepth(), 2)
+
+	<-events
+	<-events
+	gobottest.Assert(t, e.QueueDepth(), 0)
+}
+
+func TestEventQueueDepth(t *testing.T) {
+	type eventerDriver struct {
+		*testDriver
+		Eventer
+	}
+	type nestedDriver struct {
+		*eventerDriver
+	}
+	d := &eventerDriver{Eventer: NewEventer()}
+	events := d.Subscribe()
+	defer d.Unsubscribe(events)
+	d.Publish("test", 1)
+	time.Sleep(10 * time.Millisecond)
+	depth, ok := EventQueueDepth(d)
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, depth, 1)
+
+	depth, ok = EventQueueDepth(&nestedDriver{eventerDriver: d})
+	gobottest.Assert(t, ok, true)
+	gobottest.Assert(t, depth, 1)
+
+	_, ok = EventQueueDepth(&nestedDriver{})
+	gobottest.Assert(t, ok, false)
+	_, ok = EventQueueDepth(newTestDriver(newTestAdaptor("a", "/dev/null"), "d", "1"))
+	gobottest.Assert(t, ok, false)
+	_, ok = EventQueueDepth(nil)
+	gobottest.Assert(t, ok, false)
+}
+
+func TestMatchEvent(t *testing.T) {
+	gobottest.Assert(t, MatchEvent("button_*")(NewEvent("button_push", nil)), true)
+	gobottest.Assert(t, MatchEvent("button_*")(NewEvent("push", nil)), false)
+	gobottest.Assert(t, MatchEvent("[")(NewEvent("[", nil)), false)
+}
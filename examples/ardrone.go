@@ -17,7 +17,7 @@ func main() {
 
 	work := func() {
 		drone.On(ardrone.Flying, func(data interface{}) {
-			gobot.After(3*time.Second, func() {
+			gobot.AfterTimer(3*time.Second, func() {
 				drone.Land()
 			})
 		})
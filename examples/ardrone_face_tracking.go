@@ -39,11 +39,11 @@ func main() {
 			}
 		})
 		drone.On(ardrone.Flying, func(data interface{}) {
-			gobot.After(1*time.Second, func() { drone.Up(0.2) })
-			gobot.After(2*time.Second, func() { drone.Hover() })
-			gobot.After(5*time.Second, func() {
+			gobot.AfterTimer(1*time.Second, func() { drone.Up(0.2) })
+			gobot.AfterTimer(2*time.Second, func() { drone.Hover() })
+			gobot.AfterTimer(5*time.Second, func() {
 				detect = true
-				gobot.Every(300*time.Millisecond, func() {
+				gobot.EveryTimer(300*time.Millisecond, func() {
 					drone.Hover()
 					i := img
 					faces := opencv.DetectObjects(cascade, i)
@@ -69,7 +69,7 @@ func main() {
 					window.IMShow(i)
 					window.WaitKey(1)
 				})
-				gobot.After(20*time.Second, func() { drone.Land() })
+				gobot.AfterTimer(20*time.Second, func() { drone.Land() })
 			})
 		})
 	}
@@ -67,7 +67,7 @@ func main() {
 			rightY.Store(val)
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			leftStick := getLeftStick()
 
 			switch {
@@ -89,7 +89,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			rightStick := getRightStick()
 
 			switch {
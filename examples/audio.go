@@ -16,7 +16,7 @@ func main() {
 	laser := audio.NewDriver(e, "./examples/laser.mp3")
 
 	work := func() {
-		gobot.Every(2*time.Second, func() {
+		gobot.EveryTimer(2*time.Second, func() {
 			laser.Play()
 		})
 	}
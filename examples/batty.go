@@ -25,7 +25,7 @@ func main() {
 	ping := NewPingDriver(loopback, "1")
 
 	work := func() {
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			fmt.Println(ping.Ping())
 		})
 	}
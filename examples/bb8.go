@@ -27,7 +27,7 @@ func main() {
 	bb8 := bb8.NewDriver(bleAdaptor)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			r := uint8(gobot.Rand(255))
 			g := uint8(gobot.Rand(255))
 			b := uint8(gobot.Rand(255))
@@ -17,7 +17,7 @@ func main() {
 	led := gpio.NewLedDriver(beagleboneAdaptor, "P9_12")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
@@ -18,7 +18,7 @@ func main() {
 	button := gpio.NewDirectPinDriver(beagleboneAdaptor, "P8_09")
 
 	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+		gobot.EveryTimer(500*time.Millisecond, func() {
 			val, _ := button.DigitalRead()
 			if val == 1 {
 				led.DigitalWrite(1)
@@ -20,7 +20,7 @@ func main() {
 		brightness := uint8(0)
 		fadeAmount := uint8(5)
 
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			led.Brightness(brightness)
 			brightness = brightness + fadeAmount
 			if brightness == 0 || brightness == 255 {
@@ -18,7 +18,7 @@ func main() {
 	servo := gpio.NewServoDriver(beagleboneAdaptor, "P9_14")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			i := uint8(gobot.Rand(180))
 			fmt.Println("Turning", i)
 			servo.Move(i)
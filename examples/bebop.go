@@ -17,7 +17,7 @@ func main() {
 
 	work := func() {
 		drone.On(bebop.Flying, func(data interface{}) {
-			gobot.After(10*time.Second, func() {
+			gobot.AfterTimer(10*time.Second, func() {
 				drone.Land()
 			})
 		})
@@ -145,7 +145,7 @@ func main() {
 			rightY.Store(val)
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			leftStick := getLeftStick()
 
 			switch {
@@ -167,7 +167,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			rightStick := getRightStick()
 			switch {
 			case rightStick.y < -10:
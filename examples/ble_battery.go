@@ -27,7 +27,7 @@ func main() {
 	battery := ble.NewBatteryDriver(bleAdaptor)
 
 	work := func() {
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			fmt.Println("Battery level:", battery.GetBatteryLevel())
 		})
 	}
@@ -42,11 +42,11 @@ func main() {
 			log.Println("Temperature", data)
 		})
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			imu.ReadAccelerometer()
 			imu.ReadGyroscope()
 			imu.ReadTemperature()
@@ -17,7 +17,7 @@ func main() {
 	led := gpio.NewLedDriver(chipAdaptor, "XIO-P6")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
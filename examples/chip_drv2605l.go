@@ -17,7 +17,7 @@ func main() {
 	haptic := i2c.NewDRV2605LDriver(board)
 
 	work := func() {
-		gobot.Every(3*time.Second, func() {
+		gobot.EveryTimer(3*time.Second, func() {
 			pause := haptic.GetPauseWaveform(50)
 			haptic.SetSequence([]byte{1, pause, 1, pause, 1})
 			haptic.Go()
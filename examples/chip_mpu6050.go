@@ -18,7 +18,7 @@ func main() {
 	mpu6050 := i2c.NewMPU6050Driver(board)
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			mpu6050.GetData()
 
 			fmt.Println("Accelerometer", mpu6050.Accelerometer)
@@ -18,7 +18,7 @@ func main() {
 	luxSensor := i2c.NewTSL2561Driver(board, i2c.WithTSL2561Gain16X)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			broadband, ir, err := luxSensor.GetLuminocity()
 
 			if err != nil {
@@ -18,7 +18,7 @@ func main() {
 	led := gpio.NewLedDriver(digisparkAdaptor, "0")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
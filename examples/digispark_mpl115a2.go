@@ -18,7 +18,7 @@ func main() {
 	mpl115a2 := i2c.NewMPL115A2Driver(board)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			press, _ := mpl115a2.Pressure()
 			fmt.Println("Pressure", press)
 
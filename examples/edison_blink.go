@@ -21,7 +21,7 @@ func main() {
 	// e.SetBoard("sparkfun")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
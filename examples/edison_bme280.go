@@ -18,7 +18,7 @@ func main() {
 	bme280 := i2c.NewBME280Driver(a, i2c.WithAddress(0x76))
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			t, e := bme280.Temperature()
 			fmt.Println("Temperature", t)
 			if e != nil {
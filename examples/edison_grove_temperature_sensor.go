@@ -18,7 +18,7 @@ func main() {
 	sensor := aio.NewGroveTemperatureSensorDriver(board, "0")
 
 	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+		gobot.EveryTimer(500*time.Millisecond, func() {
 			fmt.Println("current temp (c): ", sensor.Temperature())
 		})
 	}
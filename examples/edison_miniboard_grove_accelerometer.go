@@ -20,7 +20,7 @@ func main() {
 	accel := i2c.NewGroveAccelerometerDriver(board)
 
 	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+		gobot.EveryTimer(500*time.Millisecond, func() {
 			if x, y, z, err := accel.XYZ(); err == nil {
 				fmt.Println(x, y, z)
 				fmt.Println(accel.Acceleration(x, y, z))
@@ -15,11 +15,11 @@ func main() {
 	robot := gobot.NewRobot(
 		"hello",
 		func() {
-			done := gobot.Every(750*time.Millisecond, func() {
+			done := gobot.EveryTimer(750*time.Millisecond, func() {
 				fmt.Println("Greetings human")
 			})
 
-			gobot.After(5*time.Second, func() {
+			gobot.AfterTimer(5*time.Second, func() {
 				done.Stop()
 				fmt.Println("We're done here")
 			})
@@ -26,7 +26,7 @@ func main() {
 	adxl345 := i2c.NewADXL345Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			x, y, z, _ := adxl345.XYZ()
 
 			fmt.Printf("x: %.7f | y: %.7f | z: %.7f \n", x, y, z)
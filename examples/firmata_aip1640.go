@@ -35,7 +35,7 @@ func main() {
 	s := 0
 	work := func() {
 		aip1640.Clear()
-		gobot.Every(600*time.Millisecond, func() {
+		gobot.EveryTimer(600*time.Millisecond, func() {
 			aip1640.DrawMatrix(smiles[s])
 			aip1640.Display()
 			s++
@@ -26,7 +26,7 @@ func main() {
 	blinkm := i2c.NewBlinkMDriver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(3*time.Second, func() {
+		gobot.EveryTimer(3*time.Second, func() {
 			r := byte(gobot.Rand(255))
 			g := byte(gobot.Rand(255))
 			b := byte(gobot.Rand(255))
@@ -19,7 +19,7 @@ func main() {
 	bme280 := i2c.NewBME280Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			t, _ := bme280.Temperature()
 			fmt.Println("Temperature", t)
 
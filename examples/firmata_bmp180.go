@@ -19,7 +19,7 @@ func main() {
 	bmp180 := i2c.NewBMP180Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			t, _ := bmp180.Temperature()
 			fmt.Println("Temperature", t)
 
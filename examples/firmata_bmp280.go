@@ -19,7 +19,7 @@ func main() {
 	bmp280 := i2c.NewBMP280Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			t, _ := bmp280.Temperature()
 			fmt.Println("Temperature", t)
 
@@ -44,11 +44,11 @@ func main() {
 			log.Println("Motion", data)
 		})
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			imu.ReadAccelerometer()
 			imu.ReadGyroscope()
 			imu.ReadTemperature()
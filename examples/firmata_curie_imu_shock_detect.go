@@ -32,7 +32,7 @@ func main() {
 			log.Println("Shock", data)
 		})
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 
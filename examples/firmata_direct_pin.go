@@ -27,7 +27,7 @@ func main() {
 	work := func() {
 		level := byte(1)
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			pin.DigitalWrite(level)
 			if level == 1 {
 				level = 0
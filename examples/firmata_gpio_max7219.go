@@ -34,7 +34,7 @@ func main() {
 	count := 0
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			max.ClearAll()
 			max.One(module, digit, bits)
 			bits = bits << 1
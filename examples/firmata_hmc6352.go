@@ -26,7 +26,7 @@ func main() {
 	hmc6352 := i2c.NewHMC6352Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			heading, _ := hmc6352.Heading()
 			fmt.Println("Heading", heading)
 		})
@@ -30,10 +30,10 @@ func main() {
 	sensor := aio.NewAnalogSensorDriver(firmataAdaptor, "0")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led1.Toggle()
 		})
-		gobot.Every(2*time.Second, func() {
+		gobot.EveryTimer(2*time.Second, func() {
 			led2.Toggle()
 		})
 		button.On(gpio.ButtonPush, func(data interface{}) {
@@ -26,7 +26,7 @@ func main() {
 	lidar := i2c.NewLIDARLiteDriver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			distance, _ := lidar.Distance()
 			fmt.Println("Distance", distance)
 		})
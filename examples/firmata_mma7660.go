@@ -26,7 +26,7 @@ func main() {
 	mma7660 := i2c.NewMMA7660Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+		gobot.EveryTimer(500*time.Millisecond, func() {
 			if x, y, z, err := mma7660.XYZ(); err == nil {
 				fmt.Println(x, y, z)
 				fmt.Println(mma7660.Acceleration(x, y, z))
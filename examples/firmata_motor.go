@@ -28,7 +28,7 @@ func main() {
 		speed := byte(0)
 		fadeAmount := byte(15)
 
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			motor.Speed(speed)
 			speed = speed + fadeAmount
 			if speed == 0 || speed == 255 {
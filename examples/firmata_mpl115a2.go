@@ -26,7 +26,7 @@ func main() {
 	mpl115a2 := i2c.NewMPL115A2Driver(firmataAdaptor)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			press, _ := mpl115a2.Pressure()
 			fmt.Println("Pressure", press)
 
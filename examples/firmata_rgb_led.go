@@ -25,7 +25,7 @@ func main() {
 	led := gpio.NewRgbLedDriver(board, "3", "5", "6")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			r := uint8(gobot.Rand(255))
 			g := uint8(gobot.Rand(255))
 			b := uint8(gobot.Rand(255))
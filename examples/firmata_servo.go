@@ -26,7 +26,7 @@ func main() {
 	servo := gpio.NewServoDriver(firmataAdaptor, "3")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			i := uint8(gobot.Rand(180))
 			fmt.Println("Turning", i)
 			servo.Move(i)
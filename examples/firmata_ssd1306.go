@@ -23,7 +23,7 @@ func main() {
 
 	work := func() {
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			fmt.Println("displaying")
 			oled.Clear()
 			if stage {
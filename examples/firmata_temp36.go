@@ -25,7 +25,7 @@ func main() {
 	firmataAdaptor := firmata.NewAdaptor(os.Args[1])
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			val, err := firmataAdaptor.AnalogRead("0")
 			if err != nil {
 				fmt.Println(err)
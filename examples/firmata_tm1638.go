@@ -42,7 +42,7 @@ func main() {
 	}
 
 	work := func() {
-		gobot.Every(400*time.Millisecond, func() {
+		gobot.EveryTimer(400*time.Millisecond, func() {
 			// Enable and change the color of the LEDs
 			modules[0].SetLED(color, ledInt)
 			modules[1].SetLED(color, ledInt)
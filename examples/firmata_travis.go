@@ -87,7 +87,7 @@ func main() {
 
 	work := func() {
 		checkTravis(master.Robot("travis"))
-		gobot.Every(10*time.Second, func() {
+		gobot.EveryTimer(10*time.Second, func() {
 			checkTravis(master.Robot("travis"))
 		})
 	}
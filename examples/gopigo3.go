@@ -19,7 +19,7 @@ func main() {
 
 	work := func() {
 		on := uint8(0xFF)
-		gobot.Every(1000*time.Millisecond, func() {
+		gobot.EveryTimer(1000*time.Millisecond, func() {
 			err := gpg3.SetLED(gopigo3.LED_EYE_RIGHT, 0x00, 0x00, on)
 			if err != nil {
 				fmt.Println(err)
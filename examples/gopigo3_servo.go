@@ -20,7 +20,7 @@ func main() {
 	servo := gpio.NewServoDriver(gpg3, "SERVO_1")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			i := uint8(gobot.Rand(180))
 			fmt.Println("Turning", i)
 			servo.Move(i)
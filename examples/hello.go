@@ -14,7 +14,7 @@ import (
 func main() {
 	robot := gobot.NewRobot(
 		func() {
-			gobot.Every(500*time.Millisecond, func() { fmt.Println("Greetings human") })
+			gobot.EveryTimer(500*time.Millisecond, func() { fmt.Println("Greetings human") })
 		},
 	)
 
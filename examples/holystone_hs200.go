@@ -23,7 +23,7 @@ func main() {
 	work := func() {
 		drone.TakeOff()
 
-		gobot.After(5*time.Second, func() {
+		gobot.AfterTimer(5*time.Second, func() {
 			drone.Land()
 		})
 	}
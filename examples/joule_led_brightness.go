@@ -21,7 +21,7 @@ func main() {
 		brightness := uint8(0)
 		fadeAmount := uint8(15)
 
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			err := led.Brightness(brightness)
 			if err != nil {
 				fmt.Println(err)
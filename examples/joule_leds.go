@@ -25,16 +25,16 @@ func main() {
 		led2.Off()
 		led3.Off()
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led0.Toggle()
 		})
-		gobot.Every(2*time.Second, func() {
+		gobot.EveryTimer(2*time.Second, func() {
 			led1.Toggle()
 		})
-		gobot.Every(4*time.Second, func() {
+		gobot.EveryTimer(4*time.Second, func() {
 			led2.Toggle()
 		})
-		gobot.Every(8*time.Second, func() {
+		gobot.EveryTimer(8*time.Second, func() {
 			led3.Toggle()
 		})
 	}
@@ -21,7 +21,7 @@ func main() {
 		speed := int16(0)
 		fadeAmount := int16(30)
 
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			motor.Speed(speed)
 			speed = speed + fadeAmount
 			if speed == 0 || speed == 300 {
@@ -39,10 +39,10 @@ func main() {
 
 	work := func() {
 		ubit.Blank()
-		gobot.After(1*time.Second, func() {
+		gobot.AfterTimer(1*time.Second, func() {
 			ubit.WriteText("Hello")
 		})
-		gobot.After(7*time.Second, func() {
+		gobot.AfterTimer(7*time.Second, func() {
 			ubit.Smile()
 		})
 	}
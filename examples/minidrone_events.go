@@ -42,7 +42,7 @@ func main() {
 
 		drone.On(minidrone.Hovering, func(data interface{}) {
 			fmt.Println("hovering!")
-			gobot.After(5*time.Second, func() {
+			gobot.AfterTimer(5*time.Second, func() {
 				drone.Land()
 			})
 		})
@@ -106,7 +106,7 @@ func main() {
 			rightY.Store(val)
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			rightStick := getRightStick()
 
 			switch {
@@ -128,7 +128,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			leftStick := getLeftStick()
 			switch {
 			case leftStick.y < -10:
@@ -97,7 +97,7 @@ func main() {
 			rightY.Store(val)
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			rightStick := getRightStick()
 
 			switch {
@@ -119,7 +119,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			leftStick := getLeftStick()
 			switch {
 			case leftStick.y < -10:
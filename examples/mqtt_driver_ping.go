@@ -36,11 +36,11 @@ func main() {
 		})
 
 		data := []byte("o")
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			helloDriver.Publish(data)
 		})
 
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			holaDriver.Publish(data)
 		})
 	}
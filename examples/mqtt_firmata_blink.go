@@ -26,10 +26,10 @@ func main() {
 			led.Off()
 		})
 		data := []byte("")
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			mqttAdaptor.Publish("lights/on", data)
 		})
-		gobot.Every(2*time.Second, func() {
+		gobot.EveryTimer(2*time.Second, func() {
 			mqttAdaptor.Publish("lights/off", data)
 		})
 	}
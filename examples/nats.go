@@ -23,10 +23,10 @@ func main() {
 			fmt.Println("hola")
 		})
 		data := []byte("o")
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			natsAdaptor.Publish("hello", data)
 		})
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			natsAdaptor.Publish("hola", data)
 		})
 	}
@@ -21,7 +21,7 @@ func main() {
 		head := 90
 		ollieBot.SetRGB(255, 0, 0)
 		ollieBot.Boost(true)
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			ollieBot.Roll(0, uint16(head))
 			time.Sleep(1 * time.Second)
 			head += 90
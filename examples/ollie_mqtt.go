@@ -47,28 +47,28 @@ func main() {
 
 		mqttAdaptor.On("rover/frente", func(msg mqtt.Message) {
 			ollie.Roll(40, FRENTE)
-			gobot.After(1*time.Second, func() {
+			gobot.AfterTimer(1*time.Second, func() {
 				ollie.Stop()
 			})
 		})
 
 		mqttAdaptor.On("rover/derecha", func(msg mqtt.Message) {
 			ollie.Roll(40, DERECHA)
-			gobot.After(1*time.Second, func() {
+			gobot.AfterTimer(1*time.Second, func() {
 				ollie.Stop()
 			})
 		})
 
 		mqttAdaptor.On("rover/atras", func(msg mqtt.Message) {
 			ollie.Roll(40, ATRAS)
-			gobot.After(1*time.Second, func() {
+			gobot.AfterTimer(1*time.Second, func() {
 				ollie.Stop()
 			})
 		})
 
 		mqttAdaptor.On("rover/izquierda", func(msg mqtt.Message) {
 			ollie.Roll(40, IZQUIERDA)
-			gobot.After(1*time.Second, func() {
+			gobot.AfterTimer(1*time.Second, func() {
 				ollie.Stop()
 			})
 		})
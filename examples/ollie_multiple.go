@@ -27,7 +27,7 @@ func NewSwarmBot(port string) *gobot.Robot {
 	ollieDriver := ollie.NewDriver(bleAdaptor)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			ollieDriver.SetRGB(uint8(gobot.Rand(255)),
 				uint8(gobot.Rand(255)),
 				uint8(gobot.Rand(255)),
@@ -19,7 +19,7 @@ func main() {
 
 	work := func() {
 		ollieBot.SetRGB(255, 0, 255)
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			// Ollie performs 360 spin trick
 			ollieBot.SetRawMotorValues(ollie.Forward, uint8(255), ollie.Reverse, uint8(255))
 		})
@@ -33,7 +33,7 @@ func main() {
 			img.Store(i)
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		gobot.EveryTimer(10*time.Millisecond, func() {
 			i := img.Load().(gocv.Mat)
 			if i.Empty() {
 				return
@@ -25,7 +25,7 @@ func main() {
 	led := gpio.NewLedDriver(core, "D7")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
@@ -28,7 +28,7 @@ func main() {
 		brightness := uint8(0)
 		fadeAmount := uint8(25)
 
-		gobot.Every(500*time.Millisecond, func() {
+		gobot.EveryTimer(500*time.Millisecond, func() {
 			led.Brightness(brightness)
 			brightness = brightness + fadeAmount
 			if brightness == 0 || brightness == 255 {
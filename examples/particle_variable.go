@@ -24,7 +24,7 @@ func main() {
 	core := particle.NewAdaptor(os.Args[1], os.Args[2])
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			if temp, err := core.Variable("temperature"); err != nil {
 				fmt.Println(err)
 			} else {
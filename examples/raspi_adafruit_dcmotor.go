@@ -46,7 +46,7 @@ func main() {
 	adaFruit := i2c.NewAdafruitMotorHatDriver(r)
 
 	work := func() {
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 
 			dcMotor := 2 // 0-based
 			adafruitDCMotorRunner(adaFruit, dcMotor)
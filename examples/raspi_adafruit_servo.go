@@ -75,7 +75,7 @@ func main() {
 	adaFruit := i2c.NewAdafruitMotorHatDriver(r)
 
 	work := func() {
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			adafruitServoMotorRunner(adaFruit)
 		})
 	}
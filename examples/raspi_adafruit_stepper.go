@@ -38,7 +38,7 @@ func main() {
 	adaFruit := i2c.NewAdafruitMotorHatDriver(r)
 
 	work := func() {
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			motor := 0 // 0-based
 			adafruitStepperMotorRunner(adaFruit, motor)
 		})
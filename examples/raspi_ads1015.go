@@ -20,7 +20,7 @@ func main() {
 	ads1015.DefaultGain, _ = ads1015.BestGainForVoltage(5.0)
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			v, _ := ads1015.ReadWithDefaults(0)
 			fmt.Println("A0", v)
 		})
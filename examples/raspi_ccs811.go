@@ -42,7 +42,7 @@ func main() {
 
 	work := func() {
 		CCS811BootData(ccs811Driver)
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			s, err := ccs811Driver.GetStatus()
 			if err != nil {
 				fmt.Printf("Error fetching data from the status register: %+v\n", err.Error())
@@ -19,7 +19,7 @@ func main() {
 	led := gpio.NewLedDriver(gp, "D2")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
@@ -20,7 +20,7 @@ func main() {
 
 	work := func() {
 
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			for _, ch := range []i2c.INA3221Channel{i2c.INA3221Channel1, i2c.INA3221Channel2, i2c.INA3221Channel3} {
 				val, err := ina.GetBusVoltage(ch)
 				if err != nil {
@@ -18,7 +18,7 @@ func main() {
 	adc := spi.NewMCP3008Driver(a)
 
 	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+		gobot.EveryTimer(100*time.Millisecond, func() {
 			result, err := adc.Read(0)
 			fmt.Println("A0", result, err)
 		})
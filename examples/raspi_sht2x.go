@@ -18,7 +18,7 @@ func main() {
 	sht2x := i2c.NewSHT2xDriver(r)
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			t, _ := sht2x.Temperature()
 			fmt.Printf("Temperature: %v\n", t)
 
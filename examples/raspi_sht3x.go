@@ -23,7 +23,7 @@ func main() {
 		sn, err := sht3x.SerialNumber()
 		fmt.Printf("Serial Number: 0x%08x, err: %v\n", sn, err)
 
-		gobot.Every(5*time.Second, func() {
+		gobot.EveryTimer(5*time.Second, func() {
 			temp, rh, err := sht3x.Sample()
 			fmt.Printf("Temp: %f F, Relative Humidity: %f, err: %v\n", temp, rh, err)
 		})
@@ -22,7 +22,7 @@ func main() {
 
 	work := func() {
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			oled.Clear()
 			if stage {
 				for x := 0; x < width; x += 5 {
@@ -27,11 +27,11 @@ func main() {
 			fmt.Printf("Streaming Data! %+v\n", data)
 		})
 
-		gobot.Every(3*time.Second, func() {
+		gobot.EveryTimer(3*time.Second, func() {
 			spheroDriver.Roll(30, uint16(gobot.Rand(360)))
 		})
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			r := uint8(gobot.Rand(255))
 			g := uint8(gobot.Rand(255))
 			b := uint8(gobot.Rand(255))
@@ -44,13 +44,13 @@ func main() {
 				conway.contact()
 			})
 
-			gobot.Every(3*time.Second, func() {
+			gobot.EveryTimer(3*time.Second, func() {
 				if conway.alive {
 					conway.movement()
 				}
 			})
 
-			gobot.Every(10*time.Second, func() {
+			gobot.EveryTimer(10*time.Second, func() {
 				if conway.alive {
 					conway.birthday()
 				}
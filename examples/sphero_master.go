@@ -37,7 +37,7 @@ func main() {
 
 	robot := gobot.NewRobot("",
 		func() {
-			gobot.Every(1*time.Second, func() {
+			gobot.EveryTimer(1*time.Second, func() {
 				sphero := master.Robot("Sphero-BPO").Device("sphero").(*sphero.SpheroDriver)
 				sphero.SetRGB(uint8(gobot.Rand(255)),
 					uint8(gobot.Rand(255)),
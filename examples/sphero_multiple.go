@@ -25,10 +25,10 @@ func NewSwarmBot(port string) *gobot.Robot {
 			fmt.Println("Collision Detected!")
 		})
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			spheroDriver.Roll(100, uint16(gobot.Rand(360)))
 		})
-		gobot.Every(3*time.Second, func() {
+		gobot.EveryTimer(3*time.Second, func() {
 			spheroDriver.SetRGB(uint8(gobot.Rand(255)),
 				uint8(gobot.Rand(255)),
 				uint8(gobot.Rand(255)),
@@ -34,7 +34,7 @@ func main() {
 		flash := false
 		on := true
 
-		gobot.Every(50*time.Millisecond, func() {
+		gobot.EveryTimer(50*time.Millisecond, func() {
 			if enabled {
 				if flash {
 					if on {
@@ -102,7 +102,7 @@ func init() {
 			drone.StartVideo()
 			drone.SetVideoEncoderRate(tello.VideoBitRateAuto)
 			drone.SetExposure(0)
-			gobot.Every(100*time.Millisecond, func() {
+			gobot.EveryTimer(100*time.Millisecond, func() {
 				drone.StartVideo()
 			})
 		})
@@ -298,7 +298,7 @@ func handleJoystick() {
 		val := float64(data.(int16))
 		rightY.Store(val)
 	})
-	gobot.Every(50*time.Millisecond, func() {
+	gobot.EveryTimer(50*time.Millisecond, func() {
 		rightStick := getRightStick()
 
 		switch {
@@ -320,7 +320,7 @@ func handleJoystick() {
 		}
 	})
 
-	gobot.Every(50*time.Millisecond, func() {
+	gobot.EveryTimer(50*time.Millisecond, func() {
 		leftStick := getLeftStick()
 		switch {
 		case leftStick.y < -10:
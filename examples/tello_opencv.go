@@ -52,7 +52,7 @@ func main() {
 			drone.SetVideoEncoderRate(tello.VideoBitRateAuto)
 			drone.SetExposure(0)
 
-			gobot.Every(100*time.Millisecond, func() {
+			gobot.EveryTimer(100*time.Millisecond, func() {
 				drone.StartVideo()
 			})
 		})
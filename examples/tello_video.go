@@ -37,7 +37,7 @@ func main() {
 			fmt.Println("Connected")
 			drone.StartVideo()
 			drone.SetVideoEncoderRate(tello.VideoBitRateAuto)
-			gobot.Every(100*time.Millisecond, func() {
+			gobot.EveryTimer(100*time.Millisecond, func() {
 				drone.StartVideo()
 			})
 		})
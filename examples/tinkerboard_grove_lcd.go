@@ -21,7 +21,7 @@ func main() {
 
 		screen.SetRGB(255, 0, 0)
 
-		gobot.After(5*time.Second, func() {
+		gobot.AfterTimer(5*time.Second, func() {
 			screen.Clear()
 			screen.Home()
 			screen.SetRGB(0, 255, 0)
@@ -29,7 +29,7 @@ func main() {
 			screen.SetCustomChar(0, i2c.CustomLCDChars["smiley"])
 			// add the custom character at the end of the string
 			screen.Write("\nTinker Board " + string(byte(0)))
-			gobot.Every(500*time.Millisecond, func() {
+			gobot.EveryTimer(500*time.Millisecond, func() {
 				screen.Scroll(false)
 			})
 		})
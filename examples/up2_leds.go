@@ -25,16 +25,16 @@ func main() {
 		green.Off()
 		yellow.Off()
 
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			red.Toggle()
 		})
-		gobot.Every(2*time.Second, func() {
+		gobot.EveryTimer(2*time.Second, func() {
 			green.Toggle()
 		})
-		gobot.Every(4*time.Second, func() {
+		gobot.EveryTimer(4*time.Second, func() {
 			yellow.Toggle()
 		})
-		gobot.Every(8*time.Second, func() {
+		gobot.EveryTimer(8*time.Second, func() {
 			blue.Toggle()
 		})
 	}
@@ -31,7 +31,7 @@ func main() {
 	led := gpio.NewLedDriver(firmataAdaptor, "2")
 
 	work := func() {
-		gobot.Every(1*time.Second, func() {
+		gobot.EveryTimer(1*time.Second, func() {
 			led.Toggle()
 		})
 	}
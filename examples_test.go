@@ -10,13 +10,13 @@ import (
 )
 
 func ExampleEvery() {
-	gobot.Every(1*time.Second, func() {
+	gobot.EveryTimer(1*time.Second, func() {
 		fmt.Println("Hello")
 	})
 }
 
 func ExampleAfter() {
-	gobot.After(1*time.Second, func() {
+	gobot.AfterTimer(1*time.Second, func() {
 		fmt.Println("Hello")
 	})
 }
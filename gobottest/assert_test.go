@@ -0,0 +1,71 @@
+package gobottest
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAssertError(t *testing.T) {
+	err := ""
+	errFunc = func(t *testing.T, message string) {
+		err = message
+	}
+
+	AssertError(t, errors.New("write error on bus 1"), "write error")
+	if err != "" {
+		t.Errorf("AssertError failed: error should contain substring")
+	}
+
+	AssertError(t, errors.New("read error"), "write error")
+	if err != `assert_test.go:20: "read error", should contain "write error"` {
+		t.Errorf("AssertError failed: error should not contain substring, got %s", err)
+	}
+
+	AssertError(t, nil, "write error")
+	if err != `assert_test.go:25: nil, should be an error containing "write error"` {
+		t.Errorf("AssertError failed: nil is no error, got %s", err)
+	}
+}
+
+func TestAssertBytes(t *testing.T) {
+	err := ""
+	errFunc = func(t *testing.T, message string) {
+		err = message
+	}
+
+	AssertBytes(t, []byte{0x01, 0x02}, []byte{0x01, 0x02})
+	if err != "" {
+		t.Errorf("AssertBytes failed: bytes should be equal")
+	}
+
+	got := make([]byte, 20)
+	want := make([]byte, 21)
+	got[18] = 0xab
+	AssertBytes(t, got, want)
+	expected := "assert_test.go:45: bytes differ at offset 18, len 20, should be len 21\n" +
+		"0010 got:  00 00 ab 00 --\n" +
+		"     want: 00 00 00 00 00\n" +
+		"                 ^^    ^^"
+	if err != expected {
+		t.Errorf("AssertBytes failed: got\n%s", err)
+	}
+}
+
+func TestEventually(t *testing.T) {
+	err := ""
+	errFunc = func(t *testing.T, message string) {
+		err = message
+	}
+
+	begin := time.Now()
+	Eventually(t, func() bool { return time.Since(begin) > 5*time.Millisecond }, time.Second)
+	if err != "" {
+		t.Errorf("Eventually failed: condition should be met")
+	}
+
+	Eventually(t, func() bool { return false }, 5*time.Millisecond)
+	if err != `assert_test.go:67: condition not met within 5ms` {
+		t.Errorf("Eventually failed: condition should not be met, got %s", err)
+	}
+}
@@ -0,0 +1,231 @@
+package gobottest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PinTransaction is an access to a pin of a Board.
+type PinTransaction struct {
+	// Time since the Board was connected
+	Time time.Duration
+	// Op is one of "DigitalWrite", "DigitalRead", "PwmWrite", "ServoWrite"
+	// and "AnalogRead"
+	Op  string
+	Pin string
+	Val int
+}
+
+func (p PinTransaction) String() string {
+	return fmt.Sprintf("%s %s %d", p.Op, p.Pin, p.Val)
+}
+
+type pinEdge struct {
+	after time.Duration
+	val   int
+}
+
+// Board is a simulated board for gpio driver tests. It implements the
+// adaptor interface and the DigitalReader, DigitalWriter, PwmWriter,
+// ServoWriter and AnalogReader interfaces of the gpio and aio packages.
+// The values read from the pins are scriptable and all pin accesses are
+// logged.
+type Board struct {
+	name         string
+	mutex        sync.Mutex
+	start        time.Time
+	levels       map[string]int
+	sequences    map[string][]int
+	edges        map[string][]pinEdge
+	errors       map[string]error
+	onWrite      map[string]func(val int)
+	transactions []PinTransaction
+}
+
+// NewBoard returns a new Board with all pins at level 0.
+func NewBoard() *Board {
+	return &Board{
+		name:      "Board",
+		start:     time.Now(),
+		levels:    make(map[string]int),
+		sequences: make(map[string][]int),
+		edges:     make(map[string][]pinEdge),
+		errors:    make(map[string]error),
+		onWrite:   make(map[string]func(val int)),
+	}
+}
+
+// Name returns the label for the Board
+func (b *Board) Name() string { return b.name }
+
+// SetName sets the label for the Board
+func (b *Board) SetName(n string) { b.name = n }
+
+// Connect restarts the time of the timed edges and of the transactions
+func (b *Board) Connect() (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.start = time.Now()
+	return
+}
+
+// Finalize does nothing
+func (b *Board) Finalize() (err error) { return }
+
+// SetLevel sets the value of the pin.
+func (b *Board) SetLevel(pin string, val int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.levels[pin] = val
+}
+
+// Level returns the current value of the pin, without logging a transaction.
+func (b *Board) Level(pin string) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.level(pin)
+}
+
+// SetSequence sets the values returned by the next reads of the pin. After
+// the sequence the pin keeps the last value.
+func (b *Board) SetSequence(pin string, vals ...int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.sequences[pin] = append([]int{}, vals...)
+}
+
+// AddEdge changes the value of the pin to val, when the given time since
+// Connect has passed.
+func (b *Board) AddEdge(pin string, after time.Duration, val int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	edges := append(b.edges[pin], pinEdge{after: after, val: val})
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].after < edges[j].after })
+	b.edges[pin] = edges
+}
+
+// SetError makes all accesses to the pin fail with err, nil removes the
+// error.
+func (b *Board) SetError(pin string, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if err == nil {
+		delete(b.errors, pin)
+		return
+	}
+	b.errors[pin] = err
+}
+
+// OnWrite sets a function, which is called after each write to the pin. It
+// can be used to simulate the reaction of a device, e.g. by SetLevel.
+func (b *Board) OnWrite(pin string, f func(val int)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.onWrite[pin] = f
+}
+
+// Transactions returns the log of all pin accesses.
+func (b *Board) Transactions() []PinTransaction {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]PinTransaction{}, b.transactions...)
+}
+
+// Writes returns the values written to the pin, in order.
+func (b *Board) Writes(pin string) (vals []int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, t := range b.transactions {
+		if t.Pin == pin && (t.Op == "DigitalWrite" || t.Op == "PwmWrite" || t.Op == "ServoWrite") {
+			vals = append(vals, t.Val)
+		}
+	}
+	return vals
+}
+
+// ClearTransactions empties the log of the pin accesses.
+func (b *Board) ClearTransactions() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.transactions = nil
+}
+
+// DigitalWrite sets the value of the pin
+func (b *Board) DigitalWrite(pin string, val byte) error {
+	return b.write("DigitalWrite", pin, int(val))
+}
+
+// PwmWrite sets the value of the pin
+func (b *Board) PwmWrite(pin string, val byte) error {
+	return b.write("PwmWrite", pin, int(val))
+}
+
+// ServoWrite sets the value of the pin
+func (b *Board) ServoWrite(pin string, val byte) error {
+	return b.write("ServoWrite", pin, int(val))
+}
+
+// DigitalRead returns the next value of the pin
+func (b *Board) DigitalRead(pin string) (int, error) {
+	return b.read("DigitalRead", pin)
+}
+
+// AnalogRead returns the next value of the pin
+func (b *Board) AnalogRead(pin string) (int, error) {
+	return b.read("AnalogRead", pin)
+}
+
+func (b *Board) write(op string, pin string, val int) error {
+	b.mutex.Lock()
+	if err := b.errors[pin]; err != nil {
+		b.mutex.Unlock()
+		return err
+	}
+	b.levels[pin] = val
+	b.log(op, pin, val)
+	onWrite := b.onWrite[pin]
+	b.mutex.Unlock()
+
+	if onWrite != nil {
+		onWrite(val)
+	}
+	return nil
+}
+
+func (b *Board) read(op string, pin string) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if err := b.errors[pin]; err != nil {
+		return 0, err
+	}
+	if seq := b.sequences[pin]; len(seq) > 0 {
+		b.levels[pin] = seq[0]
+		b.sequences[pin] = seq[1:]
+	}
+	val := b.level(pin)
+	b.log(op, pin, val)
+	return val, nil
+}
+
+// level applies the due edges and returns the value of the pin
+func (b *Board) level(pin string) int {
+	elapsed := time.Since(b.start)
+	edges := b.edges[pin]
+	for len(edges) > 0 && edges[0].after <= elapsed {
+		b.levels[pin] = edges[0].val
+		edges = edges[1:]
+	}
+	b.edges[pin] = edges
+	return b.levels[pin]
+}
+
+func (b *Board) log(op string, pin string, val int) {
+	b.transactions = append(b.transactions, PinTransaction{
+		Time: time.Since(b.start),
+		Op:   op,
+		Pin:  pin,
+		Val:  val,
+	})
+}
@@ -0,0 +1,68 @@
+package gobottest
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBoardDigitalWrite(t *testing.T) {
+	b := NewBoard()
+	var written []int
+	b.OnWrite("3", func(val int) { written = append(written, val) })
+
+	Assert(t, b.DigitalWrite("3", 1), nil)
+	Assert(t, b.PwmWrite("5", 128), nil)
+	Assert(t, b.DigitalWrite("3", 0), nil)
+	Assert(t, b.Level("3"), 0)
+	Assert(t, b.Level("5"), 128)
+	Assert(t, b.Writes("3"), []int{1, 0})
+	Assert(t, written, []int{1, 0})
+
+	transactions := b.Transactions()
+	Assert(t, len(transactions), 3)
+	Assert(t, transactions[1].String(), "PwmWrite 5 128")
+
+	b.ClearTransactions()
+	Assert(t, len(b.Transactions()), 0)
+}
+
+func TestBoardDigitalReadSequence(t *testing.T) {
+	b := NewBoard()
+	b.SetLevel("7", 1)
+	b.SetSequence("7", 0, 1, 0)
+
+	for _, want := range []int{0, 1, 0, 0} {
+		val, err := b.DigitalRead("7")
+		Assert(t, err, nil)
+		Assert(t, val, want)
+	}
+}
+
+func TestBoardDigitalReadEdges(t *testing.T) {
+	b := NewBoard()
+	b.AddEdge("7", 20*time.Millisecond, 0)
+	b.AddEdge("7", 10*time.Millisecond, 1)
+	b.Connect()
+
+	val, _ := b.DigitalRead("7")
+	Assert(t, val, 0)
+	time.Sleep(12 * time.Millisecond)
+	val, _ = b.DigitalRead("7")
+	Assert(t, val, 1)
+	time.Sleep(10 * time.Millisecond)
+	val, _ = b.DigitalRead("7")
+	Assert(t, val, 0)
+}
+
+func TestBoardError(t *testing.T) {
+	b := NewBoard()
+	b.SetError("7", errors.New("read error"))
+
+	_, err := b.DigitalRead("7")
+	Assert(t, err, errors.New("read error"))
+	Assert(t, b.DigitalWrite("7", 1), errors.New("read error"))
+
+	b.SetError("7", nil)
+	Assert(t, b.DigitalWrite("7", 1), nil)
+}
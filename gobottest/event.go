@@ -0,0 +1,76 @@
+package gobottest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// eventOnce is the part of gobot.Eventer needed to listen for an event
+type eventOnce interface {
+	Once(name string, f func(s interface{})) (err error)
+}
+
+// EventListener receives the first publication of an event. It subscribes
+// when it is created, so events published by the code under test after
+// ListenForEvent are not missed.
+type EventListener struct {
+	name string
+	data chan interface{}
+}
+
+// ListenForEvent subscribes to the event of e, which can be any
+// gobot.Eventer, e.g. a driver.
+func ListenForEvent(e eventOnce, name string) *EventListener {
+	l := &EventListener{name: name, data: make(chan interface{}, 1)}
+	e.Once(name, func(data interface{}) { l.data <- data })
+	return l
+}
+
+// Wait returns the data of the event, emits a t.Errorf if the event was not
+// published within the timeout.
+func (l *EventListener) Wait(t *testing.T, timeout time.Duration) interface{} {
+	return l.wait(t, timeout, 3)
+}
+
+// AssertNone emits a t.Errorf if the event is published within the given
+// time.
+func (l *EventListener) AssertNone(t *testing.T, d time.Duration) {
+	l.assertNone(t, d, 3)
+}
+
+// WaitForEvent subscribes to the event of e and returns its data, emits a
+// t.Errorf if the event was not published within the timeout. Use
+// ListenForEvent when the event could be published before WaitForEvent is
+// called.
+func WaitForEvent(t *testing.T, e eventOnce, name string, timeout time.Duration) interface{} {
+	return ListenForEvent(e, name).wait(t, timeout, 3)
+}
+
+// AssertNoEvent emits a t.Errorf if the event of e is published within the
+// given time.
+func AssertNoEvent(t *testing.T, e eventOnce, name string, d time.Duration) {
+	ListenForEvent(e, name).assertNone(t, d, 3)
+}
+
+func (l *EventListener) wait(t *testing.T, timeout time.Duration, depth int) interface{} {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case data := <-l.data:
+		return data
+	case <-timer.C:
+		logFailureAt(t, depth, fmt.Sprintf("event \"%s\" was not published within %v", l.name, timeout))
+		return nil
+	}
+}
+
+func (l *EventListener) assertNone(t *testing.T, d time.Duration, depth int) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case data := <-l.data:
+		logFailureAt(t, depth, fmt.Sprintf("event \"%s\" was published with \"%v\", should not be published within %v", l.name, data, d))
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,82 @@
+package gobottest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventTestEventer calls the handlers of an event on publish
+type eventTestEventer struct {
+	mutex    sync.Mutex
+	handlers map[string][]func(interface{})
+}
+
+func (e *eventTestEventer) Once(name string, f func(s interface{})) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.handlers == nil {
+		e.handlers = make(map[string][]func(interface{}))
+	}
+	e.handlers[name] = append(e.handlers[name], f)
+	return nil
+}
+
+func (e *eventTestEventer) publish(name string, data interface{}) {
+	e.mutex.Lock()
+	handlers := e.handlers[name]
+	delete(e.handlers, name)
+	e.mutex.Unlock()
+	for _, f := range handlers {
+		f(data)
+	}
+}
+
+func TestWaitForEvent(t *testing.T) {
+	e := &eventTestEventer{}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		e.publish("data", 42)
+	}()
+	var data interface{}
+	err := captureFailure(func() { data = WaitForEvent(t, e, "data", time.Second) })
+	Assert(t, data, 42)
+	Assert(t, err, "")
+
+	err = captureFailure(func() { data = WaitForEvent(t, e, "data", 5*time.Millisecond) })
+	Assert(t, data, nil)
+	Assert(t, strings.HasSuffix(err, `event "data" was not published within 5ms`), true)
+}
+
+func TestListenForEvent(t *testing.T) {
+	e := &eventTestEventer{}
+
+	l := ListenForEvent(e, "push")
+	e.publish("push", true)
+	var data interface{}
+	err := captureFailure(func() { data = l.Wait(t, time.Second) })
+	Assert(t, data, true)
+	Assert(t, err, "")
+
+	l = ListenForEvent(e, "push")
+	e.publish("release", true)
+	err = captureFailure(func() { l.AssertNone(t, 5*time.Millisecond) })
+	Assert(t, err, "")
+}
+
+func TestAssertNoEvent(t *testing.T) {
+	e := &eventTestEventer{}
+
+	err := captureFailure(func() { AssertNoEvent(t, e, "push", 5*time.Millisecond) })
+	Assert(t, err, "")
+
+	go func() {
+		time.Sleep(time.Millisecond)
+		e.publish("push", 1)
+	}()
+	err = captureFailure(func() { AssertNoEvent(t, e, "push", time.Second) })
+	Assert(t, strings.HasPrefix(err, "event_test.go:"), true)
+	Assert(t, strings.HasSuffix(err, `event "push" was published with "1", should not be published within 1s`), true)
+}
@@ -7,15 +7,29 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
+// bytesPerDiffLine is the amount of bytes shown per line of an AssertBytes
+// failure
+const bytesPerDiffLine = 16
+
+// hammerTimeout is the time after which Hammer reports a deadlock
+const hammerTimeout = 10 * time.Second
+
 var errFunc = func(t *testing.T, message string) {
 	t.Errorf(message)
 }
 
 func logFailure(t *testing.T, message string) {
-	_, file, line, _ := runtime.Caller(2)
+	logFailureAt(t, 3, message)
+}
+
+// logFailureAt reports the message at the caller with the given depth
+func logFailureAt(t *testing.T, depth int, message string) {
+	_, file, line, _ := runtime.Caller(depth)
 	s := strings.Split(file, "/")
 	errFunc(t, fmt.Sprintf("%v:%v: %v", s[len(s)-1], line, message))
 }
@@ -36,6 +50,84 @@ func Refute(t *testing.T, a interface{}, b interface{}) {
 	}
 }
 
+// AssertError checks if err is not nil and its message contains substring,
+// emits a t.Errorf otherwise.
+func AssertError(t *testing.T, err error, substring string) {
+	if err == nil {
+		logFailure(t, fmt.Sprintf("nil, should be an error containing \"%v\"", substring))
+		return
+	}
+	if !strings.Contains(err.Error(), substring) {
+		logFailure(t, fmt.Sprintf("\"%v\", should contain \"%v\"", err, substring))
+	}
+}
+
+// AssertBytes checks if got and want are equal, emits a t.Errorf with a hex
+// dump of the differing lines if they are not equal.
+func AssertBytes(t *testing.T, got []byte, want []byte) {
+	if string(got) != string(want) {
+		logFailure(t, bytesDiff(got, want))
+	}
+}
+
+// Eventually checks if cond becomes true within the timeout, emits a
+// t.Errorf if it does not.
+func Eventually(t *testing.T, cond func() bool, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			logFailure(t, fmt.Sprintf("condition not met within %v", timeout))
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// bytesDiff returns a hex dump of the lines where got and want differ. The
+// differing bytes are marked, missing bytes are shown as "--".
+func bytesDiff(got []byte, want []byte) string {
+	first := 0
+	for first < len(got) && first < len(want) && got[first] == want[first] {
+		first++
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "bytes differ at offset %d, len %d, should be len %d", first, len(got), len(want))
+
+	size := len(got)
+	if len(want) > size {
+		size = len(want)
+	}
+	hex := func(data []byte, i int) string {
+		if i < len(data) {
+			return fmt.Sprintf("%02x", data[i])
+		}
+		return "--"
+	}
+	for start := first - first%bytesPerDiffLine; start < size; start += bytesPerDiffLine {
+		var gotLine, wantLine, marks []string
+		differ := false
+		for i := start; i < start+bytesPerDiffLine && i < size; i++ {
+			g, w := hex(got, i), hex(want, i)
+			gotLine = append(gotLine, g)
+			wantLine = append(wantLine, w)
+			if g != w {
+				marks = append(marks, "^^")
+				differ = true
+			} else {
+				marks = append(marks, "  ")
+			}
+		}
+		if !differ {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%04x got:  %s", start, strings.Join(gotLine, " "))
+		fmt.Fprintf(&b, "\n     want: %s", strings.Join(wantLine, " "))
+		fmt.Fprintf(&b, "\n           %s", strings.TrimRight(strings.Join(marks, " "), " "))
+	}
+	return b.String()
+}
+
 func ExecCommand(command string, args ...string) *exec.Cmd {
 	cs := []string{"-test.run=TestHelperProcess", "--", command}
 	cs = append(cs, args...)
@@ -43,3 +135,37 @@ func ExecCommand(command string, args ...string) *exec.Cmd {
 	cmd.Env = []string{"GO_WANT_HELPER_PROCESS=1"}
 	return cmd
 }
+
+// Hammer calls all functions from the given number of goroutines at the same
+// time, each goroutine repeats this iterations times. Run the test with the
+// race detector ("go test -race") to find missing locks, e.g. around the
+// read-modify-write sequences of a driver.
+func Hammer(t *testing.T, goroutines int, iterations int, funcs ...func()) {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			<-start
+			for i := 0; i < iterations; i++ {
+				// each goroutine starts with another function
+				for j := range funcs {
+					funcs[(g+j)%len(funcs)]()
+				}
+			}
+		}(g)
+	}
+	close(start)
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(hammerTimeout):
+		logFailure(t, fmt.Sprintf("functions did not return within %v, deadlock?", hammerTimeout))
+	}
+}
@@ -40,3 +40,13 @@ func TestExecCommand(t *testing.T) {
 	val := ExecCommand("echo", "hello")
 	Refute(t, val, nil)
 }
+
+// captureFailure returns the failure message emitted by f
+func captureFailure(f func()) (message string) {
+	defer func(orig func(*testing.T, string)) { errFunc = orig }(errFunc)
+	errFunc = func(t *testing.T, m string) {
+		message = m
+	}
+	f()
+	return message
+}
@@ -0,0 +1,22 @@
+package gobottest
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestHammer(t *testing.T) {
+	var mutex sync.Mutex
+	calls := map[string]int{}
+	count := func(name string) func() {
+		return func() {
+			mutex.Lock()
+			defer mutex.Unlock()
+			calls[name]++
+		}
+	}
+
+	err := captureFailure(func() { Hammer(t, 3, 10, count("a"), count("b")) })
+	Assert(t, err, "")
+	Assert(t, calls, map[string]int{"a": 30, "b": 30})
+}
@@ -0,0 +1,31 @@
+/*
+Package hil provides helpers for hardware-in-the-loop tests, which run the
+drivers against real chips, e.g. on a single board computer in a lab.
+
+These tests are optional. They are placed in files with the build tag "hil",
+so they are only compiled with
+
+	go test -tags hil ./drivers/i2c/
+
+The hardware is described by environment variables:
+
+	GOBOT_HIL_I2C_BUS=1      i2c bus the chips are connected to
+	GOBOT_HIL_PIN_LED=17     sysfs gpio number of the pin named "LED"
+
+A test is skipped when its hardware is not configured or does not respond,
+so the same tests can run on boards with different setups:
+
+	// +build hil
+
+	package i2c_test
+
+	func TestBMP280HIL(t *testing.T) {
+		a := hil.NewAdaptor(t)
+		defer a.Finalize()
+		a.ProbeI2c(t, 0x77)
+
+		d := i2c.NewBMP280Driver(a)
+		...
+	}
+*/
+package hil // import "gobot.io/x/gobot/gobottest/hil"
@@ -0,0 +1,176 @@
+package hil
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/sysfs"
+)
+
+const (
+	// I2cBusEnv is the environment variable with the number of the i2c bus
+	I2cBusEnv = "GOBOT_HIL_I2C_BUS"
+	// PinEnvPrefix is the prefix of the environment variables with the sysfs
+	// gpio numbers of the named pins
+	PinEnvPrefix = "GOBOT_HIL_PIN_"
+)
+
+// Adaptor gives the drivers under test access to the configured i2c bus and
+// to sysfs gpio pins. The pins are addressed by their sysfs gpio number, see
+// Pin.
+type Adaptor struct {
+	name           string
+	bus            int
+	mutex          sync.Mutex
+	i2cBus         i2c.I2cDevice
+	i2cConnections i2c.Connections
+	digitalPins    map[int]*sysfs.DigitalPin
+}
+
+// NewAdaptor returns an Adaptor for the configured i2c bus, the test is
+// skipped when no bus is configured.
+func NewAdaptor(t testing.TB) *Adaptor {
+	t.Helper()
+	return &Adaptor{
+		name:        "HIL",
+		bus:         I2cBus(t),
+		digitalPins: make(map[int]*sysfs.DigitalPin),
+	}
+}
+
+// I2cBus returns the configured i2c bus, the test is skipped when no bus is
+// configured.
+func I2cBus(t testing.TB) int {
+	t.Helper()
+	val, ok := os.LookupEnv(I2cBusEnv)
+	if !ok {
+		t.Skipf("no i2c bus configured, set %s", I2cBusEnv)
+	}
+	bus, err := strconv.Atoi(val)
+	if err != nil {
+		t.Fatalf("invalid i2c bus %s=%s", I2cBusEnv, val)
+	}
+	return bus
+}
+
+// Pin returns the sysfs gpio number of the named pin, the test is skipped
+// when the pin is not configured.
+func Pin(t testing.TB, name string) string {
+	t.Helper()
+	env := PinEnvPrefix + strings.ToUpper(name)
+	val, ok := os.LookupEnv(env)
+	if !ok {
+		t.Skipf("pin %s not configured, set %s", name, env)
+	}
+	if _, err := strconv.Atoi(val); err != nil {
+		t.Fatalf("invalid pin %s=%s", env, val)
+	}
+	return val
+}
+
+// Name returns the label for the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the label for the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Connect does nothing, the bus is opened on demand
+func (a *Adaptor) Connect() (err error) { return }
+
+// Finalize closes the i2c bus and unexports the pins
+func (a *Adaptor) Finalize() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for i, pin := range a.digitalPins {
+		if perr := pin.Unexport(); perr != nil {
+			err = multierror.Append(err, perr)
+		}
+		delete(a.digitalPins, i)
+	}
+	a.i2cConnections.Close()
+	if a.i2cBus != nil {
+		if berr := a.i2cBus.Close(); berr != nil {
+			err = multierror.Append(err, berr)
+		}
+		a.i2cBus = nil
+	}
+	return
+}
+
+// GetConnection returns a connection to the device at the address, only the
+// configured bus is available.
+func (a *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if bus != a.bus {
+		return nil, fmt.Errorf("Bus number %d not configured, only bus %d", bus, a.bus)
+	}
+	if a.i2cBus == nil {
+		if a.i2cBus, err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus)); err != nil {
+			a.i2cBus = nil
+			return nil, err
+		}
+	}
+	return a.i2cConnections.Get(a.i2cBus, address), nil
+}
+
+// GetDefaultBus returns the configured bus
+func (a *Adaptor) GetDefaultBus() int {
+	return a.bus
+}
+
+// ProbeI2c skips the test, when no device responds at the address.
+func (a *Adaptor) ProbeI2c(t testing.TB, address int) {
+	t.Helper()
+	conn, err := a.GetConnection(address, a.bus)
+	if err != nil {
+		t.Skipf("i2c bus %d not available: %v", a.bus, err)
+	}
+	if _, err := conn.ReadByte(); err != nil {
+		t.Skipf("no device at 0x%02x on i2c bus %d: %v", address, a.bus, err)
+	}
+}
+
+// DigitalWrite writes the value to the pin with the sysfs gpio number
+func (a *Adaptor) DigitalWrite(pin string, val byte) (err error) {
+	p, err := a.digitalPin(pin, sysfs.OUT)
+	if err != nil {
+		return err
+	}
+	return p.Write(int(val))
+}
+
+// DigitalRead reads the value of the pin with the sysfs gpio number
+func (a *Adaptor) DigitalRead(pin string) (val int, err error) {
+	p, err := a.digitalPin(pin, sysfs.IN)
+	if err != nil {
+		return
+	}
+	return p.Read()
+}
+
+func (a *Adaptor) digitalPin(pin string, dir string) (*sysfs.DigitalPin, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	i, err := strconv.Atoi(pin)
+	if err != nil {
+		return nil, fmt.Errorf("Not a valid pin: %s", pin)
+	}
+	if a.digitalPins[i] == nil {
+		p := sysfs.NewDigitalPin(i)
+		if err := p.Export(); err != nil {
+			return nil, err
+		}
+		a.digitalPins[i] = p
+	}
+	return a.digitalPins[i], a.digitalPins[i].Direction(dir)
+}
@@ -0,0 +1,120 @@
+// +build !windows
+
+package hil
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/sysfs"
+)
+
+// make sure that this Adaptor fullfills all the required interfaces
+var _ i2c.Connector = (*Adaptor)(nil)
+var _ gpio.DigitalReader = (*Adaptor)(nil)
+var _ gpio.DigitalWriter = (*Adaptor)(nil)
+
+func syscallImpl(trap, a1, a2, a3 uintptr) (r1, r2 uintptr, err syscall.Errno) {
+	if (trap == syscall.SYS_IOCTL) && (a2 == sysfs.I2C_FUNCS) {
+		funcs := (*uint64)(unsafe.Pointer(a3))
+		*funcs = sysfs.I2C_FUNC_SMBUS_READ_BYTE | sysfs.I2C_FUNC_SMBUS_WRITE_BYTE_DATA
+	}
+	return 0, 0, 0
+}
+
+// skipRecorder records whether a test was skipped
+type skipRecorder struct {
+	testing.TB
+	skipped bool
+}
+
+func (s *skipRecorder) Helper() {}
+
+func (s *skipRecorder) Skipf(format string, args ...interface{}) {
+	s.skipped = true
+	// a skipped test does not continue
+	panic(s)
+}
+
+func skipped(f func(tb testing.TB)) (skipped bool) {
+	s := &skipRecorder{}
+	defer func() {
+		if r := recover(); r != nil && r != s {
+			panic(r)
+		}
+		skipped = s.skipped
+	}()
+	f(s)
+	return
+}
+
+func TestI2cBus(t *testing.T) {
+	os.Unsetenv(I2cBusEnv)
+	gobottest.Assert(t, skipped(func(tb testing.TB) { I2cBus(tb) }), true)
+
+	os.Setenv(I2cBusEnv, "2")
+	defer os.Unsetenv(I2cBusEnv)
+	gobottest.Assert(t, skipped(func(tb testing.TB) { I2cBus(tb) }), false)
+	gobottest.Assert(t, I2cBus(t), 2)
+}
+
+func TestPin(t *testing.T) {
+	gobottest.Assert(t, skipped(func(tb testing.TB) { Pin(tb, "led") }), true)
+
+	os.Setenv("GOBOT_HIL_PIN_LED", "17")
+	defer os.Unsetenv("GOBOT_HIL_PIN_LED")
+	gobottest.Assert(t, Pin(t, "led"), "17")
+}
+
+func TestAdaptorI2c(t *testing.T) {
+	os.Setenv(I2cBusEnv, "1")
+	defer os.Unsetenv(I2cBusEnv)
+	fs := sysfs.NewMockFilesystem([]string{"/dev/i2c-1"})
+	sysfs.SetFilesystem(fs)
+	sysfs.SetSyscall(&sysfs.MockSyscall{Impl: syscallImpl})
+
+	a := NewAdaptor(t)
+	gobottest.Assert(t, a.GetDefaultBus(), 1)
+	_, err := a.GetConnection(0x77, 0)
+	gobottest.Assert(t, err, errors.New("Bus number 0 not configured, only bus 1"))
+
+	con, err := a.GetConnection(0x77, 1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, con.WriteByteData(0x01, 0x02), nil)
+	gobottest.Assert(t, skipped(func(tb testing.TB) { a.ProbeI2c(tb, 0x77) }), false)
+	gobottest.Assert(t, a.Finalize(), nil)
+
+	delete(fs.Files, "/dev/i2c-1")
+	gobottest.Assert(t, skipped(func(tb testing.TB) { a.ProbeI2c(tb, 0x77) }), true)
+}
+
+func TestAdaptorDigitalIO(t *testing.T) {
+	os.Setenv(I2cBusEnv, "1")
+	defer os.Unsetenv(I2cBusEnv)
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio17/value",
+		"/sys/class/gpio/gpio17/direction",
+	})
+	sysfs.SetFilesystem(fs)
+
+	a := NewAdaptor(t)
+	gobottest.Assert(t, a.DigitalWrite("17", 1), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio17/value"].Contents, "1")
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio17/direction"].Contents, "out")
+
+	val, err := a.DigitalRead("17")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, a.DigitalWrite("P1", 1), errors.New("Not a valid pin: P1"))
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/unexport"].Contents, "17")
+}
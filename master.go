@@ -1,8 +1,10 @@
 package gobot
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"sync/atomic"
 
 	multierror "github.com/hashicorp/go-multierror"
@@ -34,10 +36,12 @@ func NewJSONMaster(gobot *Master) *JSONMaster {
 // Master is the main type of your Gobot application and contains a collection of
 // Robots, API commands that apply to the Master, and Events that apply to the Master.
 type Master struct {
-	robots  *Robots
-	trap    func(chan os.Signal)
-	AutoRun bool
-	running atomic.Value
+	robots      *Robots
+	groups      map[string][]string
+	groupsMutex sync.RWMutex
+	trap        func(chan os.Signal)
+	AutoRun     bool
+	running     atomic.Value
 	Commander
 	Eventer
 }
@@ -46,6 +50,7 @@ type Master struct {
 func NewMaster() *Master {
 	m := &Master{
 		robots: &Robots{},
+		groups: make(map[string][]string),
 		trap: func(c chan os.Signal) {
 			signal.Notify(c, os.Interrupt)
 		},
@@ -118,3 +123,88 @@ func (g *Master) Robot(name string) *Robot {
 	}
 	return nil
 }
+
+// AddRobotToGroup adds the robot with the given name to a named group of robots.
+// Groups are created on first use and a robot can be a member of several groups.
+func (g *Master) AddRobotToGroup(group string, name string) {
+	g.groupsMutex.Lock()
+	defer g.groupsMutex.Unlock()
+
+	for _, member := range g.groups[group] {
+		if member == name {
+			return
+		}
+	}
+	g.groups[group] = append(g.groups[group], name)
+}
+
+// RemoveRobotFromGroup removes the robot with the given name from a named group
+// of robots. The group is deleted when its last robot is removed.
+func (g *Master) RemoveRobotFromGroup(group string, name string) {
+	g.groupsMutex.Lock()
+	defer g.groupsMutex.Unlock()
+
+	members := g.groups[group]
+	for i, member := range members {
+		if member == name {
+			members = append(members[:i], members[i+1:]...)
+			break
+		}
+	}
+	if len(members) == 0 {
+		delete(g.groups, group)
+		return
+	}
+	g.groups[group] = members
+}
+
+// Groups returns the names of all robot groups.
+func (g *Master) Groups() []string {
+	g.groupsMutex.RLock()
+	defer g.groupsMutex.RUnlock()
+
+	groups := []string{}
+	for group := range g.groups {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// Group returns the robots which are members of the given group. Members which
+// have not been added to the Master are skipped.
+func (g *Master) Group(group string) *Robots {
+	g.groupsMutex.RLock()
+	defer g.groupsMutex.RUnlock()
+
+	robots := &Robots{}
+	for _, name := range g.groups[group] {
+		if robot := g.Robot(name); robot != nil {
+			*robots = append(*robots, robot)
+		}
+	}
+	return robots
+}
+
+// BroadcastCommand executes the named robot command with the given params on
+// each robot of the group. The results are returned by robot name. For robots
+// which do not provide the command an error is returned as their result.
+func (g *Master) BroadcastCommand(group string, command string, params map[string]interface{}) map[string]interface{} {
+	results := make(map[string]interface{})
+	g.Group(group).Each(func(r *Robot) {
+		f := r.Command(command)
+		if f == nil {
+			results[r.Name] = fmt.Errorf("Unknown Command %s", command)
+			return
+		}
+		results[r.Name] = f(params)
+	})
+	return results
+}
+
+// BroadcastEvent publishes the named event with the given data on each robot
+// of the group.
+func (g *Master) BroadcastEvent(group string, name string, data interface{}) {
+	g.Group(group).Each(func(r *Robot) {
+		r.Publish(name, data)
+	})
+}
@@ -164,3 +164,54 @@ func TestMasterFinalizeErrors(t *testing.T) {
 		return nil
 	}
 }
+
+func TestMasterGroups(t *testing.T) {
+	g := initTestMaster()
+	gobottest.Assert(t, g.Group("drive").Len(), 0)
+
+	g.AddRobotToGroup("drive", "Robot1")
+	g.AddRobotToGroup("drive", "Robot2")
+	g.AddRobotToGroup("drive", "Robot2")
+	g.AddRobotToGroup("drive", "Robot4")
+	gobottest.Assert(t, g.Groups(), []string{"drive"})
+	gobottest.Assert(t, g.Group("drive").Len(), 2)
+
+	g.RemoveRobotFromGroup("drive", "Robot1")
+	gobottest.Assert(t, g.Group("drive").Len(), 1)
+	g.RemoveRobotFromGroup("drive", "Robot2")
+	g.RemoveRobotFromGroup("drive", "Robot4")
+	gobottest.Assert(t, g.Groups(), []string{})
+}
+
+func TestMasterBroadcastCommand(t *testing.T) {
+	g := initTestMaster()
+	g.Robot("Robot1").AddCommand("stop", func(params map[string]interface{}) interface{} {
+		return "stopped " + params["reason"].(string)
+	})
+	g.AddRobotToGroup("drive", "Robot1")
+	g.AddRobotToGroup("drive", "Robot2")
+
+	results := g.BroadcastCommand("drive", "stop", map[string]interface{}{"reason": "test"})
+	gobottest.Assert(t, len(results), 2)
+	gobottest.Assert(t, results["Robot1"], "stopped test")
+	gobottest.Assert(t, results["Robot2"], errors.New("Unknown Command stop"))
+}
+
+func TestMasterBroadcastEvent(t *testing.T) {
+	g := initTestMaster()
+	g.AddRobotToGroup("drive", "Robot1")
+
+	sem := make(chan interface{})
+	g.Robot("Robot1").AddEvent("halt")
+	g.Robot("Robot1").On("halt", func(data interface{}) {
+		sem <- data
+	})
+	g.BroadcastEvent("drive", "halt", 42)
+
+	select {
+	case data := <-sem:
+		gobottest.Assert(t, data, 42)
+	case <-time.After(100 * time.Millisecond):
+		t.Errorf("halt event was not published")
+	}
+}
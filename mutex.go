@@ -0,0 +1,36 @@
+package gobot
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Mutex is a mutual exclusion lock, which counts how often a caller had to
+// wait for the lock. Drivers use it to report their lock contention by the
+// ContentionCounter interface. The zero value is an unlocked mutex.
+type Mutex struct {
+	// contentions is the first field to be 64-bit aligned on 32-bit platforms
+	contentions uint64
+	holders     int32
+	mutex       sync.Mutex
+}
+
+// Lock locks the mutex, a call which finds the mutex locked or awaited by
+// other callers is counted as contention.
+func (m *Mutex) Lock() {
+	if atomic.AddInt32(&m.holders, 1) > 1 {
+		atomic.AddUint64(&m.contentions, 1)
+	}
+	m.mutex.Lock()
+}
+
+// Unlock unlocks the mutex.
+func (m *Mutex) Unlock() {
+	atomic.AddInt32(&m.holders, -1)
+	m.mutex.Unlock()
+}
+
+// Contentions returns the number of Lock calls which had to wait.
+func (m *Mutex) Contentions() uint64 {
+	return atomic.LoadUint64(&m.contentions)
+}
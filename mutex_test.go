@@ -0,0 +1,29 @@
+package gobot
+
+import (
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ ContentionCounter = (*Mutex)(nil)
+
+func TestMutexContentions(t *testing.T) {
+	m := &Mutex{}
+	m.Lock()
+	m.Unlock()
+	gobottest.Assert(t, m.Contentions(), uint64(0))
+
+	m.Lock()
+	locked := make(chan bool)
+	go func() {
+		m.Lock()
+		m.Unlock()
+		close(locked)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	m.Unlock()
+	<-locked
+	gobottest.Assert(t, m.Contentions(), uint64(1))
+}
@@ -29,8 +29,12 @@ type Adaptor struct {
 	digitalPins        []*sysfs.DigitalPin
 	pwmPins            map[string]*sysfs.PWMPin
 	i2cBuses           map[int]i2c.I2cDevice
+	i2cConnections     i2c.Connections
 	usrLed             string
 	analogPath         string
+	remoteprocPath     string
+	rpmsgPath          string
+	prus               [2]*PRU
 	pinMap             map[string]int
 	pwmPinMap          map[string]pwmPinData
 	analogPinMap       map[string]string
@@ -41,10 +45,27 @@ type Adaptor struct {
 	spiBuses           [2]spi.Connection
 	spiDefaultMode     int
 	spiDefaultMaxSpeed int64
+	// the first gpiochip of the board and its first GPIO, see WithGpiochip
+	gpiochip     string
+	gpiochipBase int
+}
+
+// WithGpiochip option sets the first gpiochip of the board, given by its label
+// or sysfs name, e.g. "44e07000.gpio". The GPIOs of the pins are offset by the
+// first GPIO of the chip, for kernels which don't number the GPIOs from 0 on,
+// e.g. from 512 on. By default the GPIOs are used as numbered in the pin map.
+func WithGpiochip(chip string) func(*Adaptor) {
+	return func(b *Adaptor) {
+		b.gpiochip = chip
+	}
 }
 
 // NewAdaptor returns a new Beaglebone Black/Green Adaptor
-func NewAdaptor() *Adaptor {
+//
+// Optional params:
+//		beaglebone.WithGpiochip(string):	first gpiochip of the board
+//
+func NewAdaptor(options ...func(*Adaptor)) *Adaptor {
 	b := &Adaptor{
 		name:         gobot.DefaultName("BeagleboneBlack"),
 		digitalPins:  make([]*sysfs.DigitalPin, 120),
@@ -61,12 +82,17 @@ func NewAdaptor() *Adaptor {
 	}
 
 	b.setPaths()
+	for _, option := range options {
+		option(b)
+	}
 	return b
 }
 
 func (b *Adaptor) setPaths() {
 	b.usrLed = "/sys/class/leds/beaglebone:green:"
 	b.analogPath = "/sys/bus/iio/devices/iio:device0"
+	b.remoteprocPath = "/sys/class/remoteproc/remoteproc%d"
+	b.rpmsgPath = "/dev/rpmsg_pru%d"
 
 	b.spiDefaultBus = 0
 	b.spiDefaultMode = 0
@@ -79,9 +105,16 @@ func (b *Adaptor) Name() string { return b.name }
 // SetName sets the Adaptor name
 func (b *Adaptor) SetName(n string) { b.name = n }
 
-// Connect initializes the pwm and analog dts.
-func (b *Adaptor) Connect() error {
-	return nil
+// Connect looks up the first GPIO of the gpiochip, if one is set
+func (b *Adaptor) Connect() (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.gpiochip == "" {
+		return
+	}
+	b.gpiochipBase, err = sysfs.GpiochipBase(b.gpiochip)
+	return
 }
 
 // Finalize releases all i2c devices and exported analog, digital, pwm pins.
@@ -89,32 +122,45 @@ func (b *Adaptor) Finalize() (err error) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	for _, pin := range b.digitalPins {
+	for i, pin := range b.digitalPins {
 		if pin != nil {
 			if e := pin.Unexport(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			b.digitalPins[i] = nil
 		}
 	}
-	for _, pin := range b.pwmPins {
+	for i, pin := range b.pwmPins {
 		if pin != nil {
 			if e := pin.Unexport(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			b.pwmPins[i] = nil
 		}
 	}
-	for _, bus := range b.i2cBuses {
+	b.i2cConnections.Close()
+	for i, bus := range b.i2cBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			b.i2cBuses[i] = nil
 		}
 	}
-	for _, bus := range b.spiBuses {
+	for i, bus := range b.spiBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			b.spiBuses[i] = nil
+		}
+	}
+	for i, pru := range b.prus {
+		if pru != nil {
+			if e := pru.Stop(); e != nil {
+				err = multierror.Append(err, e)
+			}
+			b.prus[i] = nil
 		}
 	}
 	return
@@ -186,7 +232,7 @@ func (b *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinn
 		return
 	}
 	if b.digitalPins[i] == nil {
-		b.digitalPins[i] = sysfs.NewDigitalPin(i)
+		b.digitalPins[i] = sysfs.NewDigitalPin(b.gpiochipBase + i)
 		if err = muxPin(pin, "gpio"); err != nil {
 			return
 		}
@@ -261,6 +307,32 @@ func (b *Adaptor) AnalogRead(pin string) (val int, err error) {
 	return
 }
 
+// PRU returns the PRU of the core, which is 0 or 1. It is stopped by
+// Finalize.
+func (b *Adaptor) PRU(core int) (pru *PRU, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if (core != 0) && (core != 1) {
+		return nil, fmt.Errorf("PRU core %d out of range", core)
+	}
+	if b.prus[core] == nil {
+		b.prus[core] = newPRU(core, b.remoteprocPath, b.rpmsgPath)
+	}
+	return b.prus[core], nil
+}
+
+// Counter returns the count of a device of the Linux counter subsystem. The
+// eQEP units of the Beaglebone are counter devices, if the ti-eqep driver is
+// loaded, and count encoder steps or, with the "increase" function, pulses.
+func (b *Adaptor) Counter(device int, count int) (sysfsCounter sysfs.CounterPinner, err error) {
+	c := sysfs.NewCounter(device, count)
+	if _, err = c.Count(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // GetConnection returns a connection to a device on a specified bus.
 // Valid bus number is either 0 or 2 which corresponds to /dev/i2c-0 or /dev/i2c-2.
 func (b *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
@@ -273,7 +345,10 @@ func (b *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 	if b.i2cBuses[bus] == nil {
 		b.i2cBuses[bus], err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
 	}
-	return i2c.NewConnection(b.i2cBuses[bus], address), err
+	if err != nil {
+		return nil, err
+	}
+	return b.i2cConnections.Get(b.i2cBuses[bus], address), nil
 }
 
 // GetDefaultBus returns the default i2c bus for this platform
@@ -23,6 +23,7 @@ var _ gpio.PwmWriter = (*Adaptor)(nil)
 var _ gpio.ServoWriter = (*Adaptor)(nil)
 var _ sysfs.DigitalPinnerProvider = (*Adaptor)(nil)
 var _ sysfs.PWMPinnerProvider = (*Adaptor)(nil)
+var _ sysfs.CounterProvider = (*Adaptor)(nil)
 var _ i2c.Connector = (*Adaptor)(nil)
 var _ spi.Connector = (*Adaptor)(nil)
 
@@ -212,6 +213,24 @@ func TestBeagleboneAnalogReadFileError(t *testing.T) {
 	gobottest.Assert(t, strings.Contains(err.Error(), "/sys/bus/iio/devices/iio:device0/in_voltage1_raw: No such file."), true)
 }
 
+func TestBeagleboneCounter(t *testing.T) {
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/bus/counter/devices/counter0/count0/count",
+	})
+	fs.Files["/sys/bus/counter/devices/counter0/count0/count"].Contents = "42\n"
+	sysfs.SetFilesystem(fs)
+
+	a := NewAdaptor()
+	c, err := a.Counter(0, 0)
+	gobottest.Assert(t, err, nil)
+	val, err := c.Count()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 42)
+
+	_, err = a.Counter(1, 0)
+	gobottest.Refute(t, err, nil)
+}
+
 func TestBeagleboneDigitalPinDirectionFileError(t *testing.T) {
 	fs := sysfs.NewMockFilesystem([]string{
 		"/sys/class/gpio/export",
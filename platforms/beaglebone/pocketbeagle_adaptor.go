@@ -11,8 +11,12 @@ type PocketBeagleAdaptor struct {
 }
 
 // NewPocketBeagleAdaptor creates a new Adaptor for the PocketBeagle
-func NewPocketBeagleAdaptor() *PocketBeagleAdaptor {
-	a := NewAdaptor()
+//
+// Optional params:
+//		beaglebone.WithGpiochip(string):	first gpiochip of the board
+//
+func NewPocketBeagleAdaptor(options ...func(*Adaptor)) *PocketBeagleAdaptor {
+	a := NewAdaptor(options...)
 	a.SetName(gobot.DefaultName("PocketBeagle"))
 	a.pinMap = pocketBeaglePinMap
 	a.pwmPinMap = pocketBeaglePwmPinMap
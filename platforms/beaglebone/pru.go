@@ -0,0 +1,133 @@
+package beaglebone
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"gobot.io/x/gobot/sysfs"
+)
+
+// PRU is one of the two programmable real-time units of the AM335x, which
+// run their firmware independent of the Linux timing. The firmware is loaded
+// from /lib/firmware by the remoteproc framework, the data is exchanged by
+// rpmsg messages.
+type PRU struct {
+	core   int
+	path   string
+	device string
+	rpmsg  sysfs.File
+	mutex  sync.Mutex
+}
+
+// newPRU returns the PRU of the core, which is 0 or 1
+func newPRU(core int, remoteprocPath, rpmsgPath string) *PRU {
+	return &PRU{
+		core:   core,
+		path:   fmt.Sprintf(remoteprocPath, core+1),
+		device: fmt.Sprintf(rpmsgPath, core+30),
+	}
+}
+
+// Core returns the number of the PRU core
+func (p *PRU) Core() int { return p.core }
+
+// State returns the remoteproc state of the PRU, e.g. "offline" or "running"
+func (p *PRU) State() (state string, err error) {
+	fi, err := sysfs.OpenFile(p.path+"/state", os.O_RDONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer fi.Close()
+
+	buf := make([]byte, 32)
+	n, err := fi.Read(buf)
+	if err != nil {
+		return
+	}
+	return strings.TrimSpace(string(buf[:n])), nil
+}
+
+// Load stops the PRU, sets the firmware file and starts the PRU again. The
+// firmware must be located in /lib/firmware.
+func (p *PRU) Load(firmware string) (err error) {
+	if err = p.Stop(); err != nil {
+		return
+	}
+	if err = p.write("firmware", firmware); err != nil {
+		return
+	}
+	return p.Start()
+}
+
+// Start starts the PRU with its current firmware
+func (p *PRU) Start() error {
+	return p.write("state", "start")
+}
+
+// Stop stops the PRU, if it is running, and closes its rpmsg device
+func (p *PRU) Stop() (err error) {
+	if err = p.Close(); err != nil {
+		return
+	}
+	state, err := p.State()
+	if err != nil || state != "running" {
+		return
+	}
+	return p.write("state", "stop")
+}
+
+// Write sends a message to the firmware
+func (p *PRU) Write(msg []byte) (n int, err error) {
+	fi, err := p.rpmsgDevice()
+	if err != nil {
+		return
+	}
+	return fi.Write(msg)
+}
+
+// Read reads a message of the firmware, it blocks until a message is
+// available
+func (p *PRU) Read(msg []byte) (n int, err error) {
+	fi, err := p.rpmsgDevice()
+	if err != nil {
+		return
+	}
+	return fi.Read(msg)
+}
+
+// Close closes the rpmsg device of the PRU
+func (p *PRU) Close() (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.rpmsg != nil {
+		err = p.rpmsg.Close()
+		p.rpmsg = nil
+	}
+	return
+}
+
+// rpmsgDevice returns the rpmsg device, which is opened on first use, because
+// it is created by the firmware
+func (p *PRU) rpmsgDevice() (fi sysfs.File, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.rpmsg == nil {
+		p.rpmsg, err = sysfs.OpenFile(p.device, os.O_RDWR, 0644)
+	}
+	return p.rpmsg, err
+}
+
+func (p *PRU) write(file string, data string) (err error) {
+	fi, err := sysfs.OpenFile(p.path+"/"+file, os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer fi.Close()
+
+	_, err = fi.WriteString(data)
+	return
+}
@@ -0,0 +1,105 @@
+package beaglebone
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"gobot.io/x/gobot"
+)
+
+// speedOfSound is the speed of sound in air at 20°C in cm/ns
+const speedOfSound = 0.0000343
+
+// ErrNoEcho is the error resulting when the ultrasonic sensor does not
+// receive an echo in time
+var ErrNoEcho = errors.New("No echo received")
+
+// PRUPingDriver measures distances with an ultrasonic sensor like the
+// HC-SR04, which is triggered and timed by a PRU firmware, so the echo time
+// is not distorted by the scheduling of Linux.
+//
+// The firmware starts a measurement when it receives a message and answers
+// with the echo time in ns as 4 byte little endian value, which is 0 if no
+// echo was received.
+type PRUPingDriver struct {
+	name     string
+	adaptor  *Adaptor
+	core     int
+	firmware string
+	pru      *PRU
+	gobot.Commander
+}
+
+// NewPRUPingDriver returns a new PRUPingDriver, which loads the firmware
+// to the PRU core.
+//
+// Params:
+//		a *Adaptor - the Adaptor to use with this Driver
+//		core int - the PRU core, 0 or 1
+//		firmware string - the file of the firmware in /lib/firmware
+//
+func NewPRUPingDriver(a *Adaptor, core int, firmware string) *PRUPingDriver {
+	d := &PRUPingDriver{
+		name:      gobot.DefaultName("PRUPing"),
+		adaptor:   a,
+		core:      core,
+		firmware:  firmware,
+		Commander: gobot.NewCommander(),
+	}
+
+	d.AddCommand("Distance", func(params map[string]interface{}) interface{} {
+		val, err := d.Distance()
+		return map[string]interface{}{"val": val, "err": err}
+	})
+	return d
+}
+
+// Name returns the name of the driver
+func (d *PRUPingDriver) Name() string { return d.name }
+
+// SetName sets the name of the driver
+func (d *PRUPingDriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the driver
+func (d *PRUPingDriver) Connection() gobot.Connection { return d.adaptor }
+
+// Start loads the firmware to the PRU
+func (d *PRUPingDriver) Start() (err error) {
+	if d.pru, err = d.adaptor.PRU(d.core); err != nil {
+		return
+	}
+	return d.pru.Load(d.firmware)
+}
+
+// Halt stops the PRU
+func (d *PRUPingDriver) Halt() (err error) {
+	return d.pru.Stop()
+}
+
+// EchoTime measures the time in ns between the trigger and the echo
+func (d *PRUPingDriver) EchoTime() (ns uint32, err error) {
+	if _, err = d.pru.Write([]byte{'p'}); err != nil {
+		return
+	}
+	buf := make([]byte, 4)
+	n, err := d.pru.Read(buf)
+	if err != nil {
+		return
+	}
+	if n < len(buf) {
+		return 0, errors.New("Message of the PRU too short")
+	}
+	if ns = binary.LittleEndian.Uint32(buf); ns == 0 {
+		return 0, ErrNoEcho
+	}
+	return
+}
+
+// Distance measures the distance in cm
+func (d *PRUPingDriver) Distance() (cm float64, err error) {
+	ns, err := d.EchoTime()
+	if err != nil {
+		return
+	}
+	return float64(ns) * speedOfSound / 2, nil
+}
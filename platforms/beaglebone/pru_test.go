@@ -0,0 +1,96 @@
+package beaglebone
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/sysfs"
+)
+
+var _ gobot.Driver = (*PRUPingDriver)(nil)
+
+func initTestPRUFilesystem() *sysfs.MockFilesystem {
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/remoteproc/remoteproc1/state",
+		"/sys/class/remoteproc/remoteproc1/firmware",
+		"/dev/rpmsg_pru30",
+	})
+	fs.Files["/sys/class/remoteproc/remoteproc1/state"].Contents = "offline\n"
+	sysfs.SetFilesystem(fs)
+	return fs
+}
+
+func TestBeaglebonePRU(t *testing.T) {
+	fs := initTestPRUFilesystem()
+	a := NewAdaptor()
+
+	_, err := a.PRU(2)
+	gobottest.Assert(t, err, errors.New("PRU core 2 out of range"))
+
+	pru, err := a.PRU(0)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pru.Core(), 0)
+	state, _ := pru.State()
+	gobottest.Assert(t, state, "offline")
+
+	gobottest.Assert(t, pru.Load("am335x-pru0-fw"), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/remoteproc/remoteproc1/firmware"].Contents, "am335x-pru0-fw")
+	gobottest.Assert(t, fs.Files["/sys/class/remoteproc/remoteproc1/state"].Contents, "start")
+
+	// the firmware answers
+	fs.Files["/sys/class/remoteproc/remoteproc1/state"].Contents = "running\n"
+	rpmsg := fs.Files["/dev/rpmsg_pru30"]
+	_, err = pru.Write([]byte("hi"))
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, rpmsg.Contents, "hi")
+	rpmsg.Schedule(0, "ho")
+	msg := make([]byte, 2)
+	n, err := pru.Read(msg)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, string(msg[:n]), "ho")
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/remoteproc/remoteproc1/state"].Contents, "stop")
+}
+
+func TestBeaglebonePRUNoRemoteproc(t *testing.T) {
+	sysfs.SetFilesystem(sysfs.NewMockFilesystem([]string{}))
+	a := NewAdaptor()
+	pru, _ := a.PRU(1)
+	gobottest.Refute(t, pru.Load("am335x-pru1-fw"), nil)
+	_, err := pru.Write([]byte("hi"))
+	gobottest.Refute(t, err, nil)
+}
+
+func TestPRUPingDriver(t *testing.T) {
+	fs := initTestPRUFilesystem()
+	d := NewPRUPingDriver(NewAdaptor(), 0, "am335x-pru0-ping-fw")
+	gobottest.Assert(t, strings.HasPrefix(d.Name(), "PRUPing"), true)
+	d.SetName("ping")
+	gobottest.Assert(t, d.Name(), "ping")
+	gobottest.Refute(t, d.Connection(), nil)
+
+	gobottest.Assert(t, d.Start(), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/remoteproc/remoteproc1/firmware"].Contents, "am335x-pru0-ping-fw")
+
+	// an echo after 1ms
+	rpmsg := fs.Files["/dev/rpmsg_pru30"]
+	rpmsg.Schedule(0, "\x40\x42\x0F\x00")
+	cm, err := d.Distance()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, cm, 17.15)
+	gobottest.Assert(t, rpmsg.Contents, "\x40\x42\x0F\x00")
+
+	rpmsg.Schedule(0, "\x00\x00\x00\x00")
+	_, err = d.Distance()
+	gobottest.Assert(t, err, ErrNoEcho)
+
+	rpmsg.Schedule(0, "\x01")
+	ret := d.Command("Distance")(nil).(map[string]interface{})
+	gobottest.Assert(t, ret["err"], errors.New("Message of the PRU too short"))
+
+	gobottest.Assert(t, d.Halt(), nil)
+}
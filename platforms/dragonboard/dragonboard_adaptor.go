@@ -13,11 +13,12 @@ import (
 
 // Adaptor represents a Gobot Adaptor for a DragonBoard 410c
 type Adaptor struct {
-	name        string
-	digitalPins map[int]*sysfs.DigitalPin
-	pinMap      map[string]int
-	i2cBuses    [3]i2c.I2cDevice
-	mutex       *sync.Mutex
+	name           string
+	digitalPins    map[int]*sysfs.DigitalPin
+	pinMap         map[string]int
+	i2cBuses       [3]i2c.I2cDevice
+	i2cConnections i2c.Connections
+	mutex          *sync.Mutex
 }
 
 var fixedPins = map[string]int{
@@ -65,18 +66,21 @@ func (c *Adaptor) Finalize() (err error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	for _, pin := range c.digitalPins {
+	for i, pin := range c.digitalPins {
 		if pin != nil {
 			if e := pin.Unexport(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.digitalPins[i] = nil
 		}
 	}
-	for _, bus := range c.i2cBuses {
+	c.i2cConnections.Close()
+	for i, bus := range c.i2cBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.i2cBuses[i] = nil
 		}
 	}
 	return
@@ -143,7 +147,10 @@ func (c *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 	if c.i2cBuses[bus] == nil {
 		c.i2cBuses[bus], err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
 	}
-	return i2c.NewConnection(c.i2cBuses[bus], address), err
+	if err != nil {
+		return nil, err
+	}
+	return c.i2cConnections.Get(c.i2cBuses[bus], address), nil
 }
 
 // GetDefaultBus returns the default i2c bus for this platform
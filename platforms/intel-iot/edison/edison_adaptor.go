@@ -28,17 +28,18 @@ type sysfsPin struct {
 
 // Adaptor represents a Gobot Adaptor for an Intel Edison
 type Adaptor struct {
-	name        string
-	board       string
-	pinmap      map[string]sysfsPin
-	tristate    *sysfs.DigitalPin
-	digitalPins map[int]*sysfs.DigitalPin
-	pwmPins     map[int]*sysfs.PWMPin
-	i2cBus      i2c.I2cDevice
-	connect     func(e *Adaptor) (err error)
-	writeFile   func(path string, data []byte) (i int, err error)
-	readFile    func(path string) ([]byte, error)
-	mutex       *sync.Mutex
+	name           string
+	board          string
+	pinmap         map[string]sysfsPin
+	tristate       *sysfs.DigitalPin
+	digitalPins    map[int]*sysfs.DigitalPin
+	pwmPins        map[int]*sysfs.PWMPin
+	i2cBus         i2c.I2cDevice
+	i2cConnections i2c.Connections
+	connect        func(e *Adaptor) (err error)
+	writeFile      func(path string, data []byte) (i int, err error)
+	readFile       func(path string) ([]byte, error)
+	mutex          *sync.Mutex
 }
 
 // NewAdaptor returns a new Edison Adaptor
@@ -98,17 +99,21 @@ func (e *Adaptor) Connect() (err error) {
 
 // Finalize releases all i2c devices and exported analog, digital, pwm pins.
 func (e *Adaptor) Finalize() (err error) {
-	if errs := e.tristate.Unexport(); errs != nil {
-		err = multierror.Append(err, errs)
+	if e.tristate != nil {
+		if errs := e.tristate.Unexport(); errs != nil {
+			err = multierror.Append(err, errs)
+		}
+		e.tristate = nil
 	}
-	for _, pin := range e.digitalPins {
+	for i, pin := range e.digitalPins {
 		if pin != nil {
 			if errs := pin.Unexport(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			e.digitalPins[i] = nil
 		}
 	}
-	for _, pin := range e.pwmPins {
+	for i, pin := range e.pwmPins {
 		if pin != nil {
 			if errs := pin.Enable(false); errs != nil {
 				err = multierror.Append(err, errs)
@@ -116,12 +121,15 @@ func (e *Adaptor) Finalize() (err error) {
 			if errs := pin.Unexport(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			e.pwmPins[i] = nil
 		}
 	}
+	e.i2cConnections.Close()
 	if e.i2cBus != nil {
 		if errs := e.i2cBus.Close(); errs != nil {
 			err = multierror.Append(err, errs)
 		}
+		e.i2cBus = nil
 	}
 	return
 }
@@ -187,7 +195,10 @@ func (e *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 		}
 		e.i2cBus, err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
 	}
-	return i2c.NewConnection(e.i2cBus, address), err
+	if err != nil {
+		return nil, err
+	}
+	return e.i2cConnections.Get(e.i2cBus, address), nil
 }
 
 // GetDefaultBus returns the default i2c bus for this platform
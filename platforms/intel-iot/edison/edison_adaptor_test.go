@@ -247,8 +247,11 @@ func TestAdaptorFinalize(t *testing.T) {
 	sysfs.SetSyscall(&sysfs.MockSyscall{})
 	a.GetConnection(0xff, 6)
 
+	gobottest.Assert(t, a.Finalize(), nil)
+	// the resources are released only once
 	gobottest.Assert(t, a.Finalize(), nil)
 
+	a.DigitalWrite("3", 1)
 	sysfs.SetFilesystem(sysfs.NewMockFilesystem([]string{}))
 	gobottest.Refute(t, a.Finalize(), nil)
 }
@@ -18,12 +18,13 @@ type sysfsPin struct {
 
 // Adaptor represents an Intel Joule
 type Adaptor struct {
-	name        string
-	digitalPins map[int]*sysfs.DigitalPin
-	pwmPins     map[int]*sysfs.PWMPin
-	i2cBuses    [3]i2c.I2cDevice
-	connect     func(e *Adaptor) (err error)
-	mutex       *sync.Mutex
+	name           string
+	digitalPins    map[int]*sysfs.DigitalPin
+	pwmPins        map[int]*sysfs.PWMPin
+	i2cBuses       [3]i2c.I2cDevice
+	i2cConnections i2c.Connections
+	connect        func(e *Adaptor) (err error)
+	mutex          *sync.Mutex
 }
 
 // NewAdaptor returns a new Joule Adaptor
@@ -59,14 +60,15 @@ func (e *Adaptor) Finalize() (err error) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
-	for _, pin := range e.digitalPins {
+	for i, pin := range e.digitalPins {
 		if pin != nil {
 			if errs := pin.Unexport(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			e.digitalPins[i] = nil
 		}
 	}
-	for _, pin := range e.pwmPins {
+	for i, pin := range e.pwmPins {
 		if pin != nil {
 			if errs := pin.Enable(false); errs != nil {
 				err = multierror.Append(err, errs)
@@ -74,13 +76,16 @@ func (e *Adaptor) Finalize() (err error) {
 			if errs := pin.Unexport(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			e.pwmPins[i] = nil
 		}
 	}
-	for _, bus := range e.i2cBuses {
+	e.i2cConnections.Close()
+	for i, bus := range e.i2cBuses {
 		if bus != nil {
 			if errs := bus.Close(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			e.i2cBuses[i] = nil
 		}
 	}
 	return
@@ -183,7 +188,10 @@ func (e *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 	if e.i2cBuses[bus] == nil {
 		e.i2cBuses[bus], err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
 	}
-	return i2c.NewConnection(e.i2cBuses[bus], address), err
+	if err != nil {
+		return nil, err
+	}
+	return e.i2cConnections.Get(e.i2cBuses[bus], address), nil
 }
 
 // GetDefaultBus returns the default i2c bus for this platform
@@ -125,6 +125,7 @@ func TestAdaptorFinalize(t *testing.T) {
 	gobottest.Assert(t, err, nil)
 	gobottest.Assert(t, a.Finalize(), nil)
 
+	a.DigitalWrite("J12_1", 1)
 	sysfs.SetFilesystem(sysfs.NewMockFilesystem([]string{}))
 	gobottest.Refute(t, a.Finalize(), nil)
 }
@@ -23,7 +23,7 @@ Example:
 		work := func() {
 			drone.TakeOff()
 			drone.On(drone.Event("flying"), func(data interface{}) {
-				gobot.After(3*time.Second, func() {
+				gobot.AfterTimer(3*time.Second, func() {
 					drone.Land()
 				})
 			})
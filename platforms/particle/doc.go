@@ -22,7 +22,7 @@ Example:
 		led := gpio.NewLedDriver(core, "D7")
 
 		work := func() {
-			gobot.Every(1*time.Second, func() {
+			gobot.EveryTimer(1*time.Second, func() {
 				led.Toggle()
 			})
 		}
@@ -8,6 +8,7 @@ type Driver struct {
 	name       string
 	connection gobot.Connection
 	gobot.Commander
+	gobot.CommandSchemer
 	gobot.Eventer
 	Messages []string
 }
@@ -23,11 +24,12 @@ type Driver struct {
 //		"pending_message"
 func NewDriver(adaptor *Adaptor) *Driver {
 	p := &Driver{
-		name:       "Pebble",
-		connection: adaptor,
-		Messages:   []string{},
-		Eventer:    gobot.NewEventer(),
-		Commander:  gobot.NewCommander(),
+		name:           "Pebble",
+		connection:     adaptor,
+		Messages:       []string{},
+		Eventer:        gobot.NewEventer(),
+		Commander:      gobot.NewCommander(),
+		CommandSchemer: gobot.NewCommandSchemer(),
 	}
 
 	p.AddEvent("button")
@@ -38,11 +40,13 @@ func NewDriver(adaptor *Adaptor) *Driver {
 		p.PublishEvent(params["name"].(string), params["data"].(string))
 		return nil
 	})
+	p.AddCommandSchema("publish_event", gobot.CommandSchema{"name": gobot.CommandParamString, "data": gobot.CommandParamString})
 
 	p.AddCommand("send_notification", func(params map[string]interface{}) interface{} {
 		p.SendNotification(params["message"].(string))
 		return nil
 	})
+	p.AddCommandSchema("send_notification", gobot.CommandSchema{"message": gobot.CommandParamString})
 
 	p.AddCommand("pending_message", func(params map[string]interface{}) interface{} {
 		return p.PendingMessage()
@@ -68,4 +68,7 @@ func TestDriver(t *testing.T) {
 
 	message := d.Command("pending_message")(map[string]interface{}{})
 	gobottest.Assert(t, message, "Hey buddy!")
+
+	gobottest.Assert(t, d.CommandSchema("send_notification").Validate(map[string]interface{}{"message": 1.0}),
+		map[string]string{"message": "must be of type string"})
 }
@@ -0,0 +1,319 @@
+package pigpiod
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+)
+
+// DefaultPort is the port the pigpio daemon listens on by default
+const DefaultPort = "8888"
+
+// the commands of the pigpio socket interface
+const (
+	cmdModes = 0
+	cmdRead  = 3
+	cmdWrite = 4
+	cmdPwm   = 5
+	cmdServo = 8
+	cmdI2CO  = 54
+	cmdI2CC  = 55
+	cmdI2CRD = 56
+	cmdI2CWD = 57
+	cmdI2CRS = 59
+	cmdI2CWS = 60
+	cmdI2CRB = 61
+	cmdI2CWB = 62
+	cmdI2CRW = 63
+	cmdI2CWW = 64
+	cmdI2CWI = 68
+	cmdSPIO  = 71
+	cmdSPIC  = 72
+	cmdSPIR  = 73
+	cmdSPIW  = 74
+	cmdSPIX  = 75
+)
+
+// the modes of a GPIO
+const (
+	modeInput  = 0
+	modeOutput = 1
+)
+
+// commands which answer with the data of the length of their result
+var extendedResult = map[uint32]bool{
+	cmdI2CRD: true,
+	cmdSPIR:  true,
+	cmdSPIX:  true,
+}
+
+var dial = func(address string) (io.ReadWriteCloser, error) {
+	return net.Dial("tcp", address)
+}
+
+// Adaptor is the Gobot Adaptor for a Raspberry Pi, which is driven by the
+// pigpio daemon over the network. The pins are the BCM GPIO numbers, e.g.
+// "17" for header pin 11.
+type Adaptor struct {
+	name    string
+	address string
+	conn    io.ReadWriteCloser
+	modes   map[uint32]uint32
+	i2cDevs map[[2]int]*i2cConnection
+	spiDevs map[int]*spiConnection
+	mutex   sync.Mutex
+}
+
+// NewAdaptor returns a new pigpiod Adaptor for the host, which is given as
+// "host" or "host:port"
+func NewAdaptor(host string) *Adaptor {
+	address := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		address = net.JoinHostPort(host, DefaultPort)
+	}
+	return &Adaptor{
+		name:    gobot.DefaultName("pigpiod"),
+		address: address,
+		modes:   make(map[uint32]uint32),
+		i2cDevs: make(map[[2]int]*i2cConnection),
+		spiDevs: make(map[int]*spiConnection),
+	}
+}
+
+// Name returns the name of the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Port returns the address of the pigpio daemon
+func (a *Adaptor) Port() string { return a.address }
+
+// Connect connects to the pigpio daemon
+func (a *Adaptor) Connect() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.conn, err = dial(a.address)
+	return
+}
+
+// Finalize closes the i2c and SPI handles and the connection to the daemon
+func (a *Adaptor) Finalize() (err error) {
+	a.mutex.Lock()
+	i2cDevs, spiDevs := a.i2cDevs, a.spiDevs
+	a.i2cDevs = make(map[[2]int]*i2cConnection)
+	a.spiDevs = make(map[int]*spiConnection)
+	a.mutex.Unlock()
+
+	for _, c := range i2cDevs {
+		if e := c.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	for _, c := range spiDevs {
+		if e := c.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.conn != nil {
+		if e := a.conn.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+		a.conn = nil
+	}
+	return
+}
+
+// DigitalRead reads the level of the GPIO
+func (a *Adaptor) DigitalRead(pin string) (val int, err error) {
+	gpio, err := a.setMode(pin, modeInput)
+	if err != nil {
+		return
+	}
+	res, _, err := a.command(cmdRead, gpio, 0, nil)
+	return int(res), err
+}
+
+// DigitalWrite writes the level to the GPIO
+func (a *Adaptor) DigitalWrite(pin string, val byte) (err error) {
+	gpio, err := a.setMode(pin, modeOutput)
+	if err != nil {
+		return
+	}
+	_, _, err = a.command(cmdWrite, gpio, uint32(val), nil)
+	return
+}
+
+// PwmWrite writes the PWM value (0-255) to the GPIO, the signal is timed by
+// the DMA of the Raspberry Pi
+func (a *Adaptor) PwmWrite(pin string, val byte) (err error) {
+	gpio, err := a.setMode(pin, modeOutput)
+	if err != nil {
+		return
+	}
+	_, _, err = a.command(cmdPwm, gpio, uint32(val), nil)
+	return
+}
+
+// ServoWrite writes the servo angle (0-180) as a pulse of 0.5-2.5ms to the
+// GPIO
+func (a *Adaptor) ServoWrite(pin string, angle byte) (err error) {
+	gpio, err := a.setMode(pin, modeOutput)
+	if err != nil {
+		return
+	}
+	width := 500 + uint32(gobot.FromScale(float64(angle), 0, 180)*2000)
+	_, _, err = a.command(cmdServo, gpio, width, nil)
+	return
+}
+
+// GetConnection returns a connection to the device at the address on the
+// i2c bus of the Raspberry Pi
+func (a *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
+	key := [2]int{bus, address}
+	a.mutex.Lock()
+	c, ok := a.i2cDevs[key]
+	a.mutex.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	res, _, err := a.command(cmdI2CO, uint32(bus), uint32(address), uint32le(0))
+	if err != nil {
+		return
+	}
+	c = &i2cConnection{adaptor: a, handle: res}
+
+	a.mutex.Lock()
+	a.i2cDevs[key] = c
+	a.mutex.Unlock()
+	return c, nil
+}
+
+// GetDefaultBus returns the default i2c bus of the Raspberry Pi
+func (a *Adaptor) GetDefaultBus() int { return 1 }
+
+// GetSpiConnection returns a connection to the device at the chip on the
+// main SPI bus of the Raspberry Pi, which is the only bus supported by this
+// adaptor
+func (a *Adaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (connection spi.Connection, err error) {
+	if busNum != 0 {
+		return nil, fmt.Errorf("Bus number %d out of range", busNum)
+	}
+	if bits != 8 {
+		return nil, errors.New("Only 8 bits per word are supported")
+	}
+
+	a.mutex.Lock()
+	c, ok := a.spiDevs[chipNum]
+	a.mutex.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	res, _, err := a.command(cmdSPIO, uint32(chipNum), uint32(maxSpeed), uint32le(uint32(mode&3)))
+	if err != nil {
+		return
+	}
+	c = &spiConnection{adaptor: a, handle: res}
+
+	a.mutex.Lock()
+	a.spiDevs[chipNum] = c
+	a.mutex.Unlock()
+	return c, nil
+}
+
+// GetSpiDefaultBus returns the default SPI bus
+func (a *Adaptor) GetSpiDefaultBus() int { return 0 }
+
+// GetSpiDefaultChip returns the default SPI chip
+func (a *Adaptor) GetSpiDefaultChip() int { return 0 }
+
+// GetSpiDefaultMode returns the default SPI mode
+func (a *Adaptor) GetSpiDefaultMode() int { return 0 }
+
+// GetSpiDefaultBits returns the default SPI number of bits
+func (a *Adaptor) GetSpiDefaultBits() int { return 8 }
+
+// GetSpiDefaultMaxSpeed returns the default SPI max speed
+func (a *Adaptor) GetSpiDefaultMaxSpeed() int64 { return 500000 }
+
+// setMode sets the mode of the GPIO, if it has changed
+func (a *Adaptor) setMode(pin string, mode uint32) (gpio uint32, err error) {
+	i, err := strconv.Atoi(pin)
+	if err != nil || i < 0 || i > 53 {
+		return 0, errors.New("Not a valid pin")
+	}
+	gpio = uint32(i)
+
+	a.mutex.Lock()
+	current, ok := a.modes[gpio]
+	a.mutex.Unlock()
+	if ok && current == mode {
+		return
+	}
+
+	if _, _, err = a.command(cmdModes, gpio, mode, nil); err != nil {
+		return
+	}
+	a.mutex.Lock()
+	a.modes[gpio] = mode
+	a.mutex.Unlock()
+	return
+}
+
+// command sends the command with its parameters and extension to the daemon
+// and returns the result, which is the length of the data for commands with
+// an extended result
+func (a *Adaptor) command(cmd, p1, p2 uint32, ext []byte) (res uint32, data []byte, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.conn == nil {
+		return 0, nil, errors.New("Not connected to pigpiod")
+	}
+
+	msg := make([]byte, 16, 16+len(ext))
+	binary.LittleEndian.PutUint32(msg[0:], cmd)
+	binary.LittleEndian.PutUint32(msg[4:], p1)
+	binary.LittleEndian.PutUint32(msg[8:], p2)
+	binary.LittleEndian.PutUint32(msg[12:], uint32(len(ext)))
+	if _, err = a.conn.Write(append(msg, ext...)); err != nil {
+		return
+	}
+
+	resp := make([]byte, 16)
+	if _, err = io.ReadFull(a.conn, resp); err != nil {
+		return
+	}
+	result := int32(binary.LittleEndian.Uint32(resp[12:]))
+	if result < 0 {
+		return 0, nil, fmt.Errorf("pigpiod command %d failed with error %d", cmd, result)
+	}
+	res = uint32(result)
+
+	if extendedResult[cmd] && res > 0 {
+		data = make([]byte, res)
+		_, err = io.ReadFull(a.conn, data)
+	}
+	return
+}
+
+func uint32le(val uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, val)
+	return b
+}
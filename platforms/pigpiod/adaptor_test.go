@@ -0,0 +1,209 @@
+package pigpiod
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// make sure that this Adaptor fullfills all the required interfaces
+var _ gobot.Adaptor = (*Adaptor)(nil)
+var _ gobot.Porter = (*Adaptor)(nil)
+var _ gpio.DigitalReader = (*Adaptor)(nil)
+var _ gpio.DigitalWriter = (*Adaptor)(nil)
+var _ gpio.PwmWriter = (*Adaptor)(nil)
+var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ i2c.Connector = (*Adaptor)(nil)
+var _ spi.Connector = (*Adaptor)(nil)
+
+// pigpiodTestCommand is a command received by the test daemon
+type pigpiodTestCommand struct {
+	cmd, p1, p2 uint32
+	ext         []byte
+}
+
+// pigpiodTestDaemon answers the commands with the result and data of the
+// respond function
+type pigpiodTestDaemon struct {
+	commands []pigpiodTestCommand
+	out      bytes.Buffer
+	closed   bool
+	respond  func(c pigpiodTestCommand) (res int32, data []byte)
+}
+
+func (d *pigpiodTestDaemon) Write(b []byte) (n int, err error) {
+	c := pigpiodTestCommand{
+		cmd: binary.LittleEndian.Uint32(b[0:]),
+		p1:  binary.LittleEndian.Uint32(b[4:]),
+		p2:  binary.LittleEndian.Uint32(b[8:]),
+		ext: b[16 : 16+binary.LittleEndian.Uint32(b[12:])],
+	}
+	d.commands = append(d.commands, c)
+
+	var res int32
+	var data []byte
+	if d.respond != nil {
+		res, data = d.respond(c)
+	}
+	resp := make([]byte, 16)
+	copy(resp, b[:12])
+	binary.LittleEndian.PutUint32(resp[12:], uint32(res))
+	d.out.Write(resp)
+	d.out.Write(data)
+	return len(b), nil
+}
+
+func (d *pigpiodTestDaemon) Read(b []byte) (n int, err error) { return d.out.Read(b) }
+
+func (d *pigpiodTestDaemon) Close() error {
+	d.closed = true
+	return nil
+}
+
+func (d *pigpiodTestDaemon) last() pigpiodTestCommand {
+	return d.commands[len(d.commands)-1]
+}
+
+func initTestAdaptor() (*Adaptor, *pigpiodTestDaemon) {
+	d := &pigpiodTestDaemon{}
+	dial = func(address string) (io.ReadWriteCloser, error) {
+		return d, nil
+	}
+	a := NewAdaptor("raspberrypi.local")
+	a.Connect()
+	return a, d
+}
+
+func TestPigpiodAdaptor(t *testing.T) {
+	a := NewAdaptor("raspberrypi.local")
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "pigpiod"), true)
+	gobottest.Assert(t, a.Port(), "raspberrypi.local:8888")
+	a.SetName("pi")
+	gobottest.Assert(t, a.Name(), "pi")
+
+	gobottest.Assert(t, NewAdaptor("10.0.0.2:9999").Port(), "10.0.0.2:9999")
+
+	gobottest.Assert(t, a.DigitalWrite("17", 1), errors.New("Not connected to pigpiod"))
+	dial = func(address string) (io.ReadWriteCloser, error) {
+		return nil, errors.New("connection refused")
+	}
+	gobottest.Assert(t, a.Connect(), errors.New("connection refused"))
+}
+
+func TestPigpiodAdaptorDigitalIO(t *testing.T) {
+	a, d := initTestAdaptor()
+	gobottest.Assert(t, a.DigitalWrite("17", 1), nil)
+	gobottest.Assert(t, d.commands, []pigpiodTestCommand{
+		{cmd: cmdModes, p1: 17, p2: modeOutput, ext: []byte{}},
+		{cmd: cmdWrite, p1: 17, p2: 1, ext: []byte{}},
+	})
+
+	// the mode is set only once
+	gobottest.Assert(t, a.DigitalWrite("17", 0), nil)
+	gobottest.Assert(t, len(d.commands), 3)
+
+	d.respond = func(c pigpiodTestCommand) (int32, []byte) { return 1, nil }
+	val, err := a.DigitalRead("17")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+	gobottest.Assert(t, d.commands[3], pigpiodTestCommand{cmd: cmdModes, p1: 17, p2: modeInput, ext: []byte{}})
+
+	gobottest.Assert(t, a.DigitalWrite("99", 1), errors.New("Not a valid pin"))
+
+	d.respond = func(c pigpiodTestCommand) (int32, []byte) { return -41, nil }
+	gobottest.Assert(t, a.DigitalWrite("18", 1), errors.New("pigpiod command 0 failed with error -41"))
+}
+
+func TestPigpiodAdaptorPwm(t *testing.T) {
+	a, d := initTestAdaptor()
+	gobottest.Assert(t, a.PwmWrite("18", 128), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdPwm, p1: 18, p2: 128, ext: []byte{}})
+
+	gobottest.Assert(t, a.ServoWrite("18", 90), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdServo, p1: 18, p2: 1500, ext: []byte{}})
+}
+
+func TestPigpiodAdaptorI2c(t *testing.T) {
+	a, d := initTestAdaptor()
+	d.respond = func(c pigpiodTestCommand) (int32, []byte) {
+		switch c.cmd {
+		case cmdI2CO:
+			return 3, nil
+		case cmdI2CRD:
+			return 2, []byte{0x12, 0x34}
+		case cmdI2CRW:
+			return 0x1234, nil
+		}
+		return 0, nil
+	}
+
+	gobottest.Assert(t, a.GetDefaultBus(), 1)
+	conn, err := a.GetConnection(0x40, 1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdI2CO, p1: 1, p2: 0x40, ext: []byte{0, 0, 0, 0}})
+	same, _ := a.GetConnection(0x40, 1)
+	gobottest.Assert(t, same, conn)
+
+	b := make([]byte, 2)
+	n, err := conn.Read(b)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 2)
+	gobottest.Assert(t, b, []byte{0x12, 0x34})
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdI2CRD, p1: 3, p2: 2, ext: []byte{}})
+
+	word, _ := conn.ReadWordData(0x10)
+	gobottest.Assert(t, word, uint16(0x1234))
+
+	gobottest.Assert(t, conn.WriteByteData(0x20, 0xAB), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdI2CWB, p1: 3, p2: 0x20, ext: []byte{0xAB, 0, 0, 0}})
+
+	gobottest.Assert(t, conn.WriteBlockData(0x30, []byte{1, 2}), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdI2CWI, p1: 3, p2: 0x30, ext: []byte{1, 2}})
+	gobottest.Refute(t, conn.WriteBlockData(0x30, make([]byte, 33)), nil)
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdI2CC, p1: 3, ext: []byte{}})
+	gobottest.Assert(t, d.closed, true)
+}
+
+func TestPigpiodAdaptorSpi(t *testing.T) {
+	a, d := initTestAdaptor()
+	d.respond = func(c pigpiodTestCommand) (int32, []byte) {
+		switch c.cmd {
+		case cmdSPIO:
+			return 1, nil
+		case cmdSPIX:
+			return int32(len(c.ext)), []byte{0xA, 0xB}
+		}
+		return 0, nil
+	}
+
+	_, err := a.GetSpiConnection(1, 0, 0, 8, 500000)
+	gobottest.Assert(t, err, errors.New("Bus number 1 out of range"))
+
+	conn, err := a.GetSpiConnection(0, 1, 3, 8, 1000000)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdSPIO, p1: 1, p2: 1000000, ext: []byte{3, 0, 0, 0}})
+
+	r := make([]byte, 2)
+	gobottest.Assert(t, conn.Tx([]byte{1, 2}, r), nil)
+	gobottest.Assert(t, r, []byte{0xA, 0xB})
+	gobottest.Assert(t, conn.Tx([]byte{1, 2}, make([]byte, 1)),
+		errors.New("Length of write (2) and read (1) buffer differ"))
+
+	gobottest.Assert(t, conn.Tx([]byte{1, 2}, nil), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdSPIW, p1: 1, ext: []byte{1, 2}})
+
+	gobottest.Assert(t, conn.Close(), nil)
+	gobottest.Assert(t, d.last(), pigpiodTestCommand{cmd: cmdSPIC, p1: 1, ext: []byte{}})
+	gobottest.Assert(t, len(a.spiDevs), 0)
+}
@@ -0,0 +1,118 @@
+package pigpiod
+
+import (
+	"fmt"
+)
+
+// i2cConnection is the handle of a device on an i2c bus of the daemon
+type i2cConnection struct {
+	adaptor *Adaptor
+	handle  uint32
+}
+
+// Read reads len(b) bytes from the device
+func (c *i2cConnection) Read(b []byte) (n int, err error) {
+	_, data, err := c.adaptor.command(cmdI2CRD, c.handle, uint32(len(b)), nil)
+	return copy(b, data), err
+}
+
+// Write writes b to the device
+func (c *i2cConnection) Write(b []byte) (n int, err error) {
+	if _, _, err = c.adaptor.command(cmdI2CWD, c.handle, 0, b); err != nil {
+		return
+	}
+	return len(b), nil
+}
+
+// Close closes the handle of the device
+func (c *i2cConnection) Close() (err error) {
+	c.adaptor.mutex.Lock()
+	for key, dev := range c.adaptor.i2cDevs {
+		if dev == c {
+			delete(c.adaptor.i2cDevs, key)
+		}
+	}
+	c.adaptor.mutex.Unlock()
+
+	_, _, err = c.adaptor.command(cmdI2CC, c.handle, 0, nil)
+	return
+}
+
+func (c *i2cConnection) ReadByte() (val byte, err error) {
+	res, _, err := c.adaptor.command(cmdI2CRS, c.handle, 0, nil)
+	return byte(res), err
+}
+
+func (c *i2cConnection) ReadByteData(reg uint8) (val uint8, err error) {
+	res, _, err := c.adaptor.command(cmdI2CRB, c.handle, uint32(reg), nil)
+	return uint8(res), err
+}
+
+func (c *i2cConnection) ReadWordData(reg uint8) (val uint16, err error) {
+	res, _, err := c.adaptor.command(cmdI2CRW, c.handle, uint32(reg), nil)
+	return uint16(res), err
+}
+
+func (c *i2cConnection) WriteByte(val byte) (err error) {
+	_, _, err = c.adaptor.command(cmdI2CWS, c.handle, uint32(val), nil)
+	return
+}
+
+func (c *i2cConnection) WriteByteData(reg uint8, val uint8) (err error) {
+	_, _, err = c.adaptor.command(cmdI2CWB, c.handle, uint32(reg), uint32le(uint32(val)))
+	return
+}
+
+func (c *i2cConnection) WriteWordData(reg uint8, val uint16) (err error) {
+	_, _, err = c.adaptor.command(cmdI2CWW, c.handle, uint32(reg), uint32le(uint32(val)))
+	return
+}
+
+// WriteBlockData writes the register and the data in one transfer
+func (c *i2cConnection) WriteBlockData(reg uint8, b []byte) (err error) {
+	if len(b) > 32 {
+		return fmt.Errorf("Writing blocks larger than 32 bytes (%v) not supported", len(b))
+	}
+	_, _, err = c.adaptor.command(cmdI2CWI, c.handle, uint32(reg), b)
+	return
+}
+
+// spiConnection is the handle of a device on the main SPI bus of the daemon
+type spiConnection struct {
+	adaptor *Adaptor
+	handle  uint32
+}
+
+// Close closes the handle of the device
+func (c *spiConnection) Close() (err error) {
+	c.adaptor.mutex.Lock()
+	for chip, dev := range c.adaptor.spiDevs {
+		if dev == c {
+			delete(c.adaptor.spiDevs, chip)
+		}
+	}
+	c.adaptor.mutex.Unlock()
+
+	_, _, err = c.adaptor.command(cmdSPIC, c.handle, 0, nil)
+	return
+}
+
+// Tx writes w and reads r in a single full-duplex transfer
+func (c *spiConnection) Tx(w, r []byte) (err error) {
+	switch {
+	case r == nil:
+		_, _, err = c.adaptor.command(cmdSPIW, c.handle, 0, w)
+	case w == nil:
+		var data []byte
+		_, data, err = c.adaptor.command(cmdSPIR, c.handle, uint32(len(r)), nil)
+		copy(r, data)
+	default:
+		if len(w) != len(r) {
+			return fmt.Errorf("Length of write (%d) and read (%d) buffer differ", len(w), len(r))
+		}
+		var data []byte
+		_, data, err = c.adaptor.command(cmdSPIX, c.handle, 0, w)
+		copy(r, data)
+	}
+	return
+}
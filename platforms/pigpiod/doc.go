@@ -0,0 +1,10 @@
+/*
+Package pigpiod contains the Gobot adaptor for a remote Raspberry Pi, which
+is driven by the pigpio daemon over the network. The GPIOs, the hardware
+timed PWM and servo pulses, i2c and SPI of the Raspberry Pi are used by a
+Gobot program running on another computer.
+
+For further information refer to pigpiod README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/pigpiod/README.md
+*/
+package pigpiod // import "gobot.io/x/gobot/platforms/pigpiod"
@@ -29,16 +29,35 @@ type Adaptor struct {
 	pwmPins            map[int]*PWMPin
 	i2cDefaultBus      int
 	i2cBuses           [2]i2c.I2cDevice
+	i2cConnections     i2c.Connections
 	spiDefaultBus      int
 	spiDefaultChip     int
 	spiDevices         [2]spi.Connection
 	spiDefaultMode     int
 	spiDefaultMaxSpeed int64
 	PiBlasterPeriod    uint32
+	// the gpiochip of the header pins and its first GPIO, see WithGpiochip
+	gpiochip     string
+	gpiochipBase int
+	ws2812Output func(data []byte) error
+}
+
+// WithGpiochip option sets the gpiochip of the header pins, given by its
+// label or sysfs name, e.g. "pinctrl-rp1" for the Raspberry Pi 5. By default
+// the header pins are the GPIOs from 0 on.
+func WithGpiochip(chip string) func(*Adaptor) {
+	return func(r *Adaptor) {
+		r.gpiochip = chip
+	}
 }
 
 // NewAdaptor creates a Raspi Adaptor
-func NewAdaptor() *Adaptor {
+//
+// Optional params:
+//		raspi.WithGpiochip(string):	gpiochip of the header pins
+//		raspi.WithWS2812Output(func([]byte) error):	output for WS2812 LEDs
+//
+func NewAdaptor(options ...func(*Adaptor)) *Adaptor {
 	r := &Adaptor{
 		mutex:           &sync.Mutex{},
 		name:            gobot.DefaultName("RaspberryPi"),
@@ -67,6 +86,10 @@ func NewAdaptor() *Adaptor {
 		}
 	}
 
+	for _, option := range options {
+		option(r)
+	}
+
 	return r
 }
 
@@ -86,9 +109,15 @@ func (r *Adaptor) SetName(n string) {
 	r.name = n
 }
 
-// Connect starts connection with board and creates
-// digitalPins and pwmPins adaptor maps
+// Connect looks up the first GPIO of the gpiochip, if one is set
 func (r *Adaptor) Connect() (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.gpiochip == "" {
+		return
+	}
+	r.gpiochipBase, err = sysfs.GpiochipBase(r.gpiochip)
 	return
 }
 
@@ -97,32 +126,37 @@ func (r *Adaptor) Finalize() (err error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	for _, pin := range r.digitalPins {
+	for i, pin := range r.digitalPins {
 		if pin != nil {
-			if perr := pin.Unexport(); err != nil {
+			if perr := pin.Unexport(); perr != nil {
 				err = multierror.Append(err, perr)
 			}
+			r.digitalPins[i] = nil
 		}
 	}
-	for _, pin := range r.pwmPins {
+	for i, pin := range r.pwmPins {
 		if pin != nil {
-			if perr := pin.Unexport(); err != nil {
+			if perr := pin.Unexport(); perr != nil {
 				err = multierror.Append(err, perr)
 			}
+			r.pwmPins[i] = nil
 		}
 	}
-	for _, bus := range r.i2cBuses {
+	r.i2cConnections.Close()
+	for i, bus := range r.i2cBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			r.i2cBuses[i] = nil
 		}
 	}
-	for _, dev := range r.spiDevices {
+	for i, dev := range r.spiDevices {
 		if dev != nil {
 			if e := dev.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			r.spiDevices[i] = nil
 		}
 	}
 	return
@@ -153,14 +187,15 @@ func (r *Adaptor) getExportedDigitalPin(translatedPin int, dir string) (sysfsPin
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if r.digitalPins[translatedPin] == nil {
-		r.digitalPins[translatedPin] = sysfs.NewDigitalPin(translatedPin)
-		if err = r.digitalPins[translatedPin].Export(); err != nil {
+	gpio := r.gpiochipBase + translatedPin
+	if r.digitalPins[gpio] == nil {
+		r.digitalPins[gpio] = sysfs.NewDigitalPin(gpio)
+		if err = r.digitalPins[gpio].Export(); err != nil {
 			return
 		}
 	}
 
-	return r.digitalPins[translatedPin], nil
+	return r.digitalPins[gpio], nil
 }
 
 // DigitalRead reads digital value from pin
@@ -189,8 +224,11 @@ func (r *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 	}
 
 	device, err := r.getI2cBus(bus)
+	if err != nil {
+		return nil, err
+	}
 
-	return i2c.NewConnection(device, address), err
+	return r.i2cConnections.Get(device, address), nil
 }
 
 func (r *Adaptor) getI2cBus(bus int) (_ i2c.I2cDevice, err error) {
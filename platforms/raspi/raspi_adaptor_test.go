@@ -106,6 +106,11 @@ func TestAdaptorFinalize(t *testing.T) {
 
 	a.GetConnection(0xff, 0)
 	gobottest.Assert(t, a.Finalize(), nil)
+
+	// everything is released already, so nothing is closed twice
+	sysfs.SetFilesystem(sysfs.NewMockFilesystem([]string{}))
+	gobottest.Assert(t, a.Finalize(), nil)
+	sysfs.SetFilesystem(fs)
 }
 
 func TestAdaptorDigitalPWM(t *testing.T) {
@@ -174,6 +179,29 @@ func TestAdaptorDigitalIO(t *testing.T) {
 	gobottest.Assert(t, err, errors.New("write error"))
 }
 
+func TestAdaptorWithGpiochip(t *testing.T) {
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpiochip571/base",
+		"/sys/class/gpio/gpiochip571/label",
+		"/sys/class/gpio/gpio575/value",
+		"/sys/class/gpio/gpio575/direction",
+	})
+	fs.Files["/sys/class/gpio/gpiochip571/base"].Contents = "571\n"
+	fs.Files["/sys/class/gpio/gpiochip571/label"].Contents = "pinctrl-rp1\n"
+	sysfs.SetFilesystem(fs)
+
+	a := NewAdaptor(WithGpiochip("pinctrl-rp1"))
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.DigitalWrite("7", 1), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/export"].Contents, "575")
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio575/value"].Contents, "1")
+
+	a = NewAdaptor(WithGpiochip("notexist"))
+	gobottest.Assert(t, a.Connect(), errors.New("No gpiochip 'notexist' found"))
+}
+
 func TestAdaptorI2c(t *testing.T) {
 	a := initTestAdaptor()
 	fs := sysfs.NewMockFilesystem([]string{
@@ -194,6 +222,14 @@ func TestAdaptorI2c(t *testing.T) {
 	gobottest.Assert(t, err, errors.New("Bus number 51 out of range"))
 
 	gobottest.Assert(t, a.GetDefaultBus(), 1)
+
+	// repeated calls return the same connection
+	con2, err := a.GetConnection(0xff, 1)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, con2 == con, true)
+	gobottest.Assert(t, a.Finalize(), nil)
+	con2, _ = a.GetConnection(0xff, 1)
+	gobottest.Assert(t, con2 == con, false)
 }
 
 func TestAdaptorSPI(t *testing.T) {
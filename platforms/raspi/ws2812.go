@@ -0,0 +1,35 @@
+package raspi
+
+import "gobot.io/x/gobot/drivers/spi"
+
+// WithWS2812Output option sets the output for WS2812 LEDs, e.g. a binding
+// of the rpi_ws281x library, which drives the LEDs on GPIO18 by PWM and DMA.
+// The spi.WS2812Driver then sends its frames to this output instead of the
+// SPI bus.
+func WithWS2812Output(output func(data []byte) error) func(*Adaptor) {
+	return func(r *Adaptor) {
+		r.ws2812Output = output
+	}
+}
+
+// WS2812Writer returns the output for WS2812 LEDs set by WithWS2812Output or
+// nil, so the spi.WS2812Driver encodes the frames for the SPI bus.
+func (r *Adaptor) WS2812Writer() spi.WS2812Writer {
+	if r.ws2812Output == nil {
+		return nil
+	}
+	return &ws2812Writer{r: r}
+}
+
+// ws2812Writer sends the frames of the spi.WS2812Driver to the WS2812 output
+// of the adaptor
+type ws2812Writer struct {
+	r *Adaptor
+}
+
+func (w *ws2812Writer) WriteWS2812(data []byte) error {
+	w.r.mutex.Lock()
+	defer w.r.mutex.Unlock()
+
+	return w.r.ws2812Output(data)
+}
@@ -0,0 +1,32 @@
+package raspi
+
+import (
+	"errors"
+	"testing"
+
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ spi.WS2812WriterProvider = (*Adaptor)(nil)
+
+func TestAdaptorWS2812Writer(t *testing.T) {
+	// SPI is used by default
+	a := initTestAdaptor()
+	gobottest.Assert(t, a.WS2812Writer(), nil)
+}
+
+func TestAdaptorWithWS2812Output(t *testing.T) {
+	var frame []byte
+	a := NewAdaptor(WithWS2812Output(func(data []byte) error {
+		frame = data
+		return nil
+	}))
+	gobottest.Assert(t, a.WS2812Writer().WriteWS2812([]byte{1, 2, 3}), nil)
+	gobottest.Assert(t, frame, []byte{1, 2, 3})
+
+	a = NewAdaptor(WithWS2812Output(func(data []byte) error {
+		return errors.New("no dma")
+	}))
+	gobottest.Assert(t, a.WS2812Writer().WriteWS2812([]byte{1}), errors.New("no dma"))
+}
@@ -0,0 +1,287 @@
+package remoteio
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/rpc"
+	"sync"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/sysfs"
+)
+
+// ErrNotConnected is the error resulting when the Adaptor is used before
+// Connect was called
+var ErrNotConnected = errors.New("Adaptor is not connected to a remote I/O server")
+
+// Adaptor represents a Gobot Adaptor which uses the digital pins, PWM, i2c and
+// SPI of a board served by a remote Server.
+type Adaptor struct {
+	name    string
+	address string
+	info    Info
+	client  *rpc.Client
+	dial    func(address string) (io.ReadWriteCloser, error)
+	token   string
+	mutex   sync.Mutex
+}
+
+// NewAdaptor returns a new remote I/O Adaptor given the TCP address of the
+// Server, e.g. "192.168.1.10:3030".
+//
+// Optional params:
+//	remoteio.WithToken(string): token to authenticate at the Server
+//	remoteio.WithTLS(*tls.Config): connect to the Server by TLS
+func NewAdaptor(address string, options ...func(*Adaptor)) *Adaptor {
+	a := &Adaptor{
+		name:    gobot.DefaultName("RemoteIO"),
+		address: address,
+		dial: func(address string) (io.ReadWriteCloser, error) {
+			return net.Dial("tcp", address)
+		},
+	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// Name returns the name of the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Port returns the address of the Server
+func (a *Adaptor) Port() string { return a.address }
+
+// Connect connects to the Server and reads the defaults of the remote board
+func (a *Adaptor) Connect() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	conn, err := a.dial(a.address)
+	if err != nil {
+		return err
+	}
+	if a.token != "" {
+		if err = authenticate(conn, a.token); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+	a.client = rpc.NewClient(conn)
+	if err = a.client.Call(ServiceName+".Info", new(bool), &a.info); err != nil {
+		a.client.Close()
+		a.client = nil
+	}
+	return
+}
+
+// Finalize closes the connection to the Server
+func (a *Adaptor) Finalize() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.client == nil {
+		return
+	}
+	err = a.client.Close()
+	a.client = nil
+	return
+}
+
+// RemoteName returns the name of the adaptor served by the Server
+func (a *Adaptor) RemoteName() string { return a.info.Name }
+
+// DigitalRead reads digital value from the specified pin of the remote board.
+func (a *Adaptor) DigitalRead(pin string) (val int, err error) {
+	err = a.call("DigitalRead", &PinRequest{Pin: pin}, &val)
+	return
+}
+
+// DigitalWrite writes digital value to the specified pin of the remote board.
+func (a *Adaptor) DigitalWrite(pin string, val byte) (err error) {
+	return a.call("DigitalWrite", &PinRequest{Pin: pin, Val: val}, new(bool))
+}
+
+// PwmWrite writes a PWM signal to the specified pin of the remote board.
+func (a *Adaptor) PwmWrite(pin string, val byte) (err error) {
+	return a.call("PwmWrite", &PinRequest{Pin: pin, Val: val}, new(bool))
+}
+
+// ServoWrite writes a servo signal to the specified pin of the remote board.
+func (a *Adaptor) ServoWrite(pin string, angle byte) (err error) {
+	return a.call("ServoWrite", &PinRequest{Pin: pin, Val: angle}, new(bool))
+}
+
+// DigitalPin returns a digital pin of the remote board.
+func (a *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinner, err error) {
+	p := &digitalPin{adaptor: a, pin: pin, dir: dir}
+	if err = p.Direction(dir); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetConnection returns a connection to a device on a specified bus of the
+// remote board.
+func (a *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
+	var handle int
+	if err = a.call("I2cOpen", &I2cRequest{Address: address, Bus: bus}, &handle); err != nil {
+		return nil, err
+	}
+	return &i2cConnection{adaptor: a, handle: handle}, nil
+}
+
+// GetDefaultBus returns the default i2c bus of the remote board
+func (a *Adaptor) GetDefaultBus() int {
+	return a.info.I2cDefaultBus
+}
+
+// GetSpiConnection returns a connection to a SPI device at the specified bus
+// and chip of the remote board.
+func (a *Adaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (connection spi.Connection, err error) {
+	var handle int
+	req := &SpiRequest{Bus: busNum, Chip: chipNum, Mode: mode, Bits: bits, MaxSpeed: maxSpeed}
+	if err = a.call("SpiOpen", req, &handle); err != nil {
+		return nil, err
+	}
+	return &spiConnection{adaptor: a, handle: handle}, nil
+}
+
+// GetSpiDefaultBus returns the default SPI bus of the remote board.
+func (a *Adaptor) GetSpiDefaultBus() int { return a.info.SpiDefaultBus }
+
+// GetSpiDefaultChip returns the default SPI chip of the remote board.
+func (a *Adaptor) GetSpiDefaultChip() int { return a.info.SpiDefaultChip }
+
+// GetSpiDefaultMode returns the default SPI mode of the remote board.
+func (a *Adaptor) GetSpiDefaultMode() int { return a.info.SpiDefaultMode }
+
+// GetSpiDefaultBits returns the default SPI number of bits of the remote board.
+func (a *Adaptor) GetSpiDefaultBits() int { return a.info.SpiDefaultBits }
+
+// GetSpiDefaultMaxSpeed returns the default SPI max speed of the remote board.
+func (a *Adaptor) GetSpiDefaultMaxSpeed() int64 { return a.info.SpiDefaultMaxSpeed }
+
+func (a *Adaptor) call(method string, args interface{}, reply interface{}) error {
+	a.mutex.Lock()
+	client := a.client
+	a.mutex.Unlock()
+
+	if client == nil {
+		return ErrNotConnected
+	}
+	return client.Call(ServiceName+"."+method, args, reply)
+}
+
+type digitalPin struct {
+	adaptor *Adaptor
+	pin     string
+	dir     string
+}
+
+func (p *digitalPin) op(op string, val int) (n int, err error) {
+	err = p.adaptor.call("DigitalPin", &PinRequest{Pin: p.pin, Direction: p.dir, Op: op, Val: byte(val)}, &n)
+	return
+}
+
+func (p *digitalPin) Export() (err error) {
+	_, err = p.op("export", 0)
+	return
+}
+
+func (p *digitalPin) Unexport() (err error) {
+	_, err = p.op("unexport", 0)
+	return
+}
+
+func (p *digitalPin) Direction(dir string) (err error) {
+	p.dir = dir
+	_, err = p.op("direction", 0)
+	return
+}
+
+func (p *digitalPin) Read() (int, error) {
+	return p.op("read", 0)
+}
+
+func (p *digitalPin) Write(val int) (err error) {
+	_, err = p.op("write", val)
+	return
+}
+
+type i2cConnection struct {
+	adaptor *Adaptor
+	handle  int
+}
+
+func (c *i2cConnection) Read(b []byte) (n int, err error) {
+	var data []byte
+	if err = c.adaptor.call("I2cRead", &I2cRequest{Handle: c.handle, Len: len(b)}, &data); err != nil {
+		return 0, err
+	}
+	return copy(b, data), nil
+}
+
+func (c *i2cConnection) Write(b []byte) (n int, err error) {
+	err = c.adaptor.call("I2cWrite", &I2cRequest{Handle: c.handle, Data: b}, &n)
+	return
+}
+
+func (c *i2cConnection) Close() error {
+	return c.adaptor.call("I2cClose", &I2cRequest{Handle: c.handle}, new(bool))
+}
+
+func (c *i2cConnection) ReadByte() (val byte, err error) {
+	err = c.adaptor.call("I2cReadByte", &I2cRequest{Handle: c.handle}, &val)
+	return
+}
+
+func (c *i2cConnection) ReadByteData(reg uint8) (val uint8, err error) {
+	err = c.adaptor.call("I2cReadByteData", &I2cRequest{Handle: c.handle, Reg: reg}, &val)
+	return
+}
+
+func (c *i2cConnection) ReadWordData(reg uint8) (val uint16, err error) {
+	err = c.adaptor.call("I2cReadWordData", &I2cRequest{Handle: c.handle, Reg: reg}, &val)
+	return
+}
+
+func (c *i2cConnection) WriteByte(val byte) error {
+	return c.adaptor.call("I2cWriteByte", &I2cRequest{Handle: c.handle, Data: []byte{val}}, new(bool))
+}
+
+func (c *i2cConnection) WriteByteData(reg uint8, val uint8) error {
+	return c.adaptor.call("I2cWriteByteData", &I2cRequest{Handle: c.handle, Reg: reg, Data: []byte{val}}, new(bool))
+}
+
+func (c *i2cConnection) WriteWordData(reg uint8, val uint16) error {
+	return c.adaptor.call("I2cWriteWordData", &I2cRequest{Handle: c.handle, Reg: reg, Word: val}, new(bool))
+}
+
+func (c *i2cConnection) WriteBlockData(reg uint8, b []byte) error {
+	return c.adaptor.call("I2cWriteBlockData", &I2cRequest{Handle: c.handle, Reg: reg, Data: b}, new(bool))
+}
+
+type spiConnection struct {
+	adaptor *Adaptor
+	handle  int
+}
+
+func (c *spiConnection) Close() error {
+	return c.adaptor.call("SpiClose", &SpiRequest{Handle: c.handle}, new(bool))
+}
+
+func (c *spiConnection) Tx(w, r []byte) error {
+	var data []byte
+	if err := c.adaptor.call("SpiTx", &SpiRequest{Handle: c.handle, Data: w, ReadLen: len(r)}, &data); err != nil {
+		return err
+	}
+	copy(r, data)
+	return nil
+}
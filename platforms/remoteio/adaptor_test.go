@@ -0,0 +1,266 @@
+package remoteio
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/sysfs"
+)
+
+// make sure that this Adaptor fullfills all the required interfaces
+var _ gobot.Adaptor = (*Adaptor)(nil)
+var _ gobot.Porter = (*Adaptor)(nil)
+var _ gpio.DigitalReader = (*Adaptor)(nil)
+var _ gpio.DigitalWriter = (*Adaptor)(nil)
+var _ gpio.PwmWriter = (*Adaptor)(nil)
+var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ sysfs.DigitalPinnerProvider = (*Adaptor)(nil)
+var _ i2c.Connector = (*Adaptor)(nil)
+var _ spi.Connector = (*Adaptor)(nil)
+
+type localTestAdaptor struct {
+	pins    map[string]int
+	pwm     map[string]byte
+	i2cRegs map[uint8]uint8
+	spiRx   []byte
+	spiTx   []byte
+}
+
+func (l *localTestAdaptor) Name() string          { return "local" }
+func (l *localTestAdaptor) SetName(string)        {}
+func (l *localTestAdaptor) Connect() (err error)  { return }
+func (l *localTestAdaptor) Finalize() (err error) { return }
+
+func (l *localTestAdaptor) DigitalRead(pin string) (int, error) { return l.pins[pin], nil }
+func (l *localTestAdaptor) DigitalWrite(pin string, val byte) error {
+	if pin == "99" {
+		return errors.New("Not a valid pin")
+	}
+	l.pins[pin] = int(val)
+	return nil
+}
+func (l *localTestAdaptor) PwmWrite(pin string, val byte) error {
+	l.pwm[pin] = val
+	return nil
+}
+
+func (l *localTestAdaptor) GetConnection(address int, bus int) (i2c.Connection, error) {
+	return &localTestI2c{regs: l.i2cRegs}, nil
+}
+func (l *localTestAdaptor) GetDefaultBus() int { return 1 }
+
+func (l *localTestAdaptor) GetSpiConnection(busNum, chip, mode, bits int, maxSpeed int64) (spi.Connection, error) {
+	return &localTestSpi{a: l}, nil
+}
+func (l *localTestAdaptor) GetSpiDefaultBus() int        { return 2 }
+func (l *localTestAdaptor) GetSpiDefaultChip() int       { return 3 }
+func (l *localTestAdaptor) GetSpiDefaultMode() int       { return 0 }
+func (l *localTestAdaptor) GetSpiDefaultBits() int       { return 8 }
+func (l *localTestAdaptor) GetSpiDefaultMaxSpeed() int64 { return 500000 }
+
+type localTestI2c struct {
+	regs   map[uint8]uint8
+	closed bool
+}
+
+func (c *localTestI2c) Read(b []byte) (int, error)            { return copy(b, []byte{1, 2, 3}), nil }
+func (c *localTestI2c) Write(b []byte) (int, error)           { return len(b), nil }
+func (c *localTestI2c) Close() error                          { c.closed = true; return nil }
+func (c *localTestI2c) ReadByte() (byte, error)               { return 0x42, nil }
+func (c *localTestI2c) ReadByteData(reg uint8) (uint8, error) { return c.regs[reg], nil }
+func (c *localTestI2c) ReadWordData(reg uint8) (uint16, error) {
+	return uint16(c.regs[reg+1])<<8 | uint16(c.regs[reg]), nil
+}
+func (c *localTestI2c) WriteByte(val byte) error { return nil }
+func (c *localTestI2c) WriteByteData(reg uint8, val uint8) error {
+	c.regs[reg] = val
+	return nil
+}
+func (c *localTestI2c) WriteWordData(reg uint8, val uint16) error {
+	c.regs[reg] = uint8(val)
+	c.regs[reg+1] = uint8(val >> 8)
+	return nil
+}
+func (c *localTestI2c) WriteBlockData(reg uint8, b []byte) error {
+	for i, v := range b {
+		c.regs[reg+uint8(i)] = v
+	}
+	return nil
+}
+
+type localTestSpi struct {
+	a *localTestAdaptor
+}
+
+func (c *localTestSpi) Close() error { return nil }
+func (c *localTestSpi) Tx(w, r []byte) error {
+	c.a.spiTx = append(c.a.spiTx, w...)
+	copy(r, c.a.spiRx)
+	return nil
+}
+
+func initTestRemoteIO() (*Adaptor, *localTestAdaptor) {
+	local := &localTestAdaptor{
+		pins:    make(map[string]int),
+		pwm:     make(map[string]byte),
+		i2cRegs: make(map[uint8]uint8),
+	}
+	s := NewServer(local)
+
+	a := NewAdaptor("localhost:3030")
+	a.dial = func(string) (io.ReadWriteCloser, error) {
+		client, server := net.Pipe()
+		go s.ServeConn(server)
+		return client, nil
+	}
+	return a, local
+}
+
+func TestRemoteIOAdaptorName(t *testing.T) {
+	a := NewAdaptor("localhost:3030")
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "RemoteIO"), true)
+	a.SetName("NewName")
+	gobottest.Assert(t, a.Name(), "NewName")
+	gobottest.Assert(t, a.Port(), "localhost:3030")
+}
+
+func TestRemoteIOAdaptorConnect(t *testing.T) {
+	a, _ := initTestRemoteIO()
+	gobottest.Assert(t, a.DigitalWrite("7", 1), ErrNotConnected)
+
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.RemoteName(), "local")
+	gobottest.Assert(t, a.GetDefaultBus(), 1)
+	gobottest.Assert(t, a.GetSpiDefaultBus(), 2)
+	gobottest.Assert(t, a.GetSpiDefaultChip(), 3)
+	gobottest.Assert(t, a.GetSpiDefaultBits(), 8)
+	gobottest.Assert(t, a.GetSpiDefaultMaxSpeed(), int64(500000))
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, a.Finalize(), nil)
+}
+
+func TestRemoteIOAdaptorConnectError(t *testing.T) {
+	a := NewAdaptor("localhost:3030")
+	a.dial = func(string) (io.ReadWriteCloser, error) {
+		return nil, errors.New("connection refused")
+	}
+	gobottest.Assert(t, a.Connect(), errors.New("connection refused"))
+}
+
+func TestRemoteIOAdaptorDigitalIO(t *testing.T) {
+	a, local := initTestRemoteIO()
+	a.Connect()
+	defer a.Finalize()
+
+	gobottest.Assert(t, a.DigitalWrite("7", 1), nil)
+	gobottest.Assert(t, local.pins["7"], 1)
+
+	local.pins["8"] = 1
+	val, err := a.DigitalRead("8")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+
+	err = a.DigitalWrite("99", 1)
+	gobottest.Assert(t, err.Error(), "Not a valid pin")
+
+	gobottest.Assert(t, a.PwmWrite("12", 128), nil)
+	gobottest.Assert(t, local.pwm["12"], byte(128))
+
+	err = a.ServoWrite("12", 90)
+	gobottest.Assert(t, err.Error(), gpio.ErrServoWriteUnsupported.Error())
+
+	_, err = a.DigitalPin("7", sysfs.OUT)
+	gobottest.Assert(t, err.Error(), ErrDigitalPinUnsupported.Error())
+}
+
+func TestRemoteIOAdaptorI2c(t *testing.T) {
+	a, local := initTestRemoteIO()
+	a.Connect()
+	defer a.Finalize()
+
+	con, err := a.GetConnection(0x40, 1)
+	gobottest.Assert(t, err, nil)
+
+	gobottest.Assert(t, con.WriteByteData(0x01, 0x55), nil)
+	gobottest.Assert(t, local.i2cRegs[0x01], uint8(0x55))
+	val, _ := con.ReadByteData(0x01)
+	gobottest.Assert(t, val, uint8(0x55))
+
+	gobottest.Assert(t, con.WriteWordData(0x02, 0x1234), nil)
+	word, _ := con.ReadWordData(0x02)
+	gobottest.Assert(t, word, uint16(0x1234))
+
+	gobottest.Assert(t, con.WriteBlockData(0x10, []byte{1, 2}), nil)
+	gobottest.Assert(t, local.i2cRegs[0x11], uint8(2))
+
+	b, _ := con.ReadByte()
+	gobottest.Assert(t, b, byte(0x42))
+	gobottest.Assert(t, con.WriteByte(0x01), nil)
+
+	buf := make([]byte, 2)
+	n, err := con.Read(buf)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 2)
+	gobottest.Assert(t, buf, []byte{1, 2})
+
+	n, _ = con.Write([]byte{1, 2, 3})
+	gobottest.Assert(t, n, 3)
+
+	gobottest.Assert(t, con.Close(), nil)
+	err = con.Close()
+	gobottest.Assert(t, err.Error(), ErrUnknownHandle.Error())
+}
+
+func TestRemoteIOAdaptorSpi(t *testing.T) {
+	a, local := initTestRemoteIO()
+	a.Connect()
+	defer a.Finalize()
+
+	local.spiRx = []byte{0, 3, 0xff}
+	con, err := a.GetSpiConnection(0, 0, 0, 8, 500000)
+	gobottest.Assert(t, err, nil)
+
+	rx := make([]byte, 3)
+	gobottest.Assert(t, con.Tx([]byte{1, 2, 3}, rx), nil)
+	gobottest.Assert(t, rx, []byte{0, 3, 0xff})
+	gobottest.Assert(t, local.spiTx, []byte{1, 2, 3})
+
+	gobottest.Assert(t, con.Close(), nil)
+}
+
+func TestRemoteIOAdaptorToken(t *testing.T) {
+	local := &localTestAdaptor{pins: make(map[string]int)}
+	s := NewServer(local, WithServerToken("secret"))
+	dial := func(string) (io.ReadWriteCloser, error) {
+		client, server := net.Pipe()
+		go s.ServeConn(server)
+		return client, nil
+	}
+
+	a := NewAdaptor("localhost:3030", WithToken("secret"))
+	a.dial = dial
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.DigitalWrite("7", 1), nil)
+	gobottest.Assert(t, local.pins["7"], 1)
+	gobottest.Assert(t, a.Finalize(), nil)
+
+	a = NewAdaptor("localhost:3030", WithToken("guess"))
+	a.dial = dial
+	gobottest.Assert(t, a.Connect(), ErrAuthenticationFailed)
+	gobottest.Assert(t, a.DigitalWrite("7", 0), ErrNotConnected)
+}
+
+func TestRemoteIOAdaptorTLS(t *testing.T) {
+	a := NewAdaptor("localhost:0", WithTLS(&tls.Config{}))
+	gobottest.Refute(t, a.Connect(), nil)
+}
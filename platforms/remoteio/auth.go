@@ -0,0 +1,89 @@
+package remoteio
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"io"
+)
+
+// ErrAuthenticationFailed is the error resulting when the Adaptor does not
+// know the token of the Server
+var ErrAuthenticationFailed = errors.New("Authentication at the remote I/O server failed")
+
+// nonceSize is the size of the challenge sent by the Server
+const nonceSize = 32
+
+// WithServerToken option sets the token, which the adaptors need to know to
+// use the Server. Each connection is authenticated by a challenge, so the
+// token itself is never sent.
+func WithServerToken(token string) func(*Server) {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithToken option sets the token to authenticate at the Server.
+func WithToken(token string) func(*Adaptor) {
+	return func(a *Adaptor) {
+		a.token = token
+	}
+}
+
+// WithTLS option connects to the Server by TLS with the given config, e.g.
+// to verify the certificate of the Server and to encrypt the connection.
+func WithTLS(config *tls.Config) func(*Adaptor) {
+	return func(a *Adaptor) {
+		a.dial = func(address string) (io.ReadWriteCloser, error) {
+			return tls.Dial("tcp", address, config)
+		}
+	}
+}
+
+// verifyClient sends a random challenge to the client and checks its answer,
+// which is the HMAC of the challenge with the token
+func verifyClient(conn io.ReadWriter, token string) error {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	if _, err := conn.Write(nonce); err != nil {
+		return err
+	}
+
+	answer := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return err
+	}
+	if !hmac.Equal(answer, sign(nonce, token)) {
+		conn.Write([]byte{0})
+		return ErrAuthenticationFailed
+	}
+	_, err := conn.Write([]byte{1})
+	return err
+}
+
+// authenticate answers the challenge of the Server
+func authenticate(conn io.ReadWriter, token string) error {
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(conn, nonce); err != nil {
+		return err
+	}
+	if _, err := conn.Write(sign(nonce, token)); err != nil {
+		return err
+	}
+
+	result := make([]byte, 1)
+	if _, err := io.ReadFull(conn, result); err != nil || result[0] != 1 {
+		return ErrAuthenticationFailed
+	}
+	return nil
+}
+
+func sign(nonce []byte, token string) []byte {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(nonce)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,9 @@
+/*
+Package remoteio contains the Gobot server and adaptor to access the digital pins,
+PWM, i2c and SPI of a board over the network. The Server and the Adaptor
+talk by net/rpc, so both need to be built from the same version of Gobot.
+
+For further information refer to remoteio README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/remoteio/README.md
+*/
+package remoteio // import "gobot.io/x/gobot/platforms/remoteio"
@@ -0,0 +1,450 @@
+package remoteio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/sysfs"
+)
+
+// ServiceName is the name of the RPC service provided by the Server
+const ServiceName = "RemoteIO"
+
+var (
+	// ErrDigitalPinUnsupported is the error resulting when the served adaptor
+	// does not provide digital pins
+	ErrDigitalPinUnsupported = errors.New("DigitalPin is not supported by this platform")
+	// ErrI2cUnsupported is the error resulting when the served adaptor does not
+	// provide i2c connections
+	ErrI2cUnsupported = errors.New("i2c is not supported by this platform")
+	// ErrSpiUnsupported is the error resulting when the served adaptor does not
+	// provide SPI connections
+	ErrSpiUnsupported = errors.New("SPI is not supported by this platform")
+	// ErrUnknownHandle is the error resulting when a connection handle is not
+	// known by the server, e.g. because it was already closed
+	ErrUnknownHandle = errors.New("Unknown connection handle")
+)
+
+// Info contains the name and the bus defaults of the served adaptor
+type Info struct {
+	Name               string
+	I2cDefaultBus      int
+	SpiDefaultBus      int
+	SpiDefaultChip     int
+	SpiDefaultMode     int
+	SpiDefaultBits     int
+	SpiDefaultMaxSpeed int64
+}
+
+// PinRequest is the request for a digital, PWM or servo pin operation
+type PinRequest struct {
+	Pin       string
+	Direction string
+	Op        string
+	Val       byte
+}
+
+// I2cRequest is the request for an i2c operation
+type I2cRequest struct {
+	Handle  int
+	Address int
+	Bus     int
+	Reg     uint8
+	Word    uint16
+	Data    []byte
+	Len     int
+}
+
+// SpiRequest is the request for a SPI operation
+type SpiRequest struct {
+	Handle   int
+	Bus      int
+	Chip     int
+	Mode     int
+	Bits     int
+	MaxSpeed int64
+	Data     []byte
+	ReadLen  int
+}
+
+// Server exposes the digital pins, PWM, i2c and SPI of a local adaptor over
+// the network, so the Adaptor of this package can use them remotely.
+type Server struct {
+	adaptor    gobot.Connection
+	rpc        *rpc.Server
+	mutex      sync.Mutex
+	nextHandle int
+	i2cConns   map[int]i2c.Connection
+	spiConns   map[int]spi.Connection
+	token      string
+}
+
+// NewServer returns a new Server for the given adaptor. The adaptor needs to
+// be connected before serving.
+//
+// Optional params:
+//	remoteio.WithServerToken(string): token the adaptors need to authenticate with
+func NewServer(a gobot.Connection, options ...func(*Server)) *Server {
+	s := &Server{
+		adaptor:  a,
+		rpc:      rpc.NewServer(),
+		i2cConns: make(map[int]i2c.Connection),
+		spiConns: make(map[int]spi.Connection),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.rpc.RegisterName(ServiceName, &Service{server: s})
+	return s
+}
+
+// ListenAndServe listens on the TCP network address and serves the incoming
+// connections. It blocks until the listener fails.
+func (s *Server) ListenAndServe(address string) error {
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts and serves the incoming connections on the listener. It blocks
+// until the listener fails.
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// ServeConn serves a single connection. It blocks until the client hangs up.
+// If a token is set, the connection is closed when the client fails to
+// authenticate.
+func (s *Server) ServeConn(conn io.ReadWriteCloser) {
+	if s.token != "" {
+		if err := verifyClient(conn, s.token); err != nil {
+			conn.Close()
+			return
+		}
+	}
+	s.rpc.ServeConn(conn)
+}
+
+// Close closes all i2c and SPI connections which were opened on behalf of
+// the clients.
+func (s *Server) Close() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for handle, conn := range s.i2cConns {
+		if e := conn.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+		delete(s.i2cConns, handle)
+	}
+	for handle, conn := range s.spiConns {
+		if e := conn.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+		delete(s.spiConns, handle)
+	}
+	return
+}
+
+func (s *Server) i2cConnection(handle int) (i2c.Connection, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	conn, ok := s.i2cConns[handle]
+	if !ok {
+		return nil, ErrUnknownHandle
+	}
+	return conn, nil
+}
+
+func (s *Server) spiConnection(handle int) (spi.Connection, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	conn, ok := s.spiConns[handle]
+	if !ok {
+		return nil, ErrUnknownHandle
+	}
+	return conn, nil
+}
+
+// Service is the RPC receiver of the Server. It is only exported to fulfill
+// the requirements of net/rpc and not intended to be used directly.
+type Service struct {
+	server *Server
+}
+
+// Info returns the name and the bus defaults of the served adaptor.
+func (r *Service) Info(_ *bool, info *Info) error {
+	info.Name = r.server.adaptor.Name()
+	if c, ok := r.server.adaptor.(i2c.Connector); ok {
+		info.I2cDefaultBus = c.GetDefaultBus()
+	}
+	if c, ok := r.server.adaptor.(spi.Connector); ok {
+		info.SpiDefaultBus = c.GetSpiDefaultBus()
+		info.SpiDefaultChip = c.GetSpiDefaultChip()
+		info.SpiDefaultMode = c.GetSpiDefaultMode()
+		info.SpiDefaultBits = c.GetSpiDefaultBits()
+		info.SpiDefaultMaxSpeed = c.GetSpiDefaultMaxSpeed()
+	}
+	return nil
+}
+
+// DigitalRead reads the value of the requested pin.
+func (r *Service) DigitalRead(req *PinRequest, val *int) (err error) {
+	reader, ok := r.server.adaptor.(gpio.DigitalReader)
+	if !ok {
+		return gpio.ErrDigitalReadUnsupported
+	}
+	*val, err = reader.DigitalRead(req.Pin)
+	return
+}
+
+// DigitalWrite writes the requested value to the requested pin.
+func (r *Service) DigitalWrite(req *PinRequest, _ *bool) error {
+	writer, ok := r.server.adaptor.(gpio.DigitalWriter)
+	if !ok {
+		return gpio.ErrDigitalWriteUnsupported
+	}
+	return writer.DigitalWrite(req.Pin, req.Val)
+}
+
+// PwmWrite writes the requested PWM value to the requested pin.
+func (r *Service) PwmWrite(req *PinRequest, _ *bool) error {
+	writer, ok := r.server.adaptor.(gpio.PwmWriter)
+	if !ok {
+		return gpio.ErrPwmWriteUnsupported
+	}
+	return writer.PwmWrite(req.Pin, req.Val)
+}
+
+// ServoWrite writes the requested angle to the requested pin.
+func (r *Service) ServoWrite(req *PinRequest, _ *bool) error {
+	writer, ok := r.server.adaptor.(gpio.ServoWriter)
+	if !ok {
+		return gpio.ErrServoWriteUnsupported
+	}
+	return writer.ServoWrite(req.Pin, req.Val)
+}
+
+// DigitalPin executes the requested operation ("export", "unexport",
+// "direction", "read" or "write") on a digital pin of the served adaptor.
+func (r *Service) DigitalPin(req *PinRequest, val *int) error {
+	provider, ok := r.server.adaptor.(sysfs.DigitalPinnerProvider)
+	if !ok {
+		return ErrDigitalPinUnsupported
+	}
+	pin, err := provider.DigitalPin(req.Pin, req.Direction)
+	if err != nil {
+		return err
+	}
+
+	switch req.Op {
+	case "export":
+		return pin.Export()
+	case "unexport":
+		return pin.Unexport()
+	case "direction":
+		return pin.Direction(req.Direction)
+	case "read":
+		*val, err = pin.Read()
+		return err
+	case "write":
+		return pin.Write(int(req.Val))
+	}
+	return fmt.Errorf("Unknown pin operation %s", req.Op)
+}
+
+// I2cOpen opens a connection to the requested i2c device and returns its handle.
+func (r *Service) I2cOpen(req *I2cRequest, handle *int) error {
+	connector, ok := r.server.adaptor.(i2c.Connector)
+	if !ok {
+		return ErrI2cUnsupported
+	}
+	conn, err := connector.GetConnection(req.Address, req.Bus)
+	if err != nil {
+		return err
+	}
+
+	r.server.mutex.Lock()
+	defer r.server.mutex.Unlock()
+
+	r.server.nextHandle++
+	*handle = r.server.nextHandle
+	r.server.i2cConns[*handle] = conn
+	return nil
+}
+
+// I2cClose closes the i2c connection with the requested handle.
+func (r *Service) I2cClose(req *I2cRequest, _ *bool) error {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+
+	r.server.mutex.Lock()
+	delete(r.server.i2cConns, req.Handle)
+	r.server.mutex.Unlock()
+
+	return conn.Close()
+}
+
+// I2cRead reads the requested amount of bytes.
+func (r *Service) I2cRead(req *I2cRequest, data *[]byte) error {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, req.Len)
+	n, err := conn.Read(buf)
+	*data = buf[:n]
+	return err
+}
+
+// I2cWrite writes the requested data.
+func (r *Service) I2cWrite(req *I2cRequest, n *int) (err error) {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	*n, err = conn.Write(req.Data)
+	return
+}
+
+// I2cReadByte reads a single byte.
+func (r *Service) I2cReadByte(req *I2cRequest, val *byte) (err error) {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	*val, err = conn.ReadByte()
+	return
+}
+
+// I2cReadByteData reads a byte from the requested register.
+func (r *Service) I2cReadByteData(req *I2cRequest, val *uint8) (err error) {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	*val, err = conn.ReadByteData(req.Reg)
+	return
+}
+
+// I2cReadWordData reads a word from the requested register.
+func (r *Service) I2cReadWordData(req *I2cRequest, val *uint16) (err error) {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	*val, err = conn.ReadWordData(req.Reg)
+	return
+}
+
+// I2cWriteByte writes the first byte of the requested data.
+func (r *Service) I2cWriteByte(req *I2cRequest, _ *bool) error {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	if len(req.Data) != 1 {
+		return i2c.ErrNotEnoughBytes
+	}
+	return conn.WriteByte(req.Data[0])
+}
+
+// I2cWriteByteData writes the first byte of the requested data to the register.
+func (r *Service) I2cWriteByteData(req *I2cRequest, _ *bool) error {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	if len(req.Data) != 1 {
+		return i2c.ErrNotEnoughBytes
+	}
+	return conn.WriteByteData(req.Reg, req.Data[0])
+}
+
+// I2cWriteWordData writes the requested word to the register.
+func (r *Service) I2cWriteWordData(req *I2cRequest, _ *bool) error {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	return conn.WriteWordData(req.Reg, req.Word)
+}
+
+// I2cWriteBlockData writes the requested data to the register.
+func (r *Service) I2cWriteBlockData(req *I2cRequest, _ *bool) error {
+	conn, err := r.server.i2cConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	return conn.WriteBlockData(req.Reg, req.Data)
+}
+
+// SpiOpen opens a connection to the requested SPI device and returns its handle.
+func (r *Service) SpiOpen(req *SpiRequest, handle *int) error {
+	connector, ok := r.server.adaptor.(spi.Connector)
+	if !ok {
+		return ErrSpiUnsupported
+	}
+	conn, err := connector.GetSpiConnection(req.Bus, req.Chip, req.Mode, req.Bits, req.MaxSpeed)
+	if err != nil {
+		return err
+	}
+
+	r.server.mutex.Lock()
+	defer r.server.mutex.Unlock()
+
+	r.server.nextHandle++
+	*handle = r.server.nextHandle
+	r.server.spiConns[*handle] = conn
+	return nil
+}
+
+// SpiClose closes the SPI connection with the requested handle.
+func (r *Service) SpiClose(req *SpiRequest, _ *bool) error {
+	conn, err := r.server.spiConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+
+	r.server.mutex.Lock()
+	delete(r.server.spiConns, req.Handle)
+	r.server.mutex.Unlock()
+
+	return conn.Close()
+}
+
+// SpiTx writes the requested data and reads ReadLen bytes at the same time.
+func (r *Service) SpiTx(req *SpiRequest, data *[]byte) error {
+	conn, err := r.server.spiConnection(req.Handle)
+	if err != nil {
+		return err
+	}
+	var buf []byte
+	if req.ReadLen > 0 {
+		buf = make([]byte, req.ReadLen)
+	}
+	err = conn.Tx(req.Data, buf)
+	*data = buf
+	return err
+}
@@ -0,0 +1,170 @@
+package serialport
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"go.bug.st/serial"
+	"gobot.io/x/gobot"
+)
+
+// bufferSize is the number of received bytes, which are kept until they are
+// read by SerialRead. When more bytes are received, the oldest are dropped,
+// like by the FIFO of a UART.
+const bufferSize = 4096
+
+// ErrNotConnected is the error resulting when the Adaptor is used before
+// Connect was called or after Finalize
+var ErrNotConnected = errors.New("Serial port is not connected")
+
+// Adaptor is the Gobot Adaptor for a serial port (UART), e.g. a USB to serial
+// converter or the UART of a single board computer. It implements the
+// SerialReader and SerialWriter interfaces of the drivers/serial package.
+type Adaptor struct {
+	name    string
+	port    string
+	mode    *serial.Mode
+	sp      io.ReadWriteCloser
+	connect func(*Adaptor) (io.ReadWriteCloser, error)
+	buf     []byte
+	readErr error
+	done    sync.WaitGroup
+	mutex   sync.Mutex
+}
+
+// NewAdaptor returns a new serial port Adaptor given the name of the port,
+// e.g. "/dev/ttyUSB0" or "COM3". By default the port is opened with 9600 baud,
+// 8 data bits, no parity and one stop bit.
+//
+// Optional params:
+//	serialport.WithBaudRate(int): baud rate of the port
+//	serialport.WithSerialMode(*serial.Mode): baud rate, data bits, parity and stop bits of the port
+func NewAdaptor(port string, options ...func(*Adaptor)) *Adaptor {
+	a := &Adaptor{
+		name: gobot.DefaultName("SerialPort"),
+		port: port,
+		mode: &serial.Mode{BaudRate: 9600},
+		connect: func(a *Adaptor) (io.ReadWriteCloser, error) {
+			return serial.Open(a.port, a.mode)
+		},
+	}
+	for _, option := range options {
+		option(a)
+	}
+	return a
+}
+
+// WithBaudRate option sets the baud rate of the port.
+func WithBaudRate(baudRate int) func(*Adaptor) {
+	return func(a *Adaptor) {
+		a.mode.BaudRate = baudRate
+	}
+}
+
+// WithSerialMode option sets the baud rate, data bits, parity and stop bits
+// of the port, e.g. the even parity used by many Modbus RTU slaves.
+func WithSerialMode(mode *serial.Mode) func(*Adaptor) {
+	return func(a *Adaptor) {
+		a.mode = mode
+	}
+}
+
+// Name returns the Adaptors name
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the Adaptors name
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Port returns the name of the serial port
+func (a *Adaptor) Port() string { return a.port }
+
+// Connect opens the serial port and starts to receive data in the background
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.sp != nil {
+		return nil
+	}
+	sp, err := a.connect(a)
+	if err != nil {
+		return err
+	}
+	a.sp = sp
+	a.buf = nil
+	a.readErr = nil
+
+	a.done.Add(1)
+	go a.receive(sp)
+	return nil
+}
+
+// Finalize closes the serial port
+func (a *Adaptor) Finalize() (err error) {
+	a.mutex.Lock()
+	sp := a.sp
+	a.sp = nil
+	a.mutex.Unlock()
+
+	if sp == nil {
+		return nil
+	}
+	err = sp.Close()
+	a.done.Wait()
+	return
+}
+
+// SerialRead reads the received bytes into b. It does not wait for data and
+// returns 0, when no data was received since the last call.
+func (a *Adaptor) SerialRead(b []byte) (n int, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.sp == nil {
+		return 0, ErrNotConnected
+	}
+	n = copy(b, a.buf)
+	a.buf = a.buf[n:]
+	if n == 0 && a.readErr != nil {
+		return 0, a.readErr
+	}
+	return n, nil
+}
+
+// SerialWrite writes b to the serial port
+func (a *Adaptor) SerialWrite(b []byte) (n int, err error) {
+	a.mutex.Lock()
+	sp := a.sp
+	a.mutex.Unlock()
+
+	if sp == nil {
+		return 0, ErrNotConnected
+	}
+	return sp.Write(b)
+}
+
+// receive reads from the port until it is closed or fails, the error is
+// returned by SerialRead once all received bytes are read
+func (a *Adaptor) receive(sp io.Reader) {
+	defer a.done.Done()
+
+	buf := make([]byte, 256)
+	for {
+		n, err := sp.Read(buf)
+
+		a.mutex.Lock()
+		a.buf = append(a.buf, buf[:n]...)
+		if len(a.buf) > bufferSize {
+			a.buf = a.buf[len(a.buf)-bufferSize:]
+		}
+		if err != nil {
+			a.readErr = err
+		}
+		a.mutex.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package serialport
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"go.bug.st/serial"
+	"gobot.io/x/gobot"
+	gobotserial "gobot.io/x/gobot/drivers/serial"
+	"gobot.io/x/gobot/gobottest"
+)
+
+// make sure that this Adaptor fullfills all the required interfaces
+var _ gobot.Adaptor = (*Adaptor)(nil)
+var _ gobot.Porter = (*Adaptor)(nil)
+var _ gobotserial.SerialReadWriter = (*Adaptor)(nil)
+
+// initTestAdaptor returns an Adaptor connected to the returned end of a pipe
+func initTestAdaptor() (*Adaptor, net.Conn) {
+	a := NewAdaptor("/dev/ttyUSB0")
+	device, port := net.Pipe()
+	a.connect = func(*Adaptor) (io.ReadWriteCloser, error) {
+		return port, nil
+	}
+	return a, device
+}
+
+// serialReadAll polls the adaptor until n bytes are read or a second elapsed
+func serialReadAll(a *Adaptor, n int) ([]byte, error) {
+	data := []byte{}
+	buf := make([]byte, n)
+	deadline := time.Now().Add(time.Second)
+	for len(data) < n && time.Now().Before(deadline) {
+		m, err := a.SerialRead(buf[:n-len(data)])
+		if err != nil {
+			return data, err
+		}
+		data = append(data, buf[:m]...)
+		time.Sleep(time.Millisecond)
+	}
+	return data, nil
+}
+
+func TestSerialPortAdaptor(t *testing.T) {
+	a := NewAdaptor("/dev/ttyUSB0")
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "SerialPort"), true)
+	gobottest.Assert(t, a.Port(), "/dev/ttyUSB0")
+	gobottest.Assert(t, a.mode.BaudRate, 9600)
+
+	a.SetName("gps")
+	gobottest.Assert(t, a.Name(), "gps")
+
+	a = NewAdaptor("/dev/ttyUSB0", WithBaudRate(115200))
+	gobottest.Assert(t, a.mode.BaudRate, 115200)
+
+	a = NewAdaptor("/dev/ttyUSB0", WithSerialMode(&serial.Mode{BaudRate: 19200, Parity: serial.EvenParity}))
+	gobottest.Assert(t, a.mode.BaudRate, 19200)
+	gobottest.Assert(t, a.mode.Parity, serial.EvenParity)
+}
+
+func TestSerialPortAdaptorConnectError(t *testing.T) {
+	a := NewAdaptor("/dev/ttyUSB0")
+	a.connect = func(*Adaptor) (io.ReadWriteCloser, error) {
+		return nil, errors.New("no such port")
+	}
+	gobottest.Assert(t, a.Connect(), errors.New("no such port"))
+	_, err := a.SerialRead(make([]byte, 1))
+	gobottest.Assert(t, err, ErrNotConnected)
+	_, err = a.SerialWrite([]byte{1})
+	gobottest.Assert(t, err, ErrNotConnected)
+	gobottest.Assert(t, a.Finalize(), nil)
+}
+
+func TestSerialPortAdaptorSerialRead(t *testing.T) {
+	a, device := initTestAdaptor()
+	gobottest.Assert(t, a.Connect(), nil)
+
+	// no data was received yet
+	n, err := a.SerialRead(make([]byte, 8))
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 0)
+
+	go device.Write([]byte("$GPRMC"))
+	data, err := serialReadAll(a, 6)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, string(data), "$GPRMC")
+
+	// the error of the port is returned after the received data
+	go func() {
+		device.Write([]byte{0x42})
+		device.Close()
+	}()
+	data, err = serialReadAll(a, 2)
+	gobottest.Assert(t, data, []byte{0x42})
+	gobottest.Assert(t, err, io.EOF)
+
+	gobottest.Assert(t, a.Finalize(), nil)
+	gobottest.Assert(t, a.Finalize(), nil)
+}
+
+func TestSerialPortAdaptorSerialWrite(t *testing.T) {
+	a, device := initTestAdaptor()
+	gobottest.Assert(t, a.Connect(), nil)
+	defer a.Finalize()
+
+	received := make(chan []byte)
+	go func() {
+		buf := make([]byte, 3)
+		n, _ := io.ReadFull(device, buf)
+		received <- buf[:n]
+	}()
+
+	n, err := a.SerialWrite([]byte{0xaa, 0xb4, 0x06})
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, n, 3)
+	gobottest.Assert(t, <-received, []byte{0xaa, 0xb4, 0x06})
+}
+
+func TestSerialPortAdaptorBufferOverrun(t *testing.T) {
+	a, device := initTestAdaptor()
+	gobottest.Assert(t, a.Connect(), nil)
+	defer a.Finalize()
+
+	data := make([]byte, bufferSize+10)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	device.Write(data)
+	device.Write([]byte{})
+
+	// the oldest bytes are dropped
+	buf := make([]byte, 1)
+	a.SerialRead(buf)
+	gobottest.Assert(t, buf[0], byte(10))
+}
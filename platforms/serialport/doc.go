@@ -0,0 +1,43 @@
+/*
+Package serialport contains the Gobot adaptor for a serial port (UART), which
+is used by the drivers of the drivers/serial package.
+
+Installing:
+
+	go get gobot.io/x/gobot/platforms/serialport
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+
+		"gobot.io/x/gobot"
+		"gobot.io/x/gobot/drivers/serial"
+		"gobot.io/x/gobot/platforms/serialport"
+	)
+
+	func main() {
+		adaptor := serialport.NewAdaptor("/dev/ttyUSB0")
+		gps := serial.NewGPSDriver(adaptor)
+
+		work := func() {
+			gps.On(serial.Position, func(data interface{}) {
+				fmt.Println("Position", data)
+			})
+		}
+
+		robot := gobot.NewRobot("gpsBot",
+			[]gobot.Connection{adaptor},
+			[]gobot.Device{gps},
+			work,
+		)
+
+		robot.Start()
+	}
+
+For further information refer to serialport README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/serialport/README.md
+*/
+package serialport // import "gobot.io/x/gobot/platforms/serialport"
@@ -0,0 +1,343 @@
+package simulator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/sysfs"
+)
+
+const (
+	// pwmPeriod is the period of the PWM pins in ns
+	pwmPeriod = 10000000
+	// servoPeriod is the period of a servo signal in ns
+	servoPeriod = 20000000
+)
+
+type i2cKey struct {
+	bus     int
+	address int
+}
+
+type spiKey struct {
+	bus  int
+	chip int
+}
+
+// Adaptor represents a Gobot Adaptor for a simulated board. The digital and
+// PWM pins are created on first use, the analog inputs and the i2c and SPI
+// devices are added before the robot is started.
+type Adaptor struct {
+	name       string
+	start      time.Time
+	pins       map[string]*digitalPin
+	pwmPins    map[string]*pwmPin
+	waveforms  map[string]Waveform
+	i2cDevices map[i2cKey]*i2cConnection
+	spiDevices map[spiKey]SpiDevice
+	mutex      sync.Mutex
+}
+
+// NewAdaptor returns a new simulator Adaptor without any devices
+func NewAdaptor() *Adaptor {
+	return &Adaptor{
+		name:       gobot.DefaultName("Simulator"),
+		start:      time.Now(),
+		pins:       make(map[string]*digitalPin),
+		pwmPins:    make(map[string]*pwmPin),
+		waveforms:  make(map[string]Waveform),
+		i2cDevices: make(map[i2cKey]*i2cConnection),
+		spiDevices: make(map[spiKey]SpiDevice),
+	}
+}
+
+// Name returns the name of the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Connect restarts the time of the waveforms
+func (a *Adaptor) Connect() (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.start = time.Now()
+	return
+}
+
+// Finalize does nothing, the simulated board keeps its state
+func (a *Adaptor) Finalize() (err error) { return }
+
+// SetWaveform sets the waveform of an input pin. AnalogRead returns its
+// value and DigitalRead returns 1 for values greater than 0.
+func (a *Adaptor) SetWaveform(pin string, waveform Waveform) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.waveforms[pin] = waveform
+}
+
+// AddI2cDevice adds a virtual device at the address on the i2c bus
+func (a *Adaptor) AddI2cDevice(bus int, address int, device I2cDevice) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.i2cDevices[i2cKey{bus: bus, address: address}] = &i2cConnection{device: device}
+}
+
+// AddSpiDevice adds a virtual device at the chip on the SPI bus
+func (a *Adaptor) AddSpiDevice(bus int, chip int, device SpiDevice) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.spiDevices[spiKey{bus: bus, chip: chip}] = device
+}
+
+// DigitalRead reads the waveform of the pin if set, else the last value
+// written to the pin.
+func (a *Adaptor) DigitalRead(pin string) (val int, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if waveform, ok := a.waveforms[pin]; ok {
+		if waveform(time.Since(a.start)) > 0 {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return a.digitalPin(pin).value, nil
+}
+
+// DigitalWrite writes the value to the pin
+func (a *Adaptor) DigitalWrite(pin string, val byte) (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.digitalPin(pin).value = int(val)
+	return
+}
+
+// AnalogRead reads the waveform of the pin
+func (a *Adaptor) AnalogRead(pin string) (val int, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	waveform, ok := a.waveforms[pin]
+	if !ok {
+		return 0, fmt.Errorf("No waveform for analog pin %s", pin)
+	}
+	return waveform(time.Since(a.start)), nil
+}
+
+// PwmWrite writes the PWM value (0-255) as duty cycle to the PWM pin
+func (a *Adaptor) PwmWrite(pin string, val byte) (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	p := a.pwmPin(pin)
+	p.period = pwmPeriod
+	p.duty = uint32(gobot.FromScale(float64(val), 0, 255) * pwmPeriod)
+	p.enabled = true
+	return
+}
+
+// ServoWrite writes the servo angle (0-180) as a pulse of 0.5-2.5ms with a
+// period of 20ms to the PWM pin
+func (a *Adaptor) ServoWrite(pin string, angle byte) (err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	p := a.pwmPin(pin)
+	p.period = servoPeriod
+	p.duty = uint32(500000 + gobot.FromScale(float64(angle), 0, 180)*2000000)
+	p.enabled = true
+	return
+}
+
+// DigitalPin returns the digital pin
+func (a *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinner, err error) {
+	a.mutex.Lock()
+	p := a.digitalPin(pin)
+	a.mutex.Unlock()
+
+	if err = p.Direction(dir); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// PWMPin returns the PWM pin
+func (a *Adaptor) PWMPin(pin string) (sysfsPin sysfs.PWMPinner, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.pwmPin(pin), nil
+}
+
+// GetConnection returns a connection to the virtual device at the address
+// on the i2c bus. All connections to a device share one lock.
+func (a *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	conn, ok := a.i2cDevices[i2cKey{bus: bus, address: address}]
+	if !ok {
+		return nil, fmt.Errorf("No i2c device at address 0x%x on bus %d", address, bus)
+	}
+	return conn, nil
+}
+
+// GetDefaultBus returns the default i2c bus
+func (a *Adaptor) GetDefaultBus() int { return 0 }
+
+// GetSpiConnection returns a connection to the virtual device at the chip on
+// the SPI bus
+func (a *Adaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpeed int64) (connection spi.Connection, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	device, ok := a.spiDevices[spiKey{bus: busNum, chip: chipNum}]
+	if !ok {
+		return nil, fmt.Errorf("No SPI device at chip %d on bus %d", chipNum, busNum)
+	}
+	return &spiConnection{device: device}, nil
+}
+
+// GetSpiDefaultBus returns the default SPI bus
+func (a *Adaptor) GetSpiDefaultBus() int { return 0 }
+
+// GetSpiDefaultChip returns the default SPI chip
+func (a *Adaptor) GetSpiDefaultChip() int { return 0 }
+
+// GetSpiDefaultMode returns the default SPI mode
+func (a *Adaptor) GetSpiDefaultMode() int { return 0 }
+
+// GetSpiDefaultBits returns the default SPI number of bits
+func (a *Adaptor) GetSpiDefaultBits() int { return 8 }
+
+// GetSpiDefaultMaxSpeed returns the default SPI max speed
+func (a *Adaptor) GetSpiDefaultMaxSpeed() int64 { return 500000 }
+
+// digitalPin returns the pin, it must be called with the mutex held
+func (a *Adaptor) digitalPin(pin string) *digitalPin {
+	p, ok := a.pins[pin]
+	if !ok {
+		p = &digitalPin{adaptor: a, pin: pin, dir: sysfs.IN}
+		a.pins[pin] = p
+	}
+	return p
+}
+
+// pwmPin returns the pin, it must be called with the mutex held
+func (a *Adaptor) pwmPin(pin string) *pwmPin {
+	p, ok := a.pwmPins[pin]
+	if !ok {
+		p = &pwmPin{adaptor: a, polarity: "normal", period: pwmPeriod}
+		a.pwmPins[pin] = p
+	}
+	return p
+}
+
+type digitalPin struct {
+	adaptor *Adaptor
+	pin     string
+	dir     string
+	value   int
+}
+
+func (p *digitalPin) Export() error   { return nil }
+func (p *digitalPin) Unexport() error { return nil }
+
+func (p *digitalPin) Direction(dir string) error {
+	if dir != sysfs.IN && dir != sysfs.OUT {
+		return fmt.Errorf("Invalid direction %s of pin %s", dir, p.pin)
+	}
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	p.dir = dir
+	return nil
+}
+
+func (p *digitalPin) Read() (int, error) {
+	return p.adaptor.DigitalRead(p.pin)
+}
+
+func (p *digitalPin) Write(val int) error {
+	return p.adaptor.DigitalWrite(p.pin, byte(val))
+}
+
+type pwmPin struct {
+	adaptor  *Adaptor
+	enabled  bool
+	polarity string
+	period   uint32
+	duty     uint32
+}
+
+func (p *pwmPin) Export() error   { return nil }
+func (p *pwmPin) Unexport() error { return nil }
+
+func (p *pwmPin) Enable(enable bool) error {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	p.enabled = enable
+	return nil
+}
+
+func (p *pwmPin) Polarity() (string, error) {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	return p.polarity, nil
+}
+
+func (p *pwmPin) InvertPolarity(invert bool) error {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	p.polarity = "normal"
+	if invert {
+		p.polarity = "inverted"
+	}
+	return nil
+}
+
+func (p *pwmPin) Period() (uint32, error) {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	return p.period, nil
+}
+
+func (p *pwmPin) SetPeriod(period uint32) error {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	p.period = period
+	return nil
+}
+
+func (p *pwmPin) DutyCycle() (uint32, error) {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	return p.duty, nil
+}
+
+func (p *pwmPin) SetDutyCycle(duty uint32) error {
+	p.adaptor.mutex.Lock()
+	defer p.adaptor.mutex.Unlock()
+
+	if duty > p.period {
+		return fmt.Errorf("Duty cycle %d exceeds the period %d", duty, p.period)
+	}
+	p.duty = duty
+	return nil
+}
@@ -0,0 +1,157 @@
+package simulator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/aio"
+	"gobot.io/x/gobot/drivers/gpio"
+	"gobot.io/x/gobot/drivers/i2c"
+	"gobot.io/x/gobot/drivers/spi"
+	"gobot.io/x/gobot/gobottest"
+	"gobot.io/x/gobot/sysfs"
+)
+
+// make sure that this Adaptor fullfills all the required interfaces
+var _ gobot.Adaptor = (*Adaptor)(nil)
+var _ gpio.DigitalReader = (*Adaptor)(nil)
+var _ gpio.DigitalWriter = (*Adaptor)(nil)
+var _ gpio.PwmWriter = (*Adaptor)(nil)
+var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ aio.AnalogReader = (*Adaptor)(nil)
+var _ sysfs.DigitalPinnerProvider = (*Adaptor)(nil)
+var _ sysfs.PWMPinnerProvider = (*Adaptor)(nil)
+var _ i2c.Connector = (*Adaptor)(nil)
+var _ spi.Connector = (*Adaptor)(nil)
+
+func TestSimulatorAdaptor(t *testing.T) {
+	a := NewAdaptor()
+	gobottest.Assert(t, strings.HasPrefix(a.Name(), "Simulator"), true)
+	a.SetName("sim")
+	gobottest.Assert(t, a.Name(), "sim")
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.Finalize(), nil)
+}
+
+func TestSimulatorAdaptorDigital(t *testing.T) {
+	a := NewAdaptor()
+	led := gpio.NewLedDriver(a, "7")
+	gobottest.Assert(t, led.On(), nil)
+
+	val, err := a.DigitalRead("7")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1)
+
+	pin, err := a.DigitalPin("7", sysfs.IN)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, pin.Write(0), nil)
+	val, _ = pin.Read()
+	gobottest.Assert(t, val, 0)
+
+	_, err = a.DigitalPin("7", "sideways")
+	gobottest.Assert(t, err, errors.New("Invalid direction sideways of pin 7"))
+
+	// a waveform drives the input
+	a.SetWaveform("8", Constant(100))
+	val, _ = a.DigitalRead("8")
+	gobottest.Assert(t, val, 1)
+}
+
+func TestSimulatorAdaptorAnalogRead(t *testing.T) {
+	a := NewAdaptor()
+	_, err := a.AnalogRead("A0")
+	gobottest.Assert(t, err, errors.New("No waveform for analog pin A0"))
+
+	a.SetWaveform("A0", Constant(512))
+	val, err := a.AnalogRead("A0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 512)
+}
+
+func TestSimulatorAdaptorPwm(t *testing.T) {
+	a := NewAdaptor()
+	gobottest.Assert(t, a.PwmWrite("3", 255), nil)
+	pin, _ := a.PWMPin("3")
+	duty, _ := pin.DutyCycle()
+	gobottest.Assert(t, duty, uint32(pwmPeriod))
+
+	gobottest.Assert(t, a.ServoWrite("3", 90), nil)
+	period, _ := pin.Period()
+	gobottest.Assert(t, period, uint32(servoPeriod))
+	duty, _ = pin.DutyCycle()
+	gobottest.Assert(t, duty, uint32(1500000))
+
+	gobottest.Assert(t, pin.SetDutyCycle(servoPeriod+1),
+		errors.New("Duty cycle 20000001 exceeds the period 20000000"))
+	gobottest.Assert(t, pin.InvertPolarity(true), nil)
+	polarity, _ := pin.Polarity()
+	gobottest.Assert(t, polarity, "inverted")
+}
+
+func TestSimulatorAdaptorI2c(t *testing.T) {
+	a := NewAdaptor()
+	_, err := a.GetConnection(0x40, 1)
+	gobottest.Assert(t, err, errors.New("No i2c device at address 0x40 on bus 1"))
+
+	regs := NewRegisters(map[uint8]uint8{0x10: 0x34, 0x11: 0x12})
+	a.AddI2cDevice(1, 0x40, regs)
+	conn, err := a.GetConnection(0x40, 1)
+	gobottest.Assert(t, err, nil)
+
+	word, err := conn.ReadWordData(0x10)
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, word, uint16(0x1234))
+
+	gobottest.Assert(t, conn.WriteByteData(0x20, 0xAB), nil)
+	gobottest.Assert(t, regs.Get(0x20), uint8(0xAB))
+
+	gobottest.Assert(t, conn.WriteBlockData(0x30, []byte{1, 2, 3}), nil)
+	b := make([]byte, 3)
+	gobottest.Assert(t, conn.WriteByte(0x30), nil)
+	n, err := conn.Read(b)
+	gobottest.Assert(t, n, 3)
+	gobottest.Assert(t, b, []byte{1, 2, 3})
+
+	regs.Set(0x21, 0x42)
+	val, _ := conn.ReadByteData(0x21)
+	gobottest.Assert(t, val, uint8(0x42))
+	gobottest.Assert(t, conn.Close(), nil)
+}
+
+func TestSimulatorAdaptorSpi(t *testing.T) {
+	a := NewAdaptor()
+	_, err := a.GetSpiConnection(0, 1, 0, 8, 500000)
+	gobottest.Assert(t, err, errors.New("No SPI device at chip 1 on bus 0"))
+
+	a.AddSpiDevice(0, 1, SpiFunc(func(w, r []byte) error {
+		for i := range r {
+			r[i] = w[i] + 1
+		}
+		return nil
+	}))
+	conn, err := a.GetSpiConnection(0, 1, 0, 8, 500000)
+	gobottest.Assert(t, err, nil)
+	r := make([]byte, 2)
+	gobottest.Assert(t, conn.Tx([]byte{1, 2}, r), nil)
+	gobottest.Assert(t, r, []byte{2, 3})
+	gobottest.Assert(t, conn.Close(), nil)
+}
+
+func TestSimulatorWaveforms(t *testing.T) {
+	period := 4 * time.Second
+	sine := Sine(0, 100, period)
+	gobottest.Assert(t, sine(0), 50)
+	gobottest.Assert(t, sine(time.Second), 100)
+	gobottest.Assert(t, sine(3*time.Second), 0)
+
+	square := Square(0, 1, period)
+	gobottest.Assert(t, square(time.Second), 1)
+	gobottest.Assert(t, square(3*time.Second), 0)
+
+	sawtooth := Sawtooth(0, 100, period)
+	gobottest.Assert(t, sawtooth(time.Second), 25)
+	gobottest.Assert(t, sawtooth(5*time.Second), 25)
+}
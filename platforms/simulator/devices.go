@@ -0,0 +1,239 @@
+package simulator
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrNotEnoughBytes is the error resulting when a virtual i2c device returns
+// fewer bytes than requested
+var ErrNotEnoughBytes = errors.New("Not enough bytes read")
+
+// Waveform returns the value of an input at the time since the adaptor was
+// connected
+type Waveform func(t time.Duration) int
+
+// Constant returns a Waveform of a constant value
+func Constant(val int) Waveform {
+	return func(time.Duration) int { return val }
+}
+
+// Sine returns a Waveform of a sine wave between min and max, starting in
+// the middle
+func Sine(min, max int, period time.Duration) Waveform {
+	return func(t time.Duration) int {
+		phase := 2 * math.Pi * float64(t%period) / float64(period)
+		return min + int(math.Round(float64(max-min)*(1+math.Sin(phase))/2))
+	}
+}
+
+// Square returns a Waveform, which is max for the first half of each period
+// and min for the second half
+func Square(min, max int, period time.Duration) Waveform {
+	return func(t time.Duration) int {
+		if t%period < period/2 {
+			return max
+		}
+		return min
+	}
+}
+
+// Sawtooth returns a Waveform, which rises from min to max within each
+// period
+func Sawtooth(min, max int, period time.Duration) Waveform {
+	return func(t time.Duration) int {
+		return min + int(float64(max-min)*float64(t%period)/float64(period))
+	}
+}
+
+// I2cDevice is a virtual device on an i2c bus, the SMBus operations of a
+// connection are sent as plain writes and reads.
+type I2cDevice interface {
+	Read(b []byte) (n int, err error)
+	Write(b []byte) (n int, err error)
+}
+
+// Registers is a virtual i2c device with 256 registers of a byte, as most i2c
+// devices are accessed. The first byte of a write sets the register pointer,
+// the following bytes are written to the registers from the pointer on. A
+// read reads the registers from the pointer on. The pointer is incremented
+// with each register.
+type Registers struct {
+	regs    [256]uint8
+	pointer uint8
+	mutex   sync.Mutex
+}
+
+// NewRegisters returns a new Registers device with the initial register
+// values, all other registers are 0
+func NewRegisters(values map[uint8]uint8) *Registers {
+	r := &Registers{}
+	for reg, val := range values {
+		r.regs[reg] = val
+	}
+	return r
+}
+
+// Get returns the value of the register
+func (r *Registers) Get(reg uint8) uint8 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.regs[reg]
+}
+
+// Set sets the value of the register, e.g. to simulate a new measurement
+func (r *Registers) Set(reg uint8, val uint8) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.regs[reg] = val
+}
+
+// Read reads the registers from the register pointer on
+func (r *Registers) Read(b []byte) (n int, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i := range b {
+		b[i] = r.regs[r.pointer]
+		r.pointer++
+	}
+	return len(b), nil
+}
+
+// Write sets the register pointer and writes the registers from there on
+func (r *Registers) Write(b []byte) (n int, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(b) == 0 {
+		return 0, nil
+	}
+	r.pointer = b[0]
+	for _, val := range b[1:] {
+		r.regs[r.pointer] = val
+		r.pointer++
+	}
+	return len(b), nil
+}
+
+// SpiDevice is a virtual device on a SPI bus
+type SpiDevice interface {
+	// Tx writes w and reads r in a single full-duplex transfer
+	Tx(w, r []byte) error
+}
+
+// SpiFunc is a SpiDevice implemented by a function, e.g. to simulate a
+// SPI ADC by a Waveform.
+type SpiFunc func(w, r []byte) error
+
+// Tx calls the function
+func (f SpiFunc) Tx(w, r []byte) error { return f(w, r) }
+
+type i2cConnection struct {
+	device I2cDevice
+	mutex  sync.Mutex
+}
+
+func (c *i2cConnection) Read(b []byte) (n int, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.device.Read(b)
+}
+
+func (c *i2cConnection) Write(b []byte) (n int, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.device.Write(b)
+}
+
+func (c *i2cConnection) Close() error { return nil }
+
+func (c *i2cConnection) ReadByte() (val byte, err error) {
+	b := []byte{0}
+	if err = c.read(b); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (c *i2cConnection) ReadByteData(reg uint8) (val uint8, err error) {
+	b := []byte{0}
+	if err = c.readRegister(reg, b); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// ReadWordData reads a word as little endian, as the SMBus does
+func (c *i2cConnection) ReadWordData(reg uint8) (val uint16, err error) {
+	b := []byte{0, 0}
+	if err = c.readRegister(reg, b); err != nil {
+		return 0, err
+	}
+	return uint16(b[0]) | uint16(b[1])<<8, nil
+}
+
+func (c *i2cConnection) WriteByte(val byte) (err error) {
+	_, err = c.Write([]byte{val})
+	return
+}
+
+func (c *i2cConnection) WriteByteData(reg uint8, val uint8) (err error) {
+	_, err = c.Write([]byte{reg, val})
+	return
+}
+
+// WriteWordData writes a word as little endian, as the SMBus does
+func (c *i2cConnection) WriteWordData(reg uint8, val uint16) (err error) {
+	_, err = c.Write([]byte{reg, byte(val), byte(val >> 8)})
+	return
+}
+
+func (c *i2cConnection) WriteBlockData(reg uint8, b []byte) (err error) {
+	_, err = c.Write(append([]byte{reg}, b...))
+	return
+}
+
+// readRegister writes the register and reads b, without another transfer
+// in between
+func (c *i2cConnection) readRegister(reg uint8, b []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, err := c.device.Write([]byte{reg}); err != nil {
+		return err
+	}
+	return c.readLocked(b)
+}
+
+func (c *i2cConnection) read(b []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.readLocked(b)
+}
+
+func (c *i2cConnection) readLocked(b []byte) error {
+	n, err := c.device.Read(b)
+	if err != nil {
+		return err
+	}
+	if n < len(b) {
+		return ErrNotEnoughBytes
+	}
+	return nil
+}
+
+type spiConnection struct {
+	device SpiDevice
+}
+
+func (c *spiConnection) Close() error { return nil }
+
+func (c *spiConnection) Tx(w, r []byte) error { return c.device.Tx(w, r) }
@@ -0,0 +1,9 @@
+/*
+Package simulator contains the Gobot adaptor for a simulated board with
+virtual digital pins, PWM, analog inputs, i2c and SPI devices, to run and
+demo robots without hardware.
+
+For further information refer to simulator README:
+https://github.com/hybridgroup/gobot/blob/master/platforms/simulator/README.md
+*/
+package simulator // import "gobot.io/x/gobot/platforms/simulator"
@@ -22,7 +22,7 @@ Example:
 		driver := sphero.NewSpheroDriver(adaptor)
 
 		work := func() {
-			gobot.Every(3*time.Second, func() {
+			gobot.EveryTimer(3*time.Second, func() {
 				driver.Roll(30, uint16(gobot.Rand(360)))
 			})
 		}
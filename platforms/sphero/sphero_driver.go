@@ -39,6 +39,7 @@ type SpheroDriver struct {
 	responseChannel chan []uint8
 	gobot.Eventer
 	gobot.Commander
+	gobot.CommandSchemer
 }
 
 // NewSpheroDriver returns a new SpheroDriver given a Sphero Adaptor.
@@ -60,6 +61,7 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		connection:      a,
 		Eventer:         gobot.NewEventer(),
 		Commander:       gobot.NewCommander(),
+		CommandSchemer:  gobot.NewCommandSchemer(),
 		packetChannel:   make(chan *packet, 1024),
 		responseChannel: make(chan []uint8, 1024),
 	}
@@ -75,6 +77,7 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		s.SetRGB(r, g, b)
 		return nil
 	})
+	s.AddCommandSchema("SetRGB", gobot.CommandSchema{"r": gobot.CommandParamNumber, "g": gobot.CommandParamNumber, "b": gobot.CommandParamNumber})
 
 	s.AddCommand("Roll", func(params map[string]interface{}) interface{} {
 		speed := uint8(params["speed"].(float64))
@@ -82,6 +85,7 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		s.Roll(speed, heading)
 		return nil
 	})
+	s.AddCommandSchema("Roll", gobot.CommandSchema{"speed": gobot.CommandParamNumber, "heading": gobot.CommandParamNumber})
 
 	s.AddCommand("Stop", func(params map[string]interface{}) interface{} {
 		s.Stop()
@@ -101,24 +105,28 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		s.SetBackLED(level)
 		return nil
 	})
+	s.AddCommandSchema("SetBackLED", gobot.CommandSchema{"level": gobot.CommandParamNumber})
 
 	s.AddCommand("SetRotationRate", func(params map[string]interface{}) interface{} {
 		level := uint8(params["level"].(float64))
 		s.SetRotationRate(level)
 		return nil
 	})
+	s.AddCommandSchema("SetRotationRate", gobot.CommandSchema{"level": gobot.CommandParamNumber})
 
 	s.AddCommand("SetHeading", func(params map[string]interface{}) interface{} {
 		heading := uint16(params["heading"].(float64))
 		s.SetHeading(heading)
 		return nil
 	})
+	s.AddCommandSchema("SetHeading", gobot.CommandSchema{"heading": gobot.CommandParamNumber})
 
 	s.AddCommand("SetStabilization", func(params map[string]interface{}) interface{} {
 		on := params["enable"].(bool)
 		s.SetStabilization(on)
 		return nil
 	})
+	s.AddCommandSchema("SetStabilization", gobot.CommandSchema{"enable": gobot.CommandParamBool})
 
 	s.AddCommand("SetDataStreaming", func(params map[string]interface{}) interface{} {
 		N := uint16(params["N"].(float64))
@@ -130,6 +138,13 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		s.SetDataStreaming(DataStreamingConfig{N: N, M: M, Mask2: Mask2, Pcnt: Pcnt, Mask: Mask})
 		return nil
 	})
+	s.AddCommandSchema("SetDataStreaming", gobot.CommandSchema{
+		"N":     gobot.CommandParamNumber,
+		"M":     gobot.CommandParamNumber,
+		"Mask":  gobot.CommandParamNumber,
+		"Pcnt":  gobot.CommandParamNumber,
+		"Mask2": gobot.CommandParamNumber,
+	})
 
 	s.AddCommand("ConfigureLocator", func(params map[string]interface{}) interface{} {
 		Flags := uint8(params["Flags"].(float64))
@@ -140,6 +155,12 @@ func NewSpheroDriver(a *Adaptor) *SpheroDriver {
 		s.ConfigureLocator(LocatorConfig{Flags: Flags, X: X, Y: Y, YawTare: YawTare})
 		return nil
 	})
+	s.AddCommandSchema("ConfigureLocator", gobot.CommandSchema{
+		"Flags":   gobot.CommandParamNumber,
+		"X":       gobot.CommandParamNumber,
+		"Y":       gobot.CommandParamNumber,
+		"YawTare": gobot.CommandParamNumber,
+	})
 
 	return s
 }
@@ -227,10 +248,11 @@ func (s *SpheroDriver) Start() (err error) {
 // Returns true on successful halt.
 func (s *SpheroDriver) Halt() (err error) {
 	if s.adaptor().connected {
-		gobot.Every(10*time.Millisecond, func() {
+		stop := gobot.EveryTimer(10*time.Millisecond, func() {
 			s.Stop()
 		})
 		time.Sleep(1 * time.Second)
+		stop.Stop()
 	}
 	return
 }
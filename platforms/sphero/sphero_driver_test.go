@@ -78,6 +78,11 @@ func TestSpheroDriver(t *testing.T) {
 	ret = d.Command("ReadLocator")(nil)
 	gobottest.Assert(t, ret, []int16{})
 
+	gobottest.Assert(t, d.CommandSchema("Roll").Validate(map[string]interface{}{"speed": 100.0}),
+		map[string]string{"heading": "is required"})
+	gobottest.Assert(t, d.CommandSchema("SetStabilization").Validate(map[string]interface{}{"enable": 1.0}),
+		map[string]string{"enable": "must be of type bool"})
+
 	gobottest.Assert(t, strings.HasPrefix(d.Name(), "Sphero"), true)
 	gobottest.Assert(t, strings.HasPrefix(d.Connection().Name(), "Sphero"), true)
 }
@@ -3,6 +3,9 @@ package tinkerboard
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 
 	multierror "github.com/hashicorp/go-multierror"
@@ -18,22 +21,47 @@ type sysfsPin struct {
 
 // Adaptor represents a Gobot Adaptor for the ASUS Tinker Board
 type Adaptor struct {
-	name        string
-	pinmap      map[string]sysfsPin
-	digitalPins map[int]*sysfs.DigitalPin
-	pwmPins     map[int]*sysfs.PWMPin
-	i2cBuses    [2]i2c.I2cDevice
-	mutex       *sync.Mutex
+	name           string
+	pinmap         map[string]sysfsPin
+	analogPath     string
+	analogPinMap   map[string]string
+	digitalPins    map[int]*sysfs.DigitalPin
+	pwmPins        map[int]*sysfs.PWMPin
+	i2cBuses       [2]i2c.I2cDevice
+	i2cConnections i2c.Connections
+	mutex          *sync.Mutex
+	// the first gpiochip of the board and its first GPIO, see WithGpiochip
+	gpiochip     string
+	gpiochipBase int
+}
+
+// WithGpiochip option sets the first gpiochip of the board, given by its label
+// or sysfs name, e.g. "gpio0". The GPIOs of the pins are offset by the first
+// GPIO of the chip, for kernels which don't number the GPIOs from 0 on, e.g.
+// from 512 on. By default the GPIOs are used as numbered in the pin map.
+func WithGpiochip(chip string) func(*Adaptor) {
+	return func(c *Adaptor) {
+		c.gpiochip = chip
+	}
 }
 
 // NewAdaptor creates a Tinkerboard Adaptor
-func NewAdaptor() *Adaptor {
+//
+// Optional params:
+//		tinkerboard.WithGpiochip(string):	first gpiochip of the board
+//
+func NewAdaptor(options ...func(*Adaptor)) *Adaptor {
 	c := &Adaptor{
-		name:  gobot.DefaultName("Tinker Board"),
-		mutex: &sync.Mutex{},
+		name:         gobot.DefaultName("Tinker Board"),
+		analogPath:   "/sys/bus/iio/devices/iio:device0",
+		analogPinMap: analogPins,
+		mutex:        &sync.Mutex{},
 	}
 
 	c.setPins()
+	for _, option := range options {
+		option(c)
+	}
 	return c
 }
 
@@ -43,9 +71,16 @@ func (c *Adaptor) Name() string { return c.name }
 // SetName sets the name of the Adaptor
 func (c *Adaptor) SetName(n string) { c.name = n }
 
-// Connect initializes the board
+// Connect looks up the first GPIO of the gpiochip, if one is set
 func (c *Adaptor) Connect() (err error) {
-	return nil
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.gpiochip == "" {
+		return
+	}
+	c.gpiochipBase, err = sysfs.GpiochipBase(c.gpiochip)
+	return
 }
 
 // Finalize closes connection to board and pins
@@ -53,14 +88,15 @@ func (c *Adaptor) Finalize() (err error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	for _, pin := range c.digitalPins {
+	for i, pin := range c.digitalPins {
 		if pin != nil {
 			if e := pin.Unexport(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.digitalPins[i] = nil
 		}
 	}
-	for _, pin := range c.pwmPins {
+	for i, pin := range c.pwmPins {
 		if pin != nil {
 			if errs := pin.Enable(false); errs != nil {
 				err = multierror.Append(err, errs)
@@ -68,13 +104,16 @@ func (c *Adaptor) Finalize() (err error) {
 			if errs := pin.Unexport(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			c.pwmPins[i] = nil
 		}
 	}
-	for _, bus := range c.i2cBuses {
+	c.i2cConnections.Close()
+	for i, bus := range c.i2cBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.i2cBuses[i] = nil
 		}
 	}
 	return
@@ -131,6 +170,28 @@ func (c *Adaptor) ServoWrite(pin string, angle byte) (err error) {
 	return pwmPin.SetDutyCycle(duty)
 }
 
+// AnalogRead returns the raw 10 bit value of a SARADC channel, which
+// corresponds to 0-1.8V
+func (c *Adaptor) AnalogRead(pin string) (val int, err error) {
+	analogPin, err := c.translateAnalogPin(pin)
+	if err != nil {
+		return
+	}
+	fi, err := sysfs.OpenFile(fmt.Sprintf("%v/%v", c.analogPath, analogPin), os.O_RDONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer fi.Close()
+
+	var buf = make([]byte, 1024)
+	n, err := fi.Read(buf)
+	if err != nil {
+		return
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(buf[:n])))
+}
+
 // DigitalPin returns matched digitalPin for specified values
 func (c *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinner, err error) {
 	c.mutex.Lock()
@@ -141,6 +202,7 @@ func (c *Adaptor) DigitalPin(pin string, dir string) (sysfsPin sysfs.DigitalPinn
 	if err != nil {
 		return
 	}
+	i += c.gpiochipBase
 
 	if c.digitalPins[i] == nil {
 		c.digitalPins[i] = sysfs.NewDigitalPin(i)
@@ -206,7 +268,10 @@ func (c *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 	if c.i2cBuses[bus] == nil {
 		c.i2cBuses[bus], err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
 	}
-	return i2c.NewConnection(c.i2cBuses[bus], address), err
+	if err != nil {
+		return nil, err
+	}
+	return c.i2cConnections.Get(c.i2cBuses[bus], address), nil
 }
 
 // GetDefaultBus returns the default i2c bus for this platform
@@ -237,3 +302,12 @@ func (c *Adaptor) translatePwmPin(pin string) (i int, err error) {
 	}
 	return
 }
+
+func (c *Adaptor) translateAnalogPin(pin string) (value string, err error) {
+	if val, ok := c.analogPinMap[pin]; ok {
+		value = val
+	} else {
+		err = errors.New("Not a valid analog pin")
+	}
+	return
+}
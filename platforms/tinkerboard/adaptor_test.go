@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"gobot.io/x/gobot"
+	"gobot.io/x/gobot/drivers/aio"
 	"gobot.io/x/gobot/drivers/gpio"
 	"gobot.io/x/gobot/drivers/i2c"
 	"gobot.io/x/gobot/gobottest"
@@ -18,6 +19,7 @@ var _ gpio.DigitalReader = (*Adaptor)(nil)
 var _ gpio.DigitalWriter = (*Adaptor)(nil)
 var _ gpio.PwmWriter = (*Adaptor)(nil)
 var _ gpio.ServoWriter = (*Adaptor)(nil)
+var _ aio.AnalogReader = (*Adaptor)(nil)
 var _ sysfs.DigitalPinnerProvider = (*Adaptor)(nil)
 var _ sysfs.PWMPinnerProvider = (*Adaptor)(nil)
 var _ i2c.Connector = (*Adaptor)(nil)
@@ -37,6 +39,7 @@ func initTestTinkerboardAdaptor() (*Adaptor, *sysfs.MockFilesystem) {
 		"/sys/class/pwm/pwmchip0/pwm0/period",
 		"/sys/class/pwm/pwmchip0/pwm0/duty_cycle",
 		"/sys/class/pwm/pwmchip0/pwm0/polarity",
+		"/sys/bus/iio/devices/iio:device0/in_voltage1_raw",
 	})
 
 	sysfs.SetFilesystem(fs)
@@ -65,6 +68,48 @@ func TestTinkerboardAdaptorDigitalIO(t *testing.T) {
 	gobottest.Assert(t, a.Finalize(), nil)
 }
 
+func TestTinkerboardAdaptorWithGpiochip(t *testing.T) {
+	fs := sysfs.NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpiochip512/base",
+		"/sys/class/gpio/gpiochip512/label",
+		"/sys/class/gpio/gpio529/value",
+		"/sys/class/gpio/gpio529/direction",
+	})
+	fs.Files["/sys/class/gpio/gpiochip512/base"].Contents = "512\n"
+	fs.Files["/sys/class/gpio/gpiochip512/label"].Contents = "gpio0\n"
+	sysfs.SetFilesystem(fs)
+
+	a := NewAdaptor(WithGpiochip("gpio0"))
+	gobottest.Assert(t, a.Connect(), nil)
+	gobottest.Assert(t, a.DigitalWrite("7", 1), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/export"].Contents, "529")
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio529/value"].Contents, "1")
+	gobottest.Assert(t, a.Finalize(), nil)
+
+	a = NewAdaptor(WithGpiochip("notexist"))
+	gobottest.Assert(t, a.Connect(), errors.New("No gpiochip 'notexist' found"))
+}
+
+func TestTinkerboardAdaptorAnalogRead(t *testing.T) {
+	a, fs := initTestTinkerboardAdaptor()
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_raw"].Contents = "567\n"
+	i, err := a.AnalogRead("adc1")
+	gobottest.Assert(t, i, 567)
+	gobottest.Assert(t, err, nil)
+
+	_, err = a.AnalogRead("adc9")
+	gobottest.Assert(t, err, errors.New("Not a valid analog pin"))
+
+	_, err = a.AnalogRead("adc0")
+	gobottest.Refute(t, err, nil)
+
+	fs.WithReadError = true
+	_, err = a.AnalogRead("adc1")
+	gobottest.Assert(t, err, errors.New("read error"))
+}
+
 func TestAdaptorDigitalWriteError(t *testing.T) {
 	a, fs := initTestTinkerboardAdaptor()
 	fs.WithWriteError = true
@@ -114,3 +114,11 @@ var fixedPins = map[string]sysfsPin{
 		pwmPin: -1,
 	},
 }
+
+// analogPins are the channels of the RK3288 SARADC, which are read by the
+// IIO subsystem
+var analogPins = map[string]string{
+	"adc0": "in_voltage0_raw",
+	"adc1": "in_voltage1_raw",
+	"adc2": "in_voltage2_raw",
+}
@@ -41,6 +41,7 @@ type Adaptor struct {
 	digitalPins        map[int]*sysfs.DigitalPin
 	pwmPins            map[int]*sysfs.PWMPin
 	i2cBuses           [6]i2c.I2cDevice
+	i2cConnections     i2c.Connections
 	mutex              *sync.Mutex
 	spiDefaultBus      int
 	spiDefaultChip     int
@@ -77,14 +78,15 @@ func (c *Adaptor) Finalize() (err error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	for _, pin := range c.digitalPins {
+	for i, pin := range c.digitalPins {
 		if pin != nil {
 			if e := pin.Unexport(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.digitalPins[i] = nil
 		}
 	}
-	for _, pin := range c.pwmPins {
+	for i, pin := range c.pwmPins {
 		if pin != nil {
 			if errs := pin.Enable(false); errs != nil {
 				err = multierror.Append(err, errs)
@@ -92,20 +94,24 @@ func (c *Adaptor) Finalize() (err error) {
 			if errs := pin.Unexport(); errs != nil {
 				err = multierror.Append(err, errs)
 			}
+			c.pwmPins[i] = nil
 		}
 	}
-	for _, bus := range c.i2cBuses {
+	c.i2cConnections.Close()
+	for i, bus := range c.i2cBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.i2cBuses[i] = nil
 		}
 	}
-	for _, bus := range c.spiBuses {
+	for i, bus := range c.spiBuses {
 		if bus != nil {
 			if e := bus.Close(); e != nil {
 				err = multierror.Append(err, e)
 			}
+			c.spiBuses[i] = nil
 		}
 	}
 
@@ -250,7 +256,10 @@ func (c *Adaptor) GetConnection(address int, bus int) (connection i2c.Connection
 	if c.i2cBuses[bus] == nil {
 		c.i2cBuses[bus], err = sysfs.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", bus))
 	}
-	return i2c.NewConnection(c.i2cBuses[bus], address), err
+	if err != nil {
+		return nil, err
+	}
+	return c.i2cConnections.Get(c.i2cBuses[bus], address), nil
 }
 
 // GetDefaultBus returns the default i2c bus for this platform
@@ -0,0 +1,142 @@
+package gobot
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// PollOption is an option of a Poller, which is accepted by the drivers
+// emitting periodic events
+type PollOption func(*Poller)
+
+// WithPollInterval option sets the interval between two polls.
+func WithPollInterval(interval time.Duration) PollOption {
+	return func(p *Poller) {
+		p.interval = interval
+	}
+}
+
+// WithPollJitter option adds a random time up to the given jitter to each
+// interval, so several drivers with the same interval don't access a shared
+// bus at the same time.
+func WithPollJitter(jitter time.Duration) PollOption {
+	return func(p *Poller) {
+		p.jitter = jitter
+	}
+}
+
+// WithPollBackoff option doubles the interval after each failed poll up to
+// the given maximum, until a poll succeeds again.
+func WithPollBackoff(max time.Duration) PollOption {
+	return func(p *Poller) {
+		p.maxBackoff = max
+	}
+}
+
+// Poller calls a poll function in its own goroutine, at first immediately
+// and then after each interval, until it is stopped.
+type Poller struct {
+	interval   time.Duration
+	jitter     time.Duration
+	maxBackoff time.Duration
+	halt       chan struct{}
+	done       chan struct{}
+	mutex      sync.Mutex
+}
+
+// NewPoller returns a new Poller with the default interval and options.
+func NewPoller(interval time.Duration, options ...PollOption) *Poller {
+	p := &Poller{interval: interval}
+	p.Apply(options...)
+	return p
+}
+
+// Apply applies the options to the Poller, they take effect on the next
+// start.
+func (p *Poller) Apply(options ...PollOption) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, option := range options {
+		option(p)
+	}
+}
+
+// Interval returns the interval between two polls
+func (p *Poller) Interval() time.Duration {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.interval
+}
+
+// Running returns true, if the Poller is started
+func (p *Poller) Running() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.halt != nil
+}
+
+// Start starts calling poll, a running poll goroutine is stopped before. A
+// poll function which returns an error is called again after the backoff.
+func (p *Poller) Start(poll func() error) {
+	p.Stop()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.halt = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.run(poll, p.interval, p.jitter, p.maxBackoff, p.halt, p.done)
+}
+
+// Stop stops the poll goroutine and waits until a running poll has
+// returned, so it must not be called from the poll function. Stop does
+// nothing, if the Poller is not started.
+func (p *Poller) Stop() {
+	p.mutex.Lock()
+	halt, done := p.halt, p.done
+	p.halt, p.done = nil, nil
+	p.mutex.Unlock()
+
+	if halt == nil {
+		return
+	}
+	close(halt)
+	<-done
+}
+
+func (p *Poller) run(poll func() error, interval, jitter, maxBackoff time.Duration,
+	halt chan struct{}, done chan struct{}) {
+	defer close(done)
+
+	wait := interval
+	for {
+		select {
+		case <-halt:
+			return
+		default:
+		}
+
+		if err := poll(); err != nil && maxBackoff > wait {
+			wait *= 2
+			if wait > maxBackoff {
+				wait = maxBackoff
+			}
+		} else if err == nil {
+			wait = interval
+		}
+
+		next := wait
+		if jitter > 0 {
+			next += time.Duration(rand.Int63n(int64(jitter)))
+		}
+		timer := time.NewTimer(next)
+		select {
+		case <-timer.C:
+		case <-halt:
+			timer.Stop()
+			return
+		}
+	}
+}
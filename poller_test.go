@@ -0,0 +1,91 @@
+package gobot
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestNewPoller(t *testing.T) {
+	p := NewPoller(10*time.Millisecond, WithPollInterval(time.Second),
+		WithPollJitter(time.Millisecond), WithPollBackoff(time.Minute))
+	gobottest.Assert(t, p.Interval(), time.Second)
+	gobottest.Assert(t, p.jitter, time.Millisecond)
+	gobottest.Assert(t, p.maxBackoff, time.Minute)
+	gobottest.Assert(t, p.Running(), false)
+
+	p.Apply(WithPollInterval(time.Millisecond))
+	gobottest.Assert(t, p.Interval(), time.Millisecond)
+}
+
+func TestPollerStartStop(t *testing.T) {
+	p := NewPoller(time.Millisecond)
+	var mutex sync.Mutex
+	polls := 0
+	p.Start(func() error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		polls++
+		return nil
+	})
+	gobottest.Assert(t, p.Running(), true)
+
+	time.Sleep(20 * time.Millisecond)
+	p.Stop()
+	gobottest.Assert(t, p.Running(), false)
+
+	mutex.Lock()
+	stopped := polls
+	mutex.Unlock()
+	gobottest.Assert(t, stopped > 1, true)
+
+	// no polls after stop
+	time.Sleep(10 * time.Millisecond)
+	mutex.Lock()
+	gobottest.Assert(t, polls, stopped)
+	mutex.Unlock()
+
+	// stopping twice is ok
+	p.Stop()
+}
+
+func TestPollerRestart(t *testing.T) {
+	p := NewPoller(time.Hour)
+	first := make(chan bool, 1)
+	second := make(chan bool, 1)
+	p.Start(func() error {
+		first <- true
+		return nil
+	})
+	<-first
+	p.Start(func() error {
+		second <- true
+		return nil
+	})
+	<-second
+	p.Stop()
+	gobottest.Assert(t, len(first), 0)
+}
+
+func TestPollerBackoff(t *testing.T) {
+	p := NewPoller(time.Millisecond, WithPollBackoff(40*time.Millisecond))
+	var mutex sync.Mutex
+	var times []time.Time
+	p.Start(func() error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		times = append(times, time.Now())
+		return errors.New("poll error")
+	})
+	time.Sleep(100 * time.Millisecond)
+	p.Stop()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	// 1, 2, 4, 8, 16, 32 and 40ms would be at least 103ms for all polls
+	gobottest.Assert(t, len(times) < 8, true)
+	gobottest.Assert(t, times[len(times)-1].Sub(times[len(times)-2]) >= 16*time.Millisecond, true)
+}
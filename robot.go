@@ -1,11 +1,13 @@
 package gobot
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"sync/atomic"
+	"syscall"
 
 	"sync"
 
@@ -115,7 +117,7 @@ func NewRobot(v ...interface{}) *Robot {
 		devices:     &Devices{},
 		done:        make(chan bool, 1),
 		trap: func(c chan os.Signal) {
-			signal.Notify(c, os.Interrupt)
+			signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		},
 		AutoRun:   true,
 		Work:      nil,
@@ -197,7 +199,8 @@ func (r *Robot) Start(args ...interface{}) (err error) {
 	return
 }
 
-// Stop stops a Robot's connections and Devices
+// Stop stops a Robot's connections and Devices. The connections are
+// finalized concurrently, see Connections.Finalize. Stop can be called again.
 func (r *Robot) Stop() error {
 	var result error
 	log.Println("Stopping Robot", r.Name, "...")
@@ -210,11 +213,54 @@ func (r *Robot) Stop() error {
 		result = multierror.Append(result, err)
 	}
 
-	r.done <- true
-	r.running.Store(false)
+	r.release()
 	return result
 }
 
+// Run starts the Robot and blocks until the context is done or SIGINT or
+// SIGTERM is received. Then the work registered by Robot.Every and
+// Robot.After is cancelled, the devices are halted in the reverse order of
+// their start, so a device is halted before the devices it depends on, see
+// Dependent, and the connections are finalized, see Connections.Finalize.
+// All errors of the start and shutdown are returned.
+func (r *Robot) Run(ctx context.Context) (err error) {
+	if serr := r.Start(false); serr != nil {
+		err = multierror.Append(err, serr)
+	} else {
+		c := make(chan os.Signal, 1)
+		r.trap(c)
+		defer signal.Stop(c)
+
+		select {
+		case <-ctx.Done():
+		case <-c:
+		}
+	}
+
+	log.Println("Stopping Robot", r.Name, "...")
+	r.workRegistry.cancelAll()
+
+	if herr := r.Devices().haltDependentsFirst(); herr != nil {
+		err = multierror.Append(err, herr)
+	}
+	if ferr := r.Connections().Finalize(); ferr != nil {
+		err = multierror.Append(err, ferr)
+	}
+
+	r.release()
+	return
+}
+
+// release releases the work routine and marks the Robot as stopped. The work
+// routine is released only once, so the Robot can be stopped again.
+func (r *Robot) release() {
+	select {
+	case r.done <- true:
+	default:
+	}
+	r.running.Store(false)
+}
+
 // Running returns if the Robot is currently started or not
 func (r *Robot) Running() bool {
 	return r.running.Load().(bool)
@@ -245,6 +291,18 @@ func (r *Robot) Device(name string) Device {
 	return nil
 }
 
+// Telemetry returns the telemetry snapshots of all devices of the robot,
+// which implement the Telemeter interface, by the device name.
+func (r *Robot) Telemetry() map[string]map[string]interface{} {
+	telemetry := map[string]map[string]interface{}{}
+	r.devices.Each(func(d Device) {
+		if t, ok := d.(Telemeter); ok {
+			telemetry[d.Name()] = t.Telemetry()
+		}
+	})
+	return telemetry
+}
+
 // Connections returns all connections associated with this robot.
 func (r *Robot) Connections() *Connections {
 	return r.connections
@@ -1,6 +1,9 @@
 package gobot
 
 import (
+	"context"
+	"errors"
+	"os"
 	"testing"
 	"time"
 
@@ -65,3 +68,102 @@ func TestRobotStartAutoRun(t *testing.T) {
 	gobottest.Assert(t, r.Stop(), nil)
 	gobottest.Assert(t, r.Running(), false)
 }
+
+type testTelemeterDriver struct {
+	*testDriver
+}
+
+func (t *testTelemeterDriver) Telemetry() map[string]interface{} {
+	return map[string]interface{}{"pin": t.pin}
+}
+
+func TestRobotTelemetry(t *testing.T) {
+	r := newTestRobot("Robot1")
+	gobottest.Assert(t, r.Telemetry(), map[string]map[string]interface{}{})
+
+	r.AddDevice(&testTelemeterDriver{newTestDriver(newTestAdaptor("Connection4", "/dev/null"), "Device4", "4")})
+	gobottest.Assert(t, r.Telemetry(), map[string]map[string]interface{}{
+		"Device4": {"pin": "4"},
+	})
+}
+
+type testHaltOrderDriver struct {
+	*testDriver
+	halted *[]string
+}
+
+func (t *testHaltOrderDriver) Halt() (err error) {
+	*t.halted = append(*t.halted, t.name)
+	return
+}
+
+func TestRobotRun(t *testing.T) {
+	var halted []string
+	adaptor := newTestAdaptor("Connection1", "/dev/null")
+	expander := &testHaltOrderDriver{newTestDriver(adaptor, "Expander", "0"), &halted}
+	led := &testHaltOrderDriver{newTestDriver(adaptor, "Led", "1"), &halted}
+	r := NewRobot("run", []Connection{adaptor}, []Device{expander, led})
+	r.trap = func(c chan os.Signal) {}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() { result <- r.Run(ctx) }()
+
+	time.Sleep(10 * time.Millisecond)
+	gobottest.Assert(t, r.Running(), true)
+	work := r.Every(context.Background(), time.Hour, func() {})
+
+	cancel()
+	gobottest.Assert(t, <-result, nil)
+	gobottest.Assert(t, r.Running(), false)
+	r.WorkEveryWaitGroup.Wait()
+	gobottest.Assert(t, r.WorkRegistry().Get(work.ID()), (*RobotWork)(nil))
+	// the devices are halted in reverse order
+	gobottest.Assert(t, halted, []string{"Led", "Expander"})
+}
+
+// testHaltOrderDependent is a testHaltOrderDriver, which depends on other
+// drivers
+type testHaltOrderDependent struct {
+	*testHaltOrderDriver
+	deps []Driver
+}
+
+func (t *testHaltOrderDependent) Dependencies() []Driver { return t.deps }
+
+func TestRobotRunHaltDependentsFirst(t *testing.T) {
+	var halted []string
+	adaptor := newTestAdaptor("Connection1", "/dev/null")
+	expander := &testHaltOrderDriver{newTestDriver(adaptor, "Expander", "0"), &halted}
+	// the led is added before the expander it depends on
+	led := &testHaltOrderDependent{&testHaltOrderDriver{newTestDriver(adaptor, "Led", "1"), &halted}, []Driver{expander}}
+	sensor := &testHaltOrderDriver{newTestDriver(adaptor, "Sensor", "2"), &halted}
+	r := NewRobot("run", []Connection{adaptor}, []Device{led, expander, sensor})
+	r.trap = func(c chan os.Signal) {}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() { result <- r.Run(ctx) }()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	gobottest.Assert(t, <-result, nil)
+	gobottest.Assert(t, halted, []string{"Led", "Sensor", "Expander"})
+}
+
+func TestRobotRunSignal(t *testing.T) {
+	r := newTestRobot("Robot1")
+	gobottest.Assert(t, r.Run(context.Background()), nil)
+	gobottest.Assert(t, r.Running(), false)
+}
+
+func TestRobotRunStartError(t *testing.T) {
+	testAdaptorConnect = func() (err error) {
+		return errors.New("connect error")
+	}
+	defer func() { testAdaptorConnect = func() (err error) { return } }()
+
+	r := newTestRobot("Robot1")
+	gobottest.Refute(t, r.Run(context.Background()), nil)
+	gobottest.Assert(t, r.Running(), false)
+}
@@ -161,6 +161,15 @@ func (rwr *RobotWorkRegistry) delete(id uuid.UUID) {
 	delete(rwr.r, id.String())
 }
 
+// cancelAll cancels all units of RobotWork
+func (rwr *RobotWorkRegistry) cancelAll() {
+	rwr.Lock()
+	defer rwr.Unlock()
+	for _, rw := range rwr.r {
+		rw.cancelFunc()
+	}
+}
+
 // registerAfter creates a new unit of RobotWork and sets up its context/cancellation
 func (rwr *RobotWorkRegistry) registerAfter(ctx context.Context, d time.Duration, f func()) *RobotWork {
 	rwr.Lock()
@@ -0,0 +1,235 @@
+package gobot
+
+import (
+	"container/heap"
+	"log"
+	"sync"
+	"time"
+)
+
+// schedulerIdleTimeout is the time after which an idle worker of the
+// scheduler exits.
+const schedulerIdleTimeout = time.Second
+
+// Timer is the handle of a function scheduled by EveryTimer or AfterTimer. It
+// allows to cancel further executions of the function.
+type Timer struct {
+	scheduler *scheduler
+	f         func()
+	period    time.Duration
+	next      time.Time
+	// the tick of the running execution
+	due time.Time
+	// position in the heap of the scheduler, -1 when not scheduled
+	index     int
+	running   bool
+	overruns  int
+	onOverrun func(overrun time.Duration)
+}
+
+// Overruns returns how often an execution of a periodic function took longer
+// than the period.
+func (t *Timer) Overruns() int {
+	t.scheduler.mutex.Lock()
+	defer t.scheduler.mutex.Unlock()
+	return t.overruns
+}
+
+// OnOverrun sets the function, which is called when an execution of a
+// periodic function took longer than the period, with the time by which the
+// period was exceeded. By default the overrun is logged.
+func (t *Timer) OnOverrun(f func(overrun time.Duration)) {
+	t.scheduler.mutex.Lock()
+	defer t.scheduler.mutex.Unlock()
+	t.onOverrun = f
+}
+
+// Stop cancels further executions of the function. An execution which is
+// already running is not interrupted. Stop returns false, if the function
+// was not scheduled anymore, e.g. because AfterTimer has already fired.
+func (t *Timer) Stop() bool {
+	s := t.scheduler
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&s.timers, t.index)
+	return true
+}
+
+// scheduler runs the functions of all timers with a single goroutine, which
+// waits for the next due timer, and a pool of workers, which execute the
+// functions. Workers are started on demand and exit when being idle, so a
+// blocking function does not delay the others.
+type scheduler struct {
+	mutex   sync.Mutex
+	timers  timerHeap
+	running bool
+	wake    chan struct{}
+	jobs    chan *Timer
+}
+
+var defaultScheduler = newScheduler()
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		wake: make(chan struct{}, 1),
+		jobs: make(chan *Timer),
+	}
+}
+
+// schedule adds a timer, which fires first after the given delay
+func (s *scheduler) schedule(delay, period time.Duration, f func()) *Timer {
+	t := &Timer{
+		scheduler: s,
+		f:         f,
+		period:    period,
+		next:      time.Now().Add(delay),
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	heap.Push(&s.timers, t)
+	if !s.running {
+		s.running = true
+		go s.run()
+	} else if t.index == 0 {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return t
+}
+
+// run dispatches the due timers and waits for the next one, it returns when
+// no timers are left
+func (s *scheduler) run() {
+	wait := time.NewTimer(time.Hour)
+	defer wait.Stop()
+	for {
+		s.mutex.Lock()
+		now := time.Now()
+		for len(s.timers) > 0 && !s.timers[0].next.After(now) {
+			t := s.timers[0]
+			due := t.next
+			if t.period > 0 {
+				// the ticks are absolute, so the executions don't drift.
+				// Missed ticks are dropped.
+				t.next = t.next.Add(t.period)
+				if !t.next.After(now) {
+					t.next = t.next.Add((now.Sub(t.next)/t.period + 1) * t.period)
+				}
+				heap.Fix(&s.timers, 0)
+			} else {
+				heap.Pop(&s.timers)
+			}
+			// executions of the same timer don't overlap
+			if !t.running {
+				t.running = true
+				t.due = due
+				s.dispatch(t)
+			}
+		}
+		if len(s.timers) == 0 {
+			s.running = false
+			s.mutex.Unlock()
+			return
+		}
+		delay := s.timers[0].next.Sub(now)
+		s.mutex.Unlock()
+
+		if !wait.Stop() {
+			select {
+			case <-wait.C:
+			default:
+			}
+		}
+		wait.Reset(delay)
+		select {
+		case <-wait.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// dispatch passes the timer to an idle worker or starts a new one
+func (s *scheduler) dispatch(t *Timer) {
+	select {
+	case s.jobs <- t:
+	default:
+		go s.work(t)
+	}
+}
+
+func (s *scheduler) work(t *Timer) {
+	idle := time.NewTimer(schedulerIdleTimeout)
+	defer idle.Stop()
+	for {
+		t.f()
+		s.finished(t)
+
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		idle.Reset(schedulerIdleTimeout)
+		select {
+		case t = <-s.jobs:
+		case <-idle.C:
+			return
+		}
+	}
+}
+
+// finished marks the execution of the timer as finished and reports an
+// overrun of the period
+func (s *scheduler) finished(t *Timer) {
+	s.mutex.Lock()
+	t.running = false
+	overrun := time.Since(t.due) - t.period
+	if t.period <= 0 || overrun <= 0 {
+		s.mutex.Unlock()
+		return
+	}
+	t.overruns++
+	onOverrun := t.onOverrun
+	s.mutex.Unlock()
+
+	if onOverrun != nil {
+		onOverrun(overrun)
+	} else {
+		log.Printf("Periodic execution took %v longer than the period of %v", overrun, t.period)
+	}
+}
+
+// timerHeap implements heap.Interface ordered by the next execution time
+type timerHeap []*Timer
+
+func (h timerHeap) Len() int           { return len(h) }
+func (h timerHeap) Less(i, j int) bool { return h[i].next.Before(h[j].next) }
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	t := x.(*Timer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
@@ -0,0 +1,148 @@
+package gobot
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestSchedulerAfterStop(t *testing.T) {
+	s := newScheduler()
+	var calls int32
+	timer := s.schedule(20*time.Millisecond, 0, func() {
+		atomic.AddInt32(&calls, 1)
+	})
+	gobottest.Assert(t, timer.Stop(), true)
+	gobottest.Assert(t, timer.Stop(), false)
+
+	time.Sleep(40 * time.Millisecond)
+	gobottest.Assert(t, atomic.LoadInt32(&calls), int32(0))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	gobottest.Assert(t, s.running, false)
+}
+
+func TestSchedulerOrder(t *testing.T) {
+	s := newScheduler()
+	fired := make(chan int, 3)
+	s.schedule(30*time.Millisecond, 0, func() { fired <- 3 })
+	s.schedule(10*time.Millisecond, 0, func() { fired <- 1 })
+	s.schedule(20*time.Millisecond, 0, func() { fired <- 2 })
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case n := <-fired:
+			gobottest.Assert(t, n, i)
+		case <-time.After(time.Second):
+			t.Fatalf("timer %d was not fired", i)
+		}
+	}
+}
+
+func TestSchedulerBlockingFunction(t *testing.T) {
+	s := newScheduler()
+	release := make(chan bool)
+	var blocked, calls int32
+	slow := s.schedule(time.Millisecond, 2*time.Millisecond, func() {
+		atomic.AddInt32(&blocked, 1)
+		<-release
+	})
+	fast := s.schedule(time.Millisecond, 2*time.Millisecond, func() {
+		atomic.AddInt32(&calls, 1)
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	// the blocked function is not executed again, but the other one is
+	gobottest.Assert(t, atomic.LoadInt32(&blocked), int32(1))
+	gobottest.Assert(t, atomic.LoadInt32(&calls) > 2, true)
+
+	gobottest.Assert(t, slow.Stop(), true)
+	gobottest.Assert(t, fast.Stop(), true)
+	close(release)
+}
+
+func TestEveryTimerPanic(t *testing.T) {
+	defer func() {
+		gobottest.Assert(t, recover(), "non-positive interval for gobot.EveryTimer")
+	}()
+	EveryTimer(0, func() {})
+}
+
+func TestEveryTimer(t *testing.T) {
+	var calls int32
+	timer := EveryTimer(2*time.Millisecond, func() {
+		atomic.AddInt32(&calls, 1)
+	})
+	time.Sleep(15 * time.Millisecond)
+	gobottest.Assert(t, timer.Stop(), true)
+	gobottest.Assert(t, atomic.LoadInt32(&calls) > 2, true)
+}
+
+func TestAfterTimerStop(t *testing.T) {
+	var calls int32
+	timer := AfterTimer(10*time.Millisecond, func() {
+		atomic.AddInt32(&calls, 1)
+	})
+	gobottest.Assert(t, timer.Stop(), true)
+	time.Sleep(20 * time.Millisecond)
+	gobottest.Assert(t, atomic.LoadInt32(&calls), int32(0))
+}
+
+func TestSchedulerDriftFree(t *testing.T) {
+	s := newScheduler()
+	period := 10 * time.Millisecond
+	timers := make(chan *Timer, 1)
+	dues := make(chan time.Time, 20)
+	timer := s.schedule(period, period, func() {
+		timer := <-timers
+		s.mutex.Lock()
+		dues <- timer.due
+		s.mutex.Unlock()
+		timers <- timer
+		// the execution time must not delay the next ticks
+		time.Sleep(3 * time.Millisecond)
+	})
+	timers <- timer
+	defer timer.Stop()
+
+	// the executions are due on the ticks of the first one, independent of
+	// the execution time and the latency of the scheduler. A tick might be
+	// skipped on a busy machine, but the following ones stay on the ticks.
+	first := <-dues
+	for i := 0; i < 10; i++ {
+		since := (<-dues).Sub(first)
+		gobottest.Assert(t, since > 0, true)
+		gobottest.Assert(t, since%period, time.Duration(0))
+	}
+}
+
+func TestSchedulerOverrun(t *testing.T) {
+	s := newScheduler()
+	period := 10 * time.Millisecond
+	overruns := make(chan time.Duration, 10)
+	var calls int32
+	timer := s.schedule(period, period, func() {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(15 * time.Millisecond)
+	})
+	timer.OnOverrun(func(overrun time.Duration) {
+		overruns <- overrun
+	})
+
+	select {
+	case overrun := <-overruns:
+		gobottest.Assert(t, overrun >= 5*time.Millisecond, true)
+	case <-time.After(time.Second):
+		t.Fatalf("overrun was not reported")
+	}
+	time.Sleep(50 * time.Millisecond)
+	timer.Stop()
+
+	// every second tick is skipped
+	n := atomic.LoadInt32(&calls)
+	gobottest.Assert(t, n >= 2 && n <= 5, true)
+	gobottest.Assert(t, timer.Overruns() >= 2, true)
+}
@@ -0,0 +1,63 @@
+package sysfs
+
+import (
+	"fmt"
+	"strconv"
+
+	"gobot.io/x/gobot"
+)
+
+// COUNTERPATH is the path of the devices of the Linux counter subsystem
+const COUNTERPATH = "/sys/bus/counter/devices"
+
+// CounterPinner is the interface for sysfs counter interactions
+type CounterPinner = gobot.CounterPinner
+
+// CounterProvider is the interface that an Adaptor should implement to allow
+// clients to obtain access to the hardware counters available on that board.
+type CounterProvider = gobot.CounterProvider
+
+// Counter is a count of a device of the Linux counter subsystem, which counts
+// pulses or encoder steps in hardware, e.g. an eQEP unit or an interrupt
+// counter.
+type Counter struct {
+	Path string
+}
+
+// NewCounter returns a new Counter for the count of the counter device
+func NewCounter(device, count int) *Counter {
+	return &Counter{Path: fmt.Sprintf("%s/counter%d/count%d", COUNTERPATH, device, count)}
+}
+
+// Count reads the count value
+func (c *Counter) Count() (val int, err error) {
+	s, err := readString(c.Path + "/count")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(s)
+}
+
+// SetCount writes the count value
+func (c *Counter) SetCount(val int) error {
+	return writeString(c.Path+"/count", strconv.Itoa(val))
+}
+
+// Enable writes the enable attribute of the count
+func (c *Counter) Enable(enable bool) error {
+	val := "0"
+	if enable {
+		val = "1"
+	}
+	return writeString(c.Path+"/enable", val)
+}
+
+// Function reads the count function
+func (c *Counter) Function() (string, error) {
+	return readString(c.Path + "/function")
+}
+
+// SetFunction writes the count function
+func (c *Counter) SetFunction(function string) error {
+	return writeString(c.Path+"/function", function)
+}
@@ -0,0 +1,45 @@
+package sysfs
+
+import (
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+var _ CounterPinner = (*Counter)(nil)
+
+func TestCounter(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/sys/bus/counter/devices/counter1/count0/count",
+		"/sys/bus/counter/devices/counter1/count0/enable",
+		"/sys/bus/counter/devices/counter1/count0/function",
+	})
+	fs.Files["/sys/bus/counter/devices/counter1/count0/count"].Contents = "1234\n"
+	fs.Files["/sys/bus/counter/devices/counter1/count0/function"].Contents = "quadrature x4\n"
+	SetFilesystem(fs)
+
+	c := NewCounter(1, 0)
+	gobottest.Assert(t, c.Path, "/sys/bus/counter/devices/counter1/count0")
+
+	val, err := c.Count()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, val, 1234)
+
+	gobottest.Assert(t, c.SetCount(0), nil)
+	gobottest.Assert(t, fs.Files["/sys/bus/counter/devices/counter1/count0/count"].Contents, "0")
+
+	gobottest.Assert(t, c.Enable(true), nil)
+	gobottest.Assert(t, fs.Files["/sys/bus/counter/devices/counter1/count0/enable"].Contents, "1")
+	gobottest.Assert(t, c.Enable(false), nil)
+	gobottest.Assert(t, fs.Files["/sys/bus/counter/devices/counter1/count0/enable"].Contents, "0")
+
+	function, err := c.Function()
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, function, "quadrature x4")
+	gobottest.Assert(t, c.SetFunction("increase"), nil)
+	gobottest.Assert(t, fs.Files["/sys/bus/counter/devices/counter1/count0/function"].Contents, "increase")
+
+	_, err = NewCounter(2, 0).Count()
+	gobottest.Refute(t, err, nil)
+	gobottest.Refute(t, NewCounter(2, 0).Enable(true), nil)
+}
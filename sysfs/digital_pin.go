@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"syscall"
 	"time"
+
+	"gobot.io/x/gobot"
 )
 
 const (
@@ -25,24 +27,11 @@ const (
 var errNotExported = errors.New("pin has not been exported")
 
 // DigitalPinner is the interface for sysfs gpio interactions
-type DigitalPinner interface {
-	// Export exports the pin for use by the operating system
-	Export() error
-	// Unexport unexports the pin and releases the pin from the operating system
-	Unexport() error
-	// Direction sets the direction for the pin
-	Direction(string) error
-	// Read reads the current value of the pin
-	Read() (int, error)
-	// Write writes to the pin
-	Write(int) error
-}
+type DigitalPinner = gobot.DigitalPinner
 
 // DigitalPinnerProvider is the interface that an Adaptor should implement to allow
 // clients to obtain access to any DigitalPin's available on that board.
-type DigitalPinnerProvider interface {
-	DigitalPin(string, string) (DigitalPinner, error)
-}
+type DigitalPinnerProvider = gobot.DigitalPinnerProvider
 
 type DigitalPin struct {
 	pin   string
@@ -50,8 +39,15 @@ type DigitalPin struct {
 
 	value     File
 	direction File
+	// the last direction written, to skip needless writes
+	dir string
 }
 
+var (
+	lowValue  = []byte("0")
+	highValue = []byte("1")
+)
+
 // NewDigitalPin returns a DigitalPin given the pin number and an optional sysfs pin label.
 // If no label is supplied the default label will prepend "gpio" to the pin number,
 // eg. a pin number of 10 will have a label of "gpio10"
@@ -66,13 +62,34 @@ func NewDigitalPin(pin int, v ...string) *DigitalPin {
 	return d
 }
 
+// Direction sets the direction for the pin. Writing the direction file is
+// skipped, when the pin already has this direction, because writing "out"
+// drives the pin low.
 func (d *DigitalPin) Direction(dir string) error {
+	if d.direction != nil && dir == d.dir {
+		return nil
+	}
 	_, err := writeFile(d.direction, []byte(dir))
-	return err
+	if err != nil {
+		d.dir = ""
+		return err
+	}
+	d.dir = dir
+	return nil
 }
 
+// Write writes to the pin by the value file, which is kept open while the
+// pin is exported.
 func (d *DigitalPin) Write(b int) error {
-	_, err := writeFile(d.value, []byte(strconv.Itoa(b)))
+	var err error
+	switch b {
+	case LOW:
+		_, err = writeFile(d.value, lowValue)
+	case HIGH:
+		_, err = writeFile(d.value, highValue)
+	default:
+		_, err = writeFile(d.value, []byte(strconv.Itoa(b)))
+	}
 	return err
 }
 
@@ -103,6 +120,7 @@ func (d *DigitalPin) Export() error {
 	if d.direction != nil {
 		d.direction.Close()
 	}
+	d.dir = ""
 
 	attempt := 0
 	for {
@@ -144,6 +162,7 @@ func (d *DigitalPin) Unexport() error {
 		d.direction.Close()
 		d.direction = nil
 	}
+	d.dir = ""
 	if d.value != nil {
 		d.value.Close()
 		d.value = nil
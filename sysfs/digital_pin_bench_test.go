@@ -19,3 +19,21 @@ func BenchmarkDigitalRead(b *testing.B) {
 	}
 
 }
+
+func BenchmarkDigitalWrite(b *testing.B) {
+	fs := NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio10/value",
+		"/sys/class/gpio/gpio10/direction",
+	})
+
+	SetFilesystem(fs)
+	pin := NewDigitalPin(10)
+	pin.Export()
+
+	for i := 0; i < b.N; i++ {
+		pin.Direction(OUT)
+		pin.Write(i & 1)
+	}
+}
@@ -9,7 +9,15 @@ import (
 	"gobot.io/x/gobot/gobottest"
 )
 
+// restoreWriteFile returns a function, which restores the current writeFile
+func restoreWriteFile() func() {
+	f := writeFile
+	return func() { writeFile = f }
+}
+
 func TestDigitalPin(t *testing.T) {
+	defer restoreWriteFile()()
+
 	fs := NewMockFilesystem([]string{
 		"/sys/class/gpio/export",
 		"/sys/class/gpio/unexport",
@@ -86,6 +94,8 @@ func TestDigitalPin(t *testing.T) {
 }
 
 func TestDigitalPinExportError(t *testing.T) {
+	defer restoreWriteFile()()
+
 	fs := NewMockFilesystem([]string{
 		"/sys/class/gpio/export",
 		"/sys/class/gpio/unexport",
@@ -105,6 +115,8 @@ func TestDigitalPinExportError(t *testing.T) {
 }
 
 func TestDigitalPinUnexportError(t *testing.T) {
+	defer restoreWriteFile()()
+
 	fs := NewMockFilesystem([]string{
 		"/sys/class/gpio/export",
 		"/sys/class/gpio/unexport",
@@ -122,3 +134,53 @@ func TestDigitalPinUnexportError(t *testing.T) {
 	err := pin.Unexport()
 	gobottest.Refute(t, err, nil)
 }
+
+func TestDigitalPinDirectionAndWrite(t *testing.T) {
+	defer restoreWriteFile()()
+
+	fs := NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio10/value",
+		"/sys/class/gpio/gpio10/direction",
+	})
+
+	SetFilesystem(fs)
+
+	writes := 0
+	writeFile = func(f File, data []byte) (int, error) {
+		writes++
+		return f.Write(data)
+	}
+
+	pin := NewDigitalPin(10)
+	gobottest.Assert(t, pin.Export(), nil)
+	writes = 0
+
+	// the direction is only written when it changes
+	gobottest.Assert(t, pin.Direction(OUT), nil)
+	gobottest.Assert(t, pin.Direction(OUT), nil)
+	gobottest.Assert(t, writes, 1)
+	gobottest.Assert(t, pin.Direction(IN), nil)
+	gobottest.Assert(t, writes, 2)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio10/direction"].Contents, "in")
+
+	// a new export writes the direction again
+	gobottest.Assert(t, pin.Export(), nil)
+	writes = 0
+	gobottest.Assert(t, pin.Direction(IN), nil)
+	gobottest.Assert(t, writes, 1)
+
+	gobottest.Assert(t, pin.Write(HIGH), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio10/value"].Contents, "1")
+	gobottest.Assert(t, pin.Write(LOW), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio10/value"].Contents, "0")
+	gobottest.Assert(t, pin.Write(2), nil)
+	gobottest.Assert(t, fs.Files["/sys/class/gpio/gpio10/value"].Contents, "2")
+
+	writeFile = func(File, []byte) (int, error) {
+		return 0, errors.New("write error")
+	}
+	gobottest.Assert(t, pin.Direction(OUT), errors.New("write error"))
+	gobottest.Assert(t, pin.dir, "")
+}
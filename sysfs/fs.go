@@ -2,6 +2,8 @@ package sysfs
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // A File represents basic IO interactions with the underlying file system
@@ -22,6 +24,12 @@ type Filesystem interface {
 	Stat(name string) (os.FileInfo, error)
 }
 
+// Globber is the optional interface of a Filesystem, which lists the files
+// matching a pattern. Without it, the pattern is matched by filepath.Glob.
+type Globber interface {
+	Glob(pattern string) (matches []string, err error)
+}
+
 // NativeFilesystem represents the native file system implementation
 type NativeFilesystem struct{}
 
@@ -43,6 +51,11 @@ func (fs *NativeFilesystem) Stat(name string) (os.FileInfo, error) {
 	return os.Stat(name)
 }
 
+// Glob calls filepath.Glob()
+func (fs *NativeFilesystem) Glob(pattern string) (matches []string, err error) {
+	return filepath.Glob(pattern)
+}
+
 // OpenFile calls either the NativeFilesystem or user defined OpenFile
 func OpenFile(name string, flag int, perm os.FileMode) (file File, err error) {
 	return fs.OpenFile(name, flag, perm)
@@ -52,3 +65,41 @@ func OpenFile(name string, flag int, perm os.FileMode) (file File, err error) {
 func Stat(name string) (os.FileInfo, error) {
 	return fs.Stat(name)
 }
+
+// Glob calls either the NativeFilesystem or user defined Glob, or
+// filepath.Glob if the user defined Filesystem is no Globber
+func Glob(pattern string) (matches []string, err error) {
+	if g, ok := fs.(Globber); ok {
+		return g.Glob(pattern)
+	}
+	return filepath.Glob(pattern)
+}
+
+// readString reads the single value of a sysfs file without the trailing
+// newline
+func readString(path string) (string, error) {
+	file, err := OpenFile(path, os.O_RDONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, 64)
+	n, err := file.Read(buf)
+	if n == 0 && err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(buf[:n])), nil
+}
+
+// writeString writes the single value to a sysfs file
+func writeString(path string, val string) error {
+	file, err := OpenFile(path, os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write([]byte(val))
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"path"
+	"sort"
 	"strings"
 	"time"
 )
@@ -28,7 +30,14 @@ type MockFile struct {
 	Closed   bool
 	fd       uintptr
 
-	fs *MockFilesystem
+	fs     *MockFilesystem
+	events []mockEvent
+}
+
+// mockEvent changes the contents of a MockFile at a point in time
+type mockEvent struct {
+	at       time.Time
+	contents string
 }
 
 var (
@@ -61,12 +70,33 @@ func (f *MockFile) Sync() (err error) {
 	return nil
 }
 
+// Schedule changes f.Contents to contents, when the given time has passed.
+// The change is applied by the first read after that time, so e.g. the
+// rising and falling edges of a digital pin can be simulated by scheduling
+// "1" and "0" for its value file.
+func (f *MockFile) Schedule(after time.Duration, contents string) {
+	event := mockEvent{at: time.Now().Add(after), contents: contents}
+	i := len(f.events)
+	for i > 0 && f.events[i-1].at.After(event.at) {
+		i--
+	}
+	f.events = append(f.events, mockEvent{})
+	copy(f.events[i+1:], f.events[i:])
+	f.events[i] = event
+}
+
 // Read copies b bytes from f.Contents
 func (f *MockFile) Read(b []byte) (n int, err error) {
 	if f.fs.WithReadError {
 		return 0, readErr
 	}
 
+	now := time.Now()
+	for len(f.events) > 0 && !f.events[0].at.After(now) {
+		f.Contents = f.events[0].contents
+		f.events = f.events[1:]
+	}
+
 	count := len(b)
 	if len(f.Contents) < count {
 		count = len(f.Contents)
@@ -148,6 +178,33 @@ func (fs *MockFilesystem) Stat(name string) (os.FileInfo, error) {
 	return nil, &os.PathError{Err: errors.New(name + ": No such file.")}
 }
 
+// Glob returns the names of the files in fs.Files matching the pattern, in
+// lexical order like filepath.Glob. Directories match by the files in them.
+func (fs *MockFilesystem) Glob(pattern string) (matches []string, err error) {
+	found := make(map[string]bool)
+	depth := strings.Count(pattern, "/")
+	for name := range fs.Files {
+		// match the directories of the file as well
+		parts := strings.Split(name, "/")
+		if len(parts) <= depth {
+			continue
+		}
+		candidate := strings.Join(parts[:depth+1], "/")
+		matched, err := path.Match(pattern, candidate)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			found[candidate] = true
+		}
+	}
+	for name := range found {
+		matches = append(matches, name)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
 // Add adds a new file to fs.Files given a name, and returns the newly created file
 func (fs *MockFilesystem) Add(name string) *MockFile {
 	f := &MockFile{
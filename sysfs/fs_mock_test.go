@@ -2,6 +2,7 @@ package sysfs
 
 import (
 	"testing"
+	"time"
 
 	"gobot.io/x/gobot/gobottest"
 )
@@ -77,3 +78,64 @@ func TestMockFilesystemRead(t *testing.T) {
 	n, _ = f2.ReadAt(buffer, 10)
 	gobottest.Assert(t, n, 3)
 }
+
+func TestMockFileSchedule(t *testing.T) {
+	fs := NewMockFilesystem([]string{"/sys/class/gpio/gpio10/value"})
+	f := fs.Files["/sys/class/gpio/gpio10/value"]
+	f.Contents = "0"
+	f.Schedule(20*time.Millisecond, "0")
+	f.Schedule(10*time.Millisecond, "1")
+
+	buffer := make([]byte, 1)
+	f.Read(buffer)
+	gobottest.Assert(t, string(buffer), "0")
+
+	time.Sleep(12 * time.Millisecond)
+	f.Read(buffer)
+	gobottest.Assert(t, string(buffer), "1")
+
+	time.Sleep(10 * time.Millisecond)
+	f.Read(buffer)
+	gobottest.Assert(t, string(buffer), "0")
+}
+
+func TestMockFileScheduleDigitalPin(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/gpio10/value",
+		"/sys/class/gpio/gpio10/direction",
+	})
+	SetFilesystem(fs)
+	pin := NewDigitalPin(10)
+	gobottest.Assert(t, pin.Export(), nil)
+	gobottest.Assert(t, pin.Direction(IN), nil)
+
+	fs.Files["/sys/class/gpio/gpio10/value"].Contents = "0"
+	fs.Files["/sys/class/gpio/gpio10/value"].Schedule(5*time.Millisecond, "1")
+
+	var values []int
+	for i := 0; i < 20; i++ {
+		val, err := pin.Read()
+		gobottest.Assert(t, err, nil)
+		if len(values) == 0 || values[len(values)-1] != val {
+			values = append(values, val)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	gobottest.Assert(t, values, []int{0, 1})
+}
+
+func TestMockFilesystemGlob(t *testing.T) {
+	var _ Globber = (*MockFilesystem)(nil)
+	fs := NewMockFilesystem([]string{"a/b1/c", "a/b2/c", "a/x", "d"})
+
+	matches, err := fs.Glob("a/b*")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, matches, []string{"a/b1", "a/b2"})
+
+	matches, _ = fs.Glob("a/*/c")
+	gobottest.Assert(t, matches, []string{"a/b1/c", "a/b2/c"})
+
+	_, err = fs.Glob("[")
+	gobottest.Refute(t, err, nil)
+}
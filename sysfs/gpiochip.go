@@ -0,0 +1,33 @@
+package sysfs
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// GpiochipBase returns the number of the first GPIO of a gpiochip, so a line
+// of the chip is the GPIO base plus its offset. The chip is given by its name
+// in the sysfs, e.g. "gpiochip512", or by its label, e.g. "pinctrl-rp1" of
+// the main header of the Raspberry Pi 5.
+func GpiochipBase(chip string) (int, error) {
+	if base, err := readString(GPIOPATH + "/" + chip + "/base"); err == nil {
+		return strconv.Atoi(base)
+	}
+
+	dirs, err := Glob(GPIOPATH + "/gpiochip*")
+	if err != nil {
+		return 0, err
+	}
+	for _, dir := range dirs {
+		label, err := readString(dir + "/label")
+		if err != nil || label != chip {
+			continue
+		}
+		base, err := readString(dir + "/base")
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(base)
+	}
+	return 0, fmt.Errorf("No gpiochip '%s' found", chip)
+}
@@ -0,0 +1,54 @@
+package sysfs
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"gobot.io/x/gobot/gobottest"
+)
+
+func TestGpiochipBase(t *testing.T) {
+	fs := NewMockFilesystem([]string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/gpiochip0/base",
+		"/sys/class/gpio/gpiochip0/label",
+		"/sys/class/gpio/gpiochip571/base",
+		"/sys/class/gpio/gpiochip571/label",
+	})
+	fs.Files["/sys/class/gpio/gpiochip0/base"].Contents = "0\n"
+	fs.Files["/sys/class/gpio/gpiochip0/label"].Contents = "gpio-brcmstb@107d508500\n"
+	fs.Files["/sys/class/gpio/gpiochip571/base"].Contents = "571\n"
+	fs.Files["/sys/class/gpio/gpiochip571/label"].Contents = "pinctrl-rp1\n"
+	SetFilesystem(fs)
+
+	base, err := GpiochipBase("pinctrl-rp1")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, base, 571)
+
+	base, err = GpiochipBase("gpiochip0")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, base, 0)
+
+	_, err = GpiochipBase("gpiochip1")
+	gobottest.Assert(t, err, errors.New("No gpiochip 'gpiochip1' found"))
+
+	// a chip is found by its name without Glob
+	SetFilesystem(&noGlobFilesystem{fs})
+	base, err = GpiochipBase("gpiochip571")
+	gobottest.Assert(t, err, nil)
+	gobottest.Assert(t, base, 571)
+}
+
+// noGlobFilesystem is a Filesystem, which is no Globber
+type noGlobFilesystem struct {
+	fs *MockFilesystem
+}
+
+func (n *noGlobFilesystem) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return n.fs.OpenFile(name, flag, perm)
+}
+
+func (n *noGlobFilesystem) Stat(name string) (os.FileInfo, error) {
+	return n.fs.Stat(name)
+}
@@ -3,6 +3,7 @@ package sysfs
 import (
 	"fmt"
 	"os"
+	"sync"
 	"syscall"
 	"unsafe"
 )
@@ -48,6 +49,9 @@ type i2cSmbusIoctlData struct {
 type i2cDevice struct {
 	file  File
 	funcs uint64 // adapter functionality mask
+	// buffer for block writes with the register, reused by each write
+	blockBuf   [33]byte
+	blockMutex sync.Mutex
 }
 
 // NewI2cDevice returns an io.ReadWriteCloser with the proper ioctrl given
@@ -162,7 +166,10 @@ func (d *i2cDevice) WriteBlockData(reg uint8, data []byte) (err error) {
 		return fmt.Errorf("Writing blocks larger than 32 bytes (%v) not supported", len(data))
 	}
 
-	buf := make([]byte, len(data)+1)
+	d.blockMutex.Lock()
+	defer d.blockMutex.Unlock()
+
+	buf := d.blockBuf[:len(data)+1]
 	copy(buf[1:], data)
 	buf[0] = reg
 
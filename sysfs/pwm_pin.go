@@ -7,35 +7,16 @@ import (
 	"strconv"
 	"syscall"
 	"time"
+
+	"gobot.io/x/gobot"
 )
 
-// PWMPin is the interface for sysfs PWM interactions
-type PWMPinner interface {
-	// Export exports the pin for use by the operating system
-	Export() error
-	// Unexport unexports the pin and releases the pin from the operating system
-	Unexport() error
-	// Enable enables/disables the PWM pin
-	Enable(bool) (err error)
-	// Polarity returns the polarity either normal or inverted
-	Polarity() (polarity string, err error)
-	// InvertPolarity sets the polarity to inverted if called with true
-	InvertPolarity(invert bool) (err error)
-	// Period returns the current PWM period for pin
-	Period() (period uint32, err error)
-	// SetPeriod sets the current PWM period for pin
-	SetPeriod(period uint32) (err error)
-	// DutyCycle returns the duty cycle for the pin
-	DutyCycle() (duty uint32, err error)
-	// SetDutyCycle writes the duty cycle to the pin
-	SetDutyCycle(duty uint32) (err error)
-}
+// PWMPinner is the interface for sysfs PWM interactions
+type PWMPinner = gobot.PWMPinner
 
 // PWMPinnerProvider is the interface that an Adaptor should implement to allow
 // clients to obtain access to any PWMPin's available on that board.
-type PWMPinnerProvider interface {
-	PWMPin(string) (PWMPinner, error)
-}
+type PWMPinnerProvider = gobot.PWMPinnerProvider
 
 type PWMPin struct {
 	pin     string
@@ -10,8 +10,11 @@ import (
 
 // Every triggers f every t time.Duration until the end of days, or when a Stop()
 // is called on the Ticker that is returned by the Every function.
-// It does not wait for the previous execution of f to finish before
-// it fires the next f.
+// Like by EveryTimer, the executions don't drift with the execution time of f,
+// because the ticks of the Ticker are absolute. When f takes longer than t,
+// ticks are dropped.
+//
+// Deprecated: Use EveryTimer, which runs f by the shared scheduler.
 func Every(t time.Duration, f func()) *time.Ticker {
 	ticker := time.NewTicker(t)
 
@@ -27,9 +30,31 @@ func Every(t time.Duration, f func()) *time.Ticker {
 	return ticker
 }
 
-// After triggers f after t duration.
+// After triggers f after t duration by the shared scheduler.
+//
+// Deprecated: Use AfterTimer, which allows to cancel the execution.
 func After(t time.Duration, f func()) {
-	time.AfterFunc(t, f)
+	defaultScheduler.schedule(t, 0, f)
+}
+
+// EveryTimer triggers f every t time.Duration until Stop() is called on the
+// Timer that is returned by the EveryTimer function.
+// All timers share one scheduler, f is executed by a pool of workers. The
+// executions are scheduled on absolute ticks, so they don't drift with the
+// execution time of f. A tick is skipped, when the previous execution has not
+// finished yet, this overrun is logged or passed to Timer.OnOverrun.
+func EveryTimer(t time.Duration, f func()) *Timer {
+	if t <= 0 {
+		panic("non-positive interval for gobot.EveryTimer")
+	}
+	return defaultScheduler.schedule(t, t, f)
+}
+
+// AfterTimer triggers f after t duration by the shared scheduler, unless
+// Stop() is called on the Timer that is returned by the AfterTimer function
+// before.
+func AfterTimer(t time.Duration, f func()) *Timer {
+	return defaultScheduler.schedule(t, 0, f)
 }
 
 // Rand returns a positive random int up to max